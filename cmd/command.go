@@ -20,14 +20,21 @@
 package answercmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/apache/answer/internal/base/conf"
+	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/cli"
 	"github.com/apache/answer/internal/install"
 	"github.com/apache/answer/internal/migrations"
+	searchindexrepo "github.com/apache/answer/internal/repo/search_index"
+	"github.com/apache/answer/internal/repo/search_sync"
+	"github.com/apache/answer/internal/service/search_index"
 	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/log"
 	"github.com/spf13/cobra"
@@ -53,6 +60,10 @@ var (
 	i18nSourcePath string
 	// i18nTargetPath i18n to path
 	i18nTargetPath string
+	// backupOutputPath the directory the backup archive is written to
+	backupOutputPath string
+	// restoreArchivePath the backup archive to restore
+	restoreArchivePath string
 )
 
 func init() {
@@ -76,7 +87,11 @@ func init() {
 
 	i18nCmd.Flags().StringVarP(&i18nTargetPath, "target", "t", "", "i18n target path, eg: -t ./i18n/target")
 
-	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd} {
+	backupCmd.Flags().StringVarP(&backupOutputPath, "path", "p", "./", "backup archive path, eg: -p ./backup/")
+
+	restoreCmd.Flags().StringVarP(&restoreArchivePath, "file", "f", "", "backup archive file, eg: -f ./answer_backup.tar.gz")
+
+	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, backupCmd, restoreCmd, reindexCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd} {
 		rootCmd.AddCommand(cmd)
 	}
 }
@@ -174,6 +189,97 @@ To run answer, use:
 		},
 	}
 
+	backupCmd = &cobra.Command{
+		Use:   "backup",
+		Short: "Back up data into a portable archive",
+		Long:  `Back up database, uploaded files and config into an archive which can be restored into any supported database`,
+		Run: func(_ *cobra.Command, _ []string) {
+			fmt.Println("Answer is backing up data")
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			archivePath := filepath.Join(backupOutputPath,
+				fmt.Sprintf("answer_backup_%s.tar.gz", time.Now().Format("2006-01-02-150405")))
+			manifest, err := migrations.Backup(c.Data.Database, &migrations.BackupOptions{
+				AnswerVersion:  Version,
+				UploadPath:     c.ServiceConfig.UploadPath,
+				ConfigFilePath: cli.GetConfigFilePath(),
+			}, archivePath)
+			if err != nil {
+				fmt.Println("backup failed: ", err.Error())
+				os.Exit(1)
+			}
+			fmt.Printf("Answer backed up %d tables and %d uploaded files into %s\n",
+				len(manifest.Tables), manifest.UploadFiles, archivePath)
+		},
+	}
+
+	restoreCmd = &cobra.Command{
+		Use:   "restore",
+		Short: "Restore data from a backup archive",
+		Long: `Restore the backup archive into the database in the config file, which must be empty.
+If there is no config file, the config in the archive is restored and used.`,
+		Run: func(_ *cobra.Command, _ []string) {
+			if len(restoreArchivePath) == 0 {
+				fmt.Println("backup archive file is required, eg: -f ./answer_backup.tar.gz")
+				os.Exit(1)
+			}
+			cli.FormatAllPath(dataDirPath)
+			opts := &migrations.RestoreOptions{}
+			if !cli.CheckConfigFile(cli.GetConfigFilePath()) {
+				fmt.Println("config file not exists, try to restore the config in the backup...")
+				if err := migrations.RestoreConfig(restoreArchivePath, cli.GetConfigFilePath()); err != nil {
+					fmt.Println("restore config failed: ", err.Error())
+					os.Exit(1)
+				}
+			}
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				os.Exit(1)
+			}
+			opts.UploadPath = c.ServiceConfig.UploadPath
+			manifest, err := migrations.Restore(c.Data.Database, opts, restoreArchivePath)
+			if err != nil {
+				fmt.Println("restore failed: ", err.Error())
+				os.Exit(1)
+			}
+			fmt.Printf("Answer restored %d tables and %d uploaded files from the backup of %s made at %s\n",
+				len(manifest.Tables), manifest.UploadFiles, manifest.Driver, manifest.CreatedAt.Format(time.RFC3339))
+		},
+	}
+
+	reindexCmd = &cobra.Command{
+		Use:   "reindex",
+		Short: "Rebuild the built-in search index",
+		Long:  `Rebuild the built-in search index from all the questions and answers`,
+		Run: func(_ *cobra.Command, _ []string) {
+			log.SetLogger(log.NewStdLogger(os.Stdout))
+			cli.FormatAllPath(dataDirPath)
+			c, err := conf.ReadConfig(cli.GetConfigFilePath())
+			if err != nil {
+				fmt.Println("read config failed: ", err.Error())
+				return
+			}
+			db, err := data.NewDB(false, c.Data.Database)
+			if err != nil {
+				fmt.Println("connect database failed: ", err.Error())
+				os.Exit(1)
+			}
+			defer db.Close()
+			d := &data.Data{DB: db}
+			searchIndexService := search_index.NewSearchIndexService(searchindexrepo.NewSearchIndexRepo(d))
+			if err = searchIndexService.Rebuild(context.Background(), search_sync.NewPluginSyncer(d)); err != nil {
+				fmt.Println("rebuild search index failed: ", err.Error())
+				os.Exit(1)
+			}
+			fmt.Println("rebuild search index done")
+		},
+	}
+
 	checkCmd = &cobra.Command{
 		Use:   "check",
 		Short: "Check the required environment",
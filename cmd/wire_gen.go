@@ -27,14 +27,21 @@ import (
 	"github.com/apache/answer/internal/repo/collection"
 	"github.com/apache/answer/internal/repo/comment"
 	"github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/digest"
+	"github.com/apache/answer/internal/repo/draft"
 	"github.com/apache/answer/internal/repo/export"
 	"github.com/apache/answer/internal/repo/file_record"
 	"github.com/apache/answer/internal/repo/hierarchical_tag"
 	"github.com/apache/answer/internal/repo/limit"
 	"github.com/apache/answer/internal/repo/meta"
+	"github.com/apache/answer/internal/repo/moderation"
 	notification2 "github.com/apache/answer/internal/repo/notification"
+	"github.com/apache/answer/internal/repo/oauth_provider"
+	"github.com/apache/answer/internal/repo/personal_access_token"
 	"github.com/apache/answer/internal/repo/plugin_config"
 	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/question_schedule"
+	"github.com/apache/answer/internal/repo/queue_message"
 	"github.com/apache/answer/internal/repo/rank"
 	"github.com/apache/answer/internal/repo/reason"
 	"github.com/apache/answer/internal/repo/report"
@@ -42,6 +49,8 @@ import (
 	"github.com/apache/answer/internal/repo/revision"
 	"github.com/apache/answer/internal/repo/role"
 	"github.com/apache/answer/internal/repo/search_common"
+	"github.com/apache/answer/internal/repo/search_index"
+	"github.com/apache/answer/internal/repo/search_sync"
 	"github.com/apache/answer/internal/repo/site_info"
 	"github.com/apache/answer/internal/repo/tag"
 	"github.com/apache/answer/internal/repo/tag_common"
@@ -49,8 +58,8 @@ import (
 	"github.com/apache/answer/internal/repo/user"
 	"github.com/apache/answer/internal/repo/user_external_login"
 	"github.com/apache/answer/internal/repo/user_notification_config"
+	"github.com/apache/answer/internal/repo/webhook"
 	"github.com/apache/answer/internal/router"
-	"github.com/apache/answer/internal/service"
 	"github.com/apache/answer/internal/service/action"
 	activity2 "github.com/apache/answer/internal/service/activity"
 	activity_common2 "github.com/apache/answer/internal/service/activity_common"
@@ -65,19 +74,29 @@ import (
 	config2 "github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/content"
 	"github.com/apache/answer/internal/service/dashboard"
+	digest2 "github.com/apache/answer/internal/service/digest"
+	draft2 "github.com/apache/answer/internal/service/draft"
+	"github.com/apache/answer/internal/service/email_reply"
 	"github.com/apache/answer/internal/service/event_queue"
 	export2 "github.com/apache/answer/internal/service/export"
 	file_record2 "github.com/apache/answer/internal/service/file_record"
 	"github.com/apache/answer/internal/service/follow"
+	hierarchical_tag2 "github.com/apache/answer/internal/service/hierarchical_tag"
 	"github.com/apache/answer/internal/service/importer"
 	meta2 "github.com/apache/answer/internal/service/meta"
 	"github.com/apache/answer/internal/service/meta_common"
+	moderation2 "github.com/apache/answer/internal/service/moderation"
+	"github.com/apache/answer/internal/service/moderation_common"
 	"github.com/apache/answer/internal/service/notice_queue"
 	"github.com/apache/answer/internal/service/notification"
 	"github.com/apache/answer/internal/service/notification_common"
+	oauth_provider2 "github.com/apache/answer/internal/service/oauth_provider"
 	"github.com/apache/answer/internal/service/object_info"
+	personal_access_token2 "github.com/apache/answer/internal/service/personal_access_token"
 	"github.com/apache/answer/internal/service/plugin_common"
 	"github.com/apache/answer/internal/service/question_common"
+	question_schedule2 "github.com/apache/answer/internal/service/question_schedule"
+	queue_message2 "github.com/apache/answer/internal/service/queue_message"
 	rank2 "github.com/apache/answer/internal/service/rank"
 	reason2 "github.com/apache/answer/internal/service/reason"
 	report2 "github.com/apache/answer/internal/service/report"
@@ -85,7 +104,9 @@ import (
 	review2 "github.com/apache/answer/internal/service/review"
 	"github.com/apache/answer/internal/service/revision_common"
 	role2 "github.com/apache/answer/internal/service/role"
+	search_index2 "github.com/apache/answer/internal/service/search_index"
 	"github.com/apache/answer/internal/service/search_parser"
+	search_sync2 "github.com/apache/answer/internal/service/search_sync"
 	"github.com/apache/answer/internal/service/service_config"
 	"github.com/apache/answer/internal/service/siteinfo"
 	"github.com/apache/answer/internal/service/siteinfo_common"
@@ -96,6 +117,7 @@ import (
 	"github.com/apache/answer/internal/service/user_common"
 	user_external_login2 "github.com/apache/answer/internal/service/user_external_login"
 	user_notification_config2 "github.com/apache/answer/internal/service/user_notification_config"
+	webhook2 "github.com/apache/answer/internal/service/webhook"
 	"github.com/segmentfault/pacman"
 	"github.com/segmentfault/pacman/log"
 )
@@ -138,23 +160,29 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	emailService := export2.NewEmailService(configService, emailRepo, siteInfoCommonService)
 	userRoleRelRepo := role.NewUserRoleRelRepo(dataData)
 	roleRepo := role.NewRoleRepo(dataData)
-	roleService := role2.NewRoleService(roleRepo)
+	powerRepo := role.NewPowerRepo(dataData)
+	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
+	roleService := role2.NewRoleService(roleRepo, powerRepo, rolePowerRelRepo)
 	userRoleRelService := role2.NewUserRoleRelService(userRoleRelRepo, roleService)
 	userCommon := usercommon.NewUserCommon(userRepo, userRoleRelService, authService, siteInfoCommonService)
 	userExternalLoginRepo := user_external_login.NewUserExternalLoginRepo(dataData)
 	userNotificationConfigRepo := user_notification_config.NewUserNotificationConfigRepo(dataData)
-	userNotificationConfigService := user_notification_config2.NewUserNotificationConfigService(userRepo, userNotificationConfigRepo)
+	digestRepo := digest.NewDigestRepo(dataData)
+	followRepo := activity_common.NewFollowRepo(dataData, uniqueIDRepo, activityRepo)
+	digestService := digest2.NewDigestService(digestRepo, followRepo, userRepo, emailService, siteInfoCommonService)
+	userNotificationConfigService := user_notification_config2.NewUserNotificationConfigService(userRepo, userNotificationConfigRepo, digestService)
 	userExternalLoginService := user_external_login2.NewUserExternalLoginService(userRepo, userCommon, userExternalLoginRepo, emailService, siteInfoCommonService, userActiveActivityRepo, userNotificationConfigService)
-	questionRepo := question.NewQuestionRepo(dataData, uniqueIDRepo)
-	answerRepo := answer.NewAnswerRepo(dataData, uniqueIDRepo, userRankRepo, activityRepo)
+	searchChangeRepo := search_sync.NewSearchChangeRepo(dataData)
+	questionRepo := question.NewQuestionRepo(dataData, uniqueIDRepo, searchChangeRepo)
+	answerRepo := answer.NewAnswerRepo(dataData, uniqueIDRepo, userRankRepo, activityRepo, searchChangeRepo)
 	voteRepo := activity_common.NewVoteRepo(dataData, activityRepo)
-	followRepo := activity_common.NewFollowRepo(dataData, uniqueIDRepo, activityRepo)
 	tagCommonRepo := tag_common.NewTagCommonRepo(dataData, uniqueIDRepo)
 	tagRelRepo := tag.NewTagRelRepo(dataData, uniqueIDRepo)
 	tagRepo := tag.NewTagRepo(dataData, uniqueIDRepo)
 	revisionRepo := revision.NewRevisionRepo(dataData, uniqueIDRepo)
 	revisionService := revision_common.NewRevisionService(revisionRepo, userRepo)
-	activityQueueService := activity_queue.NewActivityQueueService()
+	queueMessageRepo := queue_message.NewQueueMessageRepo(dataData)
+	activityQueueService := activity_queue.NewActivityQueueService(queueMessageRepo)
 	tagCommonService := tag_common2.NewTagCommonService(tagCommonRepo, tagRelRepo, tagRepo, revisionService, siteInfoCommonService, activityQueueService)
 	collectionRepo := collection.NewCollectionRepo(dataData, uniqueIDRepo)
 	collectionCommon := collectioncommon.NewCollectionCommon(collectionRepo)
@@ -162,43 +190,50 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	metaRepo := meta.NewMetaRepo(dataData)
 	metaCommonService := metacommon.NewMetaCommonService(metaRepo)
 	questionCommon := questioncommon.NewQuestionCommon(questionRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, siteInfoCommonService, dataData)
-	eventQueueService := event_queue.NewEventQueueService()
+	eventQueueService := event_queue.NewEventQueueService(queueMessageRepo)
 	fileRecordRepo := file_record.NewFileRecordRepo(dataData)
 	fileRecordService := file_record2.NewFileRecordService(fileRecordRepo, revisionRepo, serviceConf, siteInfoCommonService, userCommon)
 	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, eventQueueService, fileRecordService)
 	captchaRepo := captcha.NewCaptchaRepo(dataData)
 	captchaService := action.NewCaptchaService(captchaRepo)
 	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService)
-	commentRepo := comment.NewCommentRepo(dataData, uniqueIDRepo)
-	commentCommonRepo := comment.NewCommentCommonRepo(dataData, uniqueIDRepo)
+	commentRepo := comment.NewCommentRepo(dataData, uniqueIDRepo, searchChangeRepo)
+	commentCommonRepo := comment.NewCommentCommonRepo(dataData, uniqueIDRepo, searchChangeRepo)
 	objService := object_info.NewObjService(answerRepo, questionRepo, commentCommonRepo, tagCommonRepo, tagCommonService)
-	notificationQueueService := notice_queue.NewNotificationQueueService()
-	externalNotificationQueueService := notice_queue.NewNewQuestionNotificationQueueService()
+	notificationQueueService := notice_queue.NewNotificationQueueService(queueMessageRepo)
+	externalNotificationQueueService := notice_queue.NewNewQuestionNotificationQueueService(queueMessageRepo)
 	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, notificationQueueService, externalNotificationQueueService, activityQueueService, eventQueueService)
-	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
 	rolePowerRelService := role2.NewRolePowerRelService(rolePowerRelRepo, userRoleRelService)
 	rankService := rank2.NewRankService(userCommon, userRankRepo, objService, userRoleRelService, rolePowerRelService, configService)
 	limitRepo := limit.NewRateLimitRepo(dataData)
-	rateLimitMiddleware := middleware.NewRateLimitMiddleware(limitRepo)
+	rateLimitMiddleware := middleware.NewRateLimitMiddleware(limitRepo, siteInfoCommonService, userCommon)
 	commentController := controller.NewCommentController(commentService, rankService, captchaService, rateLimitMiddleware)
 	reportRepo := report.NewReportRepo(dataData, uniqueIDRepo)
-	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, activityQueueService)
+	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, activityQueueService, searchChangeRepo)
 	answerActivityRepo := activity.NewAnswerActivityRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
 	answerActivityService := activity2.NewAnswerActivityService(answerActivityRepo, configService)
 	externalNotificationService := notification.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalNotificationQueueService, userExternalLoginRepo, siteInfoCommonService)
 	reviewRepo := review.NewReviewRepo(dataData)
-	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService)
-	questionService := content.NewQuestionService(activityRepo, questionRepo, answerRepo, tagCommonService, tagService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, reviewRepo)
+	moderationRepo := moderation.NewModerationRepo(dataData)
+	moderationCommonService := moderation_common.NewModerationCommonService(moderationRepo, objService)
+	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService, moderationCommonService)
+	hierarchicalTagRepo := hierarchical_tag.NewHierarchicalTagRepo(dataData)
+	hierarchicalTagService := hierarchical_tag2.NewHierarchicalTagService(hierarchicalTagRepo, roleService, userRoleRelService, userCommon)
+	questionScheduleRepo := question_schedule.NewQuestionScheduleRepo(dataData)
+	questionScheduleService := question_schedule2.NewQuestionScheduleService(questionScheduleRepo, tagCommonService, configService)
+	draftRepo := draft.NewDraftRepo(dataData)
+	draftService := draft2.NewDraftService(draftRepo, questionRepo, answerRepo, eventQueueService)
+	questionService := content.NewQuestionService(activityRepo, questionRepo, answerRepo, tagCommonService, tagService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, reviewRepo, hierarchicalTagService, questionScheduleService, draftService)
 	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, notificationQueueService, externalNotificationQueueService, activityQueueService, reviewService, eventQueueService)
 	reportHandle := report_handle.NewReportHandle(questionService, answerService, commentService)
-	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService, eventQueueService)
+	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService, eventQueueService, moderationCommonService)
 	reportController := controller.NewReportController(reportService, rankService, captchaService)
 	contentVoteRepo := activity.NewVoteRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
 	voteService := content.NewVoteService(contentVoteRepo, configService, questionRepo, answerRepo, commentCommonRepo, objService, eventQueueService)
 	voteController := controller.NewVoteController(voteService, rankService, captchaService)
-	tagController := controller.NewTagController(tagService, tagCommonService, rankService)
-	hierarchicalTagRepo := hierarchical_tag.NewHierarchicalTagRepo(dataData)
-	hierarchicalTagService := service.NewHierarchicalTagService(hierarchicalTagRepo)
+	reviewActivityRepo := activity.NewReviewActivityRepo(dataData, activityRepo, userRankRepo, configService)
+	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo, hierarchicalTagService, moderationCommonService)
+	tagController := controller.NewTagController(tagService, tagCommonService, rankService, contentRevisionService)
 	hierarchicalTagController := controller.NewHierarchicalTagController(hierarchicalTagService)
 	followFollowRepo := activity.NewFollowRepo(dataData, uniqueIDRepo, activityRepo)
 	followService := follow.NewFollowService(followFollowRepo, followRepo, tagCommonRepo)
@@ -206,14 +241,12 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	collectionGroupRepo := collection.NewCollectionGroupRepo(dataData)
 	collectionService := collection2.NewCollectionService(collectionRepo, collectionGroupRepo, questionCommon)
 	collectionController := controller.NewCollectionController(collectionService)
-	questionController := controller.NewQuestionController(questionService, answerService, rankService, siteInfoCommonService, captchaService, rateLimitMiddleware)
-	answerController := controller.NewAnswerController(answerService, rankService, captchaService, siteInfoCommonService, rateLimitMiddleware)
-	searchParser := search_parser.NewSearchParser(tagCommonService, userCommon)
+	questionController := controller.NewQuestionController(questionService, answerService, rankService, siteInfoCommonService, captchaService, rateLimitMiddleware, contentRevisionService)
+	answerController := controller.NewAnswerController(answerService, rankService, captchaService, siteInfoCommonService, rateLimitMiddleware, contentRevisionService)
+	searchParser := search_parser.NewSearchParser(tagCommonService, userCommon, hierarchicalTagService)
 	searchRepo := search_common.NewSearchRepo(dataData, uniqueIDRepo, userCommon, tagCommonService)
 	searchService := content.NewSearchService(searchParser, searchRepo)
 	searchController := controller.NewSearchController(searchService, captchaService)
-	reviewActivityRepo := activity.NewReviewActivityRepo(dataData, activityRepo, userRankRepo, configService)
-	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo)
 	revisionController := controller.NewRevisionController(contentRevisionService, rankService)
 	rankController := controller.NewRankController(rankService)
 	userAdminRepo := user.NewUserAdminRepo(dataData, authRepo)
@@ -226,7 +259,12 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	reasonService := reason2.NewReasonService(reasonRepo)
 	reasonController := controller.NewReasonController(reasonService)
 	themeController := controller_admin.NewThemeController()
-	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, emailService, tagCommonService, configService, questionCommon, fileRecordService)
+	pluginConfigRepo := plugin_config.NewPluginConfigRepo(dataData)
+	importerService := importer.NewImporterService(questionService, rankService, userCommon)
+	searchIndexRepo := search_index.NewSearchIndexRepo(dataData)
+	searchIndexService := search_index2.NewSearchIndexService(searchIndexRepo)
+	pluginCommonService := plugin_common.NewPluginCommonService(pluginConfigRepo, pluginUserConfigRepo, configService, dataData, importerService, searchIndexService)
+	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, emailService, tagCommonService, configService, questionCommon, fileRecordService, pluginCommonService)
 	siteInfoController := controller_admin.NewSiteInfoController(siteInfoService)
 	controllerSiteInfoController := controller.NewSiteInfoController(siteInfoCommonService)
 	notificationCommon := notificationcommon.NewNotificationCommon(dataData, notificationRepo, userCommon, activityRepo, followRepo, objService, notificationQueueService, userExternalLoginRepo, siteInfoCommonService)
@@ -243,9 +281,6 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	activityService := activity2.NewActivityService(activityActivityRepo, userCommon, activityCommon, tagCommonService, objService, commentCommonService, revisionService, metaCommonService, configService)
 	activityController := controller.NewActivityController(activityService)
 	roleController := controller_admin.NewRoleController(roleService)
-	pluginConfigRepo := plugin_config.NewPluginConfigRepo(dataData)
-	importerService := importer.NewImporterService(questionService, rankService, userCommon)
-	pluginCommonService := plugin_common.NewPluginCommonService(pluginConfigRepo, pluginUserConfigRepo, configService, dataData, importerService)
 	pluginController := controller_admin.NewPluginController(pluginCommonService)
 	permissionController := controller.NewPermissionController(rankService)
 	userPluginController := controller.NewUserPluginController(pluginCommonService)
@@ -254,15 +289,39 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	metaController := controller.NewMetaController(metaService)
 	badgeGroupRepo := badge_group.NewBadgeGroupRepo(dataData, uniqueIDRepo)
 	eventRuleRepo := badge.NewEventRuleRepo(dataData)
+	badgeRuleRepo := badge.NewBadgeRuleRepo(dataData)
 	badgeAwardService := badge2.NewBadgeAwardService(badgeAwardRepo, badgeRepo, userCommon, objService, notificationQueueService)
-	badgeEventService := badge2.NewBadgeEventService(dataData, eventQueueService, badgeRepo, eventRuleRepo, badgeAwardService)
+	badgeEventService := badge2.NewBadgeEventService(dataData, eventQueueService, badgeRepo, eventRuleRepo, badgeRuleRepo, badgeAwardService)
 	badgeService := badge2.NewBadgeService(badgeRepo, badgeGroupRepo, badgeAwardRepo, badgeEventService, siteInfoCommonService)
 	badgeController := controller.NewBadgeController(badgeService, badgeAwardService)
-	controller_adminBadgeController := controller_admin.NewBadgeController(badgeService)
-	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, hierarchicalTagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, badgeController, controller_adminBadgeController)
+	badgeGroupService := badge2.NewBadgeGroupService(badgeGroupRepo, badgeRepo)
+	controller_adminBadgeController := controller_admin.NewBadgeController(badgeService, badgeGroupService)
+	queueMessageService := queue_message2.NewQueueMessageService(queueMessageRepo)
+	queueMessageController := controller_admin.NewQueueMessageController(queueMessageService)
+	webhookRepo := webhook.NewWebhookRepo(dataData)
+	webhookService := webhook2.NewWebhookService(webhookRepo, eventQueueService, queueMessageRepo, objService, siteInfoCommonService)
+	webhookController := controller_admin.NewWebhookController(webhookService)
+	searchSyncer := search_sync.NewPluginSyncer(dataData)
+	searchSyncService := search_sync2.NewSearchSyncService(searchChangeRepo, searchSyncer)
+	searchSyncController := controller_admin.NewSearchSyncController(searchSyncService)
+	emailReplyService := email_reply.NewEmailReplyService(emailService, userRepo, userRoleRelService, rankService, captchaService, siteInfoCommonService, commentCommonService, commentService, answerService)
+	emailReplyController := controller.NewEmailReplyController(emailReplyService)
+	personalAccessTokenRepo := personal_access_token.NewPersonalAccessTokenRepo(dataData)
+	personalAccessTokenService := personal_access_token2.NewPersonalAccessTokenService(personalAccessTokenRepo, userRepo, userCommon, userRoleRelService)
+	personalAccessTokenController := controller.NewPersonalAccessTokenController(personalAccessTokenService)
+	controller_adminPersonalAccessTokenController := controller_admin.NewPersonalAccessTokenController(personalAccessTokenService)
+	oAuthProviderRepo := oauth_provider.NewOAuthProviderRepo(dataData)
+	oAuthProviderService := oauth_provider2.NewOAuthProviderService(oAuthProviderRepo, userRepo, siteInfoCommonService, configService)
+	oAuthProviderController := controller.NewOAuthProviderController(oAuthProviderService)
+	oAuthClientController := controller_admin.NewOAuthClientController(oAuthProviderService)
+	draftController := controller.NewDraftController(draftService, rankService)
+	questionAutoCloseController := controller_admin.NewQuestionAutoCloseController(questionScheduleService)
+	moderationService := moderation2.NewModerationService(moderationRepo, reportRepo, reviewRepo, revisionRepo, objService, userCommon, reportService, reviewService, contentRevisionService)
+	moderationController := controller.NewModerationController(moderationService, rankService)
+	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, hierarchicalTagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, badgeController, controller_adminBadgeController, queueMessageController, webhookController, searchSyncController, emailReplyController, personalAccessTokenController, controller_adminPersonalAccessTokenController, oAuthProviderController, oAuthClientController, draftController, questionAutoCloseController, rateLimitMiddleware, moderationController)
 	swaggerRouter := router.NewSwaggerRouter(swaggerConf)
 	uiRouter := router.NewUIRouter(controllerSiteInfoController, siteInfoCommonService)
-	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService)
+	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService, personalAccessTokenService)
 	avatarMiddleware := middleware.NewAvatarMiddleware(serviceConf, uploaderService)
 	shortIDMiddleware := middleware.NewShortIDMiddleware(siteInfoCommonService)
 	templateRenderController := templaterender.NewTemplateRenderController(questionService, userService, tagService, answerService, commentService, siteInfoCommonService, questionRepo)
@@ -276,7 +335,7 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	renderController := controller.NewRenderController()
 	pluginAPIRouter := router.NewPluginAPIRouter(connectorController, userCenterController, captchaController, embedController, renderController)
 	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, templateRouter, pluginAPIRouter, uiConf)
-	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService, fileRecordService, userAdminService, serviceConf)
+	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService, fileRecordService, userAdminService, digestService, draftService, serviceConf, oAuthProviderService)
 	application := newApplication(serverConf, ginEngine, scheduledTaskManager)
 	return application, func() {
 		cleanup2()
@@ -31,6 +31,26 @@ const docTemplate = `{
                 "responses": {}
             }
         },
+        "/.well-known/openid-configuration": {
+            "get": {
+                "description": "the discovery document of OpenID Connect, the issuer is the site url",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth"
+                ],
+                "summary": "get the openid provider metadata",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.OIDCDiscoveryResp"
+                        }
+                    }
+                }
+            }
+        },
         "/answer/admin/api/answer/page": {
             "get": {
                 "security": [
@@ -135,14 +155,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/badge/status": {
+        "/answer/admin/api/badge": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get badge detail with its rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AdminBadge"
+                ],
+                "summary": "get badge detail with its rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "badge id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetBadgeDetailResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update badge status",
+                "description": "update badge",
                 "consumes": [
                     "application/json"
                 ],
@@ -152,15 +219,15 @@ const docTemplate = `{
                 "tags": [
                     "AdminBadge"
                 ],
-                "summary": "update badge status",
+                "summary": "update badge",
                 "parameters": [
                     {
-                        "description": "UpdateBadgeStatusReq",
+                        "description": "UpdateBadgeReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateBadgeStatusReq"
+                            "$ref": "#/definitions/schema.UpdateBadgeReq"
                         }
                     }
                 ],
@@ -172,16 +239,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/admin/api/badges": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "list all badges by page",
+                "description": "add badge awarded by rule",
                 "consumes": [
                     "application/json"
                 ],
@@ -191,71 +256,73 @@ const docTemplate = `{
                 "tags": [
                     "AdminBadge"
                 ],
-                "summary": "list all badges by page",
+                "summary": "add badge awarded by rule",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
+                        "description": "AddBadgeReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddBadgeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
                     {
-                        "enum": [
-                            "",
-                            "active",
-                            "inactive"
-                        ],
-                        "type": "string",
-                        "description": "badge status",
-                        "name": "status",
-                        "in": "query"
-                    },
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete badge",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "AdminBadge"
+                ],
+                "summary": "delete badge",
+                "parameters": [
                     {
-                        "type": "string",
-                        "description": "search param",
-                        "name": "q",
-                        "in": "query"
+                        "description": "DeleteBadgeReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.DeleteBadgeReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetBadgeListPagedResp"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/admin/api/dashboard": {
-            "get": {
+        "/answer/admin/api/badge-group": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "DashboardInfo",
+                "description": "update badge group",
                 "consumes": [
                     "application/json"
                 ],
@@ -263,9 +330,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "AdminBadge"
+                ],
+                "summary": "update badge group",
+                "parameters": [
+                    {
+                        "description": "UpdateBadgeGroupReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateBadgeGroupReq"
+                        }
+                    }
                 ],
-                "summary": "DashboardInfo",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -274,16 +352,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/admin/api/delete/permanently": {
-            "delete": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete permanently",
+                "description": "add badge group",
                 "consumes": [
                     "application/json"
                 ],
@@ -291,17 +367,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "AdminBadge"
                 ],
-                "summary": "delete permanently",
+                "summary": "add badge group",
                 "parameters": [
                     {
-                        "description": "DeletePermanentlyReq",
+                        "description": "AddBadgeGroupReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.DeletePermanentlyReq"
+                            "$ref": "#/definitions/schema.AddBadgeGroupReq"
                         }
                     }
                 ],
@@ -313,23 +389,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/admin/api/language/options": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get language options",
+                "description": "delete badge group without badges",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lang"
+                    "AdminBadge"
+                ],
+                "summary": "delete badge group without badges",
+                "parameters": [
+                    {
+                        "description": "DeleteBadgeGroupReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.DeleteBadgeGroupReq"
+                        }
+                    }
                 ],
-                "summary": "Get language options",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -340,30 +428,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/plugin/config": {
+        "/answer/admin/api/badge-groups": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get plugin config",
+                "description": "list all badge groups",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "AdminPlugin"
-                ],
-                "summary": "get plugin config",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "plugin_slug_name",
-                        "name": "plugin_slug_name",
-                        "in": "query",
-                        "required": true
-                    }
+                    "AdminBadge"
                 ],
+                "summary": "list all badge groups",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -376,7 +455,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetPluginConfigResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.BadgeGroupItem"
+                                            }
                                         }
                                     }
                                 }
@@ -384,14 +466,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/answer/admin/api/badge/backfill": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update plugin config",
+                "description": "award badge to users who already meet its rule",
                 "consumes": [
                     "application/json"
                 ],
@@ -399,17 +483,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AdminPlugin"
+                    "AdminBadge"
                 ],
-                "summary": "update plugin config",
+                "summary": "award badge to users who already meet its rule",
                 "parameters": [
                     {
-                        "description": "UpdatePluginConfigReq",
+                        "description": "BackfillBadgeReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdatePluginConfigReq"
+                            "$ref": "#/definitions/schema.BackfillBadgeReq"
                         }
                     }
                 ],
@@ -423,14 +507,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/plugin/status": {
+        "/answer/admin/api/badge/status": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update plugin status",
+                "description": "update badge status",
                 "consumes": [
                     "application/json"
                 ],
@@ -438,17 +522,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AdminPlugin"
+                    "AdminBadge"
                 ],
-                "summary": "update plugin status",
+                "summary": "update badge status",
                 "parameters": [
                     {
-                        "description": "UpdatePluginStatusReq",
+                        "description": "UpdateBadgeStatusReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdatePluginStatusReq"
+                            "$ref": "#/definitions/schema.UpdateBadgeStatusReq"
                         }
                     }
                 ],
@@ -462,14 +546,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/plugins": {
+        "/answer/admin/api/badges": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get plugin list",
+                "description": "list all badges by page",
                 "consumes": [
                     "application/json"
                 ],
@@ -477,20 +561,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "AdminPlugin"
+                    "AdminBadge"
                 ],
-                "summary": "get plugin list",
+                "summary": "list all badges by page",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "status: active/inactive",
-                        "name": "status",
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "have config",
-                        "name": "have_config",
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "",
+                            "active",
+                            "inactive"
+                        ],
+                        "type": "string",
+                        "description": "badge status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search param",
+                        "name": "q",
                         "in": "query"
                     }
                 ],
@@ -508,7 +609,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/schema.GetPluginListResp"
+                                                "$ref": "#/definitions/schema.GetBadgeListPagedResp"
                                             }
                                         }
                                     }
@@ -519,14 +620,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/question/page": {
+        "/answer/admin/api/dashboard": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Status:[available,closed,deleted,pending]",
+                "description": "DashboardInfo",
                 "consumes": [
                     "application/json"
                 ],
@@ -536,39 +637,7 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "AdminQuestionPage admin question page",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "available",
-                            "closed",
-                            "deleted",
-                            "pending"
-                        ],
-                        "type": "string",
-                        "description": "user status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "question id or title",
-                        "name": "query",
-                        "in": "query"
-                    }
-                ],
+                "summary": "DashboardInfo",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -579,14 +648,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/question/status": {
-            "put": {
+        "/answer/admin/api/delete/permanently": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update question status",
+                "description": "delete permanently",
                 "consumes": [
                     "application/json"
                 ],
@@ -596,15 +665,15 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "update question status",
+                "summary": "delete permanently",
                 "parameters": [
                     {
-                        "description": "AdminUpdateQuestionStatusReq",
+                        "description": "DeletePermanentlyReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.AdminUpdateQuestionStatusReq"
+                            "$ref": "#/definitions/schema.DeletePermanentlyReq"
                         }
                     }
                 ],
@@ -618,14 +687,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/reasons": {
-            "get": {
+        "/answer/admin/api/hierarchical-tags": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get reasons by object type and action",
+                "description": "Delete a hierarchical tag together with its whole subtree",
                 "consumes": [
                     "application/json"
                 ],
@@ -633,33 +702,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reason"
+                    "HierarchicalTag"
                 ],
-                "summary": "get reasons by object type and action",
+                "summary": "Delete hierarchical tag",
                 "parameters": [
                     {
-                        "enum": [
-                            "question",
-                            "answer",
-                            "comment",
-                            "user"
-                        ],
-                        "type": "string",
-                        "description": "object_type",
-                        "name": "object_type",
-                        "in": "query",
-                        "required": true
-                    },
+                        "description": "hierarchical tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.DeleteHierarchicalTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/admin/api/hierarchical-tags/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Export the whole hierarchical tag taxonomy as a json, yaml or csv file",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "HierarchicalTag"
+                ],
+                "summary": "Export hierarchical tags",
+                "parameters": [
                     {
                         "enum": [
-                            "status",
-                            "close",
-                            "flag",
-                            "review"
+                            "json",
+                            "yaml",
+                            "csv"
                         ],
                         "type": "string",
-                        "description": "action",
-                        "name": "action",
+                        "description": "file format",
+                        "name": "format",
                         "in": "query",
                         "required": true
                     }
@@ -668,27 +759,41 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "type": "file"
                         }
                     }
                 }
             }
         },
-        "/answer/admin/api/roles": {
-            "get": {
+        "/answer/admin/api/hierarchical-tags/import": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get role list",
+                "description": "Import a hierarchical tag taxonomy matched by slug name, a dry run only returns the diff",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "HierarchicalTag"
+                ],
+                "summary": "Import hierarchical tags",
+                "parameters": [
+                    {
+                        "description": "taxonomy file",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ImportHierarchicalTagsReq"
+                        }
+                    }
                 ],
-                "summary": "get role list",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -701,10 +806,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetRoleResp"
-                                            }
+                                            "$ref": "#/definitions/schema.ImportHierarchicalTagsResp"
                                         }
                                     }
                                 }
@@ -714,64 +816,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/setting/privileges": {
-            "get": {
+        "/answer/admin/api/hierarchical-tags/order": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "GetPrivilegesConfig get privileges config",
+                "description": "Sort the children of a hierarchical tag, an empty parent sorts the root tags",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "HierarchicalTag"
+                ],
+                "summary": "Reorder hierarchical tags",
+                "parameters": [
+                    {
+                        "description": "parent and the children in their new order",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ReorderHierarchicalTagsReq"
+                        }
+                    }
                 ],
-                "summary": "GetPrivilegesConfig get privileges config",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.GetPrivilegesConfigResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/answer/admin/api/hierarchical-tags/parent": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update privileges config",
+                "description": "Move a hierarchical tag and its subtree under a new parent, an empty parent moves it to the root level",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "HierarchicalTag"
                 ],
-                "summary": "update privileges config",
+                "summary": "Move hierarchical tag",
                 "parameters": [
                     {
-                        "description": "config",
+                        "description": "hierarchical tag and new parent",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdatePrivilegesConfigReq"
+                            "$ref": "#/definitions/schema.MoveHierarchicalTagReq"
                         }
                     }
                 ],
@@ -785,21 +894,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/setting/smtp": {
+        "/answer/admin/api/hierarchical-tags/permission": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "GetSMTPConfig get smtp config",
+                "description": "Get the roles allowed to file questions, the leaf-only setting and the moderators of a hierarchical tag",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "HierarchicalTag"
+                ],
+                "summary": "Get hierarchical tag permission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "hierarchical tag id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "GetSMTPConfig get smtp config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -812,7 +930,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetSMTPConfigResp"
+                                            "$ref": "#/definitions/schema.HierarchicalTagPermissionResp"
                                         }
                                     }
                                 }
@@ -827,22 +945,25 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update smtp config",
+                "description": "Update the roles allowed to file questions, the leaf-only setting and the moderators of a hierarchical tag",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "HierarchicalTag"
                 ],
-                "summary": "update smtp config",
+                "summary": "Update hierarchical tag permission",
                 "parameters": [
                     {
-                        "description": "smtp config",
+                        "description": "hierarchical tag permission",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateSMTPConfigReq"
+                            "$ref": "#/definitions/schema.UpdateHierarchicalTagPermissionReq"
                         }
                     }
                 ],
@@ -856,64 +977,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/branding": {
+        "/answer/admin/api/language/options": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site interface",
+                "description": "Get language options",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "Lang"
                 ],
-                "summary": "get site interface",
+                "summary": "Get language options",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.SiteBrandingResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/answer/admin/api/oauth/client": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site info branding",
+                "description": "update the name, redirect uris and scopes of an oauth client",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site info branding",
+                "summary": "update an oauth client",
                 "parameters": [
                     {
-                        "description": "branding info",
+                        "description": "oauth client",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteBrandingReq"
+                            "$ref": "#/definitions/schema.UpdateOAuthClientReq"
                         }
                     }
                 ],
@@ -925,23 +1039,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/admin/api/siteinfo/custom-css-html": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site info custom html css config",
+                "description": "register an oauth client, the client secret is only returned in this response",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site info custom html css config",
+                "summary": "register an oauth client",
+                "parameters": [
+                    {
+                        "description": "oauth client",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddOAuthClientReq"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -954,7 +1080,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteCustomCssHTMLResp"
+                                            "$ref": "#/definitions/schema.AddOAuthClientResp"
                                         }
                                     }
                                 }
@@ -963,28 +1089,31 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site custom css html config",
+                "description": "delete an oauth client, the tokens issued to it stop working",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site custom css html config",
+                "summary": "delete an oauth client",
                 "parameters": [
                     {
-                        "description": "login info",
+                        "description": "oauth client",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteCustomCssHTMLReq"
+                            "$ref": "#/definitions/schema.DeleteOAuthClientReq"
                         }
                     }
                 ],
@@ -998,21 +1127,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/general": {
-            "get": {
+        "/answer/admin/api/oauth/client/secret": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site general information",
+                "description": "generate a new secret for an oauth client, the old one stops working at once",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site general information",
+                "summary": "reset the secret of an oauth client",
+                "parameters": [
+                    {
+                        "description": "oauth client",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ResetOAuthClientSecretReq"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1025,7 +1168,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteGeneralResp"
+                                            "$ref": "#/definitions/schema.ResetOAuthClientSecretResp"
                                         }
                                     }
                                 }
@@ -1033,57 +1176,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "update site general information",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "admin"
-                ],
-                "summary": "update site general information",
-                "parameters": [
-                    {
-                        "description": "general",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.SiteGeneralReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
             }
         },
-        "/answer/admin/api/siteinfo/interface": {
+        "/answer/admin/api/oauth/clients": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site interface",
+                "description": "get the applications which can sign in the users with their accounts of the site",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site interface",
+                "summary": "get the oauth clients",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1096,7 +1205,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteInterfaceResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.OAuthClientItem"
+                                            }
                                         }
                                     }
                                 }
@@ -1104,29 +1216,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/answer/admin/api/personal-access-token": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site info interface",
+                "description": "revoke a personal access token of any user",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site info interface",
+                "summary": "revoke a personal access token of any user",
                 "parameters": [
                     {
-                        "description": "general",
+                        "description": "personal access token",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteInterfaceReq"
+                            "$ref": "#/definitions/schema.RevokePersonalAccessTokenReq"
                         }
                     }
                 ],
@@ -1140,21 +1257,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/legal": {
+        "/answer/admin/api/personal-access-tokens/page": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Set the legal information for the site",
+                "description": "get the personal access tokens of the users, filtered by the user if the user id is given",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Set the legal information for the site",
+                "summary": "get the personal access tokens of the users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "user id",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1167,7 +1304,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteLegalResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.AdminPersonalAccessTokenItem"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -1175,57 +1327,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/answer/admin/api/plugin/config": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site legal info",
+                "description": "get plugin config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "AdminPlugin"
                 ],
-                "summary": "update site legal info",
+                "summary": "get plugin config",
                 "parameters": [
                     {
-                        "description": "write info",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.SiteLegalReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/admin/api/siteinfo/login": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
+                        "type": "string",
+                        "description": "plugin_slug_name",
+                        "name": "plugin_slug_name",
+                        "in": "query",
+                        "required": true
                     }
                 ],
-                "description": "get site info login config",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "admin"
-                ],
-                "summary": "get site info login config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1238,7 +1365,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteLoginResp"
+                                            "$ref": "#/definitions/schema.GetPluginConfigResp"
                                         }
                                     }
                                 }
@@ -1253,22 +1380,25 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site login",
+                "description": "update plugin config",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "AdminPlugin"
                 ],
-                "summary": "update site login",
+                "summary": "update plugin config",
                 "parameters": [
                     {
-                        "description": "login info",
+                        "description": "UpdatePluginConfigReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteLoginReq"
+                            "$ref": "#/definitions/schema.UpdatePluginConfigReq"
                         }
                     }
                 ],
@@ -1282,64 +1412,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/seo": {
-            "get": {
+        "/answer/admin/api/plugin/status": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site seo information",
-                "produces": [
+                "description": "update plugin status",
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "admin"
-                ],
-                "summary": "get site seo information",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.SiteSeoResp"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "update site seo information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "AdminPlugin"
                 ],
-                "summary": "update site seo information",
+                "summary": "update plugin status",
                 "parameters": [
                     {
-                        "description": "seo",
+                        "description": "UpdatePluginStatusReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteSeoReq"
+                            "$ref": "#/definitions/schema.UpdatePluginStatusReq"
                         }
                     }
                 ],
@@ -1353,21 +1451,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/siteinfo/theme": {
+        "/answer/admin/api/plugins": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site info theme config",
+                "description": "get plugin list",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "AdminPlugin"
+                ],
+                "summary": "get plugin list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "status: active/inactive",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "have config",
+                        "name": "have_config",
+                        "in": "query"
+                    }
                 ],
-                "summary": "get site info theme config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1380,7 +1495,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteThemeResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetPluginListResp"
+                                            }
                                         }
                                     }
                                 }
@@ -1388,57 +1506,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "update site custom css html config",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "admin"
-                ],
-                "summary": "update site custom css html config",
-                "parameters": [
-                    {
-                        "description": "login info",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.SiteThemeReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
             }
         },
-        "/answer/admin/api/siteinfo/users": {
+        "/answer/admin/api/powers": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site user config",
+                "description": "get the powers which can be assigned to the roles",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get site user config",
+                "summary": "get the powers which can be assigned to the roles",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1451,7 +1535,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteUsersResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetPowerResp"
+                                            }
                                         }
                                     }
                                 }
@@ -1459,29 +1546,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/answer/admin/api/question/auto-close-rule": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update site info config about users",
+                "description": "update question auto close rule",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site info config about users",
+                "summary": "update question auto close rule",
                 "parameters": [
                     {
-                        "description": "users info",
+                        "description": "rule",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteUsersReq"
+                            "$ref": "#/definitions/schema.UpdateQuestionAutoCloseRuleReq"
                         }
                     }
                 ],
@@ -1493,94 +1585,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/admin/api/siteinfo/write": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get site interface",
-                "produces": [
+                "description": "add question auto close rule, the questions matching it are closed by the admin who adds it",
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "admin"
-                ],
-                "summary": "get site interface",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.SiteWriteResp"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "update site write info",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "update site write info",
+                "summary": "add question auto close rule",
                 "parameters": [
                     {
-                        "description": "write info",
+                        "description": "rule",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SiteWriteReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "$ref": "#/definitions/schema.AddQuestionAutoCloseRuleReq"
                         }
                     }
-                }
-            }
-        },
-        "/answer/admin/api/theme/options": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Get theme options",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "admin"
                 ],
-                "summary": "Get theme options",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1589,16 +1622,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/admin/api/user": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add user",
+                "description": "delete question auto close rule",
                 "consumes": [
                     "application/json"
                 ],
@@ -1608,15 +1639,15 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "add user",
+                "summary": "delete question auto close rule",
                 "parameters": [
                     {
-                        "description": "user",
+                        "description": "rule",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.AddUserReq"
+                            "$ref": "#/definitions/schema.DeleteQuestionAutoCloseRuleReq"
                         }
                     }
                 ],
@@ -1630,30 +1661,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/user/activation": {
+        "/answer/admin/api/question/auto-close-rules": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get user activation",
+                "description": "get question auto close rule list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get user activation",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "user id",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
+                "summary": "get question auto close rule list",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1666,7 +1688,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetUserActivationResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.QuestionAutoCloseRuleItem"
+                                            }
                                         }
                                     }
                                 }
@@ -1676,14 +1701,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/user/password": {
-            "put": {
+        "/answer/admin/api/question/page": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user password",
+                "description": "Status:[available,closed,deleted,pending]",
                 "consumes": [
                     "application/json"
                 ],
@@ -1693,16 +1718,37 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "update user password",
+                "summary": "AdminQuestionPage admin question page",
                 "parameters": [
                     {
-                        "description": "user",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UpdateUserPasswordReq"
-                        }
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "available",
+                            "closed",
+                            "deleted",
+                            "pending"
+                        ],
+                        "type": "string",
+                        "description": "user status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "question id or title",
+                        "name": "query",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1715,14 +1761,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/user/profile": {
+        "/answer/admin/api/question/status": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "edit user profile",
+                "description": "update question status",
                 "consumes": [
                     "application/json"
                 ],
@@ -1732,15 +1778,15 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "edit user profile",
+                "summary": "update question status",
                 "parameters": [
                     {
-                        "description": "user",
+                        "description": "AdminUpdateQuestionStatusReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.EditUserProfileReq"
+                            "$ref": "#/definitions/schema.AdminUpdateQuestionStatusReq"
                         }
                     }
                 ],
@@ -1754,14 +1800,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/user/role": {
-            "put": {
+        "/answer/admin/api/queue-messages/page": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user role",
+                "description": "get queued event, notification and activity messages by page, dead messages have exhausted their retries",
                 "consumes": [
                     "application/json"
                 ],
@@ -1771,36 +1817,83 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "update user role",
+                "summary": "get queued event, notification and activity messages by page",
                 "parameters": [
                     {
-                        "description": "user",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UpdateUserRoleReq"
-                        }
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "queue name, such as notification, activity or event.badge",
+                        "name": "queue",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "processing",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "message status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.QueueMessageItem"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/admin/api/user/status": {
+        "/answer/admin/api/queue-messages/replay": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user",
+                "description": "put the pending or dead messages back to the queue with their attempts reset",
                 "consumes": [
                     "application/json"
                 ],
@@ -1810,18 +1903,86 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "update user",
+                "summary": "put the failed messages back to the queue",
                 "parameters": [
                     {
-                        "description": "user",
+                        "description": "ReplayQueueMessagesReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateUserStatusReq"
+                            "$ref": "#/definitions/schema.ReplayQueueMessagesReq"
                         }
                     }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.ReplayQueueMessagesResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/admin/api/reasons": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get reasons by object type and action",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reason"
+                ],
+                "summary": "get reasons by object type and action",
+                "parameters": [
+                    {
+                        "enum": [
+                            "question",
+                            "answer",
+                            "comment",
+                            "user"
+                        ],
+                        "type": "string",
+                        "description": "object_type",
+                        "name": "object_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "status",
+                            "close",
+                            "flag",
+                            "review"
+                        ],
+                        "type": "string",
+                        "description": "action",
+                        "name": "action",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1832,14 +1993,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/admin/api/users": {
-            "post": {
+        "/answer/admin/api/role": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add users",
+                "description": "update custom role and replace its powers",
                 "consumes": [
                     "application/json"
                 ],
@@ -1849,15 +2010,15 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "add users",
+                "summary": "update custom role",
                 "parameters": [
                     {
-                        "description": "user",
+                        "description": "role",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.AddUsersReq"
+                            "$ref": "#/definitions/schema.UpdateRoleReq"
                         }
                     }
                 ],
@@ -1869,31 +2030,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/admin/api/users/activation": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "send user activation",
+                "description": "add custom role with the selected powers",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "send user activation",
+                "summary": "add custom role",
                 "parameters": [
                     {
-                        "description": "SendUserActivationReq",
+                        "description": "role",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.SendUserActivationReq"
+                            "$ref": "#/definitions/schema.AddRoleReq"
                         }
                     }
                 ],
@@ -1905,138 +2067,60 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/admin/api/users/page": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get user page",
+                "description": "remove custom role, the users who have the role lose it",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "get user page",
+                "summary": "remove custom role",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "search query: email, username or id:[id]",
-                        "name": "query",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "staff user",
-                        "name": "staff",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "suspended",
-                            "deleted",
-                            "inactive"
-                        ],
-                        "type": "string",
-                        "description": "user status",
-                        "name": "status",
-                        "in": "query"
+                        "description": "role",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RemoveRoleReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "records": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetUserPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/activity/timeline": {
+        "/answer/admin/api/roles": {
             "get": {
-                "description": "get object timeline",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get role list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
-                ],
-                "summary": "get object timeline",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "object id",
-                        "name": "object_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "tag slug name",
-                        "name": "tag_slug_name",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "question",
-                            "answer",
-                            "tag"
-                        ],
-                        "type": "string",
-                        "description": "object type",
-                        "name": "object_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "is show vote",
-                        "name": "show_vote",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "get role list",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2049,7 +2133,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetObjectTimelineResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetRoleResp"
+                                            }
                                         }
                                     }
                                 }
@@ -2059,25 +2146,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/activity/timeline/detail": {
-            "get": {
-                "description": "get object timeline detail",
+        "/answer/admin/api/search/reindex": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "reindex all the questions and answers to the enabled search plugin in background",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
+                    "admin"
                 ],
-                "summary": "get object timeline detail",
+                "summary": "reindex all the contents to the search plugin",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "revision id",
-                        "name": "revision_id",
-                        "in": "query",
-                        "required": true
+                        "description": "search plugin",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ReindexSearchReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/admin/api/search/sync": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
                     }
                 ],
+                "description": "get how many changes each enabled search plugin has not synced and since when",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "get the sync status of the enabled search plugins",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2090,7 +2212,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetObjectTimelineResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetSearchSyncStatusResp"
+                                            }
                                         }
                                     }
                                 }
@@ -2100,69 +2225,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/answer": {
-            "put": {
+        "/answer/admin/api/setting/privileges": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update Answer",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "GetPrivilegesConfig get privileges config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
-                ],
-                "summary": "Update Answer",
-                "parameters": [
-                    {
-                        "description": "AnswerUpdateReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.AnswerUpdateReq"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "GetPrivilegesConfig get privileges config",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetPrivilegesConfigResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add answer",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update privileges config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
+                    "admin"
                 ],
-                "summary": "Add Answer",
+                "summary": "update privileges config",
                 "parameters": [
                     {
-                        "description": "add answer request",
+                        "description": "config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.AnswerAddReq"
+                            "$ref": "#/definitions/schema.UpdatePrivilegesConfigReq"
                         }
                     }
                 ],
@@ -2174,32 +2294,66 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/answer/admin/api/setting/smtp": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete answer",
-                "consumes": [
+                "description": "GetSMTPConfig get smtp config",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "GetSMTPConfig get smtp config",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetSMTPConfigResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update smtp config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
+                    "admin"
                 ],
-                "summary": "delete answer",
+                "summary": "update smtp config",
                 "parameters": [
                     {
-                        "description": "answer",
+                        "description": "smtp config",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.RemoveAnswerReq"
+                            "$ref": "#/definitions/schema.UpdateSMTPConfigReq"
                         }
                     }
                 ],
@@ -2213,35 +2367,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/answer/acceptance": {
-            "post": {
+        "/answer/admin/api/setting/smtp/reply-secret": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Accept Answer",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "replace the secret signing the reply addresses of the notification emails, the reply addresses sent before can not be used any more",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
-                ],
-                "summary": "Accept Answer",
-                "parameters": [
-                    {
-                        "description": "AcceptAnswerReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.AcceptAnswerReq"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "rotate the email reply secret",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2252,28 +2392,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/answer/info": {
+        "/answer/admin/api/siteinfo/branding": {
             "get": {
-                "description": "Get Answer Detail",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "get site interface",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
-                ],
-                "summary": "Get Answer Detail",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "get site interface",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2286,7 +2419,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetAnswerInfoResp"
+                                            "$ref": "#/definitions/schema.SiteBrandingResp"
                                         }
                                     }
                                 }
@@ -2294,87 +2427,100 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/answer/page": {
-            "get": {
-                "description": "AnswerList \u003cbr\u003e \u003cb\u003eorder\u003c/b\u003e (default or updated)",
-                "consumes": [
-                    "application/json"
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "update site info branding",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
+                    "admin"
                 ],
-                "summary": "AnswerList",
+                "summary": "update site info branding",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "question_id",
-                        "name": "question_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "order",
-                        "name": "order",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "page_size",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
+                        "description": "branding info",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SiteBrandingReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/answer/recover": {
-            "post": {
+        "/answer/admin/api/siteinfo/custom-css-html": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "recover the deleted answer",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get site info custom html css config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Answer"
+                    "admin"
                 ],
-                "summary": "recover answer",
-                "parameters": [
-                    {
-                        "description": "answer",
+                "summary": "get site info custom html css config",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SiteCustomCssHTMLResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update site custom css html config",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "update site custom css html config",
+                "parameters": [
+                    {
+                        "description": "login info",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.RecoverAnswerReq"
+                            "$ref": "#/definitions/schema.SiteCustomCssHTMLReq"
                         }
                     }
                 ],
@@ -2388,29 +2534,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/badge": {
+        "/answer/admin/api/siteinfo/general": {
             "get": {
-                "description": "get badge info",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "get site general information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api-badge"
-                ],
-                "summary": "get badge info",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "default": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "get site general information",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2423,7 +2561,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetBadgeInfoResp"
+                                            "$ref": "#/definitions/schema.SiteGeneralResp"
                                         }
                                     }
                                 }
@@ -2431,92 +2569,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/badge/awards/page": {
-            "get": {
-                "description": "get badge award list",
-                "consumes": [
-                    "application/json"
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "update site general information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api-badge"
+                    "admin"
                 ],
-                "summary": "get badge award list",
+                "summary": "update site general information",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "badge id",
-                        "name": "badge_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "only list the award by username",
-                        "name": "username",
-                        "in": "query"
+                        "description": "general",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SiteGeneralReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.GetBadgeInfoResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/badge/user/awards": {
+        "/answer/admin/api/siteinfo/interface": {
             "get": {
-                "description": "get user badge award list",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "get site interface",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api-badge"
-                ],
-                "summary": "get user badge award list",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "user name",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "get site interface",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2529,10 +2632,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetUserBadgeAwardListResp"
-                                            }
+                                            "$ref": "#/definitions/schema.SiteInterfaceResp"
                                         }
                                     }
                                 }
@@ -2540,68 +2640,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/badge/user/awards/recent": {
-            "get": {
-                "description": "get user badge award list",
-                "consumes": [
-                    "application/json"
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "update site info interface",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api-badge"
+                    "admin"
                 ],
-                "summary": "get user badge award list",
+                "summary": "update site info interface",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "user name",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
+                        "description": "general",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SiteInterfaceReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetUserBadgeAwardListResp"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/badges": {
+        "/answer/admin/api/siteinfo/legal": {
             "get": {
-                "description": "list all badges group by group",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Set the legal information for the site",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "api-badge"
+                    "admin"
                 ],
-                "summary": "list all badges group by group",
+                "summary": "Set the legal information for the site",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2614,10 +2703,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetBadgeListResp"
-                                            }
+                                            "$ref": "#/definitions/schema.SiteLegalResp"
                                         }
                                     }
                                 }
@@ -2625,34 +2711,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/collection/switch": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add collection",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update site legal info",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Collection"
+                    "admin"
                 ],
-                "summary": "add collection",
+                "summary": "update site legal info",
                 "parameters": [
                     {
-                        "description": "collection",
+                        "description": "write info",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.CollectionSwitchReq"
+                            "$ref": "#/definitions/schema.SiteLegalReq"
                         }
                     }
                 ],
@@ -2660,43 +2741,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.CollectionSwitchResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/comment": {
+        "/answer/admin/api/siteinfo/login": {
             "get": {
-                "description": "get comment by id",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get site info login config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
-                ],
-                "summary": "get comment by id",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "get site info login config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2709,22 +2774,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetCommentResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.SiteLoginResp"
                                         }
                                     }
                                 }
@@ -2739,25 +2789,22 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update comment",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update site login",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
+                    "admin"
                 ],
-                "summary": "update comment",
+                "summary": "update site login",
                 "parameters": [
                     {
-                        "description": "comment",
+                        "description": "login info",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateCommentReq"
+                            "$ref": "#/definitions/schema.SiteLoginReq"
                         }
                     }
                 ],
@@ -2769,35 +2816,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/answer/admin/api/siteinfo/rate-limit": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add comment",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get the quotas of the route groups for the anonymous, new and other users",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
-                ],
-                "summary": "add comment",
-                "parameters": [
-                    {
-                        "description": "comment",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.AddCommentReq"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "get site rate limit config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2810,7 +2845,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetCommentResp"
+                                            "$ref": "#/definitions/schema.SiteRateLimitResp"
                                         }
                                     }
                                 }
@@ -2819,31 +2854,28 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "remove comment",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update the quotas of the route groups for the anonymous, new and other users",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
+                    "admin"
                 ],
-                "summary": "remove comment",
+                "summary": "update site rate limit config",
                 "parameters": [
                     {
-                        "description": "comment",
+                        "description": "rate limit",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.RemoveCommentReq"
+                            "$ref": "#/definitions/schema.SiteRateLimitReq"
                         }
                     }
                 ],
@@ -2857,46 +2889,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/comment/page": {
+        "/answer/admin/api/siteinfo/review": {
             "get": {
-                "description": "get comment page",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the pipeline of the reviewers which check the new posts and the actions of the verdicts",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comment"
-                ],
-                "summary": "get comment page",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "object id",
-                        "name": "object_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "vote"
-                        ],
-                        "type": "string",
-                        "description": "query condition",
-                        "name": "query_cond",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "get site review config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2909,22 +2916,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetCommentResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.SiteReviewResp"
                                         }
                                     }
                                 }
@@ -2932,29 +2924,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/connector/binding/email": {
-            "post": {
-                "description": "external login binding user send email",
-                "consumes": [
-                    "application/json"
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "update the pipeline of the reviewers which check the new posts and the actions of the verdicts",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PluginConnector"
+                    "admin"
                 ],
-                "summary": "external login binding user send email",
+                "summary": "update site review config",
                 "parameters": [
                     {
-                        "description": "external login binding user send email",
+                        "description": "review",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.ExternalLoginBindingUserSendEmailReq"
+                            "$ref": "#/definitions/schema.SiteReviewReq"
                         }
                     }
                 ],
@@ -2962,39 +2954,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.ExternalLoginBindingUserSendEmailResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/connector/info": {
+        "/answer/admin/api/siteinfo/seo": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all enabled connectors",
+                "description": "get site seo information",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PluginConnector"
+                    "admin"
                 ],
-                "summary": "get all enabled connectors",
+                "summary": "get site seo information",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3007,10 +2987,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.ConnectorInfoResp"
-                                            }
+                                            "$ref": "#/definitions/schema.SiteSeoResp"
                                         }
                                     }
                                 }
@@ -3018,56 +2995,75 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update site seo information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "update site seo information",
+                "parameters": [
+                    {
+                        "description": "seo",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SiteSeoReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
             }
         },
-        "/answer/api/v1/connector/user/info": {
+        "/answer/admin/api/siteinfo/settings/export": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get all connectors info about user",
+                "description": "export the site info, the privileges and the plugin settings as a json file, the plugin config values pinned by the environment variables are not included",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "PluginConnector"
+                    "admin"
                 ],
-                "summary": "get all connectors info about user",
+                "summary": "export site settings",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.ConnectorUserInfoResp"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "file"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/connector/user/unbinding": {
-            "delete": {
+        "/answer/admin/api/siteinfo/settings/import": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "unbind external user login",
+                "description": "import the exported settings file, nothing is changed if any value is invalid, a dry run only checks the file",
                 "consumes": [
                     "application/json"
                 ],
@@ -3075,43 +3071,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PluginConnector"
+                    "admin"
                 ],
-                "summary": "unbind external user login",
+                "summary": "import site settings",
                 "parameters": [
                     {
-                        "description": "ExternalLoginUnbindingReq",
+                        "description": "settings file",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.ExternalLoginUnbindingReq"
+                            "$ref": "#/definitions/schema.ImportSiteSettingsReq"
                         }
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/embed/config": {
-            "get": {
-                "description": "get embed plugin config",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Plugin"
-                ],
-                "summary": "get embed plugin config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3124,10 +3097,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/plugin.EmbedConfig"
-                                            }
+                                            "$ref": "#/definitions/schema.ImportSiteSettingsResp"
                                         }
                                     }
                                 }
@@ -3137,43 +3107,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/file": {
-            "post": {
+        "/answer/admin/api/siteinfo/theme": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "upload file",
-                "consumes": [
-                    "multipart/form-data"
+                "description": "get site info theme config",
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "Upload"
-                ],
-                "summary": "upload file",
-                "parameters": [
-                    {
-                        "enum": [
-                            "post",
-                            "post_attachment",
-                            "avatar",
-                            "branding"
-                        ],
-                        "type": "string",
-                        "description": "identify the source of the file upload",
-                        "name": "source",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "admin"
                 ],
+                "summary": "get site info theme config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3186,7 +3134,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "$ref": "#/definitions/schema.SiteThemeResp"
                                         }
                                     }
                                 }
@@ -3194,37 +3142,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/follow": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "follow object or cancel follow operation",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update site custom css html config",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Activity"
+                    "admin"
                 ],
-                "summary": "follow object or cancel follow operation",
+                "summary": "update site custom css html config",
                 "parameters": [
                     {
-                        "description": "follow",
+                        "description": "login info",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.FollowReq"
+                            "$ref": "#/definitions/schema.SiteThemeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
+                }
+            }
+        },
+        "/answer/admin/api/siteinfo/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get site user config",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
                 ],
+                "summary": "get site user config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3237,7 +3205,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.FollowResp"
+                                            "$ref": "#/definitions/schema.SiteUsersResp"
                                         }
                                     }
                                 }
@@ -3245,34 +3213,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/follow/tags": {
+            },
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user follow tags",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "update site info config about users",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Activity"
+                    "admin"
                 ],
-                "summary": "update user follow tags",
+                "summary": "update site info config about users",
                 "parameters": [
                     {
-                        "description": "follow",
+                        "description": "users info",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateFollowTagsReq"
+                            "$ref": "#/definitions/schema.SiteUsersReq"
                         }
                     }
                 ],
@@ -3286,27 +3249,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/hierarchical-tags": {
+        "/answer/admin/api/siteinfo/write": {
             "get": {
-                "description": "Get hierarchical tags by parent ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "get site interface",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "HierarchicalTag"
-                ],
-                "summary": "Get hierarchical tags",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "parent tag ID",
-                        "name": "parent_id",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "get site interface",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3319,7 +3276,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetHierarchicalTagsResp"
+                                            "$ref": "#/definitions/schema.SiteWriteResp"
                                         }
                                     }
                                 }
@@ -3329,25 +3286,27 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an existing hierarchical tag",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "update site write info",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "HierarchicalTag"
+                    "admin"
                 ],
-                "summary": "Update hierarchical tag",
+                "summary": "update site write info",
                 "parameters": [
                     {
-                        "description": "hierarchical tag data",
+                        "description": "write info",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateHierarchicalTagReq"
+                            "$ref": "#/definitions/schema.SiteWriteReq"
                         }
                     }
                 ],
@@ -3359,9 +3318,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/answer/admin/api/theme/options": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get theme options",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get theme options",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/admin/api/user": {
             "post": {
-                "description": "Create a new hierarchical tag",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add user",
                 "consumes": [
                     "application/json"
                 ],
@@ -3369,17 +3360,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "HierarchicalTag"
+                    "admin"
                 ],
-                "summary": "Create hierarchical tag",
+                "summary": "add user",
                 "parameters": [
                     {
-                        "description": "hierarchical tag data",
+                        "description": "user",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.CreateHierarchicalTagReq"
+                            "$ref": "#/definitions/schema.AddUserReq"
                         }
                     }
                 ],
@@ -3393,24 +3384,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/hierarchical-tags/path": {
+        "/answer/admin/api/user/activation": {
             "get": {
-                "description": "Get the full path of a hierarchical tag",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "get user activation",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "HierarchicalTag"
+                    "admin"
                 ],
-                "summary": "Get hierarchical tag path",
+                "summary": "get user activation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "tag ID",
-                        "name": "tag_id",
+                        "description": "user id",
+                        "name": "user_id",
                         "in": "query",
                         "required": true
                     }
@@ -3427,7 +3420,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.HierarchicalTagPathResp"
+                                            "$ref": "#/definitions/schema.GetUserActivationResp"
                                         }
                                     }
                                 }
@@ -3437,78 +3430,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/hierarchical-tags/test": {
-            "get": {
-                "description": "Simple test endpoint to verify hierarchical tag system is working",
+        "/answer/admin/api/user/custom-roles": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "replace the custom roles of the user, the built-in role is not changed",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "HierarchicalTag"
+                    "admin"
+                ],
+                "summary": "update user custom roles",
+                "parameters": [
+                    {
+                        "description": "user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateUserCustomRolesReq"
+                        }
+                    }
                 ],
-                "summary": "Test hierarchical tag endpoint",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/language/config": {
-            "get": {
-                "description": "get language config mapping",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Lang"
-                ],
-                "summary": "get language config mapping",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Accept-Language",
-                        "name": "Accept-Language",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/language/options": {
-            "get": {
-                "description": "Get language options",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Lang"
-                ],
-                "summary": "Get language options",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3519,56 +3469,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/meta/reaction": {
-            "get": {
-                "description": "get reaction for an object",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Meta"
-                ],
-                "summary": "get reaction",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "object_id",
-                        "name": "object_id",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.ReactionRespItem"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    }
-                }
-            },
+        "/answer/admin/api/user/password": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update reaction. if not exist, add one",
+                "description": "update user password",
                 "consumes": [
                     "application/json"
                 ],
@@ -3576,17 +3484,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Meta"
+                    "admin"
                 ],
-                "summary": "add or update reaction",
+                "summary": "update user password",
                 "parameters": [
                     {
-                        "description": "reaction",
+                        "description": "user",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateReactionReq"
+                            "$ref": "#/definitions/schema.UpdateUserPasswordReq"
                         }
                     }
                 ],
@@ -3600,14 +3508,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/notification/page": {
-            "get": {
+        "/answer/admin/api/user/profile": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get notification list",
+                "description": "edit user profile",
                 "consumes": [
                     "application/json"
                 ],
@@ -3615,45 +3523,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "admin"
                 ],
-                "summary": "get notification list",
+                "summary": "edit user profile",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "inbox",
-                            "achievement"
-                        ],
-                        "type": "string",
-                        "description": "type",
-                        "name": "type",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "all",
-                            "posts",
-                            "invites",
-                            "votes"
-                        ],
-                        "type": "string",
-                        "description": "inbox_type",
-                        "name": "inbox_type",
-                        "in": "query",
-                        "required": true
+                        "description": "user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.EditUserProfileReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -3666,14 +3547,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/notification/read/state": {
+        "/answer/admin/api/user/role": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "ClearUnRead",
+                "description": "update user role",
                 "consumes": [
                     "application/json"
                 ],
@@ -3681,17 +3562,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "admin"
                 ],
-                "summary": "ClearUnRead",
+                "summary": "update user role",
                 "parameters": [
                     {
-                        "description": "NotificationClearIDRequest",
+                        "description": "user",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.NotificationClearIDRequest"
+                            "$ref": "#/definitions/schema.UpdateUserRoleReq"
                         }
                     }
                 ],
@@ -3705,14 +3586,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/notification/read/state/all": {
+        "/answer/admin/api/user/status": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "ClearUnRead",
+                "description": "update user",
                 "consumes": [
                     "application/json"
                 ],
@@ -3720,17 +3601,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "admin"
                 ],
-                "summary": "ClearUnRead",
+                "summary": "update user",
                 "parameters": [
                     {
-                        "description": "NotificationClearRequest",
+                        "description": "user",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.NotificationClearRequest"
+                            "$ref": "#/definitions/schema.UpdateUserStatusReq"
                         }
                     }
                 ],
@@ -3744,14 +3625,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/notification/status": {
-            "get": {
+        "/answer/admin/api/users": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "GetRedDot",
+                "description": "add users",
                 "consumes": [
                     "application/json"
                 ],
@@ -3759,9 +3640,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "admin"
+                ],
+                "summary": "add users",
+                "parameters": [
+                    {
+                        "description": "user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddUsersReq"
+                        }
+                    }
                 ],
-                "summary": "GetRedDot",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3770,32 +3662,31 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/answer/admin/api/users/activation": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "DelRedDot",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "send user activation",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notification"
+                    "admin"
                 ],
-                "summary": "DelRedDot",
+                "summary": "send user activation",
                 "parameters": [
                     {
-                        "description": "NotificationClearRequest",
+                        "description": "SendUserActivationReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.NotificationClearRequest"
+                            "$ref": "#/definitions/schema.SendUserActivationReq"
                         }
                     }
                 ],
@@ -3809,75 +3700,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/permission": {
+        "/answer/admin/api/users/page": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "check user permission",
+                "description": "get user page",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Permission"
+                    "admin"
                 ],
-                "summary": "check user permission",
+                "summary": "get user page",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "access-token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "search query: email, username or id:[id]",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "staff user",
+                        "name": "staff",
+                        "in": "query"
                     },
                     {
                         "enum": [
-                            "question.add",
-                            "question.edit",
-                            "question.edit_without_review",
-                            "question.delete",
-                            "question.close",
-                            "question.reopen",
-                            "question.vote_up",
-                            "question.vote_down",
-                            "question.pin",
-                            "question.unpin",
-                            "question.hide",
-                            "question.show",
-                            "answer.add",
-                            "answer.edit",
-                            "answer.edit_without_review",
-                            "answer.delete",
-                            "answer.accept",
-                            "answer.vote_up",
-                            "answer.vote_down",
-                            "answer.invite_someone_to_answer",
-                            "comment.add",
-                            "comment.edit",
-                            "comment.delete",
-                            "comment.vote_up",
-                            "comment.vote_down",
-                            "report.add",
-                            "tag.add",
-                            "tag.edit",
-                            "tag.edit_slug_name",
-                            "tag.edit_without_review",
-                            "tag.delete",
-                            "tag.synonym",
-                            "link.url_limit",
-                            "vote.detail",
-                            "answer.audit",
-                            "question.audit",
-                            "tag.audit",
-                            "tag.use_reserved_tag"
+                            "suspended",
+                            "deleted",
+                            "inactive"
                         ],
                         "type": "string",
-                        "description": "permission key",
-                        "name": "action",
-                        "in": "query",
-                        "required": true
+                        "description": "user status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -3892,10 +3764,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "boolean"
-                                            }
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "records": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetUserPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -3905,14 +3789,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/personal/answer/page": {
-            "get": {
+        "/answer/admin/api/webhook": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "list personal answers",
+                "description": "update webhook, the secret is kept when it is empty",
                 "consumes": [
                     "application/json"
                 ],
@@ -3920,44 +3804,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Personal"
+                    "admin"
                 ],
-                "summary": "list personal answers",
+                "summary": "update webhook",
                 "parameters": [
                     {
-                        "type": "string",
-                        "default": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "newest",
-                            "score"
-                        ],
-                        "type": "string",
-                        "description": "order",
-                        "name": "order",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "0",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "20",
-                        "description": "page_size",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
+                        "description": "webhook",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateWebhookReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -3968,16 +3826,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/personal/collection/page": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "list personal collections",
+                "description": "add webhook, the secret is generated when it is empty and it is only returned here",
                 "consumes": [
                     "application/json"
                 ],
@@ -3985,66 +3841,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Collection"
+                    "admin"
                 ],
-                "summary": "list personal collections",
+                "summary": "add webhook",
                 "parameters": [
                     {
-                        "type": "string",
-                        "default": "0",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "20",
-                        "description": "page_size",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                        "description": "webhook",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "$ref": "#/definitions/schema.AddWebhookReq"
                         }
                     }
-                }
-            }
-        },
-        "/answer/api/v1/personal/comment/page": {
-            "get": {
-                "description": "user personal comment list",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Comment"
-                ],
-                "summary": "user personal comment list",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "query"
-                    }
                 ],
                 "responses": {
                     "200": {
@@ -4058,22 +3867,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetCommentPersonalWithPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.AddWebhookResp"
                                         }
                                     }
                                 }
@@ -4081,11 +3875,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/personal/qa/top": {
-            "get": {
-                "description": "UserTop",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete webhook",
                 "consumes": [
                     "application/json"
                 ],
@@ -4093,17 +3890,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "admin"
                 ],
-                "summary": "UserTop",
+                "summary": "delete webhook",
                 "parameters": [
                     {
-                        "type": "string",
-                        "default": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
+                        "description": "webhook",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.DeleteWebhookReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -4116,16 +3914,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/personal/rank/page": {
+        "/answer/admin/api/webhook/deliveries/page": {
             "get": {
-                "description": "user personal rank list",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get the delivery log of the webhook, every retry is logged",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Rank"
+                    "admin"
                 ],
-                "summary": "user personal rank list",
+                "summary": "get the delivery log of the webhook",
                 "parameters": [
                     {
                         "type": "integer",
@@ -4141,9 +3944,10 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "query"
+                        "description": "webhook id",
+                        "name": "webhook_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -4168,7 +3972,7 @@ const docTemplate = `{
                                                         "list": {
                                                             "type": "array",
                                                             "items": {
-                                                                "$ref": "#/definitions/schema.GetRankPersonalPageResp"
+                                                                "$ref": "#/definitions/schema.WebhookDeliveryItem"
                                                             }
                                                         }
                                                     }
@@ -4183,14 +3987,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/personal/user/info": {
-            "get": {
+        "/answer/admin/api/webhook/test": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "GetOtherUserInfoByUsername",
+                "description": "send a ping event to the webhook and return the delivery result",
                 "consumes": [
                     "application/json"
                 ],
@@ -4198,18 +4002,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "admin"
                 ],
-                "summary": "GetOtherUserInfoByUsername",
+                "summary": "send a ping event to the webhook",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
+                        "description": "webhook",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.TestWebhookReq"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4222,7 +4028,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetOtherUserInfoResp"
+                                            "$ref": "#/definitions/schema.WebhookDeliveryItem"
                                         }
                                     }
                                 }
@@ -4232,38 +4038,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/personal/vote/page": {
+        "/answer/admin/api/webhooks": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get user personal votes",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get webhook list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Activity"
-                ],
-                "summary": "get user personal votes",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "get webhook list",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4276,22 +4065,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetVoteWithPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.WebhookItem"
+                                            }
                                         }
                                     }
                                 }
@@ -4301,19 +4078,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/plugin/status": {
+        "/answer/api/v1/activity/timeline": {
             "get": {
-                "description": "get all plugins status",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get object timeline",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Plugin"
+                    "Comment"
+                ],
+                "summary": "get object timeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "object id",
+                        "name": "object_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "tag slug name",
+                        "name": "tag_slug_name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "question",
+                            "answer",
+                            "tag"
+                        ],
+                        "type": "string",
+                        "description": "object type",
+                        "name": "object_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "is show vote",
+                        "name": "show_vote",
+                        "in": "query"
+                    }
                 ],
-                "summary": "get all plugins status",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4326,10 +4131,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetPluginListResp"
-                                            }
+                                            "$ref": "#/definitions/schema.GetObjectTimelineResp"
                                         }
                                     }
                                 }
@@ -4339,53 +4141,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/post/render": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "render post content",
-                "consumes": [
-                    "application/json"
-                ],
+        "/answer/api/v1/activity/timeline/detail": {
+            "get": {
+                "description": "get object timeline detail",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Upload"
+                    "Comment"
                 ],
-                "summary": "render post content",
+                "summary": "get object timeline detail",
                 "parameters": [
                     {
-                        "description": "PostRenderReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.PostRenderReq"
-                        }
+                        "type": "string",
+                        "description": "revision id",
+                        "name": "revision_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetObjectTimelineResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/question": {
+        "/answer/api/v1/answer": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update question",
+                "description": "Update Answer",
                 "consumes": [
                     "application/json"
                 ],
@@ -4393,17 +4197,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "update question",
+                "summary": "Update Answer",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "AnswerUpdateReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.QuestionUpdate"
+                            "$ref": "#/definitions/schema.AnswerUpdateReq"
                         }
                     }
                 ],
@@ -4422,7 +4226,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add question",
+                "description": "add answer",
                 "consumes": [
                     "application/json"
                 ],
@@ -4430,17 +4234,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "add question",
+                "summary": "Add Answer",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "add answer request",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.QuestionAdd"
+                            "$ref": "#/definitions/schema.AnswerAddReq"
                         }
                     }
                 ],
@@ -4459,7 +4263,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "delete question",
+                "description": "delete answer",
                 "consumes": [
                     "application/json"
                 ],
@@ -4467,17 +4271,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "delete question",
+                "summary": "delete answer",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "answer",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.RemoveQuestionReq"
+                            "$ref": "#/definitions/schema.RemoveAnswerReq"
                         }
                     }
                 ],
@@ -4491,14 +4295,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question/answer": {
+        "/answer/api/v1/answer/acceptance": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add question and answer",
+                "description": "Accept Answer",
                 "consumes": [
                     "application/json"
                 ],
@@ -4506,17 +4310,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "add question and answer",
+                "summary": "Accept Answer",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "AcceptAnswerReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.QuestionAddByAnswer"
+                            "$ref": "#/definitions/schema.AcceptAnswerReq"
                         }
                     }
                 ],
@@ -4530,9 +4334,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question/info": {
+        "/answer/api/v1/answer/info": {
             "get": {
-                "description": "get question details",
+                "description": "Get Answer Detail",
                 "consumes": [
                     "application/json"
                 ],
@@ -4540,14 +4344,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "get question details",
+                "summary": "Get Answer Detail",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "1",
-                        "description": "Question TagID",
+                        "description": "id",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -4557,15 +4360,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetAnswerInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/question/invite": {
+        "/answer/api/v1/answer/page": {
             "get": {
-                "description": "get question invite user info",
+                "description": "AnswerList \u003cbr\u003e \u003cb\u003eorder\u003c/b\u003e (default or updated)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4573,15 +4388,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "get question invite user info",
+                "summary": "AnswerList",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "1",
-                        "description": "Question ID",
-                        "name": "id",
+                        "description": "question_id",
+                        "name": "question_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "order",
+                        "name": "order",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "page_size",
+                        "name": "page_size",
                         "in": "query",
                         "required": true
                     }
@@ -4594,14 +4429,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/answer/api/v1/answer/recover": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update question invite user",
+                "description": "recover the deleted answer",
                 "consumes": [
                     "application/json"
                 ],
@@ -4609,17 +4446,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "update question invite user",
+                "summary": "recover answer",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "answer",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.QuestionUpdateInviteUser"
+                            "$ref": "#/definitions/schema.RecoverAnswerReq"
                         }
                     }
                 ],
@@ -4633,99 +4470,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question/link": {
-            "get": {
-                "description": "get question link",
-                "tags": [
-                    "Question"
-                ],
-                "summary": "get question link",
-                "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "name": "in_days",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "newest",
-                            "active",
-                            "hot",
-                            "score",
-                            "unanswered",
-                            "recommend",
-                            "frequent"
-                        ],
-                        "type": "string",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "name": "question_id",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.QuestionPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/question/operation": {
+        "/answer/api/v1/answer/rollback": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Operation question \\n operation [pin unpin hide show]",
+                "description": "rollback answer to an old revision, a new revision is created with the content of the old one",
                 "consumes": [
                     "application/json"
                 ],
@@ -4733,17 +4485,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Answer"
                 ],
-                "summary": "Operation question",
+                "summary": "rollback answer to an old revision",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "revision",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.OperationQuestionReq"
+                            "$ref": "#/definitions/schema.RollbackRevisionReq"
                         }
                     }
                 ],
@@ -4757,9 +4509,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question/page": {
+        "/answer/api/v1/badge": {
             "get": {
-                "description": "get questions by page",
+                "description": "get badge info",
                 "consumes": [
                     "application/json"
                 ],
@@ -4767,18 +4519,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "api-badge"
                 ],
-                "summary": "get questions by page",
+                "summary": "get badge info",
                 "parameters": [
                     {
-                        "description": "QuestionPageReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.QuestionPageReq"
-                        }
+                        "type": "string",
+                        "default": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -4793,22 +4544,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.QuestionPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.GetBadgeInfoResp"
                                         }
                                     }
                                 }
@@ -4818,9 +4554,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question/recommend/page": {
+        "/answer/api/v1/badge/awards/page": {
             "get": {
-                "description": "get recommend questions by page",
+                "description": "get badge award list",
                 "consumes": [
                     "application/json"
                 ],
@@ -4828,18 +4564,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "api-badge"
                 ],
-                "summary": "get recommend questions by page",
+                "summary": "get badge award list",
                 "parameters": [
                     {
-                        "description": "QuestionPageReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.QuestionPageReq"
-                        }
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "badge id",
+                        "name": "badge_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only list the award by username",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -4854,22 +4606,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.QuestionPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.GetBadgeInfoResp"
                                         }
                                     }
                                 }
@@ -4879,14 +4616,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/question/recover": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "recover deleted question",
+        "/answer/api/v1/badge/user/awards": {
+            "get": {
+                "description": "get user badge award list",
                 "consumes": [
                     "application/json"
                 ],
@@ -4894,38 +4626,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "api-badge"
                 ],
-                "summary": "recover deleted question",
+                "summary": "get user badge award list",
                 "parameters": [
                     {
-                        "description": "question",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.QuestionRecoverReq"
-                        }
+                        "type": "string",
+                        "description": "user name",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetUserBadgeAwardListResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/question/reopen": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "reopen question",
+        "/answer/api/v1/badge/user/awards/recent": {
+            "get": {
+                "description": "get user badge award list",
                 "consumes": [
                     "application/json"
                 ],
@@ -4933,54 +4673,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "api-badge"
                 ],
-                "summary": "reopen question",
-                "parameters": [
-                    {
-                        "description": "question",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.ReopenQuestionReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/question/similar": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "fuzzy query similar questions based on title",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Question"
-                ],
-                "summary": "fuzzy query similar questions based on title",
+                "summary": "get user badge award list",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "string",
-                        "description": "title",
-                        "name": "title",
+                        "description": "user name",
+                        "name": "username",
                         "in": "query",
                         "required": true
                     }
@@ -4989,15 +4689,30 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetUserBadgeAwardListResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/question/similar/tag": {
+        "/answer/api/v1/badges": {
             "get": {
-                "description": "Search Similar Question",
+                "description": "list all badges group by group",
                 "consumes": [
                     "application/json"
                 ],
@@ -5005,37 +4720,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
-                ],
-                "summary": "Search Similar Question",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "default": "",
-                        "description": "question_id",
-                        "name": "question_id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "api-badge"
                 ],
+                "summary": "list all badges group by group",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetBadgeListResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/question/status": {
-            "put": {
+        "/answer/api/v1/collection/switch": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Close question",
+                "description": "add collection",
                 "consumes": [
                     "application/json"
                 ],
@@ -5043,17 +4763,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Question"
+                    "Collection"
                 ],
-                "summary": "Close question",
+                "summary": "add collection",
                 "parameters": [
                     {
-                        "description": "question",
+                        "description": "collection",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.CloseQuestionReq"
+                            "$ref": "#/definitions/schema.CollectionSwitchReq"
                         }
                     }
                 ],
@@ -5061,33 +4781,41 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.CollectionSwitchResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/question/tags": {
+        "/answer/api/v1/comment": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get tag list",
+                "description": "get comment by id",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Comment"
                 ],
-                "summary": "get tag list",
+                "summary": "get comment by id",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "tag",
-                        "name": "tag",
-                        "in": "query"
+                        "description": "id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -5102,10 +4830,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetTagBasicResp"
-                                            }
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetCommentResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -5113,16 +4853,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/reasons": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get reasons by object type and action",
+                "description": "update comment",
                 "consumes": [
                     "application/json"
                 ],
@@ -5130,35 +4868,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reason"
+                    "Comment"
                 ],
-                "summary": "get reasons by object type and action",
+                "summary": "update comment",
                 "parameters": [
                     {
-                        "enum": [
-                            "question",
-                            "answer",
-                            "comment",
-                            "user"
-                        ],
-                        "type": "string",
-                        "description": "object_type",
-                        "name": "object_type",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "status",
-                            "close",
-                            "flag",
-                            "review"
-                        ],
-                        "type": "string",
-                        "description": "action",
-                        "name": "action",
-                        "in": "query",
-                        "required": true
+                        "description": "comment",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateCommentReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -5169,11 +4890,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/render/config": {
-            "get": {
-                "description": "GetRenderConfig",
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add comment",
                 "consumes": [
                     "application/json"
                 ],
@@ -5181,9 +4905,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PluginRender"
+                    "Comment"
+                ],
+                "summary": "add comment",
+                "parameters": [
+                    {
+                        "description": "comment",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddCommentReq"
+                        }
+                    }
                 ],
-                "summary": "GetRenderConfig",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -5196,7 +4931,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/plugin.RenderConfig"
+                                            "$ref": "#/definitions/schema.GetCommentResp"
                                         }
                                     }
                                 }
@@ -5204,16 +4939,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/report": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add report \u003cbr\u003e source (question, answer, comment, user)",
+                "description": "remove comment",
                 "consumes": [
                     "application/json"
                 ],
@@ -5221,17 +4954,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Report"
+                    "Comment"
                 ],
-                "summary": "add report",
+                "summary": "remove comment",
                 "parameters": [
                     {
-                        "description": "report",
+                        "description": "comment",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.AddReportReq"
+                            "$ref": "#/definitions/schema.RemoveCommentReq"
                         }
                     }
                 ],
@@ -5245,69 +4978,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/report/review": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "review report",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Report"
-                ],
-                "summary": "review report",
-                "parameters": [
-                    {
-                        "description": "flag",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.ReviewReportReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/report/unreviewed/post": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get unreviewed report post page",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+        "/answer/api/v1/comment/page": {
+            "get": {
+                "description": "get comment page",
+                "produces": [
+                    "application/json"
                 ],
                 "tags": [
-                    "Report"
+                    "Comment"
                 ],
-                "summary": "get unreviewed report post page",
+                "summary": "get comment page",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "page",
                         "name": "page",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "object id",
+                        "name": "object_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "vote"
+                        ],
+                        "type": "string",
+                        "description": "query condition",
+                        "name": "query_cond",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -5332,7 +5040,7 @@ const docTemplate = `{
                                                         "list": {
                                                             "type": "array",
                                                             "items": {
-                                                                "$ref": "#/definitions/schema.GetReportListPageResp"
+                                                                "$ref": "#/definitions/schema.GetCommentResp"
                                                             }
                                                         }
                                                     }
@@ -5347,14 +5055,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/review/pending/post": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "update review",
+        "/answer/api/v1/connector/binding/email": {
+            "post": {
+                "description": "external login binding user send email",
                 "consumes": [
                     "application/json"
                 ],
@@ -5362,61 +5065,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Review"
+                    "PluginConnector"
                 ],
-                "summary": "update review",
+                "summary": "external login binding user send email",
                 "parameters": [
                     {
-                        "description": "review",
+                        "description": "external login binding user send email",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateReviewReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "$ref": "#/definitions/schema.ExternalLoginBindingUserSendEmailReq"
                         }
                     }
-                }
-            }
-        },
-        "/answer/api/v1/review/pending/post/page": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get unreviewed post page",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Review"
-                ],
-                "summary": "get unreviewed post page",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "object_id",
-                        "name": "object_id",
-                        "in": "query"
-                    }
                 ],
                 "responses": {
                     "200": {
@@ -5430,22 +5091,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetUnreviewedPostPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.ExternalLoginBindingUserSendEmailResp"
                                         }
                                     }
                                 }
@@ -5455,21 +5101,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/reviewing/type": {
+        "/answer/api/v1/connector/info": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get reviewing type",
+                "description": "get all enabled connectors",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Revision"
+                    "PluginConnector"
                 ],
-                "summary": "get reviewing type",
+                "summary": "get all enabled connectors",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -5484,7 +5130,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/schema.GetReviewingTypeResp"
+                                                "$ref": "#/definitions/schema.ConnectorInfoResp"
                                             }
                                         }
                                     }
@@ -5495,25 +5141,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/revisions": {
+        "/answer/api/v1/connector/user/info": {
             "get": {
-                "description": "get revision list",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get all connectors info about user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Revision"
-                ],
-                "summary": "get revision list",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "object id",
-                        "name": "object_id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "PluginConnector"
                 ],
+                "summary": "get all connectors info about user",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -5528,7 +5170,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/schema.GetRevisionResp"
+                                                "$ref": "#/definitions/schema.ConnectorUserInfoResp"
                                             }
                                         }
                                     }
@@ -5539,29 +5181,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/revisions/audit": {
-            "put": {
+        "/answer/api/v1/connector/user/unbinding": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "revision audit operation:approve or reject",
+                "description": "unbind external user login",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Revision"
+                    "PluginConnector"
                 ],
-                "summary": "revision audit",
+                "summary": "unbind external user login",
                 "parameters": [
                     {
-                        "description": "audit",
+                        "description": "ExternalLoginUnbindingReq",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.RevisionAuditReq"
+                            "$ref": "#/definitions/schema.ExternalLoginUnbindingReq"
                         }
                     }
                 ],
@@ -5575,66 +5220,83 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/revisions/edit/check": {
+        "/answer/api/v1/draft": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "check can update revision",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get the draft of a question, answer or edit, the data is null if there is no draft",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Revision"
+                    "Draft"
                 ],
-                "summary": "check can update revision",
+                "summary": "get the draft of a question, answer or edit",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "string",
-                        "description": "id",
-                        "name": "id",
+                        "description": "question, answer or edit",
+                        "name": "type",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the question id of the answer, the post id of the edit",
+                        "name": "object_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.DraftItem"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/revisions/unreviewed": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get unreviewed revision list",
+                "description": "save the draft automatically while the user is writing, there is one draft for each question,\nanswer or edit. Check conflict in the response, it is true if others have edited the post\nsince the edit draft started, save with rebase after the user resolves it.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Revision"
+                    "Draft"
                 ],
-                "summary": "get unreviewed revision list",
+                "summary": "save the draft of a question, answer or edit",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "page id",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
+                        "description": "draft",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.SaveDraftReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -5649,22 +5311,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetUnreviewedRevisionResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.DraftItem"
                                         }
                                     }
                                 }
@@ -5672,109 +5319,103 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/search": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "search object",
+                "description": "discard the draft of a question, answer or edit",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Search"
+                    "Draft"
                 ],
-                "summary": "search object",
+                "summary": "discard the draft of a question, answer or edit",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "query string",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "newest",
-                            "active",
-                            "score",
-                            "relevance"
-                        ],
-                        "type": "string",
-                        "description": "order",
-                        "name": "order",
-                        "in": "query",
-                        "required": true
+                        "description": "draft",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RemoveDraftReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.SearchResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/search/desc": {
-            "get": {
-                "description": "get search description",
+        "/answer/api/v1/email/inbound": {
+            "post": {
+                "description": "receive the reply of the notification email from the mail server or the inbound webhook of\nthe mail provider, the reply is posted as a comment or an answer. The body is the raw email,\nor a form with the raw email in the field \"email\" or \"body-mime\". The mail gateway has to send\nthe inbound secret configured in the smtp settings in the header X-Answer-Inbound-Secret or the query.",
+                "consumes": [
+                    "message/rfc822",
+                    "multipart/form-data",
+                    "application/x-www-form-urlencoded"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Search"
+                    "EmailReply"
+                ],
+                "summary": "receive the reply of the notification email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "the inbound secret",
+                        "name": "X-Answer-Inbound-Secret",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "the inbound secret",
+                        "name": "secret",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "the address the email is delivered to",
+                        "name": "recipient",
+                        "in": "query"
+                    }
                 ],
-                "summary": "get search description",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.SearchResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/siteinfo": {
+        "/answer/api/v1/embed/config": {
             "get": {
-                "description": "get site info",
+                "description": "get embed plugin config",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "site"
+                    "Plugin"
                 ],
-                "summary": "get site info",
+                "summary": "get embed plugin config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -5787,7 +5428,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.SiteInfoResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/plugin.EmbedConfig"
+                                            }
                                         }
                                     }
                                 }
@@ -5797,26 +5441,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/siteinfo/legal": {
-            "get": {
-                "description": "get site legal info",
-                "produces": [
-                    "application/json"
+        "/answer/api/v1/file": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "upload file",
+                "consumes": [
+                    "multipart/form-data"
                 ],
                 "tags": [
-                    "site"
+                    "Upload"
                 ],
-                "summary": "get site legal info",
+                "summary": "upload file",
                 "parameters": [
                     {
                         "enum": [
-                            "tos",
-                            "privacy"
+                            "post",
+                            "post_attachment",
+                            "avatar",
+                            "branding"
                         ],
                         "type": "string",
-                        "description": "legal information type",
-                        "name": "info_type",
-                        "in": "query",
+                        "description": "identify the source of the file upload",
+                        "name": "source",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "file",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
@@ -5832,7 +5490,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetSiteLegalInfoResp"
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -5842,9 +5500,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/tag": {
-            "get": {
-                "description": "get tag one",
+        "/answer/api/v1/follow": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "follow object or cancel follow operation",
                 "consumes": [
                     "application/json"
                 ],
@@ -5852,23 +5515,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Activity"
                 ],
-                "summary": "get tag one",
+                "summary": "follow object or cancel follow operation",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "tag id",
-                        "name": "tag_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "tag name",
-                        "name": "tag_name",
-                        "in": "query",
-                        "required": true
+                        "description": "follow",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.FollowReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -5883,7 +5541,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetTagResp"
+                                            "$ref": "#/definitions/schema.FollowResp"
                                         }
                                     }
                                 }
@@ -5891,14 +5549,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/answer/api/v1/follow/tags": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update tag",
+                "description": "update user follow tags",
                 "consumes": [
                     "application/json"
                 ],
@@ -5906,17 +5566,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Activity"
                 ],
-                "summary": "update tag",
+                "summary": "update user follow tags",
                 "parameters": [
                     {
-                        "description": "tag",
+                        "description": "follow",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateTagReq"
+                            "$ref": "#/definitions/schema.UpdateFollowTagsReq"
                         }
                     }
                 ],
@@ -5928,14 +5588,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "add tag",
+            }
+        },
+        "/answer/api/v1/hierarchical-tags": {
+            "get": {
+                "description": "Get hierarchical tags by parent ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -5943,36 +5600,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "HierarchicalTag"
                 ],
-                "summary": "add tag",
+                "summary": "Get hierarchical tags",
                 "parameters": [
                     {
-                        "description": "tag",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.AddTagReq"
-                        }
+                        "type": "string",
+                        "description": "parent tag ID",
+                        "name": "parent_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetHierarchicalTagsResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             },
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "delete tag",
+            "put": {
+                "description": "Update an existing hierarchical tag",
                 "consumes": [
                     "application/json"
                 ],
@@ -5980,56 +5641,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "HierarchicalTag"
                 ],
-                "summary": "delete tag",
-                "parameters": [
-                    {
-                        "description": "tag",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.RemoveTagReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/tag/merge": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "merge tag",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Tag"
-                ],
-                "summary": "merge tag",
+                "summary": "Update hierarchical tag",
                 "parameters": [
                     {
-                        "description": "tag",
+                        "description": "hierarchical tag data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.AddTagReq"
+                            "$ref": "#/definitions/schema.UpdateHierarchicalTagReq"
                         }
                     }
                 ],
@@ -6041,16 +5663,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/tag/recover": {
+            },
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "recover delete tag",
+                "description": "Create a new hierarchical tag",
                 "consumes": [
                     "application/json"
                 ],
@@ -6058,17 +5673,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "HierarchicalTag"
                 ],
-                "summary": "recover delete tag",
+                "summary": "Create hierarchical tag",
                 "parameters": [
                     {
-                        "description": "tag",
+                        "description": "hierarchical tag data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.RecoverTagReq"
+                            "$ref": "#/definitions/schema.CreateHierarchicalTagReq"
                         }
                     }
                 ],
@@ -6082,14 +5697,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/tag/synonym": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "update tag",
+        "/answer/api/v1/hierarchical-tags/path": {
+            "get": {
+                "description": "Get the full path of a hierarchical tag",
                 "consumes": [
                     "application/json"
                 ],
@@ -6097,49 +5707,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "HierarchicalTag"
                 ],
-                "summary": "update tag",
+                "summary": "Get hierarchical tag path",
                 "parameters": [
                     {
-                        "description": "tag",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UpdateTagSynonymReq"
-                        }
+                        "type": "string",
+                        "description": "tag ID",
+                        "name": "tag_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.HierarchicalTagPathResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/tag/synonyms": {
+        "/answer/api/v1/hierarchical-tags/test": {
             "get": {
-                "description": "get tag synonyms",
+                "description": "Simple test endpoint to verify hierarchical tag system is working",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
-                ],
-                "summary": "get tag synonyms",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "tag id",
-                        "name": "tag_id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "HierarchicalTag"
                 ],
+                "summary": "Test hierarchical tag endpoint",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -6152,7 +5763,8 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetTagSynonymsResp"
+                                            "type": "object",
+                                            "additionalProperties": true
                                         }
                                     }
                                 }
@@ -6162,28 +5774,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/tags": {
+        "/answer/api/v1/hierarchical-tags/tree": {
             "get": {
-                "description": "get tags list by slug name",
+                "description": "Get the whole hierarchical tag tree",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
-                ],
-                "summary": "get tags list",
-                "parameters": [
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "string collection",
-                        "name": "tags",
-                        "in": "query"
-                    }
+                    "HierarchicalTag"
                 ],
+                "summary": "Get hierarchical tag tree",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -6198,7 +5798,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/schema.GetTagBasicResp"
+                                                "$ref": "#/definitions/entity.HierarchicalTagWithChildren"
                                             }
                                         }
                                     }
@@ -6209,85 +5809,75 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/tags/following": {
+        "/answer/api/v1/language/config": {
             "get": {
-                "security": [
+                "description": "get language config mapping",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lang"
+                ],
+                "summary": "get language config mapping",
+                "parameters": [
                     {
-                        "ApiKeyAuth": []
+                        "type": "string",
+                        "description": "Accept-Language",
+                        "name": "Accept-Language",
+                        "in": "header",
+                        "required": true
                     }
                 ],
-                "description": "get following tag list",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/language/options": {
+            "get": {
+                "description": "Get language options",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Lang"
                 ],
-                "summary": "get following tag list",
+                "summary": "Get language options",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.GetFollowingTagsResp"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/tags/page": {
+        "/answer/api/v1/meta/reaction": {
             "get": {
-                "description": "get tag page",
+                "description": "get reaction for an object",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tag"
+                    "Meta"
                 ],
-                "summary": "get tag page",
+                "summary": "get reaction",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "slug_name",
-                        "name": "slug_name",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "popular",
-                            "name",
-                            "newest"
-                        ],
                         "type": "string",
-                        "description": "query condition",
-                        "name": "query_cond",
-                        "in": "query"
+                        "description": "object_id",
+                        "name": "object_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -6302,22 +5892,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "allOf": [
-                                                {
-                                                    "$ref": "#/definitions/pager.PageModel"
-                                                },
-                                                {
-                                                    "type": "object",
-                                                    "properties": {
-                                                        "list": {
-                                                            "type": "array",
-                                                            "items": {
-                                                                "$ref": "#/definitions/schema.GetTagPageResp"
-                                                            }
-                                                        }
-                                                    }
-                                                }
-                                            ]
+                                            "$ref": "#/definitions/schema.ReactionRespItem"
                                         }
                                     }
                                 }
@@ -6325,64 +5900,53 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/user/action/record": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "ActionRecord",
+                "description": "update reaction. if not exist, add one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "User"
+                    "Meta"
                 ],
-                "summary": "ActionRecord",
+                "summary": "add or update reaction",
                 "parameters": [
                     {
-                        "enum": [
-                            "login",
-                            "e_mail",
-                            "find_pass"
-                        ],
-                        "type": "string",
-                        "description": "action",
-                        "name": "action",
-                        "in": "query",
-                        "required": true
+                        "description": "reaction",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateReactionReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.ActionRecordResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/email": {
-            "put": {
+        "/answer/api/v1/moderation/claim": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "user change email verification",
+                "description": "claim the item so that other moderators can not handle it, the claim expires in 15 minutes.\nClaim it again to renew the claim.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6390,17 +5954,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Moderation"
                 ],
-                "summary": "user change email verification",
+                "summary": "claim moderation item",
                 "parameters": [
                     {
-                        "description": "UserChangeEmailVerifyReq",
+                        "description": "item",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UserChangeEmailVerifyReq"
+                            "$ref": "#/definitions/schema.ModerationClaimReq"
                         }
                     }
                 ],
@@ -6408,20 +5972,30 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.ModerationClaimResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/user/email/change/code": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "send email to the user email then change their email",
+                "description": "release the claim of the item, it is released automatically once the item is handled",
                 "consumes": [
                     "application/json"
                 ],
@@ -6429,17 +6003,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Moderation"
                 ],
-                "summary": "send email to the user email then change their email",
+                "summary": "release moderation item",
                 "parameters": [
                     {
-                        "description": "UserChangeEmailSendCodeReq",
+                        "description": "item",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UserChangeEmailSendCodeReq"
+                            "$ref": "#/definitions/schema.ModerationClaimReq"
                         }
                     }
                 ],
@@ -6453,9 +6027,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/user/email/verification": {
-            "post": {
-                "description": "UserVerifyEmail",
+        "/answer/api/v1/moderation/items": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "approve, reject or delete the items. Approving a report ignores it and keeps the post,\nrejecting or deleting a report deletes the post. Deleting a revision rejects it.\nThe result of each item is returned, the items claimed by others are skipped.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6463,17 +6042,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Moderation"
                 ],
-                "summary": "UserVerifyEmail",
+                "summary": "moderate items in bulk",
                 "parameters": [
                     {
-                        "type": "string",
-                        "default": "",
-                        "description": "code",
-                        "name": "code",
-                        "in": "query",
-                        "required": true
+                        "description": "items",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ModerateItemsReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -6488,7 +6068,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.UserLoginResp"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.ModerateItemResult"
+                                            }
                                         }
                                     }
                                 }
@@ -6498,37 +6081,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/user/email/verification/send": {
-            "post": {
+        "/answer/api/v1/moderation/logs": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "UserVerifyEmailSend",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get the actions of the moderators with the status before and after, the latest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Moderation"
                 ],
-                "summary": "UserVerifyEmailSend",
+                "summary": "get moderation log page",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "",
-                        "description": "captcha_id",
-                        "name": "captcha_id",
+                        "description": "the moderator who took the action",
+                        "name": "operator_id",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "default": "",
-                        "description": "captcha_code",
-                        "name": "captcha_code",
+                        "description": "report, review or revision",
+                        "name": "item_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "object id",
+                        "name": "object_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
                         "in": "query"
                     }
                 ],
@@ -6536,30 +6132,92 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.ModerationLogResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/info": {
+        "/answer/api/v1/moderation/queue": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get user info, if user no login response http code is 200, but user info is null",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get the pending reports, the posts waiting for review and the unreviewed revisions in one queue,\nthe oldest first. Each item shows the moderator who claims it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Moderation"
+                ],
+                "summary": "get moderation queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "report, review or revision",
+                        "name": "item_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "the slug name of the tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the items submitted at least the hours ago",
+                        "name": "min_age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "only the items submitted at most the hours ago",
+                        "name": "max_age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "GetUserInfoByUserID",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -6572,7 +6230,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetCurrentLoginUserInfoResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.ModerationQueueItem"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -6580,14 +6253,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/answer/api/v1/notification/page": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "UserUpdateInfo update user info",
+                "description": "get notification list",
                 "consumes": [
                     "application/json"
                 ],
@@ -6595,60 +6270,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Notification"
                 ],
-                "summary": "UserUpdateInfo update user info",
+                "summary": "get notification list",
                 "parameters": [
                     {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "inbox",
+                            "achievement"
+                        ],
                         "type": "string",
-                        "description": "access-token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "type",
+                        "name": "type",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "UpdateInfoRequest",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UpdateInfoRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/user/info/search": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "SearchUserListByName",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "SearchUserListByName",
-                "parameters": [
-                    {
+                        "enum": [
+                            "all",
+                            "posts",
+                            "invites",
+                            "votes"
+                        ],
                         "type": "string",
-                        "description": "username",
-                        "name": "username",
+                        "description": "inbox_type",
+                        "name": "inbox_type",
                         "in": "query",
                         "required": true
                     }
@@ -6657,32 +6315,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.GetOtherUserInfoResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/interface": {
+        "/answer/api/v1/notification/read/state": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "UserUpdateInterface update user interface config",
+                "description": "ClearUnRead",
                 "consumes": [
                     "application/json"
                 ],
@@ -6690,24 +6336,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Notification"
                 ],
-                "summary": "UserUpdateInterface update user interface config",
+                "summary": "ClearUnRead",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "access-token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "UpdateInfoRequest",
+                        "description": "NotificationClearIDRequest",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateUserInterfaceRequest"
+                            "$ref": "#/definitions/schema.NotificationClearIDRequest"
                         }
                     }
                 ],
@@ -6721,9 +6360,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/user/login/email": {
-            "post": {
-                "description": "UserEmailLogin",
+        "/answer/api/v1/notification/read/state/all": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ClearUnRead",
                 "consumes": [
                     "application/json"
                 ],
@@ -6731,17 +6375,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Notification"
                 ],
-                "summary": "UserEmailLogin",
+                "summary": "ClearUnRead",
                 "parameters": [
                     {
-                        "description": "UserEmailLogin",
+                        "description": "NotificationClearRequest",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UserEmailLoginReq"
+                            "$ref": "#/definitions/schema.NotificationClearRequest"
                         }
                     }
                 ],
@@ -6749,32 +6393,20 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.UserLoginResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/logout": {
+        "/answer/api/v1/notification/status": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "user logout",
+                "description": "GetRedDot",
                 "consumes": [
                     "application/json"
                 ],
@@ -6782,9 +6414,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Notification"
                 ],
-                "summary": "user logout",
+                "summary": "GetRedDot",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -6793,16 +6425,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/user/notification/config": {
+            },
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user's notification config",
+                "description": "DelRedDot",
                 "consumes": [
                     "application/json"
                 ],
@@ -6810,17 +6440,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Notification"
                 ],
-                "summary": "update user's notification config",
+                "summary": "DelRedDot",
                 "parameters": [
                     {
-                        "description": "UpdateUserNotificationConfigReq",
+                        "description": "NotificationClearRequest",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateUserNotificationConfigReq"
+                            "$ref": "#/definitions/schema.NotificationClearRequest"
                         }
                     }
                 ],
@@ -6832,24 +6462,72 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/answer/api/v1/oauth/authorize": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get user's notification config",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "check the authorization request sent by the oauth client and get what the consent page shows.\nIf redirect_url is returned, the request is invalid and the user should be sent to it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "OAuth"
+                ],
+                "summary": "check the authorization request for the consent page",
+                "parameters": [
+                    {
+                        "maxLength": 64,
+                        "type": "string",
+                        "name": "client_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maxLength": 128,
+                        "type": "string",
+                        "name": "code_challenge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "code_challenge_method",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "name": "nonce",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 1024,
+                        "type": "string",
+                        "name": "redirect_uri",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "response_type",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 255,
+                        "type": "string",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 1024,
+                        "type": "string",
+                        "name": "state",
+                        "in": "query"
+                    }
                 ],
-                "summary": "get user's notification config",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -6862,7 +6540,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetUserNotificationConfigResp"
+                                            "$ref": "#/definitions/schema.OAuthAuthorizeInfoResp"
                                         }
                                     }
                                 }
@@ -6870,50 +6548,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/answer/api/v1/user/notification/unsubscribe": {
-            "put": {
-                "description": "unsubscribe notification",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "unsubscribe notification",
-                "parameters": [
-                    {
-                        "description": "UserUnsubscribeNotificationReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UserUnsubscribeNotificationReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            }
-        },
-        "/answer/api/v1/user/password": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "UserModifyPassWord",
+                "description": "approve or deny the authorization request, send the user to the returned redirect_url\nwhich brings the authorization code or the error to the oauth client.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6921,17 +6563,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "OAuth"
                 ],
-                "summary": "UserModifyPassWord",
+                "summary": "approve or deny the authorization request",
                 "parameters": [
                     {
-                        "description": "UserModifyPasswordReq",
+                        "description": "consent",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UserModifyPasswordReq"
+                            "$ref": "#/definitions/schema.OAuthConsentReq"
                         }
                     }
                 ],
@@ -6939,49 +6581,128 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.OAuthConsentResp"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/password/replacement": {
-            "post": {
-                "description": "UseRePassWord",
-                "consumes": [
-                    "application/json"
+        "/answer/api/v1/permission": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "check user permission",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Permission"
                 ],
-                "summary": "UseRePassWord",
+                "summary": "check user permission",
                 "parameters": [
                     {
-                        "description": "UserRePassWordRequest",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UserRePassWordRequest"
-                        }
+                        "type": "string",
+                        "description": "access-token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "question.add",
+                            "question.edit",
+                            "question.edit_without_review",
+                            "question.delete",
+                            "question.close",
+                            "question.reopen",
+                            "question.vote_up",
+                            "question.vote_down",
+                            "question.pin",
+                            "question.unpin",
+                            "question.hide",
+                            "question.show",
+                            "answer.add",
+                            "answer.edit",
+                            "answer.edit_without_review",
+                            "answer.delete",
+                            "answer.accept",
+                            "answer.vote_up",
+                            "answer.vote_down",
+                            "answer.invite_someone_to_answer",
+                            "comment.add",
+                            "comment.edit",
+                            "comment.delete",
+                            "comment.vote_up",
+                            "comment.vote_down",
+                            "report.add",
+                            "tag.add",
+                            "tag.edit",
+                            "tag.edit_slug_name",
+                            "tag.edit_without_review",
+                            "tag.delete",
+                            "tag.synonym",
+                            "link.url_limit",
+                            "vote.detail",
+                            "answer.audit",
+                            "question.audit",
+                            "tag.audit",
+                            "tag.use_reserved_tag"
+                        ],
+                        "type": "string",
+                        "description": "permission key",
+                        "name": "action",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "boolean"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/password/reset": {
+        "/answer/api/v1/personal/access-token": {
             "post": {
-                "description": "RetrievePassWord",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create a personal access token, the token is only returned in this response.\nSend it in the header \"Authorization: Bearer \u003ctoken\u003e\" to call the api allowed by the scopes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -6989,53 +6710,19 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "PersonalAccessToken"
                 ],
-                "summary": "RetrievePassWord",
+                "summary": "create a personal access token",
                 "parameters": [
                     {
-                        "description": "UserRetrievePassWordRequest",
+                        "description": "personal access token",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UserRetrievePassWordRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/schema.AddPersonalAccessTokenReq"
                         }
                     }
-                }
-            }
-        },
-        "/answer/api/v1/user/plugin/config": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "get user plugin config",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "UserPlugin"
-                ],
-                "summary": "get user plugin config",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "plugin_slug_name",
-                        "name": "plugin_slug_name",
-                        "in": "query",
-                        "required": true
-                    }
                 ],
                 "responses": {
                     "200": {
@@ -7049,7 +6736,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.GetPluginConfigResp"
+                                            "$ref": "#/definitions/schema.AddPersonalAccessTokenResp"
                                         }
                                     }
                                 }
@@ -7058,13 +6745,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "update user plugin config",
+                "description": "revoke a personal access token of the login user",
                 "consumes": [
                     "application/json"
                 ],
@@ -7072,17 +6759,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "UserPlugin"
+                    "PersonalAccessToken"
                 ],
-                "summary": "update user plugin config",
+                "summary": "revoke a personal access token of the login user",
                 "parameters": [
                     {
-                        "description": "UpdatePluginConfigReq",
+                        "description": "personal access token",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdateUserPluginConfigReq"
+                            "$ref": "#/definitions/schema.RevokePersonalAccessTokenReq"
                         }
                     }
                 ],
@@ -7096,24 +6783,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/user/plugin/configs": {
+        "/answer/api/v1/personal/access-tokens": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get plugin list that used for user.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get the personal access tokens of the login user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "UserPlugin"
+                    "PersonalAccessToken"
                 ],
-                "summary": "get plugin list that used for user.",
+                "summary": "get the personal access tokens of the login user",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -7128,7 +6812,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/schema.GetUserPluginListResp"
+                                                "$ref": "#/definitions/schema.PersonalAccessTokenItem"
                                             }
                                         }
                                     }
@@ -7139,44 +6823,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/user/ranking": {
+        "/answer/api/v1/personal/answer/page": {
             "get": {
-                "description": "get user ranking",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "get user ranking",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.UserRankingResp"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
+                "security": [
+                    {
+                        "ApiKeyAuth": []
                     }
-                }
-            }
-        },
-        "/answer/api/v1/user/register/email": {
-            "post": {
-                "description": "UserRegisterByEmail",
+                ],
+                "description": "list personal answers",
                 "consumes": [
                     "application/json"
                 ],
@@ -7184,45 +6838,64 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Personal"
                 ],
-                "summary": "UserRegisterByEmail",
+                "summary": "list personal answers",
                 "parameters": [
                     {
-                        "description": "UserRegisterReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UserRegisterReq"
-                        }
+                        "type": "string",
+                        "default": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "score"
+                        ],
+                        "type": "string",
+                        "description": "order",
+                        "name": "order",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "0",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "20",
+                        "description": "page_size",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.UserLoginResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/user/staff": {
+        "/answer/api/v1/personal/collection/page": {
             "get": {
-                "description": "get user staff",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "list personal collections",
                 "consumes": [
                     "application/json"
                 ],
@@ -7230,19 +6903,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Collection"
                 ],
-                "summary": "get user staff",
+                "summary": "list personal collections",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "username",
-                        "name": "username",
+                        "default": "0",
+                        "description": "page",
+                        "name": "page",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
+                        "default": "20",
                         "description": "page_size",
                         "name": "page_size",
                         "in": "query",
@@ -7253,51 +6928,40 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handler.RespBody"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schema.GetUserStaffResp"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
             }
         },
-        "/answer/api/v1/vote/down": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "add vote",
-                "consumes": [
-                    "application/json"
-                ],
+        "/answer/api/v1/personal/comment/page": {
+            "get": {
+                "description": "user personal comment list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Activity"
+                    "Comment"
                 ],
-                "summary": "vote down",
+                "summary": "user personal comment list",
                 "parameters": [
                     {
-                        "description": "vote",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.VoteReq"
-                        }
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -7312,7 +6976,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.VoteResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetCommentPersonalWithPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -7322,33 +7001,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/answer/api/v1/vote/up": {
-            "post": {
+        "/answer/api/v1/personal/drafts/page": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "add vote",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "get the drafts of the login user, the latest saved first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Activity"
+                    "Draft"
                 ],
-                "summary": "vote up",
+                "summary": "get the drafts of the login user",
                 "parameters": [
                     {
-                        "description": "vote",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.VoteReq"
-                        }
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -7363,7 +7042,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schema.VoteResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.DraftItem"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -7373,29 +7067,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/custom.css": {
+        "/answer/api/v1/personal/qa/top": {
             "get": {
-                "description": "get site custom CSS",
-                "produces": [
-                    "text/css"
-                ],
-                "tags": [
-                    "site"
-                ],
-                "summary": "get site custom CSS",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/installation/base-info": {
-            "post": {
-                "description": "init base info",
+                "description": "UserTop",
                 "consumes": [
                     "application/json"
                 ],
@@ -7403,18 +7077,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "installation"
+                    "Question"
                 ],
-                "summary": "init base info",
+                "summary": "UserTop",
                 "parameters": [
                     {
-                        "description": "InitBaseInfoReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/install.InitBaseInfoReq"
-                        }
+                        "type": "string",
+                        "default": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -7427,19 +7100,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/installation/config-file/check": {
-            "post": {
-                "description": "check config file if exist when installation",
-                "consumes": [
-                    "application/json"
-                ],
+        "/answer/api/v1/personal/rank/page": {
+            "get": {
+                "description": "user personal rank list",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "installation"
+                    "Rank"
+                ],
+                "summary": "user personal rank list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query"
+                    }
                 ],
-                "summary": "check config file if exist when installation",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -7452,7 +7142,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/install.CheckConfigFileResp"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetRankPersonalPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -7462,9 +7167,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/installation/db/check": {
-            "post": {
-                "description": "check database if exist when installation",
+        "/answer/api/v1/personal/user/info": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "GetOtherUserInfoByUsername",
                 "consumes": [
                     "application/json"
                 ],
@@ -7472,18 +7182,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "installation"
+                    "User"
                 ],
-                "summary": "check database if exist when installation",
+                "summary": "GetOtherUserInfoByUsername",
                 "parameters": [
                     {
-                        "description": "CheckDatabaseReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/install.CheckDatabaseReq"
-                        }
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -7498,7 +7206,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/install.CheckConfigFileResp"
+                                            "$ref": "#/definitions/schema.GetOtherUserInfoResp"
                                         }
                                     }
                                 }
@@ -7508,9 +7216,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/installation/init": {
-            "post": {
-                "description": "init environment",
+        "/answer/api/v1/personal/vote/page": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get user personal votes",
                 "consumes": [
                     "application/json"
                 ],
@@ -7518,69 +7231,73 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "installation"
+                    "Activity"
                 ],
-                "summary": "init environment",
+                "summary": "get user personal votes",
                 "parameters": [
                     {
-                        "description": "CheckDatabaseReq",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/install.CheckDatabaseReq"
-                        }
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetVoteWithPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/installation/language/config": {
+        "/answer/api/v1/plugin/status": {
             "get": {
-                "description": "get installation language config mapping",
-                "produces": [
+                "description": "get all plugins status",
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "Lang"
-                ],
-                "summary": "get installation language config mapping",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "installation language",
-                        "name": "lang",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.RespBody"
-                        }
-                    }
-                }
-            }
-        },
-        "/installation/language/options": {
-            "get": {
-                "description": "get installation language options",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lang"
+                    "Plugin"
                 ],
-                "summary": "get installation language options",
+                "summary": "get all plugins status",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -7595,7 +7312,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/translator.LangOption"
+                                                "$ref": "#/definitions/schema.GetPluginListResp"
                                             }
                                         }
                                     }
@@ -7606,14 +7323,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/personal/question/page": {
-            "get": {
+        "/answer/api/v1/post/render": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "list personal questions",
+                "description": "render post content",
                 "consumes": [
                     "application/json"
                 ],
@@ -7621,44 +7338,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Personal"
+                    "Upload"
                 ],
-                "summary": "list personal questions",
+                "summary": "render post content",
                 "parameters": [
                     {
-                        "type": "string",
-                        "default": "string",
-                        "description": "username",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "newest",
-                            "score"
-                        ],
-                        "type": "string",
-                        "description": "order",
-                        "name": "order",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "0",
-                        "description": "page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "20",
-                        "description": "page_size",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
+                        "description": "PostRenderReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.PostRenderReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -7671,523 +7362,5417 @@ const docTemplate = `{
                 }
             }
         },
-        "/robots.txt": {
-            "get": {
-                "description": "get site robots information",
+        "/answer/api/v1/question": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update question",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "site"
+                    "Question"
+                ],
+                "summary": "update question",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.QuestionUpdate"
+                        }
+                    }
                 ],
-                "summary": "get site robots information",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/handler.RespBody"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "constant.NotificationChannelKey": {
-            "type": "string",
-            "enum": [
-                "email"
-            ],
-            "x-enum-varnames": [
-                "EmailChannel"
-            ]
-        },
-        "constant.Privilege": {
-            "type": "object",
-            "properties": {
-                "key": {
-                    "type": "string"
-                },
-                "label": {
-                    "type": "string"
-                },
-                "value": {
-                    "type": "integer",
-                    "minimum": 1
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add question",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "add question",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.QuestionAdd"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete question",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "delete question",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RemoveQuestionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/answer": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add question and answer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "add question and answer",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.QuestionAddByAnswer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/info": {
+            "get": {
+                "description": "get question details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "get question details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "1",
+                        "description": "Question TagID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/invite": {
+            "get": {
+                "description": "get question invite user info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "get question invite user info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "1",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update question invite user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "update question invite user",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.QuestionUpdateInviteUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/link": {
+            "get": {
+                "description": "get question link",
+                "tags": [
+                    "Question"
+                ],
+                "summary": "get question link",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "in_days",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "active",
+                            "hot",
+                            "score",
+                            "unanswered",
+                            "recommend",
+                            "frequent"
+                        ],
+                        "type": "string",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "question_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.QuestionPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/operation": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Operation question \\n operation [pin unpin hide show]",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "Operation question",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.OperationQuestionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/page": {
+            "get": {
+                "description": "get questions by page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "get questions by page",
+                "parameters": [
+                    {
+                        "description": "QuestionPageReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.QuestionPageReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.QuestionPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/recommend/page": {
+            "get": {
+                "description": "get recommend questions by page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "get recommend questions by page",
+                "parameters": [
+                    {
+                        "description": "QuestionPageReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.QuestionPageReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.QuestionPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/recover": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "recover deleted question",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "recover deleted question",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.QuestionRecoverReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/reopen": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "reopen question",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "reopen question",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ReopenQuestionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/rollback": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "rollback question to an old revision, a new revision is created with the content of the old one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "rollback question to an old revision",
+                "parameters": [
+                    {
+                        "description": "revision",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RollbackRevisionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/similar": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "fuzzy query similar questions based on title",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "fuzzy query similar questions based on title",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "string",
+                        "description": "title",
+                        "name": "title",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/similar/tag": {
+            "get": {
+                "description": "Search Similar Question",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "Search Similar Question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "",
+                        "description": "question_id",
+                        "name": "question_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/status": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Close question",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "Close question",
+                "parameters": [
+                    {
+                        "description": "question",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.CloseQuestionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/question/tags": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get tag list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "get tag list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetTagBasicResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/reasons": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get reasons by object type and action",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reason"
+                ],
+                "summary": "get reasons by object type and action",
+                "parameters": [
+                    {
+                        "enum": [
+                            "question",
+                            "answer",
+                            "comment",
+                            "user"
+                        ],
+                        "type": "string",
+                        "description": "object_type",
+                        "name": "object_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "status",
+                            "close",
+                            "flag",
+                            "review"
+                        ],
+                        "type": "string",
+                        "description": "action",
+                        "name": "action",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/render/config": {
+            "get": {
+                "description": "GetRenderConfig",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PluginRender"
+                ],
+                "summary": "GetRenderConfig",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/plugin.RenderConfig"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/report": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add report \u003cbr\u003e source (question, answer, comment, user)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "add report",
+                "parameters": [
+                    {
+                        "description": "report",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddReportReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/report/review": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "review report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "review report",
+                "parameters": [
+                    {
+                        "description": "flag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ReviewReportReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/report/unreviewed/post": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get unreviewed report post page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "get unreviewed report post page",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetReportListPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/review/pending/post": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "update review",
+                "parameters": [
+                    {
+                        "description": "review",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateReviewReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/review/pending/post/page": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get unreviewed post page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "get unreviewed post page",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "object_id",
+                        "name": "object_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetUnreviewedPostPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/reviewing/type": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get reviewing type",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "get reviewing type",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetReviewingTypeResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/revisions": {
+            "get": {
+                "description": "get revision list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "get revision list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "object id",
+                        "name": "object_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetRevisionResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/revisions/audit": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "revision audit operation:approve or reject",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "revision audit",
+                "parameters": [
+                    {
+                        "description": "audit",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RevisionAuditReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/revisions/diff": {
+            "get": {
+                "description": "get the changes of title, content and tags between two revisions of the same object",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "get the changes between two revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "old revision id",
+                        "name": "from_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "new revision id",
+                        "name": "to_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "line",
+                            "word"
+                        ],
+                        "type": "string",
+                        "description": "compare the content by line or word",
+                        "name": "granularity",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetRevisionDiffResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/revisions/edit/check": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "check can update revision",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "check can update revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/revisions/unreviewed": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get unreviewed revision list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revision"
+                ],
+                "summary": "get unreviewed revision list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "page id",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetUnreviewedRevisionResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "search object",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "search object",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "query string",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "active",
+                            "score",
+                            "relevance"
+                        ],
+                        "type": "string",
+                        "description": "order",
+                        "name": "order",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SearchResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/search/desc": {
+            "get": {
+                "description": "get search description",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "get search description",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SearchResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/siteinfo": {
+            "get": {
+                "description": "get site info",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "site"
+                ],
+                "summary": "get site info",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.SiteInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/siteinfo/legal": {
+            "get": {
+                "description": "get site legal info",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "site"
+                ],
+                "summary": "get site legal info",
+                "parameters": [
+                    {
+                        "enum": [
+                            "tos",
+                            "privacy"
+                        ],
+                        "type": "string",
+                        "description": "legal information type",
+                        "name": "info_type",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetSiteLegalInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tag": {
+            "get": {
+                "description": "get tag one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "get tag one",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "tag id",
+                        "name": "tag_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tag name",
+                        "name": "tag_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetTagResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "update tag",
+                "parameters": [
+                    {
+                        "description": "tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "add tag",
+                "parameters": [
+                    {
+                        "description": "tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "delete tag",
+                "parameters": [
+                    {
+                        "description": "tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RemoveTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tag/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "merge tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "merge tag",
+                "parameters": [
+                    {
+                        "description": "tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.AddTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tag/recover": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "recover delete tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "recover delete tag",
+                "parameters": [
+                    {
+                        "description": "tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RecoverTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tag/rollback": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "rollback tag to an old revision, a new revision is created with the content of the old one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "rollback tag to an old revision",
+                "parameters": [
+                    {
+                        "description": "revision",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RollbackRevisionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tag/synonym": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update tag",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "update tag",
+                "parameters": [
+                    {
+                        "description": "tag",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateTagSynonymReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tag/synonyms": {
+            "get": {
+                "description": "get tag synonyms",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "get tag synonyms",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "tag id",
+                        "name": "tag_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetTagSynonymsResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tags": {
+            "get": {
+                "description": "get tags list by slug name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "get tags list",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "string collection",
+                        "name": "tags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetTagBasicResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tags/following": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get following tag list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "get following tag list",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetFollowingTagsResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/tags/page": {
+            "get": {
+                "description": "get tag page",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tag"
+                ],
+                "summary": "get tag page",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "slug_name",
+                        "name": "slug_name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "popular",
+                            "name",
+                            "newest"
+                        ],
+                        "type": "string",
+                        "description": "query condition",
+                        "name": "query_cond",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/pager.PageModel"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "list": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/schema.GetTagPageResp"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/action/record": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ActionRecord",
+                "tags": [
+                    "User"
+                ],
+                "summary": "ActionRecord",
+                "parameters": [
+                    {
+                        "enum": [
+                            "login",
+                            "e_mail",
+                            "find_pass"
+                        ],
+                        "type": "string",
+                        "description": "action",
+                        "name": "action",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.ActionRecordResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/email": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "user change email verification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "user change email verification",
+                "parameters": [
+                    {
+                        "description": "UserChangeEmailVerifyReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserChangeEmailVerifyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/email/change/code": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "send email to the user email then change their email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "send email to the user email then change their email",
+                "parameters": [
+                    {
+                        "description": "UserChangeEmailSendCodeReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserChangeEmailSendCodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/email/verification": {
+            "post": {
+                "description": "UserVerifyEmail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserVerifyEmail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "",
+                        "description": "code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.UserLoginResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/email/verification/send": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "UserVerifyEmailSend",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserVerifyEmailSend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "",
+                        "description": "captcha_id",
+                        "name": "captcha_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "",
+                        "description": "captcha_code",
+                        "name": "captcha_code",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/info": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get user info, if user no login response http code is 200, but user info is null",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "GetUserInfoByUserID",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetCurrentLoginUserInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "UserUpdateInfo update user info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserUpdateInfo update user info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "access-token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdateInfoRequest",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateInfoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/info/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "SearchUserListByName",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "SearchUserListByName",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetOtherUserInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/interface": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "UserUpdateInterface update user interface config",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserUpdateInterface update user interface config",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "access-token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "UpdateInfoRequest",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateUserInterfaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/login/email": {
+            "post": {
+                "description": "UserEmailLogin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserEmailLogin",
+                "parameters": [
+                    {
+                        "description": "UserEmailLogin",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserEmailLoginReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.UserLoginResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/logout": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "user logout",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "user logout",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/notification/config": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update user's notification config",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "update user's notification config",
+                "parameters": [
+                    {
+                        "description": "UpdateUserNotificationConfigReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateUserNotificationConfigReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get user's notification config",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "get user's notification config",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetUserNotificationConfigResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/notification/unsubscribe": {
+            "put": {
+                "description": "unsubscribe notification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "unsubscribe notification",
+                "parameters": [
+                    {
+                        "description": "UserUnsubscribeNotificationReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserUnsubscribeNotificationReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/password": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "UserModifyPassWord",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserModifyPassWord",
+                "parameters": [
+                    {
+                        "description": "UserModifyPasswordReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserModifyPasswordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/password/replacement": {
+            "post": {
+                "description": "UseRePassWord",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UseRePassWord",
+                "parameters": [
+                    {
+                        "description": "UserRePassWordRequest",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserRePassWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/password/reset": {
+            "post": {
+                "description": "RetrievePassWord",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "RetrievePassWord",
+                "parameters": [
+                    {
+                        "description": "UserRetrievePassWordRequest",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserRetrievePassWordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/plugin/config": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get user plugin config",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserPlugin"
+                ],
+                "summary": "get user plugin config",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "plugin_slug_name",
+                        "name": "plugin_slug_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetPluginConfigResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "update user plugin config",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserPlugin"
+                ],
+                "summary": "update user plugin config",
+                "parameters": [
+                    {
+                        "description": "UpdatePluginConfigReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdateUserPluginConfigReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/plugin/configs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get plugin list that used for user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "UserPlugin"
+                ],
+                "summary": "get plugin list that used for user.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.GetUserPluginListResp"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/ranking": {
+            "get": {
+                "description": "get user ranking",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "get user ranking",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.UserRankingResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/register/email": {
+            "post": {
+                "description": "UserRegisterByEmail",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "UserRegisterByEmail",
+                "parameters": [
+                    {
+                        "description": "UserRegisterReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserRegisterReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.UserLoginResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/user/staff": {
+            "get": {
+                "description": "get user staff",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "get user staff",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "page_size",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.GetUserStaffResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/vote/down": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add vote",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Activity"
+                ],
+                "summary": "vote down",
+                "parameters": [
+                    {
+                        "description": "vote",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.VoteReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.VoteResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/answer/api/v1/vote/up": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "add vote",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Activity"
+                ],
+                "summary": "vote up",
+                "parameters": [
+                    {
+                        "description": "vote",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.VoteReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schema.VoteResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/custom.css": {
+            "get": {
+                "description": "get site custom CSS",
+                "produces": [
+                    "text/css"
+                ],
+                "tags": [
+                    "site"
+                ],
+                "summary": "get site custom CSS",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/installation/base-info": {
+            "post": {
+                "description": "init base info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "installation"
+                ],
+                "summary": "init base info",
+                "parameters": [
+                    {
+                        "description": "InitBaseInfoReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/install.InitBaseInfoReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/installation/config-file/check": {
+            "post": {
+                "description": "check config file if exist when installation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "installation"
+                ],
+                "summary": "check config file if exist when installation",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/install.CheckConfigFileResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/installation/db/check": {
+            "post": {
+                "description": "check database if exist when installation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "installation"
+                ],
+                "summary": "check database if exist when installation",
+                "parameters": [
+                    {
+                        "description": "CheckDatabaseReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/install.CheckDatabaseReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/install.CheckConfigFileResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/installation/init": {
+            "post": {
+                "description": "init environment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "installation"
+                ],
+                "summary": "init environment",
+                "parameters": [
+                    {
+                        "description": "CheckDatabaseReq",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/install.CheckDatabaseReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/installation/language/config": {
+            "get": {
+                "description": "get installation language config mapping",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lang"
+                ],
+                "summary": "get installation language config mapping",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "installation language",
+                        "name": "lang",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/installation/language/options": {
+            "get": {
+                "description": "get installation language options",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lang"
+                ],
+                "summary": "get installation language options",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.RespBody"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/translator.LangOption"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/jwks": {
+            "get": {
+                "description": "get the public keys to verify the id tokens",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth"
+                ],
+                "summary": "get the public keys to verify the id tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.JWKSResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/revoke": {
+            "post": {
+                "description": "the revocation endpoint of RFC 7009, it succeeds even if the token is unknown",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth"
+                ],
+                "summary": "revoke the access token or the refresh token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "token",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "access_token or refresh_token",
+                        "name": "token_type_hint",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "client id",
+                        "name": "client_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "client secret",
+                        "name": "client_secret",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/schema.OAuthErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/token": {
+            "post": {
+                "description": "the token endpoint of RFC 6749, the client authenticates by basic auth or the form,\nthe public clients only send the client_id and must use PKCE.",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth"
+                ],
+                "summary": "exchange the authorization code or the refresh token for the tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "authorization_code or refresh_token",
+                        "name": "grant_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "authorization code",
+                        "name": "code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "redirect uri",
+                        "name": "redirect_uri",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PKCE code verifier",
+                        "name": "code_verifier",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "refresh token",
+                        "name": "refresh_token",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "scope",
+                        "name": "scope",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "client id",
+                        "name": "client_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "client secret",
+                        "name": "client_secret",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.OAuthTokenResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schema.OAuthErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/userinfo": {
+            "get": {
+                "description": "the userinfo endpoint of OpenID Connect, the claims depend on the granted scopes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth"
+                ],
+                "summary": "get the claims of the user who authorized the access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.OAuthUserInfoResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/schema.OAuthErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/personal/question/page": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "list personal questions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personal"
+                ],
+                "summary": "list personal questions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "string",
+                        "description": "username",
+                        "name": "username",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "score"
+                        ],
+                        "type": "string",
+                        "description": "order",
+                        "name": "order",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "0",
+                        "description": "page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "20",
+                        "description": "page_size",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RespBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/robots.txt": {
+            "get": {
+                "description": "get site robots information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "site"
+                ],
+                "summary": "get site robots information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "constant.NotificationChannelKey": {
+            "type": "string",
+            "enum": [
+                "email"
+            ],
+            "x-enum-varnames": [
+                "EmailChannel"
+            ]
+        },
+        "constant.Privilege": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "diff.Operation": {
+            "type": "string",
+            "enum": [
+                "equal",
+                "insert",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "Equal",
+                "Insert",
+                "Delete"
+            ]
+        },
+        "diff.Segment": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/diff.Operation"
                 }
             }
         },
         "entity.BadgeLevel": {
             "type": "integer",
             "enum": [
-                1,
-                2,
-                3
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "BadgeLevelBronze",
+                "BadgeLevelSilver",
+                "BadgeLevelGold"
+            ]
+        },
+        "entity.HierarchicalTagWithChildren": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.HierarchicalTagWithChildren"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "leaf_only": {
+                    "type": "boolean"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "slug_name": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.RespBody": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "http code",
+                    "type": "integer"
+                },
+                "data": {
+                    "description": "response data"
+                },
+                "msg": {
+                    "description": "response message",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "reason key",
+                    "type": "string"
+                }
+            }
+        },
+        "install.CheckConfigFileResp": {
+            "type": "object",
+            "properties": {
+                "config_file_exist": {
+                    "type": "boolean"
+                },
+                "db_connection_success": {
+                    "type": "boolean"
+                },
+                "db_table_exist": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "install.CheckDatabaseReq": {
+            "type": "object",
+            "required": [
+                "db_type"
+            ],
+            "properties": {
+                "db_file": {
+                    "type": "string"
+                },
+                "db_host": {
+                    "type": "string"
+                },
+                "db_name": {
+                    "type": "string"
+                },
+                "db_password": {
+                    "type": "string"
+                },
+                "db_type": {
+                    "type": "string",
+                    "enum": [
+                        "postgres",
+                        "sqlite3",
+                        "mysql"
+                    ]
+                },
+                "db_username": {
+                    "type": "string"
+                },
+                "ssl_cert": {
+                    "type": "string"
+                },
+                "ssl_enabled": {
+                    "type": "boolean"
+                },
+                "ssl_key": {
+                    "type": "string"
+                },
+                "ssl_mode": {
+                    "type": "string"
+                },
+                "ssl_root_cert": {
+                    "type": "string"
+                }
+            }
+        },
+        "install.InitBaseInfoReq": {
+            "type": "object",
+            "required": [
+                "contact_email",
+                "email",
+                "external_content_display",
+                "lang",
+                "name",
+                "password",
+                "site_name",
+                "site_url"
+            ],
+            "properties": {
+                "contact_email": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "external_content_display": {
+                    "type": "string",
+                    "enum": [
+                        "always_display",
+                        "ask_before_display"
+                    ]
+                },
+                "lang": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "login_required": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 2
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 8
+                },
+                "site_name": {
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "site_url": {
+                    "type": "string",
+                    "maxLength": 512
+                }
+            }
+        },
+        "jwt.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                }
+            }
+        },
+        "pager.PageModel": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "list": {}
+            }
+        },
+        "plugin.EmbedConfig": {
+            "type": "object",
+            "properties": {
+                "enable": {
+                    "type": "boolean"
+                },
+                "platform": {
+                    "type": "string"
+                }
+            }
+        },
+        "plugin.RenderConfig": {
+            "type": "object",
+            "properties": {
+                "select_theme": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AcceptAnswerReq": {
+            "type": "object",
+            "required": [
+                "question_id"
+            ],
+            "properties": {
+                "answer_id": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "string",
+                    "maxLength": 30
+                }
+            }
+        },
+        "schema.ActObjectInfo": {
+            "type": "object",
+            "properties": {
+                "answer_id": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "main_tag_slug_name": {
+                    "type": "string"
+                },
+                "object_type": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ActObjectTimeline": {
+            "type": "object",
+            "properties": {
+                "activity_id": {
+                    "type": "string"
+                },
+                "activity_type": {
+                    "type": "string"
+                },
+                "cancelled": {
+                    "type": "boolean"
+                },
+                "cancelled_at": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "object_id": {
+                    "type": "string"
+                },
+                "object_type": {
+                    "type": "string"
+                },
+                "revision_id": {
+                    "type": "string"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                }
+            }
+        },
+        "schema.ActionRecordResp": {
+            "type": "object",
+            "properties": {
+                "captcha_id": {
+                    "type": "string"
+                },
+                "captcha_img": {
+                    "type": "string"
+                },
+                "verify": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "schema.AddBadgeGroupReq": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                }
+            }
+        },
+        "schema.AddBadgeReq": {
+            "type": "object",
+            "required": [
+                "group_id",
+                "icon",
+                "level",
+                "name",
+                "rule"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 4096
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "is_single": {
+                    "description": "IsSingle the badge can only be awarded once to each user",
+                    "type": "boolean"
+                },
+                "level": {
+                    "enum": [
+                        1,
+                        2,
+                        3
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BadgeLevel"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "rule": {
+                    "$ref": "#/definitions/schema.BadgeRule"
+                }
+            }
+        },
+        "schema.AddCommentReq": {
+            "type": "object",
+            "required": [
+                "object_id",
+                "original_text"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "type": "string"
+                },
+                "mention_username_list": {
+                    "description": "@ user id list",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "object_id": {
+                    "description": "object id",
+                    "type": "string"
+                },
+                "original_text": {
+                    "description": "original comment content",
+                    "type": "string",
+                    "maxLength": 600,
+                    "minLength": 2
+                },
+                "reply_comment_id": {
+                    "description": "reply comment id",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AddOAuthClientReq": {
+            "type": "object",
+            "required": [
+                "name",
+                "redirect_uris",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "public": {
+                    "description": "Public the client cannot keep a secret, such as the mobile or single page apps, it must use PKCE",
+                    "type": "boolean"
+                },
+                "redirect_uris": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.AddOAuthClientResp": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "client_secret": {
+                    "description": "ClientSecret it is empty for the public clients and cannot be got again",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "public": {
+                    "type": "boolean"
+                },
+                "redirect_uris": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.AddPersonalAccessTokenReq": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "description": "ExpiresInDays the token never expires if it is 0",
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.AddPersonalAccessTokenResp": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "expired_at": {
+                    "description": "ExpiredAt the token never expires if it is 0",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "description": "Token the token to put in the header \"Authorization: Bearer \u003ctoken\u003e\", it cannot be got again",
+                    "type": "string"
+                },
+                "token_prefix": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AddQuestionAutoCloseRuleReq": {
+            "type": "object",
+            "required": [
+                "close_type",
+                "inactive_days",
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "close_msg": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "close_type": {
+                    "description": "CloseType the close reason returned by the reasons api",
+                    "type": "integer"
+                },
+                "inactive_days": {
+                    "description": "InactiveDays close the questions which have no activity for days",
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "no_answer": {
+                    "description": "NoAnswer only close the questions which have no answer",
+                    "type": "boolean"
+                },
+                "tag": {
+                    "description": "Tag slug name, the rule applies to all questions when it is empty",
+                    "type": "string",
+                    "maxLength": 35
+                }
+            }
+        },
+        "schema.AddReportReq": {
+            "type": "object",
+            "required": [
+                "object_id",
+                "report_type"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "description": "captcha_id",
+                    "type": "string"
+                },
+                "content": {
+                    "description": "report content",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "object_id": {
+                    "description": "object id",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "report_type": {
+                    "description": "report type",
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.AddRoleReq": {
+            "type": "object",
+            "required": [
+                "name",
+                "power_types"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "power_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.AddTagReq": {
+            "type": "object",
+            "required": [
+                "display_name",
+                "original_text",
+                "slug_name"
+            ],
+            "properties": {
+                "display_name": {
+                    "description": "display_name",
+                    "type": "string",
+                    "maxLength": 35
+                },
+                "original_text": {
+                    "description": "original text",
+                    "type": "string",
+                    "maxLength": 65536
+                },
+                "slug_name": {
+                    "description": "slug_name",
+                    "type": "string",
+                    "maxLength": 35
+                }
+            }
+        },
+        "schema.AddUserReq": {
+            "type": "object",
+            "required": [
+                "display_name",
+                "email",
+                "password"
+            ],
+            "properties": {
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 2
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 8
+                }
+            }
+        },
+        "schema.AddUsersReq": {
+            "type": "object",
+            "properties": {
+                "users": {
+                    "description": "users info line by line",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AddWebhookReq": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "name",
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "secret": {
+                    "description": "Secret key of the HMAC signature, generated when empty",
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 1024
+                }
+            }
+        },
+        "schema.AddWebhookResp": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AdminPersonalAccessTokenItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "expired_at": {
+                    "description": "ExpiredAt the token never expires if it is 0",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token_prefix": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                }
+            }
+        },
+        "schema.AdminUpdateAnswerStatusReq": {
+            "type": "object",
+            "required": [
+                "answer_id",
+                "status"
+            ],
+            "properties": {
+                "answer_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "available",
+                        "deleted"
+                    ]
+                }
+            }
+        },
+        "schema.AdminUpdateQuestionStatusReq": {
+            "type": "object",
+            "required": [
+                "question_id",
+                "status"
+            ],
+            "properties": {
+                "question_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "available",
+                        "closed",
+                        "deleted"
+                    ]
+                }
+            }
+        },
+        "schema.AnswerAddReq": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string",
+                    "maxLength": 65535,
+                    "minLength": 6
+                },
+                "question_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AnswerInfo": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "integer"
+                },
+                "collected": {
+                    "type": "boolean"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "create_time": {
+                    "type": "integer"
+                },
+                "html": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "member_actions": {
+                    "description": "MemberActions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.PermissionMemberAction"
+                    }
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "question_info": {
+                    "$ref": "#/definitions/schema.QuestionInfoResp"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "update_time": {
+                    "type": "integer"
+                },
+                "update_user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                },
+                "user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                },
+                "vote_count": {
+                    "type": "integer"
+                },
+                "vote_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AnswerUpdateReq": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string",
+                    "maxLength": 65535,
+                    "minLength": 6
+                },
+                "edit_summary": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AvatarInfo": {
+            "type": "object",
+            "properties": {
+                "custom": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "gravatar": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "type": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "schema.BackfillBadgeReq": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.BadgeGroupItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.BadgeListInfo": {
+            "type": "object",
+            "properties": {
+                "award_count": {
+                    "description": "badge award count",
+                    "type": "integer"
+                },
+                "earned_count": {
+                    "description": "badge earned count",
+                    "type": "integer"
+                },
+                "icon": {
+                    "description": "badge icon",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "badge id",
+                    "type": "string"
+                },
+                "level": {
+                    "description": "badge level",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BadgeLevel"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "badge name",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.BadgeRule": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "metric",
+                "threshold"
+            ],
+            "properties": {
+                "event_types": {
+                    "description": "EventTypes the events triggering the evaluation, such as question.accept",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "metric": {
+                    "description": "Metric what is counted",
+                    "type": "string",
+                    "enum": [
+                        "question_count",
+                        "answer_count",
+                        "accepted_answer_count",
+                        "comment_count",
+                        "received_vote_count",
+                        "post_vote_count",
+                        "post_streak_days"
+                    ]
+                },
+                "recipient": {
+                    "description": "Recipient who is evaluated and awarded, the actor of the event by default",
+                    "type": "string",
+                    "enum": [
+                        "actor",
+                        "question_author",
+                        "answer_author",
+                        "comment_author"
+                    ]
+                },
+                "tag_slug_name": {
+                    "description": "TagSlugName only count the posts of the questions with this tag, for the question and answer counts",
+                    "type": "string",
+                    "maxLength": 35
+                },
+                "threshold": {
+                    "description": "Threshold the value the metric needs to reach",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "schema.BadgeStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "inactive"
+            ],
+            "x-enum-varnames": [
+                "BadgeStatusActive",
+                "BadgeStatusInactive"
+            ]
+        },
+        "schema.CloseQuestionReq": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "close_msg": {
+                    "description": "close_type",
+                    "type": "string"
+                },
+                "close_type": {
+                    "description": "close_type",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.CollectionSwitchReq": {
+            "type": "object",
+            "required": [
+                "group_id",
+                "object_id"
+            ],
+            "properties": {
+                "bookmark": {
+                    "type": "boolean"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "object_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.CollectionSwitchResp": {
+            "type": "object",
+            "properties": {
+                "object_collection_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.ConfigField": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "env_name": {
+                    "description": "EnvName the environment variable which pins the value, the value is hidden and can not be changed",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.ConfigFieldOption"
+                    }
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "ui_options": {
+                    "$ref": "#/definitions/schema.ConfigFieldUIOptions"
+                },
+                "value": {}
+            }
+        },
+        "schema.ConfigFieldOption": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ConfigFieldUIOptions": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/schema.UIOptionAction"
+                },
+                "class_name": {
+                    "type": "string"
+                },
+                "field_class_name": {
+                    "type": "string"
+                },
+                "input_type": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "placeholder": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "variant": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ConnectorInfoResp": {
+            "type": "object",
+            "properties": {
+                "icon": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ConnectorUserInfoResp": {
+            "type": "object",
+            "properties": {
+                "binding": {
+                    "type": "boolean"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.CreateHierarchicalTagReq": {
+            "type": "object",
+            "required": [
+                "display_name",
+                "name",
+                "slug_name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "slug_name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "schema.DeleteBadgeGroupReq": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.DeleteBadgeReq": {
+            "type": "object",
+            "required": [
+                "id"
             ],
-            "x-enum-varnames": [
-                "BadgeLevelBronze",
-                "BadgeLevelSilver",
-                "BadgeLevelGold"
-            ]
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
         },
-        "handler.RespBody": {
+        "schema.DeleteHierarchicalTagReq": {
             "type": "object",
+            "required": [
+                "id"
+            ],
             "properties": {
-                "code": {
-                    "description": "http code",
-                    "type": "integer"
-                },
-                "data": {
-                    "description": "response data"
-                },
-                "msg": {
-                    "description": "response message",
+                "id": {
                     "type": "string"
-                },
-                "reason": {
-                    "description": "reason key",
+                }
+            }
+        },
+        "schema.DeleteOAuthClientReq": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
                     "type": "string"
                 }
             }
         },
-        "install.CheckConfigFileResp": {
+        "schema.DeletePermanentlyReq": {
             "type": "object",
+            "required": [
+                "type"
+            ],
             "properties": {
-                "config_file_exist": {
-                    "type": "boolean"
-                },
-                "db_connection_success": {
-                    "type": "boolean"
-                },
-                "db_table_exist": {
-                    "type": "boolean"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "users",
+                        "questions",
+                        "answers"
+                    ]
                 }
             }
         },
-        "install.CheckDatabaseReq": {
+        "schema.DeleteQuestionAutoCloseRuleReq": {
             "type": "object",
             "required": [
-                "db_type"
+                "id"
             ],
             "properties": {
-                "db_file": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.DeleteWebhookReq": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
                     "type": "string"
+                }
+            }
+        },
+        "schema.DigestSchedule": {
+            "type": "object",
+            "properties": {
+                "frequency": {
+                    "description": "daily or weekly",
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly"
+                    ]
                 },
-                "db_host": {
+                "hour": {
+                    "description": "the hour of the day to send the digest",
+                    "type": "integer",
+                    "maximum": 23,
+                    "minimum": 0
+                },
+                "timezone": {
+                    "description": "the IANA time zone name, the site time zone is used if empty",
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "weekday": {
+                    "description": "the day of the week to send the weekly digest, 0 is Sunday",
+                    "type": "integer",
+                    "maximum": 6,
+                    "minimum": 0
+                }
+            }
+        },
+        "schema.DraftItem": {
+            "type": "object",
+            "properties": {
+                "base_revision_id": {
                     "type": "string"
                 },
-                "db_name": {
+                "conflict": {
+                    "description": "Conflict the post has been edited by others since the edit draft started",
+                    "type": "boolean"
+                },
+                "content": {
                     "type": "string"
                 },
-                "db_password": {
+                "current_revision_id": {
+                    "description": "CurrentRevisionID the latest revision of the post, only for the edit drafts",
                     "type": "string"
                 },
-                "db_type": {
-                    "type": "string",
-                    "enum": [
-                        "postgres",
-                        "sqlite3",
-                        "mysql"
-                    ]
+                "expired_at": {
+                    "type": "integer"
                 },
-                "db_username": {
+                "id": {
                     "type": "string"
                 },
-                "ssl_cert": {
+                "object_id": {
                     "type": "string"
                 },
-                "ssl_enabled": {
+                "object_removed": {
+                    "description": "ObjectRemoved the question or answer the draft belongs to has been deleted",
                     "type": "boolean"
                 },
-                "ssl_key": {
+                "question_id": {
+                    "description": "QuestionID the question the draft belongs to, it is empty for the new question drafts",
                     "type": "string"
                 },
-                "ssl_mode": {
+                "question_title": {
+                    "description": "QuestionTitle the title of the question the draft belongs to, to show in the list of drafts",
                     "type": "string"
                 },
-                "ssl_root_cert": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
                 }
             }
         },
-        "install.InitBaseInfoReq": {
+        "schema.EditUserProfileReq": {
             "type": "object",
             "required": [
-                "contact_email",
+                "display_name",
                 "email",
-                "external_content_display",
-                "lang",
-                "name",
-                "password",
-                "site_name",
-                "site_url"
+                "user_id"
             ],
             "properties": {
-                "contact_email": {
+                "display_name": {
                     "type": "string",
-                    "maxLength": 500
+                    "maxLength": 30,
+                    "minLength": 2
                 },
                 "email": {
                     "type": "string",
                     "maxLength": 500
                 },
-                "external_content_display": {
-                    "type": "string",
-                    "enum": [
-                        "always_display",
-                        "ask_before_display"
-                    ]
-                },
-                "lang": {
-                    "type": "string",
-                    "maxLength": 30
-                },
-                "login_required": {
-                    "type": "boolean"
+                "user_id": {
+                    "type": "string"
                 },
-                "name": {
+                "username": {
                     "type": "string",
                     "maxLength": 30,
                     "minLength": 2
-                },
-                "password": {
-                    "type": "string",
-                    "maxLength": 32,
-                    "minLength": 8
-                },
-                "site_name": {
-                    "type": "string",
-                    "maxLength": 30
-                },
-                "site_url": {
-                    "type": "string",
-                    "maxLength": 512
                 }
             }
         },
-        "pager.PageModel": {
+        "schema.ExternalLoginBindingUserSendEmailReq": {
             "type": "object",
+            "required": [
+                "binding_key",
+                "email"
+            ],
             "properties": {
-                "count": {
-                    "type": "integer"
+                "binding_key": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "list": {}
+                "email": {
+                    "type": "string",
+                    "maxLength": 512
+                },
+                "must": {
+                    "description": "If must is true, whatever email if exists, try to bind user.\nIf must is false, when email exist, will only be prompted with a warning.",
+                    "type": "boolean"
+                }
             }
         },
-        "plugin.EmbedConfig": {
+        "schema.ExternalLoginBindingUserSendEmailResp": {
             "type": "object",
             "properties": {
-                "enable": {
-                    "type": "boolean"
-                },
-                "platform": {
+                "access_token": {
                     "type": "string"
+                },
+                "email_exist_and_must_be_confirmed": {
+                    "type": "boolean"
                 }
             }
         },
-        "plugin.RenderConfig": {
+        "schema.ExternalLoginUnbindingReq": {
             "type": "object",
+            "required": [
+                "external_id"
+            ],
             "properties": {
-                "select_theme": {
-                    "type": "string"
+                "external_id": {
+                    "type": "string",
+                    "maxLength": 128
                 }
             }
         },
-        "schema.AcceptAnswerReq": {
+        "schema.FollowReq": {
             "type": "object",
             "required": [
-                "question_id"
+                "object_id"
             ],
             "properties": {
-                "answer_id": {
-                    "type": "string"
+                "is_cancel": {
+                    "description": "is cancel",
+                    "type": "boolean"
                 },
-                "question_id": {
-                    "type": "string",
-                    "maxLength": 30
+                "object_id": {
+                    "description": "object id",
+                    "type": "string"
                 }
             }
         },
-        "schema.ActObjectInfo": {
+        "schema.FollowResp": {
             "type": "object",
             "properties": {
-                "answer_id": {
-                    "type": "string"
-                },
-                "display_name": {
-                    "type": "string"
-                },
-                "main_tag_slug_name": {
-                    "type": "string"
-                },
-                "object_type": {
-                    "type": "string"
-                },
-                "question_id": {
-                    "type": "string"
+                "follows": {
+                    "description": "the followers of object",
+                    "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "is_followed": {
+                    "description": "if user is followed object will be true,otherwise false",
+                    "type": "boolean"
+                }
+            }
+        },
+        "schema.GetAnswerInfoResp": {
+            "type": "object",
+            "properties": {
+                "info": {
+                    "$ref": "#/definitions/schema.AnswerInfo"
                 },
-                "username": {
-                    "type": "string"
+                "question": {
+                    "$ref": "#/definitions/schema.QuestionInfoResp"
                 }
             }
         },
-        "schema.ActObjectTimeline": {
+        "schema.GetBadgeDetailResp": {
             "type": "object",
             "properties": {
-                "activity_id": {
+                "award_count": {
+                    "type": "integer"
+                },
+                "description": {
                     "type": "string"
                 },
-                "activity_type": {
+                "group_id": {
                     "type": "string"
                 },
-                "cancelled": {
-                    "type": "boolean"
+                "handler": {
+                    "description": "Handler the built-in handler awarding the badge, Rule for the badges defined by the admin",
+                    "type": "string"
                 },
-                "cancelled_at": {
-                    "type": "integer"
+                "icon": {
+                    "type": "string"
                 },
-                "comment": {
+                "id": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "integer"
+                "is_single": {
+                    "type": "boolean"
                 },
-                "object_id": {
-                    "type": "string"
+                "level": {
+                    "$ref": "#/definitions/entity.BadgeLevel"
                 },
-                "object_type": {
+                "name": {
                     "type": "string"
                 },
-                "revision_id": {
-                    "type": "string"
+                "rule": {
+                    "$ref": "#/definitions/schema.BadgeRule"
                 },
-                "user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
+                "status": {
+                    "$ref": "#/definitions/schema.BadgeStatus"
                 }
             }
         },
-        "schema.ActionRecordResp": {
+        "schema.GetBadgeInfoResp": {
             "type": "object",
             "properties": {
-                "captcha_id": {
-                    "type": "string"
+                "award_count": {
+                    "description": "badge award count",
+                    "type": "integer"
                 },
-                "captcha_img": {
+                "description": {
+                    "description": "badge description",
                     "type": "string"
                 },
-                "verify": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "schema.AddCommentReq": {
-            "type": "object",
-            "required": [
-                "object_id",
-                "original_text"
-            ],
-            "properties": {
-                "captcha_code": {
-                    "type": "string"
+                "earned_count": {
+                    "description": "badge earned count",
+                    "type": "integer"
                 },
-                "captcha_id": {
+                "icon": {
+                    "description": "badge icon",
                     "type": "string"
                 },
-                "mention_username_list": {
-                    "description": "@ user id list",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "object_id": {
-                    "description": "object id",
+                "id": {
+                    "description": "badge id",
                     "type": "string"
                 },
-                "original_text": {
-                    "description": "original comment content",
-                    "type": "string",
-                    "maxLength": 600,
-                    "minLength": 2
+                "is_single": {
+                    "description": "badge is single or multiple",
+                    "type": "boolean"
                 },
-                "reply_comment_id": {
-                    "description": "reply comment id",
+                "level": {
+                    "description": "badge level",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BadgeLevel"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "badge name",
                     "type": "string"
                 }
             }
         },
-        "schema.AddReportReq": {
+        "schema.GetBadgeListPagedResp": {
             "type": "object",
-            "required": [
-                "object_id",
-                "report_type"
-            ],
             "properties": {
-                "captcha_code": {
-                    "type": "string"
+                "award_count": {
+                    "description": "badge award count",
+                    "type": "integer"
                 },
-                "captcha_id": {
-                    "description": "captcha_id",
+                "description": {
+                    "description": "badge description",
                     "type": "string"
                 },
-                "content": {
-                    "description": "report content",
-                    "type": "string",
-                    "maxLength": 500
-                },
-                "object_id": {
-                    "description": "object id",
-                    "type": "string",
-                    "maxLength": 20
+                "earned": {
+                    "description": "badge earned count",
+                    "type": "boolean"
                 },
-                "report_type": {
-                    "description": "report type",
-                    "type": "integer"
-                }
-            }
-        },
-        "schema.AddTagReq": {
-            "type": "object",
-            "required": [
-                "display_name",
-                "original_text",
-                "slug_name"
-            ],
-            "properties": {
-                "display_name": {
-                    "description": "display_name",
-                    "type": "string",
-                    "maxLength": 35
+                "group_name": {
+                    "description": "badge group name",
+                    "type": "string"
                 },
-                "original_text": {
-                    "description": "original text",
-                    "type": "string",
-                    "maxLength": 65536
+                "icon": {
+                    "description": "badge icon",
+                    "type": "string"
                 },
-                "slug_name": {
-                    "description": "slug_name",
-                    "type": "string",
-                    "maxLength": 35
-                }
-            }
-        },
-        "schema.AddUserReq": {
-            "type": "object",
-            "required": [
-                "display_name",
-                "email",
-                "password"
-            ],
-            "properties": {
-                "display_name": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "minLength": 2
+                "id": {
+                    "description": "badge id",
+                    "type": "string"
                 },
-                "email": {
-                    "type": "string",
-                    "maxLength": 500
+                "level": {
+                    "description": "badge level",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BadgeLevel"
+                        }
+                    ]
                 },
-                "password": {
-                    "type": "string",
-                    "maxLength": 32,
-                    "minLength": 8
-                }
-            }
-        },
-        "schema.AddUsersReq": {
-            "type": "object",
-            "properties": {
-                "users": {
-                    "description": "users info line by line",
-                    "type": "string"
-                }
-            }
-        },
-        "schema.AdminUpdateAnswerStatusReq": {
-            "type": "object",
-            "required": [
-                "answer_id",
-                "status"
-            ],
-            "properties": {
-                "answer_id": {
+                "name": {
+                    "description": "badge name",
                     "type": "string"
                 },
                 "status": {
-                    "type": "string",
-                    "enum": [
-                        "available",
-                        "deleted"
+                    "description": "badge status",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.BadgeStatus"
+                        }
                     ]
                 }
             }
         },
-        "schema.AdminUpdateQuestionStatusReq": {
+        "schema.GetBadgeListResp": {
             "type": "object",
-            "required": [
-                "question_id",
-                "status"
-            ],
             "properties": {
-                "question_id": {
-                    "type": "string"
+                "badges": {
+                    "description": "badge list info",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.BadgeListInfo"
+                    }
                 },
-                "status": {
-                    "type": "string",
-                    "enum": [
-                        "available",
-                        "closed",
-                        "deleted"
-                    ]
+                "group_name": {
+                    "description": "badge group name",
+                    "type": "string"
                 }
             }
         },
-        "schema.AnswerAddReq": {
+        "schema.GetCommentPersonalWithPageResp": {
             "type": "object",
-            "required": [
-                "content"
-            ],
             "properties": {
-                "captcha_code": {
+                "answer_id": {
+                    "description": "answer id",
                     "type": "string"
                 },
-                "captcha_id": {
+                "comment_id": {
+                    "description": "comment id",
                     "type": "string"
                 },
                 "content": {
+                    "description": "content",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "create time",
+                    "type": "integer"
+                },
+                "object_id": {
+                    "description": "object id",
+                    "type": "string"
+                },
+                "object_type": {
+                    "description": "object type",
                     "type": "string",
-                    "maxLength": 65535,
-                    "minLength": 6
+                    "enum": [
+                        "question",
+                        "answer",
+                        "tag",
+                        "comment"
+                    ]
                 },
                 "question_id": {
+                    "description": "question id",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "title",
+                    "type": "string"
+                },
+                "url_title": {
+                    "description": "url title",
                     "type": "string"
                 }
             }
         },
-        "schema.AnswerInfo": {
+        "schema.GetCommentResp": {
             "type": "object",
             "properties": {
-                "accepted": {
-                    "type": "integer"
-                },
-                "collected": {
-                    "type": "boolean"
-                },
-                "content": {
+                "comment_id": {
+                    "description": "comment id",
                     "type": "string"
                 },
-                "create_time": {
+                "created_at": {
+                    "description": "create time",
                     "type": "integer"
                 },
-                "html": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "is_vote": {
+                    "description": "current user if already vote this comment",
+                    "type": "boolean"
                 },
                 "member_actions": {
                     "description": "MemberActions",
@@ -8196,787 +12781,840 @@ const docTemplate = `{
                         "$ref": "#/definitions/schema.PermissionMemberAction"
                     }
                 },
-                "question_id": {
+                "object_id": {
+                    "description": "object id",
                     "type": "string"
                 },
-                "question_info": {
-                    "$ref": "#/definitions/schema.QuestionInfoResp"
-                },
-                "status": {
-                    "type": "integer"
-                },
-                "update_time": {
-                    "type": "integer"
+                "original_text": {
+                    "description": "original comment content",
+                    "type": "string"
                 },
-                "update_user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
+                "parsed_text": {
+                    "description": "parsed comment content",
+                    "type": "string"
                 },
-                "user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
+                "reply_comment_id": {
+                    "description": "reply comment id",
+                    "type": "string"
                 },
-                "vote_count": {
-                    "type": "integer"
+                "reply_user_display_name": {
+                    "description": "reply user display name",
+                    "type": "string"
                 },
-                "vote_status": {
+                "reply_user_id": {
+                    "description": "reply user id",
                     "type": "string"
-                }
-            }
-        },
-        "schema.AnswerUpdateReq": {
-            "type": "object",
-            "required": [
-                "content"
-            ],
-            "properties": {
-                "captcha_code": {
+                },
+                "reply_user_status": {
+                    "description": "reply user status",
                     "type": "string"
                 },
-                "captcha_id": {
+                "reply_username": {
+                    "description": "reply user username",
                     "type": "string"
                 },
-                "content": {
-                    "type": "string",
-                    "maxLength": 65535,
-                    "minLength": 6
+                "user_avatar": {
+                    "description": "user avatar",
+                    "type": "string"
                 },
-                "edit_summary": {
+                "user_display_name": {
+                    "description": "user display name",
                     "type": "string"
                 },
-                "id": {
+                "user_id": {
+                    "description": "user id",
                     "type": "string"
                 },
-                "question_id": {
+                "user_status": {
+                    "description": "user status",
                     "type": "string"
                 },
-                "title": {
+                "username": {
+                    "description": "username",
                     "type": "string"
+                },
+                "vote_count": {
+                    "description": "user vote amount",
+                    "type": "integer"
                 }
             }
         },
-        "schema.AvatarInfo": {
+        "schema.GetCurrentLoginUserInfoResp": {
             "type": "object",
             "properties": {
-                "custom": {
-                    "type": "string",
-                    "maxLength": 200
+                "access_token": {
+                    "description": "access token",
+                    "type": "string"
                 },
-                "gravatar": {
-                    "type": "string",
-                    "maxLength": 200
+                "answer_count": {
+                    "description": "answer count",
+                    "type": "integer"
                 },
-                "type": {
-                    "type": "string",
-                    "maxLength": 100
-                }
-            }
-        },
-        "schema.BadgeListInfo": {
-            "type": "object",
-            "properties": {
-                "award_count": {
-                    "description": "badge award count",
+                "authority_group": {
+                    "description": "authority group",
                     "type": "integer"
                 },
-                "earned_count": {
-                    "description": "badge earned count",
+                "avatar": {
+                    "$ref": "#/definitions/schema.AvatarInfo"
+                },
+                "bio": {
+                    "description": "bio markdown",
+                    "type": "string"
+                },
+                "bio_html": {
+                    "description": "bio html",
+                    "type": "string"
+                },
+                "color_scheme": {
+                    "description": "Color scheme",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "create time",
                     "type": "integer"
                 },
-                "icon": {
-                    "description": "badge icon",
+                "display_name": {
+                    "description": "display name",
                     "type": "string"
                 },
+                "e_mail": {
+                    "description": "email",
+                    "type": "string"
+                },
+                "follow_count": {
+                    "description": "follow count",
+                    "type": "integer"
+                },
+                "have_password": {
+                    "description": "user have password",
+                    "type": "boolean"
+                },
                 "id": {
-                    "description": "badge id",
+                    "description": "user id",
                     "type": "string"
                 },
-                "level": {
-                    "description": "badge level",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.BadgeLevel"
-                        }
-                    ]
+                "language": {
+                    "description": "language",
+                    "type": "string"
                 },
-                "name": {
-                    "description": "badge name",
+                "last_login_date": {
+                    "description": "last login date",
+                    "type": "integer"
+                },
+                "location": {
+                    "description": "location",
                     "type": "string"
-                }
-            }
-        },
-        "schema.BadgeStatus": {
-            "type": "string",
-            "enum": [
-                "active",
-                "inactive"
-            ],
-            "x-enum-varnames": [
-                "BadgeStatusActive",
-                "BadgeStatusInactive"
-            ]
-        },
-        "schema.CloseQuestionReq": {
-            "type": "object",
-            "required": [
-                "id"
-            ],
-            "properties": {
-                "close_msg": {
-                    "description": "close_type",
+                },
+                "mail_status": {
+                    "description": "mail status(1 pass 2 to be verified)",
+                    "type": "integer"
+                },
+                "mobile": {
+                    "description": "mobile",
+                    "type": "string"
+                },
+                "notice_status": {
+                    "description": "notice status(1 on 2off)",
+                    "type": "integer"
+                },
+                "question_count": {
+                    "description": "question count",
+                    "type": "integer"
+                },
+                "rank": {
+                    "description": "rank",
+                    "type": "integer"
+                },
+                "role_id": {
+                    "description": "role id",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "user status",
                     "type": "string"
                 },
-                "close_type": {
-                    "description": "close_type",
+                "suspended_until": {
+                    "description": "suspended until timestamp",
                     "type": "integer"
                 },
-                "id": {
+                "username": {
+                    "description": "username",
                     "type": "string"
-                }
-            }
-        },
-        "schema.CollectionSwitchReq": {
-            "type": "object",
-            "required": [
-                "group_id",
-                "object_id"
-            ],
-            "properties": {
-                "bookmark": {
-                    "type": "boolean"
                 },
-                "group_id": {
+                "visit_token": {
+                    "description": "visit token",
                     "type": "string"
                 },
-                "object_id": {
+                "website": {
+                    "description": "website",
                     "type": "string"
                 }
             }
         },
-        "schema.CollectionSwitchResp": {
-            "type": "object",
-            "properties": {
-                "object_collection_count": {
-                    "type": "integer"
-                }
-            }
-        },
-        "schema.ConfigField": {
+        "schema.GetFollowingTagsResp": {
             "type": "object",
             "properties": {
-                "description": {
+                "display_name": {
+                    "description": "display name",
                     "type": "string"
                 },
-                "name": {
+                "main_tag_slug_name": {
+                    "description": "if main tag slug name is not empty, this tag is synonymous with the main tag",
                     "type": "string"
                 },
-                "options": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.ConfigFieldOption"
-                    }
+                "recommend": {
+                    "type": "boolean"
                 },
-                "required": {
+                "reserved": {
                     "type": "boolean"
                 },
-                "title": {
+                "slug_name": {
+                    "description": "slug name",
                     "type": "string"
                 },
-                "type": {
+                "tag_id": {
+                    "description": "tag id",
                     "type": "string"
-                },
-                "ui_options": {
-                    "$ref": "#/definitions/schema.ConfigFieldUIOptions"
-                },
-                "value": {}
+                }
             }
         },
-        "schema.ConfigFieldOption": {
+        "schema.GetHierarchicalTagsResp": {
             "type": "object",
             "properties": {
-                "label": {
-                    "type": "string"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.HierarchicalTagItem"
+                    }
+                }
+            }
+        },
+        "schema.GetObjectTimelineResp": {
+            "type": "object",
+            "properties": {
+                "object_info": {
+                    "$ref": "#/definitions/schema.ActObjectInfo"
                 },
-                "value": {
-                    "type": "string"
+                "timeline": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.ActObjectTimeline"
+                    }
                 }
             }
         },
-        "schema.ConfigFieldUIOptions": {
+        "schema.GetOtherUserInfoByUsernameResp": {
             "type": "object",
             "properties": {
-                "action": {
-                    "$ref": "#/definitions/schema.UIOptionAction"
+                "answer_count": {
+                    "description": "answer count",
+                    "type": "integer"
                 },
-                "class_name": {
+                "avatar": {
+                    "description": "avatar",
                     "type": "string"
                 },
-                "field_class_name": {
+                "bio": {
+                    "description": "bio markdown",
                     "type": "string"
                 },
-                "input_type": {
+                "bio_html": {
+                    "description": "bio html",
                     "type": "string"
                 },
-                "label": {
-                    "type": "string"
+                "created_at": {
+                    "description": "create time",
+                    "type": "integer"
                 },
-                "placeholder": {
+                "display_name": {
+                    "description": "display name",
                     "type": "string"
                 },
-                "rows": {
-                    "type": "string"
+                "follow_count": {
+                    "description": "email\nfollow count",
+                    "type": "integer"
                 },
-                "text": {
+                "id": {
+                    "description": "user id",
                     "type": "string"
                 },
-                "variant": {
-                    "type": "string"
-                }
-            }
-        },
-        "schema.ConnectorInfoResp": {
-            "type": "object",
-            "properties": {
-                "icon": {
-                    "type": "string"
+                "last_login_date": {
+                    "description": "last login date",
+                    "type": "integer"
                 },
-                "link": {
+                "location": {
+                    "description": "location",
                     "type": "string"
                 },
-                "name": {
+                "mobile": {
+                    "description": "mobile",
                     "type": "string"
-                }
-            }
-        },
-        "schema.ConnectorUserInfoResp": {
-            "type": "object",
-            "properties": {
-                "binding": {
-                    "type": "boolean"
                 },
-                "external_id": {
-                    "type": "string"
+                "question_count": {
+                    "description": "question count",
+                    "type": "integer"
                 },
-                "icon": {
-                    "type": "string"
+                "rank": {
+                    "description": "rank",
+                    "type": "integer"
                 },
-                "link": {
+                "status": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "schema.CreateHierarchicalTagReq": {
-            "type": "object",
-            "required": [
-                "display_name",
-                "name",
-                "slug_name"
-            ],
-            "properties": {
-                "description": {
+                "status_msg": {
                     "type": "string"
                 },
-                "display_name": {
-                    "type": "string",
-                    "maxLength": 100
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100
+                "suspended_until": {
+                    "description": "suspended until timestamp",
+                    "type": "integer"
                 },
-                "parent_id": {
+                "username": {
+                    "description": "username",
                     "type": "string"
                 },
-                "slug_name": {
-                    "type": "string",
-                    "maxLength": 100
+                "website": {
+                    "description": "website",
+                    "type": "string"
                 }
             }
         },
-        "schema.DeletePermanentlyReq": {
+        "schema.GetOtherUserInfoResp": {
             "type": "object",
-            "required": [
-                "type"
-            ],
             "properties": {
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "users",
-                        "questions",
-                        "answers"
-                    ]
+                "info": {
+                    "$ref": "#/definitions/schema.GetOtherUserInfoByUsernameResp"
                 }
             }
         },
-        "schema.EditUserProfileReq": {
+        "schema.GetPluginConfigResp": {
             "type": "object",
-            "required": [
-                "display_name",
-                "email",
-                "user_id"
-            ],
             "properties": {
-                "display_name": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "minLength": 2
-                },
-                "email": {
-                    "type": "string",
-                    "maxLength": 500
+                "config_fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.ConfigField"
+                    }
                 },
-                "user_id": {
+                "description": {
                     "type": "string"
                 },
-                "username": {
-                    "type": "string",
-                    "maxLength": 30,
-                    "minLength": 2
-                }
-            }
-        },
-        "schema.ExternalLoginBindingUserSendEmailReq": {
-            "type": "object",
-            "required": [
-                "binding_key",
-                "email"
-            ],
-            "properties": {
-                "binding_key": {
-                    "type": "string",
-                    "maxLength": 100
-                },
-                "email": {
-                    "type": "string",
-                    "maxLength": 512
+                "name": {
+                    "type": "string"
                 },
-                "must": {
-                    "description": "If must is true, whatever email if exists, try to bind user.\nIf must is false, when email exist, will only be prompted with a warning.",
-                    "type": "boolean"
+                "slug_name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
                 }
             }
         },
-        "schema.ExternalLoginBindingUserSendEmailResp": {
+        "schema.GetPluginListResp": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "description": {
                     "type": "string"
                 },
-                "email_exist_and_must_be_confirmed": {
+                "enabled": {
                     "type": "boolean"
-                }
-            }
-        },
-        "schema.ExternalLoginUnbindingReq": {
-            "type": "object",
-            "required": [
-                "external_id"
-            ],
-            "properties": {
-                "external_id": {
-                    "type": "string",
-                    "maxLength": 128
-                }
-            }
-        },
-        "schema.FollowReq": {
-            "type": "object",
-            "required": [
-                "object_id"
-            ],
-            "properties": {
-                "is_cancel": {
-                    "description": "is cancel",
+                },
+                "have_config": {
                     "type": "boolean"
                 },
-                "object_id": {
-                    "description": "object id",
+                "link": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug_name": {
+                    "type": "string"
+                },
+                "version": {
                     "type": "string"
                 }
             }
         },
-        "schema.FollowResp": {
+        "schema.GetPowerResp": {
             "type": "object",
             "properties": {
-                "follows": {
-                    "description": "the followers of object",
+                "description": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "is_followed": {
-                    "description": "if user is followed object will be true,otherwise false",
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
+                },
+                "power_type": {
+                    "type": "string"
                 }
             }
         },
-        "schema.GetAnswerInfoResp": {
+        "schema.GetPrivilegesConfigResp": {
             "type": "object",
             "properties": {
-                "info": {
-                    "$ref": "#/definitions/schema.AnswerInfo"
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.PrivilegeOption"
+                    }
                 },
-                "question": {
-                    "$ref": "#/definitions/schema.QuestionInfoResp"
+                "selected_level": {
+                    "$ref": "#/definitions/schema.PrivilegeLevel"
                 }
             }
         },
-        "schema.GetBadgeInfoResp": {
+        "schema.GetRankPersonalPageResp": {
             "type": "object",
             "properties": {
-                "award_count": {
-                    "description": "badge award count",
-                    "type": "integer"
+                "answer_id": {
+                    "description": "answer id",
+                    "type": "string"
                 },
-                "description": {
-                    "description": "badge description",
+                "content": {
+                    "description": "content",
                     "type": "string"
                 },
-                "earned_count": {
-                    "description": "badge earned count",
+                "created_at": {
+                    "description": "create time",
                     "type": "integer"
                 },
-                "icon": {
-                    "description": "badge icon",
+                "object_id": {
+                    "description": "object id",
                     "type": "string"
                 },
-                "id": {
-                    "description": "badge id",
+                "object_type": {
+                    "description": "object type",
+                    "type": "string",
+                    "enum": [
+                        "question",
+                        "answer",
+                        "tag",
+                        "comment"
+                    ]
+                },
+                "question_id": {
+                    "description": "question id",
                     "type": "string"
                 },
-                "is_single": {
-                    "description": "badge is single or multiple",
-                    "type": "boolean"
+                "rank_type": {
+                    "description": "rank type",
+                    "type": "string"
                 },
-                "level": {
-                    "description": "badge level",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.BadgeLevel"
-                        }
-                    ]
+                "reputation": {
+                    "description": "reputation",
+                    "type": "integer"
                 },
-                "name": {
-                    "description": "badge name",
+                "title": {
+                    "description": "title",
+                    "type": "string"
+                },
+                "url_title": {
+                    "description": "url title",
                     "type": "string"
                 }
             }
         },
-        "schema.GetBadgeListPagedResp": {
+        "schema.GetReportListPageResp": {
             "type": "object",
             "properties": {
-                "award_count": {
-                    "description": "badge award count",
+                "answer_accepted": {
+                    "type": "boolean"
+                },
+                "answer_count": {
                     "type": "integer"
                 },
-                "description": {
-                    "description": "badge description",
+                "answer_id": {
                     "type": "string"
                 },
-                "earned": {
-                    "description": "badge earned count",
-                    "type": "boolean"
+                "author_user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
                 },
-                "group_name": {
-                    "description": "badge group name",
+                "comment_id": {
                     "type": "string"
                 },
-                "icon": {
-                    "description": "badge icon",
+                "created_at": {
+                    "type": "integer"
+                },
+                "flag_id": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "badge id",
+                "object_id": {
                     "type": "string"
                 },
-                "level": {
-                    "description": "badge level",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.BadgeLevel"
-                        }
+                "object_show_status": {
+                    "type": "integer"
+                },
+                "object_status": {
+                    "type": "integer"
+                },
+                "object_type": {
+                    "type": "string",
+                    "enum": [
+                        "question",
+                        "answer",
+                        "comment"
                     ]
                 },
+                "original_text": {
+                    "type": "string"
+                },
+                "parsed_text": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "$ref": "#/definitions/schema.ReasonItem"
+                },
+                "reason_content": {
+                    "type": "string"
+                },
+                "submit_at": {
+                    "type": "integer"
+                },
+                "submitter_user": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.TagResp"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url_title": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.GetReviewingTypeResp": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
                 "name": {
-                    "description": "badge name",
                     "type": "string"
                 },
-                "status": {
-                    "description": "badge status",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/schema.BadgeStatus"
-                        }
-                    ]
+                "todo_amount": {
+                    "type": "integer"
                 }
             }
         },
-        "schema.GetBadgeListResp": {
+        "schema.GetRevisionDiffResp": {
             "type": "object",
             "properties": {
-                "badges": {
-                    "description": "badge list info",
+                "added_tags": {
+                    "description": "AddedTags RemovedTags the slug names of the tags changed, only for questions",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.BadgeListInfo"
+                        "type": "string"
+                    }
+                },
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/diff.Segment"
+                    }
+                },
+                "from": {
+                    "$ref": "#/definitions/schema.GetRevisionResp"
+                },
+                "object_id": {
+                    "type": "string"
+                },
+                "object_type": {
+                    "type": "string"
+                },
+                "removed_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/diff.Segment"
                     }
                 },
-                "group_name": {
-                    "description": "badge group name",
-                    "type": "string"
+                "to": {
+                    "$ref": "#/definitions/schema.GetRevisionResp"
                 }
             }
         },
-        "schema.GetCommentPersonalWithPageResp": {
+        "schema.GetRevisionResp": {
             "type": "object",
             "properties": {
-                "answer_id": {
-                    "description": "answer id",
+                "content": {},
+                "create_at": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
                 },
-                "comment_id": {
-                    "description": "comment id",
+                "object_id": {
                     "type": "string"
                 },
-                "content": {
-                    "description": "content",
+                "reason": {
                     "type": "string"
                 },
-                "created_at": {
-                    "description": "create time",
+                "status": {
                     "type": "integer"
                 },
-                "object_id": {
-                    "description": "object id",
+                "title": {
                     "type": "string"
                 },
-                "object_type": {
-                    "description": "object type",
-                    "type": "string",
-                    "enum": [
-                        "question",
-                        "answer",
-                        "tag",
-                        "comment"
-                    ]
-                },
-                "question_id": {
-                    "description": "question id",
+                "url_title": {
                     "type": "string"
                 },
-                "title": {
-                    "description": "title",
+                "use_id": {
                     "type": "string"
                 },
-                "url_title": {
-                    "description": "url title",
-                    "type": "string"
+                "user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
                 }
             }
         },
-        "schema.GetCommentResp": {
+        "schema.GetRoleResp": {
             "type": "object",
             "properties": {
-                "comment_id": {
-                    "description": "comment id",
+                "built_in": {
+                    "description": "the built-in roles can not be modified",
+                    "type": "boolean"
+                },
+                "description": {
                     "type": "string"
                 },
-                "created_at": {
-                    "description": "create time",
+                "id": {
                     "type": "integer"
                 },
-                "is_vote": {
-                    "description": "current user if already vote this comment",
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
                 },
-                "member_actions": {
-                    "description": "MemberActions",
+                "power_types": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.PermissionMemberAction"
+                        "type": "string"
                     }
-                },
-                "object_id": {
-                    "description": "object id",
-                    "type": "string"
-                },
-                "original_text": {
-                    "description": "original comment content",
-                    "type": "string"
-                },
-                "parsed_text": {
-                    "description": "parsed comment content",
-                    "type": "string"
-                },
-                "reply_comment_id": {
-                    "description": "reply comment id",
+                }
+            }
+        },
+        "schema.GetSMTPConfigResp": {
+            "type": "object",
+            "properties": {
+                "encryption": {
+                    "description": "\"\" SSL TLS",
                     "type": "string"
                 },
-                "reply_user_display_name": {
-                    "description": "reply user display name",
+                "from_email": {
                     "type": "string"
                 },
-                "reply_user_id": {
-                    "description": "reply user id",
+                "from_name": {
                     "type": "string"
                 },
-                "reply_user_status": {
-                    "description": "reply user status",
+                "reply_inbound_secret": {
                     "type": "string"
                 },
-                "reply_username": {
-                    "description": "reply user username",
+                "reply_to_address": {
                     "type": "string"
                 },
-                "user_avatar": {
-                    "description": "user avatar",
-                    "type": "string"
+                "smtp_authentication": {
+                    "type": "boolean"
                 },
-                "user_display_name": {
-                    "description": "user display name",
+                "smtp_host": {
                     "type": "string"
                 },
-                "user_id": {
-                    "description": "user id",
+                "smtp_password": {
                     "type": "string"
                 },
-                "user_status": {
-                    "description": "user status",
-                    "type": "string"
+                "smtp_port": {
+                    "type": "integer"
                 },
-                "username": {
-                    "description": "username",
+                "smtp_username": {
                     "type": "string"
-                },
-                "vote_count": {
-                    "description": "user vote amount",
-                    "type": "integer"
                 }
             }
         },
-        "schema.GetCurrentLoginUserInfoResp": {
+        "schema.GetSearchSyncStatusResp": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "description": "access token",
-                    "type": "string"
+                "cursor": {
+                    "description": "Cursor the id of the last change synced to the plugin",
+                    "type": "integer"
                 },
-                "answer_count": {
-                    "description": "answer count",
+                "lag": {
+                    "description": "Lag seconds since the oldest change not synced yet",
                     "type": "integer"
                 },
-                "authority_group": {
-                    "description": "authority group",
+                "latest_cursor": {
+                    "description": "LatestCursor the id of the latest change",
                     "type": "integer"
                 },
-                "avatar": {
-                    "$ref": "#/definitions/schema.AvatarInfo"
+                "need_reindex": {
+                    "description": "NeedReindex the changes the plugin missed are pruned, it is reindexed once enabled",
+                    "type": "boolean"
                 },
-                "bio": {
-                    "description": "bio markdown",
-                    "type": "string"
+                "pending_count": {
+                    "description": "PendingCount the number of the changes not synced yet",
+                    "type": "integer"
                 },
-                "bio_html": {
-                    "description": "bio html",
+                "plugin_slug_name": {
                     "type": "string"
                 },
-                "color_scheme": {
-                    "description": "Color scheme",
-                    "type": "string"
+                "reindexed_at": {
+                    "type": "integer"
                 },
-                "created_at": {
-                    "description": "create time",
+                "reindexing": {
+                    "type": "boolean"
+                },
+                "synced_at": {
                     "type": "integer"
+                }
+            }
+        },
+        "schema.GetSiteLegalInfoResp": {
+            "type": "object",
+            "properties": {
+                "privacy_policy_original_text": {
+                    "type": "string"
                 },
-                "display_name": {
-                    "description": "display name",
+                "privacy_policy_parsed_text": {
                     "type": "string"
                 },
-                "e_mail": {
-                    "description": "email",
+                "terms_of_service_original_text": {
                     "type": "string"
                 },
-                "follow_count": {
-                    "description": "follow count",
-                    "type": "integer"
+                "terms_of_service_parsed_text": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.GetTagBasicResp": {
+            "type": "object",
+            "properties": {
+                "display_name": {
+                    "type": "string"
                 },
-                "have_password": {
-                    "description": "user have password",
+                "recommend": {
                     "type": "boolean"
                 },
-                "id": {
-                    "description": "user id",
-                    "type": "string"
+                "reserved": {
+                    "type": "boolean"
                 },
-                "language": {
-                    "description": "language",
+                "slug_name": {
                     "type": "string"
                 },
-                "last_login_date": {
-                    "description": "last login date",
+                "tag_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.GetTagPageResp": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "created time",
                     "type": "integer"
                 },
-                "location": {
-                    "description": "location",
+                "description": {
+                    "description": "description",
                     "type": "string"
                 },
-                "mail_status": {
-                    "description": "mail status(1 pass 2 to be verified)",
-                    "type": "integer"
-                },
-                "mobile": {
-                    "description": "mobile",
+                "display_name": {
+                    "description": "display_name",
                     "type": "string"
                 },
-                "notice_status": {
-                    "description": "notice status(1 on 2off)",
-                    "type": "integer"
+                "excerpt": {
+                    "description": "excerpt",
+                    "type": "string"
                 },
-                "question_count": {
-                    "description": "question count",
+                "follow_count": {
+                    "description": "follower amount",
                     "type": "integer"
                 },
-                "rank": {
-                    "description": "rank",
-                    "type": "integer"
+                "is_follower": {
+                    "description": "is follower",
+                    "type": "boolean"
                 },
-                "role_id": {
-                    "description": "role id",
-                    "type": "integer"
+                "original_text": {
+                    "description": "original text",
+                    "type": "string"
                 },
-                "status": {
-                    "description": "user status",
+                "parsed_text": {
+                    "description": "parsed_text",
                     "type": "string"
                 },
-                "suspended_until": {
-                    "description": "suspended until timestamp",
+                "question_count": {
+                    "description": "question amount",
                     "type": "integer"
                 },
-                "username": {
-                    "description": "username",
-                    "type": "string"
+                "recommend": {
+                    "type": "boolean"
                 },
-                "visit_token": {
-                    "description": "visit token",
+                "reserved": {
+                    "type": "boolean"
+                },
+                "slug_name": {
+                    "description": "slug_name",
                     "type": "string"
                 },
-                "website": {
-                    "description": "website",
+                "tag_id": {
+                    "description": "tag_id",
                     "type": "string"
+                },
+                "updated_at": {
+                    "description": "updated time",
+                    "type": "integer"
                 }
             }
         },
-        "schema.GetFollowingTagsResp": {
+        "schema.GetTagResp": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
                 "display_name": {
-                    "description": "display name",
                     "type": "string"
                 },
+                "excerpt": {
+                    "type": "string"
+                },
+                "follow_count": {
+                    "type": "integer"
+                },
+                "is_follower": {
+                    "type": "boolean"
+                },
                 "main_tag_slug_name": {
                     "description": "if main tag slug name is not empty, this tag is synonymous with the main tag",
                     "type": "string"
                 },
+                "member_actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.PermissionMemberAction"
+                    }
+                },
+                "original_text": {
+                    "type": "string"
+                },
+                "parsed_text": {
+                    "type": "string"
+                },
+                "question_count": {
+                    "type": "integer"
+                },
                 "recommend": {
                     "type": "boolean"
                 },
@@ -8984,187 +13622,269 @@ const docTemplate = `{
                     "type": "boolean"
                 },
                 "slug_name": {
-                    "description": "slug name",
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 },
                 "tag_id": {
-                    "description": "tag id",
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
                 }
             }
         },
-        "schema.GetHierarchicalTagsResp": {
+        "schema.GetTagSynonymsResp": {
             "type": "object",
             "properties": {
-                "tags": {
+                "member_actions": {
+                    "description": "MemberActions",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.HierarchicalTagItem"
+                        "$ref": "#/definitions/schema.PermissionMemberAction"
                     }
-                }
-            }
-        },
-        "schema.GetObjectTimelineResp": {
-            "type": "object",
-            "properties": {
-                "object_info": {
-                    "$ref": "#/definitions/schema.ActObjectInfo"
                 },
-                "timeline": {
+                "synonyms": {
+                    "description": "synonyms",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.ActObjectTimeline"
+                        "$ref": "#/definitions/schema.TagSynonym"
                     }
                 }
             }
         },
-        "schema.GetOtherUserInfoByUsernameResp": {
+        "schema.GetUnreviewedPostPageResp": {
             "type": "object",
             "properties": {
-                "answer_count": {
-                    "description": "answer count",
-                    "type": "integer"
-                },
-                "avatar": {
-                    "description": "avatar",
+                "answer_id": {
                     "type": "string"
                 },
-                "bio": {
-                    "description": "bio markdown",
-                    "type": "string"
+                "author_user_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
                 },
-                "bio_html": {
-                    "description": "bio html",
+                "comment_id": {
                     "type": "string"
                 },
                 "created_at": {
-                    "description": "create time",
                     "type": "integer"
                 },
-                "display_name": {
-                    "description": "display name",
+                "object_id": {
                     "type": "string"
                 },
-                "follow_count": {
-                    "description": "email\nfollow count",
+                "object_show_status": {
                     "type": "integer"
                 },
-                "id": {
-                    "description": "user id",
+                "object_status": {
+                    "type": "integer"
+                },
+                "object_type": {
+                    "type": "string",
+                    "enum": [
+                        "question",
+                        "answer",
+                        "comment"
+                    ]
+                },
+                "original_text": {
                     "type": "string"
                 },
-                "last_login_date": {
-                    "description": "last login date",
-                    "type": "integer"
+                "parsed_text": {
+                    "type": "string"
                 },
-                "location": {
-                    "description": "location",
+                "question_id": {
                     "type": "string"
                 },
-                "mobile": {
-                    "description": "mobile",
+                "reason": {
                     "type": "string"
                 },
-                "question_count": {
-                    "description": "question count",
+                "review_id": {
                     "type": "integer"
                 },
-                "rank": {
-                    "description": "rank",
+                "submit_at": {
                     "type": "integer"
                 },
-                "status": {
-                    "type": "string"
-                },
-                "status_msg": {
+                "submitter_display_name": {
                     "type": "string"
                 },
-                "suspended_until": {
-                    "description": "suspended until timestamp",
-                    "type": "integer"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.TagResp"
+                    }
                 },
-                "username": {
-                    "description": "username",
+                "title": {
                     "type": "string"
                 },
-                "website": {
-                    "description": "website",
+                "url_title": {
                     "type": "string"
                 }
             }
         },
-        "schema.GetOtherUserInfoResp": {
+        "schema.GetUnreviewedRevisionResp": {
             "type": "object",
             "properties": {
                 "info": {
-                    "$ref": "#/definitions/schema.GetOtherUserInfoByUsernameResp"
+                    "$ref": "#/definitions/schema.UnreviewedRevisionInfoInfo"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "unreviewed_info": {
+                    "$ref": "#/definitions/schema.GetRevisionResp"
                 }
             }
         },
-        "schema.GetPluginConfigResp": {
+        "schema.GetUserActivationResp": {
             "type": "object",
             "properties": {
-                "config_fields": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.ConfigField"
-                    }
-                },
-                "description": {
+                "activation_url": {
                     "type": "string"
+                }
+            }
+        },
+        "schema.GetUserBadgeAwardListResp": {
+            "type": "object",
+            "properties": {
+                "earned_count": {
+                    "description": "badge award count",
+                    "type": "integer"
                 },
-                "name": {
+                "icon": {
+                    "description": "badge icon",
                     "type": "string"
                 },
-                "slug_name": {
+                "id": {
+                    "description": "badge id",
                     "type": "string"
                 },
-                "version": {
+                "level": {
+                    "description": "badge level",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BadgeLevel"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "badge name",
                     "type": "string"
                 }
             }
         },
-        "schema.GetPluginListResp": {
+        "schema.GetUserNotificationConfigResp": {
             "type": "object",
             "properties": {
-                "description": {
+                "all_new_question": {
+                    "$ref": "#/definitions/schema.NotificationChannelConfig"
+                },
+                "all_new_question_for_following_tags": {
+                    "$ref": "#/definitions/schema.NotificationChannelConfig"
+                },
+                "digest": {
+                    "$ref": "#/definitions/schema.NotificationChannelConfig"
+                },
+                "digest_schedule": {
+                    "$ref": "#/definitions/schema.DigestSchedule"
+                },
+                "inbox": {
+                    "$ref": "#/definitions/schema.NotificationChannelConfig"
+                }
+            }
+        },
+        "schema.GetUserPageResp": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "description": "avatar",
                     "type": "string"
                 },
-                "enabled": {
-                    "type": "boolean"
+                "created_at": {
+                    "description": "create time",
+                    "type": "integer"
                 },
-                "have_config": {
-                    "type": "boolean"
+                "custom_role_ids": {
+                    "description": "custom role ids",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "link": {
+                "deleted_at": {
+                    "description": "delete time",
+                    "type": "integer"
+                },
+                "display_name": {
+                    "description": "display name",
+                    "type": "string"
+                },
+                "e_mail": {
+                    "description": "email",
+                    "type": "string"
+                },
+                "rank": {
+                    "description": "rank",
+                    "type": "integer"
+                },
+                "role_id": {
+                    "description": "role id",
+                    "type": "integer"
+                },
+                "role_name": {
+                    "description": "role name",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "user status(normal,suspended,deleted,inactive)",
+                    "type": "string"
+                },
+                "suspended_at": {
+                    "description": "suspended time",
+                    "type": "integer"
+                },
+                "suspended_until": {
+                    "description": "suspended until time",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "user id",
                     "type": "string"
                 },
+                "username": {
+                    "description": "username",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.GetUserPluginListResp": {
+            "type": "object",
+            "properties": {
                 "name": {
                     "type": "string"
                 },
                 "slug_name": {
                     "type": "string"
-                },
-                "version": {
-                    "type": "string"
                 }
             }
         },
-        "schema.GetPrivilegesConfigResp": {
+        "schema.GetUserStaffResp": {
             "type": "object",
             "properties": {
-                "options": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.PrivilegeOption"
-                    }
+                "avatar": {
+                    "description": "avatar",
+                    "type": "string"
                 },
-                "selected_level": {
-                    "$ref": "#/definitions/schema.PrivilegeLevel"
+                "display_name": {
+                    "description": "display name",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "username",
+                    "type": "string"
                 }
             }
         },
-        "schema.GetRankPersonalPageResp": {
+        "schema.GetVoteWithPageResp": {
             "type": "object",
             "properties": {
                 "answer_id": {
@@ -9197,14 +13917,6 @@ const docTemplate = `{
                     "description": "question id",
                     "type": "string"
                 },
-                "rank_type": {
-                    "description": "rank type",
-                    "type": "string"
-                },
-                "reputation": {
-                    "description": "reputation",
-                    "type": "integer"
-                },
                 "title": {
                     "description": "title",
                     "type": "string"
@@ -9212,352 +13924,417 @@ const docTemplate = `{
                 "url_title": {
                     "description": "url title",
                     "type": "string"
+                },
+                "vote_type": {
+                    "description": "vote type",
+                    "type": "string"
                 }
             }
         },
-        "schema.GetReportListPageResp": {
+        "schema.HierarchicalTagExchangeItem": {
             "type": "object",
             "properties": {
-                "answer_accepted": {
-                    "type": "boolean"
-                },
-                "answer_count": {
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
-                "answer_id": {
+                "display_name": {
                     "type": "string"
                 },
-                "author_user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
+                "parent_slug_name": {
+                    "type": "string"
                 },
-                "comment_id": {
+                "slug_name": {
                     "type": "string"
                 },
-                "created_at": {
+                "sort_order": {
                     "type": "integer"
-                },
-                "flag_id": {
+                }
+            }
+        },
+        "schema.HierarchicalTagImportChange": {
+            "type": "object",
+            "properties": {
+                "action": {
                     "type": "string"
                 },
-                "object_id": {
-                    "type": "string"
+                "after": {
+                    "$ref": "#/definitions/schema.HierarchicalTagExchangeItem"
                 },
-                "object_show_status": {
-                    "type": "integer"
+                "before": {
+                    "$ref": "#/definitions/schema.HierarchicalTagExchangeItem"
+                }
+            }
+        },
+        "schema.HierarchicalTagImportProblem": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
                 },
-                "object_status": {
+                "row": {
                     "type": "integer"
                 },
-                "object_type": {
-                    "type": "string",
-                    "enum": [
-                        "question",
-                        "answer",
-                        "comment"
-                    ]
-                },
-                "original_text": {
+                "slug_name": {
                     "type": "string"
+                }
+            }
+        },
+        "schema.HierarchicalTagItem": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.HierarchicalTagItem"
+                    }
                 },
-                "parsed_text": {
+                "description": {
                     "type": "string"
                 },
-                "question_id": {
+                "display_name": {
                     "type": "string"
                 },
-                "reason": {
-                    "$ref": "#/definitions/schema.ReasonItem"
+                "has_children": {
+                    "type": "boolean"
                 },
-                "reason_content": {
+                "id": {
                     "type": "string"
                 },
-                "submit_at": {
+                "leaf_only": {
+                    "type": "boolean"
+                },
+                "level": {
                     "type": "integer"
                 },
-                "submitter_user": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
+                "name": {
+                    "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.TagResp"
-                    }
+                "parent_id": {
+                    "type": "string"
                 },
-                "title": {
+                "path": {
                     "type": "string"
                 },
-                "url_title": {
+                "slug_name": {
                     "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
                 }
             }
         },
-        "schema.GetReviewingTypeResp": {
+        "schema.HierarchicalTagPathResp": {
             "type": "object",
             "properties": {
-                "label": {
+                "display_path": {
                     "type": "string"
                 },
-                "name": {
+                "path": {
                     "type": "string"
                 },
-                "todo_amount": {
-                    "type": "integer"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.HierarchicalTagItem"
+                    }
                 }
             }
         },
-        "schema.GetRevisionResp": {
+        "schema.HierarchicalTagPermissionResp": {
             "type": "object",
             "properties": {
-                "content": {},
-                "create_at": {
-                    "type": "integer"
-                },
                 "id": {
                     "type": "string"
                 },
-                "object_id": {
-                    "type": "string"
+                "leaf_only": {
+                    "description": "LeafOnly questions can only be filed under the descendants of the tag",
+                    "type": "boolean"
                 },
-                "reason": {
+                "moderators": {
+                    "description": "Moderators users moderating the questions filed under the tag and its subtree",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.UserBasicInfo"
+                    }
+                },
+                "role_ids": {
+                    "description": "RoleIDs roles allowed to file questions under the tag and its subtree, empty means all roles",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "schema.ImportHierarchicalTagsReq": {
+            "type": "object",
+            "required": [
+                "content",
+                "format"
+            ],
+            "properties": {
+                "content": {
                     "type": "string"
                 },
-                "status": {
+                "dry_run": {
+                    "description": "DryRun only returns the diff without applying it",
+                    "type": "boolean"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "json",
+                        "yaml",
+                        "csv"
+                    ]
+                }
+            }
+        },
+        "schema.ImportHierarchicalTagsResp": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.HierarchicalTagImportChange"
+                    }
+                },
+                "created": {
                     "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "url_title": {
-                    "type": "string"
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.HierarchicalTagImportProblem"
+                    }
                 },
-                "use_id": {
-                    "type": "string"
+                "unchanged": {
+                    "type": "integer"
                 },
-                "user_info": {
-                    "$ref": "#/definitions/schema.UserBasicInfo"
+                "updated": {
+                    "type": "integer"
                 }
             }
         },
-        "schema.GetRoleResp": {
+        "schema.ImportSiteSettingsReq": {
             "type": "object",
+            "required": [
+                "content"
+            ],
             "properties": {
-                "description": {
+                "content": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
+                "dry_run": {
+                    "description": "DryRun only checks the file without applying it",
+                    "type": "boolean"
                 }
             }
         },
-        "schema.GetSMTPConfigResp": {
+        "schema.ImportSiteSettingsResp": {
             "type": "object",
             "properties": {
-                "encryption": {
-                    "description": "\"\" SSL TLS",
-                    "type": "string"
+                "dry_run": {
+                    "type": "boolean"
                 },
-                "from_email": {
-                    "type": "string"
+                "plugin_configs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "from_name": {
-                    "type": "string"
+                "plugin_status": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "smtp_authentication": {
+                "privileges": {
                     "type": "boolean"
                 },
-                "smtp_host": {
-                    "type": "string"
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.SiteSettingsImportProblem"
+                    }
                 },
-                "smtp_password": {
+                "site_info": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "skipped_plugins": {
+                    "description": "SkippedPlugins the plugins in the file which are not installed",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.JWKSResp": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jwt.JWK"
+                    }
+                }
+            }
+        },
+        "schema.LoadingAction": {
+            "type": "object",
+            "properties": {
+                "state": {
                     "type": "string"
                 },
-                "smtp_port": {
-                    "type": "integer"
-                },
-                "smtp_username": {
+                "text": {
                     "type": "string"
                 }
             }
         },
-        "schema.GetSiteLegalInfoResp": {
+        "schema.ModerateItemResult": {
             "type": "object",
             "properties": {
-                "privacy_policy_original_text": {
+                "item_id": {
                     "type": "string"
                 },
-                "privacy_policy_parsed_text": {
+                "item_type": {
                     "type": "string"
                 },
-                "terms_of_service_original_text": {
+                "msg": {
+                    "description": "Msg the reason why the action failed",
                     "type": "string"
                 },
-                "terms_of_service_parsed_text": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "schema.GetTagBasicResp": {
+        "schema.ModerateItemsReq": {
             "type": "object",
+            "required": [
+                "action",
+                "items"
+            ],
             "properties": {
-                "display_name": {
-                    "type": "string"
-                },
-                "recommend": {
-                    "type": "boolean"
-                },
-                "reserved": {
-                    "type": "boolean"
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "approve",
+                        "reject",
+                        "delete"
+                    ]
                 },
-                "slug_name": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/schema.ModerationItem"
+                    }
                 },
-                "tag_id": {
-                    "type": "string"
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
                 }
             }
         },
-        "schema.GetTagPageResp": {
+        "schema.ModerationClaimReq": {
             "type": "object",
+            "required": [
+                "item_id",
+                "item_type"
+            ],
             "properties": {
-                "created_at": {
-                    "description": "created time",
-                    "type": "integer"
-                },
-                "description": {
-                    "description": "description",
-                    "type": "string"
-                },
-                "display_name": {
-                    "description": "display_name",
-                    "type": "string"
-                },
-                "excerpt": {
-                    "description": "excerpt",
+                "item_id": {
                     "type": "string"
                 },
-                "follow_count": {
-                    "description": "follower amount",
+                "item_type": {
+                    "type": "string",
+                    "enum": [
+                        "report",
+                        "review",
+                        "revision"
+                    ]
+                }
+            }
+        },
+        "schema.ModerationClaimResp": {
+            "type": "object",
+            "properties": {
+                "expired_at": {
                     "type": "integer"
                 },
-                "is_follower": {
-                    "description": "is follower",
-                    "type": "boolean"
-                },
-                "original_text": {
-                    "description": "original text",
-                    "type": "string"
-                },
-                "parsed_text": {
-                    "description": "parsed_text",
+                "item_id": {
                     "type": "string"
                 },
-                "question_count": {
-                    "description": "question amount",
-                    "type": "integer"
-                },
-                "recommend": {
-                    "type": "boolean"
-                },
-                "reserved": {
-                    "type": "boolean"
-                },
-                "slug_name": {
-                    "description": "slug_name",
+                "item_type": {
                     "type": "string"
-                },
-                "tag_id": {
-                    "description": "tag_id",
+                }
+            }
+        },
+        "schema.ModerationItem": {
+            "type": "object",
+            "required": [
+                "item_id",
+                "item_type"
+            ],
+            "properties": {
+                "item_id": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "description": "updated time",
-                    "type": "integer"
+                "item_type": {
+                    "type": "string",
+                    "enum": [
+                        "report",
+                        "review",
+                        "revision"
+                    ]
                 }
             }
         },
-        "schema.GetTagResp": {
+        "schema.ModerationLogResp": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "integer"
-                },
-                "description": {
+                "action": {
                     "type": "string"
                 },
-                "display_name": {
-                    "type": "string"
+                "after": {
+                    "$ref": "#/definitions/schema.ModerationSnapshot"
                 },
-                "excerpt": {
-                    "type": "string"
+                "before": {
+                    "$ref": "#/definitions/schema.ModerationSnapshot"
                 },
-                "follow_count": {
+                "created_at": {
                     "type": "integer"
                 },
-                "is_follower": {
-                    "type": "boolean"
-                },
-                "main_tag_slug_name": {
-                    "description": "if main tag slug name is not empty, this tag is synonymous with the main tag",
-                    "type": "string"
-                },
-                "member_actions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.PermissionMemberAction"
-                    }
-                },
-                "original_text": {
-                    "type": "string"
-                },
-                "parsed_text": {
-                    "type": "string"
-                },
-                "question_count": {
+                "id": {
                     "type": "integer"
                 },
-                "recommend": {
-                    "type": "boolean"
-                },
-                "reserved": {
-                    "type": "boolean"
-                },
-                "slug_name": {
+                "item_id": {
                     "type": "string"
                 },
-                "status": {
+                "item_type": {
                     "type": "string"
                 },
-                "tag_id": {
+                "object_id": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "integer"
-                }
-            }
-        },
-        "schema.GetTagSynonymsResp": {
-            "type": "object",
-            "properties": {
-                "member_actions": {
-                    "description": "MemberActions",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.PermissionMemberAction"
-                    }
+                "operator_info": {
+                    "$ref": "#/definitions/schema.UserBasicInfo"
                 },
-                "synonyms": {
-                    "description": "synonyms",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.TagSynonym"
-                    }
+                "reason": {
+                    "type": "string"
                 }
             }
         },
-        "schema.GetUnreviewedPostPageResp": {
+        "schema.ModerationQueueItem": {
             "type": "object",
             "properties": {
                 "answer_id": {
@@ -9566,30 +14343,30 @@ const docTemplate = `{
                 "author_user_info": {
                     "$ref": "#/definitions/schema.UserBasicInfo"
                 },
+                "claim_expired_at": {
+                    "type": "integer"
+                },
+                "claim_user_info": {
+                    "description": "ClaimUserInfo the moderator who is working on the item, it is empty if nobody claims it",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.UserBasicInfo"
+                        }
+                    ]
+                },
                 "comment_id": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "integer"
-                },
-                "object_id": {
+                "item_id": {
                     "type": "string"
                 },
-                "object_show_status": {
-                    "type": "integer"
+                "item_type": {
+                    "type": "string"
                 },
-                "object_status": {
-                    "type": "integer"
+                "object_id": {
+                    "type": "string"
                 },
                 "object_type": {
-                    "type": "string",
-                    "enum": [
-                        "question",
-                        "answer",
-                        "comment"
-                    ]
-                },
-                "original_text": {
                     "type": "string"
                 },
                 "parsed_text": {
@@ -9599,17 +14376,12 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "reason": {
+                    "description": "Reason the content of the report or the reason why the post needs review",
                     "type": "string"
                 },
-                "review_id": {
-                    "type": "integer"
-                },
                 "submit_at": {
                     "type": "integer"
                 },
-                "submitter_display_name": {
-                    "type": "string"
-                },
                 "tags": {
                     "type": "array",
                     "items": {
@@ -9624,300 +14396,310 @@ const docTemplate = `{
                 }
             }
         },
-        "schema.GetUnreviewedRevisionResp": {
+        "schema.ModerationSnapshot": {
             "type": "object",
             "properties": {
-                "info": {
-                    "$ref": "#/definitions/schema.UnreviewedRevisionInfoInfo"
+                "item_status": {
+                    "type": "integer"
                 },
-                "type": {
-                    "type": "string"
+                "object_show_status": {
+                    "type": "integer"
                 },
-                "unreviewed_info": {
-                    "$ref": "#/definitions/schema.GetRevisionResp"
+                "object_status": {
+                    "type": "integer"
                 }
             }
         },
-        "schema.GetUserActivationResp": {
+        "schema.MoveHierarchicalTagReq": {
             "type": "object",
+            "required": [
+                "id"
+            ],
             "properties": {
-                "activation_url": {
+                "id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "ParentID new parent tag ID, empty means moving to the root level",
                     "type": "string"
                 }
             }
         },
-        "schema.GetUserBadgeAwardListResp": {
+        "schema.NotificationChannelConfig": {
             "type": "object",
             "properties": {
-                "earned_count": {
-                    "description": "badge award count",
-                    "type": "integer"
-                },
-                "icon": {
-                    "description": "badge icon",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "badge id",
-                    "type": "string"
-                },
-                "level": {
-                    "description": "badge level",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/entity.BadgeLevel"
-                        }
-                    ]
+                "enable": {
+                    "type": "boolean"
                 },
-                "name": {
-                    "description": "badge name",
+                "key": {
+                    "$ref": "#/definitions/constant.NotificationChannelKey"
+                }
+            }
+        },
+        "schema.NotificationClearIDRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string"
                 }
             }
         },
-        "schema.GetUserNotificationConfigResp": {
+        "schema.NotificationClearRequest": {
             "type": "object",
+            "required": [
+                "type"
+            ],
             "properties": {
-                "all_new_question": {
-                    "$ref": "#/definitions/schema.NotificationChannelConfig"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "inbox",
+                        "achievement"
+                    ]
+                }
+            }
+        },
+        "schema.OAuthAuthorizeInfoResp": {
+            "type": "object",
+            "properties": {
+                "client_name": {
+                    "type": "string"
                 },
-                "all_new_question_for_following_tags": {
-                    "$ref": "#/definitions/schema.NotificationChannelConfig"
+                "consented": {
+                    "description": "Consented the user has granted these scopes to the client before, the page can approve it without asking",
+                    "type": "boolean"
                 },
-                "inbox": {
-                    "$ref": "#/definitions/schema.NotificationChannelConfig"
+                "redirect_url": {
+                    "description": "RedirectURL it is set when the request is invalid, the user should be sent back to the client with the error",
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "schema.GetUserPageResp": {
+        "schema.OAuthClientItem": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "description": "avatar",
+                "client_id": {
                     "type": "string"
                 },
                 "created_at": {
-                    "description": "create time",
-                    "type": "integer"
-                },
-                "deleted_at": {
-                    "description": "delete time",
                     "type": "integer"
                 },
-                "display_name": {
-                    "description": "display name",
+                "id": {
                     "type": "string"
                 },
-                "e_mail": {
-                    "description": "email",
+                "name": {
                     "type": "string"
                 },
-                "rank": {
-                    "description": "rank",
-                    "type": "integer"
+                "public": {
+                    "type": "boolean"
                 },
-                "role_id": {
-                    "description": "role id",
-                    "type": "integer"
+                "redirect_uris": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "role_name": {
-                    "description": "role name",
-                    "type": "string"
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.OAuthConsentReq": {
+            "type": "object",
+            "required": [
+                "client_id"
+            ],
+            "properties": {
+                "approve": {
+                    "type": "boolean"
                 },
-                "status": {
-                    "description": "user status(normal,suspended,deleted,inactive)",
+                "client_id": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "code_challenge": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "code_challenge_method": {
                     "type": "string"
                 },
-                "suspended_at": {
-                    "description": "suspended time",
-                    "type": "integer"
+                "nonce": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "suspended_until": {
-                    "description": "suspended until time",
-                    "type": "integer"
+                "redirect_uri": {
+                    "type": "string",
+                    "maxLength": 1024
                 },
-                "user_id": {
-                    "description": "user id",
+                "response_type": {
                     "type": "string"
                 },
-                "username": {
-                    "description": "username",
-                    "type": "string"
+                "scope": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "state": {
+                    "type": "string",
+                    "maxLength": 1024
                 }
             }
         },
-        "schema.GetUserPluginListResp": {
+        "schema.OAuthConsentResp": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "slug_name": {
+                "redirect_url": {
+                    "description": "RedirectURL send the user back to the client with the code or the error",
                     "type": "string"
                 }
             }
         },
-        "schema.GetUserStaffResp": {
+        "schema.OAuthErrorResp": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "description": "avatar",
-                    "type": "string"
-                },
-                "display_name": {
-                    "description": "display name",
+                "error": {
                     "type": "string"
                 },
-                "username": {
-                    "description": "username",
+                "error_description": {
                     "type": "string"
                 }
             }
         },
-        "schema.GetVoteWithPageResp": {
+        "schema.OAuthTokenResp": {
             "type": "object",
             "properties": {
-                "answer_id": {
-                    "description": "answer id",
-                    "type": "string"
-                },
-                "content": {
-                    "description": "content",
+                "access_token": {
                     "type": "string"
                 },
-                "created_at": {
-                    "description": "create time",
+                "expires_in": {
                     "type": "integer"
                 },
-                "object_id": {
-                    "description": "object id",
-                    "type": "string"
-                },
-                "object_type": {
-                    "description": "object type",
-                    "type": "string",
-                    "enum": [
-                        "question",
-                        "answer",
-                        "tag",
-                        "comment"
-                    ]
-                },
-                "question_id": {
-                    "description": "question id",
+                "id_token": {
                     "type": "string"
                 },
-                "title": {
-                    "description": "title",
+                "refresh_token": {
                     "type": "string"
                 },
-                "url_title": {
-                    "description": "url title",
+                "scope": {
                     "type": "string"
                 },
-                "vote_type": {
-                    "description": "vote type",
+                "token_type": {
                     "type": "string"
                 }
             }
         },
-        "schema.HierarchicalTagItem": {
+        "schema.OAuthUserInfoResp": {
             "type": "object",
             "properties": {
-                "children": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.HierarchicalTagItem"
-                    }
-                },
-                "description": {
-                    "type": "string"
-                },
-                "display_name": {
+                "email": {
                     "type": "string"
                 },
-                "has_children": {
+                "email_verified": {
                     "type": "boolean"
                 },
-                "id": {
+                "locale": {
                     "type": "string"
                 },
-                "level": {
-                    "type": "integer"
-                },
                 "name": {
                     "type": "string"
                 },
-                "parent_id": {
+                "picture": {
                     "type": "string"
                 },
-                "path": {
+                "preferred_username": {
                     "type": "string"
                 },
-                "slug_name": {
+                "profile": {
+                    "type": "string"
+                },
+                "sub": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
+                },
+                "website": {
                     "type": "string"
                 }
             }
         },
-        "schema.HierarchicalTagPathResp": {
+        "schema.OIDCDiscoveryResp": {
             "type": "object",
             "properties": {
-                "display_path": {
+                "authorization_endpoint": {
                     "type": "string"
                 },
-                "path": {
-                    "type": "string"
+                "claims_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "tags": {
+                "code_challenge_methods_supported": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schema.HierarchicalTagItem"
+                        "type": "string"
                     }
-                }
-            }
-        },
-        "schema.LoadingAction": {
-            "type": "object",
-            "properties": {
-                "state": {
+                },
+                "grant_types_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id_token_signing_alg_values_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "issuer": {
                     "type": "string"
                 },
-                "text": {
+                "jwks_uri": {
                     "type": "string"
-                }
-            }
-        },
-        "schema.NotificationChannelConfig": {
-            "type": "object",
-            "properties": {
-                "enable": {
-                    "type": "boolean"
                 },
-                "key": {
-                    "$ref": "#/definitions/constant.NotificationChannelKey"
-                }
-            }
-        },
-        "schema.NotificationClearIDRequest": {
-            "type": "object",
-            "properties": {
-                "id": {
+                "response_types_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "revocation_endpoint": {
+                    "type": "string"
+                },
+                "scopes_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "subject_types_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token_endpoint": {
+                    "type": "string"
+                },
+                "token_endpoint_auth_methods_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "userinfo_endpoint": {
                     "type": "string"
-                }
-            }
-        },
-        "schema.NotificationClearRequest": {
-            "type": "object",
-            "required": [
-                "type"
-            ],
-            "properties": {
-                "type": {
-                    "type": "string",
-                    "enum": [
-                        "inbox",
-                        "achievement"
-                    ]
                 }
             }
         },
@@ -9996,6 +14778,36 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.PersonalAccessTokenItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "expired_at": {
+                    "description": "ExpiredAt the token never expires if it is 0",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token_prefix": {
+                    "type": "string"
+                }
+            }
+        },
         "schema.PostRenderReq": {
             "type": "object",
             "properties": {
@@ -10040,6 +14852,7 @@ const docTemplate = `{
             "type": "object",
             "required": [
                 "content",
+                "hierarchical_tag_ids",
                 "tags",
                 "title"
             ],
@@ -10057,6 +14870,18 @@ const docTemplate = `{
                     "maxLength": 65535,
                     "minLength": 6
                 },
+                "hierarchical_tag_ids": {
+                    "description": "hierarchical tag ids",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "publish_at": {
+                    "description": "publish at, unix timestamp in seconds, the question is published immediately when it is empty",
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "tags": {
                     "description": "tags",
                     "type": "array",
@@ -10077,6 +14902,7 @@ const docTemplate = `{
             "required": [
                 "answer_content",
                 "content",
+                "hierarchical_tag_ids",
                 "tags",
                 "title"
             ],
@@ -10099,6 +14925,13 @@ const docTemplate = `{
                     "maxLength": 65535,
                     "minLength": 6
                 },
+                "hierarchical_tag_ids": {
+                    "description": "hierarchical tag ids",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "mention_username_list": {
                     "type": "array",
                     "items": {
@@ -10120,6 +14953,39 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.QuestionAutoCloseRuleItem": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "close_msg": {
+                    "type": "string"
+                },
+                "close_type": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inactive_days": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "no_answer": {
+                    "type": "boolean"
+                },
+                "tag": {
+                    "description": "Tag slug name, the rule applies to all questions when it is empty",
+                    "type": "string"
+                }
+            }
+        },
         "schema.QuestionInfoResp": {
             "type": "object",
             "properties": {
@@ -10162,6 +15028,12 @@ const docTemplate = `{
                 "follow_count": {
                     "type": "integer"
                 },
+                "hierarchical_tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.HierarchicalTagItem"
+                    }
+                },
                 "html": {
                     "type": "string"
                 },
@@ -10234,6 +15106,11 @@ const docTemplate = `{
         "schema.QuestionPageReq": {
             "type": "object",
             "properties": {
+                "category": {
+                    "description": "Category slug path of a hierarchical tag such as backend/go, questions in its subtree are included",
+                    "type": "string",
+                    "maxLength": 500
+                },
                 "in_days": {
                     "type": "integer",
                     "minimum": 1
@@ -10379,6 +15256,7 @@ const docTemplate = `{
             "type": "object",
             "required": [
                 "content",
+                "hierarchical_tag_ids",
                 "id",
                 "tags",
                 "title"
@@ -10401,6 +15279,13 @@ const docTemplate = `{
                     "description": "edit summary",
                     "type": "string"
                 },
+                "hierarchical_tag_ids": {
+                    "description": "hierarchical tag ids, keep the current ones when omitted and remove all of them when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "description": "question id",
                     "type": "string"
@@ -10450,6 +15335,35 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.QueueMessageItem": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "queue": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
         "schema.ReactionRespItem": {
             "type": "object",
             "properties": {
@@ -10516,6 +15430,17 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.ReindexSearchReq": {
+            "type": "object",
+            "required": [
+                "plugin_slug_name"
+            ],
+            "properties": {
+                "plugin_slug_name": {
+                    "type": "string"
+                }
+            }
+        },
         "schema.RemoveAnswerReq": {
             "type": "object",
             "required": [
@@ -10551,6 +15476,25 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.RemoveDraftReq": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "object_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "question",
+                        "answer",
+                        "edit"
+                    ]
+                }
+            }
+        },
         "schema.RemoveQuestionReq": {
             "type": "object",
             "required": [
@@ -10570,6 +15514,17 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.RemoveRoleReq": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
         "schema.RemoveTagReq": {
             "type": "object",
             "required": [
@@ -10590,6 +15545,67 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.ReorderHierarchicalTagsReq": {
+            "type": "object",
+            "required": [
+                "tag_ids"
+            ],
+            "properties": {
+                "parent_id": {
+                    "description": "ParentID parent tag ID, empty means sorting the root tags",
+                    "type": "string"
+                },
+                "tag_ids": {
+                    "description": "TagIDs the children in their new order, the ones not given are placed after them",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.ReplayQueueMessagesReq": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "schema.ReplayQueueMessagesResp": {
+            "type": "object",
+            "properties": {
+                "replayed": {
+                    "description": "Replayed the number of messages put back to the queue, processing messages are skipped",
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.ResetOAuthClientSecretReq": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ResetOAuthClientSecretResp": {
+            "type": "object",
+            "properties": {
+                "client_secret": {
+                    "type": "string"
+                }
+            }
+        },
         "schema.ReviewReportReq": {
             "type": "object",
             "required": [
@@ -10621,6 +15637,11 @@ const docTemplate = `{
                         "ignore_report"
                     ]
                 },
+                "reason": {
+                    "description": "Reason why the moderator takes the action, it is saved in the moderation log",
+                    "type": "string",
+                    "maxLength": 500
+                },
                 "tags": {
                     "type": "array",
                     "items": {
@@ -10645,9 +15666,89 @@ const docTemplate = `{
                     "description": "object id",
                     "type": "string"
                 },
-                "operation": {
-                    "description": "approve or reject",
-                    "type": "string"
+                "operation": {
+                    "description": "approve or reject",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason why the moderator takes the action, it is saved in the moderation log",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "schema.RevokePersonalAccessTokenReq": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.RollbackRevisionReq": {
+            "type": "object",
+            "required": [
+                "revision_id"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "type": "string"
+                },
+                "edit_summary": {
+                    "type": "string"
+                },
+                "revision_id": {
+                    "description": "the old revision id",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.SaveDraftReq": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "base_revision_id": {
+                    "description": "BaseRevisionID the revision the editor loaded, only used by the edit drafts when the draft is created",
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string",
+                    "maxLength": 65535
+                },
+                "object_id": {
+                    "description": "ObjectID the question id of the answer drafts, the question or answer id of the edit drafts",
+                    "type": "string"
+                },
+                "rebase": {
+                    "description": "Rebase the user has resolved the conflict, the draft starts from the latest revision from now on",
+                    "type": "boolean"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 150
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "question",
+                        "answer",
+                        "edit"
+                    ]
                 }
             }
         },
@@ -11089,6 +16190,64 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.SiteLocalReviewer": {
+            "type": "object",
+            "required": [
+                "match_status"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "keywords": {
+                    "description": "Keywords the post containing any of them is not approved, case insensitive",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "match_status": {
+                    "description": "MatchStatus the verdict when a keyword or pattern matches, need_review or delete_directly",
+                    "type": "string",
+                    "enum": [
+                        "need_review",
+                        "delete_directly"
+                    ]
+                },
+                "max_link_density": {
+                    "description": "MaxLinkDensity the max links per 100 words, 0 means no limit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "max_links": {
+                    "description": "MaxLinks the max links in a post, 0 means no limit",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "new_account_days": {
+                    "description": "NewAccountDays the authors registered in the last days are new accounts",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "new_account_max_links": {
+                    "description": "NewAccountMaxLinks the max links in a post of the new accounts",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "new_account_rank": {
+                    "description": "NewAccountRank the authors whose reputation is lower than it are new accounts",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "patterns": {
+                    "description": "Patterns the post matching any of the regular expressions is not approved",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "schema.SiteLoginReq": {
             "type": "object",
             "properties": {
@@ -11135,6 +16294,249 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.SiteRateLimitReq": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "new_user_rank": {
+                    "description": "the users whose rank is lower than this are limited by the new user quota",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.SiteRateLimitRule"
+                    }
+                }
+            }
+        },
+        "schema.SiteRateLimitResp": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "new_user_rank": {
+                    "description": "the users whose rank is lower than this are limited by the new user quota",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.SiteRateLimitRule"
+                    }
+                }
+            }
+        },
+        "schema.SiteRateLimitRule": {
+            "type": "object",
+            "required": [
+                "group",
+                "window"
+            ],
+            "properties": {
+                "anonymous_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "group": {
+                    "type": "string",
+                    "enum": [
+                        "question",
+                        "answer",
+                        "comment",
+                        "edit",
+                        "vote",
+                        "report",
+                        "search",
+                        "upload"
+                    ]
+                },
+                "new_user_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "per_ip": {
+                    "description": "count the requests by ip even if the user is logged in, so the users sharing the same ip share the quota",
+                    "type": "boolean"
+                },
+                "user_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "window": {
+                    "description": "window size in seconds",
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 1
+                }
+            }
+        },
+        "schema.SiteReviewReq": {
+            "type": "object",
+            "required": [
+                "delete_action",
+                "delete_threshold",
+                "local_reviewer",
+                "need_review_action",
+                "review_threshold"
+            ],
+            "properties": {
+                "delete_action": {
+                    "description": "DeleteAction the action when the verdict of the pipeline is delete",
+                    "type": "string",
+                    "enum": [
+                        "hold",
+                        "shadow_hide",
+                        "delete"
+                    ]
+                },
+                "delete_threshold": {
+                    "description": "DeleteThreshold the post is deleted if the total weight of the reviewers which ask to delete it reaches it",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "local_reviewer": {
+                    "$ref": "#/definitions/schema.SiteLocalReviewer"
+                },
+                "need_review_action": {
+                    "description": "NeedReviewAction the action when the verdict of the pipeline is need review",
+                    "type": "string",
+                    "enum": [
+                        "hold",
+                        "shadow_hide",
+                        "delete"
+                    ]
+                },
+                "rejection_window_days": {
+                    "description": "RejectionWindowDays only the rejections in the last days are counted",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1
+                },
+                "review_threshold": {
+                    "description": "ReviewThreshold the post is held if the total weight of the reviewers which do not approve it reaches it",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "reviewers": {
+                    "description": "Reviewers the reviewers run in the order, the enabled reviewer plugins which are not listed run after them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.SiteReviewer"
+                    }
+                },
+                "suspend_after_rejections": {
+                    "description": "SuspendAfterRejections suspend the author once the posts are rejected so many times in the window, 0 means never",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "suspend_days": {
+                    "description": "SuspendDays how long the author is suspended, 0 means forever",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "schema.SiteReviewResp": {
+            "type": "object",
+            "required": [
+                "delete_action",
+                "delete_threshold",
+                "local_reviewer",
+                "need_review_action",
+                "review_threshold"
+            ],
+            "properties": {
+                "delete_action": {
+                    "description": "DeleteAction the action when the verdict of the pipeline is delete",
+                    "type": "string",
+                    "enum": [
+                        "hold",
+                        "shadow_hide",
+                        "delete"
+                    ]
+                },
+                "delete_threshold": {
+                    "description": "DeleteThreshold the post is deleted if the total weight of the reviewers which ask to delete it reaches it",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "local_reviewer": {
+                    "$ref": "#/definitions/schema.SiteLocalReviewer"
+                },
+                "need_review_action": {
+                    "description": "NeedReviewAction the action when the verdict of the pipeline is need review",
+                    "type": "string",
+                    "enum": [
+                        "hold",
+                        "shadow_hide",
+                        "delete"
+                    ]
+                },
+                "rejection_window_days": {
+                    "description": "RejectionWindowDays only the rejections in the last days are counted",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1
+                },
+                "review_threshold": {
+                    "description": "ReviewThreshold the post is held if the total weight of the reviewers which do not approve it reaches it",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "reviewers": {
+                    "description": "Reviewers the reviewers run in the order, the enabled reviewer plugins which are not listed run after them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.SiteReviewer"
+                    }
+                },
+                "suspend_after_rejections": {
+                    "description": "SuspendAfterRejections suspend the author once the posts are rejected so many times in the window, 0 means never",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "suspend_days": {
+                    "description": "SuspendDays how long the author is suspended, 0 means forever",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "schema.SiteReviewer": {
+            "type": "object",
+            "required": [
+                "slug_name"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "slug_name": {
+                    "description": "SlugName the slug name of the reviewer plugin, or local_reviewer for the built-in reviewer",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "stop_on_approve": {
+                    "description": "StopOnApprove the later reviewers are skipped if this reviewer approves the post",
+                    "type": "boolean"
+                },
+                "stop_on_reject": {
+                    "description": "StopOnReject the later reviewers are skipped if this reviewer does not approve the post",
+                    "type": "boolean"
+                },
+                "weight": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                }
+            }
+        },
         "schema.SiteSeoReq": {
             "type": "object",
             "required": [
@@ -11169,6 +16571,24 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.SiteSettingsImportProblem": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "key": {
+                    "description": "Key the site type or the plugin slug name",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "section": {
+                    "type": "string"
+                }
+            }
+        },
         "schema.SiteThemeReq": {
             "type": "object",
             "required": [
@@ -11445,6 +16865,17 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.TestWebhookReq": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
         "schema.ThemeOption": {
             "type": "object",
             "properties": {
@@ -11500,32 +16931,101 @@ const docTemplate = `{
                 "object_creator_user_id": {
                     "type": "string"
                 },
-                "object_id": {
+                "object_id": {
+                    "type": "string"
+                },
+                "object_type": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "string"
+                },
+                "show_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.TagResp"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url_title": {
+                    "type": "string"
+                }
+            }
+        },
+        "schema.UpdateBadgeGroupReq": {
+            "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                }
+            }
+        },
+        "schema.UpdateBadgeReq": {
+            "type": "object",
+            "required": [
+                "group_id",
+                "icon",
+                "id",
+                "level",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 4096
+                },
+                "group_id": {
                     "type": "string"
                 },
-                "object_type": {
-                    "type": "string"
+                "icon": {
+                    "type": "string",
+                    "maxLength": 1024
                 },
-                "question_id": {
+                "id": {
                     "type": "string"
                 },
-                "show_status": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "integer"
+                "is_single": {
+                    "type": "boolean"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schema.TagResp"
-                    }
+                "level": {
+                    "enum": [
+                        1,
+                        2,
+                        3
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.BadgeLevel"
+                        }
+                    ]
                 },
-                "title": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
                 },
-                "url_title": {
-                    "type": "string"
+                "rule": {
+                    "description": "Rule nil keeps the current rule, the built-in badges keep their handlers unless a rule is given",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schema.BadgeRule"
+                        }
+                    ]
                 }
             }
         },
@@ -11588,6 +17088,33 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UpdateHierarchicalTagPermissionReq": {
+            "type": "object",
+            "required": [
+                "id",
+                "moderator_user_ids"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "leaf_only": {
+                    "type": "boolean"
+                },
+                "moderator_user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "role_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
         "schema.UpdateHierarchicalTagReq": {
             "type": "object",
             "required": [
@@ -11647,6 +17174,37 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UpdateOAuthClientReq": {
+            "type": "object",
+            "required": [
+                "id",
+                "name",
+                "redirect_uris",
+                "scopes"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "redirect_uris": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "schema.UpdatePluginConfigReq": {
             "type": "object",
             "required": [
@@ -11700,6 +17258,46 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UpdateQuestionAutoCloseRuleReq": {
+            "type": "object",
+            "required": [
+                "close_type",
+                "id",
+                "inactive_days",
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "close_msg": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "close_type": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inactive_days": {
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "no_answer": {
+                    "type": "boolean"
+                },
+                "tag": {
+                    "type": "string",
+                    "maxLength": 35
+                }
+            }
+        },
         "schema.UpdateReactionReq": {
             "type": "object",
             "required": [
@@ -11735,6 +17333,11 @@ const docTemplate = `{
                 "status"
             ],
             "properties": {
+                "reason": {
+                    "description": "Reason why the moderator takes the action, it is saved in the moderation log",
+                    "type": "string",
+                    "maxLength": 500
+                },
                 "review_id": {
                     "type": "integer"
                 },
@@ -11747,6 +17350,33 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UpdateRoleReq": {
+            "type": "object",
+            "required": [
+                "id",
+                "name",
+                "power_types"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "power_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "schema.UpdateSMTPConfigReq": {
             "type": "object",
             "properties": {
@@ -11766,6 +17396,15 @@ const docTemplate = `{
                     "type": "string",
                     "maxLength": 256
                 },
+                "reply_inbound_secret": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 16
+                },
+                "reply_to_address": {
+                    "type": "string",
+                    "maxLength": 256
+                },
                 "smtp_authentication": {
                     "type": "boolean"
                 },
@@ -11841,6 +17480,25 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UpdateUserCustomRolesReq": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "role_ids": {
+                    "description": "custom role ids, remove all custom roles when empty",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "user_id": {
+                    "description": "user id",
+                    "type": "string"
+                }
+            }
+        },
         "schema.UpdateUserInterfaceRequest": {
             "type": "object",
             "required": [
@@ -11869,6 +17527,12 @@ const docTemplate = `{
                 "all_new_question_for_following_tags": {
                     "$ref": "#/definitions/schema.NotificationChannelConfig"
                 },
+                "digest": {
+                    "$ref": "#/definitions/schema.NotificationChannelConfig"
+                },
+                "digest_schedule": {
+                    "$ref": "#/definitions/schema.DigestSchedule"
+                },
                 "inbox": {
                     "$ref": "#/definitions/schema.NotificationChannelConfig"
                 }
@@ -11964,6 +17628,42 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.UpdateWebhookReq": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "id",
+                "name",
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "secret": {
+                    "description": "Secret the new secret, the current one is kept when empty",
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 1024
+                }
+            }
+        },
         "schema.UserBasicInfo": {
             "type": "object",
             "properties": {
@@ -12357,6 +18057,71 @@ const docTemplate = `{
                 }
             }
         },
+        "schema.WebhookDeliveryItem": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "delivery_id": {
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "Duration milliseconds",
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "request_body": {
+                    "type": "string"
+                },
+                "response_body": {
+                    "type": "string"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "schema.WebhookItem": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "translator.LangOption": {
             "type": "object",
             "properties": {
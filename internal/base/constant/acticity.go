@@ -56,6 +56,7 @@ const (
 	ActQuestionUnPin     ActivityTypeKey = "question.unpin"
 	ActQuestionHide      ActivityTypeKey = "question.hide"
 	ActQuestionShow      ActivityTypeKey = "question.show"
+	ActQuestionPublished ActivityTypeKey = "question.published"
 )
 
 const (
@@ -52,4 +52,6 @@ const (
 	RateLimitCacheTime                         = 5 * time.Minute
 	RedDotCacheKey                             = "answer:red-dot:%s:%s"
 	RedDotCacheTime                            = 30 * 24 * time.Hour
+	OAuthAccessTokenCacheKey                   = "answer:oauth:access-token:"
+	OAuthAccessTokenCacheTime                  = time.Hour
 )
@@ -43,4 +43,7 @@ const (
 
 	EmailTplKeyNewQuestionTitle = "email_tpl.new_question.title"
 	EmailTplKeyNewQuestionBody  = "email_tpl.new_question.body"
+
+	EmailTplKeyDigestTitle = "email_tpl.digest.title"
+	EmailTplKeyDigestBody  = "email_tpl.digest.body"
 )
@@ -73,3 +73,26 @@ const (
 	EventCommentVote   EventType = eventComment + "." + eventVote
 	EventCommentFlag   EventType = eventComment + "." + eventFlag
 )
+
+// EventTypes all the event types, used to validate the subscriptions
+var EventTypes = []EventType{
+	EventUserUpdate, EventUserShare,
+	EventQuestionCreate, EventQuestionUpdate, EventQuestionDelete, EventQuestionVote,
+	EventQuestionAccept, EventQuestionFlag, EventQuestionReact,
+	EventAnswerCreate, EventAnswerUpdate, EventAnswerDelete, EventAnswerVote, EventAnswerFlag, EventAnswerReact,
+	EventCommentCreate, EventCommentUpdate, EventCommentDelete, EventCommentVote, EventCommentFlag,
+}
+
+// EventExtraScheduled the extra info set to "true" on the question create event of a scheduled question,
+// the event is sent when the question is published instead of when it is submitted
+const EventExtraScheduled = "scheduled"
+
+// IsEventType reports whether the event type exists
+func IsEventType(eventType string) bool {
+	for _, t := range EventTypes {
+		if string(t) == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -67,6 +67,7 @@ const (
 	InboxSource                          NotificationSource = "inbox"
 	AllNewQuestionSource                 NotificationSource = "all_new_question"
 	AllNewQuestionForFollowingTagsSource NotificationSource = "all_new_question_for_following_tags"
+	DigestSource                         NotificationSource = "digest"
 )
 
 const (
@@ -47,6 +47,13 @@ const (
 
 const (
 	EmailConfigKey = "email.config"
+	// EmailReplySecretKey the secret to sign the reply addresses of the notification emails
+	EmailReplySecretKey = "email.reply_secret"
+)
+
+const (
+	// OAuthSigningKeyConfigKey the RSA private key in PEM to sign the id tokens issued to the oauth clients
+	OAuthSigningKeyConfigKey = "oauth.signing_key"
 )
 
 const (
@@ -31,4 +31,6 @@ const (
 	SiteTypeTheme         = "theme"
 	SiteTypePrivileges    = "privileges"
 	SiteTypeUsers         = "users"
+	SiteTypeRateLimit     = "rate-limit"
+	SiteTypeReview        = "review"
 )
@@ -24,7 +24,10 @@ import (
 	"fmt"
 
 	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/digest"
+	"github.com/apache/answer/internal/service/draft"
 	"github.com/apache/answer/internal/service/file_record"
+	"github.com/apache/answer/internal/service/oauth_provider"
 	"github.com/apache/answer/internal/service/service_config"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/apache/answer/internal/service/user_admin"
@@ -34,11 +37,14 @@ import (
 
 // ScheduledTaskManager scheduled task manager
 type ScheduledTaskManager struct {
-	siteInfoService   siteinfo_common.SiteInfoCommonService
-	questionService   *content.QuestionService
-	fileRecordService *file_record.FileRecordService
-	userAdminService  *user_admin.UserAdminService
-	serviceConfig     *service_config.ServiceConfig
+	siteInfoService      siteinfo_common.SiteInfoCommonService
+	questionService      *content.QuestionService
+	fileRecordService    *file_record.FileRecordService
+	userAdminService     *user_admin.UserAdminService
+	digestService        *digest.DigestService
+	draftService         *draft.DraftService
+	serviceConfig        *service_config.ServiceConfig
+	oauthProviderService *oauth_provider.OAuthProviderService
 }
 
 // NewScheduledTaskManager new scheduled task manager
@@ -47,14 +53,20 @@ func NewScheduledTaskManager(
 	questionService *content.QuestionService,
 	fileRecordService *file_record.FileRecordService,
 	userAdminService *user_admin.UserAdminService,
+	digestService *digest.DigestService,
+	draftService *draft.DraftService,
 	serviceConfig *service_config.ServiceConfig,
+	oauthProviderService *oauth_provider.OAuthProviderService,
 ) *ScheduledTaskManager {
 	manager := &ScheduledTaskManager{
-		siteInfoService:   siteInfoService,
-		questionService:   questionService,
-		fileRecordService: fileRecordService,
-		userAdminService:  userAdminService,
-		serviceConfig:     serviceConfig,
+		siteInfoService:      siteInfoService,
+		questionService:      questionService,
+		fileRecordService:    fileRecordService,
+		userAdminService:     userAdminService,
+		digestService:        digestService,
+		draftService:         draftService,
+		serviceConfig:        serviceConfig,
+		oauthProviderService: oauthProviderService,
 	}
 	return manager
 }
@@ -95,6 +107,50 @@ func (s *ScheduledTaskManager) Run() {
 		log.Error(err)
 	}
 
+	// Send the due digest emails every 10 minutes
+	_, err = c.AddFunc("*/10 * * * *", func() {
+		log.Infof("send digest cron execution")
+		s.digestService.SendDueDigests(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	// Publish the scheduled questions every minute
+	_, err = c.AddFunc("* * * * *", func() {
+		s.questionService.PublishScheduledQuestionsCron(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	// Close the inactive questions matching the auto close rules every hour
+	_, err = c.AddFunc("20 */1 * * *", func() {
+		log.Infof("auto close questions cron execution")
+		s.questionService.AutoCloseQuestionsCron(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	// Remove the drafts which are not saved for a long time every day
+	_, err = c.AddFunc("30 3 * * *", func() {
+		log.Infof("remove expired drafts cron execution")
+		s.draftService.RemoveExpiredDrafts(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
+	// Remove the expired oauth authorization codes every day
+	_, err = c.AddFunc("40 3 * * *", func() {
+		log.Infof("remove expired authorization codes cron execution")
+		s.oauthProviderService.RemoveExpiredAuthorizationCodes(context.Background())
+	})
+	if err != nil {
+		log.Error(err)
+	}
+
 	if s.serviceConfig.CleanUpUploads {
 		log.Infof("clean up uploads cron enabled")
 
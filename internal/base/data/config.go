@@ -28,7 +28,43 @@ type Database struct {
 	MaxIdleConn     int    `json:"max_idle_conn" mapstructure:"max_idle_conn" yaml:"max_idle_conn,omitempty"`
 }
 
+const (
+	// CacheTypeMemory keeps the cache in memory only
+	CacheTypeMemory = "memory"
+	// CacheTypeFile keeps the cache in memory and saves it to the file_path periodically
+	CacheTypeFile = "file"
+	// CacheTypeRedis keeps the cache in redis, so it can be shared by multiple instances
+	CacheTypeRedis = "redis"
+)
+
 // CacheConf cache
 type CacheConf struct {
-	FilePath string `json:"file_path" mapstructure:"file_path" yaml:"file_path"`
+	// Type cache type: memory, file or redis. If empty, file is used when file_path is set, otherwise memory.
+	Type     string     `json:"type" mapstructure:"type" yaml:"type,omitempty"`
+	FilePath string     `json:"file_path" mapstructure:"file_path" yaml:"file_path"`
+	Redis    *RedisConf `json:"redis" mapstructure:"redis" yaml:"redis,omitempty"`
+}
+
+// RedisConf redis cache config
+type RedisConf struct {
+	Addr     string `json:"addr" mapstructure:"addr" yaml:"addr"`
+	Username string `json:"username" mapstructure:"username" yaml:"username,omitempty"`
+	Password string `json:"password" mapstructure:"password" yaml:"password,omitempty"`
+	DB       int    `json:"db" mapstructure:"db" yaml:"db,omitempty"`
+	// KeyPrefix is added to all keys, so that multiple sites can share the same redis
+	KeyPrefix string `json:"key_prefix" mapstructure:"key_prefix" yaml:"key_prefix,omitempty"`
+	TLS       bool   `json:"tls" mapstructure:"tls" yaml:"tls,omitempty"`
+	// TLSSkipVerify skip verifying the certificate of the redis server, only for testing
+	TLSSkipVerify bool `json:"tls_skip_verify" mapstructure:"tls_skip_verify" yaml:"tls_skip_verify,omitempty"`
+}
+
+// GetType get the cache type, defaults to the file cache if the file path is set
+func (c *CacheConf) GetType() string {
+	if len(c.Type) > 0 {
+		return c.Type
+	}
+	if len(c.FilePath) > 0 {
+		return CacheTypeFile
+	}
+	return CacheTypeMemory
 }
@@ -20,6 +20,7 @@
 package data
 
 import (
+	"fmt"
 	"path/filepath"
 	"time"
 
@@ -105,32 +106,45 @@ func NewCache(c *CacheConf) (cache.Cache, func(), error) {
 		return pluginCache, func() {}, nil
 	}
 
-	// TODO What cache type should be initialized according to the configuration file
+	switch c.GetType() {
+	case CacheTypeMemory:
+		return memory.NewCache(), func() {}, nil
+	case CacheTypeFile:
+		return newFileCache(c.FilePath)
+	case CacheTypeRedis:
+		return NewRedisCache(c.Redis)
+	default:
+		return nil, nil, fmt.Errorf("unsupported cache type: %s", c.Type)
+	}
+}
+
+// newFileCache new memory cache which is loaded from and saved to the file
+func newFileCache(filePath string) (cache.Cache, func(), error) {
 	memCache := memory.NewCache()
 
-	if len(c.FilePath) > 0 {
-		cacheFileDir := filepath.Dir(c.FilePath)
+	if len(filePath) > 0 {
+		cacheFileDir := filepath.Dir(filePath)
 		log.Debugf("try to create cache directory %s", cacheFileDir)
 		err := dir.CreateDirIfNotExist(cacheFileDir)
 		if err != nil {
 			log.Errorf("create cache dir failed: %s", err)
 		}
-		log.Infof("try to load cache file from %s", c.FilePath)
-		if err := memory.Load(memCache, c.FilePath); err != nil {
+		log.Infof("try to load cache file from %s", filePath)
+		if err := memory.Load(memCache, filePath); err != nil {
 			log.Warn(err)
 		}
 		go func() {
 			ticker := time.Tick(time.Minute)
 			for range ticker {
-				if err := memory.Save(memCache, c.FilePath); err != nil {
+				if err := memory.Save(memCache, filePath); err != nil {
 					log.Warn(err)
 				}
 			}
 		}()
 	}
 	cleanup := func() {
-		log.Infof("try to save cache file to %s", c.FilePath)
-		if err := memory.Save(memCache, c.FilePath); err != nil {
+		log.Infof("try to save cache file to %s", filePath)
+		if err := memory.Save(memCache, filePath); err != nil {
 			log.Warn(err)
 		}
 	}
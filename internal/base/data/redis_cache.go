@@ -0,0 +1,171 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentfault/pacman/cache"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ExpiringCounter is implemented by the caches which increase a counter and set its expiration in one atomic step
+type ExpiringCounter interface {
+	// IncreaseWithTTL increase the value, the ttl is set if the key has no expiration, such as a new key
+	IncreaseWithTTL(ctx context.Context, key string, value int64, ttl time.Duration) (data int64, err error)
+}
+
+// increaseWithTTLScript the counter is created by INCRBY without expiration, so it is set in the same script
+var increaseWithTTLScript = redis.NewScript(`
+local count = redis.call("INCRBY", KEYS[1], ARGV[1])
+if redis.call("PTTL", KEYS[1]) < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return count
+`)
+
+// RedisCache cache implemented by redis
+type RedisCache struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// NewRedisCache new redis cache instance, it fails if the redis server can not be reached
+func NewRedisCache(c *RedisConf) (cache.Cache, func(), error) {
+	if c == nil || len(c.Addr) == 0 {
+		return nil, nil, fmt.Errorf("redis cache address is required")
+	}
+	opts := &redis.Options{
+		Addr:     c.Addr,
+		Username: c.Username,
+		Password: c.Password,
+		DB:       c.DB,
+	}
+	if c.TLS {
+		opts.TLSConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: c.TLSSkipVerify,
+		}
+	}
+	client := redis.NewClient(opts)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, nil, fmt.Errorf("connect to redis %s failed: %w", c.Addr, err)
+	}
+	cleanup := func() {
+		log.Info("closing the redis cache")
+		if err := client.Close(); err != nil {
+			log.Warn(err)
+		}
+	}
+	return &RedisCache{client: client, keyPrefix: c.KeyPrefix}, cleanup, nil
+}
+
+func (r *RedisCache) key(key string) string {
+	return r.keyPrefix + key
+}
+
+// GetString get string value, exist is false if the key is not found
+func (r *RedisCache) GetString(ctx context.Context, key string) (data string, exist bool, err error) {
+	data, err = r.client.Get(ctx, r.key(key)).Result()
+	if err == redis.Nil {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return data, true, nil
+}
+
+// SetString set string value, the key never expires if ttl is 0
+func (r *RedisCache) SetString(ctx context.Context, key, value string, ttl time.Duration) (err error) {
+	return r.client.Set(ctx, r.key(key), value, ttl).Err()
+}
+
+// GetInt64 get int64 value, exist is false if the key is not found
+func (r *RedisCache) GetInt64(ctx context.Context, key string) (data int64, exist bool, err error) {
+	value, exist, err := r.GetString(ctx, key)
+	if err != nil || !exist {
+		return 0, exist, err
+	}
+	data, err = strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return data, true, nil
+}
+
+// SetInt64 set int64 value, the key never expires if ttl is 0
+func (r *RedisCache) SetInt64(ctx context.Context, key string, value int64, ttl time.Duration) (err error) {
+	return r.client.Set(ctx, r.key(key), value, ttl).Err()
+}
+
+// Increase increase the value atomically, the ttl of the key is kept
+func (r *RedisCache) Increase(ctx context.Context, key string, value int64) (data int64, err error) {
+	return r.client.IncrBy(ctx, r.key(key), value).Result()
+}
+
+// Decrease decrease the value atomically, the ttl of the key is kept
+func (r *RedisCache) Decrease(ctx context.Context, key string, value int64) (data int64, err error) {
+	return r.client.DecrBy(ctx, r.key(key), value).Result()
+}
+
+// IncreaseWithTTL increase the value atomically, the ttl is set if the key has no expiration
+func (r *RedisCache) IncreaseWithTTL(ctx context.Context, key string, value int64, ttl time.Duration) (
+	data int64, err error) {
+	return increaseWithTTLScript.Run(ctx, r.client, []string{r.key(key)}, value, ttl.Milliseconds()).Int64()
+}
+
+// Del delete the key
+func (r *RedisCache) Del(ctx context.Context, key string) (err error) {
+	return r.client.Del(ctx, r.key(key)).Err()
+}
+
+// Flush delete all the keys with the key prefix, or the whole database if there is no prefix
+func (r *RedisCache) Flush(ctx context.Context) (err error) {
+	if len(r.keyPrefix) == 0 {
+		return r.client.FlushDB(ctx).Err()
+	}
+	iter := r.client.Scan(ctx, 0, r.keyPrefix+"*", 1000).Iterator()
+	keys := make([]string, 0)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) >= 1000 {
+			if err = r.client.Unlink(ctx, keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err = iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		return r.client.Unlink(ctx, keys...).Err()
+	}
+	return nil
+}
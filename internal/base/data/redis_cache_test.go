@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisCache(t *testing.T, keyPrefix string) (*miniredis.Miniredis, *RedisCache) {
+	server := miniredis.RunT(t)
+	c, cleanup, err := NewCache(&CacheConf{
+		Type:  CacheTypeRedis,
+		Redis: &RedisConf{Addr: server.Addr(), KeyPrefix: keyPrefix},
+	})
+	require.NoError(t, err)
+	t.Cleanup(cleanup)
+	return server, c.(*RedisCache)
+}
+
+func TestRedisCache_String(t *testing.T) {
+	server, c := newTestRedisCache(t, "answer:")
+	ctx := context.TODO()
+
+	_, exist, err := c.GetString(ctx, "token")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	require.NoError(t, c.SetString(ctx, "token", "user", time.Minute))
+	data, exist, err := c.GetString(ctx, "token")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "user", data)
+	assert.True(t, server.Exists("answer:token"))
+
+	server.FastForward(2 * time.Minute)
+	_, exist, err = c.GetString(ctx, "token")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func TestRedisCache_Int64(t *testing.T) {
+	_, c := newTestRedisCache(t, "")
+	ctx := context.TODO()
+
+	require.NoError(t, c.SetInt64(ctx, "count", 10, 0))
+	data, err := c.Increase(ctx, "count", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(15), data)
+	data, err = c.Decrease(ctx, "count", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), data)
+
+	data, exist, err := c.GetInt64(ctx, "count")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, int64(12), data)
+
+	require.NoError(t, c.Del(ctx, "count"))
+	_, exist, err = c.GetInt64(ctx, "count")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func TestRedisCache_IncreaseWithTTL(t *testing.T) {
+	server, c := newTestRedisCache(t, "answer:")
+	ctx := context.TODO()
+
+	data, err := c.IncreaseWithTTL(ctx, "window", 1, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), data)
+	assert.Equal(t, time.Minute, server.TTL("answer:window"))
+
+	// the ttl of the existing key is kept
+	server.FastForward(30 * time.Second)
+	data, err = c.IncreaseWithTTL(ctx, "window", 2, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), data)
+	assert.Equal(t, 30*time.Second, server.TTL("answer:window"))
+
+	server.FastForward(time.Minute)
+	assert.False(t, server.Exists("answer:window"))
+}
+
+func TestRedisCache_FlushKeepsOtherPrefix(t *testing.T) {
+	server, c := newTestRedisCache(t, "answer:")
+	ctx := context.TODO()
+	require.NoError(t, server.Set("other:key", "value"))
+	require.NoError(t, c.SetString(ctx, "a", "1", 0))
+	require.NoError(t, c.SetString(ctx, "b", "2", 0))
+
+	require.NoError(t, c.Flush(ctx))
+	assert.False(t, server.Exists("answer:a"))
+	assert.False(t, server.Exists("answer:b"))
+	assert.True(t, server.Exists("other:key"))
+}
+
+func TestCacheConf_GetType(t *testing.T) {
+	assert.Equal(t, CacheTypeMemory, (&CacheConf{}).GetType())
+	assert.Equal(t, CacheTypeFile, (&CacheConf{FilePath: "/data/cache/cache.db"}).GetType())
+	assert.Equal(t, CacheTypeRedis, (&CacheConf{Type: CacheTypeRedis}).GetType())
+
+	_, _, err := NewCache(&CacheConf{Type: "unknown"})
+	assert.Error(t, err)
+	_, _, err = NewCache(&CacheConf{Type: CacheTypeRedis})
+	assert.Error(t, err)
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/service/auth"
+	"github.com/apache/answer/internal/service/personal_access_token"
 	"github.com/apache/answer/pkg/converter"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
@@ -42,20 +43,37 @@ var ctxUUIDKey = "ctxUuidKey"
 
 // AuthUserMiddleware auth user middleware
 type AuthUserMiddleware struct {
-	authService           *auth.AuthService
-	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	authService                *auth.AuthService
+	siteInfoCommonService      siteinfo_common.SiteInfoCommonService
+	personalAccessTokenService *personal_access_token.PersonalAccessTokenService
 }
 
 // NewAuthUserMiddleware new auth user middleware
 func NewAuthUserMiddleware(
 	authService *auth.AuthService,
-	siteInfoCommonService siteinfo_common.SiteInfoCommonService) *AuthUserMiddleware {
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	personalAccessTokenService *personal_access_token.PersonalAccessTokenService) *AuthUserMiddleware {
 	return &AuthUserMiddleware{
-		authService:           authService,
-		siteInfoCommonService: siteInfoCommonService,
+		authService:                authService,
+		siteInfoCommonService:      siteInfoCommonService,
+		personalAccessTokenService: personalAccessTokenService,
 	}
 }
 
+// getUserCacheInfo get the login user by the access token or the personal access token,
+// the error is the response of the rejected request.
+func (am *AuthUserMiddleware) getUserCacheInfo(ctx *gin.Context, token string) (
+	userInfo *entity.UserCacheInfo, err error) {
+	if personal_access_token.IsPersonalAccessToken(token) {
+		return am.personalAccessTokenService.Authenticate(ctx, token, ctx.Request.Method, ctx.FullPath())
+	}
+	userInfo, err = am.authService.GetUserCacheInfo(ctx, token)
+	if err != nil || userInfo == nil {
+		return nil, errors.Unauthorized(reason.UnauthorizedError)
+	}
+	return userInfo, nil
+}
+
 // Auth get token and auth user, set user info to context if user is already login
 func (am *AuthUserMiddleware) Auth() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -64,14 +82,12 @@ func (am *AuthUserMiddleware) Auth() gin.HandlerFunc {
 			ctx.Next()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
+		userInfo, err := am.getUserCacheInfo(ctx, token)
 		if err != nil {
 			ctx.Next()
 			return
 		}
-		if userInfo != nil {
-			ctx.Set(ctxUUIDKey, userInfo)
-		}
+		ctx.Set(ctxUUIDKey, userInfo)
 		ctx.Next()
 	}
 }
@@ -116,9 +132,9 @@ func (am *AuthUserMiddleware) MustAuthWithoutAccountAvailable() gin.HandlerFunc
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
-		if err != nil || userInfo == nil {
-			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
+		userInfo, err := am.getUserCacheInfo(ctx, token)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
 			ctx.Abort()
 			return
 		}
@@ -141,9 +157,9 @@ func (am *AuthUserMiddleware) MustAuthAndAccountAvailable() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetUserCacheInfo(ctx, token)
-		if err != nil || userInfo == nil {
-			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
+		userInfo, err := am.getUserCacheInfo(ctx, token)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
 			ctx.Abort()
 			return
 		}
@@ -177,7 +193,24 @@ func (am *AuthUserMiddleware) AdminAuth() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		userInfo, err := am.authService.GetAdminUserCacheInfo(ctx, token)
+		var userInfo *entity.UserCacheInfo
+		var err error
+		if personal_access_token.IsPersonalAccessToken(token) {
+			userInfo, err = am.personalAccessTokenService.Authenticate(ctx, token, ctx.Request.Method, ctx.FullPath())
+			if err != nil {
+				handler.HandleResponse(ctx, err, nil)
+				ctx.Abort()
+				return
+			}
+			// the admin scope is kept by the token, the user may not be the admin anymore
+			if userInfo.RoleID != role.RoleAdminID || userInfo.UserStatus != entity.UserStatusAvailable {
+				handler.HandleResponse(ctx, errors.Forbidden(reason.UnauthorizedError), nil)
+				ctx.Abort()
+				return
+			}
+		} else {
+			userInfo, err = am.authService.GetAdminUserCacheInfo(ctx, token)
+		}
 		if err != nil || userInfo == nil {
 			handler.HandleResponse(ctx, errors.Forbidden(reason.UnauthorizedError), nil)
 			ctx.Abort()
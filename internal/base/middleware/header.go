@@ -20,6 +20,7 @@
 package middleware
 
 import (
+	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -32,3 +33,15 @@ func HeadersByRequestURI() gin.HandlerFunc {
 		}
 	}
 }
+
+// AllowCrossOrigin allow the apps of other sites to call the api from the browser, the api must not rely on the cookies
+func AllowCrossOrigin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Access-Control-Allow-Origin", "*")
+		c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+		}
+	}
+}
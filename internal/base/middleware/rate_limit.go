@@ -22,9 +22,16 @@ package middleware
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/repo/limit"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/pkg/encryption"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -32,14 +39,83 @@ import (
 )
 
 type RateLimitMiddleware struct {
-	limitRepo *limit.LimitRepo
+	limitRepo             *limit.LimitRepo
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	userCommon            *usercommon.UserCommon
 }
 
 // NewRateLimitMiddleware new rate limit middleware
-func NewRateLimitMiddleware(limitRepo *limit.LimitRepo) *RateLimitMiddleware {
+func NewRateLimitMiddleware(
+	limitRepo *limit.LimitRepo,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	userCommon *usercommon.UserCommon,
+) *RateLimitMiddleware {
 	return &RateLimitMiddleware{
-		limitRepo: limitRepo,
+		limitRepo:             limitRepo,
+		siteInfoCommonService: siteInfoCommonService,
+		userCommon:            userCommon,
+	}
+}
+
+// Limit limits the requests of the route group by the quotas in the site rate limit config.
+// The anonymous users are counted by ip, the others are counted by user id unless the rule is per ip.
+// The admins and moderators are not limited.
+func (rm *RateLimitMiddleware) Limit(group string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if rm.limitExceeded(ctx, group) {
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func (rm *RateLimitMiddleware) limitExceeded(ctx *gin.Context, group string) bool {
+	siteRateLimit, err := rm.siteInfoCommonService.GetSiteRateLimit(ctx)
+	if err != nil {
+		log.Errorf("get site rate limit error: %s", err.Error())
+		return false
+	}
+	rule := siteRateLimit.GetRule(group)
+	if !siteRateLimit.Enabled || rule == nil || GetUserIsAdminModerator(ctx) {
+		return false
+	}
+
+	actor := "ip:" + ctx.ClientIP()
+	quota := rule.AnonymousLimit
+	if userID := GetLoginUserIDFromContext(ctx); len(userID) > 0 {
+		if !rule.PerIP {
+			actor = "user:" + userID
+		}
+		quota = rule.UserLimit
+		userInfo, exist, err := rm.userCommon.GetUserBasicInfoByID(ctx, userID)
+		if err != nil {
+			log.Errorf("get user info for rate limit error: %s", err.Error())
+		} else if exist && userInfo.Rank < siteRateLimit.NewUserRank {
+			quota = rule.NewUserLimit
+		}
+	}
+	if quota <= 0 {
+		return false
+	}
+
+	used, reset, exceeded, err := rm.limitRepo.CheckAndRecordWindow(ctx,
+		fmt.Sprintf("%s:%s", group, actor), time.Duration(rule.Window)*time.Second, quota)
+	if err != nil {
+		log.Errorf("check and record rate limit error: %s", err.Error())
+		return false
+	}
+	resetSeconds := strconv.Itoa(int(math.Ceil(reset.Seconds())))
+	ctx.Header("X-RateLimit-Limit", strconv.Itoa(quota))
+	ctx.Header("X-RateLimit-Remaining", strconv.Itoa(max(quota-used, 0)))
+	ctx.Header("X-RateLimit-Reset", resetSeconds)
+	if !exceeded {
+		return false
 	}
+	log.Debugf("rate limit exceeded: [%s] %s", group, actor)
+	ctx.Header("Retry-After", resetSeconds)
+	handler.HandleResponse(ctx, errors.New(http.StatusTooManyRequests, reason.RateLimitExceeded), nil)
+	return true
 }
 
 // DuplicateRequestRejection detects and rejects duplicate requests
@@ -34,6 +34,8 @@ const (
 	ForbiddenError = "base.forbidden_error"
 	// DuplicateRequestError duplicate request error
 	DuplicateRequestError = "base.duplicate_request_error"
+	// RateLimitExceeded too many requests in a short time
+	RateLimitExceeded = "base.rate_limit_exceeded"
 )
 
 const (
@@ -47,6 +49,10 @@ const (
 	QuestionAlreadyDeleted           = "error.question.already_deleted"
 	QuestionUnderReview              = "error.question.under_review"
 	QuestionContentCannotEmpty       = "error.question.content_cannot_empty"
+	QuestionScheduled                = "error.question.scheduled"
+	QuestionPublishTimeInvalid       = "error.question.publish_time_invalid"
+	QuestionAutoCloseRuleNotFound    = "error.question.auto_close_rule_not_found"
+	QuestionAutoCloseTypeInvalid     = "error.question.auto_close_type_invalid"
 	AnswerNotFound                   = "error.answer.not_found"
 	AnswerCannotDeleted              = "error.answer.cannot_deleted"
 	AnswerCannotUpdate               = "error.answer.cannot_update"
@@ -95,7 +101,13 @@ const (
 	RecommendTagEnter                = "error.tag.recommend_tag_enter"
 	RevisionReviewUnderway           = "error.revision.review_underway"
 	RevisionNoPermission             = "error.revision.no_permission"
+	RevisionNotFound                 = "error.revision.not_found"
+	RevisionObjectMismatch           = "error.revision.object_mismatch"
 	UserCannotUpdateYourRole         = "error.user.cannot_update_your_role"
+	RoleNotFound                     = "error.role.not_found"
+	RoleNameAlreadyExists            = "error.role.name_already_exists"
+	RoleBuiltInCannotModify          = "error.role.built_in_cannot_modify"
+	RolePowerNotFound                = "error.role.power_not_found"
 	TagCannotSetSynonymAsItself      = "error.tag.cannot_set_synonym_as_itself"
 	NotAllowedRegistration           = "error.user.not_allowed_registration"
 	NotAllowedLoginViaPassword       = "error.user.not_allowed_login_via_password"
@@ -110,6 +122,11 @@ const (
 	InvalidURLError                  = "error.common.invalid_url"
 	MetaObjectNotFound               = "error.meta.object_not_found"
 	BadgeObjectNotFound              = "error.badge.object_not_found"
+	BadgeRuleInvalid                 = "error.badge.rule_invalid"
+	BadgeRuleRequired                = "error.badge.rule_required"
+	BadgeBackfillRunning             = "error.badge.backfill_running"
+	BadgeGroupNotFound               = "error.badge.group_not_found"
+	BadgeGroupNotEmpty               = "error.badge.group_not_empty"
 	StatusInvalid                    = "error.common.status_invalid"
 	UserStatusInactive               = "error.user.status_inactive"
 	UserStatusSuspendedForever       = "error.user.status_suspended_forever"
@@ -117,6 +134,90 @@ const (
 	UserStatusDeleted                = "error.user.status_deleted"
 )
 
+// hierarchical tag reasons
+const (
+	HierarchicalTagNotFound                  = "error.hierarchical_tag.not_found"
+	HierarchicalTagParentNotFound            = "error.hierarchical_tag.parent_not_found"
+	HierarchicalTagCannotMoveUnderDescendant = "error.hierarchical_tag.cannot_move_under_descendant"
+	HierarchicalTagImportInvalid             = "error.hierarchical_tag.import_invalid"
+	HierarchicalTagImportFormatError         = "error.hierarchical_tag.import_format_error"
+	HierarchicalTagSlugNameRequired          = "error.hierarchical_tag.slug_name_required"
+	HierarchicalTagDisplayNameRequired       = "error.hierarchical_tag.display_name_required"
+	HierarchicalTagSlugNameDuplicate         = "error.hierarchical_tag.slug_name_duplicate"
+	HierarchicalTagNotSiblings               = "error.hierarchical_tag.not_siblings"
+	HierarchicalTagLeafOnly                  = "error.hierarchical_tag.leaf_only"
+	HierarchicalTagFilingForbidden           = "error.hierarchical_tag.filing_forbidden"
+	HierarchicalTagRoleNotFound              = "error.hierarchical_tag.role_not_found"
+	HierarchicalTagModeratorNotFound         = "error.hierarchical_tag.moderator_not_found"
+)
+
+// webhook reasons
+const (
+	WebhookNotFound         = "error.webhook.not_found"
+	WebhookEventTypeInvalid = "error.webhook.event_type_invalid"
+)
+
+// search sync reasons
+const (
+	SearchPluginNotFound = "error.search.plugin_not_found"
+	SearchReindexing     = "error.search.reindexing"
+)
+
+// digest reasons
+const (
+	DigestTimezoneInvalid = "error.digest.timezone_invalid"
+)
+
+// email reply reasons
+const (
+	EmailReplyAddressInvalid       = "error.email_reply.address_invalid"
+	EmailReplyUserUnavailable      = "error.email_reply.user_unavailable"
+	EmailReplySenderNotMatch       = "error.email_reply.sender_not_match"
+	EmailReplyContentEmpty         = "error.email_reply.content_empty"
+	EmailReplyCaptchaRequired      = "error.email_reply.captcha_required"
+	EmailReplyMessageInvalid       = "error.email_reply.message_invalid"
+	EmailReplyInboundDisabled      = "error.email_reply.inbound_disabled"
+	EmailReplyInboundSecretInvalid = "error.email_reply.inbound_secret_invalid"
+)
+
+// personal access token reasons
+const (
+	PersonalAccessTokenNotFound            = "error.personal_access_token.not_found"
+	PersonalAccessTokenTooMany             = "error.personal_access_token.too_many"
+	PersonalAccessTokenAdminScopeForbidden = "error.personal_access_token.admin_scope_forbidden"
+	PersonalAccessTokenScopeDenied         = "error.personal_access_token.scope_denied"
+)
+
+// oauth provider reasons
+const (
+	OAuthClientNotFound       = "error.oauth.client_not_found"
+	OAuthRedirectURIInvalid   = "error.oauth.redirect_uri_invalid"
+	OAuthRedirectURIMismatch  = "error.oauth.redirect_uri_mismatch"
+	OAuthCodeChallengeMissing = "error.oauth.code_challenge_missing"
+	OAuthClientPublic         = "error.oauth.client_public"
+)
+
+// draft reasons
+const (
+	DraftTooMany        = "error.draft.too_many"
+	DraftObjectNotFound = "error.draft.object_not_found"
+)
+
+// review reasons
+const (
+	ReviewPatternInvalid           = "error.review.pattern_invalid"
+	SiteSettingsImportInvalid      = "error.site_settings.import_invalid"
+	SiteSettingsImportFormatError  = "error.site_settings.import_format_error"
+	SiteSettingsImportVersionError = "error.site_settings.import_version_error"
+	SiteSettingsUnknownSiteType    = "error.site_settings.unknown_site_type"
+)
+
+// moderation reasons
+const (
+	ModerationItemClaimed  = "error.moderation.item_claimed"
+	ModerationItemNotFound = "error.moderation.item_not_found"
+)
+
 // user external login reasons
 const (
 	UserExternalLoginUnbindingForbidden = "error.user.external_login_unbinding_forbidden"
@@ -91,6 +91,9 @@ func NewHTTPServer(debug bool,
 
 	templateRouter.RegisterTemplateRouter(rootGroup, uiConf.BaseURL)
 
+	// oauth provider, the endpoints are relative to the site url which is the issuer
+	answerRouter.RegisterOAuthProviderRouter(r.Group(uiConf.BaseURL))
+
 	// plugin routes
 	pluginAPIRouter.RegisterUnAuthConnectorRouter(mustUnAuthV1)
 	pluginAPIRouter.RegisterAuthUserConnectorRouter(authV1)
@@ -25,7 +25,6 @@ import (
 	"time"
 
 	"github.com/apache/answer/internal/base/data"
-	"xorm.io/xorm/schemas"
 )
 
 // DumpAllData dump all database data to sql
@@ -40,5 +39,6 @@ func DumpAllData(dataConf *data.Database, dumpDataPath string) error {
 	}
 
 	name := filepath.Join(dumpDataPath, fmt.Sprintf("answer_dump_data_%s.sql", time.Now().Format("2006-01-02")))
-	return db.DumpAllToFile(name, schemas.MYSQL)
+	// dump in the dialect of the database, use `answer backup` to move data between databases
+	return db.DumpAllToFile(name, db.Dialect().URI().DBType)
 }
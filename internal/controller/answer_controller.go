@@ -47,6 +47,7 @@ type AnswerController struct {
 	actionService         *action.CaptchaService
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
 	rateLimitMiddleware   *middleware.RateLimitMiddleware
+	revisionService       *content.RevisionService
 }
 
 // NewAnswerController new controller
@@ -56,6 +57,7 @@ func NewAnswerController(
 	actionService *action.CaptchaService,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	revisionService *content.RevisionService,
 ) *AnswerController {
 	return &AnswerController{
 		answerService:         answerService,
@@ -63,6 +65,7 @@ func NewAnswerController(
 		actionService:         actionService,
 		siteInfoCommonService: siteInfoCommonService,
 		rateLimitMiddleware:   rateLimitMiddleware,
+		revisionService:       revisionService,
 	}
 }
 
@@ -308,7 +311,35 @@ func (ac *AnswerController) UpdateAnswer(ctx *gin.Context) {
 		return
 	}
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	ac.updateAnswer(ctx, req)
+}
+
+// RollbackAnswer rollback answer to an old revision
+// @Summary rollback answer to an old revision
+// @Description rollback answer to an old revision, a new revision is created with the content of the old one
+// @Tags Answer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RollbackRevisionReq true "revision"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/answer/rollback [put]
+func (ac *AnswerController) RollbackAnswer(ctx *gin.Context) {
+	rollbackReq := &schema.RollbackRevisionReq{}
+	if handler.BindAndCheck(ctx, rollbackReq) {
+		return
+	}
+	rollbackReq.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	req, err := ac.revisionService.GetAnswerRollback(ctx, rollbackReq)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ac.updateAnswer(ctx, req)
+}
 
+func (ac *AnswerController) updateAnswer(ctx *gin.Context, req *schema.AnswerUpdateReq) {
 	canList, err := ac.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
 		permission.AnswerEdit,
 		permission.AnswerEditWithoutReview,
@@ -54,4 +54,9 @@ var ProviderSetController = wire.NewSet(
 	NewBadgeController,
 	NewRenderController,
 	NewHierarchicalTagController,
+	NewEmailReplyController,
+	NewPersonalAccessTokenController,
+	NewOAuthProviderController,
+	NewDraftController,
+	NewModerationController,
 )
@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/draft"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/gin-gonic/gin"
+)
+
+// DraftController draft controller
+type DraftController struct {
+	draftService *draft.DraftService
+	rankService  *rank.RankService
+}
+
+// NewDraftController new controller
+func NewDraftController(draftService *draft.DraftService, rankService *rank.RankService) *DraftController {
+	return &DraftController{draftService: draftService, rankService: rankService}
+}
+
+// SaveDraft save the draft of a question, answer or edit
+// @Summary save the draft of a question, answer or edit
+// @Description save the draft automatically while the user is writing, there is one draft for each question,
+// @Description answer or edit. Check conflict in the response, it is true if others have edited the post
+// @Description since the edit draft started, save with rebase after the user resolves it.
+// @Tags Draft
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SaveDraftReq true "draft"
+// @Success 200 {object} handler.RespBody{data=schema.DraftItem}
+// @Router /answer/api/v1/draft [put]
+func (dc *DraftController) SaveDraft(ctx *gin.Context) {
+	req := &schema.SaveDraftReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	// same as the question detail, the users who can reopen the questions can view the hidden ones
+	canViewHidden, err := dc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionReopen, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanViewHidden = canViewHidden
+
+	resp, err := dc.draftService.SaveDraft(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetDraft get the draft of a question, answer or edit
+// @Summary get the draft of a question, answer or edit
+// @Description get the draft of a question, answer or edit, the data is null if there is no draft
+// @Tags Draft
+// @Produce json
+// @Security ApiKeyAuth
+// @Param type query string true "question, answer or edit"
+// @Param object_id query string false "the question id of the answer, the post id of the edit"
+// @Success 200 {object} handler.RespBody{data=schema.DraftItem}
+// @Router /answer/api/v1/draft [get]
+func (dc *DraftController) GetDraft(ctx *gin.Context) {
+	req := &schema.GetDraftReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	canViewHidden, err := dc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionReopen, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanViewHidden = canViewHidden
+
+	resp, err := dc.draftService.GetDraft(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemoveDraft discard the draft of a question, answer or edit
+// @Summary discard the draft of a question, answer or edit
+// @Description discard the draft of a question, answer or edit
+// @Tags Draft
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveDraftReq true "draft"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/draft [delete]
+func (dc *DraftController) RemoveDraft(ctx *gin.Context) {
+	req := &schema.RemoveDraftReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := dc.draftService.RemoveDraft(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetDraftPage get the drafts of the login user
+// @Summary get the drafts of the login user
+// @Description get the drafts of the login user, the latest saved first
+// @Tags Draft
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.DraftItem}}
+// @Router /answer/api/v1/personal/drafts/page [get]
+func (dc *DraftController) GetDraftPage(ctx *gin.Context) {
+	req := &schema.GetDraftPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	canViewHidden, err := dc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionReopen, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanViewHidden = canViewHidden
+
+	resp, total, err := dc.draftService.GetDraftPage(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	handler.HandleResponse(ctx, nil, pager.NewPageModel(total, resp))
+}
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/service/email_reply"
+	"github.com/apache/answer/pkg/mailreply"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// maxInboundEmailSize the max size of the inbound email
+	maxInboundEmailSize = 10 << 20
+	// inboundSecretHeader the header with the secret of the mail gateway, it can be the query "secret" too
+	inboundSecretHeader = "X-Answer-Inbound-Secret"
+)
+
+// EmailReplyController email reply controller
+type EmailReplyController struct {
+	emailReplyService *email_reply.EmailReplyService
+}
+
+// NewEmailReplyController new controller
+func NewEmailReplyController(emailReplyService *email_reply.EmailReplyService) *EmailReplyController {
+	return &EmailReplyController{emailReplyService: emailReplyService}
+}
+
+// Inbound godoc
+// @Summary receive the reply of the notification email
+// @Description receive the reply of the notification email from the mail server or the inbound webhook of
+// @Description the mail provider, the reply is posted as a comment or an answer. The body is the raw email,
+// @Description or a form with the raw email in the field "email" or "body-mime". The mail gateway has to send
+// @Description the inbound secret configured in the smtp settings in the header X-Answer-Inbound-Secret or the query.
+// @Tags EmailReply
+// @Accept message/rfc822,multipart/form-data,application/x-www-form-urlencoded
+// @Produce json
+// @Param X-Answer-Inbound-Secret header string false "the inbound secret"
+// @Param secret query string false "the inbound secret"
+// @Param recipient query string false "the address the email is delivered to"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/email/inbound [post]
+func (ec *EmailReplyController) Inbound(ctx *gin.Context) {
+	secret := ctx.GetHeader(inboundSecretHeader)
+	if len(secret) == 0 {
+		secret = ctx.Query("secret")
+	}
+	if err := ec.emailReplyService.CheckInboundSecret(ctx, secret); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxInboundEmailSize)
+	recipient := ctx.Query("recipient")
+
+	var raw io.Reader
+	switch ctx.ContentType() {
+	case gin.MIMEMultipartPOSTForm, gin.MIMEPOSTForm:
+		content := ctx.PostForm("email")
+		if len(content) == 0 {
+			content = ctx.PostForm("body-mime")
+		}
+		if len(recipient) == 0 {
+			recipient = ctx.PostForm("recipient")
+		}
+		raw = strings.NewReader(content)
+	default:
+		raw = ctx.Request.Body
+	}
+
+	msg, err := mailreply.ParseMessage(raw)
+	if err != nil {
+		log.Warnf("parse inbound email failed: %s", err)
+		handler.HandleResponse(ctx, errors.BadRequest(reason.EmailReplyMessageInvalid), nil)
+		return
+	}
+	err = ec.emailReplyService.Reply(ctx, msg, recipient)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -20,19 +20,22 @@
 package controller
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/schema"
-	"github.com/apache/answer/internal/service"
+	hierarchicaltag "github.com/apache/answer/internal/service/hierarchical_tag"
 	"github.com/gin-gonic/gin"
 )
 
 // HierarchicalTagController hierarchical tag controller
 type HierarchicalTagController struct {
-	hierarchicalTagService *service.HierarchicalTagService
+	hierarchicalTagService *hierarchicaltag.HierarchicalTagService
 }
 
 // NewHierarchicalTagController new hierarchical tag controller
-func NewHierarchicalTagController(hierarchicalTagService *service.HierarchicalTagService) *HierarchicalTagController {
+func NewHierarchicalTagController(hierarchicalTagService *hierarchicaltag.HierarchicalTagService) *HierarchicalTagController {
 	return &HierarchicalTagController{
 		hierarchicalTagService: hierarchicalTagService,
 	}
@@ -62,6 +65,18 @@ func (htc *HierarchicalTagController) GetHierarchicalTags(ctx *gin.Context) {
 	handler.HandleResponse(ctx, nil, resp)
 }
 
+// GetHierarchicalTagTree godoc
+// @Summary Get hierarchical tag tree
+// @Description Get the whole hierarchical tag tree
+// @Tags HierarchicalTag
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]entity.HierarchicalTagWithChildren}
+// @Router /answer/api/v1/hierarchical-tags/tree [get]
+func (htc *HierarchicalTagController) GetHierarchicalTagTree(ctx *gin.Context) {
+	resp, err := htc.hierarchicalTagService.GetHierarchicalTagTree(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // CreateHierarchicalTag godoc
 // @Summary Create hierarchical tag
 // @Description Create a new hierarchical tag
@@ -100,6 +115,150 @@ func (htc *HierarchicalTagController) UpdateHierarchicalTag(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// DeleteHierarchicalTag godoc
+// @Summary Delete hierarchical tag
+// @Description Delete a hierarchical tag together with its whole subtree
+// @Tags HierarchicalTag
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.DeleteHierarchicalTagReq true "hierarchical tag"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/hierarchical-tags [delete]
+func (htc *HierarchicalTagController) DeleteHierarchicalTag(ctx *gin.Context) {
+	req := &schema.DeleteHierarchicalTagReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := htc.hierarchicalTagService.DeleteHierarchicalTag(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// MoveHierarchicalTag godoc
+// @Summary Move hierarchical tag
+// @Description Move a hierarchical tag and its subtree under a new parent, an empty parent moves it to the root level
+// @Tags HierarchicalTag
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.MoveHierarchicalTagReq true "hierarchical tag and new parent"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/hierarchical-tags/parent [put]
+func (htc *HierarchicalTagController) MoveHierarchicalTag(ctx *gin.Context) {
+	req := &schema.MoveHierarchicalTagReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := htc.hierarchicalTagService.MoveHierarchicalTag(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ReorderHierarchicalTags godoc
+// @Summary Reorder hierarchical tags
+// @Description Sort the children of a hierarchical tag, an empty parent sorts the root tags
+// @Tags HierarchicalTag
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.ReorderHierarchicalTagsReq true "parent and the children in their new order"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/hierarchical-tags/order [put]
+func (htc *HierarchicalTagController) ReorderHierarchicalTags(ctx *gin.Context) {
+	req := &schema.ReorderHierarchicalTagsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := htc.hierarchicalTagService.ReorderHierarchicalTags(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetHierarchicalTagPermission godoc
+// @Summary Get hierarchical tag permission
+// @Description Get the roles allowed to file questions, the leaf-only setting and the moderators of a hierarchical tag
+// @Tags HierarchicalTag
+// @Security ApiKeyAuth
+// @Produce json
+// @Param id query string true "hierarchical tag id"
+// @Success 200 {object} handler.RespBody{data=schema.HierarchicalTagPermissionResp}
+// @Router /answer/admin/api/hierarchical-tags/permission [get]
+func (htc *HierarchicalTagController) GetHierarchicalTagPermission(ctx *gin.Context) {
+	req := &schema.GetHierarchicalTagPermissionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := htc.hierarchicalTagService.GetHierarchicalTagPermission(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateHierarchicalTagPermission godoc
+// @Summary Update hierarchical tag permission
+// @Description Update the roles allowed to file questions, the leaf-only setting and the moderators of a hierarchical tag
+// @Tags HierarchicalTag
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateHierarchicalTagPermissionReq true "hierarchical tag permission"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/hierarchical-tags/permission [put]
+func (htc *HierarchicalTagController) UpdateHierarchicalTagPermission(ctx *gin.Context) {
+	req := &schema.UpdateHierarchicalTagPermissionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := htc.hierarchicalTagService.UpdateHierarchicalTagPermission(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ExportHierarchicalTags godoc
+// @Summary Export hierarchical tags
+// @Description Export the whole hierarchical tag taxonomy as a json, yaml or csv file
+// @Tags HierarchicalTag
+// @Security ApiKeyAuth
+// @Produce json
+// @Param format query string true "file format" Enums(json, yaml, csv)
+// @Success 200 {file} file
+// @Router /answer/admin/api/hierarchical-tags/export [get]
+func (htc *HierarchicalTagController) ExportHierarchicalTags(ctx *gin.Context) {
+	req := &schema.ExportHierarchicalTagsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := htc.hierarchicalTagService.ExportHierarchicalTags(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", resp.FileName))
+	ctx.Data(http.StatusOK, resp.ContentType, resp.Content)
+}
+
+// ImportHierarchicalTags godoc
+// @Summary Import hierarchical tags
+// @Description Import a hierarchical tag taxonomy matched by slug name, a dry run only returns the diff
+// @Tags HierarchicalTag
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.ImportHierarchicalTagsReq true "taxonomy file"
+// @Success 200 {object} handler.RespBody{data=schema.ImportHierarchicalTagsResp}
+// @Router /answer/admin/api/hierarchical-tags/import [post]
+func (htc *HierarchicalTagController) ImportHierarchicalTags(ctx *gin.Context) {
+	req := &schema.ImportHierarchicalTagsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := htc.hierarchicalTagService.ImportHierarchicalTags(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetHierarchicalTagPath godoc
 // @Summary Get hierarchical tag path
 // @Description Get the full path of a hierarchical tag
@@ -0,0 +1,208 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/moderation"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// ModerationController moderation controller
+type ModerationController struct {
+	moderationService *moderation.ModerationService
+	rankService       *rank.RankService
+}
+
+// NewModerationController new controller
+func NewModerationController(
+	moderationService *moderation.ModerationService,
+	rankService *rank.RankService,
+) *ModerationController {
+	return &ModerationController{
+		moderationService: moderationService,
+		rankService:       rankService,
+	}
+}
+
+// GetQueue get moderation queue
+// @Summary get moderation queue
+// @Description get the pending reports, the posts waiting for review and the unreviewed revisions in one queue,
+// @Description the oldest first. Each item shows the moderator who claims it.
+// @Tags Moderation
+// @Produce json
+// @Security ApiKeyAuth
+// @Param item_type query string false "report, review or revision"
+// @Param tag query string false "the slug name of the tag"
+// @Param min_age query int false "only the items submitted at least the hours ago"
+// @Param max_age query int false "only the items submitted at most the hours ago"
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.ModerationQueueItem}}
+// @Router /answer/api/v1/moderation/queue [get]
+func (mc *ModerationController) GetQueue(ctx *gin.Context) {
+	req := &schema.GetModerationQueueReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	canList, err := mc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+		permission.QuestionAudit,
+		permission.AnswerAudit,
+		permission.TagAudit,
+	})
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanReviewQuestion = canList[0]
+	req.CanReviewAnswer = canList[1]
+	req.CanReviewTag = canList[2]
+
+	resp, err := mc.moderationService.GetQueue(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// Claim claim moderation item
+// @Summary claim moderation item
+// @Description claim the item so that other moderators can not handle it, the claim expires in 15 minutes.
+// @Description Claim it again to renew the claim.
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ModerationClaimReq true "item"
+// @Success 200 {object} handler.RespBody{data=schema.ModerationClaimResp}
+// @Router /answer/api/v1/moderation/claim [post]
+func (mc *ModerationController) Claim(ctx *gin.Context) {
+	req := &schema.ModerationClaimReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := mc.moderationService.Claim(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// Release release moderation item
+// @Summary release moderation item
+// @Description release the claim of the item, it is released automatically once the item is handled
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ModerationClaimReq true "item"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/moderation/claim [delete]
+func (mc *ModerationController) Release(ctx *gin.Context) {
+	req := &schema.ModerationClaimReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := mc.moderationService.Release(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ModerateItems moderate items in bulk
+// @Summary moderate items in bulk
+// @Description approve, reject or delete the items. Approving a report ignores it and keeps the post,
+// @Description rejecting or deleting a report deletes the post. Deleting a revision rejects it.
+// @Description The result of each item is returned, the items claimed by others are skipped.
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ModerateItemsReq true "items"
+// @Success 200 {object} handler.RespBody{data=[]schema.ModerateItemResult}
+// @Router /answer/api/v1/moderation/items [put]
+func (mc *ModerationController) ModerateItems(ctx *gin.Context) {
+	req := &schema.ModerateItemsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	canList, err := mc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
+		permission.QuestionAudit,
+		permission.AnswerAudit,
+		permission.TagAudit,
+	})
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanReviewQuestion = canList[0]
+	req.CanReviewAnswer = canList[1]
+	req.CanReviewTag = canList[2]
+
+	resp, err := mc.moderationService.ModerateItems(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetLogPage get moderation log page
+// @Summary get moderation log page
+// @Description get the actions of the moderators with the status before and after, the latest first
+// @Tags Moderation
+// @Produce json
+// @Security ApiKeyAuth
+// @Param operator_id query string false "the moderator who took the action"
+// @Param item_type query string false "report, review or revision"
+// @Param object_id query string false "object id"
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.ModerationLogResp}}
+// @Router /answer/api/v1/moderation/logs [get]
+func (mc *ModerationController) GetLogPage(ctx *gin.Context) {
+	req := &schema.GetModerationLogPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+
+	resp, err := mc.moderationService.GetLogPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,250 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/oauth_provider"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// OAuthProviderController oauth provider controller
+type OAuthProviderController struct {
+	oauthProviderService *oauth_provider.OAuthProviderService
+}
+
+// NewOAuthProviderController new controller
+func NewOAuthProviderController(oauthProviderService *oauth_provider.OAuthProviderService) *OAuthProviderController {
+	return &OAuthProviderController{oauthProviderService: oauthProviderService}
+}
+
+// GetAuthorizeInfo check the authorization request for the consent page
+// @Summary check the authorization request for the consent page
+// @Description check the authorization request sent by the oauth client and get what the consent page shows.
+// @Description If redirect_url is returned, the request is invalid and the user should be sent to it.
+// @Tags OAuth
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data query schema.OAuthAuthorizeReq true "authorization request"
+// @Success 200 {object} handler.RespBody{data=schema.OAuthAuthorizeInfoResp}
+// @Router /answer/api/v1/oauth/authorize [get]
+func (oc *OAuthProviderController) GetAuthorizeInfo(ctx *gin.Context) {
+	req := &schema.OAuthAuthorizeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := oc.oauthProviderService.GetAuthorizeInfo(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// Consent approve or deny the authorization request
+// @Summary approve or deny the authorization request
+// @Description approve or deny the authorization request, send the user to the returned redirect_url
+// @Description which brings the authorization code or the error to the oauth client.
+// @Tags OAuth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.OAuthConsentReq true "consent"
+// @Success 200 {object} handler.RespBody{data=schema.OAuthConsentResp}
+// @Router /answer/api/v1/oauth/authorize [post]
+func (oc *OAuthProviderController) Consent(ctx *gin.Context) {
+	req := &schema.OAuthConsentReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := oc.oauthProviderService.Consent(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// Token exchange the authorization code or the refresh token for the tokens
+// @Summary exchange the authorization code or the refresh token for the tokens
+// @Description the token endpoint of RFC 6749, the client authenticates by basic auth or the form,
+// @Description the public clients only send the client_id and must use PKCE.
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "authorization_code or refresh_token"
+// @Param code formData string false "authorization code"
+// @Param redirect_uri formData string false "redirect uri"
+// @Param code_verifier formData string false "PKCE code verifier"
+// @Param refresh_token formData string false "refresh token"
+// @Param scope formData string false "scope"
+// @Param client_id formData string false "client id"
+// @Param client_secret formData string false "client secret"
+// @Success 200 {object} schema.OAuthTokenResp
+// @Failure 400 {object} schema.OAuthErrorResp
+// @Router /oauth/token [post]
+func (oc *OAuthProviderController) Token(ctx *gin.Context) {
+	req := &schema.OAuthTokenReq{}
+	if err := ctx.ShouldBind(req); err != nil {
+		handleOAuthError(ctx, &oauth_provider.OAuthError{Code: oauth_provider.ErrorInvalidRequest,
+			Description: "the request is malformed", StatusCode: http.StatusBadRequest})
+		return
+	}
+	setClientCredentials(ctx, &req.ClientID, &req.ClientSecret)
+
+	resp, err := oc.oauthProviderService.Token(ctx, req)
+	if err != nil {
+		handleOAuthError(ctx, err)
+		return
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Pragma", "no-cache")
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UserInfo get the claims of the user who authorized the access token
+// @Summary get the claims of the user who authorized the access token
+// @Description the userinfo endpoint of OpenID Connect, the claims depend on the granted scopes
+// @Tags OAuth
+// @Produce json
+// @Param Authorization header string true "Bearer <access token>"
+// @Success 200 {object} schema.OAuthUserInfoResp
+// @Failure 401 {object} schema.OAuthErrorResp
+// @Router /oauth/userinfo [get]
+func (oc *OAuthProviderController) UserInfo(ctx *gin.Context) {
+	accessToken, found := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	if !found && ctx.Request.Method == http.MethodPost {
+		accessToken = ctx.PostForm("access_token")
+	}
+	if len(accessToken) == 0 {
+		handleOAuthError(ctx, &oauth_provider.OAuthError{Code: oauth_provider.ErrorInvalidToken,
+			Description: "the access token is required", StatusCode: http.StatusUnauthorized})
+		return
+	}
+
+	resp, err := oc.oauthProviderService.UserInfo(ctx, strings.TrimSpace(accessToken))
+	if err != nil {
+		handleOAuthError(ctx, err)
+		return
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Revoke revoke the access token or the refresh token
+// @Summary revoke the access token or the refresh token
+// @Description the revocation endpoint of RFC 7009, it succeeds even if the token is unknown
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param token formData string true "token"
+// @Param token_type_hint formData string false "access_token or refresh_token"
+// @Param client_id formData string false "client id"
+// @Param client_secret formData string false "client secret"
+// @Success 200
+// @Failure 401 {object} schema.OAuthErrorResp
+// @Router /oauth/revoke [post]
+func (oc *OAuthProviderController) Revoke(ctx *gin.Context) {
+	req := &schema.OAuthRevokeReq{}
+	if err := ctx.ShouldBind(req); err != nil {
+		handleOAuthError(ctx, &oauth_provider.OAuthError{Code: oauth_provider.ErrorInvalidRequest,
+			Description: "the request is malformed", StatusCode: http.StatusBadRequest})
+		return
+	}
+	setClientCredentials(ctx, &req.ClientID, &req.ClientSecret)
+
+	if err := oc.oauthProviderService.Revoke(ctx, req); err != nil {
+		handleOAuthError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusOK)
+}
+
+// Discovery get the openid provider metadata
+// @Summary get the openid provider metadata
+// @Description the discovery document of OpenID Connect, the issuer is the site url
+// @Tags OAuth
+// @Produce json
+// @Success 200 {object} schema.OIDCDiscoveryResp
+// @Router /.well-known/openid-configuration [get]
+func (oc *OAuthProviderController) Discovery(ctx *gin.Context) {
+	resp, err := oc.oauthProviderService.Discovery(ctx)
+	if err != nil {
+		handleOAuthError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// JWKS get the public keys to verify the id tokens
+// @Summary get the public keys to verify the id tokens
+// @Description get the public keys to verify the id tokens
+// @Tags OAuth
+// @Produce json
+// @Success 200 {object} schema.JWKSResp
+// @Router /oauth/jwks [get]
+func (oc *OAuthProviderController) JWKS(ctx *gin.Context) {
+	resp, err := oc.oauthProviderService.JWKS(ctx)
+	if err != nil {
+		handleOAuthError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// setClientCredentials the client credentials in basic auth take precedence over the ones in the form,
+// they are form encoded before being put in basic auth as RFC 6749 requires
+func setClientCredentials(ctx *gin.Context, clientID, clientSecret *string) {
+	username, password, ok := ctx.Request.BasicAuth()
+	if !ok {
+		return
+	}
+	if id, err := url.QueryUnescape(username); err == nil {
+		*clientID = id
+	}
+	if secret, err := url.QueryUnescape(password); err == nil {
+		*clientSecret = secret
+	}
+}
+
+// handleOAuthError the errors are returned as the oauth specifications require instead of the common response body
+func handleOAuthError(ctx *gin.Context, err error) {
+	var oauthErr *oauth_provider.OAuthError
+	if !errors.As(err, &oauthErr) {
+		log.Error(err)
+		oauthErr = &oauth_provider.OAuthError{Code: oauth_provider.ErrorServerError,
+			StatusCode: http.StatusInternalServerError}
+	}
+	switch oauthErr.Code {
+	case oauth_provider.ErrorInvalidClient:
+		ctx.Header("WWW-Authenticate", `Basic realm="oauth"`)
+	case oauth_provider.ErrorInvalidToken:
+		ctx.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(oauthErr.StatusCode, &schema.OAuthErrorResp{
+		Error:            oauthErr.Code,
+		ErrorDescription: oauthErr.Description,
+	})
+}
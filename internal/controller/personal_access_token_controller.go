@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/personal_access_token"
+	"github.com/gin-gonic/gin"
+)
+
+// PersonalAccessTokenController personal access token controller
+type PersonalAccessTokenController struct {
+	personalAccessTokenService *personal_access_token.PersonalAccessTokenService
+}
+
+// NewPersonalAccessTokenController new controller
+func NewPersonalAccessTokenController(
+	personalAccessTokenService *personal_access_token.PersonalAccessTokenService) *PersonalAccessTokenController {
+	return &PersonalAccessTokenController{personalAccessTokenService: personalAccessTokenService}
+}
+
+// GetPersonalAccessTokens get the personal access tokens of the login user
+// @Summary get the personal access tokens of the login user
+// @Description get the personal access tokens of the login user
+// @Tags PersonalAccessToken
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.PersonalAccessTokenItem}
+// @Router /answer/api/v1/personal/access-tokens [get]
+func (pc *PersonalAccessTokenController) GetPersonalAccessTokens(ctx *gin.Context) {
+	resp, err := pc.personalAccessTokenService.GetUserTokens(ctx, middleware.GetLoginUserIDFromContext(ctx))
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddPersonalAccessToken create a personal access token
+// @Summary create a personal access token
+// @Description create a personal access token, the token is only returned in this response.
+// @Description Send it in the header "Authorization: Bearer <token>" to call the api allowed by the scopes.
+// @Tags PersonalAccessToken
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddPersonalAccessTokenReq true "personal access token"
+// @Success 200 {object} handler.RespBody{data=schema.AddPersonalAccessTokenResp}
+// @Router /answer/api/v1/personal/access-token [post]
+func (pc *PersonalAccessTokenController) AddPersonalAccessToken(ctx *gin.Context) {
+	req := &schema.AddPersonalAccessTokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := pc.personalAccessTokenService.AddToken(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RevokePersonalAccessToken revoke a personal access token of the login user
+// @Summary revoke a personal access token of the login user
+// @Description revoke a personal access token of the login user
+// @Tags PersonalAccessToken
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RevokePersonalAccessTokenReq true "personal access token"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/personal/access-token [delete]
+func (pc *PersonalAccessTokenController) RevokePersonalAccessToken(ctx *gin.Context) {
+	req := &schema.RevokePersonalAccessTokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := pc.personalAccessTokenService.RevokeToken(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -49,6 +49,7 @@ type QuestionController struct {
 	siteInfoService     siteinfo_common.SiteInfoCommonService
 	actionService       *action.CaptchaService
 	rateLimitMiddleware *middleware.RateLimitMiddleware
+	revisionService     *content.RevisionService
 }
 
 // NewQuestionController new controller
@@ -59,6 +60,7 @@ func NewQuestionController(
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	actionService *action.CaptchaService,
 	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	revisionService *content.RevisionService,
 ) *QuestionController {
 	return &QuestionController{
 		questionService:     questionService,
@@ -67,6 +69,7 @@ func NewQuestionController(
 		siteInfoService:     siteInfoService,
 		actionService:       actionService,
 		rateLimitMiddleware: rateLimitMiddleware,
+		revisionService:     revisionService,
 	}
 }
 
@@ -102,6 +105,9 @@ func (qc *QuestionController) RemoveQuestion(ctx *gin.Context) {
 	}
 
 	can, err := qc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionDelete, req.ID)
+	if err == nil && !can {
+		can, err = qc.questionService.IsHierarchicalTagModerator(ctx, req.UserID, req.ID)
+	}
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -176,6 +182,9 @@ func (qc *QuestionController) CloseQuestion(ctx *gin.Context) {
 	req.ID = uid.DeShortID(req.ID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 	can, err := qc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionClose, "")
+	if err == nil && !can {
+		can, err = qc.questionService.IsHierarchicalTagModerator(ctx, req.UserID, req.ID)
+	}
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -207,6 +216,9 @@ func (qc *QuestionController) ReopenQuestion(ctx *gin.Context) {
 	req.QuestionID = uid.DeShortID(req.QuestionID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 	can, err := qc.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionReopen, "")
+	if err == nil && !can {
+		can, err = qc.questionService.IsHierarchicalTagModerator(ctx, req.UserID, req.QuestionID)
+	}
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -251,11 +263,16 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 		return
 	}
 	objectOwner := qc.rankService.CheckOperationObjectOwner(ctx, userID, id)
+	isTagModerator, err := qc.questionService.IsHierarchicalTagModerator(ctx, userID, id)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 
-	req.CanEdit = canList[0] || objectOwner
-	req.CanDelete = canList[1]
-	req.CanClose = canList[2]
-	req.CanReopen = canList[3]
+	req.CanEdit = canList[0] || objectOwner || isTagModerator
+	req.CanDelete = canList[1] || isTagModerator
+	req.CanClose = canList[2] || isTagModerator
+	req.CanReopen = canList[3] || isTagModerator
 	req.CanPin = canList[4]
 	req.CanUnPin = canList[5]
 	req.CanHide = canList[6]
@@ -434,6 +451,7 @@ func (qc *QuestionController) AddQuestion(ctx *gin.Context) {
 	req.CanReopen = canList[4]
 	req.CanUseReservedTag = canList[5]
 	req.CanAddTag = canList[6]
+	req.CanSchedule = isAdmin
 	if !req.CanAdd {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
@@ -628,6 +646,35 @@ func (qc *QuestionController) UpdateQuestion(ctx *gin.Context) {
 	}
 	req.ID = uid.DeShortID(req.ID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	qc.updateQuestion(ctx, req, errFields)
+}
+
+// RollbackQuestion rollback question to an old revision
+// @Summary rollback question to an old revision
+// @Description rollback question to an old revision, a new revision is created with the content of the old one
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RollbackRevisionReq true "revision"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/rollback [put]
+func (qc *QuestionController) RollbackQuestion(ctx *gin.Context) {
+	rollbackReq := &schema.RollbackRevisionReq{}
+	if handler.BindAndCheck(ctx, rollbackReq) {
+		return
+	}
+	rollbackReq.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	req, err := qc.revisionService.GetQuestionRollback(ctx, rollbackReq)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	qc.updateQuestion(ctx, req, nil)
+}
+
+func (qc *QuestionController) updateQuestion(ctx *gin.Context, req *schema.QuestionUpdate, errFields []*validator.FormErrorField) {
 	canList, requireRanks, err := qc.rankService.CheckOperationPermissionsForRanks(ctx, req.UserID, []string{
 		permission.QuestionEdit,
 		permission.QuestionDelete,
@@ -655,9 +702,14 @@ func (qc *QuestionController) UpdateQuestion(ctx *gin.Context) {
 	}
 
 	objectOwner := qc.rankService.CheckOperationObjectOwner(ctx, req.UserID, req.ID)
-	req.CanEdit = canList[0] || objectOwner
-	req.CanDelete = canList[1]
-	req.NoNeedReview = canList[2] || objectOwner
+	isTagModerator, err := qc.questionService.IsHierarchicalTagModerator(ctx, req.UserID, req.ID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanEdit = canList[0] || objectOwner || isTagModerator
+	req.CanDelete = canList[1] || isTagModerator
+	req.NoNeedReview = canList[2] || objectOwner || isTagModerator
 	req.CanUseReservedTag = canList[3]
 	req.CanAddTag = canList[4]
 	if !req.CanEdit {
@@ -81,6 +81,26 @@ func (rc *RevisionController) GetRevisionList(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, list)
 }
 
+// GetRevisionDiff godoc
+// @Summary get the changes between two revisions
+// @Description get the changes of title, content and tags between two revisions of the same object
+// @Tags Revision
+// @Produce json
+// @Param from_id query string true "old revision id"
+// @Param to_id query string true "new revision id"
+// @Param granularity query string false "compare the content by line or word" Enums(line, word)
+// @Success 200 {object} handler.RespBody{data=schema.GetRevisionDiffResp}
+// @Router /answer/api/v1/revisions/diff [get]
+func (rc *RevisionController) GetRevisionDiff(ctx *gin.Context) {
+	req := &schema.GetRevisionDiffReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := rc.revisionListService.GetRevisionDiff(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetUnreviewedRevisionList godoc
 // @Summary get unreviewed revision list
 // @Description get unreviewed revision list
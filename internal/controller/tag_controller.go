@@ -25,6 +25,7 @@ import (
 	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/content"
 	"github.com/apache/answer/internal/service/permission"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/internal/service/tag"
@@ -38,6 +39,7 @@ type TagController struct {
 	tagService       *tag.TagService
 	tagCommonService *tag_common.TagCommonService
 	rankService      *rank.RankService
+	revisionService  *content.RevisionService
 }
 
 // NewTagController new controller
@@ -45,8 +47,14 @@ func NewTagController(
 	tagService *tag.TagService,
 	tagCommonService *tag_common.TagCommonService,
 	rankService *rank.RankService,
+	revisionService *content.RevisionService,
 ) *TagController {
-	return &TagController{tagService: tagService, tagCommonService: tagCommonService, rankService: rankService}
+	return &TagController{
+		tagService:       tagService,
+		tagCommonService: tagCommonService,
+		rankService:      rankService,
+		revisionService:  revisionService,
+	}
 }
 
 // SearchTagLike get tag list
@@ -165,6 +173,35 @@ func (tc *TagController) UpdateTag(ctx *gin.Context) {
 	}
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	tc.updateTag(ctx, req)
+}
+
+// RollbackTag rollback tag to an old revision
+// @Summary rollback tag to an old revision
+// @Description rollback tag to an old revision, a new revision is created with the content of the old one
+// @Security ApiKeyAuth
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param data body schema.RollbackRevisionReq true "revision"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/tag/rollback [put]
+func (tc *TagController) RollbackTag(ctx *gin.Context) {
+	rollbackReq := &schema.RollbackRevisionReq{}
+	if handler.BindAndCheck(ctx, rollbackReq) {
+		return
+	}
+	rollbackReq.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	req, err := tc.revisionService.GetTagRollback(ctx, rollbackReq)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	tc.updateTag(ctx, req)
+}
+
+func (tc *TagController) updateTag(ctx *gin.Context, req *schema.UpdateTagReq) {
 	canList, err := tc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
 		permission.TagEdit,
 		permission.TagEditWithoutReview,
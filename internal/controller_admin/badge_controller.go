@@ -28,12 +28,17 @@ import (
 )
 
 type BadgeController struct {
-	badgeService *badge.BadgeService
+	badgeService      *badge.BadgeService
+	badgeGroupService *badge.BadgeGroupService
 }
 
-func NewBadgeController(badgeService *badge.BadgeService) *BadgeController {
+func NewBadgeController(
+	badgeService *badge.BadgeService,
+	badgeGroupService *badge.BadgeGroupService,
+) *BadgeController {
 	return &BadgeController{
-		badgeService: badgeService,
+		badgeService:      badgeService,
+		badgeGroupService: badgeGroupService,
 	}
 }
 
@@ -84,3 +89,176 @@ func (b *BadgeController) UpdateBadgeStatus(ctx *gin.Context) {
 	err := b.badgeService.UpdateStatus(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// GetBadgeDetail get badge detail with its rule
+// @Summary get badge detail with its rule
+// @Description get badge detail with its rule
+// @Tags AdminBadge
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query string true "badge id"
+// @Success 200 {object} handler.RespBody{data=schema.GetBadgeDetailResp}
+// @Router /answer/admin/api/badge [get]
+func (b *BadgeController) GetBadgeDetail(ctx *gin.Context) {
+	req := &schema.GetBadgeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := b.badgeService.GetBadgeDetail(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddBadge add badge awarded by rule
+// @Summary add badge awarded by rule
+// @Description add badge awarded by rule
+// @Tags AdminBadge
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddBadgeReq true "AddBadgeReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/badge [post]
+func (b *BadgeController) AddBadge(ctx *gin.Context) {
+	req := &schema.AddBadgeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := b.badgeService.AddBadge(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateBadge update badge
+// @Summary update badge
+// @Description update badge
+// @Tags AdminBadge
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateBadgeReq true "UpdateBadgeReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/badge [put]
+func (b *BadgeController) UpdateBadge(ctx *gin.Context) {
+	req := &schema.UpdateBadgeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := b.badgeService.UpdateBadge(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// DeleteBadge delete badge
+// @Summary delete badge
+// @Description delete badge
+// @Tags AdminBadge
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.DeleteBadgeReq true "DeleteBadgeReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/badge [delete]
+func (b *BadgeController) DeleteBadge(ctx *gin.Context) {
+	req := &schema.DeleteBadgeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := b.badgeService.DeleteBadge(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// BackfillBadge award badge to users who already meet its rule
+// @Summary award badge to users who already meet its rule
+// @Description award badge to users who already meet its rule
+// @Tags AdminBadge
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.BackfillBadgeReq true "BackfillBadgeReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/badge/backfill [post]
+func (b *BadgeController) BackfillBadge(ctx *gin.Context) {
+	req := &schema.BackfillBadgeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := b.badgeService.BackfillBadge(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetBadgeGroupList list all badge groups
+// @Summary list all badge groups
+// @Description list all badge groups
+// @Tags AdminBadge
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.BadgeGroupItem}
+// @Router /answer/admin/api/badge-groups [get]
+func (b *BadgeController) GetBadgeGroupList(ctx *gin.Context) {
+	resp, err := b.badgeGroupService.ListBadgeGroups(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddBadgeGroup add badge group
+// @Summary add badge group
+// @Description add badge group
+// @Tags AdminBadge
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddBadgeGroupReq true "AddBadgeGroupReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/badge-group [post]
+func (b *BadgeController) AddBadgeGroup(ctx *gin.Context) {
+	req := &schema.AddBadgeGroupReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := b.badgeGroupService.AddBadgeGroup(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateBadgeGroup update badge group
+// @Summary update badge group
+// @Description update badge group
+// @Tags AdminBadge
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateBadgeGroupReq true "UpdateBadgeGroupReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/badge-group [put]
+func (b *BadgeController) UpdateBadgeGroup(ctx *gin.Context) {
+	req := &schema.UpdateBadgeGroupReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := b.badgeGroupService.UpdateBadgeGroup(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// DeleteBadgeGroup delete badge group without badges
+// @Summary delete badge group without badges
+// @Description delete badge group without badges
+// @Tags AdminBadge
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.DeleteBadgeGroupReq true "DeleteBadgeGroupReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/badge-group [delete]
+func (b *BadgeController) DeleteBadgeGroup(ctx *gin.Context) {
+	req := &schema.DeleteBadgeGroupReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := b.badgeGroupService.DeleteBadgeGroup(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -29,4 +29,10 @@ var ProviderSetController = wire.NewSet(
 	NewRoleController,
 	NewPluginController,
 	NewBadgeController,
+	NewQueueMessageController,
+	NewWebhookController,
+	NewSearchSyncController,
+	NewPersonalAccessTokenController,
+	NewOAuthClientController,
+	NewQuestionAutoCloseController,
 )
@@ -0,0 +1,132 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/oauth_provider"
+	"github.com/gin-gonic/gin"
+)
+
+// OAuthClientController oauth client controller
+type OAuthClientController struct {
+	oauthProviderService *oauth_provider.OAuthProviderService
+}
+
+// NewOAuthClientController new oauth client controller
+func NewOAuthClientController(oauthProviderService *oauth_provider.OAuthProviderService) *OAuthClientController {
+	return &OAuthClientController{
+		oauthProviderService: oauthProviderService,
+	}
+}
+
+// GetOAuthClients get the oauth clients
+// @Summary get the oauth clients
+// @Description get the applications which can sign in the users with their accounts of the site
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.OAuthClientItem}
+// @Router /answer/admin/api/oauth/clients [get]
+func (oc *OAuthClientController) GetOAuthClients(ctx *gin.Context) {
+	resp, err := oc.oauthProviderService.GetClientList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddOAuthClient register an oauth client
+// @Summary register an oauth client
+// @Description register an oauth client, the client secret is only returned in this response
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddOAuthClientReq true "oauth client"
+// @Success 200 {object} handler.RespBody{data=schema.AddOAuthClientResp}
+// @Router /answer/admin/api/oauth/client [post]
+func (oc *OAuthClientController) AddOAuthClient(ctx *gin.Context) {
+	req := &schema.AddOAuthClientReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := oc.oauthProviderService.AddClient(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateOAuthClient update an oauth client
+// @Summary update an oauth client
+// @Description update the name, redirect uris and scopes of an oauth client
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateOAuthClientReq true "oauth client"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/oauth/client [put]
+func (oc *OAuthClientController) UpdateOAuthClient(ctx *gin.Context) {
+	req := &schema.UpdateOAuthClientReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := oc.oauthProviderService.UpdateClient(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// DeleteOAuthClient delete an oauth client
+// @Summary delete an oauth client
+// @Description delete an oauth client, the tokens issued to it stop working
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.DeleteOAuthClientReq true "oauth client"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/oauth/client [delete]
+func (oc *OAuthClientController) DeleteOAuthClient(ctx *gin.Context) {
+	req := &schema.DeleteOAuthClientReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := oc.oauthProviderService.DeleteClient(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ResetOAuthClientSecret reset the secret of an oauth client
+// @Summary reset the secret of an oauth client
+// @Description generate a new secret for an oauth client, the old one stops working at once
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ResetOAuthClientSecretReq true "oauth client"
+// @Success 200 {object} handler.RespBody{data=schema.ResetOAuthClientSecretResp}
+// @Router /answer/admin/api/oauth/client/secret [put]
+func (oc *OAuthClientController) ResetOAuthClientSecret(ctx *gin.Context) {
+	req := &schema.ResetOAuthClientSecretReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := oc.oauthProviderService.ResetClientSecret(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
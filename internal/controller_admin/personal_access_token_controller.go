@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/personal_access_token"
+	"github.com/gin-gonic/gin"
+)
+
+// PersonalAccessTokenController personal access token controller
+type PersonalAccessTokenController struct {
+	personalAccessTokenService *personal_access_token.PersonalAccessTokenService
+}
+
+// NewPersonalAccessTokenController new personal access token controller
+func NewPersonalAccessTokenController(
+	personalAccessTokenService *personal_access_token.PersonalAccessTokenService) *PersonalAccessTokenController {
+	return &PersonalAccessTokenController{
+		personalAccessTokenService: personalAccessTokenService,
+	}
+}
+
+// GetPersonalAccessTokenPage get the personal access tokens of the users
+// @Summary get the personal access tokens of the users
+// @Description get the personal access tokens of the users, filtered by the user if the user id is given
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param user_id query string false "user id"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.AdminPersonalAccessTokenItem}}
+// @Router /answer/admin/api/personal-access-tokens/page [get]
+func (pc *PersonalAccessTokenController) GetPersonalAccessTokenPage(ctx *gin.Context) {
+	req := &schema.GetPersonalAccessTokenPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, total, err := pc.personalAccessTokenService.GetTokenPage(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	handler.HandleResponse(ctx, nil, pager.NewPageModel(total, resp))
+}
+
+// RevokePersonalAccessToken revoke a personal access token of any user
+// @Summary revoke a personal access token of any user
+// @Description revoke a personal access token of any user
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RevokePersonalAccessTokenReq true "personal access token"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/personal-access-token [delete]
+func (pc *PersonalAccessTokenController) RevokePersonalAccessToken(ctx *gin.Context) {
+	req := &schema.RevokePersonalAccessTokenReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = true
+
+	err := pc.personalAccessTokenService.RevokeToken(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
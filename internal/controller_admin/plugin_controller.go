@@ -188,6 +188,14 @@ func (pc *PluginController) GetPluginConfig(ctx *gin.Context) {
 		resp.SetConfigFields(ctx, fn.ConfigFields())
 		return nil
 	})
+
+	envNames := plugin_common.GetPluginConfigEnvNames(req.PluginSlugName)
+	for i := range resp.ConfigFields {
+		if envName, ok := envNames[resp.ConfigFields[i].Name]; ok {
+			resp.ConfigFields[i].Value = nil
+			resp.ConfigFields[i].EnvName = envName
+		}
+	}
 	handler.HandleResponse(ctx, nil, resp)
 }
 
@@ -207,7 +215,7 @@ func (pc *PluginController) UpdatePluginConfig(ctx *gin.Context) {
 		return
 	}
 
-	configFields, _ := json.Marshal(req.ConfigFields)
+	configFields, _ := json.Marshal(plugin_common.ApplyPluginConfigEnv(req.PluginSlugName, req.ConfigFields))
 	err := plugin.CallConfig(func(fn plugin.Config) error {
 		if fn.Info().SlugName == req.PluginSlugName {
 			return fn.ConfigReceiver(configFields)
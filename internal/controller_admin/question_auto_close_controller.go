@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/middleware"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/question_schedule"
+	"github.com/gin-gonic/gin"
+)
+
+// QuestionAutoCloseController question auto close rule controller
+type QuestionAutoCloseController struct {
+	questionScheduleService *question_schedule.QuestionScheduleService
+}
+
+// NewQuestionAutoCloseController new question auto close rule controller
+func NewQuestionAutoCloseController(questionScheduleService *question_schedule.QuestionScheduleService) *QuestionAutoCloseController {
+	return &QuestionAutoCloseController{
+		questionScheduleService: questionScheduleService,
+	}
+}
+
+// GetAutoCloseRuleList get question auto close rule list
+// @Summary get question auto close rule list
+// @Description get question auto close rule list
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.QuestionAutoCloseRuleItem}
+// @Router /answer/admin/api/question/auto-close-rules [get]
+func (qc *QuestionAutoCloseController) GetAutoCloseRuleList(ctx *gin.Context) {
+	resp, err := qc.questionScheduleService.GetAutoCloseRuleList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddAutoCloseRule add question auto close rule
+// @Summary add question auto close rule
+// @Description add question auto close rule, the questions matching it are closed by the admin who adds it
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddQuestionAutoCloseRuleReq true "rule"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/question/auto-close-rule [post]
+func (qc *QuestionAutoCloseController) AddAutoCloseRule(ctx *gin.Context) {
+	req := &schema.AddQuestionAutoCloseRuleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := qc.questionScheduleService.AddAutoCloseRule(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateAutoCloseRule update question auto close rule
+// @Summary update question auto close rule
+// @Description update question auto close rule
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateQuestionAutoCloseRuleReq true "rule"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/question/auto-close-rule [put]
+func (qc *QuestionAutoCloseController) UpdateAutoCloseRule(ctx *gin.Context) {
+	req := &schema.UpdateQuestionAutoCloseRuleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := qc.questionScheduleService.UpdateAutoCloseRule(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// DeleteAutoCloseRule delete question auto close rule
+// @Summary delete question auto close rule
+// @Description delete question auto close rule
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.DeleteQuestionAutoCloseRuleReq true "rule"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/question/auto-close-rule [delete]
+func (qc *QuestionAutoCloseController) DeleteAutoCloseRule(ctx *gin.Context) {
+	req := &schema.DeleteQuestionAutoCloseRuleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := qc.questionScheduleService.DeleteAutoCloseRule(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/queue_message"
+	"github.com/gin-gonic/gin"
+)
+
+// QueueMessageController queue message controller
+type QueueMessageController struct {
+	queueMessageService *queue_message.QueueMessageService
+}
+
+// NewQueueMessageController new queue message controller
+func NewQueueMessageController(queueMessageService *queue_message.QueueMessageService) *QueueMessageController {
+	return &QueueMessageController{
+		queueMessageService: queueMessageService,
+	}
+}
+
+// GetQueueMessagePage get queued event, notification and activity messages by page
+// @Summary get queued event, notification and activity messages by page
+// @Description get queued event, notification and activity messages by page, dead messages have exhausted their retries
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param queue query string false "queue name, such as notification, activity or event.badge"
+// @Param status query string false "message status" Enums(pending, processing, dead)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.QueueMessageItem}}
+// @Router /answer/admin/api/queue-messages/page [get]
+func (qc *QueueMessageController) GetQueueMessagePage(ctx *gin.Context) {
+	req := &schema.GetQueueMessagePageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := qc.queueMessageService.GetQueueMessagePage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ReplayQueueMessages put the failed messages back to the queue
+// @Summary put the failed messages back to the queue
+// @Description put the pending or dead messages back to the queue with their attempts reset
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ReplayQueueMessagesReq true "ReplayQueueMessagesReq"
+// @Success 200 {object} handler.RespBody{data=schema.ReplayQueueMessagesResp}
+// @Router /answer/admin/api/queue-messages/replay [put]
+func (qc *QueueMessageController) ReplayQueueMessages(ctx *gin.Context) {
+	req := &schema.ReplayQueueMessagesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := qc.queueMessageService.ReplayQueueMessages(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -52,3 +52,73 @@ func (rc *RoleController) GetRoleList(ctx *gin.Context) {
 	resp, err := rc.roleService.GetRoleList(ctx)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// GetPowerList get power list
+// @Summary get the powers which can be assigned to the roles
+// @Description get the powers which can be assigned to the roles
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetPowerResp}
+// @Router /answer/admin/api/powers [get]
+func (rc *RoleController) GetPowerList(ctx *gin.Context) {
+	resp, err := rc.roleService.GetPowerList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddRole add custom role
+// @Summary add custom role
+// @Description add custom role with the selected powers
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.AddRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [post]
+func (rc *RoleController) AddRole(ctx *gin.Context) {
+	req := &schema.AddRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := rc.roleService.AddRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateRole update custom role
+// @Summary update custom role
+// @Description update custom role and replace its powers
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [put]
+func (rc *RoleController) UpdateRole(ctx *gin.Context) {
+	req := &schema.UpdateRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := rc.roleService.UpdateRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveRole remove custom role
+// @Summary remove custom role
+// @Description remove custom role, the users who have the role lose it
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [delete]
+func (rc *RoleController) RemoveRole(ctx *gin.Context) {
+	req := &schema.RemoveRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := rc.roleService.RemoveRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/search_sync"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchSyncController search sync controller
+type SearchSyncController struct {
+	searchSyncService *search_sync.SearchSyncService
+}
+
+// NewSearchSyncController new search sync controller
+func NewSearchSyncController(searchSyncService *search_sync.SearchSyncService) *SearchSyncController {
+	return &SearchSyncController{
+		searchSyncService: searchSyncService,
+	}
+}
+
+// GetSearchSyncStatus get the sync status of the enabled search plugins
+// @Summary get the sync status of the enabled search plugins
+// @Description get how many changes each enabled search plugin has not synced and since when
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetSearchSyncStatusResp}
+// @Router /answer/admin/api/search/sync [get]
+func (sc *SearchSyncController) GetSearchSyncStatus(ctx *gin.Context) {
+	resp, err := sc.searchSyncService.GetSyncStatus(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ReindexSearch reindex all the contents to the search plugin
+// @Summary reindex all the contents to the search plugin
+// @Description reindex all the questions and answers to the enabled search plugin in background
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ReindexSearchReq true "search plugin"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/search/reindex [post]
+func (sc *SearchSyncController) ReindexSearch(ctx *gin.Context) {
+	req := &schema.ReindexSearchReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := sc.searchSyncService.Reindex(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
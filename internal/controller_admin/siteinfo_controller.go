@@ -20,6 +20,7 @@
 package controller_admin
 
 import (
+	"fmt"
 	"html"
 	"net/http"
 
@@ -173,6 +174,32 @@ func (sc *SiteInfoController) GetSiteUsers(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// GetSiteRateLimit get site rate limit config
+// @Summary get site rate limit config
+// @Description get the quotas of the route groups for the anonymous, new and other users
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteRateLimitResp}
+// @Router /answer/admin/api/siteinfo/rate-limit [get]
+func (sc *SiteInfoController) GetSiteRateLimit(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteRateLimit(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetSiteReview get site review config
+// @Summary get site review config
+// @Description get the pipeline of the reviewers which check the new posts and the actions of the verdicts
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.SiteReviewResp}
+// @Router /answer/admin/api/siteinfo/review [get]
+func (sc *SiteInfoController) GetSiteReview(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.GetSiteReview(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetRobots get site robots information
 // @Summary get site robots information
 // @Description get site robots information
@@ -398,6 +425,95 @@ func (sc *SiteInfoController) UpdateSiteUsers(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// UpdateSiteRateLimit update site rate limit config
+// @Summary update site rate limit config
+// @Description update the quotas of the route groups for the anonymous, new and other users
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteRateLimitReq true "rate limit"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/rate-limit [put]
+func (sc *SiteInfoController) UpdateSiteRateLimit(ctx *gin.Context) {
+	req := &schema.SiteRateLimitReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteRateLimit(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateSiteReview update site review config
+// @Summary update site review config
+// @Description update the pipeline of the reviewers which check the new posts and the actions of the verdicts
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param data body schema.SiteReviewReq true "review"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/siteinfo/review [put]
+func (sc *SiteInfoController) UpdateSiteReview(ctx *gin.Context) {
+	req := &schema.SiteReviewReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.siteInfoService.SaveSiteReview(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ExportSiteSettings export site settings
+// @Summary export site settings
+// @Description export the site info, the privileges and the plugin settings as a json file, the plugin config values pinned by the environment variables are not included
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {file} file
+// @Router /answer/admin/api/siteinfo/settings/export [get]
+func (sc *SiteInfoController) ExportSiteSettings(ctx *gin.Context) {
+	resp, err := sc.siteInfoService.ExportSiteSettings(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", resp.FileName))
+	ctx.Data(http.StatusOK, resp.ContentType, resp.Content)
+}
+
+// ImportSiteSettings import site settings
+// @Summary import site settings
+// @Description import the exported settings file, nothing is changed if any value is invalid, a dry run only checks the file
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.ImportSiteSettingsReq true "settings file"
+// @Success 200 {object} handler.RespBody{data=schema.ImportSiteSettingsResp}
+// @Router /answer/admin/api/siteinfo/settings/import [post]
+func (sc *SiteInfoController) ImportSiteSettings(ctx *gin.Context) {
+	req := &schema.ImportSiteSettingsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := sc.siteInfoService.ImportSiteSettings(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RotateEmailReplySecret rotate the email reply secret
+// @Summary rotate the email reply secret
+// @Description replace the secret signing the reply addresses of the notification emails, the reply addresses sent before can not be used any more
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/admin/api/setting/smtp/reply-secret [put]
+func (sc *SiteInfoController) RotateEmailReplySecret(ctx *gin.Context) {
+	err := sc.siteInfoService.RotateEmailReplySecret(ctx)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // GetSMTPConfig get smtp config
 // @Summary GetSMTPConfig get smtp config
 // @Description GetSMTPConfig get smtp config
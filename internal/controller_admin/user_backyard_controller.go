@@ -89,6 +89,26 @@ func (uc *UserAdminController) UpdateUserRole(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// UpdateUserCustomRoles update user custom roles
+// @Summary update user custom roles
+// @Description replace the custom roles of the user, the built-in role is not changed
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.UpdateUserCustomRolesReq true "user"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/custom-roles [put]
+func (uc *UserAdminController) UpdateUserCustomRoles(ctx *gin.Context) {
+	req := &schema.UpdateUserCustomRolesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := uc.userService.UpdateUserCustomRoles(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // AddUser add user
 // @Summary add user
 // @Description add user
@@ -0,0 +1,158 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookController webhook controller
+type WebhookController struct {
+	webhookService *webhook.WebhookService
+}
+
+// NewWebhookController new webhook controller
+func NewWebhookController(webhookService *webhook.WebhookService) *WebhookController {
+	return &WebhookController{
+		webhookService: webhookService,
+	}
+}
+
+// GetWebhookList get webhook list
+// @Summary get webhook list
+// @Description get webhook list
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.WebhookItem}
+// @Router /answer/admin/api/webhooks [get]
+func (wc *WebhookController) GetWebhookList(ctx *gin.Context) {
+	resp, err := wc.webhookService.GetWebhookList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddWebhook add webhook
+// @Summary add webhook
+// @Description add webhook, the secret is generated when it is empty and it is only returned here
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddWebhookReq true "webhook"
+// @Success 200 {object} handler.RespBody{data=schema.AddWebhookResp}
+// @Router /answer/admin/api/webhook [post]
+func (wc *WebhookController) AddWebhook(ctx *gin.Context) {
+	req := &schema.AddWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := wc.webhookService.AddWebhook(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateWebhook update webhook
+// @Summary update webhook
+// @Description update webhook, the secret is kept when it is empty
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateWebhookReq true "webhook"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/webhook [put]
+func (wc *WebhookController) UpdateWebhook(ctx *gin.Context) {
+	req := &schema.UpdateWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := wc.webhookService.UpdateWebhook(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// DeleteWebhook delete webhook
+// @Summary delete webhook
+// @Description delete webhook
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.DeleteWebhookReq true "webhook"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/webhook [delete]
+func (wc *WebhookController) DeleteWebhook(ctx *gin.Context) {
+	req := &schema.DeleteWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := wc.webhookService.DeleteWebhook(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// TestWebhook send a ping event to the webhook
+// @Summary send a ping event to the webhook
+// @Description send a ping event to the webhook and return the delivery result
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.TestWebhookReq true "webhook"
+// @Success 200 {object} handler.RespBody{data=schema.WebhookDeliveryItem}
+// @Router /answer/admin/api/webhook/test [post]
+func (wc *WebhookController) TestWebhook(ctx *gin.Context) {
+	req := &schema.TestWebhookReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := wc.webhookService.TestWebhook(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetWebhookDeliveryPage get the delivery log of the webhook
+// @Summary get the delivery log of the webhook
+// @Description get the delivery log of the webhook, every retry is logged
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param webhook_id query string true "webhook id"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.WebhookDeliveryItem}}
+// @Router /answer/admin/api/webhook/deliveries/page [get]
+func (wc *WebhookController) GetWebhookDeliveryPage(ctx *gin.Context) {
+	req := &schema.GetWebhookDeliveryPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, total, err := wc.webhookService.GetWebhookDeliveryPage(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	handler.HandleResponse(ctx, nil, pager.NewPageModel(total, resp))
+}
@@ -37,6 +37,9 @@ const (
 
 	BadgeSingleAward = 1
 	BadgeMultiAward  = 2
+
+	// BadgeRuleHandler the badges defined by the admin, the rule is kept in the param
+	BadgeRuleHandler = "Rule"
 )
 
 // Badge badge
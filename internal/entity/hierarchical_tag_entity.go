@@ -26,20 +26,26 @@ const (
 	HierarchicalTagStatusDeleted   = 10
 )
 
+const (
+	QuestionHierarchicalTagRelStatusRemoved   = 0
+	QuestionHierarchicalTagRelStatusAvailable = 1
+)
+
 // HierarchicalTag represents a hierarchical tag structure
 type HierarchicalTag struct {
-	ID          string    `xorm:"not null pk comment('hierarchical_tag_id') BIGINT(20) id"`
-	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
-	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
-	Name        string    `xorm:"not null VARCHAR(100) name"`
-	SlugName    string    `xorm:"not null unique VARCHAR(100) slug_name"`
-	ParentID    string    `xorm:"default null BIGINT(20) parent_id"`
-	Level       int       `xorm:"not null default 0 INT(11) level"`
-	Path        string    `xorm:"not null TEXT path"`
-	DisplayName string    `xorm:"not null VARCHAR(100) display_name"`
-	Description string    `xorm:"TEXT description"`
-	Status      int       `xorm:"not null default 1 INT(11) status"`
-	SortOrder   int       `xorm:"not null default 0 INT(11) sort_order"`
+	ID          string    `xorm:"not null pk comment('hierarchical_tag_id') BIGINT(20) id" json:"id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at" json:"-"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at" json:"-"`
+	Name        string    `xorm:"not null VARCHAR(100) name" json:"name"`
+	SlugName    string    `xorm:"not null unique VARCHAR(100) slug_name" json:"slug_name"`
+	ParentID    string    `xorm:"default null BIGINT(20) parent_id" json:"parent_id,omitempty"`
+	Level       int       `xorm:"not null default 0 INT(11) level" json:"level"`
+	Path        string    `xorm:"not null TEXT path" json:"path"`
+	DisplayName string    `xorm:"not null VARCHAR(100) display_name" json:"display_name"`
+	Description string    `xorm:"TEXT description" json:"description,omitempty"`
+	Status      int       `xorm:"not null default 1 INT(11) status" json:"-"`
+	SortOrder   int       `xorm:"not null default 0 INT(11) sort_order" json:"sort_order"`
+	LeafOnly    bool      `xorm:"not null default false BOOL leaf_only" json:"leaf_only"`
 }
 
 // TableName hierarchical tag table name
@@ -62,6 +68,32 @@ func (QuestionHierarchicalTagRel) TableName() string {
 	return "question_hierarchical_tag_rel"
 }
 
+// HierarchicalTagRoleRel a role allowed to file questions under the hierarchical tag and its subtree
+type HierarchicalTagRoleRel struct {
+	ID                int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt         time.Time `xorm:"created TIMESTAMP created_at"`
+	HierarchicalTagID string    `xorm:"not null INDEX BIGINT(20) hierarchical_tag_id"`
+	RoleID            int       `xorm:"not null default 1 INT(11) role_id"`
+}
+
+// TableName hierarchical tag role relation table name
+func (HierarchicalTagRoleRel) TableName() string {
+	return "hierarchical_tag_role_rel"
+}
+
+// HierarchicalTagModerator a user moderating the hierarchical tag and its subtree
+type HierarchicalTagModerator struct {
+	ID                int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt         time.Time `xorm:"created TIMESTAMP created_at"`
+	HierarchicalTagID string    `xorm:"not null INDEX BIGINT(20) hierarchical_tag_id"`
+	UserID            string    `xorm:"not null INDEX BIGINT(20) user_id"`
+}
+
+// TableName hierarchical tag moderator table name
+func (HierarchicalTagModerator) TableName() string {
+	return "hierarchical_tag_moderator"
+}
+
 // HierarchicalTagWithChildren represents a hierarchical tag with its children
 type HierarchicalTagWithChildren struct {
 	HierarchicalTag
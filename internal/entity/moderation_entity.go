@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	// ModerationItemTypeReport the pending report of a post
+	ModerationItemTypeReport = "report"
+	// ModerationItemTypeReview the post which is waiting for review
+	ModerationItemTypeReview = "review"
+	// ModerationItemTypeRevision the unreviewed revision of a post or tag
+	ModerationItemTypeRevision = "revision"
+)
+
+// ModerationClaim the moderator who is working on the moderation item, the claim is a lease which expires
+type ModerationClaim struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	ItemType  string    `xorm:"not null default '' UNIQUE(moderation_item) VARCHAR(32) item_type"`
+	ItemID    string    `xorm:"not null default '' UNIQUE(moderation_item) VARCHAR(64) item_id"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	ExpiredAt time.Time `xorm:"not null INDEX TIMESTAMP expired_at"`
+}
+
+// TableName moderation claim table name
+func (ModerationClaim) TableName() string {
+	return "moderation_claim"
+}
+
+// ModerationLog the action of a moderator, the log is never updated or removed
+type ModerationLog struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UserID    string    `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+	ItemType  string    `xorm:"not null default '' VARCHAR(32) item_type"`
+	ItemID    string    `xorm:"not null default '' VARCHAR(64) item_id"`
+	ObjectID  string    `xorm:"not null default 0 INDEX BIGINT(20) object_id"`
+	Action    string    `xorm:"not null default '' VARCHAR(32) action"`
+	// BeforeSnapshot the status of the item and its object before the action in json
+	BeforeSnapshot string `xorm:"not null TEXT before_snapshot"`
+	// AfterSnapshot the status of the item and its object after the action in json
+	AfterSnapshot string `xorm:"not null TEXT after_snapshot"`
+	Reason        string `xorm:"not null default '' VARCHAR(500) reason"`
+}
+
+// TableName moderation log table name
+func (ModerationLog) TableName() string {
+	return "moderation_log"
+}
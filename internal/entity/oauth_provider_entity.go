@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	OAuthClientStatusAvailable = 1
+	OAuthClientStatusDeleted   = 10
+
+	OAuthRefreshTokenStatusAvailable = 1
+	OAuthRefreshTokenStatusRevoked   = 10
+
+	OAuthAuthorizationCodeStatusAvailable = 1
+	OAuthAuthorizationCodeStatusUsed      = 2
+	OAuthAuthorizationCodeStatusRevoked   = 10
+)
+
+// OAuthClient the application registered by the admin to sign in users with their site accounts
+type OAuthClient struct {
+	ID        string    `xorm:"not null pk BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	ClientID  string    `xorm:"not null default '' UNIQUE VARCHAR(64) client_id"`
+	// SecretHash the sha256 of the client secret, it is empty for the public clients
+	SecretHash string `xorm:"not null default '' VARCHAR(64) secret_hash"`
+	Name       string `xorm:"not null default '' VARCHAR(128) name"`
+	// RedirectURIs the redirect uris split by the new line
+	RedirectURIs string `xorm:"not null TEXT redirect_uris"`
+	Scopes       string `xorm:"not null default '' VARCHAR(255) scopes"`
+	// Public the public clients such as the mobile or single page apps cannot keep a secret, PKCE is required
+	Public bool `xorm:"not null default false BOOL public"`
+	Status int  `xorm:"not null default 1 INT(11) status"`
+}
+
+// TableName oauth client table name
+func (OAuthClient) TableName() string {
+	return "oauth_client"
+}
+
+// OAuthRefreshToken the refresh token issued to the client, only the hash of it is stored
+type OAuthRefreshToken struct {
+	ID        string    `xorm:"not null pk BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	ClientID  string    `xorm:"not null default '' INDEX VARCHAR(64) client_id"`
+	UserID    string    `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+	TokenHash string    `xorm:"not null default '' UNIQUE VARCHAR(64) token_hash"`
+	Scope     string    `xorm:"not null default '' VARCHAR(255) scope"`
+	ExpiredAt time.Time `xorm:"TIMESTAMP expired_at"`
+	Status    int       `xorm:"not null default 1 INT(11) status"`
+	// GrantID the authorization code the token comes from, it is kept when the token is rotated
+	GrantID string `xorm:"not null default 0 INDEX BIGINT(20) grant_id"`
+	// AccessTokenHash the access token issued with the refresh token, it is removed when the grant is revoked
+	AccessTokenHash string `xorm:"not null default '' VARCHAR(64) access_token_hash"`
+}
+
+// TableName oauth refresh token table name
+func (OAuthRefreshToken) TableName() string {
+	return "oauth_refresh_token"
+}
+
+// OAuthConsent the scopes the user has agreed to grant to the client
+type OAuthConsent struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	UserID    string    `xorm:"not null default 0 UNIQUE(user_client) BIGINT(20) user_id"`
+	ClientID  string    `xorm:"not null default '' UNIQUE(user_client) VARCHAR(64) client_id"`
+	Scope     string    `xorm:"not null default '' VARCHAR(255) scope"`
+}
+
+// TableName oauth consent table name
+func (OAuthConsent) TableName() string {
+	return "oauth_consent"
+}
+
+// OAuthAuthorizationCode the authorization code issued to the client, only the hash of it is stored.
+// It can be exchanged once, the tokens issued for it are revoked if it is used again.
+type OAuthAuthorizationCode struct {
+	ID                  string    `xorm:"not null pk BIGINT(20) id"`
+	CreatedAt           time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	CodeHash            string    `xorm:"not null default '' UNIQUE VARCHAR(64) code_hash"`
+	ClientID            string    `xorm:"not null default '' VARCHAR(64) client_id"`
+	UserID              string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	RedirectURI         string    `xorm:"not null TEXT redirect_uri"`
+	Scope               string    `xorm:"not null default '' VARCHAR(255) scope"`
+	CodeChallenge       string    `xorm:"not null default '' VARCHAR(128) code_challenge"`
+	CodeChallengeMethod string    `xorm:"not null default '' VARCHAR(10) code_challenge_method"`
+	Nonce               string    `xorm:"not null default '' VARCHAR(255) nonce"`
+	AuthTime            int64     `xorm:"not null default 0 BIGINT(20) auth_time"`
+	ExpiredAt           time.Time `xorm:"INDEX TIMESTAMP expired_at"`
+	Status              int       `xorm:"not null default 1 INT(11) status"`
+	// RedirectURISent the client sent the redirect uri in the authorization request, the token request must send it too
+	RedirectURISent bool `xorm:"not null default false BOOL redirect_uri_sent"`
+}
+
+// TableName oauth authorization code table name
+func (OAuthAuthorizationCode) TableName() string {
+	return "oauth_authorization_code"
+}
+
+// OAuthAccessToken the access token issued to the client kept in the cache
+type OAuthAccessToken struct {
+	ClientID string `json:"client_id"`
+	UserID   string `json:"user_id"`
+	Scope    string `json:"scope"`
+}
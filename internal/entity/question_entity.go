@@ -28,6 +28,7 @@ const (
 	QuestionStatusClosed    = 2
 	QuestionStatusDeleted   = 10
 	QuestionStatusPending   = 11
+	QuestionStatusScheduled = 12
 	QuestionUnPin           = 1
 	QuestionPin             = 2
 	QuestionShow            = 1
@@ -39,6 +40,7 @@ var AdminQuestionSearchStatus = map[string]int{
 	"closed":    QuestionStatusClosed,
 	"deleted":   QuestionStatusDeleted,
 	"pending":   QuestionStatusPending,
+	"scheduled": QuestionStatusScheduled,
 }
 
 var AdminQuestionSearchStatusIntToString = map[int]string{
@@ -46,6 +48,7 @@ var AdminQuestionSearchStatusIntToString = map[int]string{
 	QuestionStatusClosed:    "closed",
 	QuestionStatusDeleted:   "deleted",
 	QuestionStatusPending:   "pending",
+	QuestionStatusScheduled: "scheduled",
 }
 
 // Question question
@@ -85,6 +88,16 @@ func (Question) TableName() string {
 type QuestionWithTagsRevision struct {
 	Question
 	Tags []*TagSimpleInfoForRevision `json:"tags"`
+	// HierarchicalTags is nil for revisions recorded before hierarchical tags existed
+	HierarchicalTags []*HierarchicalTagSimpleInfoForRevision `json:"hierarchical_tags"`
+}
+
+// HierarchicalTagSimpleInfoForRevision hierarchical tag simple info for revision
+type HierarchicalTagSimpleInfoForRevision struct {
+	ID          string `xorm:"not null pk comment('hierarchical_tag_id') BIGINT(20) id"`
+	SlugName    string `xorm:"not null VARCHAR(100) slug_name"`
+	DisplayName string `xorm:"not null VARCHAR(100) display_name"`
+	Path        string `xorm:"not null TEXT path"`
 }
 
 // TagSimpleInfoForRevision tag simple info for revision
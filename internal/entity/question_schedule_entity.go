@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	QuestionScheduleStatusPending   = 1
+	QuestionScheduleStatusPublished = 2
+	QuestionScheduleStatusCancelled = 10
+)
+
+// QuestionSchedule the time when a scheduled question is published
+type QuestionSchedule struct {
+	ID         string    `xorm:"not null pk BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	QuestionID string    `xorm:"not null default 0 UNIQUE BIGINT(20) question_id"`
+	UserID     string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	PublishAt  time.Time `xorm:"not null INDEX TIMESTAMP publish_at"`
+	Status     int       `xorm:"not null default 1 INT(11) status"`
+}
+
+// TableName question schedule table name
+func (QuestionSchedule) TableName() string {
+	return "question_schedule"
+}
+
+const (
+	QuestionAutoCloseRuleStatusActive   = 1
+	QuestionAutoCloseRuleStatusInactive = 11
+)
+
+// QuestionAutoCloseRule closes the questions which have no activity for days
+type QuestionAutoCloseRule struct {
+	ID           string    `xorm:"not null pk BIGINT(20) id"`
+	CreatedAt    time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt    time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+	UserID       string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	Name         string    `xorm:"not null default '' VARCHAR(128) name"`
+	TagID        string    `xorm:"not null default 0 BIGINT(20) tag_id"`
+	InactiveDays int       `xorm:"not null default 0 INT(11) inactive_days"`
+	NoAnswer     bool      `xorm:"not null default false BOOL no_answer"`
+	CloseType    int       `xorm:"not null default 0 INT(11) close_type"`
+	CloseMsg     string    `xorm:"not null default '' VARCHAR(1024) close_msg"`
+	Status       int       `xorm:"not null default 1 INT(11) status"`
+}
+
+// TableName question auto close rule table name
+func (QuestionAutoCloseRule) TableName() string {
+	return "question_auto_close_rule"
+}
@@ -39,6 +39,8 @@ type Review struct {
 	Submitter      string    `xorm:"not null default '' VARCHAR(100) submitter"`
 	Reason         string    `xorm:"not null TEXT reason"`
 	Status         int       `xorm:"not null default 0 INT(11) status"`
+	// ShadowHide the post is hidden from others while it is pending, but the author is not told it is under review
+	ShadowHide bool `xorm:"not null default false BOOL shadow_hide"`
 }
 
 // TableName review table name
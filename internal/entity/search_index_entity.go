@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+// SearchIndexDocument a question or answer in the built-in search index
+type SearchIndexDocument struct {
+	ObjectID    string `xorm:"not null pk BIGINT(20) object_id"`
+	ObjectType  string `xorm:"not null default '' VARCHAR(20) object_type"`
+	QuestionID  string `xorm:"not null default 0 INDEX BIGINT(20) question_id"`
+	UserID      string `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+	Answers     int64  `xorm:"not null default 0 INT(11) answers"`
+	Views       int64  `xorm:"not null default 0 INT(11) views"`
+	Score       int64  `xorm:"not null default 0 INT(11) score"`
+	HasAccepted bool   `xorm:"not null default false BOOL has_accepted"`
+	Created     int64  `xorm:"not null default 0 BIGINT(20) 'created'"`
+	Active      int64  `xorm:"not null default 0 BIGINT(20) active"`
+	Length      int    `xorm:"not null default 0 INT(11) length"`
+	Status      int    `xorm:"not null default 1 INT(11) status"`
+	// AnswerUsers the ids of the users answered the question joined and wrapped by comma, e.g. ",1,2,"
+	AnswerUsers string `xorm:"not null TEXT answer_users"`
+	// CommentUsers the ids of the users commented on the document, wrapped by comma like the answer users
+	CommentUsers string `xorm:"not null TEXT comment_users"`
+}
+
+// TableName search index document table name
+func (SearchIndexDocument) TableName() string {
+	return "search_index_document"
+}
+
+// SearchIndexTerm the occurrences of a term in a document
+type SearchIndexTerm struct {
+	ID             int64  `xorm:"not null pk autoincr BIGINT(20) id"`
+	Term           string `xorm:"not null default '' INDEX(term_weight) VARCHAR(64) term"`
+	ObjectID       string `xorm:"not null INDEX BIGINT(20) object_id"`
+	Frequency      int    `xorm:"not null default 0 INT(11) frequency"`
+	TitleFrequency int    `xorm:"not null default 0 INT(11) title_frequency"`
+	// Weight the frequency with the title counted more, the postings of a term are ranked by it
+	Weight int `xorm:"not null default 0 INDEX(term_weight) INT(11) weight"`
+	// DocLength the length of the document, kept here to rank without loading the documents
+	DocLength int `xorm:"not null default 0 INT(11) doc_length"`
+	// Positions the first positions of the term joined by comma, used to match the phrases
+	Positions string `xorm:"not null TEXT positions"`
+}
+
+// TableName search index term table name
+func (SearchIndexTerm) TableName() string {
+	return "search_index_term"
+}
+
+// SearchIndexTag the tag of a document in the built-in search index
+type SearchIndexTag struct {
+	ID       int64  `xorm:"not null pk autoincr BIGINT(20) id"`
+	ObjectID string `xorm:"not null INDEX BIGINT(20) object_id"`
+	TagID    string `xorm:"not null INDEX BIGINT(20) tag_id"`
+}
+
+// TableName search index tag table name
+func (SearchIndexTag) TableName() string {
+	return "search_index_tag"
+}
@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// SearchChange a searchable content is changed, the id is the cursor of the search sync
+type SearchChange struct {
+	ID         int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"not null default CURRENT_TIMESTAMP created INDEX TIMESTAMP created_at"`
+	ObjectID   string    `xorm:"not null default 0 BIGINT(20) object_id"`
+	ObjectType string    `xorm:"not null default '' VARCHAR(20) object_type"`
+}
+
+// TableName search change table name
+func (SearchChange) TableName() string {
+	return "search_change"
+}
+
+// SearchSyncCursor the position of the changes a search plugin has synced
+type SearchSyncCursor struct {
+	PluginSlugName string    `xorm:"not null pk VARCHAR(100) plugin_slug_name"`
+	UpdatedAt      time.Time `xorm:"updated TIMESTAMP updated_at"`
+	// Cursor the id of the last synced change, SearchSyncCursorReindex if the plugin must be reindexed
+	Cursor int64 `xorm:"not null default 0 BIGINT(20) sync_cursor"`
+	// ReindexedAt the time the last full reindex finished
+	ReindexedAt time.Time `xorm:"TIMESTAMP reindexed_at"`
+}
+
+// SearchSyncCursorReindex the changes after the cursor are pruned, all the contents must be synced again
+const SearchSyncCursorReindex = -1
+
+// TableName search sync cursor table name
+func (SearchSyncCursor) TableName() string {
+	return "search_sync_cursor"
+}
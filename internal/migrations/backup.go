@@ -0,0 +1,518 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/pkg/dir"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+const (
+	// BackupFormatVersion the version of the backup archive layout
+	BackupFormatVersion = 1
+
+	backupManifestName = "manifest.json"
+	backupTableDir     = "tables/"
+	backupUploadDir    = "uploads/"
+	backupConfigName   = "config/config.yaml"
+	backupBatchSize    = 100
+)
+
+// BackupManifest describes the backup archive
+type BackupManifest struct {
+	FormatVersion int            `json:"format_version"`
+	AnswerVersion string         `json:"answer_version"`
+	DBVersion     int64          `json:"db_version"`
+	Driver        string         `json:"driver"`
+	CreatedAt     time.Time      `json:"created_at"`
+	Tables        []*BackupTable `json:"tables"`
+	UploadFiles   int            `json:"upload_files"`
+}
+
+// BackupTable the table and the amount of rows in the backup archive
+type BackupTable struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+// BackupOptions the sources of the backup
+type BackupOptions struct {
+	AnswerVersion  string
+	UploadPath     string
+	ConfigFilePath string
+}
+
+// Backup writes all the tables as json lines, the uploaded files and the config file into a tar.gz archive.
+// The rows are keyed by the column names, so the archive can be restored into any supported database.
+func Backup(dbConf *data.Database, opts *BackupOptions, archivePath string) (manifest *BackupManifest, err error) {
+	engine, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return nil, err
+	}
+	defer engine.Close()
+
+	version := &entity.Version{ID: 1}
+	if _, err = engine.Get(version); err != nil {
+		return nil, fmt.Errorf("get db version failed: %w", err)
+	}
+	manifest = &BackupManifest{
+		FormatVersion: BackupFormatVersion,
+		AnswerVersion: opts.AnswerVersion,
+		DBVersion:     version.VersionNumber,
+		Driver:        dbConf.Driver,
+		CreatedAt:     time.Now(),
+	}
+
+	if err = dir.CreateDirIfNotExist(filepath.Dir(archivePath)); err != nil {
+		return nil, err
+	}
+	// the archive has the config file and all the data, only the owner can read it
+	file, err := os.OpenFile(archivePath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	gw := gzip.NewWriter(file)
+	tw := tar.NewWriter(gw)
+
+	for _, bean := range tables {
+		table, err := backupTable(engine, tw, bean)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Tables = append(manifest.Tables, table)
+	}
+	if len(opts.UploadPath) > 0 {
+		if manifest.UploadFiles, err = backupUploadFiles(tw, opts.UploadPath); err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.ConfigFilePath) > 0 {
+		content, err := os.ReadFile(opts.ConfigFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("read config file failed: %w", err)
+		}
+		if err = writeTarFile(tw, backupConfigName, content); err != nil {
+			return nil, err
+		}
+	}
+	content, _ := json.MarshalIndent(manifest, "", "  ")
+	if err = writeTarFile(tw, backupManifestName, content); err != nil {
+		return nil, err
+	}
+
+	if err = tw.Close(); err != nil {
+		return nil, err
+	}
+	if err = gw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, file.Close()
+}
+
+func backupTable(engine *xorm.Engine, tw *tar.Writer, bean any) (table *BackupTable, err error) {
+	tableInfo, err := engine.TableInfo(bean)
+	if err != nil {
+		return nil, err
+	}
+	table = &BackupTable{Name: tableInfo.Name}
+
+	// the size of the tar entry must be known before writing, so the rows are buffered in a temporary file
+	tmp, err := os.CreateTemp("", "answer-backup-*.jsonl")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+	w := bufio.NewWriter(tmp)
+	beanType := reflect.TypeOf(bean).Elem()
+	rows, err := engine.Table(tableInfo.Name).Rows(reflect.New(beanType).Interface())
+	if err != nil {
+		return nil, fmt.Errorf("read table %s failed: %w", tableInfo.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		row := reflect.New(beanType)
+		if err = rows.Scan(row.Interface()); err != nil {
+			return nil, fmt.Errorf("read table %s failed: %w", tableInfo.Name, err)
+		}
+		values := make(map[string]any, len(tableInfo.Columns()))
+		for _, col := range tableInfo.Columns() {
+			values[col.Name] = row.Elem().FieldByIndex(col.FieldIndex).Interface()
+		}
+		line, err := json.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+		_, _ = w.Write(line)
+		_ = w.WriteByte('\n')
+		table.Rows++
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if err = w.Flush(); err != nil {
+		return nil, err
+	}
+	return table, writeTarFileFrom(tw, backupTableDir+tableInfo.Name+".jsonl", tmp)
+}
+
+func backupUploadFiles(tw *tar.Writer, uploadPath string) (count int, err error) {
+	if _, err = os.Stat(uploadPath); os.IsNotExist(err) {
+		return 0, nil
+	}
+	err = filepath.WalkDir(uploadPath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(uploadPath, filePath)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		count++
+		return writeTarFileFrom(tw, backupUploadDir+filepath.ToSlash(relPath), file)
+	})
+	return count, err
+}
+
+func writeTarFile(tw *tar.Writer, name string, content []byte) error {
+	return writeTarEntry(tw, name, int64(len(content)), bytes.NewReader(content))
+}
+
+// writeTarFileFrom writes the whole file from the beginning into the archive
+func writeTarFileFrom(tw *tar.Writer, name string, file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return writeTarEntry(tw, name, info.Size(), file)
+}
+
+func writeTarEntry(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.CopyN(tw, r, size)
+	return err
+}
+
+// ReadBackupManifest reads the manifest of the backup archive
+func ReadBackupManifest(archivePath string) (manifest *BackupManifest, err error) {
+	err = walkBackupArchive(archivePath, func(name string, r io.Reader) error {
+		if name != backupManifestName {
+			return nil
+		}
+		manifest = &BackupManifest{}
+		return json.NewDecoder(r).Decode(manifest)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("manifest not found in %s", archivePath)
+	}
+	return manifest, nil
+}
+
+// RestoreOptions the targets of the restore
+type RestoreOptions struct {
+	UploadPath string
+}
+
+// Restore loads the backup archive into an empty database, the tables are created by the current schema.
+// The archive must be made from a database at the same version as this Answer.
+// The rows are inserted in one transaction, so a failed restore leaves the tables empty and can be retried.
+func Restore(dbConf *data.Database, opts *RestoreOptions, archivePath string) (manifest *BackupManifest, err error) {
+	manifest, err = ReadBackupManifest(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.FormatVersion != BackupFormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d", manifest.FormatVersion)
+	}
+	if manifest.DBVersion != ExpectedVersion() {
+		return nil, fmt.Errorf("the backup is made at db version %d but this Answer expects %d, "+
+			"please upgrade the source to the same version before backup", manifest.DBVersion, ExpectedVersion())
+	}
+
+	engine, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return nil, err
+	}
+	defer engine.Close()
+	missingTables, err := checkEmptyDatabase(engine)
+	if err != nil {
+		return nil, err
+	}
+	if err = engine.Sync(missingTables...); err != nil {
+		return nil, fmt.Errorf("create tables failed: %w", err)
+	}
+
+	beans := make(map[string]any, len(tables))
+	for _, bean := range tables {
+		tableInfo, err := engine.TableInfo(bean)
+		if err != nil {
+			return nil, err
+		}
+		beans[tableInfo.Name] = bean
+	}
+	session := engine.NewSession()
+	defer session.Close()
+	if err = session.Begin(); err != nil {
+		return nil, err
+	}
+	err = walkBackupArchive(archivePath, func(name string, r io.Reader) error {
+		switch {
+		case strings.HasPrefix(name, backupTableDir):
+			tableName := strings.TrimSuffix(strings.TrimPrefix(name, backupTableDir), ".jsonl")
+			bean, ok := beans[tableName]
+			if !ok {
+				return fmt.Errorf("unknown table %s in backup", tableName)
+			}
+			return restoreTable(engine, session, bean, r)
+		case strings.HasPrefix(name, backupUploadDir):
+			if len(opts.UploadPath) == 0 {
+				return nil
+			}
+			return restoreFile(opts.UploadPath, strings.TrimPrefix(name, backupUploadDir), r)
+		}
+		return nil
+	})
+	if err != nil {
+		_ = session.Rollback()
+		return nil, err
+	}
+	if err = session.Commit(); err != nil {
+		return nil, fmt.Errorf("commit restored rows failed: %w", err)
+	}
+	if err = resetSequences(engine); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// checkEmptyDatabase returns the tables to be created. The tables left by a failed restore are empty,
+// they are kept as they are, so they do not block the retry.
+func checkEmptyDatabase(engine *xorm.Engine) (missingTables []any, err error) {
+	for _, bean := range tables {
+		exist, err := engine.IsTableExist(bean)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			missingTables = append(missingTables, bean)
+			continue
+		}
+		count, err := engine.Count(bean)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("the database is not empty, please restore into a new database")
+		}
+	}
+	return missingTables, nil
+}
+
+// RestoreConfig writes the config file in the backup archive to the config file path
+func RestoreConfig(archivePath, configFilePath string) (err error) {
+	found := false
+	err = walkBackupArchive(archivePath, func(name string, r io.Reader) error {
+		if name != backupConfigName {
+			return nil
+		}
+		found = true
+		return restoreFile(filepath.Dir(configFilePath), filepath.Base(configFilePath), r)
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("config file not found in %s", archivePath)
+	}
+	return nil
+}
+
+func restoreTable(engine *xorm.Engine, session *xorm.Session, bean any, r io.Reader) (err error) {
+	tableInfo, err := engine.TableInfo(bean)
+	if err != nil {
+		return err
+	}
+	beanType := reflect.TypeOf(bean).Elem()
+	batch := reflect.MakeSlice(reflect.SliceOf(reflect.PointerTo(beanType)), 0, backupBatchSize)
+	insert := func() error {
+		if batch.Len() == 0 {
+			return nil
+		}
+		// keep the created and updated time in the backup
+		_, err := session.Table(tableInfo.Name).NoAutoTime().Insert(batch.Interface())
+		if err != nil {
+			return fmt.Errorf("restore table %s failed: %w", tableInfo.Name, err)
+		}
+		batch = batch.Slice(0, 0)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		values := make(map[string]json.RawMessage)
+		if err = json.Unmarshal(scanner.Bytes(), &values); err != nil {
+			return fmt.Errorf("decode row of table %s failed: %w", tableInfo.Name, err)
+		}
+		row := reflect.New(beanType)
+		for _, col := range tableInfo.Columns() {
+			raw, ok := values[col.Name]
+			if !ok {
+				continue
+			}
+			if err = json.Unmarshal(raw, row.Elem().FieldByIndex(col.FieldIndex).Addr().Interface()); err != nil {
+				return fmt.Errorf("decode column %s.%s failed: %w", tableInfo.Name, col.Name, err)
+			}
+		}
+		batch = reflect.Append(batch, row)
+		if batch.Len() >= backupBatchSize {
+			if err = insert(); err != nil {
+				return err
+			}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+	return insert()
+}
+
+// resetSequences moves the postgres sequences after the restored ids, the other databases do it by themselves
+func resetSequences(engine *xorm.Engine) error {
+	if engine.Dialect().URI().DBType != schemas.POSTGRES {
+		return nil
+	}
+	for _, bean := range tables {
+		if err := resetSequence(engine, bean); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetSequence moves the postgres sequence of the table after its max id, the rows inserted with their ids
+// do not move it, so the next insert would get a used id
+func resetSequence(engine *xorm.Engine, bean any) error {
+	if engine.Dialect().URI().DBType != schemas.POSTGRES {
+		return nil
+	}
+	tableInfo, err := engine.TableInfo(bean)
+	if err != nil {
+		return err
+	}
+	if len(tableInfo.AutoIncrement) == 0 {
+		return nil
+	}
+	_, err = engine.Exec(fmt.Sprintf(
+		"SELECT setval(pg_get_serial_sequence('%s', '%s'), COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false)",
+		tableInfo.Name, tableInfo.AutoIncrement, tableInfo.AutoIncrement, tableInfo.Name))
+	if err != nil {
+		return fmt.Errorf("reset sequence of table %s failed: %w", tableInfo.Name, err)
+	}
+	return nil
+}
+
+func restoreFile(baseDir, name string, r io.Reader) error {
+	target := filepath.Join(baseDir, filepath.FromSlash(path.Clean("/"+name)))
+	if err := dir.CreateDirIfNotExist(filepath.Dir(target)); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(target, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err = io.Copy(file, r); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func walkBackupArchive(archivePath string, fn func(name string, r io.Reader) error) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gr, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("open backup archive failed: %w", err)
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read backup archive failed: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err = fn(header.Name, tr); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm/schemas"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourceConf := &data.Database{Driver: string(schemas.SQLITE), Connection: filepath.Join(tmpDir, "source.db")}
+	source, err := data.NewDB(false, sourceConf)
+	require.NoError(t, err)
+	require.NoError(t, source.Sync(tables...))
+	_, err = source.Insert(&entity.Version{ID: 1, VersionNumber: ExpectedVersion()})
+	require.NoError(t, err)
+	createdAt := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	_, err = source.NoAutoTime().Insert(&entity.User{ID: "1", Username: "admin", EMail: "admin@example.com",
+		CreatedAt: createdAt, UpdatedAt: createdAt, Status: entity.UserStatusAvailable})
+	require.NoError(t, err)
+	_, err = source.Insert(&entity.QueueMessage{Queue: "notification", Payload: "{}", NextRunAt: createdAt})
+	require.NoError(t, err)
+	require.NoError(t, source.Close())
+
+	uploadPath := filepath.Join(tmpDir, "uploads")
+	require.NoError(t, os.MkdirAll(filepath.Join(uploadPath, "avatar"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(uploadPath, "avatar", "a.png"), []byte("png"), 0o644))
+	configFilePath := filepath.Join(tmpDir, "config.yaml")
+	require.NoError(t, os.WriteFile(configFilePath, []byte("debug: false\n"), 0o644))
+
+	archivePath := filepath.Join(tmpDir, "backup", "answer_backup.tar.gz")
+	manifest, err := Backup(sourceConf, &BackupOptions{
+		AnswerVersion:  "test",
+		UploadPath:     uploadPath,
+		ConfigFilePath: configFilePath,
+	}, archivePath)
+	require.NoError(t, err)
+	assert.Equal(t, ExpectedVersion(), manifest.DBVersion)
+	assert.Equal(t, 1, manifest.UploadFiles)
+	info, err := os.Stat(archivePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	targetConf := &data.Database{Driver: string(schemas.SQLITE), Connection: filepath.Join(tmpDir, "target.db")}
+	restoreUploadPath := filepath.Join(tmpDir, "restored_uploads")
+	_, err = Restore(targetConf, &RestoreOptions{UploadPath: restoreUploadPath}, archivePath)
+	require.NoError(t, err)
+
+	target, err := data.NewDB(false, targetConf)
+	require.NoError(t, err)
+	defer target.Close()
+	user := &entity.User{}
+	exist, err := target.ID("1").Get(user)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, "admin", user.Username)
+	assert.True(t, createdAt.Equal(user.CreatedAt))
+	count, err := target.Count(&entity.QueueMessage{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	content, err := os.ReadFile(filepath.Join(restoreUploadPath, "avatar", "a.png"))
+	assert.NoError(t, err)
+	assert.Equal(t, "png", string(content))
+
+	restoredConfigPath := filepath.Join(tmpDir, "restored", "config.yaml")
+	require.NoError(t, RestoreConfig(archivePath, restoredConfigPath))
+	content, err = os.ReadFile(restoredConfigPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "debug: false\n", string(content))
+
+	_, err = Restore(targetConf, &RestoreOptions{}, archivePath)
+	assert.Error(t, err, "restore into a database which is not empty")
+}
+
+func TestRestoreRetryAfterFailure(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeArchive := func(archivePath string, tableFiles map[string]string) {
+		file, err := os.Create(archivePath)
+		require.NoError(t, err)
+		defer file.Close()
+		gw := gzip.NewWriter(file)
+		tw := tar.NewWriter(gw)
+		// the version rows are restored before the broken table
+		for _, name := range []string{"version", "broken"} {
+			if content, ok := tableFiles[name]; ok {
+				require.NoError(t, writeTarFile(tw, backupTableDir+name+".jsonl", []byte(content)))
+			}
+		}
+		content, _ := json.Marshal(&BackupManifest{FormatVersion: BackupFormatVersion, DBVersion: ExpectedVersion()})
+		require.NoError(t, writeTarFile(tw, backupManifestName, content))
+		require.NoError(t, tw.Close())
+		require.NoError(t, gw.Close())
+	}
+	versionRows := fmt.Sprintf("{\"id\":1,\"version_number\":%d}\n", ExpectedVersion())
+	brokenArchivePath := filepath.Join(tmpDir, "broken.tar.gz")
+	writeArchive(brokenArchivePath, map[string]string{"version": versionRows, "broken": "{}\n"})
+	archivePath := filepath.Join(tmpDir, "backup.tar.gz")
+	writeArchive(archivePath, map[string]string{"version": versionRows})
+
+	targetConf := &data.Database{Driver: string(schemas.SQLITE), Connection: filepath.Join(tmpDir, "target.db")}
+	_, err := Restore(targetConf, &RestoreOptions{}, brokenArchivePath)
+	require.Error(t, err)
+
+	// the rows of the failed restore are rolled back, so it can be restored again
+	_, err = Restore(targetConf, &RestoreOptions{}, archivePath)
+	require.NoError(t, err)
+	target, err := data.NewDB(false, targetConf)
+	require.NoError(t, err)
+	defer target.Close()
+	version := &entity.Version{ID: 1}
+	exist, err := target.Get(version)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, ExpectedVersion(), version.VersionNumber)
+}
@@ -67,6 +67,8 @@ func (m *Mentor) InitDB() error {
 	m.do("init version table", m.initVersionTable)
 	m.do("init admin user", m.initAdminUser)
 	m.do("init config", m.initConfig)
+	m.do("init email reply secret", m.initEmailReplySecret)
+	m.do("init oauth signing key", m.initOAuthSigningKey)
 	m.do("init default privileges config", m.initDefaultRankPrivileges)
 	m.do("init role", m.initRole)
 	m.do("init power", m.initPower)
@@ -83,6 +85,7 @@ func (m *Mentor) InitDB() error {
 	m.do("init site info legal", m.initSiteInfoLegalConfig)
 	m.do("init default content", m.initDefaultContent)
 	m.do("init default badges", m.initDefaultBadges)
+	m.do("reset sequences", m.resetSequences)
 	return m.err
 }
 
@@ -130,6 +133,21 @@ func (m *Mentor) initConfig() {
 	_, m.err = m.engine.Context(m.ctx).Insert(defaultConfigTable)
 }
 
+func (m *Mentor) initEmailReplySecret() {
+	_, m.err = m.engine.Context(m.ctx).Insert(&entity.Config{
+		ID: emailReplySecretConfigID, Key: constant.EmailReplySecretKey, Value: newEmailReplySecret()})
+}
+
+func (m *Mentor) initOAuthSigningKey() {
+	signingKey, err := newOAuthSigningKey()
+	if err != nil {
+		m.err = err
+		return
+	}
+	_, m.err = m.engine.Context(m.ctx).Insert(&entity.Config{
+		ID: oauthSigningKeyConfigID, Key: constant.OAuthSigningKeyConfigKey, Value: signingKey})
+}
+
 func (m *Mentor) initDefaultRankPrivileges() {
 	chooseOption := schema.DefaultPrivilegeOptions.Choose(schema.PrivilegeLevel2)
 	for _, privilege := range chooseOption.Privileges {
@@ -457,6 +475,11 @@ func (m *Mentor) initDefaultContent() {
 	}
 }
 
+// resetSequences the default data is inserted with its ids, move the postgres sequences after them
+func (m *Mentor) resetSequences() {
+	m.err = resetSequences(m.engine)
+}
+
 func (m *Mentor) initDefaultBadges() {
 	uniqueIDRepo := unique.NewUniqueIDRepo(&data.Data{DB: m.engine})
 
@@ -76,6 +76,30 @@ var (
 		&entity.BadgeAward{},
 		&entity.FileRecord{},
 		&entity.PluginKVStorage{},
+		&entity.HierarchicalTag{},
+		&entity.QuestionHierarchicalTagRel{},
+		&entity.HierarchicalTagRoleRel{},
+		&entity.HierarchicalTagModerator{},
+		&entity.QueueMessage{},
+		&entity.QueueMessageReceipt{},
+		&entity.Webhook{},
+		&entity.WebhookDelivery{},
+		&entity.SearchIndexDocument{},
+		&entity.SearchIndexTerm{},
+		&entity.SearchIndexTag{},
+		&entity.SearchChange{},
+		&entity.SearchSyncCursor{},
+		&entity.UserDigestConfig{},
+		&entity.PersonalAccessToken{},
+		&entity.OAuthClient{},
+		&entity.OAuthRefreshToken{},
+		&entity.OAuthAuthorizationCode{},
+		&entity.OAuthConsent{},
+		&entity.Draft{},
+		&entity.QuestionSchedule{},
+		&entity.QuestionAutoCloseRule{},
+		&entity.ModerationClaim{},
+		&entity.ModerationLog{},
 	}
 
 	roles = []*entity.Role{
@@ -350,6 +374,7 @@ var (
 		{ID: 128, Key: "rank.answer.undeleted", Value: `-1`},
 		{ID: 129, Key: "rank.question.undeleted", Value: `-1`},
 		{ID: 130, Key: "rank.tag.undeleted", Value: `-1`},
+		{ID: 133, Key: "question.published", Value: `0`},
 	}
 
 	defaultBadgeGroupTable = []*entity.BadgeGroup{
@@ -104,6 +104,21 @@ var migrations = []Migration{
 	NewMigration("v1.5.1", "add plugin kv storage", addPluginKVStorage, true),
 	NewMigration("v1.6.0", "move user config to interface", moveUserConfigToInterface, true),
 	NewMigration("v1.7.0", "add hierarchical tags", addHierarchicalTags, false),
+	// the migrations below are not released upstream, the suffixed versions keep them apart from the upstream ones
+	NewMigration("v1.7.0-1", "add hierarchical tag permissions", addHierarchicalTagPermissions, false),
+	NewMigration("v1.7.0-2", "add queue message", addQueueMessage, false),
+	NewMigration("v1.7.0-3", "add webhook", addWebhook, false),
+	NewMigration("v1.7.0-4", "add search index", addSearchIndex, false),
+	NewMigration("v1.7.0-5", "add search change and sync cursor", addSearchSync, false),
+	NewMigration("v1.7.0-6", "add user digest config", addUserDigestConfig, false),
+	NewMigration("v1.7.0-7", "add email reply secret", addEmailReplySecret, false),
+	NewMigration("v1.7.0-8", "add personal access token", addPersonalAccessToken, false),
+	NewMigration("v1.7.0-9", "add oauth provider", addOAuthProvider, false),
+	NewMigration("v1.7.0-10", "add draft", addDraft, false),
+	NewMigration("v1.7.0-11", "add question schedule", addQuestionSchedule, false),
+	NewMigration("v1.7.0-12", "add moderation queue", addModeration, false),
+	NewMigration("v1.7.0-13", "add review shadow hide", addReviewShadowHide, false),
+	NewMigration("v1.7.0-14", "reset role sequence", resetRoleSequence, false),
 }
 
 func GetMigrations() []Migration {
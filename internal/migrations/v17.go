@@ -21,175 +21,79 @@ package migrations
 
 import (
 	"context"
-	"time"
+	"fmt"
 
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/permission"
 	"xorm.io/xorm"
 )
 
-func addHierarchicalTags(ctx context.Context, x *xorm.Engine) error {
-	type HierarchicalTag struct {
-		ID          string    `xorm:"not null pk comment('hierarchical_tag_id') BIGINT(20) id"`
-		CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
-		UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
-		Name        string    `xorm:"not null VARCHAR(100) name"`
-		SlugName    string    `xorm:"not null unique VARCHAR(100) slug_name"`
-		ParentID    string    `xorm:"default null BIGINT(20) parent_id"`
-		Level       int       `xorm:"not null default 0 INT(11) level"`
-		Path        string    `xorm:"not null TEXT path"`
-		DisplayName string    `xorm:"not null VARCHAR(100) display_name"`
-		Description string    `xorm:"TEXT description"`
-		Status      int       `xorm:"not null default 1 INT(11) status"`
-		SortOrder   int       `xorm:"not null default 0 INT(11) sort_order"`
+func addRecoverPermission(ctx context.Context, x *xorm.Engine) error {
+	powers := []*entity.Power{
+		{ID: 39, Name: "recover answer", PowerType: permission.AnswerUnDelete, Description: "recover deleted answer"},
+		{ID: 40, Name: "recover question", PowerType: permission.QuestionUnDelete, Description: "recover deleted question"},
+		{ID: 41, Name: "recover tag", PowerType: permission.TagUnDelete, Description: "recover deleted tag"},
 	}
-
-	type QuestionHierarchicalTagRel struct {
-		ID                  int64     `xorm:"not null pk autoincr BIGINT(20) id"`
-		CreatedAt           time.Time `xorm:"created TIMESTAMP created_at"`
-		QuestionID          string    `xorm:"not null INDEX BIGINT(20) question_id"`
-		HierarchicalTagID   string    `xorm:"not null INDEX BIGINT(20) hierarchical_tag_id"`
-		HierarchicalTagPath string    `xorm:"not null TEXT hierarchical_tag_path"`
-		Status              int       `xorm:"not null default 1 INT(11) status"`
+	for _, power := range powers {
+		exist, err := x.Context(ctx).Get(&entity.Power{PowerType: power.PowerType})
+		if err != nil {
+			return fmt.Errorf("get power failed: %w", err)
+		}
+		if exist {
+			_, err = x.Context(ctx).ID(power.ID).Update(power)
+			if err != nil {
+				return fmt.Errorf("update power failed: %w", err)
+			}
+		} else {
+			_, err = x.Context(ctx).Insert(power)
+			if err != nil {
+				return fmt.Errorf("insert power failed: %w", err)
+			}
+		}
 	}
 
-	err := x.Context(ctx).Sync(new(HierarchicalTag), new(QuestionHierarchicalTagRel))
-	if err != nil {
-		return err
-	}
+	rolePowerRels := []*entity.RolePowerRel{
+		{RoleID: 2, PowerType: permission.AnswerUnDelete},
+		{RoleID: 2, PowerType: permission.QuestionUnDelete},
+		{RoleID: 2, PowerType: permission.TagUnDelete},
 
-	// Insert sample hierarchical tags
-	sampleTags := []HierarchicalTag{
-		// Root level tags
-		{
-			ID:          "1001",
-			Name:        "customer",
-			SlugName:    "customer",
-			DisplayName: "Customer",
-			ParentID:    "",
-			Level:       0,
-			Path:        "#Customer",
-			Description: "Customer related topics",
-			Status:      1,
-			SortOrder:   1,
-		},
-		{
-			ID:          "1002",
-			Name:        "internal",
-			SlugName:    "internal",
-			DisplayName: "Internal",
-			ParentID:    "",
-			Level:       0,
-			Path:        "#Internal",
-			Description: "Internal topics",
-			Status:      1,
-			SortOrder:   2,
-		},
-		// Customer sub-categories
-		{
-			ID:          "2001",
-			Name:        "fullstack",
-			SlugName:    "fullstack",
-			DisplayName: "FullStack",
-			ParentID:    "1001",
-			Level:       1,
-			Path:        "#Customer#FullStack",
-			Description: "Full stack development",
-			Status:      1,
-			SortOrder:   1,
-		},
-		{
-			ID:          "2002",
-			Name:        "backend",
-			SlugName:    "backend",
-			DisplayName: "Backend",
-			ParentID:    "1001",
-			Level:       1,
-			Path:        "#Customer#Backend",
-			Description: "Backend development",
-			Status:      1,
-			SortOrder:   2,
-		},
-		{
-			ID:          "2003",
-			Name:        "frontend",
-			SlugName:    "frontend",
-			DisplayName: "Frontend",
-			ParentID:    "1001",
-			Level:       1,
-			Path:        "#Customer#Frontend",
-			Description: "Frontend development",
-			Status:      1,
-			SortOrder:   3,
-		},
-		{
-			ID:          "2004",
-			Name:        "mobile",
-			SlugName:    "mobile",
-			DisplayName: "Mobile",
-			ParentID:    "1001",
-			Level:       1,
-			Path:        "#Customer#Mobile",
-			Description: "Mobile development",
-			Status:      1,
-			SortOrder:   4,
-		},
-		// Backend sub-categories
-		{
-			ID:          "3001",
-			Name:        "nodejs",
-			SlugName:    "nodejs",
-			DisplayName: "Node.js",
-			ParentID:    "2002",
-			Level:       2,
-			Path:        "#Customer#Backend#Node.js",
-			Description: "Node.js backend development",
-			Status:      1,
-			SortOrder:   1,
-		},
-		{
-			ID:          "3002",
-			Name:        "java",
-			SlugName:    "java",
-			DisplayName: "Java",
-			ParentID:    "2002",
-			Level:       2,
-			Path:        "#Customer#Backend#Java",
-			Description: "Java backend development",
-			Status:      1,
-			SortOrder:   2,
-		},
-		{
-			ID:          "3003",
-			Name:        "python",
-			SlugName:    "python",
-			DisplayName: "Python",
-			ParentID:    "2002",
-			Level:       2,
-			Path:        "#Customer#Backend#Python",
-			Description: "Python backend development",
-			Status:      1,
-			SortOrder:   3,
-		},
-		// FullStack sub-categories
-		{
-			ID:          "3004",
-			Name:        "java-fullstack",
-			SlugName:    "java-fullstack",
-			DisplayName: "Java",
-			ParentID:    "2001",
-			Level:       2,
-			Path:        "#Customer#FullStack#Java",
-			Description: "Java full stack development",
-			Status:      1,
-			SortOrder:   1,
-		},
+		{RoleID: 3, PowerType: permission.AnswerUnDelete},
+		{RoleID: 3, PowerType: permission.QuestionUnDelete},
+		{RoleID: 3, PowerType: permission.TagUnDelete},
 	}
-
-	for _, tag := range sampleTags {
-		_, err = x.Context(ctx).Insert(&tag)
+	for _, rel := range rolePowerRels {
+		exist, err := x.Context(ctx).Get(&entity.RolePowerRel{RoleID: rel.RoleID, PowerType: rel.PowerType})
 		if err != nil {
-			return err
+			return fmt.Errorf("get role power rel failed: %w", err)
+		}
+		if exist {
+			continue
+		}
+		_, err = x.Context(ctx).Insert(rel)
+		if err != nil {
+			return fmt.Errorf("insert role power rel failed: %w", err)
 		}
 	}
 
+	defaultConfigTable := []*entity.Config{
+		{ID: 128, Key: "rank.answer.undeleted", Value: `-1`},
+		{ID: 129, Key: "rank.question.undeleted", Value: `-1`},
+		{ID: 130, Key: "rank.tag.undeleted", Value: `-1`},
+	}
+	for _, c := range defaultConfigTable {
+		exist, err := x.Context(ctx).Get(&entity.Config{ID: c.ID})
+		if err != nil {
+			return fmt.Errorf("get config failed: %w", err)
+		}
+		if exist {
+			if _, err = x.Context(ctx).Update(c, &entity.Config{ID: c.ID}); err != nil {
+				return fmt.Errorf("update config failed: %w", err)
+			}
+			continue
+		}
+		if _, err = x.Context(ctx).Insert(&entity.Config{ID: c.ID, Key: c.Key, Value: c.Value}); err != nil {
+			return fmt.Errorf("add config failed: %w", err)
+		}
+	}
 	return nil
 }
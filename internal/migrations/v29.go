@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+func addHierarchicalTagPermissions(ctx context.Context, x *xorm.Engine) error {
+	type HierarchicalTag struct {
+		LeafOnly bool `xorm:"not null default false BOOL leaf_only"`
+	}
+
+	type HierarchicalTagRoleRel struct {
+		ID                int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+		CreatedAt         time.Time `xorm:"created TIMESTAMP created_at"`
+		HierarchicalTagID string    `xorm:"not null INDEX BIGINT(20) hierarchical_tag_id"`
+		RoleID            int       `xorm:"not null default 1 INT(11) role_id"`
+	}
+
+	type HierarchicalTagModerator struct {
+		ID                int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+		CreatedAt         time.Time `xorm:"created TIMESTAMP created_at"`
+		HierarchicalTagID string    `xorm:"not null INDEX BIGINT(20) hierarchical_tag_id"`
+		UserID            string    `xorm:"not null INDEX BIGINT(20) user_id"`
+	}
+
+	return x.Context(ctx).Sync(new(HierarchicalTag), new(HierarchicalTagRoleRel), new(HierarchicalTagModerator))
+}
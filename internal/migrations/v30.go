@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+func addQueueMessage(ctx context.Context, x *xorm.Engine) error {
+	type QueueMessage struct {
+		ID          int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+		CreatedAt   time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		UpdatedAt   time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+		Queue       string    `xorm:"not null default '' INDEX VARCHAR(64) queue"`
+		Payload     string    `xorm:"not null MEDIUMTEXT payload"`
+		Status      int       `xorm:"not null default 1 INDEX INT(11) status"`
+		Attempts    int       `xorm:"not null default 0 INT(11) attempts"`
+		NextRunAt   time.Time `xorm:"not null INDEX TIMESTAMP next_run_at"`
+		LockedUntil time.Time `xorm:"TIMESTAMP locked_until"`
+		LastError   string    `xorm:"TEXT last_error"`
+		OrderKey    string    `xorm:"not null default '' INDEX VARCHAR(128) order_key"`
+	}
+	type QueueMessageReceipt struct {
+		ID        int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+		CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		MessageID int64     `xorm:"not null INDEX BIGINT(20) message_id"`
+		EffectKey string    `xorm:"not null UNIQUE VARCHAR(191) effect_key"`
+	}
+	return x.Context(ctx).Sync(new(QueueMessage), new(QueueMessageReceipt))
+}
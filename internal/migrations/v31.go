@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+func addWebhook(ctx context.Context, x *xorm.Engine) error {
+	type Webhook struct {
+		ID         string    `xorm:"not null pk BIGINT(20) id"`
+		CreatedAt  time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		UpdatedAt  time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+		Name       string    `xorm:"not null default '' VARCHAR(128) name"`
+		URL        string    `xorm:"not null default '' VARCHAR(1024) url"`
+		Secret     string    `xorm:"not null default '' VARCHAR(128) secret"`
+		EventTypes string    `xorm:"not null TEXT event_types"`
+		Status     int       `xorm:"not null default 1 INT(11) status"`
+	}
+
+	type WebhookDelivery struct {
+		ID             int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+		CreatedAt      time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		WebhookID      string    `xorm:"not null INDEX BIGINT(20) webhook_id"`
+		DeliveryID     string    `xorm:"not null default '' INDEX VARCHAR(64) delivery_id"`
+		EventType      string    `xorm:"not null default '' VARCHAR(64) event_type"`
+		Attempt        int       `xorm:"not null default 1 INT(11) attempt"`
+		RequestBody    string    `xorm:"not null MEDIUMTEXT request_body"`
+		ResponseStatus int       `xorm:"not null default 0 INT(11) response_status"`
+		ResponseBody   string    `xorm:"TEXT response_body"`
+		Error          string    `xorm:"TEXT error"`
+		Success        bool      `xorm:"not null default false BOOL success"`
+		Duration       int64     `xorm:"not null default 0 BIGINT(20) duration"`
+	}
+
+	return x.Context(ctx).Sync(new(Webhook), new(WebhookDelivery))
+}
@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+
+	"xorm.io/xorm"
+)
+
+func addSearchIndex(ctx context.Context, x *xorm.Engine) error {
+	type SearchIndexDocument struct {
+		ObjectID     string `xorm:"not null pk BIGINT(20) object_id"`
+		ObjectType   string `xorm:"not null default '' VARCHAR(20) object_type"`
+		QuestionID   string `xorm:"not null default 0 INDEX BIGINT(20) question_id"`
+		UserID       string `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+		Answers      int64  `xorm:"not null default 0 INT(11) answers"`
+		Views        int64  `xorm:"not null default 0 INT(11) views"`
+		Score        int64  `xorm:"not null default 0 INT(11) score"`
+		HasAccepted  bool   `xorm:"not null default false BOOL has_accepted"`
+		Created      int64  `xorm:"not null default 0 BIGINT(20) 'created'"`
+		Active       int64  `xorm:"not null default 0 BIGINT(20) active"`
+		Length       int    `xorm:"not null default 0 INT(11) length"`
+		Status       int    `xorm:"not null default 1 INT(11) status"`
+		AnswerUsers  string `xorm:"not null TEXT answer_users"`
+		CommentUsers string `xorm:"not null TEXT comment_users"`
+	}
+	type SearchIndexTerm struct {
+		ID             int64  `xorm:"not null pk autoincr BIGINT(20) id"`
+		Term           string `xorm:"not null default '' INDEX(term_weight) VARCHAR(64) term"`
+		ObjectID       string `xorm:"not null INDEX BIGINT(20) object_id"`
+		Frequency      int    `xorm:"not null default 0 INT(11) frequency"`
+		TitleFrequency int    `xorm:"not null default 0 INT(11) title_frequency"`
+		Weight         int    `xorm:"not null default 0 INDEX(term_weight) INT(11) weight"`
+		DocLength      int    `xorm:"not null default 0 INT(11) doc_length"`
+		Positions      string `xorm:"not null TEXT positions"`
+	}
+	type SearchIndexTag struct {
+		ID       int64  `xorm:"not null pk autoincr BIGINT(20) id"`
+		ObjectID string `xorm:"not null INDEX BIGINT(20) object_id"`
+		TagID    string `xorm:"not null INDEX BIGINT(20) tag_id"`
+	}
+	return x.Context(ctx).Sync(new(SearchIndexDocument), new(SearchIndexTerm), new(SearchIndexTag))
+}
@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+func addUserDigestConfig(ctx context.Context, x *xorm.Engine) error {
+	type UserDigestConfig struct {
+		UserID     string    `xorm:"not null pk BIGINT(20) user_id"`
+		CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+		UpdatedAt  time.Time `xorm:"updated TIMESTAMP updated_at"`
+		Frequency  string    `xorm:"not null default 'daily' VARCHAR(20) frequency"`
+		Weekday    int       `xorm:"not null default 1 INT(11) weekday"`
+		Hour       int       `xorm:"not null default 8 INT(11) hour"`
+		Timezone   string    `xorm:"not null default '' VARCHAR(64) timezone"`
+		LastSentAt time.Time `xorm:"TIMESTAMP last_sent_at"`
+		NextSendAt time.Time `xorm:"INDEX TIMESTAMP next_send_at"`
+	}
+	return x.Context(ctx).Sync(new(UserDigestConfig))
+}
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+const emailReplySecretConfigID = 131
+
+func addEmailReplySecret(ctx context.Context, x *xorm.Engine) error {
+	exist, err := x.Context(ctx).Get(&entity.Config{Key: constant.EmailReplySecretKey})
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if exist {
+		return nil
+	}
+	_, err = x.Context(ctx).Insert(&entity.Config{
+		ID: emailReplySecretConfigID, Key: constant.EmailReplySecretKey, Value: newEmailReplySecret()})
+	if err != nil {
+		return fmt.Errorf("insert config failed: %w", err)
+	}
+	return nil
+}
+
+// newEmailReplySecret generates the secret to sign the reply addresses, every site has its own one
+func newEmailReplySecret() string {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return hex.EncodeToString(secret)
+}
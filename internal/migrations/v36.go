@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+func addPersonalAccessToken(ctx context.Context, x *xorm.Engine) error {
+	type PersonalAccessToken struct {
+		ID          string    `xorm:"not null pk BIGINT(20) id"`
+		CreatedAt   time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		UpdatedAt   time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+		UserID      string    `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+		Name        string    `xorm:"not null default '' VARCHAR(128) name"`
+		TokenHash   string    `xorm:"not null default '' UNIQUE VARCHAR(64) token_hash"`
+		TokenPrefix string    `xorm:"not null default '' VARCHAR(32) token_prefix"`
+		Scopes      string    `xorm:"not null default '' VARCHAR(255) scopes"`
+		ExpiredAt   time.Time `xorm:"TIMESTAMP expired_at"`
+		LastUsedAt  time.Time `xorm:"TIMESTAMP last_used_at"`
+		Status      int       `xorm:"not null default 1 INT(11) status"`
+	}
+	return x.Context(ctx).Sync(new(PersonalAccessToken))
+}
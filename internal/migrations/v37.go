@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+const oauthSigningKeyConfigID = 132
+
+func addOAuthProvider(ctx context.Context, x *xorm.Engine) error {
+	type OAuthClient struct {
+		ID           string    `xorm:"not null pk BIGINT(20) id"`
+		CreatedAt    time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		UpdatedAt    time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+		ClientID     string    `xorm:"not null default '' UNIQUE VARCHAR(64) client_id"`
+		SecretHash   string    `xorm:"not null default '' VARCHAR(64) secret_hash"`
+		Name         string    `xorm:"not null default '' VARCHAR(128) name"`
+		RedirectURIs string    `xorm:"not null TEXT redirect_uris"`
+		Scopes       string    `xorm:"not null default '' VARCHAR(255) scopes"`
+		Public       bool      `xorm:"not null default false BOOL public"`
+		Status       int       `xorm:"not null default 1 INT(11) status"`
+	}
+	type OAuthRefreshToken struct {
+		ID              string    `xorm:"not null pk BIGINT(20) id"`
+		CreatedAt       time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		ClientID        string    `xorm:"not null default '' INDEX VARCHAR(64) client_id"`
+		UserID          string    `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+		TokenHash       string    `xorm:"not null default '' UNIQUE VARCHAR(64) token_hash"`
+		Scope           string    `xorm:"not null default '' VARCHAR(255) scope"`
+		ExpiredAt       time.Time `xorm:"TIMESTAMP expired_at"`
+		Status          int       `xorm:"not null default 1 INT(11) status"`
+		GrantID         string    `xorm:"not null default 0 INDEX BIGINT(20) grant_id"`
+		AccessTokenHash string    `xorm:"not null default '' VARCHAR(64) access_token_hash"`
+	}
+	type OAuthAuthorizationCode struct {
+		ID                  string    `xorm:"not null pk BIGINT(20) id"`
+		CreatedAt           time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		CodeHash            string    `xorm:"not null default '' UNIQUE VARCHAR(64) code_hash"`
+		ClientID            string    `xorm:"not null default '' VARCHAR(64) client_id"`
+		UserID              string    `xorm:"not null default 0 BIGINT(20) user_id"`
+		RedirectURI         string    `xorm:"not null TEXT redirect_uri"`
+		Scope               string    `xorm:"not null default '' VARCHAR(255) scope"`
+		CodeChallenge       string    `xorm:"not null default '' VARCHAR(128) code_challenge"`
+		CodeChallengeMethod string    `xorm:"not null default '' VARCHAR(10) code_challenge_method"`
+		Nonce               string    `xorm:"not null default '' VARCHAR(255) nonce"`
+		AuthTime            int64     `xorm:"not null default 0 BIGINT(20) auth_time"`
+		ExpiredAt           time.Time `xorm:"INDEX TIMESTAMP expired_at"`
+		Status              int       `xorm:"not null default 1 INT(11) status"`
+		RedirectURISent     bool      `xorm:"not null default false BOOL redirect_uri_sent"`
+	}
+	type OAuthConsent struct {
+		ID        int       `xorm:"not null pk autoincr INT(11) id"`
+		CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		UpdatedAt time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+		UserID    string    `xorm:"not null default 0 UNIQUE(user_client) BIGINT(20) user_id"`
+		ClientID  string    `xorm:"not null default '' UNIQUE(user_client) VARCHAR(64) client_id"`
+		Scope     string    `xorm:"not null default '' VARCHAR(255) scope"`
+	}
+	err := x.Context(ctx).Sync(new(OAuthClient), new(OAuthRefreshToken), new(OAuthAuthorizationCode), new(OAuthConsent))
+	if err != nil {
+		return fmt.Errorf("sync table failed: %w", err)
+	}
+
+	exist, err := x.Context(ctx).Get(&entity.Config{Key: constant.OAuthSigningKeyConfigKey})
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if exist {
+		return nil
+	}
+	signingKey, err := newOAuthSigningKey()
+	if err != nil {
+		return err
+	}
+	_, err = x.Context(ctx).Insert(&entity.Config{
+		ID: oauthSigningKeyConfigID, Key: constant.OAuthSigningKeyConfigKey, Value: signingKey})
+	if err != nil {
+		return fmt.Errorf("insert config failed: %w", err)
+	}
+	return nil
+}
+
+// newOAuthSigningKey generates the RSA key to sign the id tokens, every site has its own one
+func newOAuthSigningKey() (string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", fmt.Errorf("generate oauth signing key failed: %w", err)
+	}
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	return string(pem.EncodeToMemory(block)), nil
+}
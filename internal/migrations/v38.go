@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+func addDraft(ctx context.Context, x *xorm.Engine) error {
+	type Draft struct {
+		ID             string    `xorm:"not null pk BIGINT(20) id"`
+		CreatedAt      time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		UpdatedAt      time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+		UserID         string    `xorm:"not null default 0 UNIQUE(user_draft) BIGINT(20) user_id"`
+		DraftType      string    `xorm:"not null default '' UNIQUE(user_draft) VARCHAR(32) draft_type"`
+		ObjectID       string    `xorm:"not null default 0 UNIQUE(user_draft) BIGINT(20) object_id"`
+		Title          string    `xorm:"not null default '' VARCHAR(150) title"`
+		Content        string    `xorm:"not null MEDIUMTEXT content"`
+		Tags           string    `xorm:"not null TEXT tags"`
+		BaseRevisionID string    `xorm:"not null default 0 BIGINT(20) base_revision_id"`
+		ExpiredAt      time.Time `xorm:"INDEX TIMESTAMP expired_at"`
+	}
+	return x.Context(ctx).Sync(new(Draft))
+}
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+func addModeration(ctx context.Context, x *xorm.Engine) error {
+	type ModerationClaim struct {
+		ID        int       `xorm:"not null pk autoincr INT(11) id"`
+		CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		UpdatedAt time.Time `xorm:"updated not null default CURRENT_TIMESTAMP TIMESTAMP updated_at"`
+		ItemType  string    `xorm:"not null default '' UNIQUE(moderation_item) VARCHAR(32) item_type"`
+		ItemID    string    `xorm:"not null default '' UNIQUE(moderation_item) VARCHAR(64) item_id"`
+		UserID    string    `xorm:"not null default 0 BIGINT(20) user_id"`
+		ExpiredAt time.Time `xorm:"not null INDEX TIMESTAMP expired_at"`
+	}
+	type ModerationLog struct {
+		ID             int       `xorm:"not null pk autoincr INT(11) id"`
+		CreatedAt      time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+		UserID         string    `xorm:"not null default 0 INDEX BIGINT(20) user_id"`
+		ItemType       string    `xorm:"not null default '' VARCHAR(32) item_type"`
+		ItemID         string    `xorm:"not null default '' VARCHAR(64) item_id"`
+		ObjectID       string    `xorm:"not null default 0 INDEX BIGINT(20) object_id"`
+		Action         string    `xorm:"not null default '' VARCHAR(32) action"`
+		BeforeSnapshot string    `xorm:"not null TEXT before_snapshot"`
+		AfterSnapshot  string    `xorm:"not null TEXT after_snapshot"`
+		Reason         string    `xorm:"not null default '' VARCHAR(500) reason"`
+	}
+	err := x.Context(ctx).Sync(new(ModerationClaim), new(ModerationLog))
+	if err != nil {
+		return fmt.Errorf("sync table failed: %w", err)
+	}
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/queue_message"
 	"github.com/apache/answer/internal/service/activity_common"
 	"github.com/apache/answer/internal/service/activity_type"
 	"github.com/apache/answer/pkg/obj"
@@ -137,7 +138,15 @@ func (ar *ActivityRepo) GetUserIDObjectIDActivitySum(ctx context.Context, userID
 
 // AddActivity add activity
 func (ar *ActivityRepo) AddActivity(ctx context.Context, activity *entity.Activity) (err error) {
-	_, err = ar.data.DB.Context(ctx).Insert(activity)
+	// the activity is queued, a retry must not count the reputation of the activity twice
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (any, error) {
+		session = session.Context(ctx)
+		added, err := queue_message.AddReceipt(ctx, session, "activity")
+		if err != nil || !added {
+			return nil, err
+		}
+		return session.Insert(activity)
+	})
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
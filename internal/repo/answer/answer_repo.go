@@ -33,19 +33,20 @@ import (
 	"github.com/apache/answer/internal/service/activity_common"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
 	"github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/internal/service/search_sync"
 	"github.com/apache/answer/internal/service/unique"
 	"github.com/apache/answer/pkg/uid"
-	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 )
 
 // answerRepo answer repository
 type answerRepo struct {
-	data         *data.Data
-	uniqueIDRepo unique.UniqueIDRepo
-	userRankRepo rank.UserRankRepo
-	activityRepo activity_common.ActivityRepo
+	data             *data.Data
+	uniqueIDRepo     unique.UniqueIDRepo
+	userRankRepo     rank.UserRankRepo
+	activityRepo     activity_common.ActivityRepo
+	searchChangeRepo search_sync.SearchChangeRepo
 }
 
 // NewAnswerRepo new repository
@@ -54,12 +55,14 @@ func NewAnswerRepo(
 	uniqueIDRepo unique.UniqueIDRepo,
 	userRankRepo rank.UserRankRepo,
 	activityRepo activity_common.ActivityRepo,
+	searchChangeRepo search_sync.SearchChangeRepo,
 ) answercommon.AnswerRepo {
 	return &answerRepo{
-		data:         data,
-		uniqueIDRepo: uniqueIDRepo,
-		userRankRepo: userRankRepo,
-		activityRepo: activityRepo,
+		data:             data,
+		uniqueIDRepo:     uniqueIDRepo,
+		userRankRepo:     userRankRepo,
+		activityRepo:     activityRepo,
+		searchChangeRepo: searchChangeRepo,
 	}
 }
 
@@ -459,73 +462,9 @@ func (ar *answerRepo) SumVotesByQuestionID(ctx context.Context, questionID strin
 	return count, nil
 }
 
-// updateSearch update search, if search plugin not enable, do nothing
+// updateSearch records the answer is changed, the search plugins sync it in background
 func (ar *answerRepo) updateSearch(ctx context.Context, answerID string) (err error) {
-	answerID = uid.DeShortID(answerID)
-	// check search plugin
-	var (
-		s plugin.Search
-	)
-	_ = plugin.CallSearch(func(search plugin.Search) error {
-		s = search
-		return nil
-	})
-	if s == nil {
-		return
-	}
-	answer, exist, err := ar.GetAnswer(ctx, answerID)
-	if !exist {
-		return
-	}
-	if err != nil {
-		return err
-	}
-
-	// get question
-	var (
-		question = new(entity.Question)
-	)
-	exist, err = ar.data.DB.Context(ctx).Where("id = ?", answer.QuestionID).Get(&question)
-	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
-	}
-	if !exist {
-		return
-	}
-
-	// get tags
-	var (
-		tagListList = make([]*entity.TagRel, 0)
-		tags        = make([]string, 0)
-	)
-	st := ar.data.DB.Context(ctx).Where("object_id = ?", uid.DeShortID(question.ID))
-	st.Where("status = ?", entity.TagRelStatusAvailable)
-	err = st.Find(&tagListList)
-	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
-	}
-	for _, tag := range tagListList {
-		tags = append(tags, tag.TagID)
-	}
-
-	content := &plugin.SearchContent{
-		ObjectID:    answerID,
-		Title:       question.Title,
-		Type:        constant.AnswerObjectType,
-		Content:     answer.OriginalText,
-		Answers:     0,
-		Status:      plugin.SearchContentStatus(answer.Status),
-		Tags:        tags,
-		QuestionID:  answer.QuestionID,
-		UserID:      answer.UserID,
-		Views:       int64(question.ViewCount),
-		Created:     answer.CreatedAt.Unix(),
-		Active:      answer.UpdatedAt.Unix(),
-		Score:       int64(answer.VoteCount),
-		HasAccepted: answer.Accepted == schema.AnswerAcceptedEnable,
-	}
-	err = s.UpdateContent(ctx, content)
-	return
+	return ar.searchChangeRepo.AddChanges(ctx, answerID)
 }
 
 func (ar *answerRepo) DeletePermanentlyAnswers(ctx context.Context) error {
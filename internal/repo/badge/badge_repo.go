@@ -53,6 +53,29 @@ func (r *badgeRepo) GetByID(ctx context.Context, id string) (badge *entity.Badge
 	return
 }
 
+// Add adds a badge defined by the admin
+func (r *badgeRepo) Add(ctx context.Context, badge *entity.Badge) (err error) {
+	badge.ID, err = r.uniqueIDRepo.GenUniqueIDStr(ctx, (&entity.Badge{}).TableName())
+	if err != nil {
+		return err
+	}
+	_, err = r.data.DB.Context(ctx).Insert(badge)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// Update updates the badge info and its rule
+func (r *badgeRepo) Update(ctx context.Context, badge *entity.Badge) (err error) {
+	_, err = r.data.DB.Context(ctx).ID(badge.ID).
+		Cols("name", "description", "icon", "level", "single", "badge_group_id", "handler", "param").Update(badge)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 func (r *badgeRepo) GetByIDs(ctx context.Context, ids []string) (badges []*entity.Badge, err error) {
 	badges = make([]*entity.Badge, 0)
 	err = r.data.DB.Context(ctx).In("id", ids).Find(&badges)
@@ -98,6 +121,26 @@ func (r *badgeRepo) ListActivated(ctx context.Context, page int, pageSize int) (
 	return
 }
 
+// ListActivatedByHandler returns the activated badges awarded by the handler
+func (r *badgeRepo) ListActivatedByHandler(ctx context.Context, handler string) (badges []*entity.Badge, err error) {
+	badges = make([]*entity.Badge, 0)
+	err = r.data.DB.Context(ctx).Where("status = ?", entity.BadgeStatusActive).And("handler = ?", handler).Find(&badges)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountByGroupID counts the badges in the group which are not deleted
+func (r *badgeRepo) CountByGroupID(ctx context.Context, groupID int64) (count int64, err error) {
+	count, err = r.data.DB.Context(ctx).Where("badge_group_id = ?", groupID).
+		And("status <> ?", entity.BadgeStatusDeleted).Count(&entity.Badge{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // ListInactivated returns a list of inactivated badges
 func (r *badgeRepo) ListInactivated(ctx context.Context, page int, pageSize int) (badges []*entity.Badge, total int64, err error) {
 	badges = make([]*entity.Badge, 0)
@@ -0,0 +1,300 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package badge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/badge"
+	"github.com/apache/answer/pkg/obj"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// badgeRuleRepo counts the metrics of the badge rules
+type badgeRuleRepo struct {
+	data *data.Data
+}
+
+// NewBadgeRuleRepo creates a new badge rule repository
+func NewBadgeRuleRepo(data *data.Data) badge.BadgeRuleRepo {
+	return &badgeRuleRepo{
+		data: data,
+	}
+}
+
+type badgeRuleUserAmount struct {
+	UserID string `xorm:"user_id"`
+	Amount int64  `xorm:"amount"`
+}
+
+// GetRuleMetric get the metric value of the user, objectID is the post the event happened on
+func (br *badgeRuleRepo) GetRuleMetric(ctx context.Context, rule *schema.BadgeRule, userID, objectID string) (
+	value int64, err error) {
+	switch rule.Metric {
+	case schema.BadgeRuleMetricQuestionCount, schema.BadgeRuleMetricAnswerCount,
+		schema.BadgeRuleMetricAcceptedAnswerCount, schema.BadgeRuleMetricCommentCount:
+		value, err = br.postSession(ctx, rule).And(br.postTable(rule)+".user_id = ?", userID).Count()
+	case schema.BadgeRuleMetricReceivedVoteCount:
+		var questionVotes, answerVotes int64
+		questionVotes, err = br.data.DB.Context(ctx).Where("user_id = ?", userID).
+			In("status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed).
+			SumInt(&entity.Question{}, "vote_count")
+		if err == nil {
+			answerVotes, err = br.data.DB.Context(ctx).Where("user_id = ?", userID).
+				And("status = ?", entity.AnswerStatusAvailable).SumInt(&entity.Answer{}, "vote_count")
+		}
+		value = questionVotes + answerVotes
+	case schema.BadgeRuleMetricPostVoteCount:
+		value, err = br.getPostVoteCount(ctx, objectID)
+	case schema.BadgeRuleMetricPostStreakDays:
+		value, err = br.getPostStreakDays(ctx, userID, rule.Threshold)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return value, err
+}
+
+// GetRuleCandidates get the users reaching the threshold of the rule, with the posts for the per post metrics
+func (br *badgeRuleRepo) GetRuleCandidates(ctx context.Context, rule *schema.BadgeRule) (
+	awards []*entity.BadgeAward, err error) {
+	awards = make([]*entity.BadgeAward, 0)
+	switch rule.Metric {
+	case schema.BadgeRuleMetricQuestionCount, schema.BadgeRuleMetricAnswerCount,
+		schema.BadgeRuleMetricAcceptedAnswerCount, schema.BadgeRuleMetricCommentCount:
+		amounts := make([]*badgeRuleUserAmount, 0)
+		table := br.postTable(rule)
+		err = br.postSession(ctx, rule).Select(table + ".user_id AS user_id, COUNT(*) AS amount").
+			GroupBy(table + ".user_id").Having(fmt.Sprintf("COUNT(*) >= %d", rule.Threshold)).Find(&amounts)
+		for _, amount := range amounts {
+			awards = append(awards, &entity.BadgeAward{UserID: amount.UserID, AwardKey: entity.BadgeEmptyAwardKey})
+		}
+	case schema.BadgeRuleMetricReceivedVoteCount:
+		awards, err = br.getReceivedVoteCandidates(ctx, rule.Threshold)
+	case schema.BadgeRuleMetricPostVoteCount:
+		awards, err = br.getPostVoteCandidates(ctx, rule.Threshold)
+	case schema.BadgeRuleMetricPostStreakDays:
+		awards, err = br.getPostStreakCandidates(ctx, rule.Threshold)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return awards, err
+}
+
+func (br *badgeRuleRepo) postTable(rule *schema.BadgeRule) string {
+	switch rule.Metric {
+	case schema.BadgeRuleMetricQuestionCount:
+		return entity.Question{}.TableName()
+	case schema.BadgeRuleMetricCommentCount:
+		return (&entity.Comment{}).TableName()
+	default:
+		return entity.Answer{}.TableName()
+	}
+}
+
+// postSession the available posts counted by the rule, limited to the questions with the tag if it is given
+func (br *badgeRuleRepo) postSession(ctx context.Context, rule *schema.BadgeRule) *xorm.Session {
+	table := br.postTable(rule)
+	session := br.data.DB.Context(ctx).Table(table)
+	questionIDField := table + ".question_id"
+	switch rule.Metric {
+	case schema.BadgeRuleMetricQuestionCount:
+		questionIDField = table + ".id"
+		session.In(table+".status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed)
+	case schema.BadgeRuleMetricAnswerCount:
+		session.Where(table+".status = ?", entity.AnswerStatusAvailable)
+	case schema.BadgeRuleMetricAcceptedAnswerCount:
+		session.Where(table+".status = ?", entity.AnswerStatusAvailable).
+			And(table+".adopted = ?", schema.AnswerAcceptedEnable)
+	case schema.BadgeRuleMetricCommentCount:
+		session.Where(table+".status = ?", entity.CommentStatusAvailable)
+	}
+	if len(rule.TagSlugName) > 0 && rule.TagScoped() {
+		session.Join("INNER", "tag_rel", "tag_rel.object_id = "+questionIDField+" AND tag_rel.status = ?",
+			entity.TagRelStatusAvailable).
+			Join("INNER", "tag", "tag.id = tag_rel.tag_id").
+			And("tag.slug_name = ?", rule.TagSlugName)
+	}
+	return session
+}
+
+func (br *badgeRuleRepo) getPostVoteCount(ctx context.Context, objectID string) (value int64, err error) {
+	objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
+	if err != nil {
+		return 0, nil
+	}
+	switch objectType {
+	case constant.QuestionObjectType:
+		question := &entity.Question{}
+		_, err = br.data.DB.Context(ctx).ID(objectID).Cols("vote_count").Get(question)
+		return int64(question.VoteCount), err
+	case constant.AnswerObjectType:
+		answer := &entity.Answer{}
+		_, err = br.data.DB.Context(ctx).ID(objectID).Cols("vote_count").Get(answer)
+		return int64(answer.VoteCount), err
+	}
+	return 0, nil
+}
+
+func (br *badgeRuleRepo) getReceivedVoteCandidates(ctx context.Context, threshold int64) (
+	awards []*entity.BadgeAward, err error) {
+	questionVotes := make([]*badgeRuleUserAmount, 0)
+	err = br.data.DB.Context(ctx).Table(entity.Question{}.TableName()).
+		Select("user_id, SUM(vote_count) AS amount").
+		In("status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed).
+		GroupBy("user_id").Find(&questionVotes)
+	if err != nil {
+		return nil, err
+	}
+	answerVotes := make([]*badgeRuleUserAmount, 0)
+	err = br.data.DB.Context(ctx).Table(entity.Answer{}.TableName()).
+		Select("user_id, SUM(vote_count) AS amount").
+		Where("status = ?", entity.AnswerStatusAvailable).
+		GroupBy("user_id").Find(&answerVotes)
+	if err != nil {
+		return nil, err
+	}
+
+	votes := make(map[string]int64)
+	for _, amount := range append(questionVotes, answerVotes...) {
+		votes[amount.UserID] += amount.Amount
+	}
+	awards = make([]*entity.BadgeAward, 0)
+	for userID, amount := range votes {
+		if amount >= threshold {
+			awards = append(awards, &entity.BadgeAward{UserID: userID, AwardKey: entity.BadgeEmptyAwardKey})
+		}
+	}
+	return awards, nil
+}
+
+func (br *badgeRuleRepo) getPostVoteCandidates(ctx context.Context, threshold int64) (
+	awards []*entity.BadgeAward, err error) {
+	questions := make([]*entity.Question, 0)
+	err = br.data.DB.Context(ctx).Cols("id", "user_id").Where("vote_count >= ?", threshold).
+		In("status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed).Find(&questions)
+	if err != nil {
+		return nil, err
+	}
+	answers := make([]*entity.Answer, 0)
+	err = br.data.DB.Context(ctx).Cols("id", "user_id").Where("vote_count >= ?", threshold).
+		And("status = ?", entity.AnswerStatusAvailable).Find(&answers)
+	if err != nil {
+		return nil, err
+	}
+
+	awards = make([]*entity.BadgeAward, 0, len(questions)+len(answers))
+	for _, question := range questions {
+		awards = append(awards, &entity.BadgeAward{UserID: question.UserID, AwardKey: question.ID})
+	}
+	for _, answer := range answers {
+		awards = append(awards, &entity.BadgeAward{UserID: answer.UserID, AwardKey: answer.ID})
+	}
+	return awards, nil
+}
+
+// getPostStreakDays count the consecutive days up to the latest posting day, today or yesterday,
+// with questions or answers, at most days
+func (br *badgeRuleRepo) getPostStreakDays(ctx context.Context, userID string, days int64) (streak int64, err error) {
+	since := streakSince(days)
+	questions := make([]*entity.Question, 0)
+	err = br.data.DB.Context(ctx).Cols("created_at").Where("user_id = ?", userID).
+		And("created_at >= ?", since).In("status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed).
+		Find(&questions)
+	if err != nil {
+		return 0, err
+	}
+	answers := make([]*entity.Answer, 0)
+	err = br.data.DB.Context(ctx).Cols("created_at").Where("user_id = ?", userID).
+		And("created_at >= ?", since).And("status = ?", entity.AnswerStatusAvailable).
+		Find(&answers)
+	if err != nil {
+		return 0, err
+	}
+
+	postDays := make(map[string]bool)
+	for _, question := range questions {
+		postDays[question.CreatedAt.Local().Format(time.DateOnly)] = true
+	}
+	for _, answer := range answers {
+		postDays[answer.CreatedAt.Local().Format(time.DateOnly)] = true
+	}
+	// the streak is not broken until today is over, so it counts back from yesterday if there is no post today
+	day := time.Now()
+	if !postDays[day.Format(time.DateOnly)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for streak < days && postDays[day.Format(time.DateOnly)] {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak, nil
+}
+
+func (br *badgeRuleRepo) getPostStreakCandidates(ctx context.Context, days int64) (
+	awards []*entity.BadgeAward, err error) {
+	since := streakSince(days)
+	userIDs := make([]string, 0)
+	err = br.data.DB.Context(ctx).Table(entity.Question{}.TableName()).Distinct("user_id").
+		Where("created_at >= ?", since).Find(&userIDs)
+	if err != nil {
+		return nil, err
+	}
+	answerUserIDs := make([]string, 0)
+	err = br.data.DB.Context(ctx).Table(entity.Answer{}.TableName()).Distinct("user_id").
+		Where("created_at >= ?", since).Find(&answerUserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	checked := make(map[string]bool)
+	awards = make([]*entity.BadgeAward, 0)
+	for _, userID := range append(userIDs, answerUserIDs...) {
+		if checked[userID] {
+			continue
+		}
+		checked[userID] = true
+		streak, err := br.getPostStreakDays(ctx, userID, days)
+		if err != nil {
+			return nil, err
+		}
+		if streak >= days {
+			awards = append(awards, &entity.BadgeAward{UserID: userID, AwardKey: entity.BadgeEmptyAwardKey})
+		}
+	}
+	return awards, nil
+}
+
+// streakSince the beginning of the first day of a streak of the days ending today
+func streakSince(days int64) time.Time {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	// one more day for the streak which ends yesterday
+	return today.AddDate(0, 0, -int(days))
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/queue_message"
 	"github.com/apache/answer/internal/service/badge"
 	"github.com/apache/answer/internal/service/unique"
 	"github.com/segmentfault/pacman/errors"
@@ -78,6 +79,14 @@ func (r *badgeAwardRepo) AwardBadgeForUser(ctx context.Context, badgeAward *enti
 		if exist {
 			return nil, fmt.Errorf("badge already awarded")
 		}
+		added, err := queue_message.AddReceipt(ctx, session,
+			fmt.Sprintf("badge:%s:%s:%s", badgeAward.BadgeID, badgeAward.UserID, badgeAward.AwardKey))
+		if err != nil {
+			return nil, err
+		}
+		if !added {
+			return nil, fmt.Errorf("badge already awarded")
+		}
 
 		_, err = session.Insert(badgeAward)
 		if err != nil {
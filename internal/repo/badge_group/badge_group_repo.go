@@ -22,9 +22,11 @@ package badge_group
 import (
 	"context"
 	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/service/badge"
 	"github.com/apache/answer/internal/service/unique"
+	"github.com/segmentfault/pacman/errors"
 )
 
 type badgeGroupRepo struct {
@@ -46,5 +48,38 @@ func (r *badgeGroupRepo) ListGroups(ctx context.Context) (groups []*entity.Badge
 }
 
 func (r *badgeGroupRepo) AddGroup(ctx context.Context, group *entity.BadgeGroup) (err error) {
+	group.ID, err = r.uniqueIDRepo.GenUniqueIDStr(ctx, entity.BadgeGroup{}.TableName())
+	if err != nil {
+		return err
+	}
+	_, err = r.data.DB.Context(ctx).Insert(group)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func (r *badgeGroupRepo) GetGroup(ctx context.Context, id string) (group *entity.BadgeGroup, exist bool, err error) {
+	group = &entity.BadgeGroup{}
+	exist, err = r.data.DB.Context(ctx).ID(id).Get(group)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func (r *badgeGroupRepo) UpdateGroup(ctx context.Context, group *entity.BadgeGroup) (err error) {
+	_, err = r.data.DB.Context(ctx).ID(group.ID).Cols("name").Update(group)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func (r *badgeGroupRepo) DeleteGroup(ctx context.Context, id string) (err error) {
+	_, err = r.data.DB.Context(ctx).ID(id).Delete(&entity.BadgeGroup{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
 	return
 }
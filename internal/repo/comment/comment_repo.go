@@ -30,29 +30,35 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/service/comment"
 	"github.com/apache/answer/internal/service/comment_common"
+	"github.com/apache/answer/internal/service/search_sync"
 	"github.com/apache/answer/internal/service/unique"
 	"github.com/segmentfault/pacman/errors"
 )
 
 // commentRepo comment repository
 type commentRepo struct {
-	data         *data.Data
-	uniqueIDRepo unique.UniqueIDRepo
+	data             *data.Data
+	uniqueIDRepo     unique.UniqueIDRepo
+	searchChangeRepo search_sync.SearchChangeRepo
 }
 
 // NewCommentRepo new repository
-func NewCommentRepo(data *data.Data, uniqueIDRepo unique.UniqueIDRepo) comment.CommentRepo {
+func NewCommentRepo(data *data.Data, uniqueIDRepo unique.UniqueIDRepo,
+	searchChangeRepo search_sync.SearchChangeRepo) comment.CommentRepo {
 	return &commentRepo{
-		data:         data,
-		uniqueIDRepo: uniqueIDRepo,
+		data:             data,
+		uniqueIDRepo:     uniqueIDRepo,
+		searchChangeRepo: searchChangeRepo,
 	}
 }
 
 // NewCommentCommonRepo new repository
-func NewCommentCommonRepo(data *data.Data, uniqueIDRepo unique.UniqueIDRepo) comment_common.CommentCommonRepo {
+func NewCommentCommonRepo(data *data.Data, uniqueIDRepo unique.UniqueIDRepo,
+	searchChangeRepo search_sync.SearchChangeRepo) comment_common.CommentCommonRepo {
 	return &commentRepo{
-		data:         data,
-		uniqueIDRepo: uniqueIDRepo,
+		data:             data,
+		uniqueIDRepo:     uniqueIDRepo,
+		searchChangeRepo: searchChangeRepo,
 	}
 }
 
@@ -64,8 +70,9 @@ func (cr *commentRepo) AddComment(ctx context.Context, comment *entity.Comment)
 	}
 	_, err = cr.data.DB.Context(ctx).Insert(comment)
 	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = cr.searchChangeRepo.AddChanges(ctx, comment.ObjectID)
 	return
 }
 
@@ -74,8 +81,9 @@ func (cr *commentRepo) RemoveComment(ctx context.Context, commentID string) (err
 	session := cr.data.DB.Context(ctx).ID(commentID)
 	_, err = session.Update(&entity.Comment{Status: entity.CommentStatusDeleted})
 	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	cr.updateSearch(ctx, commentID)
 	return
 }
 
@@ -87,11 +95,21 @@ func (cr *commentRepo) UpdateCommentContent(
 		ParsedText:   parsedText,
 	})
 	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	cr.updateSearch(ctx, commentID)
 	return
 }
 
+// updateSearch records the object the comment belongs to is changed
+func (cr *commentRepo) updateSearch(ctx context.Context, commentID string) {
+	comment, exist, err := cr.GetCommentWithoutStatus(ctx, commentID)
+	if err != nil || !exist {
+		return
+	}
+	_ = cr.searchChangeRepo.AddChanges(ctx, comment.ObjectID)
+}
+
 // GetComment get comment one
 func (cr *commentRepo) GetComment(ctx context.Context, commentID string) (
 	comment *entity.Comment, exist bool, err error) {
@@ -143,6 +161,13 @@ func (cr *commentRepo) GetCommentPage(ctx context.Context, commentQuery *comment
 
 // RemoveAllUserComment remove all user comment
 func (cr *commentRepo) RemoveAllUserComment(ctx context.Context, userID string) (err error) {
+	objectIDs := make([]string, 0)
+	err = cr.data.DB.Context(ctx).Table((&entity.Comment{}).TableName()).Distinct("object_id").
+		Where("user_id = ?", userID).Where("status != ?", entity.CommentStatusDeleted).Find(&objectIDs)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
 	session := cr.data.DB.Context(ctx).Where("user_id = ?", userID)
 	session.Where("status != ?", entity.CommentStatusDeleted)
 	affected, err := session.Update(&entity.Comment{Status: entity.CommentStatusDeleted})
@@ -150,5 +175,6 @@ func (cr *commentRepo) RemoveAllUserComment(ctx context.Context, userID string)
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	log.Infof("delete user comment, userID: %s, affected: %d", userID, affected)
+	_ = cr.searchChangeRepo.AddChanges(ctx, objectIDs...)
 	return
 }
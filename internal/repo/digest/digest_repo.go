@@ -0,0 +1,160 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package digest
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/digest"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// digestRepo digest repository
+type digestRepo struct {
+	data *data.Data
+}
+
+// NewDigestRepo new repository
+func NewDigestRepo(data *data.Data) digest.DigestRepo {
+	return &digestRepo{
+		data: data,
+	}
+}
+
+// GetConfig get the digest config of the user
+func (dr *digestRepo) GetConfig(ctx context.Context, userID string) (
+	conf *entity.UserDigestConfig, exist bool, err error) {
+	conf = &entity.UserDigestConfig{}
+	exist, err = dr.data.DB.Context(ctx).Where("user_id = ?", userID).Get(conf)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveConfig save the schedule of the digest, if existed, update, if not exist, insert
+func (dr *digestRepo) SaveConfig(ctx context.Context, conf *entity.UserDigestConfig) (err error) {
+	exist, err := dr.data.DB.Context(ctx).Where("user_id = ?", conf.UserID).Exist(&entity.UserDigestConfig{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		_, err = dr.data.DB.Context(ctx).Where("user_id = ?", conf.UserID).
+			Cols("frequency", "weekday", "hour", "timezone", "next_send_at").Update(conf)
+	} else {
+		_, err = dr.data.DB.Context(ctx).Insert(conf)
+	}
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetDueConfigs get the configs of the digests which are due and turned on
+func (dr *digestRepo) GetDueConfigs(ctx context.Context, now time.Time, limit int) (
+	configs []*entity.UserDigestConfig, err error) {
+	configs = make([]*entity.UserDigestConfig, 0)
+	err = dr.data.DB.Context(ctx).Where("next_send_at <= ?", now).
+		And(builder.In("user_id", builder.Select("user_id").From(entity.UserNotificationConfig{}.TableName()).
+			Where(builder.Eq{"source": string(constant.DigestSource), "enabled": true}))).
+		Asc("next_send_at").Limit(limit).Find(&configs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateSendTime update the time of the last digest and the next one
+func (dr *digestRepo) UpdateSendTime(ctx context.Context, userID string, lastSentAt, nextSendAt time.Time) (err error) {
+	_, err = dr.data.DB.Context(ctx).Where("user_id = ?", userID).Cols("last_sent_at", "next_send_at").
+		Update(&entity.UserDigestConfig{LastSentAt: lastSentAt, NextSendAt: nextSendAt})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUnreadNotifications get the latest unread inbox notifications since the time
+func (dr *digestRepo) GetUnreadNotifications(ctx context.Context, userID string, since time.Time, limit int) (
+	notifications []*entity.Notification, err error) {
+	notifications = make([]*entity.Notification, 0)
+	err = dr.data.DB.Context(ctx).Where("user_id = ?", userID).
+		And("type = ?", schema.NotificationTypeInbox).
+		And("is_read = ?", schema.NotificationNotRead).
+		And("status = ?", schema.NotificationStatusNormal).
+		And("updated_at > ?", since).
+		Desc("updated_at").Limit(limit).Find(&notifications)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionsByTags get the top questions with any of the tags created since the time
+func (dr *digestRepo) GetQuestionsByTags(ctx context.Context, tagIDs []string, since time.Time,
+	excludedUserID string, unanswered bool, limit int) (questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	session := dr.data.DB.Context(ctx).
+		In("id", builder.Select("object_id").From(entity.TagRel{}.TableName()).
+			Where(builder.In("tag_id", tagIDs)).And(builder.Eq{"status": entity.TagRelStatusAvailable})).
+		And("status = ?", entity.QuestionStatusAvailable).
+		And("`show` = ?", entity.QuestionShow).
+		And("created_at > ?", since).
+		And("user_id <> ?", excludedUserID)
+	if unanswered {
+		session.And("answer_count = 0").Desc("created_at")
+	} else {
+		session.Desc("vote_count", "answer_count", "created_at")
+	}
+	err = session.Limit(limit).Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnsweredTagIDs get the tags of the questions the user answered most
+func (dr *digestRepo) GetAnsweredTagIDs(ctx context.Context, userID string, limit int) (tagIDs []string, err error) {
+	rows := make([]struct {
+		TagID string `xorm:"tag_id"`
+		Total int64  `xorm:"total"`
+	}, 0)
+	err = dr.data.DB.Context(ctx).Table(entity.TagRel{}.TableName()).Alias("tr").
+		Select("tr.tag_id, COUNT(*) AS total").
+		Join("INNER", []string{entity.Answer{}.TableName(), "a"}, "a.question_id = tr.object_id").
+		Where("a.user_id = ?", userID).
+		And("a.status = ?", entity.AnswerStatusAvailable).
+		And("tr.status = ?", entity.TagRelStatusAvailable).
+		GroupBy("tr.tag_id").OrderBy("total DESC").Limit(limit).Find(&rows)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, row := range rows {
+		tagIDs = append(tagIDs, row.TagID)
+	}
+	return tagIDs, nil
+}
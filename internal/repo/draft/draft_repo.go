@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package draft
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/draft"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// draftRepo draft repository
+type draftRepo struct {
+	data *data.Data
+}
+
+// NewDraftRepo new repository
+func NewDraftRepo(data *data.Data) draft.DraftRepo {
+	return &draftRepo{
+		data: data,
+	}
+}
+
+// AddDraft add draft
+func (dr *draftRepo) AddDraft(ctx context.Context, draft *entity.Draft) (err error) {
+	_, err = dr.data.DB.Context(ctx).Insert(draft)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateDraft update the content of the draft
+func (dr *draftRepo) UpdateDraft(ctx context.Context, draft *entity.Draft) (err error) {
+	_, err = dr.data.DB.Context(ctx).ID(draft.ID).
+		Cols("title", "content", "tags", "base_revision_id", "expired_at").Update(draft)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDraft get the draft of the user for the object
+func (dr *draftRepo) GetDraft(ctx context.Context, userID, draftType, objectID string) (
+	draft *entity.Draft, exist bool, err error) {
+	draft = &entity.Draft{}
+	exist, err = dr.data.DB.Context(ctx).Where("user_id = ?", userID).
+		And("draft_type = ?", draftType).And("object_id = ?", objectID).Get(draft)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDraftPage get the drafts of the user, the latest updated first
+func (dr *draftRepo) GetDraftPage(ctx context.Context, page, pageSize int, userID string) (
+	drafts []*entity.Draft, total int64, err error) {
+	drafts = make([]*entity.Draft, 0)
+	session := dr.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("updated_at")
+	total, err = pager.Help(page, pageSize, &drafts, &entity.Draft{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountUserDrafts count the drafts of the user
+func (dr *draftRepo) CountUserDrafts(ctx context.Context, userID string) (count int64, err error) {
+	count, err = dr.data.DB.Context(ctx).Where("user_id = ?", userID).Count(&entity.Draft{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveDraft remove the draft of the user for the object
+func (dr *draftRepo) RemoveDraft(ctx context.Context, userID, draftType, objectID string) (err error) {
+	_, err = dr.data.DB.Context(ctx).Where("user_id = ?", userID).
+		And("draft_type = ?", draftType).And("object_id = ?", objectID).Delete(&entity.Draft{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveExpiredDrafts remove the drafts which are expired before the time
+func (dr *draftRepo) RemoveExpiredDrafts(ctx context.Context, before time.Time) (count int64, err error) {
+	count, err = dr.data.DB.Context(ctx).Where("expired_at < ?", before).Delete(&entity.Draft{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -23,24 +23,27 @@ import (
 	"context"
 
 	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
+	hierarchicaltag "github.com/apache/answer/internal/service/hierarchical_tag"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
 )
 
-// HierarchicalTagRepo hierarchical tag repository
-type HierarchicalTagRepo struct {
+// hierarchicalTagRepo hierarchical tag repository
+type hierarchicalTagRepo struct {
 	data *data.Data
 }
 
 // NewHierarchicalTagRepo new repository
-func NewHierarchicalTagRepo(data *data.Data) *HierarchicalTagRepo {
-	return &HierarchicalTagRepo{
+func NewHierarchicalTagRepo(data *data.Data) hierarchicaltag.HierarchicalTagRepo {
+	return &hierarchicalTagRepo{
 		data: data,
 	}
 }
 
 // GetByParentID gets hierarchical tags by parent ID
-func (hr *HierarchicalTagRepo) GetByParentID(ctx context.Context, parentID string) (tags []*entity.HierarchicalTag, err error) {
+func (hr *hierarchicalTagRepo) GetByParentID(ctx context.Context, parentID string) (tags []*entity.HierarchicalTag, err error) {
 	session := hr.data.DB.Context(ctx).Where("status = ?", entity.HierarchicalTagStatusAvailable)
 	if parentID == "" || parentID == "0" {
 		session = session.Where("parent_id IS NULL OR parent_id = ''")
@@ -55,8 +58,34 @@ func (hr *HierarchicalTagRepo) GetByParentID(ctx context.Context, parentID strin
 	return
 }
 
+// GetAll gets all available hierarchical tags in one query
+func (hr *hierarchicalTagRepo) GetAll(ctx context.Context) (tags []*entity.HierarchicalTag, err error) {
+	tags = make([]*entity.HierarchicalTag, 0)
+	err = hr.data.DB.Context(ctx).Where("status = ?", entity.HierarchicalTagStatusAvailable).
+		OrderBy("level ASC, sort_order ASC, display_name ASC").Find(&tags)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeletedBySlugNames gets deleted hierarchical tags by slug names
+func (hr *hierarchicalTagRepo) GetDeletedBySlugNames(ctx context.Context, slugNames []string) (
+	tags []*entity.HierarchicalTag, err error) {
+	tags = make([]*entity.HierarchicalTag, 0)
+	if len(slugNames) == 0 {
+		return
+	}
+	err = hr.data.DB.Context(ctx).In("slug_name", slugNames).
+		Where("status = ?", entity.HierarchicalTagStatusDeleted).Find(&tags)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // GetByID gets hierarchical tag by ID
-func (hr *HierarchicalTagRepo) GetByID(ctx context.Context, id string) (tag *entity.HierarchicalTag, exist bool, err error) {
+func (hr *hierarchicalTagRepo) GetByID(ctx context.Context, id string) (tag *entity.HierarchicalTag, exist bool, err error) {
 	tag = &entity.HierarchicalTag{}
 	exist, err = hr.data.DB.Context(ctx).Where("id = ? AND status = ?", id, entity.HierarchicalTagStatusAvailable).Get(tag)
 	if err != nil {
@@ -65,8 +94,22 @@ func (hr *HierarchicalTagRepo) GetByID(ctx context.Context, id string) (tag *ent
 	return
 }
 
+// GetByIDs gets available hierarchical tags by IDs
+func (hr *hierarchicalTagRepo) GetByIDs(ctx context.Context, ids []string) (tags []*entity.HierarchicalTag, err error) {
+	tags = make([]*entity.HierarchicalTag, 0)
+	if len(ids) == 0 {
+		return
+	}
+	err = hr.data.DB.Context(ctx).In("id", ids).
+		Where("status = ?", entity.HierarchicalTagStatusAvailable).Find(&tags)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // GetBySlugName gets hierarchical tag by slug name
-func (hr *HierarchicalTagRepo) GetBySlugName(ctx context.Context, slugName string) (tag *entity.HierarchicalTag, exist bool, err error) {
+func (hr *hierarchicalTagRepo) GetBySlugName(ctx context.Context, slugName string) (tag *entity.HierarchicalTag, exist bool, err error) {
 	tag = &entity.HierarchicalTag{}
 	exist, err = hr.data.DB.Context(ctx).Where("slug_name = ? AND status = ?", slugName, entity.HierarchicalTagStatusAvailable).Get(tag)
 	if err != nil {
@@ -76,23 +119,40 @@ func (hr *HierarchicalTagRepo) GetBySlugName(ctx context.Context, slugName strin
 }
 
 // Create creates a new hierarchical tag
-func (hr *HierarchicalTagRepo) Create(ctx context.Context, tag *entity.HierarchicalTag) (err error) {
+func (hr *hierarchicalTagRepo) Create(ctx context.Context, tag *entity.HierarchicalTag) (err error) {
 	// Calculate level and path
-	if tag.ParentID != "" && tag.ParentID != "0" {
+	if !isRootID(tag.ParentID) {
 		parent, exist, err := hr.GetByID(ctx, tag.ParentID)
 		if err != nil {
 			return err
 		}
 		if !exist {
-			return errors.BadRequest("parent tag not found")
+			return errors.BadRequest(reason.HierarchicalTagParentNotFound)
 		}
 		tag.Level = parent.Level + 1
 		tag.Path = parent.Path + "#" + tag.DisplayName
 	} else {
+		tag.ParentID = ""
 		tag.Level = 0
 		tag.Path = "#" + tag.DisplayName
 	}
 
+	// new tags are placed after their siblings
+	last := &entity.HierarchicalTag{}
+	session := hr.data.DB.Context(ctx).Where("status = ?", entity.HierarchicalTagStatusAvailable)
+	if isRootID(tag.ParentID) {
+		session.And("(parent_id IS NULL OR parent_id = '' OR parent_id = '0')")
+	} else {
+		session.And("parent_id = ?", tag.ParentID)
+	}
+	exist, err := session.Desc("sort_order").Get(last)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		tag.SortOrder = last.SortOrder + 1
+	}
+
 	_, err = hr.data.DB.Context(ctx).Insert(tag)
 	if err != nil {
 		err = errors.InternalServer(err.Error())
@@ -100,23 +160,326 @@ func (hr *HierarchicalTagRepo) Create(ctx context.Context, tag *entity.Hierarchi
 	return
 }
 
-// Update updates hierarchical tag
-func (hr *HierarchicalTagRepo) Update(ctx context.Context, tag *entity.HierarchicalTag) (err error) {
-	_, err = hr.data.DB.Context(ctx).Where("id = ?", tag.ID).Update(tag)
+// Update updates hierarchical tag, the path of its subtree is rewritten as the display name may change
+func (hr *hierarchicalTagRepo) Update(ctx context.Context, tag *entity.HierarchicalTag) (err error) {
+	_, err = hr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.Where("id = ?", tag.ID).Update(tag)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		return nil, hr.relocate(session, tag.ID, tag.ParentID)
+	})
+	return err
+}
+
+// Move moves a hierarchical tag with its whole subtree under a new parent.
+// An empty parent ID moves the tag to the root level.
+func (hr *hierarchicalTagRepo) Move(ctx context.Context, tagID, parentID string) (err error) {
+	_, err = hr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		return nil, hr.relocate(session.Context(ctx), tagID, parentID)
+	})
+	return err
+}
+
+// Delete soft deletes a hierarchical tag with its whole subtree and detaches them from questions
+func (hr *hierarchicalTagRepo) Delete(ctx context.Context, tagID string) (err error) {
+	_, err = hr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		tagMapping, children, err := hr.loadTree(session)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := tagMapping[tagID]; !ok {
+			return nil, errors.BadRequest(reason.HierarchicalTagNotFound)
+		}
+
+		ids := []string{tagID}
+		for i := 0; i < len(ids); i++ {
+			for _, child := range children[ids[i]] {
+				ids = append(ids, child.ID)
+			}
+		}
+
+		_, err = session.In("id", ids).Cols("status").
+			Update(&entity.HierarchicalTag{Status: entity.HierarchicalTagStatusDeleted})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		_, err = session.In("hierarchical_tag_id", ids).Cols("status").
+			Update(&entity.QuestionHierarchicalTagRel{Status: entity.QuestionHierarchicalTagRelStatusRemoved})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// Import creates or updates the given hierarchical tags and rebuilds the level and path
+// of the whole tree in one transaction. Tags which already exist are matched by ID,
+// deleted ones among them become available again.
+func (hr *hierarchicalTagRepo) Import(ctx context.Context, tags []*entity.HierarchicalTag) (err error) {
+	_, err = hr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		for _, tag := range tags {
+			exist, err := session.Where("id = ?", tag.ID).Exist(&entity.HierarchicalTag{})
+			if err != nil {
+				return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			}
+			tag.Status = entity.HierarchicalTagStatusAvailable
+			if exist {
+				_, err = session.Where("id = ?", tag.ID).
+					Cols("name", "slug_name", "parent_id", "display_name", "description", "status", "sort_order").
+					Update(tag)
+			} else {
+				_, err = session.Insert(tag)
+			}
+			if err != nil {
+				return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			}
+		}
+
+		_, children, err := hr.loadTree(session)
+		if err != nil {
+			return nil, err
+		}
+		for _, root := range children[""] {
+			if err = hr.rewriteSubtree(session, root, 0, "", children); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// Reorder sorts the children of the parent in the given order, the children not given
+// keep their relative order after them. An empty parent ID sorts the root tags.
+func (hr *hierarchicalTagRepo) Reorder(ctx context.Context, parentID string, tagIDs []string) (err error) {
+	_, err = hr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, children, err := hr.loadTree(session)
+		if err != nil {
+			return nil, err
+		}
+		if isRootID(parentID) {
+			parentID = ""
+		}
+		siblings := children[parentID]
+		siblingMapping := make(map[string]*entity.HierarchicalTag, len(siblings))
+		for _, sibling := range siblings {
+			siblingMapping[sibling.ID] = sibling
+		}
+
+		sorted := make([]*entity.HierarchicalTag, 0, len(siblings))
+		seen := make(map[string]bool, len(tagIDs))
+		for _, tagID := range tagIDs {
+			sibling, ok := siblingMapping[tagID]
+			if !ok {
+				return nil, errors.BadRequest(reason.HierarchicalTagNotSiblings)
+			}
+			if !seen[tagID] {
+				seen[tagID] = true
+				sorted = append(sorted, sibling)
+			}
+		}
+		for _, sibling := range siblings {
+			if !seen[sibling.ID] {
+				sorted = append(sorted, sibling)
+			}
+		}
+
+		for i, sibling := range sorted {
+			if sibling.SortOrder == i {
+				continue
+			}
+			sibling.SortOrder = i
+			_, err = session.Where("id = ?", sibling.ID).Cols("sort_order").Update(sibling)
+			if err != nil {
+				return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// UpdatePermission replaces the filing settings and the moderators of a hierarchical tag
+func (hr *hierarchicalTagRepo) UpdatePermission(ctx context.Context, tagID string, leafOnly bool,
+	roleIDs []int, moderatorUserIDs []string) (err error) {
+	_, err = hr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.Where("id = ?", tagID).Cols("leaf_only").Update(&entity.HierarchicalTag{LeafOnly: leafOnly})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+
+		_, err = session.Where("hierarchical_tag_id = ?", tagID).Delete(&entity.HierarchicalTagRoleRel{})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		roleRels := make([]*entity.HierarchicalTagRoleRel, 0, len(roleIDs))
+		for _, roleID := range roleIDs {
+			roleRels = append(roleRels, &entity.HierarchicalTagRoleRel{HierarchicalTagID: tagID, RoleID: roleID})
+		}
+		if len(roleRels) > 0 {
+			if _, err = session.Insert(roleRels); err != nil {
+				return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			}
+		}
+
+		_, err = session.Where("hierarchical_tag_id = ?", tagID).Delete(&entity.HierarchicalTagModerator{})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		moderators := make([]*entity.HierarchicalTagModerator, 0, len(moderatorUserIDs))
+		for _, userID := range moderatorUserIDs {
+			moderators = append(moderators, &entity.HierarchicalTagModerator{HierarchicalTagID: tagID, UserID: userID})
+		}
+		if len(moderators) > 0 {
+			if _, err = session.Insert(moderators); err != nil {
+				return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// GetRoleRels gets the roles allowed to file questions under the hierarchical tags,
+// all of them are returned when no tag ID is given.
+func (hr *hierarchicalTagRepo) GetRoleRels(ctx context.Context, tagIDs []string) (
+	rels []*entity.HierarchicalTagRoleRel, err error) {
+	rels = make([]*entity.HierarchicalTagRoleRel, 0)
+	session := hr.data.DB.Context(ctx)
+	if len(tagIDs) > 0 {
+		session.In("hierarchical_tag_id", tagIDs)
+	}
+	err = session.Asc("id").Find(&rels)
 	if err != nil {
-		err = errors.InternalServer(err.Error())
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetModerators gets the moderators of a hierarchical tag
+func (hr *hierarchicalTagRepo) GetModerators(ctx context.Context, tagID string) (
+	moderators []*entity.HierarchicalTagModerator, err error) {
+	moderators = make([]*entity.HierarchicalTagModerator, 0)
+	err = hr.data.DB.Context(ctx).Where("hierarchical_tag_id = ?", tagID).Asc("id").Find(&moderators)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
 	return
 }
 
+// GetModeratedTagIDs gets the IDs of the hierarchical tags moderated by the user
+func (hr *hierarchicalTagRepo) GetModeratedTagIDs(ctx context.Context, userID string) (tagIDs []string, err error) {
+	tagIDs = make([]string, 0)
+	err = hr.data.DB.Context(ctx).Table(entity.HierarchicalTagModerator{}.TableName()).
+		Where("user_id = ?", userID).Cols("hierarchical_tag_id").Find(&tagIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// loadTree loads all available hierarchical tags, indexed by ID and grouped by parent ID.
+// Root tags are grouped under the empty parent ID.
+func (hr *hierarchicalTagRepo) loadTree(session *xorm.Session) (
+	tagMapping map[string]*entity.HierarchicalTag, children map[string][]*entity.HierarchicalTag, err error) {
+	tags := make([]*entity.HierarchicalTag, 0)
+	err = session.Where("status = ?", entity.HierarchicalTagStatusAvailable).
+		OrderBy("sort_order ASC, display_name ASC").Find(&tags)
+	if err != nil {
+		return nil, nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	tagMapping = make(map[string]*entity.HierarchicalTag, len(tags))
+	children = make(map[string][]*entity.HierarchicalTag)
+	for _, tag := range tags {
+		if isRootID(tag.ParentID) {
+			tag.ParentID = ""
+		}
+		tagMapping[tag.ID] = tag
+		children[tag.ParentID] = append(children[tag.ParentID], tag)
+	}
+	return tagMapping, children, nil
+}
+
+// relocate places the tag under the parent and rewrites the level and path of the tag, all of its
+// descendants and the denormalized path of their question relations.
+func (hr *hierarchicalTagRepo) relocate(session *xorm.Session, tagID, parentID string) error {
+	tagMapping, children, err := hr.loadTree(session)
+	if err != nil {
+		return err
+	}
+	tag, ok := tagMapping[tagID]
+	if !ok {
+		return errors.BadRequest(reason.HierarchicalTagNotFound)
+	}
+
+	level, parentPath := 0, ""
+	if isRootID(parentID) {
+		parentID = ""
+	} else {
+		parent, ok := tagMapping[parentID]
+		if !ok {
+			return errors.BadRequest(reason.HierarchicalTagParentNotFound)
+		}
+		// walk up from the new parent, reaching the tag itself means a cycle
+		for ancestor, depth := parent, 0; ancestor != nil && depth <= len(tagMapping); depth++ {
+			if ancestor.ID == tag.ID {
+				return errors.BadRequest(reason.HierarchicalTagCannotMoveUnderDescendant)
+			}
+			ancestor = tagMapping[ancestor.ParentID]
+		}
+		level, parentPath = parent.Level+1, parent.Path
+	}
+	tag.ParentID = parentID
+	_, err = session.Where("id = ?", tag.ID).Cols("parent_id").Update(tag)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return hr.rewriteSubtree(session, tag, level, parentPath, children)
+}
+
+// rewriteSubtree rewrites the level and path of the tag and its descendants which are out of date
+func (hr *hierarchicalTagRepo) rewriteSubtree(session *xorm.Session, tag *entity.HierarchicalTag,
+	level int, parentPath string, children map[string][]*entity.HierarchicalTag) error {
+	path := parentPath + "#" + tag.DisplayName
+	if tag.Level != level || tag.Path != path {
+		tag.Level, tag.Path = level, path
+		_, err := session.Where("id = ?", tag.ID).Cols("level", "path").Update(tag)
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		_, err = session.Where("hierarchical_tag_id = ?", tag.ID).Cols("hierarchical_tag_path").
+			Update(&entity.QuestionHierarchicalTagRel{HierarchicalTagPath: tag.Path})
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	for _, child := range children[tag.ID] {
+		if err := hr.rewriteSubtree(session, child, level+1, tag.Path, children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isRootID(id string) bool {
+	return id == "" || id == "0"
+}
+
 // GetPath gets the full path of a hierarchical tag
-func (hr *HierarchicalTagRepo) GetPath(ctx context.Context, tagID string) (path string, tags []*entity.HierarchicalTag, err error) {
+func (hr *hierarchicalTagRepo) GetPath(ctx context.Context, tagID string) (path string, tags []*entity.HierarchicalTag, err error) {
 	tag, exist, err := hr.GetByID(ctx, tagID)
 	if err != nil {
 		return
 	}
 	if !exist {
-		err = errors.BadRequest("tag not found")
+		err = errors.BadRequest(reason.HierarchicalTagNotFound)
 		return
 	}
 
@@ -125,7 +488,7 @@ func (hr *HierarchicalTagRepo) GetPath(ctx context.Context, tagID string) (path
 
 	// Get all parent tags
 	currentTag := tag
-	for currentTag.ParentID != "" && currentTag.ParentID != "0" {
+	for !isRootID(currentTag.ParentID) {
 		parent, exist, err := hr.GetByID(ctx, currentTag.ParentID)
 		if err != nil {
 			return path, tags, err
@@ -141,7 +504,7 @@ func (hr *HierarchicalTagRepo) GetPath(ctx context.Context, tagID string) (path
 }
 
 // HasChildren checks if a hierarchical tag has children
-func (hr *HierarchicalTagRepo) HasChildren(ctx context.Context, tagID string) (bool, error) {
+func (hr *hierarchicalTagRepo) HasChildren(ctx context.Context, tagID string) (bool, error) {
 	count, err := hr.data.DB.Context(ctx).Where("parent_id = ? AND status = ?", tagID, entity.HierarchicalTagStatusAvailable).Count(&entity.HierarchicalTag{})
 	if err != nil {
 		return false, errors.InternalServer(err.Error())
@@ -150,7 +513,7 @@ func (hr *HierarchicalTagRepo) HasChildren(ctx context.Context, tagID string) (b
 }
 
 // CreateQuestionTagRel creates relationship between question and hierarchical tag
-func (hr *HierarchicalTagRepo) CreateQuestionTagRel(ctx context.Context, questionID, tagID string) (err error) {
+func (hr *hierarchicalTagRepo) CreateQuestionTagRel(ctx context.Context, questionID, tagID string) (err error) {
 	// Get the full path for the tag
 	path, _, err := hr.GetPath(ctx, tagID)
 	if err != nil {
@@ -161,7 +524,7 @@ func (hr *HierarchicalTagRepo) CreateQuestionTagRel(ctx context.Context, questio
 		QuestionID:          questionID,
 		HierarchicalTagID:   tagID,
 		HierarchicalTagPath: path,
-		Status:              1,
+		Status:              entity.QuestionHierarchicalTagRelStatusAvailable,
 	}
 
 	_, err = hr.data.DB.Context(ctx).Insert(rel)
@@ -172,8 +535,8 @@ func (hr *HierarchicalTagRepo) CreateQuestionTagRel(ctx context.Context, questio
 }
 
 // GetQuestionTagRels gets hierarchical tag relationships for a question
-func (hr *HierarchicalTagRepo) GetQuestionTagRels(ctx context.Context, questionID string) (rels []*entity.QuestionHierarchicalTagRel, err error) {
-	err = hr.data.DB.Context(ctx).Where("question_id = ? AND status = ?", questionID, 1).Find(&rels)
+func (hr *hierarchicalTagRepo) GetQuestionTagRels(ctx context.Context, questionID string) (rels []*entity.QuestionHierarchicalTagRel, err error) {
+	err = hr.data.DB.Context(ctx).Where("question_id = ? AND status = ?", questionID, entity.QuestionHierarchicalTagRelStatusAvailable).Find(&rels)
 	if err != nil {
 		err = errors.InternalServer(err.Error())
 	}
@@ -181,8 +544,9 @@ func (hr *HierarchicalTagRepo) GetQuestionTagRels(ctx context.Context, questionI
 }
 
 // RemoveQuestionTagRels removes all hierarchical tag relationships for a question
-func (hr *HierarchicalTagRepo) RemoveQuestionTagRels(ctx context.Context, questionID string) (err error) {
-	_, err = hr.data.DB.Context(ctx).Where("question_id = ?", questionID).Update(&entity.QuestionHierarchicalTagRel{Status: 0})
+func (hr *hierarchicalTagRepo) RemoveQuestionTagRels(ctx context.Context, questionID string) (err error) {
+	_, err = hr.data.DB.Context(ctx).Where("question_id = ?", questionID).Cols("status").
+		Update(&entity.QuestionHierarchicalTagRel{Status: entity.QuestionHierarchicalTagRelStatusRemoved})
 	if err != nil {
 		err = errors.InternalServer(err.Error())
 	}
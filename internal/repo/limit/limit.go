@@ -22,16 +22,21 @@ package limit
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
+
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/segmentfault/pacman/errors"
-	"time"
 )
 
 // LimitRepo auth repository
 type LimitRepo struct {
 	data *data.Data
+	// windowLock makes the counting atomic for the caches which can not do it by themselves,
+	// they are in the memory of the process
+	windowLock sync.Mutex
 }
 
 // NewRateLimitRepo new repository
@@ -62,3 +67,53 @@ func (lr *LimitRepo) CheckAndRecord(ctx context.Context, key string) (limit bool
 func (lr *LimitRepo) ClearRecord(ctx context.Context, key string) error {
 	return lr.data.Cache.Del(ctx, constant.RateLimitCacheKeyPrefix+key)
 }
+
+// CheckAndRecordWindow counts the request in the sliding window of the key.
+// The count of the previous fixed window is weighted by how much it overlaps the sliding window.
+// The request is counted before it is checked, so the concurrent requests can not exceed the quota together.
+// If the quota is used up, the count is rolled back and limit is true.
+func (lr *LimitRepo) CheckAndRecordWindow(ctx context.Context, key string, window time.Duration, quota int) (
+	used int, reset time.Duration, limit bool, err error) {
+	now := time.Now()
+	current := now.UnixNano() / int64(window)
+	elapsed := time.Duration(now.UnixNano() - current*int64(window))
+	reset = window - elapsed
+
+	currentKey := fmt.Sprintf("%s%s:%d", constant.RateLimitCacheKeyPrefix, key, current)
+	previousKey := fmt.Sprintf("%s%s:%d", constant.RateLimitCacheKeyPrefix, key, current-1)
+	// keep it for the next window which looks back on it
+	currentCount, err := lr.increaseWindow(ctx, currentKey, 2*window)
+	if err != nil {
+		return 0, reset, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	previousCount, _, err := lr.data.Cache.GetInt64(ctx, previousKey)
+	if err != nil {
+		return 0, reset, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	used = int(currentCount) + int(float64(previousCount)*float64(reset)/float64(window))
+	if used <= quota {
+		return used, reset, false, nil
+	}
+	if _, err = lr.data.Cache.Decrease(ctx, currentKey, 1); err != nil {
+		return used - 1, reset, true, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return used - 1, reset, true, nil
+}
+
+// increaseWindow increase the count of the window by one, the key expires after the ttl
+func (lr *LimitRepo) increaseWindow(ctx context.Context, key string, ttl time.Duration) (count int64, err error) {
+	if counter, ok := lr.data.Cache.(data.ExpiringCounter); ok {
+		return counter.IncreaseWithTTL(ctx, key, 1, ttl)
+	}
+	lr.windowLock.Lock()
+	defer lr.windowLock.Unlock()
+	_, exist, err := lr.data.Cache.GetInt64(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	if !exist {
+		return 1, lr.data.Cache.SetInt64(ctx, key, 1, ttl)
+	}
+	return lr.data.Cache.Increase(ctx, key, 1)
+}
@@ -0,0 +1,220 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/moderation_common"
+	"github.com/apache/answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// moderationRepo moderation repository
+type moderationRepo struct {
+	data *data.Data
+}
+
+// NewModerationRepo new repository
+func NewModerationRepo(data *data.Data) moderation_common.ModerationRepo {
+	return &moderationRepo{
+		data: data,
+	}
+}
+
+// GetClaim get the claim of the item
+func (mr *moderationRepo) GetClaim(ctx context.Context, itemType, itemID string) (
+	claim *entity.ModerationClaim, exist bool, err error) {
+	claim = &entity.ModerationClaim{}
+	exist, err = mr.data.DB.Context(ctx).Where("item_type = ?", itemType).And("item_id = ?", itemID).Get(claim)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetActiveClaims get the claims which are not expired
+func (mr *moderationRepo) GetActiveClaims(ctx context.Context, now time.Time) (
+	claims []*entity.ModerationClaim, err error) {
+	claims = make([]*entity.ModerationClaim, 0)
+	err = mr.data.DB.Context(ctx).Where("expired_at > ?", now).Find(&claims)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveClaim claim the item for the user, or renew the claim of the user.
+// The claim is not saved if another user holds it and it is not expired.
+func (mr *moderationRepo) SaveClaim(ctx context.Context, claim *entity.ModerationClaim) (saved bool, err error) {
+	res, err := mr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		old := &entity.ModerationClaim{}
+		exist, err := session.Where("item_type = ?", claim.ItemType).And("item_id = ?", claim.ItemID).
+			ForUpdate().Get(old)
+		if err != nil {
+			return false, err
+		}
+		if !exist {
+			_, err = session.Insert(claim)
+			return err == nil, err
+		}
+		if old.UserID != claim.UserID && old.ExpiredAt.After(time.Now()) {
+			return false, nil
+		}
+		_, err = session.ID(old.ID).Cols("user_id", "expired_at").Update(claim)
+		return err == nil, err
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return res.(bool), nil
+}
+
+// RemoveClaim remove the claim of the item which is held by the user
+func (mr *moderationRepo) RemoveClaim(ctx context.Context, itemType, itemID, userID string) (err error) {
+	_, err = mr.data.DB.Context(ctx).Where("item_type = ?", itemType).And("item_id = ?", itemID).
+		And("user_id = ?", userID).Delete(&entity.ModerationClaim{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveItemClaim remove the claim of the item whoever holds it
+func (mr *moderationRepo) RemoveItemClaim(ctx context.Context, itemType, itemID string) (err error) {
+	_, err = mr.data.DB.Context(ctx).Where("item_type = ?", itemType).And("item_id = ?", itemID).
+		Delete(&entity.ModerationClaim{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddLog add moderation log, the logs are never updated or removed
+func (mr *moderationRepo) AddLog(ctx context.Context, moderationLog *entity.ModerationLog) (err error) {
+	_, err = mr.data.DB.Context(ctx).Insert(moderationLog)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetLogPage get moderation log page, the latest first
+func (mr *moderationRepo) GetLogPage(ctx context.Context, page, pageSize int, cond *entity.ModerationLog) (
+	logs []*entity.ModerationLog, total int64, err error) {
+	logs = make([]*entity.ModerationLog, 0)
+	session := mr.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &logs, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQueuePage get the page of the pending reports, reviews and unreviewed revisions, the oldest first
+func (mr *moderationRepo) GetQueuePage(ctx context.Context, page, pageSize int, cond *moderation_common.QueueCond) (
+	items []*moderation_common.QueueItem, total int64, err error) {
+	items = make([]*moderation_common.QueueItem, 0)
+	parts := make([]string, 0, 3)
+	args := make([]any, 0)
+	addPart := func(itemType, table, reasonColumn string, statusCond builder.Cond) error {
+		if len(cond.ItemType) > 0 && cond.ItemType != itemType {
+			return nil
+		}
+		b := builder.MySQL().Select(
+			fmt.Sprintf("'%s' AS item_type", itemType),
+			"id AS item_id",
+			"object_id",
+			reasonColumn+" AS reason",
+			"created_at AS submit_at",
+		).From(table).Where(statusCond)
+		if !cond.SubmittedAfter.IsZero() {
+			b.And(builder.Gte{"created_at": cond.SubmittedAfter})
+		}
+		if !cond.SubmittedBefore.IsZero() {
+			b.And(builder.Lte{"created_at": cond.SubmittedBefore})
+		}
+		// only the questions have tags
+		if len(cond.TagSlugName) > 0 {
+			b.And(builder.In("object_id", builder.Select("tag_rel.object_id").From("tag_rel").
+				InnerJoin("tag", "tag.id = tag_rel.tag_id").
+				Where(builder.Eq{"tag.slug_name": cond.TagSlugName}).
+				And(builder.Eq{"tag_rel.status": entity.TagRelStatusAvailable})))
+		}
+		partSQL, partArgs, err := b.ToSQL()
+		if err != nil {
+			return err
+		}
+		parts = append(parts, partSQL)
+		args = append(args, partArgs...)
+		return nil
+	}
+
+	err = addPart(entity.ModerationItemTypeReport, "report", "content",
+		builder.Eq{"status": entity.ReportStatusPending})
+	if err == nil {
+		err = addPart(entity.ModerationItemTypeReview, "review", "reason",
+			builder.Eq{"status": entity.ReviewStatusPending})
+	}
+	if err == nil && len(cond.RevisionObjectTypes) > 0 {
+		err = addPart(entity.ModerationItemTypeRevision, "revision", "log",
+			builder.Eq{"status": entity.RevisionUnreviewedStatus}.And(builder.In("object_type", cond.RevisionObjectTypes)))
+	}
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(parts) == 0 {
+		return items, 0, nil
+	}
+	unionSQL := "(" + strings.Join(parts, " UNION ALL ") + ")"
+
+	countSQL, _, err := builder.MySQL().Select("COUNT(*) AS total").From(unionSQL, "c").ToSQL()
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	querySQL, _, err := builder.MySQL().Select("*").From(unionSQL, "q").
+		OrderBy("submit_at ASC, item_type ASC, item_id ASC").Limit(pageSize, (page-1)*pageSize).ToSQL()
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	res, err := mr.data.DB.Context(ctx).Query(append([]any{countSQL}, args...)...)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(res) > 0 {
+		total = converter.StringToInt64(string(res[0]["total"]))
+	}
+	err = mr.data.DB.Context(ctx).SQL(querySQL, args...).Find(&items)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return items, total, nil
+}
@@ -0,0 +1,304 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package oauth_provider
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/oauth_provider"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// oauthProviderRepo oauth provider repository
+type oauthProviderRepo struct {
+	data *data.Data
+}
+
+// NewOAuthProviderRepo new repository
+func NewOAuthProviderRepo(data *data.Data) oauth_provider.OAuthProviderRepo {
+	return &oauthProviderRepo{
+		data: data,
+	}
+}
+
+// AddClient add oauth client
+func (or *oauthProviderRepo) AddClient(ctx context.Context, client *entity.OAuthClient) (err error) {
+	_, err = or.data.DB.Context(ctx).Insert(client)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateClient update the name, redirect uris and scopes of the oauth client
+func (or *oauthProviderRepo) UpdateClient(ctx context.Context, client *entity.OAuthClient) (err error) {
+	_, err = or.data.DB.Context(ctx).ID(client.ID).Cols("name", "redirect_uris", "scopes").Update(client)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateClientSecret update the secret hash of the oauth client
+func (or *oauthProviderRepo) UpdateClientSecret(ctx context.Context, id, secretHash string) (err error) {
+	_, err = or.data.DB.Context(ctx).ID(id).Cols("secret_hash").Update(&entity.OAuthClient{SecretHash: secretHash})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// DeleteClient delete the oauth client and revoke all the refresh tokens issued to it
+func (or *oauthProviderRepo) DeleteClient(ctx context.Context, client *entity.OAuthClient) (err error) {
+	_, err = or.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.ID(client.ID).Cols("status").
+			Update(&entity.OAuthClient{Status: entity.OAuthClientStatusDeleted})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Where("client_id = ?", client.ClientID).Cols("status").
+			Update(&entity.OAuthRefreshToken{Status: entity.OAuthRefreshTokenStatusRevoked})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Where("client_id = ?", client.ClientID).Delete(&entity.OAuthConsent{})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetClient get oauth client which is not deleted
+func (or *oauthProviderRepo) GetClient(ctx context.Context, id string) (
+	client *entity.OAuthClient, exist bool, err error) {
+	client = &entity.OAuthClient{}
+	exist, err = or.data.DB.Context(ctx).ID(id).Where("status = ?", entity.OAuthClientStatusAvailable).Get(client)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetClientByClientID get oauth client which is not deleted by the client id
+func (or *oauthProviderRepo) GetClientByClientID(ctx context.Context, clientID string) (
+	client *entity.OAuthClient, exist bool, err error) {
+	client = &entity.OAuthClient{}
+	exist, err = or.data.DB.Context(ctx).Where("client_id = ?", clientID).
+		And("status = ?", entity.OAuthClientStatusAvailable).Get(client)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetClientList get all oauth clients which are not deleted
+func (or *oauthProviderRepo) GetClientList(ctx context.Context) (clients []*entity.OAuthClient, err error) {
+	clients = make([]*entity.OAuthClient, 0)
+	err = or.data.DB.Context(ctx).Where("status = ?", entity.OAuthClientStatusAvailable).
+		Desc("created_at").Find(&clients)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddAuthorizationCode add authorization code
+func (or *oauthProviderRepo) AddAuthorizationCode(ctx context.Context, code *entity.OAuthAuthorizationCode) (err error) {
+	_, err = or.data.DB.Context(ctx).Insert(code)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAuthorizationCodeByHash get authorization code by the hash of the code, whatever its status is
+func (or *oauthProviderRepo) GetAuthorizationCodeByHash(ctx context.Context, codeHash string) (
+	code *entity.OAuthAuthorizationCode, exist bool, err error) {
+	code = &entity.OAuthAuthorizationCode{}
+	exist, err = or.data.DB.Context(ctx).Where("code_hash = ?", codeHash).Get(code)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UseAuthorizationCode mark the authorization code as used, used is false if it has been used by others
+func (or *oauthProviderRepo) UseAuthorizationCode(ctx context.Context, id string) (used bool, err error) {
+	affected, err := or.data.DB.Context(ctx).ID(id).Where("status = ?", entity.OAuthAuthorizationCodeStatusAvailable).
+		Cols("status").Update(&entity.OAuthAuthorizationCode{Status: entity.OAuthAuthorizationCodeStatusUsed})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// RevokeGrant revoke the authorization code and all the tokens issued for it
+func (or *oauthProviderRepo) RevokeGrant(ctx context.Context, grantID string) (err error) {
+	tokens := make([]*entity.OAuthRefreshToken, 0)
+	_, err = or.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.ID(grantID).Cols("status").
+			Update(&entity.OAuthAuthorizationCode{Status: entity.OAuthAuthorizationCodeStatusRevoked})
+		if err != nil {
+			return nil, err
+		}
+		err = session.Where("grant_id = ?", grantID).Find(&tokens)
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Where("grant_id = ?", grantID).Cols("status").
+			Update(&entity.OAuthRefreshToken{Status: entity.OAuthRefreshTokenStatusRevoked})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, token := range tokens {
+		if len(token.AccessTokenHash) == 0 {
+			continue
+		}
+		if err = or.RemoveAccessToken(ctx, token.AccessTokenHash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveExpiredAuthorizationCodes remove the authorization codes expired before the time
+func (or *oauthProviderRepo) RemoveExpiredAuthorizationCodes(ctx context.Context, before time.Time) (
+	count int64, err error) {
+	count, err = or.data.DB.Context(ctx).Where("expired_at < ?", before).Delete(&entity.OAuthAuthorizationCode{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SetAccessToken keep the access token in the cache until it is expired
+func (or *oauthProviderRepo) SetAccessToken(ctx context.Context,
+	tokenHash string, token *entity.OAuthAccessToken) (err error) {
+	content, err := json.Marshal(token)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	err = or.data.Cache.SetString(ctx, constant.OAuthAccessTokenCacheKey+tokenHash,
+		string(content), constant.OAuthAccessTokenCacheTime)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAccessToken get the access token from the cache
+func (or *oauthProviderRepo) GetAccessToken(ctx context.Context, tokenHash string) (
+	token *entity.OAuthAccessToken, exist bool, err error) {
+	content, exist, err := or.data.Cache.GetString(ctx, constant.OAuthAccessTokenCacheKey+tokenHash)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, false, nil
+	}
+	token = &entity.OAuthAccessToken{}
+	if err = json.Unmarshal([]byte(content), token); err != nil {
+		return nil, false, nil
+	}
+	return token, true, nil
+}
+
+// RemoveAccessToken remove the access token from the cache
+func (or *oauthProviderRepo) RemoveAccessToken(ctx context.Context, tokenHash string) (err error) {
+	err = or.data.Cache.Del(ctx, constant.OAuthAccessTokenCacheKey+tokenHash)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddRefreshToken add refresh token
+func (or *oauthProviderRepo) AddRefreshToken(ctx context.Context, token *entity.OAuthRefreshToken) (err error) {
+	_, err = or.data.DB.Context(ctx).Insert(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetRefreshTokenByHash get refresh token which is not revoked by the hash of the token
+func (or *oauthProviderRepo) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (
+	token *entity.OAuthRefreshToken, exist bool, err error) {
+	token = &entity.OAuthRefreshToken{}
+	exist, err = or.data.DB.Context(ctx).Where("token_hash = ?", tokenHash).
+		And("status = ?", entity.OAuthRefreshTokenStatusAvailable).Get(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RevokeRefreshToken revoke the refresh token, revoked is false if it has been revoked by others
+func (or *oauthProviderRepo) RevokeRefreshToken(ctx context.Context, id string) (revoked bool, err error) {
+	affected, err := or.data.DB.Context(ctx).ID(id).Where("status = ?", entity.OAuthRefreshTokenStatusAvailable).
+		Cols("status").Update(&entity.OAuthRefreshToken{Status: entity.OAuthRefreshTokenStatusRevoked})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// GetConsent get the scopes the user has granted to the client
+func (or *oauthProviderRepo) GetConsent(ctx context.Context, userID, clientID string) (
+	consent *entity.OAuthConsent, exist bool, err error) {
+	consent = &entity.OAuthConsent{}
+	exist, err = or.data.DB.Context(ctx).Where("user_id = ?", userID).And("client_id = ?", clientID).Get(consent)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveConsent save the scopes the user has granted to the client, if existed, update, if not exist, insert
+func (or *oauthProviderRepo) SaveConsent(ctx context.Context, consent *entity.OAuthConsent) (err error) {
+	exist, err := or.data.DB.Context(ctx).Where("user_id = ?", consent.UserID).
+		And("client_id = ?", consent.ClientID).Exist(&entity.OAuthConsent{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		_, err = or.data.DB.Context(ctx).Where("user_id = ?", consent.UserID).
+			And("client_id = ?", consent.ClientID).Cols("scope").Update(consent)
+	} else {
+		_, err = or.data.DB.Context(ctx).Insert(consent)
+	}
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
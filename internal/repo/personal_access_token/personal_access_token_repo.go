@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package personal_access_token
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/personal_access_token"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// personalAccessTokenRepo personal access token repository
+type personalAccessTokenRepo struct {
+	data *data.Data
+}
+
+// NewPersonalAccessTokenRepo new repository
+func NewPersonalAccessTokenRepo(data *data.Data) personal_access_token.PersonalAccessTokenRepo {
+	return &personalAccessTokenRepo{
+		data: data,
+	}
+}
+
+// AddToken add personal access token
+func (pr *personalAccessTokenRepo) AddToken(ctx context.Context, token *entity.PersonalAccessToken) (err error) {
+	_, err = pr.data.DB.Context(ctx).Insert(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetToken get personal access token which is not revoked
+func (pr *personalAccessTokenRepo) GetToken(ctx context.Context, id string) (
+	token *entity.PersonalAccessToken, exist bool, err error) {
+	token = &entity.PersonalAccessToken{}
+	exist, err = pr.data.DB.Context(ctx).ID(id).
+		Where("status = ?", entity.PersonalAccessTokenStatusAvailable).Get(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTokenByHash get personal access token which is not revoked by the hash of the token
+func (pr *personalAccessTokenRepo) GetTokenByHash(ctx context.Context, tokenHash string) (
+	token *entity.PersonalAccessToken, exist bool, err error) {
+	token = &entity.PersonalAccessToken{}
+	exist, err = pr.data.DB.Context(ctx).Where("token_hash = ?", tokenHash).
+		And("status = ?", entity.PersonalAccessTokenStatusAvailable).Get(token)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserTokens get all personal access tokens of the user which are not revoked
+func (pr *personalAccessTokenRepo) GetUserTokens(ctx context.Context, userID string) (
+	tokens []*entity.PersonalAccessToken, err error) {
+	tokens = make([]*entity.PersonalAccessToken, 0)
+	err = pr.data.DB.Context(ctx).Where("user_id = ?", userID).
+		And("status = ?", entity.PersonalAccessTokenStatusAvailable).Desc("created_at").Find(&tokens)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTokenPage get personal access token page, filtered by the user if the user id is not empty
+func (pr *personalAccessTokenRepo) GetTokenPage(ctx context.Context, page, pageSize int, userID string) (
+	tokens []*entity.PersonalAccessToken, total int64, err error) {
+	tokens = make([]*entity.PersonalAccessToken, 0)
+	session := pr.data.DB.Context(ctx).Where("status = ?", entity.PersonalAccessTokenStatusAvailable)
+	if len(userID) > 0 {
+		session.And("user_id = ?", userID)
+	}
+	session.Desc("created_at")
+	total, err = pager.Help(page, pageSize, &tokens, &entity.PersonalAccessToken{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountUserTokens count the personal access tokens of the user which are not revoked
+func (pr *personalAccessTokenRepo) CountUserTokens(ctx context.Context, userID string) (count int64, err error) {
+	count, err = pr.data.DB.Context(ctx).Where("user_id = ?", userID).
+		And("status = ?", entity.PersonalAccessTokenStatusAvailable).Count(&entity.PersonalAccessToken{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RevokeToken revoke personal access token, the record is kept
+func (pr *personalAccessTokenRepo) RevokeToken(ctx context.Context, id string) (err error) {
+	_, err = pr.data.DB.Context(ctx).ID(id).Cols("status").
+		Update(&entity.PersonalAccessToken{Status: entity.PersonalAccessTokenStatusRevoked})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateLastUsedAt update the last time the token is used
+func (pr *personalAccessTokenRepo) UpdateLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) (err error) {
+	_, err = pr.data.DB.Context(ctx).ID(id).Cols("last_used_at").
+		Update(&entity.PersonalAccessToken{LastUsedAt: lastUsedAt})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -32,14 +32,21 @@ import (
 	"github.com/apache/answer/internal/repo/collection"
 	"github.com/apache/answer/internal/repo/comment"
 	"github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/digest"
+	"github.com/apache/answer/internal/repo/draft"
 	"github.com/apache/answer/internal/repo/export"
 	"github.com/apache/answer/internal/repo/file_record"
 	"github.com/apache/answer/internal/repo/hierarchical_tag"
 	"github.com/apache/answer/internal/repo/limit"
 	"github.com/apache/answer/internal/repo/meta"
+	"github.com/apache/answer/internal/repo/moderation"
 	"github.com/apache/answer/internal/repo/notification"
+	"github.com/apache/answer/internal/repo/oauth_provider"
+	"github.com/apache/answer/internal/repo/personal_access_token"
 	"github.com/apache/answer/internal/repo/plugin_config"
 	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/question_schedule"
+	"github.com/apache/answer/internal/repo/queue_message"
 	"github.com/apache/answer/internal/repo/rank"
 	"github.com/apache/answer/internal/repo/reason"
 	"github.com/apache/answer/internal/repo/report"
@@ -47,6 +54,8 @@ import (
 	"github.com/apache/answer/internal/repo/revision"
 	"github.com/apache/answer/internal/repo/role"
 	"github.com/apache/answer/internal/repo/search_common"
+	"github.com/apache/answer/internal/repo/search_index"
+	"github.com/apache/answer/internal/repo/search_sync"
 	"github.com/apache/answer/internal/repo/site_info"
 	"github.com/apache/answer/internal/repo/tag"
 	"github.com/apache/answer/internal/repo/tag_common"
@@ -54,6 +63,7 @@ import (
 	"github.com/apache/answer/internal/repo/user"
 	"github.com/apache/answer/internal/repo/user_external_login"
 	"github.com/apache/answer/internal/repo/user_notification_config"
+	"github.com/apache/answer/internal/repo/webhook"
 	"github.com/google/wire"
 )
 
@@ -107,8 +117,20 @@ var ProviderSetRepo = wire.NewSet(
 	review.NewReviewRepo,
 	badge.NewBadgeRepo,
 	badge.NewEventRuleRepo,
+	badge.NewBadgeRuleRepo,
 	badge_group.NewBadgeGroupRepo,
 	badge_award.NewBadgeAwardRepo,
 	file_record.NewFileRecordRepo,
 	hierarchical_tag.NewHierarchicalTagRepo,
+	queue_message.NewQueueMessageRepo,
+	webhook.NewWebhookRepo,
+	search_index.NewSearchIndexRepo,
+	search_sync.NewSearchChangeRepo,
+	search_sync.NewPluginSyncer,
+	digest.NewDigestRepo,
+	personal_access_token.NewPersonalAccessTokenRepo,
+	oauth_provider.NewOAuthProviderRepo,
+	draft.NewDraftRepo,
+	question_schedule.NewQuestionScheduleRepo,
+	moderation.NewModerationRepo,
 )
@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 	"unicode"
@@ -35,10 +36,10 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/search_sync"
 	"github.com/apache/answer/internal/service/unique"
 	"github.com/apache/answer/pkg/htmltext"
 	"github.com/apache/answer/pkg/uid"
-	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 	"xorm.io/builder"
@@ -47,18 +48,21 @@ import (
 
 // questionRepo question repository
 type questionRepo struct {
-	data         *data.Data
-	uniqueIDRepo unique.UniqueIDRepo
+	data             *data.Data
+	uniqueIDRepo     unique.UniqueIDRepo
+	searchChangeRepo search_sync.SearchChangeRepo
 }
 
 // NewQuestionRepo new repository
 func NewQuestionRepo(
 	data *data.Data,
 	uniqueIDRepo unique.UniqueIDRepo,
+	searchChangeRepo search_sync.SearchChangeRepo,
 ) questioncommon.QuestionRepo {
 	return &questionRepo{
-		data:         data,
-		uniqueIDRepo: uniqueIDRepo,
+		data:             data,
+		uniqueIDRepo:     uniqueIDRepo,
+		searchChangeRepo: searchChangeRepo,
 	}
 }
 
@@ -98,7 +102,11 @@ func (qr *questionRepo) UpdateQuestion(ctx context.Context, question *entity.Que
 	if handler.GetEnableShortID(ctx) {
 		question.ID = uid.EnShortID(question.ID)
 	}
-	_ = qr.UpdateSearch(ctx, question.ID)
+	if slices.Contains(Cols, "status") || slices.Contains(Cols, "show") || slices.Contains(Cols, "title") {
+		_ = qr.updateSearchWithAnswers(ctx, question.ID)
+	} else {
+		_ = qr.UpdateSearch(ctx, question.ID)
+	}
 	return
 }
 
@@ -153,7 +161,7 @@ func (qr *questionRepo) UpdateQuestionStatus(ctx context.Context, questionID str
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-	_ = qr.UpdateSearch(ctx, questionID)
+	_ = qr.updateSearchWithAnswers(ctx, questionID)
 	return nil
 }
 
@@ -163,7 +171,7 @@ func (qr *questionRepo) UpdateQuestionStatusWithOutUpdateTime(ctx context.Contex
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-	_ = qr.UpdateSearch(ctx, question.ID)
+	_ = qr.updateSearchWithAnswers(ctx, question.ID)
 	return nil
 }
 
@@ -198,7 +206,7 @@ func (qr *questionRepo) RecoverQuestion(ctx context.Context, questionID string)
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-	_ = qr.UpdateSearch(ctx, questionID)
+	_ = qr.updateSearchWithAnswers(ctx, questionID)
 	return nil
 }
 
@@ -208,6 +216,7 @@ func (qr *questionRepo) UpdateQuestionOperation(ctx context.Context, question *e
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	_ = qr.updateSearchWithAnswers(ctx, question.ID)
 	return nil
 }
 
@@ -393,7 +402,7 @@ func (qr *questionRepo) SitemapQuestions(ctx context.Context, page, pageSize int
 
 // GetQuestionPage query question page
 func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
-	tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
+	tagIDs, hierarchicalTagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
 	questionList []*entity.Question, total int64, err error) {
 	questionList = make([]*entity.Question, 0)
 	session := qr.data.DB.Context(ctx)
@@ -411,6 +420,11 @@ func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
 		session.In("tag_rel.tag_id", tagIDs)
 		session.And("tag_rel.status = ?", entity.TagRelStatusAvailable)
 	}
+	if len(hierarchicalTagIDs) > 0 {
+		session.Join("INNER", "question_hierarchical_tag_rel", "question.id = question_hierarchical_tag_rel.question_id")
+		session.In("question_hierarchical_tag_rel.hierarchical_tag_id", hierarchicalTagIDs)
+		session.And("question_hierarchical_tag_rel.status = ?", entity.QuestionHierarchicalTagRelStatusAvailable)
+	}
 	if len(userID) > 0 {
 		session.And("question.user_id = ?", userID)
 		if !showHidden {
@@ -580,58 +594,15 @@ func (qr *questionRepo) AdminQuestionPage(ctx context.Context, search *schema.Ad
 	return rows, count, nil
 }
 
-// UpdateSearch update search, if search plugin not enable, do nothing
+// UpdateSearch records the question is changed, the search plugins sync it in background
 func (qr *questionRepo) UpdateSearch(ctx context.Context, questionID string) (err error) {
-	// check search plugin
-	var s plugin.Search
-	_ = plugin.CallSearch(func(search plugin.Search) error {
-		s = search
-		return nil
-	})
-	if s == nil {
-		return
-	}
-	questionID = uid.DeShortID(questionID)
-	question, exist, err := qr.GetQuestion(ctx, questionID)
-	if !exist {
-		return
-	}
-	if err != nil {
-		return err
-	}
+	return qr.searchChangeRepo.AddChanges(ctx, questionID)
+}
 
-	// get tags
-	var (
-		tagListList = make([]*entity.TagRel, 0)
-		tags        = make([]string, 0)
-	)
-	session := qr.data.DB.Context(ctx).Where("object_id = ?", questionID)
-	session.Where("status = ?", entity.TagRelStatusAvailable)
-	err = session.Find(&tagListList)
-	if err != nil {
-		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
-	}
-	for _, tag := range tagListList {
-		tags = append(tags, tag.TagID)
-	}
-	content := &plugin.SearchContent{
-		ObjectID:    questionID,
-		Title:       question.Title,
-		Type:        constant.QuestionObjectType,
-		Content:     question.OriginalText,
-		Answers:     int64(question.AnswerCount),
-		Status:      plugin.SearchContentStatus(question.Status),
-		Tags:        tags,
-		QuestionID:  questionID,
-		UserID:      question.UserID,
-		Views:       int64(question.ViewCount),
-		Created:     question.CreatedAt.Unix(),
-		Active:      question.UpdatedAt.Unix(),
-		Score:       int64(question.VoteCount),
-		HasAccepted: question.AcceptedAnswerID != "" && question.AcceptedAnswerID != "0",
-	}
-	err = s.UpdateContent(ctx, content)
-	return
+// updateSearchWithAnswers records the question and its answers are changed,
+// the answers follow the status and the visibility of the question in search
+func (qr *questionRepo) updateSearchWithAnswers(ctx context.Context, questionIDs ...string) (err error) {
+	return qr.searchChangeRepo.AddQuestionChanges(ctx, questionIDs...)
 }
 
 func (qr *questionRepo) RemoveAllUserQuestion(ctx context.Context, userID string) (err error) {
@@ -661,9 +632,7 @@ func (qr *questionRepo) RemoveAllUserQuestion(ctx context.Context, userID string
 	}
 
 	// update search content
-	for _, id := range questionIDs {
-		_ = qr.UpdateSearch(ctx, id)
-	}
+	_ = qr.updateSearchWithAnswers(ctx, questionIDs...)
 	return nil
 }
 
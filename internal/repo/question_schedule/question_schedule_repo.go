@@ -0,0 +1,192 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/question_schedule"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// questionScheduleRepo question schedule repository
+type questionScheduleRepo struct {
+	data *data.Data
+}
+
+// NewQuestionScheduleRepo new repository
+func NewQuestionScheduleRepo(data *data.Data) question_schedule.QuestionScheduleRepo {
+	return &questionScheduleRepo{
+		data: data,
+	}
+}
+
+// AddSchedule add question schedule
+func (qr *questionScheduleRepo) AddSchedule(ctx context.Context, schedule *entity.QuestionSchedule) (err error) {
+	_, err = qr.data.DB.Context(ctx).Insert(schedule)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDueSchedules get the pending schedules whose publish time is before the time, the earliest first
+func (qr *questionScheduleRepo) GetDueSchedules(ctx context.Context, before time.Time, limit int) (
+	schedules []*entity.QuestionSchedule, err error) {
+	schedules = make([]*entity.QuestionSchedule, 0)
+	err = qr.data.DB.Context(ctx).Where("status = ?", entity.QuestionScheduleStatusPending).
+		And("publish_at <= ?", before).Asc("publish_at").Limit(limit).Find(&schedules)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateScheduleStatus update question schedule status
+func (qr *questionScheduleRepo) UpdateScheduleStatus(ctx context.Context, id string, status int) (err error) {
+	_, err = qr.data.DB.Context(ctx).ID(id).Cols("status").Update(&entity.QuestionSchedule{Status: status})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// PublishQuestion publish the scheduled question and finish its schedule in one transaction. The question is
+// only updated while it is still scheduled, published is false if it was deleted or published by someone else.
+// enqueue adds the messages announcing the question in the same transaction.
+func (qr *questionScheduleRepo) PublishQuestion(ctx context.Context, scheduleID string, question *entity.Question,
+	enqueue func(session *xorm.Session) error) (published bool, err error) {
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		affected, err := session.ID(question.ID).Where("status = ?", entity.QuestionStatusScheduled).
+			Cols("status", "created_at", "post_update_time", "parsed_text").Update(question)
+		if err != nil {
+			return nil, err
+		}
+		if affected != 1 {
+			return nil, nil
+		}
+		_, err = session.ID(scheduleID).Cols("status").
+			Update(&entity.QuestionSchedule{Status: entity.QuestionScheduleStatusPublished})
+		if err != nil {
+			return nil, err
+		}
+		if err = enqueue(session); err != nil {
+			return nil, err
+		}
+		published = true
+		return nil, nil
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return published, nil
+}
+
+// AddAutoCloseRule add auto close rule
+func (qr *questionScheduleRepo) AddAutoCloseRule(ctx context.Context, rule *entity.QuestionAutoCloseRule) (err error) {
+	_, err = qr.data.DB.Context(ctx).Insert(rule)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateAutoCloseRule update auto close rule
+func (qr *questionScheduleRepo) UpdateAutoCloseRule(ctx context.Context, rule *entity.QuestionAutoCloseRule) (err error) {
+	_, err = qr.data.DB.Context(ctx).ID(rule.ID).
+		Cols("user_id", "name", "tag_id", "inactive_days", "no_answer", "close_type", "close_msg", "status").
+		Update(rule)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// DeleteAutoCloseRule delete auto close rule
+func (qr *questionScheduleRepo) DeleteAutoCloseRule(ctx context.Context, id string) (err error) {
+	_, err = qr.data.DB.Context(ctx).ID(id).Delete(&entity.QuestionAutoCloseRule{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAutoCloseRule get auto close rule
+func (qr *questionScheduleRepo) GetAutoCloseRule(ctx context.Context, id string) (
+	rule *entity.QuestionAutoCloseRule, exist bool, err error) {
+	rule = &entity.QuestionAutoCloseRule{}
+	exist, err = qr.data.DB.Context(ctx).ID(id).Get(rule)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAutoCloseRuleList get all auto close rules
+func (qr *questionScheduleRepo) GetAutoCloseRuleList(ctx context.Context) (
+	rules []*entity.QuestionAutoCloseRule, err error) {
+	rules = make([]*entity.QuestionAutoCloseRule, 0)
+	err = qr.data.DB.Context(ctx).Asc("created_at").Find(&rules)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetActiveAutoCloseRules get the auto close rules which are active
+func (qr *questionScheduleRepo) GetActiveAutoCloseRules(ctx context.Context) (
+	rules []*entity.QuestionAutoCloseRule, err error) {
+	rules = make([]*entity.QuestionAutoCloseRule, 0)
+	err = qr.data.DB.Context(ctx).Where("status = ?", entity.QuestionAutoCloseRuleStatusActive).Find(&rules)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetInactiveQuestions get the open questions matching the rule which have no activity since the time,
+// the pinned questions are never closed automatically
+func (qr *questionScheduleRepo) GetInactiveQuestions(ctx context.Context, rule *entity.QuestionAutoCloseRule,
+	before time.Time, limit int) (questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	session := qr.data.DB.Context(ctx).Select("question.*").
+		Where("question.status = ?", entity.QuestionStatusAvailable).
+		And("question.pin = ?", entity.QuestionUnPin).
+		And("question.post_update_time < ?", before)
+	if rule.NoAnswer {
+		session.And("question.answer_count = 0")
+	}
+	if len(rule.TagID) > 0 && rule.TagID != "0" {
+		session.Join("INNER", "tag_rel", "question.id = tag_rel.object_id").
+			And("tag_rel.tag_id = ?", rule.TagID).
+			And("tag_rel.status = ?", entity.TagRelStatusAvailable)
+	}
+	err = session.Asc("question.post_update_time").Limit(limit).Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,209 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package queue_message
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/queue_message"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// queueMessageRepo queue message repository
+type queueMessageRepo struct {
+	data *data.Data
+}
+
+// NewQueueMessageRepo new repository
+func NewQueueMessageRepo(data *data.Data) queue_message.QueueMessageRepo {
+	return &queueMessageRepo{
+		data: data,
+	}
+}
+
+// AddMessages add the messages to the outbox, they are added all together or not at all
+func (qr *queueMessageRepo) AddMessages(ctx context.Context, messages ...*entity.QueueMessage) (err error) {
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		return nil, qr.AddMessagesWithSession(ctx, session, messages...)
+	})
+	return err
+}
+
+// AddMessagesWithSession add the messages to the outbox in the transaction of the caller,
+// so that they are only handled if the changes they come from are committed
+func (qr *queueMessageRepo) AddMessagesWithSession(ctx context.Context, session *xorm.Session,
+	messages ...*entity.QueueMessage) (err error) {
+	session = session.Context(ctx)
+	for _, message := range messages {
+		if _, err = session.Insert(message); err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	return nil
+}
+
+// ClaimDueMessages every message is claimed by a conditional update, so that only one worker gets it
+func (qr *queueMessageRepo) ClaimDueMessages(ctx context.Context, queue string, limit int, lease time.Duration) (
+	messages []*entity.QueueMessage, err error) {
+	now := time.Now()
+	candidates := make([]*entity.QueueMessage, 0)
+	err = qr.data.DB.Context(ctx).Where("queue = ?", queue).
+		And(builder.Or(dueCond(entity.QueueMessageStatusPending, now), dueCond(entity.QueueMessageStatusProcessing, now))).
+		And(headOfOrderCond()).
+		Asc("id").Limit(limit).Find(&candidates)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	messages = make([]*entity.QueueMessage, 0, len(candidates))
+	for _, candidate := range candidates {
+		affected, err := qr.data.DB.Context(ctx).ID(candidate.ID).And(dueCond(candidate.Status, now)).
+			Cols("status", "locked_until").
+			Update(&entity.QueueMessage{Status: entity.QueueMessageStatusProcessing, LockedUntil: now.Add(lease)})
+		if err != nil {
+			return messages, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if affected == 0 {
+			continue
+		}
+		messages = append(messages, candidate)
+	}
+	return messages, nil
+}
+
+// dueCond pending messages are due when their next run time comes, processing ones when their lease expires
+func dueCond(status int, now time.Time) builder.Cond {
+	if status == entity.QueueMessageStatusProcessing {
+		return builder.Eq{"status": status}.And(builder.Lt{"locked_until": now})
+	}
+	return builder.Eq{"status": status}.And(builder.Lte{"next_run_at": now})
+}
+
+// headOfOrderCond messages with an order key wait for the earlier ones with the same key, except the dead ones
+func headOfOrderCond() builder.Cond {
+	return builder.Expr("(order_key = '' OR NOT EXISTS (SELECT 1 FROM queue_message prior"+
+		" WHERE prior.queue = queue_message.queue AND prior.order_key = queue_message.order_key"+
+		" AND prior.id < queue_message.id AND prior.status IN (?, ?)))",
+		entity.QueueMessageStatusPending, entity.QueueMessageStatusProcessing)
+}
+
+// DeleteMessage delete the handled message, its receipts are useless once it can not be retried
+func (qr *queueMessageRepo) DeleteMessage(ctx context.Context, id int64) (err error) {
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (any, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where("message_id = ?", id).Delete(&entity.QueueMessageReceipt{}); err != nil {
+			return nil, err
+		}
+		return session.ID(id).Delete(&entity.QueueMessage{})
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateMessageRetry put the failed message back to the queue
+func (qr *queueMessageRepo) UpdateMessageRetry(ctx context.Context, id int64, attempts int, nextRunAt time.Time,
+	lastError string) (err error) {
+	_, err = qr.data.DB.Context(ctx).ID(id).Cols("status", "attempts", "next_run_at", "last_error").
+		Update(&entity.QueueMessage{
+			Status:    entity.QueueMessageStatusPending,
+			Attempts:  attempts,
+			NextRunAt: nextRunAt,
+			LastError: lastError,
+		})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateMessageDead move the failed message to dead-letter
+func (qr *queueMessageRepo) UpdateMessageDead(ctx context.Context, id int64, attempts int, lastError string) (err error) {
+	_, err = qr.data.DB.Context(ctx).ID(id).Cols("status", "attempts", "last_error").
+		Update(&entity.QueueMessage{
+			Status:    entity.QueueMessageStatusDead,
+			Attempts:  attempts,
+			LastError: lastError,
+		})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetMessagePage get messages by page, the latest first
+func (qr *queueMessageRepo) GetMessagePage(ctx context.Context, page, pageSize int, queue string, status int) (
+	messages []*entity.QueueMessage, total int64, err error) {
+	messages = make([]*entity.QueueMessage, 0)
+	session := qr.data.DB.Context(ctx).Desc("id")
+	if len(queue) > 0 {
+		session.Where("queue = ?", queue)
+	}
+	if status > 0 {
+		session.And("status = ?", status)
+	}
+	total, err = pager.Help(page, pageSize, &messages, &entity.QueueMessage{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ReplayMessages reset the attempts so that the message gets the whole retry budget again
+func (qr *queueMessageRepo) ReplayMessages(ctx context.Context, ids []int64) (affected int64, err error) {
+	affected, err = qr.data.DB.Context(ctx).In("id", ids).
+		In("status", []int{entity.QueueMessageStatusPending, entity.QueueMessageStatusDead}).
+		Cols("status", "attempts", "next_run_at", "last_error").
+		Update(&entity.QueueMessage{
+			Status:    entity.QueueMessageStatusPending,
+			NextRunAt: time.Now(),
+		})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddReceipt records in the session that the effect is applied by the handler of the queue message in ctx.
+// added is false if an earlier attempt of the message has applied it, the caller should skip the effect then.
+// It is always true if ctx does not come from a queue handler.
+func AddReceipt(ctx context.Context, session *xorm.Session, effect string) (added bool, err error) {
+	messageID, key, ok := queue_message.IdempotencyKey(ctx)
+	if !ok {
+		return true, nil
+	}
+	receipt := &entity.QueueMessageReceipt{MessageID: messageID, EffectKey: key + ":" + effect}
+	exist, err := session.Exist(&entity.QueueMessageReceipt{EffectKey: receipt.EffectKey})
+	if err != nil || exist {
+		return false, err
+	}
+	if _, err = session.Insert(receipt); err != nil {
+		return false, err
+	}
+	return true, nil
+}
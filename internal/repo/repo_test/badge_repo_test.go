@@ -0,0 +1,139 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/badge"
+	"github.com/apache/answer/internal/repo/badge_group"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_badgeRuleRepo_AnswerCount(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	badgeRuleRepo := badge.NewBadgeRuleRepo(testDataSource)
+	userID := "1000000000000000991"
+	for i := 0; i < 3; i++ {
+		id, err := uniqueIDRepo.GenUniqueIDStr(context.TODO(), entity.Answer{}.TableName())
+		require.NoError(t, err)
+		adopted := schema.AnswerAcceptedFailed
+		if i == 0 {
+			adopted = schema.AnswerAcceptedEnable
+		}
+		_, err = testDataSource.DB.Insert(&entity.Answer{
+			ID:           id,
+			QuestionID:   "10010000000000001",
+			UserID:       userID,
+			OriginalText: "answer",
+			ParsedText:   "answer",
+			Status:       entity.AnswerStatusAvailable,
+			Accepted:     adopted,
+		})
+		require.NoError(t, err)
+	}
+
+	rule := &schema.BadgeRule{Metric: schema.BadgeRuleMetricAnswerCount, Threshold: 3}
+	value, err := badgeRuleRepo.GetRuleMetric(context.TODO(), rule, userID, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), value)
+
+	awards, err := badgeRuleRepo.GetRuleCandidates(context.TODO(), rule)
+	assert.NoError(t, err)
+	userIDs := make([]string, 0)
+	for _, award := range awards {
+		userIDs = append(userIDs, award.UserID)
+	}
+	assert.Contains(t, userIDs, userID)
+
+	rule = &schema.BadgeRule{Metric: schema.BadgeRuleMetricAcceptedAnswerCount, Threshold: 2}
+	value, err = badgeRuleRepo.GetRuleMetric(context.TODO(), rule, userID, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+
+	awards, err = badgeRuleRepo.GetRuleCandidates(context.TODO(), rule)
+	assert.NoError(t, err)
+	for _, award := range awards {
+		assert.NotEqual(t, userID, award.UserID)
+	}
+}
+
+func Test_badgeRuleRepo_PostStreakDays(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	badgeRuleRepo := badge.NewBadgeRuleRepo(testDataSource)
+	userID := "1000000000000000992"
+	now := time.Now()
+	noon := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, now.Location())
+	addAnswer := func(createdAt time.Time) {
+		id, err := uniqueIDRepo.GenUniqueIDStr(context.TODO(), entity.Answer{}.TableName())
+		require.NoError(t, err)
+		_, err = testDataSource.DB.NoAutoTime().Insert(&entity.Answer{
+			ID:           id,
+			QuestionID:   "10010000000000001",
+			UserID:       userID,
+			OriginalText: "answer",
+			ParsedText:   "answer",
+			Status:       entity.AnswerStatusAvailable,
+			CreatedAt:    createdAt,
+			UpdatedAt:    createdAt,
+		})
+		require.NoError(t, err)
+	}
+	addAnswer(noon.AddDate(0, 0, -1))
+	addAnswer(noon.AddDate(0, 0, -2))
+	addAnswer(noon.AddDate(0, 0, -4))
+
+	// nothing is posted today yet, the streak ending yesterday still counts
+	rule := &schema.BadgeRule{Metric: schema.BadgeRuleMetricPostStreakDays, Threshold: 5}
+	value, err := badgeRuleRepo.GetRuleMetric(context.TODO(), rule, userID, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), value)
+
+	addAnswer(noon)
+	value, err = badgeRuleRepo.GetRuleMetric(context.TODO(), rule, userID, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), value)
+}
+
+func Test_badgeGroupRepo_CRUD(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	badgeGroupRepo := badge_group.NewBadgeGroupRepo(testDataSource, uniqueIDRepo)
+	group := &entity.BadgeGroup{Name: "community"}
+	require.NoError(t, badgeGroupRepo.AddGroup(context.TODO(), group))
+	assert.NotEmpty(t, group.ID)
+
+	group.Name = "moderation"
+	require.NoError(t, badgeGroupRepo.UpdateGroup(context.TODO(), group))
+	got, exist, err := badgeGroupRepo.GetGroup(context.TODO(), group.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "moderation", got.Name)
+
+	require.NoError(t, badgeGroupRepo.DeleteGroup(context.TODO(), group.ID))
+	_, exist, err = badgeGroupRepo.GetGroup(context.TODO(), group.ID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+}
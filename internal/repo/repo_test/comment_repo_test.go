@@ -26,6 +26,7 @@ import (
 	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/repo/comment"
+	"github.com/apache/answer/internal/repo/search_sync"
 	"github.com/apache/answer/internal/repo/unique"
 	commentService "github.com/apache/answer/internal/service/comment"
 	"github.com/stretchr/testify/assert"
@@ -45,7 +46,7 @@ func buildCommentEntity() *entity.Comment {
 
 func Test_commentRepo_AddComment(t *testing.T) {
 	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
-	commentRepo := comment.NewCommentRepo(testDataSource, uniqueIDRepo)
+	commentRepo := comment.NewCommentRepo(testDataSource, uniqueIDRepo, search_sync.NewSearchChangeRepo(testDataSource))
 	testCommentEntity := buildCommentEntity()
 	err := commentRepo.AddComment(context.TODO(), testCommentEntity)
 	assert.NoError(t, err)
@@ -56,7 +57,7 @@ func Test_commentRepo_AddComment(t *testing.T) {
 
 func Test_commentRepo_GetCommentPage(t *testing.T) {
 	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
-	commentRepo := comment.NewCommentRepo(testDataSource, uniqueIDRepo)
+	commentRepo := comment.NewCommentRepo(testDataSource, uniqueIDRepo, search_sync.NewSearchChangeRepo(testDataSource))
 	testCommentEntity := buildCommentEntity()
 	err := commentRepo.AddComment(context.TODO(), testCommentEntity)
 	assert.NoError(t, err)
@@ -77,8 +78,8 @@ func Test_commentRepo_GetCommentPage(t *testing.T) {
 
 func Test_commentRepo_UpdateComment(t *testing.T) {
 	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
-	commentRepo := comment.NewCommentRepo(testDataSource, uniqueIDRepo)
-	commonCommentRepo := comment.NewCommentCommonRepo(testDataSource, uniqueIDRepo)
+	commentRepo := comment.NewCommentRepo(testDataSource, uniqueIDRepo, search_sync.NewSearchChangeRepo(testDataSource))
+	commonCommentRepo := comment.NewCommentCommonRepo(testDataSource, uniqueIDRepo, search_sync.NewSearchChangeRepo(testDataSource))
 	testCommentEntity := buildCommentEntity()
 	err := commentRepo.AddComment(context.TODO(), testCommentEntity)
 	assert.NoError(t, err)
@@ -98,7 +99,7 @@ func Test_commentRepo_UpdateComment(t *testing.T) {
 
 func Test_commentRepo_CannotGetDeletedComment(t *testing.T) {
 	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
-	commentRepo := comment.NewCommentRepo(testDataSource, uniqueIDRepo)
+	commentRepo := comment.NewCommentRepo(testDataSource, uniqueIDRepo, search_sync.NewSearchChangeRepo(testDataSource))
 	testCommentEntity := buildCommentEntity()
 
 	err := commentRepo.AddComment(context.TODO(), testCommentEntity)
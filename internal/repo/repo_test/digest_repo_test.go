@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/digest"
+	"github.com/apache/answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_digestRepo_GetDueConfigs(t *testing.T) {
+	ctx := context.TODO()
+	digestRepo := digest.NewDigestRepo(testDataSource)
+	now := time.Now()
+
+	for _, userID := range []string{"1000000000000009301", "1000000000000009302"} {
+		require.NoError(t, digestRepo.SaveConfig(ctx, &entity.UserDigestConfig{UserID: userID,
+			Frequency: entity.DigestFrequencyDaily, Hour: 8, NextSendAt: now.Add(-time.Minute)}))
+	}
+	// only the first user turns on the digest
+	_, err := testDataSource.DB.Insert(&entity.UserNotificationConfig{UserID: "1000000000000009301",
+		Source: string(constant.DigestSource), Channels: `[{"key":"email","enable":true}]`, Enabled: true})
+	require.NoError(t, err)
+
+	configs, err := digestRepo.GetDueConfigs(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "1000000000000009301", configs[0].UserID)
+
+	require.NoError(t, digestRepo.UpdateSendTime(ctx, "1000000000000009301", now, now.Add(24*time.Hour)))
+	configs, err = digestRepo.GetDueConfigs(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, configs)
+
+	conf, exist, err := digestRepo.GetConfig(ctx, "1000000000000009301")
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, now.Unix(), conf.LastSentAt.Unix())
+
+	conf.Frequency = entity.DigestFrequencyWeekly
+	require.NoError(t, digestRepo.SaveConfig(ctx, conf))
+	conf, _, err = digestRepo.GetConfig(ctx, "1000000000000009301")
+	require.NoError(t, err)
+	assert.Equal(t, entity.DigestFrequencyWeekly, conf.Frequency)
+	assert.Equal(t, now.Unix(), conf.LastSentAt.Unix())
+}
+
+func Test_digestRepo_GetContents(t *testing.T) {
+	ctx := context.TODO()
+	digestRepo := digest.NewDigestRepo(testDataSource)
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+
+	_, err := testDataSource.DB.Insert([]*entity.Question{
+		{ID: "10010000000009311", UserID: "1000000000000009311", Title: "popular", OriginalText: "a",
+			ParsedText: "a", Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow,
+			VoteCount: 5, AnswerCount: 1, CreatedAt: now, PostUpdateTime: now},
+		{ID: "10010000000009312", UserID: "1000000000000009311", Title: "unanswered", OriginalText: "a",
+			ParsedText: "a", Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow, CreatedAt: now, PostUpdateTime: now},
+		{ID: "10010000000009313", UserID: "1000000000000009312", Title: "own", OriginalText: "a",
+			ParsedText: "a", Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow, CreatedAt: now, PostUpdateTime: now},
+		{ID: "10010000000009314", UserID: "1000000000000009311", Title: "answered before", OriginalText: "a",
+			ParsedText: "a", Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow,
+			AnswerCount: 1, CreatedAt: now, PostUpdateTime: now},
+	})
+	require.NoError(t, err)
+	tagRels := make([]*entity.TagRel, 0)
+	for _, questionID := range []string{"10010000000009311", "10010000000009312", "10010000000009313", "10010000000009314"} {
+		tagRels = append(tagRels, &entity.TagRel{ObjectID: questionID, TagID: "10300000000009311",
+			Status: entity.TagRelStatusAvailable})
+	}
+	_, err = testDataSource.DB.Insert(tagRels)
+	require.NoError(t, err)
+	_, err = testDataSource.DB.Insert(&entity.Answer{ID: "10020000000009311", QuestionID: "10010000000009314",
+		UserID: "1000000000000009312", OriginalText: "a", ParsedText: "a", Status: entity.AnswerStatusAvailable})
+	require.NoError(t, err)
+
+	tagIDs, err := digestRepo.GetAnsweredTagIDs(ctx, "1000000000000009312", 5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10300000000009311"}, tagIDs)
+
+	questions, err := digestRepo.GetQuestionsByTags(ctx, tagIDs, yesterday, "1000000000000009312", false, 10)
+	require.NoError(t, err)
+	require.Len(t, questions, 3)
+	assert.Equal(t, "10010000000009311", questions[0].ID)
+
+	questions, err = digestRepo.GetQuestionsByTags(ctx, tagIDs, yesterday, "1000000000000009312", true, 10)
+	require.NoError(t, err)
+	require.Len(t, questions, 1)
+	assert.Equal(t, "10010000000009312", questions[0].ID)
+
+	_, err = testDataSource.DB.Insert([]*entity.Notification{
+		{UserID: "1000000000000009312", ObjectID: "10010000000009311", Content: "{}",
+			Type: schema.NotificationTypeInbox, IsRead: schema.NotificationNotRead,
+			Status: schema.NotificationStatusNormal, UpdatedAt: now},
+		{UserID: "1000000000000009312", ObjectID: "10010000000009312", Content: "{}",
+			Type: schema.NotificationTypeInbox, IsRead: schema.NotificationRead,
+			Status: schema.NotificationStatusNormal, UpdatedAt: now},
+	})
+	require.NoError(t, err)
+	notifications, err := digestRepo.GetUnreadNotifications(ctx, "1000000000000009312", yesterday, 10)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "10010000000009311", notifications[0].ObjectID)
+}
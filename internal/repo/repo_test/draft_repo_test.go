@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/draft"
+	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/search_sync"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/schema"
+	draftService "github.com/apache/answer/internal/service/draft"
+	"github.com/apache/answer/internal/service/event_queue"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm"
+)
+
+func Test_draftRepo_Draft(t *testing.T) {
+	draftRepo := draft.NewDraftRepo(testDataSource)
+	userID := uid.ID().String()
+	d := &entity.Draft{
+		ID:        uid.ID().String(),
+		UserID:    userID,
+		DraftType: entity.DraftTypeQuestion,
+		ObjectID:  "0",
+		Title:     "how to write a draft",
+		Content:   "content",
+		Tags:      `["go"]`,
+		ExpiredAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, draftRepo.AddDraft(context.TODO(), d))
+
+	d.Content = "new content"
+	require.NoError(t, draftRepo.UpdateDraft(context.TODO(), d))
+	got, exist, err := draftRepo.GetDraft(context.TODO(), userID, entity.DraftTypeQuestion, "0")
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, "new content", got.Content)
+	assert.Equal(t, `["go"]`, got.Tags)
+
+	_, exist, err = draftRepo.GetDraft(context.TODO(), userID, entity.DraftTypeAnswer, "0")
+	require.NoError(t, err)
+	assert.False(t, exist)
+
+	count, err := draftRepo.CountUserDrafts(context.TODO(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	drafts, total, err := draftRepo.GetDraftPage(context.TODO(), 1, 10, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, drafts, 1)
+
+	require.NoError(t, draftRepo.RemoveDraft(context.TODO(), userID, entity.DraftTypeQuestion, "0"))
+	_, exist, err = draftRepo.GetDraft(context.TODO(), userID, entity.DraftTypeQuestion, "0")
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_draftRepo_RemoveExpiredDrafts(t *testing.T) {
+	draftRepo := draft.NewDraftRepo(testDataSource)
+	userID := uid.ID().String()
+	expired := &entity.Draft{
+		ID:        uid.ID().String(),
+		UserID:    userID,
+		DraftType: entity.DraftTypeAnswer,
+		ObjectID:  "10010000000000001",
+		ExpiredAt: time.Now().Add(-time.Hour),
+	}
+	available := &entity.Draft{
+		ID:        uid.ID().String(),
+		UserID:    userID,
+		DraftType: entity.DraftTypeAnswer,
+		ObjectID:  "10010000000000002",
+		ExpiredAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, draftRepo.AddDraft(context.TODO(), expired))
+	require.NoError(t, draftRepo.AddDraft(context.TODO(), available))
+
+	count, err := draftRepo.RemoveExpiredDrafts(context.TODO(), time.Now())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, count, int64(1))
+	_, exist, err := draftRepo.GetDraft(context.TODO(), userID, entity.DraftTypeAnswer, expired.ObjectID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+	_, exist, err = draftRepo.GetDraft(context.TODO(), userID, entity.DraftTypeAnswer, available.ObjectID)
+	require.NoError(t, err)
+	assert.True(t, exist)
+}
+
+type noopEventQueue struct{}
+
+func (noopEventQueue) Send(ctx context.Context, msg *schema.EventMsg) error { return nil }
+
+func (noopEventQueue) SendWithSession(ctx context.Context, session *xorm.Session, msg *schema.EventMsg) error {
+	return nil
+}
+
+func (noopEventQueue) Subscribe(subscriber *event_queue.Subscriber) {}
+
+func Test_draftService_HiddenQuestion(t *testing.T) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	questionRepo := question.NewQuestionRepo(testDataSource, uniqueIDRepo, search_sync.NewSearchChangeRepo(testDataSource))
+	ds := draftService.NewDraftService(draft.NewDraftRepo(testDataSource), questionRepo, nil, noopEventQueue{})
+
+	authorID := uid.ID().String()
+	q := &entity.Question{
+		UserID:       authorID,
+		Title:        "a pending question for the drafts",
+		OriginalText: "content",
+		ParsedText:   "content",
+		Status:       entity.QuestionStatusPending,
+		Show:         entity.QuestionShow,
+	}
+	require.NoError(t, questionRepo.AddQuestion(context.TODO(), q))
+
+	// the others can not draft an answer for the pending question
+	_, err := ds.SaveDraft(context.TODO(), &schema.SaveDraftReq{
+		Type: entity.DraftTypeAnswer, ObjectID: q.ID, Content: "answer", UserID: uid.ID().String()})
+	assert.Error(t, err)
+
+	// the author and the users who can view it can
+	item, err := ds.SaveDraft(context.TODO(), &schema.SaveDraftReq{
+		Type: entity.DraftTypeAnswer, ObjectID: q.ID, Content: "answer", UserID: authorID})
+	require.NoError(t, err)
+	assert.Equal(t, q.Title, item.QuestionTitle)
+	moderatorID := uid.ID().String()
+	item, err = ds.SaveDraft(context.TODO(), &schema.SaveDraftReq{
+		Type: entity.DraftTypeAnswer, ObjectID: q.ID, Content: "answer", UserID: moderatorID, CanViewHidden: true})
+	require.NoError(t, err)
+	assert.False(t, item.ObjectRemoved)
+
+	// the moderator loses the permission, the title is not shown in the list of drafts
+	drafts, _, err := ds.GetDraftPage(context.TODO(), &schema.GetDraftPageReq{Page: 1, PageSize: 10, UserID: moderatorID})
+	require.NoError(t, err)
+	require.Len(t, drafts, 1)
+	assert.True(t, drafts[0].ObjectRemoved)
+	assert.Empty(t, drafts[0].QuestionTitle)
+}
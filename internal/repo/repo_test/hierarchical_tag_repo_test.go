@@ -0,0 +1,284 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/hierarchical_tag"
+	"github.com/apache/answer/internal/repo/role"
+	hierarchicalTagService "github.com/apache/answer/internal/service/hierarchical_tag"
+	roleService "github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addHierarchicalTagTree creates root > child > grandchild and another root, all slugs are prefixed
+func addHierarchicalTagTree(t *testing.T, prefix string) (root, child, grandchild, other *entity.HierarchicalTag) {
+	hierarchicalTagRepo := hierarchical_tag.NewHierarchicalTagRepo(testDataSource)
+	newTag := func(slug, parentID string) *entity.HierarchicalTag {
+		tag := &entity.HierarchicalTag{
+			ID:          uid.ID().String(),
+			Name:        prefix + slug,
+			SlugName:    prefix + slug,
+			DisplayName: prefix + slug,
+			ParentID:    parentID,
+			Status:      entity.HierarchicalTagStatusAvailable,
+		}
+		require.NoError(t, hierarchicalTagRepo.Create(context.TODO(), tag))
+		return tag
+	}
+	root = newTag("root", "")
+	child = newTag("child", root.ID)
+	grandchild = newTag("grandchild", child.ID)
+	other = newTag("other", "")
+	return
+}
+
+func Test_hierarchicalTagRepo_Move(t *testing.T) {
+	hierarchicalTagRepo := hierarchical_tag.NewHierarchicalTagRepo(testDataSource)
+	root, child, grandchild, other := addHierarchicalTagTree(t, "move-")
+	questionID := uid.ID().String()
+	require.NoError(t, hierarchicalTagRepo.CreateQuestionTagRel(context.TODO(), questionID, grandchild.ID))
+
+	err := hierarchicalTagRepo.Move(context.TODO(), child.ID, other.ID)
+	assert.NoError(t, err)
+
+	gotChild, exist, err := hierarchicalTagRepo.GetByID(context.TODO(), child.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, other.ID, gotChild.ParentID)
+	assert.Equal(t, 1, gotChild.Level)
+	assert.Equal(t, "#move-other#move-child", gotChild.Path)
+
+	gotGrandchild, exist, err := hierarchicalTagRepo.GetByID(context.TODO(), grandchild.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 2, gotGrandchild.Level)
+	assert.Equal(t, "#move-other#move-child#move-grandchild", gotGrandchild.Path)
+
+	rels, err := hierarchicalTagRepo.GetQuestionTagRels(context.TODO(), questionID)
+	assert.NoError(t, err)
+	require.Len(t, rels, 1)
+	assert.Equal(t, gotGrandchild.Path, rels[0].HierarchicalTagPath)
+
+	// moving back to the root level
+	err = hierarchicalTagRepo.Move(context.TODO(), child.ID, "")
+	assert.NoError(t, err)
+	gotChild, _, err = hierarchicalTagRepo.GetByID(context.TODO(), child.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "", gotChild.ParentID)
+	assert.Equal(t, 0, gotChild.Level)
+	assert.Equal(t, "#move-child", gotChild.Path)
+
+	// root is untouched
+	gotRoot, _, err := hierarchicalTagRepo.GetByID(context.TODO(), root.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "#move-root", gotRoot.Path)
+}
+
+func Test_hierarchicalTagRepo_MoveUnderDescendant(t *testing.T) {
+	hierarchicalTagRepo := hierarchical_tag.NewHierarchicalTagRepo(testDataSource)
+	root, child, grandchild, _ := addHierarchicalTagTree(t, "cycle-")
+
+	assert.Error(t, hierarchicalTagRepo.Move(context.TODO(), root.ID, grandchild.ID))
+	assert.Error(t, hierarchicalTagRepo.Move(context.TODO(), child.ID, child.ID))
+
+	gotRoot, _, err := hierarchicalTagRepo.GetByID(context.TODO(), root.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "", gotRoot.ParentID)
+	assert.Equal(t, "#cycle-root", gotRoot.Path)
+}
+
+func Test_hierarchicalTagRepo_Delete(t *testing.T) {
+	hierarchicalTagRepo := hierarchical_tag.NewHierarchicalTagRepo(testDataSource)
+	root, child, grandchild, other := addHierarchicalTagTree(t, "delete-")
+	questionID := uid.ID().String()
+	require.NoError(t, hierarchicalTagRepo.CreateQuestionTagRel(context.TODO(), questionID, grandchild.ID))
+
+	err := hierarchicalTagRepo.Delete(context.TODO(), child.ID)
+	assert.NoError(t, err)
+
+	for _, id := range []string{child.ID, grandchild.ID} {
+		_, exist, err := hierarchicalTagRepo.GetByID(context.TODO(), id)
+		assert.NoError(t, err)
+		assert.False(t, exist)
+	}
+	for _, id := range []string{root.ID, other.ID} {
+		_, exist, err := hierarchicalTagRepo.GetByID(context.TODO(), id)
+		assert.NoError(t, err)
+		assert.True(t, exist)
+	}
+
+	rels, err := hierarchicalTagRepo.GetQuestionTagRels(context.TODO(), questionID)
+	assert.NoError(t, err)
+	assert.Len(t, rels, 0)
+}
+
+func Test_hierarchicalTagRepo_UpdateRewritesSubtreePath(t *testing.T) {
+	hierarchicalTagRepo := hierarchical_tag.NewHierarchicalTagRepo(testDataSource)
+	_, child, grandchild, _ := addHierarchicalTagTree(t, "rename-")
+
+	child.DisplayName = "rename-renamed"
+	err := hierarchicalTagRepo.Update(context.TODO(), child)
+	assert.NoError(t, err)
+
+	gotGrandchild, _, err := hierarchicalTagRepo.GetByID(context.TODO(), grandchild.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "#rename-root#rename-renamed#rename-grandchild", gotGrandchild.Path)
+}
+
+func Test_hierarchicalTagRepo_Import(t *testing.T) {
+	hierarchicalTagRepo := hierarchical_tag.NewHierarchicalTagRepo(testDataSource)
+	root, child, grandchild, _ := addHierarchicalTagTree(t, "import-")
+	require.NoError(t, hierarchicalTagRepo.Delete(context.TODO(), grandchild.ID))
+
+	// a new tag at the root level, the child moved under it and the deleted grandchild revived
+	newRoot := &entity.HierarchicalTag{
+		ID:          uid.ID().String(),
+		Name:        "import-new",
+		SlugName:    "import-new",
+		DisplayName: "import-new",
+	}
+	child.ParentID = newRoot.ID
+	grandchild.DisplayName = "import-revived"
+	err := hierarchicalTagRepo.Import(context.TODO(), []*entity.HierarchicalTag{newRoot, child, grandchild})
+	assert.NoError(t, err)
+
+	gotGrandchild, exist, err := hierarchicalTagRepo.GetByID(context.TODO(), grandchild.ID)
+	assert.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 2, gotGrandchild.Level)
+	assert.Equal(t, "#import-new#import-child#import-revived", gotGrandchild.Path)
+
+	tags, err := hierarchicalTagRepo.GetAll(context.TODO())
+	assert.NoError(t, err)
+	paths := make(map[string]string)
+	for _, tag := range tags {
+		paths[tag.ID] = tag.Path
+	}
+	assert.Equal(t, "#import-root", paths[root.ID])
+	assert.Equal(t, "#import-new#import-child", paths[child.ID])
+}
+
+func Test_hierarchicalTagRepo_GetByIDs(t *testing.T) {
+	hierarchicalTagRepo := hierarchical_tag.NewHierarchicalTagRepo(testDataSource)
+	root, child, grandchild, _ := addHierarchicalTagTree(t, "ids-")
+	require.NoError(t, hierarchicalTagRepo.Delete(context.TODO(), grandchild.ID))
+
+	tags, err := hierarchicalTagRepo.GetByIDs(context.TODO(), []string{root.ID, child.ID, grandchild.ID})
+	assert.NoError(t, err)
+	ids := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+	assert.ElementsMatch(t, []string{root.ID, child.ID}, ids)
+
+	tags, err = hierarchicalTagRepo.GetByIDs(context.TODO(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func Test_hierarchicalTagRepo_Reorder(t *testing.T) {
+	hierarchicalTagRepo := hierarchical_tag.NewHierarchicalTagRepo(testDataSource)
+	root, child, _, _ := addHierarchicalTagTree(t, "order-")
+	second := &entity.HierarchicalTag{
+		ID:          uid.ID().String(),
+		Name:        "order-second",
+		SlugName:    "order-second",
+		DisplayName: "order-second",
+		ParentID:    root.ID,
+		Status:      entity.HierarchicalTagStatusAvailable,
+	}
+	require.NoError(t, hierarchicalTagRepo.Create(context.TODO(), second))
+	// new tags are placed after their siblings
+	assert.Equal(t, child.SortOrder+1, second.SortOrder)
+
+	err := hierarchicalTagRepo.Reorder(context.TODO(), root.ID, []string{second.ID})
+	assert.NoError(t, err)
+	children, err := hierarchicalTagRepo.GetByParentID(context.TODO(), root.ID)
+	assert.NoError(t, err)
+	require.Len(t, children, 2)
+	assert.Equal(t, second.ID, children[0].ID)
+	assert.Equal(t, child.ID, children[1].ID)
+
+	// only siblings can be sorted together
+	assert.Error(t, hierarchicalTagRepo.Reorder(context.TODO(), root.ID, []string{root.ID}))
+}
+
+func Test_hierarchicalTagRepo_UpdatePermission(t *testing.T) {
+	hierarchicalTagRepo := hierarchical_tag.NewHierarchicalTagRepo(testDataSource)
+	root, child, _, _ := addHierarchicalTagTree(t, "permission-")
+
+	err := hierarchicalTagRepo.UpdatePermission(context.TODO(), root.ID, true, []int{2, 3}, []string{"1"})
+	assert.NoError(t, err)
+	gotRoot, _, err := hierarchicalTagRepo.GetByID(context.TODO(), root.ID)
+	assert.NoError(t, err)
+	assert.True(t, gotRoot.LeafOnly)
+
+	rels, err := hierarchicalTagRepo.GetRoleRels(context.TODO(), []string{root.ID, child.ID})
+	assert.NoError(t, err)
+	assert.Len(t, rels, 2)
+	tagIDs, err := hierarchicalTagRepo.GetModeratedTagIDs(context.TODO(), "1")
+	assert.NoError(t, err)
+	assert.Contains(t, tagIDs, root.ID)
+
+	// settings are replaced as a whole
+	err = hierarchicalTagRepo.UpdatePermission(context.TODO(), root.ID, false, nil, nil)
+	assert.NoError(t, err)
+	gotRoot, _, err = hierarchicalTagRepo.GetByID(context.TODO(), root.ID)
+	assert.NoError(t, err)
+	assert.False(t, gotRoot.LeafOnly)
+	rels, err = hierarchicalTagRepo.GetRoleRels(context.TODO(), []string{root.ID})
+	assert.NoError(t, err)
+	assert.Empty(t, rels)
+	moderators, err := hierarchicalTagRepo.GetModerators(context.TODO(), root.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, moderators)
+}
+
+func Test_hierarchicalTagService_CanFileQuestionWithCustomRole(t *testing.T) {
+	hierarchicalTagRepo := hierarchical_tag.NewHierarchicalTagRepo(testDataSource)
+	roleRepo := role.NewRoleRepo(testDataSource)
+	userRoleRelRepo := role.NewUserRoleRelRepo(testDataSource)
+	userRoleRelService := roleService.NewUserRoleRelService(userRoleRelRepo, nil)
+	hs := hierarchicalTagService.NewHierarchicalTagService(hierarchicalTagRepo, nil, userRoleRelService, nil)
+	_, child, grandchild, _ := addHierarchicalTagTree(t, "filing-")
+
+	customRole := &entity.Role{Name: "Filing " + uid.ID().String()}
+	require.NoError(t, roleRepo.AddRole(context.TODO(), customRole, nil))
+	require.NoError(t, hierarchicalTagRepo.UpdatePermission(context.TODO(), child.ID, false, []int{customRole.ID}, nil))
+
+	userID := uid.ID().String()
+	require.NoError(t, userRoleRelRepo.SaveUserRoleRel(context.TODO(), userID, roleService.RoleUserID))
+	can, denyReason, err := hs.CanFileQuestion(context.TODO(), userID, []string{grandchild.ID})
+	require.NoError(t, err)
+	assert.False(t, can)
+	assert.NotEmpty(t, denyReason)
+
+	// the custom role is allowed on the ancestor, besides the built-in role
+	require.NoError(t, userRoleRelRepo.SaveUserCustomRoleRel(context.TODO(), userID, []int{customRole.ID}))
+	can, _, err = hs.CanFileQuestion(context.TODO(), userID, []string{grandchild.ID})
+	require.NoError(t, err)
+	assert.True(t, can)
+}
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/repo/limit"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_limitRepo_CheckAndRecordWindow(t *testing.T) {
+	limitRepo := limit.NewRateLimitRepo(testDataSource)
+	key := "question:user:" + uid.ID().String()
+
+	for i := 1; i <= 3; i++ {
+		used, reset, exceeded, err := limitRepo.CheckAndRecordWindow(context.TODO(), key, time.Hour, 3)
+		require.NoError(t, err)
+		assert.False(t, exceeded)
+		assert.Equal(t, i, used)
+		assert.LessOrEqual(t, reset, time.Hour)
+	}
+
+	used, _, exceeded, err := limitRepo.CheckAndRecordWindow(context.TODO(), key, time.Hour, 3)
+	require.NoError(t, err)
+	assert.True(t, exceeded)
+	assert.Equal(t, 3, used)
+
+	// the other actors have their own quota
+	_, _, exceeded, err = limitRepo.CheckAndRecordWindow(context.TODO(), key+"-other", time.Hour, 3)
+	require.NoError(t, err)
+	assert.False(t, exceeded)
+}
+
+func Test_limitRepo_CheckAndRecordWindowConcurrent(t *testing.T) {
+	limitRepo := limit.NewRateLimitRepo(testDataSource)
+	key := "answer:user:" + uid.ID().String()
+
+	var allowed int32
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, exceeded, err := limitRepo.CheckAndRecordWindow(context.TODO(), key, time.Hour, 5)
+			assert.NoError(t, err)
+			if !exceeded {
+				atomic.AddInt32(&allowed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(5), allowed)
+
+	// the rejected requests are rolled back
+	used, _, exceeded, err := limitRepo.CheckAndRecordWindow(context.TODO(), key, time.Hour, 6)
+	require.NoError(t, err)
+	assert.False(t, exceeded)
+	assert.Equal(t, 6, used)
+}
@@ -0,0 +1,202 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/moderation"
+	"github.com/apache/answer/internal/service/moderation_common"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_moderationRepo_SaveClaim(t *testing.T) {
+	moderationRepo := moderation.NewModerationRepo(testDataSource)
+	itemID := uid.ID().String()
+
+	saved, err := moderationRepo.SaveClaim(context.TODO(), &entity.ModerationClaim{
+		ItemType:  entity.ModerationItemTypeReport,
+		ItemID:    itemID,
+		UserID:    "1",
+		ExpiredAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	// others can not claim it until it expires
+	saved, err = moderationRepo.SaveClaim(context.TODO(), &entity.ModerationClaim{
+		ItemType:  entity.ModerationItemTypeReport,
+		ItemID:    itemID,
+		UserID:    "2",
+		ExpiredAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.False(t, saved)
+
+	// the holder renews it with an expired time
+	saved, err = moderationRepo.SaveClaim(context.TODO(), &entity.ModerationClaim{
+		ItemType:  entity.ModerationItemTypeReport,
+		ItemID:    itemID,
+		UserID:    "1",
+		ExpiredAt: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	saved, err = moderationRepo.SaveClaim(context.TODO(), &entity.ModerationClaim{
+		ItemType:  entity.ModerationItemTypeReport,
+		ItemID:    itemID,
+		UserID:    "2",
+		ExpiredAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.True(t, saved)
+
+	claim, exist, err := moderationRepo.GetClaim(context.TODO(), entity.ModerationItemTypeReport, itemID)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "2", claim.UserID)
+
+	// only the holder can release it
+	err = moderationRepo.RemoveClaim(context.TODO(), entity.ModerationItemTypeReport, itemID, "1")
+	require.NoError(t, err)
+	_, exist, err = moderationRepo.GetClaim(context.TODO(), entity.ModerationItemTypeReport, itemID)
+	require.NoError(t, err)
+	assert.True(t, exist)
+
+	err = moderationRepo.RemoveClaim(context.TODO(), entity.ModerationItemTypeReport, itemID, "2")
+	require.NoError(t, err)
+	_, exist, err = moderationRepo.GetClaim(context.TODO(), entity.ModerationItemTypeReport, itemID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_moderationRepo_GetLogPage(t *testing.T) {
+	moderationRepo := moderation.NewModerationRepo(testDataSource)
+	objectID := uid.ID().String()
+
+	for _, action := range []string{"approve", "reject"} {
+		err := moderationRepo.AddLog(context.TODO(), &entity.ModerationLog{
+			UserID:         "1",
+			ItemType:       entity.ModerationItemTypeRevision,
+			ItemID:         uid.ID().String(),
+			ObjectID:       objectID,
+			Action:         action,
+			BeforeSnapshot: `{"item_status":1}`,
+			AfterSnapshot:  `{"item_status":2}`,
+		})
+		require.NoError(t, err)
+	}
+
+	logs, total, err := moderationRepo.GetLogPage(context.TODO(), 1, 10, &entity.ModerationLog{ObjectID: objectID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "reject", logs[0].Action)
+	assert.Equal(t, "approve", logs[1].Action)
+}
+
+func Test_moderationRepo_GetQueuePage(t *testing.T) {
+	moderationRepo := moderation.NewModerationRepo(testDataSource)
+	now := time.Now()
+	tagged, untagged := "10010000000009501", "10010000000009502"
+	_, err := testDataSource.DB.Insert(&entity.Tag{ID: "10300000000009501", SlugName: "moderation-queue",
+		DisplayName: "moderation-queue", MainTagID: 0, Status: entity.TagStatusAvailable})
+	require.NoError(t, err)
+	_, err = testDataSource.DB.Insert(&entity.TagRel{ObjectID: tagged, TagID: "10300000000009501",
+		Status: entity.TagRelStatusAvailable})
+	require.NoError(t, err)
+
+	_, err = testDataSource.DB.NoAutoTime().Insert([]*entity.Report{
+		{ID: "10070000000009501", UserID: "1", ObjectID: tagged, Content: "spam",
+			Status: entity.ReportStatusPending, CreatedAt: now.Add(-72 * time.Hour), UpdatedAt: now},
+		{ID: "10070000000009502", UserID: "1", ObjectID: untagged, Content: "spam",
+			Status: entity.ReportStatusPending, CreatedAt: now.Add(-72 * time.Hour), UpdatedAt: now},
+		{ID: "10070000000009503", UserID: "1", ObjectID: tagged, Content: "handled",
+			Status: entity.ReportStatusCompleted, CreatedAt: now.Add(-72 * time.Hour), UpdatedAt: now},
+	})
+	require.NoError(t, err)
+	_, err = testDataSource.DB.NoAutoTime().Insert(&entity.Review{UserID: "1", ObjectID: tagged,
+		Reason: "link", Status: entity.ReviewStatusPending, CreatedAt: now.Add(-time.Hour), UpdatedAt: now})
+	require.NoError(t, err)
+	_, err = testDataSource.DB.NoAutoTime().Insert(&entity.Revision{ID: "10080000000009501", UserID: "1",
+		ObjectType: constant.ObjectTypeStrMapping[constant.QuestionObjectType], ObjectID: tagged,
+		Title: "edit", Content: "edit", Log: "typo", Status: entity.RevisionUnreviewedStatus,
+		CreatedAt: now.Add(-24 * time.Hour), UpdatedAt: now})
+	require.NoError(t, err)
+
+	questionTypes := []int{constant.ObjectTypeStrMapping[constant.QuestionObjectType]}
+	tests := []struct {
+		name      string
+		cond      *moderation_common.QueueCond
+		itemTypes []string
+		reasons   []string
+	}{
+		{name: "all", cond: &moderation_common.QueueCond{RevisionObjectTypes: questionTypes},
+			itemTypes: []string{entity.ModerationItemTypeReport, entity.ModerationItemTypeRevision,
+				entity.ModerationItemTypeReview},
+			reasons: []string{"spam", "typo", "link"}},
+		{name: "type", cond: &moderation_common.QueueCond{ItemType: entity.ModerationItemTypeRevision,
+			RevisionObjectTypes: questionTypes},
+			itemTypes: []string{entity.ModerationItemTypeRevision}, reasons: []string{"typo"}},
+		{name: "revision can not be reviewed", cond: &moderation_common.QueueCond{},
+			itemTypes: []string{entity.ModerationItemTypeReport, entity.ModerationItemTypeReview},
+			reasons:   []string{"spam", "link"}},
+		{name: "min age", cond: &moderation_common.QueueCond{RevisionObjectTypes: questionTypes,
+			SubmittedBefore: now.Add(-12 * time.Hour)},
+			itemTypes: []string{entity.ModerationItemTypeReport, entity.ModerationItemTypeRevision},
+			reasons:   []string{"spam", "typo"}},
+		{name: "max age", cond: &moderation_common.QueueCond{RevisionObjectTypes: questionTypes,
+			SubmittedAfter: now.Add(-48 * time.Hour)},
+			itemTypes: []string{entity.ModerationItemTypeRevision, entity.ModerationItemTypeReview},
+			reasons:   []string{"typo", "link"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cond.TagSlugName = "moderation-queue"
+			items, total, err := moderationRepo.GetQueuePage(context.TODO(), 1, 10, tt.cond)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tt.itemTypes)), total)
+			itemTypes, reasons := make([]string, 0), make([]string, 0)
+			for _, item := range items {
+				assert.Equal(t, tagged, item.ObjectID)
+				itemTypes = append(itemTypes, item.ItemType)
+				reasons = append(reasons, item.Reason)
+			}
+			assert.Equal(t, tt.itemTypes, itemTypes)
+			assert.Equal(t, tt.reasons, reasons)
+		})
+	}
+
+	// the page is cut in the database
+	items, total, err := moderationRepo.GetQueuePage(context.TODO(), 2, 1, &moderation_common.QueueCond{
+		TagSlugName: "moderation-queue", RevisionObjectTypes: questionTypes})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, items, 1)
+	assert.Equal(t, entity.ModerationItemTypeRevision, items[0].ItemType)
+	assert.Equal(t, "10080000000009501", items[0].ItemID)
+	assert.WithinDuration(t, now.Add(-24*time.Hour), items[0].SubmitAt, time.Second)
+}
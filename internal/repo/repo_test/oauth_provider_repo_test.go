@@ -0,0 +1,198 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/oauth_provider"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_oauthProviderRepo_Client(t *testing.T) {
+	oauthProviderRepo := oauth_provider.NewOAuthProviderRepo(testDataSource)
+	client := &entity.OAuthClient{
+		ID:           uid.ID().String(),
+		ClientID:     uid.ID().String(),
+		SecretHash:   "hash",
+		Name:         "wiki",
+		RedirectURIs: "https://wiki.example.com/callback",
+		Scopes:       "openid profile",
+		Status:       entity.OAuthClientStatusAvailable,
+	}
+	require.NoError(t, oauthProviderRepo.AddClient(context.TODO(), client))
+
+	client.Name = "new wiki"
+	client.Scopes = "openid"
+	require.NoError(t, oauthProviderRepo.UpdateClient(context.TODO(), client))
+	require.NoError(t, oauthProviderRepo.UpdateClientSecret(context.TODO(), client.ID, "new hash"))
+	got, exist, err := oauthProviderRepo.GetClientByClientID(context.TODO(), client.ClientID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, "new wiki", got.Name)
+	assert.Equal(t, "openid", got.Scopes)
+	assert.Equal(t, "new hash", got.SecretHash)
+
+	token := &entity.OAuthRefreshToken{
+		ID:        uid.ID().String(),
+		ClientID:  client.ClientID,
+		UserID:    "1",
+		TokenHash: uid.ID().String(),
+		Scope:     "openid",
+		ExpiredAt: time.Now().Add(time.Hour),
+		Status:    entity.OAuthRefreshTokenStatusAvailable,
+	}
+	require.NoError(t, oauthProviderRepo.AddRefreshToken(context.TODO(), token))
+
+	require.NoError(t, oauthProviderRepo.DeleteClient(context.TODO(), client))
+	_, exist, err = oauthProviderRepo.GetClient(context.TODO(), client.ID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+	_, exist, err = oauthProviderRepo.GetRefreshTokenByHash(context.TODO(), token.TokenHash)
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_oauthProviderRepo_RefreshToken(t *testing.T) {
+	oauthProviderRepo := oauth_provider.NewOAuthProviderRepo(testDataSource)
+	token := &entity.OAuthRefreshToken{
+		ID:        uid.ID().String(),
+		ClientID:  uid.ID().String(),
+		UserID:    "1",
+		TokenHash: uid.ID().String(),
+		Scope:     "openid email",
+		ExpiredAt: time.Now().Add(time.Hour),
+		Status:    entity.OAuthRefreshTokenStatusAvailable,
+	}
+	require.NoError(t, oauthProviderRepo.AddRefreshToken(context.TODO(), token))
+	got, exist, err := oauthProviderRepo.GetRefreshTokenByHash(context.TODO(), token.TokenHash)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, token.ID, got.ID)
+
+	revoked, err := oauthProviderRepo.RevokeRefreshToken(context.TODO(), token.ID)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	// it can only be revoked once, so a refresh token cannot be used twice
+	revoked, err = oauthProviderRepo.RevokeRefreshToken(context.TODO(), token.ID)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func Test_oauthProviderRepo_AuthorizationCode(t *testing.T) {
+	oauthProviderRepo := oauth_provider.NewOAuthProviderRepo(testDataSource)
+	code := &entity.OAuthAuthorizationCode{
+		ID:          uid.ID().String(),
+		CodeHash:    uid.ID().String(),
+		ClientID:    uid.ID().String(),
+		UserID:      "1",
+		RedirectURI: "https://wiki.example.com/callback",
+		Scope:       "openid",
+		Nonce:       "n",
+		ExpiredAt:   time.Now().Add(time.Minute),
+		Status:      entity.OAuthAuthorizationCodeStatusAvailable,
+	}
+	require.NoError(t, oauthProviderRepo.AddAuthorizationCode(context.TODO(), code))
+	got, exist, err := oauthProviderRepo.GetAuthorizationCodeByHash(context.TODO(), code.CodeHash)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, code.ID, got.ID)
+	assert.Equal(t, code.Nonce, got.Nonce)
+
+	// the code can only be used once
+	used, err := oauthProviderRepo.UseAuthorizationCode(context.TODO(), code.ID)
+	require.NoError(t, err)
+	assert.True(t, used)
+	used, err = oauthProviderRepo.UseAuthorizationCode(context.TODO(), code.ID)
+	require.NoError(t, err)
+	assert.False(t, used)
+
+	accessTokenHash := uid.ID().String()
+	require.NoError(t, oauthProviderRepo.SetAccessToken(context.TODO(), accessTokenHash,
+		&entity.OAuthAccessToken{ClientID: code.ClientID, UserID: "1", Scope: "openid"}))
+	token := &entity.OAuthRefreshToken{
+		ID:              uid.ID().String(),
+		ClientID:        code.ClientID,
+		UserID:          "1",
+		TokenHash:       uid.ID().String(),
+		Scope:           "openid",
+		ExpiredAt:       time.Now().Add(time.Hour),
+		Status:          entity.OAuthRefreshTokenStatusAvailable,
+		GrantID:         code.ID,
+		AccessTokenHash: accessTokenHash,
+	}
+	require.NoError(t, oauthProviderRepo.AddRefreshToken(context.TODO(), token))
+
+	require.NoError(t, oauthProviderRepo.RevokeGrant(context.TODO(), code.ID))
+	got, exist, err = oauthProviderRepo.GetAuthorizationCodeByHash(context.TODO(), code.CodeHash)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, entity.OAuthAuthorizationCodeStatusRevoked, got.Status)
+	_, exist, err = oauthProviderRepo.GetRefreshTokenByHash(context.TODO(), token.TokenHash)
+	require.NoError(t, err)
+	assert.False(t, exist)
+	_, exist, err = oauthProviderRepo.GetAccessToken(context.TODO(), accessTokenHash)
+	require.NoError(t, err)
+	assert.False(t, exist)
+
+	count, err := oauthProviderRepo.RemoveExpiredAuthorizationCodes(context.TODO(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Positive(t, count)
+	_, exist, err = oauthProviderRepo.GetAuthorizationCodeByHash(context.TODO(), code.CodeHash)
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_oauthProviderRepo_AccessToken(t *testing.T) {
+	oauthProviderRepo := oauth_provider.NewOAuthProviderRepo(testDataSource)
+	tokenHash := uid.ID().String()
+	token := &entity.OAuthAccessToken{ClientID: "client", UserID: "1", Scope: "openid"}
+	require.NoError(t, oauthProviderRepo.SetAccessToken(context.TODO(), tokenHash, token))
+	gotToken, exist, err := oauthProviderRepo.GetAccessToken(context.TODO(), tokenHash)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, token, gotToken)
+	require.NoError(t, oauthProviderRepo.RemoveAccessToken(context.TODO(), tokenHash))
+	_, exist, err = oauthProviderRepo.GetAccessToken(context.TODO(), tokenHash)
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_oauthProviderRepo_Consent(t *testing.T) {
+	oauthProviderRepo := oauth_provider.NewOAuthProviderRepo(testDataSource)
+	userID, clientID := uid.ID().String(), uid.ID().String()
+	_, exist, err := oauthProviderRepo.GetConsent(context.TODO(), userID, clientID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+
+	require.NoError(t, oauthProviderRepo.SaveConsent(context.TODO(),
+		&entity.OAuthConsent{UserID: userID, ClientID: clientID, Scope: "openid"}))
+	require.NoError(t, oauthProviderRepo.SaveConsent(context.TODO(),
+		&entity.OAuthConsent{UserID: userID, ClientID: clientID, Scope: "openid email"}))
+	consent, exist, err := oauthProviderRepo.GetConsent(context.TODO(), userID, clientID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, "openid email", consent.Scope)
+}
@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/personal_access_token"
+	"github.com/apache/answer/internal/repo/role"
+	"github.com/apache/answer/internal/repo/user"
+	"github.com/apache/answer/internal/schema"
+	personalAccessTokenService "github.com/apache/answer/internal/service/personal_access_token"
+	roleService "github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_personalAccessTokenRepo_Token(t *testing.T) {
+	tokenRepo := personal_access_token.NewPersonalAccessTokenRepo(testDataSource)
+	userID := uid.ID().String()
+	token := &entity.PersonalAccessToken{
+		ID:          uid.ID().String(),
+		UserID:      userID,
+		Name:        "bot",
+		TokenHash:   uid.ID().String(),
+		TokenPrefix: "ans_pat_123456",
+		Scopes:      "read,write:question",
+		Status:      entity.PersonalAccessTokenStatusAvailable,
+	}
+	require.NoError(t, tokenRepo.AddToken(context.TODO(), token))
+
+	got, exist, err := tokenRepo.GetTokenByHash(context.TODO(), token.TokenHash)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, token.ID, got.ID)
+	assert.True(t, got.ExpiredAt.IsZero())
+	assert.True(t, got.LastUsedAt.IsZero())
+
+	lastUsedAt := time.Now().Truncate(time.Second)
+	require.NoError(t, tokenRepo.UpdateLastUsedAt(context.TODO(), token.ID, lastUsedAt))
+	got, _, err = tokenRepo.GetToken(context.TODO(), token.ID)
+	require.NoError(t, err)
+	assert.Equal(t, lastUsedAt.Unix(), got.LastUsedAt.Unix())
+
+	count, err := tokenRepo.CountUserTokens(context.TODO(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	tokens, total, err := tokenRepo.GetTokenPage(context.TODO(), 1, 10, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, tokens, 1)
+
+	require.NoError(t, tokenRepo.RevokeToken(context.TODO(), token.ID))
+	_, exist, err = tokenRepo.GetTokenByHash(context.TODO(), token.TokenHash)
+	require.NoError(t, err)
+	assert.False(t, exist)
+	tokens, err = tokenRepo.GetUserTokens(context.TODO(), userID)
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func Test_personalAccessTokenService_AuthenticateUnavailableUser(t *testing.T) {
+	userRepo := user.NewUserRepo(testDataSource)
+	ps := personalAccessTokenService.NewPersonalAccessTokenService(
+		personal_access_token.NewPersonalAccessTokenRepo(testDataSource), userRepo, nil,
+		roleService.NewUserRoleRelService(role.NewUserRoleRelRepo(testDataSource), nil))
+
+	owner := &entity.User{
+		Username:    "pat" + uid.ID().String(),
+		EMail:       uid.ID().String() + "@example.com",
+		MailStatus:  entity.EmailStatusAvailable,
+		Status:      entity.UserStatusAvailable,
+		DisplayName: "pat owner",
+	}
+	require.NoError(t, userRepo.AddUser(context.TODO(), owner))
+	token, err := ps.AddToken(context.TODO(), &schema.AddPersonalAccessTokenReq{
+		Name: "bot", Scopes: []string{schema.PersonalAccessTokenScopeRead}, UserID: owner.ID})
+	require.NoError(t, err)
+
+	userInfo, err := ps.Authenticate(context.TODO(), token.Token, http.MethodGet, "/answer/api/v1/question/info")
+	require.NoError(t, err)
+	assert.Equal(t, owner.ID, userInfo.UserID)
+
+	// the token stops working once the owner is suspended or deleted
+	for _, status := range []int{entity.UserStatusSuspended, entity.UserStatusDeleted} {
+		_, err = testDataSource.DB.ID(owner.ID).Cols("status").Update(&entity.User{Status: status})
+		require.NoError(t, err)
+		_, err = ps.Authenticate(context.TODO(), token.Token, http.MethodGet, "/answer/api/v1/question/info")
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,232 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/question_schedule"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm"
+)
+
+func Test_questionScheduleRepo_GetDueSchedules(t *testing.T) {
+	questionScheduleRepo := question_schedule.NewQuestionScheduleRepo(testDataSource)
+	due := &entity.QuestionSchedule{
+		ID:         uid.ID().String(),
+		QuestionID: uid.ID().String(),
+		UserID:     "1",
+		PublishAt:  time.Now().Add(-time.Minute),
+		Status:     entity.QuestionScheduleStatusPending,
+	}
+	later := &entity.QuestionSchedule{
+		ID:         uid.ID().String(),
+		QuestionID: uid.ID().String(),
+		UserID:     "1",
+		PublishAt:  time.Now().Add(time.Hour),
+		Status:     entity.QuestionScheduleStatusPending,
+	}
+	require.NoError(t, questionScheduleRepo.AddSchedule(context.TODO(), due))
+	require.NoError(t, questionScheduleRepo.AddSchedule(context.TODO(), later))
+
+	schedules, err := questionScheduleRepo.GetDueSchedules(context.TODO(), time.Now(), 100)
+	require.NoError(t, err)
+	assert.True(t, containsSchedule(schedules, due.ID))
+	assert.False(t, containsSchedule(schedules, later.ID))
+
+	require.NoError(t, questionScheduleRepo.UpdateScheduleStatus(context.TODO(), due.ID, entity.QuestionScheduleStatusPublished))
+	schedules, err = questionScheduleRepo.GetDueSchedules(context.TODO(), time.Now(), 100)
+	require.NoError(t, err)
+	assert.False(t, containsSchedule(schedules, due.ID))
+}
+
+func Test_questionScheduleRepo_AutoCloseRule(t *testing.T) {
+	questionScheduleRepo := question_schedule.NewQuestionScheduleRepo(testDataSource)
+	rule := &entity.QuestionAutoCloseRule{
+		ID:           uid.ID().String(),
+		UserID:       "1",
+		Name:         "stale questions",
+		TagID:        "0",
+		InactiveDays: 30,
+		NoAnswer:     true,
+		CloseType:    50,
+		Status:       entity.QuestionAutoCloseRuleStatusActive,
+	}
+	require.NoError(t, questionScheduleRepo.AddAutoCloseRule(context.TODO(), rule))
+
+	rule.Status = entity.QuestionAutoCloseRuleStatusInactive
+	require.NoError(t, questionScheduleRepo.UpdateAutoCloseRule(context.TODO(), rule))
+	got, exist, err := questionScheduleRepo.GetAutoCloseRule(context.TODO(), rule.ID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, entity.QuestionAutoCloseRuleStatusInactive, got.Status)
+	assert.True(t, got.NoAnswer)
+
+	rules, err := questionScheduleRepo.GetActiveAutoCloseRules(context.TODO())
+	require.NoError(t, err)
+	for _, r := range rules {
+		assert.NotEqual(t, rule.ID, r.ID)
+	}
+
+	require.NoError(t, questionScheduleRepo.DeleteAutoCloseRule(context.TODO(), rule.ID))
+	_, exist, err = questionScheduleRepo.GetAutoCloseRule(context.TODO(), rule.ID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
+
+func Test_questionScheduleRepo_GetInactiveQuestions(t *testing.T) {
+	questionScheduleRepo := question_schedule.NewQuestionScheduleRepo(testDataSource)
+	tagID := uid.ID().String()
+	longAgo := time.Now().AddDate(0, 0, -60)
+	newInactiveQuestion := func(answerCount, pin int, postUpdateTime time.Time) *entity.Question {
+		question := &entity.Question{
+			ID:             uid.ID().String(),
+			CreatedAt:      longAgo,
+			UserID:         "1",
+			Title:          "inactive question",
+			OriginalText:   "inactive question",
+			ParsedText:     "inactive question",
+			Pin:            pin,
+			Show:           entity.QuestionShow,
+			Status:         entity.QuestionStatusAvailable,
+			AnswerCount:    answerCount,
+			PostUpdateTime: postUpdateTime,
+		}
+		_, err := testDataSource.DB.Insert(question)
+		require.NoError(t, err)
+		_, err = testDataSource.DB.Insert(&entity.TagRel{
+			ObjectID: question.ID,
+			TagID:    tagID,
+			Status:   entity.TagRelStatusAvailable,
+		})
+		require.NoError(t, err)
+		return question
+	}
+	inactive := newInactiveQuestion(0, entity.QuestionUnPin, longAgo)
+	answered := newInactiveQuestion(1, entity.QuestionUnPin, longAgo)
+	pinned := newInactiveQuestion(0, entity.QuestionPin, longAgo)
+	active := newInactiveQuestion(0, entity.QuestionUnPin, time.Now())
+
+	rule := &entity.QuestionAutoCloseRule{TagID: tagID, InactiveDays: 30, NoAnswer: true}
+	questions, err := questionScheduleRepo.GetInactiveQuestions(context.TODO(), rule, time.Now().AddDate(0, 0, -30), 100)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{inactive.ID}, questionIDs(questions))
+
+	// the pinned and the recently active questions are never matched
+	rule.NoAnswer = false
+	questions, err = questionScheduleRepo.GetInactiveQuestions(context.TODO(), rule, time.Now().AddDate(0, 0, -30), 100)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{inactive.ID, answered.ID}, questionIDs(questions))
+	assert.NotContains(t, questionIDs(questions), pinned.ID)
+	assert.NotContains(t, questionIDs(questions), active.ID)
+}
+
+func Test_questionScheduleRepo_PublishQuestion(t *testing.T) {
+	questionScheduleRepo := question_schedule.NewQuestionScheduleRepo(testDataSource)
+	newScheduledQuestion := func() (*entity.Question, *entity.QuestionSchedule) {
+		question := &entity.Question{
+			ID:             uid.ID().String(),
+			UserID:         "1",
+			Title:          "scheduled question",
+			OriginalText:   "scheduled question",
+			ParsedText:     "scheduled question",
+			Show:           entity.QuestionShow,
+			Status:         entity.QuestionStatusScheduled,
+			PostUpdateTime: time.Now(),
+		}
+		_, err := testDataSource.DB.Insert(question)
+		require.NoError(t, err)
+		schedule := &entity.QuestionSchedule{ID: uid.ID().String(), QuestionID: question.ID, UserID: "1",
+			PublishAt: time.Now(), Status: entity.QuestionScheduleStatusPending}
+		require.NoError(t, questionScheduleRepo.AddSchedule(context.TODO(), schedule))
+		question.Status = entity.QuestionStatusAvailable
+		return question, schedule
+	}
+	enqueued := 0
+	enqueue := func(session *xorm.Session) error {
+		enqueued++
+		return nil
+	}
+	getStatus := func(question *entity.Question) int {
+		got := &entity.Question{}
+		exist, err := testDataSource.DB.ID(question.ID).Get(got)
+		require.NoError(t, err)
+		require.True(t, exist)
+		return got.Status
+	}
+
+	// the question is announced only once if the cron of two instances publish it at the same time
+	question, schedule := newScheduledQuestion()
+	published, err := questionScheduleRepo.PublishQuestion(context.TODO(), schedule.ID, question, enqueue)
+	require.NoError(t, err)
+	assert.True(t, published)
+	published, err = questionScheduleRepo.PublishQuestion(context.TODO(), schedule.ID, question, enqueue)
+	require.NoError(t, err)
+	assert.False(t, published)
+	assert.Equal(t, 1, enqueued)
+	assert.Equal(t, entity.QuestionStatusAvailable, getStatus(question))
+	schedules, err := questionScheduleRepo.GetDueSchedules(context.TODO(), time.Now(), 100)
+	require.NoError(t, err)
+	assert.False(t, containsSchedule(schedules, schedule.ID))
+
+	// the question deleted by the admin is not brought back
+	question, schedule = newScheduledQuestion()
+	_, err = testDataSource.DB.ID(question.ID).Cols("status").
+		Update(&entity.Question{Status: entity.QuestionStatusDeleted})
+	require.NoError(t, err)
+	published, err = questionScheduleRepo.PublishQuestion(context.TODO(), schedule.ID, question, enqueue)
+	require.NoError(t, err)
+	assert.False(t, published)
+	assert.Equal(t, entity.QuestionStatusDeleted, getStatus(question))
+
+	// the question stays scheduled if the messages announcing it can not be queued
+	question, schedule = newScheduledQuestion()
+	_, err = questionScheduleRepo.PublishQuestion(context.TODO(), schedule.ID, question, func(session *xorm.Session) error {
+		return fmt.Errorf("outbox is gone")
+	})
+	require.Error(t, err)
+	assert.Equal(t, entity.QuestionStatusScheduled, getStatus(question))
+	schedules, err = questionScheduleRepo.GetDueSchedules(context.TODO(), time.Now(), 100)
+	require.NoError(t, err)
+	assert.True(t, containsSchedule(schedules, schedule.ID))
+}
+
+func questionIDs(questions []*entity.Question) []string {
+	ids := make([]string, 0, len(questions))
+	for _, question := range questions {
+		ids = append(ids, question.ID)
+	}
+	return ids
+}
+
+func containsSchedule(schedules []*entity.QuestionSchedule, id string) bool {
+	for _, schedule := range schedules {
+		if schedule.ID == id {
+			return true
+		}
+	}
+	return false
+}
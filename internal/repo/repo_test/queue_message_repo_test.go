@@ -0,0 +1,209 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/activity_common"
+	"github.com/apache/answer/internal/repo/queue_message"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/service/config"
+	queueMessageService "github.com/apache/answer/internal/service/queue_message"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"xorm.io/xorm"
+)
+
+func Test_queueMessageRepo_ClaimDueMessages(t *testing.T) {
+	queueMessageRepo := queue_message.NewQueueMessageRepo(testDataSource)
+	due := &entity.QueueMessage{Queue: "test-claim", Payload: "{}", Status: entity.QueueMessageStatusPending,
+		NextRunAt: time.Now().Add(-time.Minute)}
+	later := &entity.QueueMessage{Queue: "test-claim", Payload: "{}", Status: entity.QueueMessageStatusPending,
+		NextRunAt: time.Now().Add(time.Hour)}
+	require.NoError(t, queueMessageRepo.AddMessages(context.TODO(), due))
+	require.NoError(t, queueMessageRepo.AddMessages(context.TODO(), later))
+
+	messages, err := queueMessageRepo.ClaimDueMessages(context.TODO(), "test-claim", 10, time.Minute)
+	assert.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, due.ID, messages[0].ID)
+
+	// claimed messages are hidden until the lease expires
+	messages, err = queueMessageRepo.ClaimDueMessages(context.TODO(), "test-claim", 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Empty(t, messages)
+
+	require.NoError(t, queueMessageRepo.DeleteMessage(context.TODO(), due.ID))
+	require.NoError(t, queueMessageRepo.DeleteMessage(context.TODO(), later.ID))
+}
+
+func Test_queueMessageRepo_AddMessagesWithSession(t *testing.T) {
+	queueMessageRepo := queue_message.NewQueueMessageRepo(testDataSource)
+	newMessage := func() *entity.QueueMessage {
+		return &entity.QueueMessage{Queue: "test-session", Payload: "{}", Status: entity.QueueMessageStatusPending,
+			NextRunAt: time.Now().Add(-time.Minute)}
+	}
+
+	// the messages of a rolled back transaction are never handled
+	_, err := testDataSource.DB.Transaction(func(session *xorm.Session) (any, error) {
+		if err := queueMessageRepo.AddMessagesWithSession(context.TODO(), session, newMessage(), newMessage()); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("rollback")
+	})
+	require.Error(t, err)
+	_, total, err := queueMessageRepo.GetMessagePage(context.TODO(), 1, 10, "test-session", 0)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+
+	committed := []*entity.QueueMessage{newMessage(), newMessage()}
+	_, err = testDataSource.DB.Transaction(func(session *xorm.Session) (any, error) {
+		return nil, queueMessageRepo.AddMessagesWithSession(context.TODO(), session, committed...)
+	})
+	require.NoError(t, err)
+	messages, err := queueMessageRepo.ClaimDueMessages(context.TODO(), "test-session", 10, time.Minute)
+	require.NoError(t, err)
+	assert.Len(t, messages, 2)
+	for _, message := range committed {
+		require.NoError(t, queueMessageRepo.DeleteMessage(context.TODO(), message.ID))
+	}
+}
+
+func Test_queueMessageRepo_ExpiredLease(t *testing.T) {
+	queueMessageRepo := queue_message.NewQueueMessageRepo(testDataSource)
+	message := &entity.QueueMessage{Queue: "test-lease", Payload: "{}", Status: entity.QueueMessageStatusPending,
+		NextRunAt: time.Now().Add(-time.Minute)}
+	require.NoError(t, queueMessageRepo.AddMessages(context.TODO(), message))
+
+	messages, err := queueMessageRepo.ClaimDueMessages(context.TODO(), "test-lease", 10, -time.Second)
+	assert.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	// the worker holding the message is gone, so another one takes it over
+	messages, err = queueMessageRepo.ClaimDueMessages(context.TODO(), "test-lease", 10, time.Minute)
+	assert.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, message.ID, messages[0].ID)
+
+	require.NoError(t, queueMessageRepo.DeleteMessage(context.TODO(), message.ID))
+}
+
+func Test_queueMessageRepo_DeadAndReplay(t *testing.T) {
+	queueMessageRepo := queue_message.NewQueueMessageRepo(testDataSource)
+	message := &entity.QueueMessage{Queue: "test-dead", Payload: "{}", Status: entity.QueueMessageStatusPending,
+		NextRunAt: time.Now()}
+	require.NoError(t, queueMessageRepo.AddMessages(context.TODO(), message))
+
+	err := queueMessageRepo.UpdateMessageRetry(context.TODO(), message.ID, 1, time.Now().Add(time.Hour), "failed")
+	assert.NoError(t, err)
+	messages, err := queueMessageRepo.ClaimDueMessages(context.TODO(), "test-dead", 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Empty(t, messages)
+
+	err = queueMessageRepo.UpdateMessageDead(context.TODO(), message.ID, 8, "failed again")
+	assert.NoError(t, err)
+	dead, total, err := queueMessageRepo.GetMessagePage(context.TODO(), 1, 10, "test-dead", entity.QueueMessageStatusDead)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 8, dead[0].Attempts)
+	assert.Equal(t, "failed again", dead[0].LastError)
+
+	affected, err := queueMessageRepo.ReplayMessages(context.TODO(), []int64{message.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+	messages, err = queueMessageRepo.ClaimDueMessages(context.TODO(), "test-dead", 10, time.Minute)
+	assert.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, 0, messages[0].Attempts)
+
+	require.NoError(t, queueMessageRepo.DeleteMessage(context.TODO(), message.ID))
+}
+
+func Test_queueMessageRepo_OrderKey(t *testing.T) {
+	queueMessageRepo := queue_message.NewQueueMessageRepo(testDataSource)
+	newMessage := func(orderKey string) *entity.QueueMessage {
+		message := &entity.QueueMessage{Queue: "test-order", OrderKey: orderKey, Payload: "{}",
+			Status: entity.QueueMessageStatusPending, NextRunAt: time.Now().Add(-time.Minute)}
+		require.NoError(t, queueMessageRepo.AddMessages(context.TODO(), message))
+		return message
+	}
+	first, second, other := newMessage("1"), newMessage("1"), newMessage("2")
+
+	messages, err := queueMessageRepo.ClaimDueMessages(context.TODO(), "test-order", 10, time.Minute)
+	assert.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, first.ID, messages[0].ID)
+	assert.Equal(t, other.ID, messages[1].ID)
+
+	// the failed message holds the following one back
+	err = queueMessageRepo.UpdateMessageRetry(context.TODO(), first.ID, 1, time.Now().Add(time.Hour), "failed")
+	assert.NoError(t, err)
+	messages, err = queueMessageRepo.ClaimDueMessages(context.TODO(), "test-order", 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Empty(t, messages)
+
+	// until it is dead
+	err = queueMessageRepo.UpdateMessageDead(context.TODO(), first.ID, 8, "failed")
+	assert.NoError(t, err)
+	messages, err = queueMessageRepo.ClaimDueMessages(context.TODO(), "test-order", 10, time.Minute)
+	assert.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, second.ID, messages[0].ID)
+
+	for _, message := range []*entity.QueueMessage{first, second, other} {
+		require.NoError(t, queueMessageRepo.DeleteMessage(context.TODO(), message.ID))
+	}
+}
+
+func Test_queueMessageRepo_ReceiptSkipsRetriedEffect(t *testing.T) {
+	queueMessageRepo := queue_message.NewQueueMessageRepo(testDataSource)
+	activityRepo := activity_common.NewActivityRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource),
+		config.NewConfigService(nil))
+	message := &entity.QueueMessage{Queue: "test.receipt", Payload: "{}", NextRunAt: time.Now()}
+	require.NoError(t, queueMessageRepo.AddMessages(context.TODO(), message))
+
+	userID := uid.ID().String()
+	ctx := queueMessageService.WithIdempotencyKey(context.TODO(), message.ID, "test.receipt:1")
+	// the handler fails after the activity is added, the retry must not add it again
+	for i := 0; i < 2; i++ {
+		require.NoError(t, activityRepo.AddActivity(ctx, &entity.Activity{UserID: userID, ActivityType: 1, Rank: 10, HasRank: 1}))
+	}
+	count, err := testDataSource.DB.Where("user_id = ?", userID).Count(&entity.Activity{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// the activities out of the queue are not affected
+	require.NoError(t, activityRepo.AddActivity(context.TODO(), &entity.Activity{UserID: userID, ActivityType: 1}))
+	count, err = testDataSource.DB.Where("user_id = ?", userID).Count(&entity.Activity{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	require.NoError(t, queueMessageRepo.DeleteMessage(context.TODO(), message.ID))
+	count, err = testDataSource.DB.Where("message_id = ?", message.ID).Count(&entity.QueueMessageReceipt{})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/apache/answer/internal/repo/activity_common"
 	"github.com/apache/answer/internal/repo/config"
 	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/search_sync"
 	"github.com/apache/answer/internal/repo/tag"
 	"github.com/apache/answer/internal/repo/tag_common"
 	"github.com/apache/answer/internal/repo/unique"
@@ -39,7 +40,7 @@ import (
 func Test_questionRepo_GetRecommend(t *testing.T) {
 	var (
 		uniqueIDRepo       = unique.NewUniqueIDRepo(testDataSource)
-		questionRepo       = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		questionRepo       = question.NewQuestionRepo(testDataSource, uniqueIDRepo, search_sync.NewSearchChangeRepo(testDataSource))
 		userRepo           = user.NewUserRepo(testDataSource)
 		tagRelRepo         = tag.NewTagRelRepo(testDataSource, uniqueIDRepo)
 		tagRepo            = tag.NewTagRepo(testDataSource, uniqueIDRepo)
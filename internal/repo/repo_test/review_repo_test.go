@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/review"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_reviewRepo_GetUserRejectedReviewCount(t *testing.T) {
+	reviewRepo := review.NewReviewRepo(testDataSource)
+	userID := uid.ID().String()
+	since := time.Now().Add(-time.Hour)
+
+	// the review is rejected by the reviewer, and the other is rejected because the user deletes the post
+	for _, reviewerUserID := range []string{"1", userID} {
+		r := &entity.Review{
+			UserID:         userID,
+			ObjectID:       uid.ID().String(),
+			ObjectType:     1,
+			ReviewerUserID: "0",
+			Status:         entity.ReviewStatusPending,
+		}
+		require.NoError(t, reviewRepo.AddReview(context.TODO(), r))
+		require.NoError(t, reviewRepo.UpdateReviewStatus(context.TODO(), r.ID, reviewerUserID, entity.ReviewStatusRejected))
+	}
+
+	count, err := reviewRepo.GetUserRejectedReviewCount(context.TODO(), userID, since)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = reviewRepo.GetUserRejectedReviewCount(context.TODO(), userID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/repo/question"
 	"github.com/apache/answer/internal/repo/revision"
+	"github.com/apache/answer/internal/repo/search_sync"
 	"github.com/apache/answer/internal/repo/unique"
 	"github.com/stretchr/testify/assert"
 )
@@ -64,7 +65,7 @@ func Test_revisionRepo_AddRevision(t *testing.T) {
 	var (
 		uniqueIDRepo = unique.NewUniqueIDRepo(testDataSource)
 		revisionRepo = revision.NewRevisionRepo(testDataSource, uniqueIDRepo)
-		questionRepo = question.NewQuestionRepo(testDataSource, uniqueIDRepo)
+		questionRepo = question.NewQuestionRepo(testDataSource, uniqueIDRepo, search_sync.NewSearchChangeRepo(testDataSource))
 	)
 
 	// create question
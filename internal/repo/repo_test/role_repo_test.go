@@ -0,0 +1,117 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/role"
+	"github.com/apache/answer/internal/service/permission"
+	service "github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_roleRepo_CustomRole(t *testing.T) {
+	roleRepo := role.NewRoleRepo(testDataSource)
+	rolePowerRelRepo := role.NewRolePowerRelRepo(testDataSource)
+
+	customRole := &entity.Role{Name: "Tag curator " + uid.ID().String(), Description: "curate tags"}
+	require.NoError(t, roleRepo.AddRole(context.TODO(), customRole, []string{permission.TagEdit, permission.TagSynonym}))
+	assert.NotZero(t, customRole.ID)
+
+	powers, err := rolePowerRelRepo.GetRolePowerTypeList(context.TODO(), customRole.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{permission.TagEdit, permission.TagSynonym}, powers)
+
+	customRole.Description = "curate and merge tags"
+	require.NoError(t, roleRepo.UpdateRole(context.TODO(), customRole, []string{permission.TagMerge}))
+	got, exist, err := roleRepo.GetRole(context.TODO(), customRole.ID)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "curate and merge tags", got.Description)
+
+	powers, err = rolePowerRelRepo.GetRolesPowerTypeList(context.TODO(), []int{service.RoleUserID, customRole.ID})
+	require.NoError(t, err)
+	assert.Contains(t, powers, permission.TagMerge)
+	assert.NotContains(t, powers, permission.TagEdit)
+
+	require.NoError(t, roleRepo.RemoveRole(context.TODO(), customRole.ID))
+	_, exist, err = roleRepo.GetRole(context.TODO(), customRole.ID)
+	require.NoError(t, err)
+	assert.False(t, exist)
+	powers, err = rolePowerRelRepo.GetRolePowerTypeList(context.TODO(), customRole.ID)
+	require.NoError(t, err)
+	assert.Empty(t, powers)
+}
+
+func Test_roleRepo_AddRoleAfterDefaultRoles(t *testing.T) {
+	roleRepo := role.NewRoleRepo(testDataSource)
+
+	// the default roles are inserted with their ids, the custom role must not take one of them
+	customRole := &entity.Role{Name: "Reviewer " + uid.ID().String()}
+	require.NoError(t, roleRepo.AddRole(context.TODO(), customRole, nil))
+	assert.Greater(t, customRole.ID, service.RoleModeratorID)
+	got, exist, err := roleRepo.GetRole(context.TODO(), service.RoleUserID)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, "User", got.Name)
+
+	require.NoError(t, roleRepo.RemoveRole(context.TODO(), customRole.ID))
+}
+
+func Test_userRoleRelRepo_SaveUserCustomRoleRel(t *testing.T) {
+	roleRepo := role.NewRoleRepo(testDataSource)
+	userRoleRelRepo := role.NewUserRoleRelRepo(testDataSource)
+	userID := uid.ID().String()
+
+	customRole := &entity.Role{Name: "Support engineer " + uid.ID().String()}
+	require.NoError(t, roleRepo.AddRole(context.TODO(), customRole, nil))
+	require.NoError(t, userRoleRelRepo.SaveUserRoleRel(context.TODO(), userID, service.RoleModeratorID))
+	require.NoError(t, userRoleRelRepo.SaveUserCustomRoleRel(context.TODO(), userID, []int{customRole.ID}))
+
+	// the built-in role is kept apart from the custom roles
+	require.NoError(t, userRoleRelRepo.SaveUserRoleRel(context.TODO(), userID, service.RoleAdminID))
+	rel, exist, err := userRoleRelRepo.GetUserRoleRel(context.TODO(), userID)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, service.RoleAdminID, rel.RoleID)
+
+	rels, err := userRoleRelRepo.GetUserRoleRelList(context.TODO(), []string{userID})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{service.RoleAdminID, customRole.ID}, userRoleIDs(rels))
+
+	require.NoError(t, userRoleRelRepo.SaveUserCustomRoleRel(context.TODO(), userID, nil))
+	rels, err = userRoleRelRepo.GetUserRoleRelList(context.TODO(), []string{userID})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{service.RoleAdminID}, userRoleIDs(rels))
+
+	require.NoError(t, roleRepo.RemoveRole(context.TODO(), customRole.ID))
+}
+
+func userRoleIDs(rels []*entity.UserRoleRel) (roleIDs []int) {
+	for _, rel := range rels {
+		roleIDs = append(roleIDs, rel.RoleID)
+	}
+	return roleIDs
+}
@@ -0,0 +1,155 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/repo/search_index"
+	searchindex "github.com/apache/answer/internal/service/search_index"
+	"github.com/apache/answer/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSearchCond(words ...string) *plugin.SearchBasicCond {
+	return &plugin.SearchBasicCond{Page: 1, PageSize: 10, Words: words, Order: plugin.SearchRelevanceOrder,
+		VoteAmount: -1, ViewAmount: -1, AnswerAmount: -1}
+}
+
+func Test_searchIndexRepo_Search(t *testing.T) {
+	ctx := context.TODO()
+	searchIndexService := searchindex.NewSearchIndexService(search_index.NewSearchIndexRepo(testDataSource))
+	contents := []*plugin.SearchContent{
+		{ObjectID: "10010000000009001", Type: constant.QuestionObjectType, Title: "How to configure the cache",
+			Content: "<p>Connections to redis keep timing out.</p>", QuestionID: "10010000000009001",
+			UserID: "1", Tags: []string{"101"}, Status: plugin.SearchContentStatusAvailable, Created: 1,
+			AnswerUserIDs: []string{"2"}},
+		{ObjectID: "10010000000009002", Type: constant.QuestionObjectType, Title: "Search is slow",
+			Content:    "The cache is configured but the search index is rebuilt on every start. 如何配置搜索",
+			QuestionID: "10010000000009002", UserID: "2", Status: plugin.SearchContentStatusClosed, Created: 2,
+			Comments: []string{"Try the cache plugin."}, CommentUserIDs: []string{"3"}},
+		{ObjectID: "10020000000009003", Type: constant.AnswerObjectType, Title: "How to configure the cache",
+			Content: "Set the connection timeout of redis.", QuestionID: "10010000000009001",
+			UserID: "2", Status: plugin.SearchContentStatusAvailable, HasAccepted: true, Created: 3},
+	}
+	for _, content := range contents {
+		require.NoError(t, searchIndexService.UpdateContent(ctx, content))
+	}
+
+	// the title matches rank first, the words are stemmed
+	res, total, err := searchIndexService.SearchQuestions(ctx, newSearchCond("configuring", "cache"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, res, 2)
+	assert.Equal(t, "10010000000009001", res[0].ID)
+
+	res, total, err = searchIndexService.SearchContents(ctx, newSearchCond(`"redis keep"`))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "10010000000009001", res[0].ID)
+
+	res, _, err = searchIndexService.SearchContents(ctx, newSearchCond("搜索"))
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, "10010000000009002", res[0].ID)
+
+	cond := newSearchCond("redis")
+	cond.AnswerAccepted = plugin.AcceptedCondTrue
+	res, _, err = searchIndexService.SearchAnswers(ctx, cond)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, constant.AnswerObjectType, res[0].Type)
+
+	cond = newSearchCond()
+	cond.TagIDs = [][]string{{"101"}}
+	res, total, err = searchIndexService.SearchQuestions(ctx, cond)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "10010000000009001", res[0].ID)
+
+	cond = newSearchCond("cache")
+	cond.ExcludedTagIDs = []string{"101"}
+	res, _, err = searchIndexService.SearchQuestions(ctx, cond)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, "10010000000009002", res[0].ID)
+
+	cond = newSearchCond("cache")
+	cond.TitleOnly = true
+	res, _, err = searchIndexService.SearchQuestions(ctx, cond)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, "10010000000009001", res[0].ID)
+
+	filters := []func(cond *plugin.SearchBasicCond){
+		func(cond *plugin.SearchBasicCond) { cond.Closed = true },
+		func(cond *plugin.SearchBasicCond) { cond.CommentUserID = "3" },
+		func(cond *plugin.SearchBasicCond) { cond.CreatedFrom, cond.CreatedTo = 2, 3 },
+	}
+	for _, filter := range filters {
+		cond = newSearchCond("cache")
+		filter(cond)
+		res, _, err = searchIndexService.SearchContents(ctx, cond)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, "10010000000009002", res[0].ID)
+	}
+
+	cond = newSearchCond()
+	cond.AnswerUserID = "2"
+	res, _, err = searchIndexService.SearchQuestions(ctx, cond)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, "10010000000009001", res[0].ID)
+
+	// the other orders are applied among the matched documents
+	cond = newSearchCond("cache")
+	cond.Order = plugin.SearchNewestOrder
+	res, total, err = searchIndexService.SearchQuestions(ctx, cond)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, res, 2)
+	assert.Equal(t, "10010000000009002", res[0].ID)
+
+	contents[0].Status = plugin.SearchContentStatusDeleted
+	require.NoError(t, searchIndexService.UpdateContent(ctx, contents[0]))
+	res, _, err = searchIndexService.SearchQuestions(ctx, newSearchCond("redis"))
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	// only the occurrences with the highest weights are loaded, the rarity of the term still counts all of them
+	require.NoError(t, searchIndexService.UpdateContent(ctx, &plugin.SearchContent{ObjectID: "10010000000009004",
+		Type: constant.QuestionObjectType, Title: "Redis cache", Content: "The redis cache and the redis cluster.",
+		QuestionID: "10010000000009004", Status: plugin.SearchContentStatusAvailable, Created: 4}))
+	searchIndexRepo := search_index.NewSearchIndexRepo(testDataSource)
+	postings, err := searchIndexRepo.GetTerms(ctx, []string{"redi"}, false, 1)
+	require.NoError(t, err)
+	require.Len(t, postings, 1)
+	assert.Equal(t, "10010000000009004", postings[0].ObjectID)
+	docCounts, err := searchIndexRepo.GetTermDocCounts(ctx, []string{"redi"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), docCounts["redi"])
+	docCounts, err = searchIndexRepo.GetTermDocCounts(ctx, []string{"redi"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), docCounts["redi"])
+}
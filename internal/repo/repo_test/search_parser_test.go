@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/hierarchical_tag"
+	"github.com/apache/answer/internal/repo/tag"
+	"github.com/apache/answer/internal/repo/tag_common"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/repo/user"
+	"github.com/apache/answer/internal/schema"
+	hierarchicaltag "github.com/apache/answer/internal/service/hierarchical_tag"
+	"github.com/apache/answer/internal/service/search_parser"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (searchSiteInfo) FormatAvatar(ctx context.Context, originalAvatarData, email string, userStatus int) *schema.AvatarInfo {
+	return &schema.AvatarInfo{}
+}
+
+func newTestSearchParser() *search_parser.SearchParser {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	tagCommonService := tagcommon.NewTagCommonService(tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo),
+		tag.NewTagRelRepo(testDataSource, uniqueIDRepo), tag.NewTagRepo(testDataSource, uniqueIDRepo),
+		nil, searchSiteInfo{}, nil)
+	userCommon := usercommon.NewUserCommon(user.NewUserRepo(testDataSource), nil, nil, searchSiteInfo{})
+	hierarchicalTagService := hierarchicaltag.NewHierarchicalTagService(
+		hierarchical_tag.NewHierarchicalTagRepo(testDataSource), nil, nil, userCommon)
+	return search_parser.NewSearchParser(tagCommonService, userCommon, hierarchicalTagService)
+}
+
+func Test_searchParser_ParseCategory(t *testing.T) {
+	root, child, grandchild, _ := addHierarchicalTagTree(t, "parser-")
+	sp := newTestSearchParser()
+
+	tests := []struct {
+		name    string
+		query   string
+		tagIDs  []string
+		noMatch bool
+		words   []string
+	}{
+		{name: "bare", query: "category:parser-root goroutine",
+			tagIDs: []string{root.ID, child.ID, grandchild.ID}, words: []string{"goroutine"}},
+		{name: "bracket", query: "[category:parser-root/parser-child] goroutine",
+			tagIDs: []string{child.ID, grandchild.ID}, words: []string{"goroutine"}},
+		{name: "bracket after tag", query: "goroutine [category:parser-grandchild]",
+			tagIDs: []string{grandchild.ID}, words: []string{"goroutine"}},
+		{name: "unknown bare", query: "category:parser-missing goroutine", noMatch: true, words: []string{"goroutine"}},
+		{name: "unknown bracket", query: "[category:parser-missing] goroutine", noMatch: true, words: []string{"goroutine"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond := sp.ParseStructure(context.TODO(), &schema.SearchDTO{Query: tt.query})
+			assert.ElementsMatch(t, tt.tagIDs, cond.HierarchicalTagIDs)
+			assert.Equal(t, tt.noMatch, cond.NoMatch)
+			assert.Empty(t, cond.Tags)
+			assert.Equal(t, tt.words, cond.Words)
+		})
+	}
+}
+
+func Test_searchParser_ParseStructure(t *testing.T) {
+	ctx := context.TODO()
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	tags := []*entity.Tag{
+		{SlugName: "parser-go", DisplayName: "parser-go", Status: entity.TagStatusAvailable},
+		{SlugName: "parser-rust", DisplayName: "parser-rust", Status: entity.TagStatusAvailable},
+		{SlugName: "parser-java", DisplayName: "parser-java", Status: entity.TagStatusAvailable},
+	}
+	require.NoError(t, tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo).AddTagList(ctx, tags))
+	goID, rustID, javaID := tags[0].ID, tags[1].ID, tags[2].ID
+	parserUser := &entity.User{
+		Username:    "parseruser",
+		Pass:        "parseruser",
+		EMail:       "parseruser@example.com",
+		MailStatus:  entity.EmailStatusAvailable,
+		Status:      entity.UserStatusAvailable,
+		DisplayName: "parseruser",
+	}
+	require.NoError(t, user.NewUserRepo(testDataSource).AddUser(ctx, parserUser))
+
+	date := func(year int, month time.Month, day int) int64 {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.Local).Unix()
+	}
+	sp := newTestSearchParser()
+	tests := []struct {
+		name          string
+		query         string
+		currentUserID string
+		want          *schema.SearchCondition
+	}{
+		{name: "created month range", query: "created:2024-01..2024-06 fish",
+			want: &schema.SearchCondition{CreatedFrom: date(2024, 1, 1), CreatedTo: date(2024, 7, 1),
+				Words: []string{"fish"}}},
+		{name: "created year", query: "created:2024 fish",
+			want: &schema.SearchCondition{CreatedFrom: date(2024, 1, 1), CreatedTo: date(2025, 1, 1),
+				Words: []string{"fish"}}},
+		{name: "created open end", query: "created:2024-01-15.. fish",
+			want: &schema.SearchCondition{CreatedFrom: date(2024, 1, 15), Words: []string{"fish"}}},
+		{name: "created open start", query: "created:..2024-02-29 fish",
+			want: &schema.SearchCondition{CreatedTo: date(2024, 3, 1), Words: []string{"fish"}}},
+		{name: "tag groups", query: "[parser-go] OR [parser-rust] [parser-java] fish",
+			want: &schema.SearchCondition{Tags: [][]string{{goID, rustID}, {javaID}}, Words: []string{"fish"}}},
+		{name: "unknown tag in group", query: "[parser-go] OR [parser-missing] fish",
+			want: &schema.SearchCondition{Tags: [][]string{{goID}}, Words: []string{"fish"}}},
+		{name: "excluded tags", query: "-[parser-go] [parser-rust] -[parser-java] fish",
+			want: &schema.SearchCondition{Tags: [][]string{{rustID}}, ExcludedTags: []string{goID, javaID},
+				Words: []string{"fish"}}},
+		{name: "in title", query: "in:title fish",
+			want: &schema.SearchCondition{InTitle: true, TargetType: constant.QuestionObjectType,
+				Words: []string{"fish"}}},
+		{name: "words", query: "fish tank",
+			want: &schema.SearchCondition{Words: []string{"fish", "tank"}}},
+		{name: "words joined by OR", query: "fish OR tank",
+			want: &schema.SearchCondition{Words: []string{"fish", "tank"}, MatchAnyWord: true}},
+		{name: "quoted words joined by OR", query: `"warm water" OR tank`,
+			want: &schema.SearchCondition{Words: []string{`"warm water"`, "tank"}, MatchAnyWord: true}},
+		{name: "commented by username", query: "commented-by:parseruser fish",
+			want: &schema.SearchCondition{CommentUserID: parserUser.ID, Words: []string{"fish"}}},
+		{name: "commented by me", query: "commented-by:me fish", currentUserID: "42",
+			want: &schema.SearchCondition{CommentUserID: "42", Words: []string{"fish"}}},
+		{name: "commented by me anonymous", query: "commented-by:me fish",
+			want: &schema.SearchCondition{NoMatch: true, Words: []string{"fish"}}},
+		{name: "commented by unknown user", query: "commented-by:parsernobody fish",
+			want: &schema.SearchCondition{NoMatch: true, Words: []string{"fish"}}},
+		{name: "answered by username", query: "answered-by:parseruser fish",
+			want: &schema.SearchCondition{AnswerUserID: parserUser.ID, TargetType: constant.QuestionObjectType,
+				Words: []string{"fish"}}},
+		{name: "answered by me", query: "answered-by:me fish", currentUserID: "42",
+			want: &schema.SearchCondition{AnswerUserID: "42", TargetType: constant.QuestionObjectType,
+				Words: []string{"fish"}}},
+		{name: "answered by me anonymous", query: "answered-by:me fish",
+			want: &schema.SearchCondition{NoMatch: true, TargetType: constant.QuestionObjectType,
+				Words: []string{"fish"}}},
+		{name: "answered by unknown user", query: "answered-by:parsernobody fish",
+			want: &schema.SearchCondition{NoMatch: true, TargetType: constant.QuestionObjectType,
+				Words: []string{"fish"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.VoteAmount, tt.want.Views, tt.want.AnswerAmount = -1, -1, -1
+			cond := sp.ParseStructure(ctx, &schema.SearchDTO{Query: tt.query, UserID: tt.currentUserID})
+			for _, group := range cond.Tags {
+				assert.NotEmpty(t, group)
+			}
+			cond.Tags = sortTagGroups(cond.Tags)
+			tt.want.Tags = sortTagGroups(tt.want.Tags)
+			assert.ElementsMatch(t, tt.want.ExcludedTags, cond.ExcludedTags)
+			cond.ExcludedTags, tt.want.ExcludedTags = nil, nil
+			assert.Equal(t, tt.want, cond)
+		})
+	}
+}
+
+// sortTagGroups sorts the ids in each tag group, the order of ids in a group does not matter
+func sortTagGroups(groups [][]string) [][]string {
+	for _, group := range groups {
+		sort.Strings(group)
+	}
+	return groups
+}
@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/search_common"
+	"github.com/apache/answer/internal/repo/tag"
+	"github.com/apache/answer/internal/repo/tag_common"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/repo/user"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type searchSiteInfo struct {
+	siteinfo_common.SiteInfoCommonService
+}
+
+func (searchSiteInfo) GetSiteWrite(ctx context.Context) (*schema.SiteWriteResp, error) {
+	return &schema.SiteWriteResp{}, nil
+}
+
+func (searchSiteInfo) FormatListAvatar(ctx context.Context, userList []*entity.User) map[string]*schema.AvatarInfo {
+	return make(map[string]*schema.AvatarInfo)
+}
+
+func newSearchCondition(words ...string) *schema.SearchCondition {
+	return &schema.SearchCondition{Words: words, VoteAmount: -1, Views: -1, AnswerAmount: -1}
+}
+
+func searchResultIDs(res []*schema.SearchResult) (ids []string) {
+	for _, r := range res {
+		ids = append(ids, r.Object.ID)
+	}
+	return ids
+}
+
+func Test_searchRepo_Search(t *testing.T) {
+	ctx := context.TODO()
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	tagCommonService := tagcommon.NewTagCommonService(tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo),
+		tag.NewTagRelRepo(testDataSource, uniqueIDRepo), tag.NewTagRepo(testDataSource, uniqueIDRepo),
+		nil, searchSiteInfo{}, nil)
+	userCommon := usercommon.NewUserCommon(user.NewUserRepo(testDataSource), nil, nil, searchSiteInfo{})
+	searchRepo := search_common.NewSearchRepo(testDataSource, uniqueIDRepo, userCommon, tagCommonService)
+
+	january := time.Date(2021, 1, 10, 0, 0, 0, 0, time.Local)
+	june := time.Date(2021, 6, 10, 0, 0, 0, 0, time.Local)
+	_, err := testDataSource.DB.NoAutoTime().Insert([]*entity.Question{
+		{ID: "10010000000009201", UserID: "1000000000000009201", Title: "zebrafish tank setup",
+			OriginalText: "zebrafish need warm water", ParsedText: "zebrafish", Status: entity.QuestionStatusAvailable,
+			Show: entity.QuestionShow, AcceptedAnswerID: "10020000000009203", AnswerCount: 1,
+			CreatedAt: january, UpdatedAt: january, PostUpdateTime: january},
+		{ID: "10010000000009202", UserID: "1000000000000009202", Title: "feeding the fish",
+			OriginalText: "zebrafish eat flakes", ParsedText: "zebrafish", Status: entity.QuestionStatusClosed,
+			Show: entity.QuestionShow, AcceptedAnswerID: "0",
+			CreatedAt: june, UpdatedAt: june, PostUpdateTime: june},
+	})
+	require.NoError(t, err)
+	_, err = testDataSource.DB.NoAutoTime().Insert(&entity.Answer{ID: "10020000000009203",
+		QuestionID: "10010000000009201", UserID: "1000000000000009203", OriginalText: "zebrafish like 26 degrees",
+		ParsedText: "zebrafish", Status: entity.AnswerStatusAvailable, Accepted: schema.AnswerAcceptedEnable,
+		CreatedAt: june, UpdatedAt: june})
+	require.NoError(t, err)
+	_, err = testDataSource.DB.Insert([]*entity.TagRel{
+		{ObjectID: "10010000000009201", TagID: "10300000000009201", Status: entity.TagRelStatusAvailable},
+		{ObjectID: "10010000000009202", TagID: "10300000000009202", Status: entity.TagRelStatusAvailable},
+	})
+	require.NoError(t, err)
+	_, err = testDataSource.DB.Insert(&entity.Comment{ID: "10040000000009204", UserID: "1000000000000009204",
+		ObjectID: "10010000000009202", QuestionID: "10010000000009202", Status: entity.CommentStatusAvailable,
+		OriginalText: "nice", ParsedText: "nice"})
+	require.NoError(t, err)
+
+	questionCases := []struct {
+		name string
+		cond func(cond *schema.SearchCondition)
+		ids  []string
+	}{
+		{"all", func(cond *schema.SearchCondition) {}, []string{"10010000000009202", "10010000000009201"}},
+		{"excluded tag", func(cond *schema.SearchCondition) { cond.ExcludedTags = []string{"10300000000009202"} },
+			[]string{"10010000000009201"}},
+		{"tag or", func(cond *schema.SearchCondition) {
+			cond.Tags = [][]string{{"10300000000009201", "10300000000009202"}}
+		}, []string{"10010000000009202", "10010000000009201"}},
+		{"closed", func(cond *schema.SearchCondition) { cond.Closed = true }, []string{"10010000000009202"}},
+		{"unanswered", func(cond *schema.SearchCondition) { cond.AnswerAmount = 0 }, []string{"10010000000009202"}},
+		{"has accepted", func(cond *schema.SearchCondition) { cond.HasAccepted = true }, []string{"10010000000009201"}},
+		{"commented by", func(cond *schema.SearchCondition) { cond.CommentUserID = "1000000000000009204" },
+			[]string{"10010000000009202"}},
+		{"answered by", func(cond *schema.SearchCondition) { cond.AnswerUserID = "1000000000000009203" },
+			[]string{"10010000000009201"}},
+		{"created", func(cond *schema.SearchCondition) {
+			cond.CreatedFrom = time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local).Unix()
+			cond.CreatedTo = time.Date(2021, 2, 1, 0, 0, 0, 0, time.Local).Unix()
+		}, []string{"10010000000009201"}},
+		{"in title", func(cond *schema.SearchCondition) { cond.InTitle = true }, []string{"10010000000009201"}},
+	}
+	for _, c := range questionCases {
+		t.Run(c.name, func(t *testing.T) {
+			cond := newSearchCondition("zebrafish")
+			c.cond(cond)
+			res, total, err := searchRepo.SearchQuestions(ctx, cond, 1, 10, "newest")
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(c.ids)), total)
+			assert.Equal(t, c.ids, searchResultIDs(res))
+		})
+	}
+
+	cond := newSearchCondition("zebrafish")
+	cond.CreatedFrom = time.Date(2021, 6, 1, 0, 0, 0, 0, time.Local).Unix()
+	res, total, err := searchRepo.SearchContents(ctx, cond, 1, 10, "relevance")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.ElementsMatch(t, []string{"10010000000009202", "10020000000009203"}, searchResultIDs(res))
+
+	cond = newSearchCondition("zebrafish")
+	cond.ExcludedTags = []string{"10300000000009201"}
+	res, total, err = searchRepo.SearchAnswers(ctx, cond, 1, 10, "newest")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Empty(t, res)
+}
@@ -0,0 +1,139 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/comment"
+	"github.com/apache/answer/internal/repo/question"
+	"github.com/apache/answer/internal/repo/search_sync"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_searchChangeRepo_GetChangesSince(t *testing.T) {
+	ctx := context.TODO()
+	var (
+		uniqueIDRepo     = unique.NewUniqueIDRepo(testDataSource)
+		searchChangeRepo = search_sync.NewSearchChangeRepo(testDataSource)
+		questionRepo     = question.NewQuestionRepo(testDataSource, uniqueIDRepo, searchChangeRepo)
+		commentRepo      = comment.NewCommentRepo(testDataSource, uniqueIDRepo, searchChangeRepo)
+		syncer           = search_sync.NewPluginSyncer(testDataSource)
+	)
+	cursor, err := searchChangeRepo.GetLatestChangeID(ctx)
+	require.NoError(t, err)
+
+	q := &entity.Question{
+		UserID:       "1",
+		Title:        "how to sync the search engine",
+		OriginalText: "sync",
+		ParsedText:   "<p>sync</p>",
+		Status:       entity.QuestionStatusAvailable,
+		Show:         entity.QuestionShow,
+	}
+	require.NoError(t, questionRepo.AddQuestion(ctx, q))
+	require.NoError(t, questionRepo.UpdateSearch(ctx, q.ID))
+	require.NoError(t, commentRepo.AddComment(ctx, &entity.Comment{
+		UserID: "1", ObjectID: q.ID, QuestionID: q.ID, Status: entity.CommentStatusAvailable,
+		OriginalText: "use the cursor", ParsedText: "<p>use the cursor</p>",
+	}))
+	select {
+	case <-searchChangeRepo.Changed():
+	default:
+		t.Fatal("changes are not notified")
+	}
+
+	// the question changed twice is returned once with its comments
+	changes, nextCursor, err := syncer.GetChangesSince(ctx, cursor, 100)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, q.ID, changes[0].ObjectID)
+	assert.Equal(t, nextCursor, changes[0].Cursor)
+	assert.False(t, changes[0].Deleted)
+	assert.Equal(t, []string{"<p>use the cursor</p>"}, changes[0].Content.Comments)
+
+	count, _, err := searchChangeRepo.CountChanges(ctx, cursor)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// the hidden question is removed from the search with its answers
+	_, err = testDataSource.DB.Context(ctx).Insert(&entity.Answer{ID: "10020000000009105", QuestionID: q.ID,
+		UserID: "2", OriginalText: "answer", ParsedText: "<p>answer</p>", Status: entity.AnswerStatusAvailable})
+	require.NoError(t, err)
+	require.NoError(t, questionRepo.UpdateQuestionOperation(ctx, &entity.Question{ID: q.ID, Show: entity.QuestionHide}))
+	changes, nextCursor, err = syncer.GetChangesSince(ctx, nextCursor, 100)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	for _, change := range changes {
+		assert.Contains(t, []string{q.ID, "10020000000009105"}, change.ObjectID)
+		assert.True(t, change.Deleted)
+	}
+
+	// and it comes back with them
+	require.NoError(t, questionRepo.UpdateQuestionOperation(ctx, &entity.Question{ID: q.ID, Show: entity.QuestionShow}))
+	changes, nextCursor, err = syncer.GetChangesSince(ctx, nextCursor, 100)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	for _, change := range changes {
+		assert.False(t, change.Deleted)
+	}
+
+	changes, latest, err := syncer.GetChangesSince(ctx, nextCursor, 100)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, nextCursor, latest)
+}
+
+func Test_searchChangeRepo_PruneChanges(t *testing.T) {
+	ctx := context.TODO()
+	searchChangeRepo := search_sync.NewSearchChangeRepo(testDataSource)
+	require.NoError(t, searchChangeRepo.AddChanges(ctx, "10010000000009101", "10020000000009102"))
+	latestID, err := searchChangeRepo.GetLatestChangeID(ctx)
+	require.NoError(t, err)
+
+	// the disabled plugin keeps the changes it has not synced
+	require.NoError(t, searchChangeRepo.SaveCursor(ctx, &entity.SearchSyncCursor{
+		PluginSlugName: "test_search_synced", Cursor: latestID}))
+	require.NoError(t, searchChangeRepo.SaveCursor(ctx, &entity.SearchSyncCursor{
+		PluginSlugName: "test_search_behind", Cursor: latestID - 1}))
+	require.NoError(t, searchChangeRepo.PruneChanges(ctx, latestID, time.Now().Add(-time.Hour)))
+	count, _, err := searchChangeRepo.CountChanges(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// the expired changes are pruned anyway, the plugin behind has to be reindexed
+	require.NoError(t, searchChangeRepo.PruneChanges(ctx, latestID, time.Now().Add(time.Hour)))
+	count, _, err = searchChangeRepo.CountChanges(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	behind, exist, err := searchChangeRepo.GetCursor(ctx, "test_search_behind")
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, int64(entity.SearchSyncCursorReindex), behind.Cursor)
+	synced, _, err := searchChangeRepo.GetCursor(ctx, "test_search_synced")
+	require.NoError(t, err)
+	assert.Equal(t, latestID, synced.Cursor)
+}
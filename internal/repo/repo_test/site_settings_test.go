@@ -0,0 +1,200 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package repo_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/repo/config"
+	"github.com/apache/answer/internal/repo/plugin_config"
+	"github.com/apache/answer/internal/repo/search_index"
+	"github.com/apache/answer/internal/repo/site_info"
+	"github.com/apache/answer/internal/repo/tag"
+	"github.com/apache/answer/internal/repo/tag_common"
+	"github.com/apache/answer/internal/repo/unique"
+	"github.com/apache/answer/internal/schema"
+	configservice "github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/plugin_common"
+	searchindex "github.com/apache/answer/internal/service/search_index"
+	"github.com/apache/answer/internal/service/siteinfo"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	"github.com/apache/answer/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type settingsTestPlugin struct {
+	config map[string]any
+}
+
+func (p *settingsTestPlugin) Info() plugin.Info {
+	return plugin.Info{SlugName: "settings-test"}
+}
+
+func (p *settingsTestPlugin) ConfigFields() []plugin.ConfigField {
+	return []plugin.ConfigField{
+		{Name: "token", Type: plugin.ConfigTypeInput, Value: p.config["token"]},
+		{Name: "region", Type: plugin.ConfigTypeInput, Value: p.config["region"]},
+	}
+}
+
+func (p *settingsTestPlugin) ConfigReceiver(config []byte) error {
+	received := make(map[string]any)
+	if err := json.Unmarshal(config, &received); err != nil {
+		return err
+	}
+	p.config = received
+	if received["region"] == "bad" {
+		return fmt.Errorf("region is invalid")
+	}
+	return nil
+}
+
+var settingsPlugin = &settingsTestPlugin{}
+
+func init() {
+	plugin.Register(settingsPlugin)
+}
+
+func newTestSiteInfoService() (*siteinfo.SiteInfoService, *plugin_common.PluginCommonService, *configservice.ConfigService) {
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	siteInfoRepo := site_info.NewSiteInfo(testDataSource)
+	siteInfoCommonService := siteinfo_common.NewSiteInfoCommonService(siteInfoRepo)
+	tagCommonService := tagcommon.NewTagCommonService(tag_common.NewTagCommonRepo(testDataSource, uniqueIDRepo),
+		tag.NewTagRelRepo(testDataSource, uniqueIDRepo), tag.NewTagRepo(testDataSource, uniqueIDRepo),
+		nil, siteInfoCommonService, nil)
+	configService := configservice.NewConfigService(config.NewConfigRepo(testDataSource))
+	pluginCommonService := plugin_common.NewPluginCommonService(plugin_config.NewPluginConfigRepo(testDataSource),
+		plugin_config.NewPluginUserConfigRepo(testDataSource), configService, testDataSource, nil,
+		searchindex.NewSearchIndexService(search_index.NewSearchIndexRepo(testDataSource)))
+	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, nil, tagCommonService,
+		configService, nil, nil, pluginCommonService)
+	return siteInfoService, pluginCommonService, configService
+}
+
+func saveTestSiteSettings(t *testing.T, s *siteinfo.SiteInfoService, ps *plugin_common.PluginCommonService,
+	terms string, level schema.PrivilegeLevel, pluginConfig map[string]any) {
+	ctx := context.TODO()
+	require.NoError(t, s.SaveSiteLegal(ctx, &schema.SiteLegalReq{
+		TermsOfServiceOriginalText: terms,
+		ExternalContentDisplay:     "always_display",
+	}))
+	require.NoError(t, s.UpdatePrivilegesConfig(ctx, &schema.UpdatePrivilegesConfigReq{Level: level}))
+	require.NoError(t, ps.UpdatePluginConfig(ctx, &schema.UpdatePluginConfigReq{
+		PluginSlugName: settingsPlugin.Info().SlugName,
+		ConfigFields:   pluginConfig,
+	}))
+	settingsPlugin.config = pluginConfig
+}
+
+func Test_siteInfoService_ExportImportSiteSettings(t *testing.T) {
+	ctx := context.TODO()
+	// the language of the interface section is checked with the language options
+	_, err := translator.NewTranslator(&translator.I18n{BundleDir: "../../../i18n"})
+	require.NoError(t, err)
+	s, ps, configService := newTestSiteInfoService()
+	var privilegeKey string
+	for i, privilege := range schema.DefaultPrivilegeOptions.Choose(schema.PrivilegeLevel1).Privileges {
+		if privilege.Value != schema.DefaultPrivilegeOptions.Choose(schema.PrivilegeLevel3).Privileges[i].Value {
+			privilegeKey = privilege.Key
+			break
+		}
+	}
+	saveTestSiteSettings(t, s, ps, "exported terms", schema.PrivilegeLevel1,
+		map[string]any{"token": "exported", "region": "us"})
+	exportedPrivilege, err := configService.GetIntValue(ctx, privilegeKey)
+	require.NoError(t, err)
+
+	exported, err := s.ExportSiteSettings(ctx)
+	require.NoError(t, err)
+	exportedSettings := &schema.SiteSettings{}
+	require.NoError(t, json.Unmarshal(exported.Content, exportedSettings))
+	assert.Contains(t, exportedSettings.SiteInfo, constant.SiteTypeLegal)
+	assert.Equal(t, map[string]any{"token": "exported", "region": "us"},
+		exportedSettings.PluginConfigs[settingsPlugin.Info().SlugName])
+
+	changedConfig := map[string]any{"token": "changed", "region": "eu"}
+	saveTestSiteSettings(t, s, ps, "changed terms", schema.PrivilegeLevel3, changedConfig)
+	changedPrivilege, err := configService.GetIntValue(ctx, privilegeKey)
+	require.NoError(t, err)
+	require.NotEqual(t, exportedPrivilege, changedPrivilege)
+
+	// the dry run changes nothing, the plugin uses the config it used before
+	resp, err := s.ImportSiteSettings(ctx, &schema.ImportSiteSettingsReq{Content: string(exported.Content), DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	assert.Contains(t, resp.SiteInfo, constant.SiteTypeLegal)
+	assert.Equal(t, []string{settingsPlugin.Info().SlugName}, resp.PluginConfigs)
+	legal, err := s.GetSiteLegal(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "changed terms", legal.TermsOfServiceOriginalText)
+	assert.Equal(t, changedConfig, settingsPlugin.config)
+
+	// the rejected plugin config changes nothing either
+	invalidSettings := *exportedSettings
+	invalidSettings.PluginConfigs = map[string]map[string]any{
+		settingsPlugin.Info().SlugName: {"token": "invalid", "region": "bad"},
+	}
+	invalidContent, _ := json.Marshal(invalidSettings)
+	resp, err = s.ImportSiteSettings(ctx, &schema.ImportSiteSettingsReq{Content: string(invalidContent)})
+	assert.Error(t, err)
+	require.Len(t, resp.Problems, 1)
+	assert.Equal(t, settingsPlugin.Info().SlugName, resp.Problems[0].Key)
+	assert.Equal(t, changedConfig, settingsPlugin.config)
+	legal, err = s.GetSiteLegal(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "changed terms", legal.TermsOfServiceOriginalText)
+
+	_, err = s.ImportSiteSettings(ctx, &schema.ImportSiteSettingsReq{Content: string(exported.Content)})
+	require.NoError(t, err)
+	legal, err = s.GetSiteLegal(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "exported terms", legal.TermsOfServiceOriginalText)
+	privilege, err := configService.GetIntValue(ctx, privilegeKey)
+	require.NoError(t, err)
+	assert.Equal(t, exportedPrivilege, privilege)
+	assert.Equal(t, map[string]any{"token": "exported", "region": "us"}, settingsPlugin.config)
+
+	// the imported site exports the same settings, the site info is saved as the api saves it,
+	// so the file exported after importing it again is the same
+	reexported, err := s.ExportSiteSettings(ctx)
+	require.NoError(t, err)
+	reexportedSettings := &schema.SiteSettings{}
+	require.NoError(t, json.Unmarshal(reexported.Content, reexportedSettings))
+	assert.Equal(t, exportedSettings.Privileges, reexportedSettings.Privileges)
+	assert.Equal(t, exportedSettings.PluginStatus, reexportedSettings.PluginStatus)
+	assert.Equal(t, exportedSettings.PluginConfigs, reexportedSettings.PluginConfigs)
+	assert.JSONEq(t, string(exportedSettings.SiteInfo[constant.SiteTypeLegal]),
+		string(reexportedSettings.SiteInfo[constant.SiteTypeLegal]))
+
+	_, err = s.ImportSiteSettings(ctx, &schema.ImportSiteSettingsReq{Content: string(reexported.Content)})
+	require.NoError(t, err)
+	again, err := s.ExportSiteSettings(ctx)
+	require.NoError(t, err)
+	againSettings := &schema.SiteSettings{}
+	require.NoError(t, json.Unmarshal(again.Content, againSettings))
+	againSettings.ExportedAt = reexportedSettings.ExportedAt
+	assert.Equal(t, reexportedSettings, againSettings)
+}
@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/repo/webhook"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_webhookRepo_GetActiveWebhooks(t *testing.T) {
+	webhookRepo := webhook.NewWebhookRepo(testDataSource)
+	active := &entity.Webhook{ID: uid.ID().String(), Name: "active", URL: "http://localhost",
+		EventTypes: "answer.create", Status: entity.WebhookStatusActive}
+	inactive := &entity.Webhook{ID: uid.ID().String(), Name: "inactive", URL: "http://localhost",
+		EventTypes: "answer.create", Status: entity.WebhookStatusInactive}
+	require.NoError(t, webhookRepo.AddWebhook(context.TODO(), active))
+	require.NoError(t, webhookRepo.AddWebhook(context.TODO(), inactive))
+
+	webhooks, err := webhookRepo.GetActiveWebhooks(context.TODO())
+	assert.NoError(t, err)
+	ids := make([]string, 0)
+	for _, w := range webhooks {
+		ids = append(ids, w.ID)
+	}
+	assert.Contains(t, ids, active.ID)
+	assert.NotContains(t, ids, inactive.ID)
+
+	updated, err := webhookRepo.UpdateWebhook(context.TODO(), &entity.Webhook{ID: active.ID, Name: "renamed",
+		URL: "http://localhost", EventTypes: "answer.create", Status: entity.WebhookStatusActive})
+	assert.NoError(t, err)
+	assert.True(t, updated)
+
+	require.NoError(t, webhookRepo.DeleteWebhook(context.TODO(), active.ID))
+	_, exist, err := webhookRepo.GetWebhook(context.TODO(), active.ID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	// the deleted webhook is not brought back by an update
+	updated, err = webhookRepo.UpdateWebhook(context.TODO(), &entity.Webhook{ID: active.ID, Name: "renamed",
+		URL: "http://localhost", EventTypes: "answer.create", Status: entity.WebhookStatusActive})
+	assert.NoError(t, err)
+	assert.False(t, updated)
+	_, exist, err = webhookRepo.GetWebhook(context.TODO(), active.ID)
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	require.NoError(t, webhookRepo.DeleteWebhook(context.TODO(), inactive.ID))
+}
+
+func Test_webhookRepo_Delivery(t *testing.T) {
+	webhookRepo := webhook.NewWebhookRepo(testDataSource)
+	webhookID, deliveryID := uid.ID().String(), uid.ID().String()
+	for attempt := 1; attempt <= 2; attempt++ {
+		err := webhookRepo.AddDelivery(context.TODO(), &entity.WebhookDelivery{
+			WebhookID:   webhookID,
+			DeliveryID:  deliveryID,
+			EventType:   "answer.create",
+			Attempt:     attempt,
+			RequestBody: "{}",
+			Success:     attempt == 2,
+		})
+		require.NoError(t, err)
+	}
+
+	count, err := webhookRepo.CountDeliveryAttempts(context.TODO(), deliveryID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	deliveries, total, err := webhookRepo.GetDeliveryPage(context.TODO(), 1, 10, webhookID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, 2, deliveries[0].Attempt)
+	assert.True(t, deliveries[0].Success)
+}
@@ -21,6 +21,7 @@ package review
 
 import (
 	"context"
+	"time"
 
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/pager"
@@ -91,6 +92,18 @@ func (cr *reviewRepo) GetReviewCount(ctx context.Context, status int) (count int
 	return
 }
 
+// GetUserRejectedReviewCount get the count of the rejected reviews of the user's posts since the time,
+// the reviews rejected because the user deleted the posts are not counted
+func (cr *reviewRepo) GetUserRejectedReviewCount(ctx context.Context, userID string, since time.Time) (
+	count int64, err error) {
+	count, err = cr.data.DB.Context(ctx).Where("user_id = ?", userID).And("reviewer_user_id <> ?", userID).
+		And("status = ?", entity.ReviewStatusRejected).And("updated_at >= ?", since).Count(&entity.Review{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // GetReviewPage get review page
 func (cr *reviewRepo) GetReviewPage(ctx context.Context, page, pageSize int, cond *entity.Review) (
 	reviewList []*entity.Review, total int64, err error) {
@@ -24,6 +24,7 @@ import (
 
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/service/role"
 	"github.com/segmentfault/pacman/errors"
 	"xorm.io/builder"
@@ -51,3 +52,25 @@ func (rr *rolePowerRelRepo) GetRolePowerTypeList(ctx context.Context, roleID int
 	}
 	return
 }
+
+// GetRolesPowerTypeList get the power types of the roles
+func (rr *rolePowerRelRepo) GetRolesPowerTypeList(ctx context.Context, roleIDs []int) (powers []string, err error) {
+	powers = make([]string, 0)
+	err = rr.data.DB.Context(ctx).Table("role_power_rel").
+		Cols("power_type").In("role_id", roleIDs).Find(&powers)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetRolePowerRelList get role power rel list by role ids
+func (rr *rolePowerRelRepo) GetRolePowerRelList(ctx context.Context, roleIDs []int) (
+	rels []*entity.RolePowerRel, err error) {
+	rels = make([]*entity.RolePowerRel, 0)
+	err = rr.data.DB.Context(ctx).In("role_id", roleIDs).Asc("id").Find(&rels)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/apache/answer/internal/entity"
 	service "github.com/apache/answer/internal/service/role"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 // roleRepo role repository
@@ -63,3 +65,79 @@ func (rr *roleRepo) GetRoleAllMapping(ctx context.Context) (roleMapping map[int]
 	}
 	return roleMapping, nil
 }
+
+// GetRole get role by id
+func (rr *roleRepo) GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error) {
+	role = &entity.Role{}
+	exist, err = rr.data.DB.Context(ctx).ID(roleID).Get(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddRole add role with its powers
+func (rr *roleRepo) AddRole(ctx context.Context, role *entity.Role, powerTypes []string) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Insert(role); err != nil {
+			return nil, err
+		}
+		return nil, insertRolePowers(session, role.ID, powerTypes)
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateRole update role and replace its powers
+func (rr *roleRepo) UpdateRole(ctx context.Context, role *entity.Role, powerTypes []string) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.ID(role.ID).Cols("name", "description").Update(role); err != nil {
+			return nil, err
+		}
+		if _, err := session.Where(builder.Eq{"role_id": role.ID}).Delete(&entity.RolePowerRel{}); err != nil {
+			return nil, err
+		}
+		return nil, insertRolePowers(session, role.ID, powerTypes)
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveRole remove role, its powers and the users' relations
+func (rr *roleRepo) RemoveRole(ctx context.Context, roleID int) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.ID(roleID).Delete(&entity.Role{}); err != nil {
+			return nil, err
+		}
+		if _, err := session.Where(builder.Eq{"role_id": roleID}).Delete(&entity.RolePowerRel{}); err != nil {
+			return nil, err
+		}
+		if _, err := session.Where(builder.Eq{"role_id": roleID}).Delete(&entity.UserRoleRel{}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func insertRolePowers(session *xorm.Session, roleID int, powerTypes []string) (err error) {
+	if len(powerTypes) == 0 {
+		return nil
+	}
+	rels := make([]*entity.RolePowerRel, 0, len(powerTypes))
+	for _, powerType := range powerTypes {
+		rels = append(rels, &entity.RolePowerRel{RoleID: roleID, PowerType: powerType})
+	}
+	_, err = session.Insert(rels)
+	return err
+}
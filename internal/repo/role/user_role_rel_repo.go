@@ -43,12 +43,12 @@ func NewUserRoleRelRepo(data *data.Data) role.UserRoleRelRepo {
 	}
 }
 
-// SaveUserRoleRel save user role rel
+// SaveUserRoleRel save user built-in role rel
 func (ur *userRoleRelRepo) SaveUserRoleRel(ctx context.Context, userID string, roleID int) (err error) {
 	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
 		session = session.Context(ctx)
 		item := &entity.UserRoleRel{UserID: userID}
-		exist, err := session.Get(item)
+		exist, err := session.In("role_id", role.BuiltInRoleIDs).Get(item)
 		if err != nil {
 			return nil, err
 		}
@@ -91,11 +91,37 @@ func (ur *userRoleRelRepo) GetUserRoleRelListByRoleID(ctx context.Context, roleI
 	return
 }
 
-// GetUserRoleRel get user role
+// GetUserRoleRel get user built-in role
 func (ur *userRoleRelRepo) GetUserRoleRel(ctx context.Context, userID string) (
 	rolePowerRel *entity.UserRoleRel, exist bool, err error) {
 	rolePowerRel = &entity.UserRoleRel{}
-	exist, err = ur.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).Get(rolePowerRel)
+	exist, err = ur.data.DB.Context(ctx).Where(builder.Eq{"user_id": userID}).
+		In("role_id", role.BuiltInRoleIDs).Get(rolePowerRel)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveUserCustomRoleRel replace the custom roles of the user
+func (ur *userRoleRelRepo) SaveUserCustomRoleRel(ctx context.Context, userID string, roleIDs []int) (err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		_, err := session.Where(builder.Eq{"user_id": userID}).
+			NotIn("role_id", role.BuiltInRoleIDs).Delete(&entity.UserRoleRel{})
+		if err != nil {
+			return nil, err
+		}
+		if len(roleIDs) == 0 {
+			return nil, nil
+		}
+		rels := make([]*entity.UserRoleRel, 0, len(roleIDs))
+		for _, roleID := range roleIDs {
+			rels = append(rels, &entity.UserRoleRel{UserID: userID, RoleID: roleID})
+		}
+		_, err = session.Insert(rels)
+		return nil, err
+	})
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -99,8 +99,8 @@ func NewSearchRepo(
 }
 
 // SearchContents search question and answer data
-func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs [][]string, userID string, votes int, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
-	words = filterWords(words)
+func (sr *searchRepo) SearchContents(ctx context.Context, cond *schema.SearchCondition, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words := filterWords(cond.Words)
 
 	var (
 		b     *builder.Builder
@@ -148,47 +148,8 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	b.Where(likeConQ)
 	ub.Where(likeConA)
 
-	// check tag
-	for ti, tagID := range tagIDs {
-		ast := "tag_rel" + strconv.Itoa(ti)
-		b.Join("INNER", "tag_rel as "+ast, "question.id = "+ast+".object_id").
-			And(builder.Eq{
-				ast + ".status": entity.TagRelStatusAvailable,
-			}).
-			And(builder.In(ast+".tag_id", tagID))
-		ub.Join("INNER", "tag_rel as "+ast, "question_id = "+ast+".object_id").
-			And(builder.Eq{
-				ast + ".status": entity.TagRelStatusAvailable,
-			}).
-			And(builder.In(ast+".tag_id", tagID))
-		argsQ = append(argsQ, entity.TagRelStatusAvailable)
-		argsA = append(argsA, entity.TagRelStatusAvailable)
-		for _, t := range tagID {
-			argsQ = append(argsQ, t)
-			argsA = append(argsA, t)
-		}
-	}
-
-	// check user
-	if userID != "" {
-		b.Where(builder.Eq{"question.user_id": userID})
-		ub.Where(builder.Eq{"answer.user_id": userID})
-		argsQ = append(argsQ, userID)
-		argsA = append(argsA, userID)
-	}
-
-	// check vote
-	if votes == 0 {
-		b.Where(builder.Eq{"question.vote_count": votes})
-		ub.Where(builder.Eq{"answer.vote_count": votes})
-		argsQ = append(argsQ, votes)
-		argsA = append(argsA, votes)
-	} else if votes > 0 {
-		b.Where(builder.Gte{"question.vote_count": votes})
-		ub.Where(builder.Gte{"answer.vote_count": votes})
-		argsQ = append(argsQ, votes)
-		argsA = append(argsA, votes)
-	}
+	argsQ = append(argsQ, commonFilter(b, "`question`", "`question`.`id`", cond)...)
+	argsA = append(argsA, commonFilter(ub, "`answer`", "`answer`.`question_id`", cond)...)
 
 	//b = b.Union("all", ub)
 	ubSQL, _, err := ub.ToSQL()
@@ -242,15 +203,20 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 }
 
 // SearchQuestions search question data
-func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagIDs [][]string, notAccepted bool, views, answers int, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
-	words = filterWords(words)
+func (sr *searchRepo) SearchQuestions(ctx context.Context, cond *schema.SearchCondition, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words := filterWords(cond.Words)
 	var (
-		qfs  = qFields
-		args = []interface{}{}
+		qfs          = qFields
+		args         = []interface{}{}
+		searchFields = []string{"title", "original_text"}
 	)
+	// the keywords only match the title
+	if cond.InTitle {
+		searchFields = []string{"title"}
+	}
 	if order == "relevance" {
 		if len(words) > 0 {
-			qfs, args = addRelevanceField([]string{"title", "original_text"}, words, qfs)
+			qfs, args = addRelevanceField(searchFields, words, qfs)
 		} else {
 			order = "newest"
 		}
@@ -263,54 +229,54 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 
 	likeConQ := builder.NewCond()
 	for _, word := range words {
-		likeConQ = likeConQ.Or(builder.Like{"title", word}).
-			Or(builder.Like{"original_text", word})
-		args = append(args, "%"+word+"%")
-		args = append(args, "%"+word+"%")
+		for _, field := range searchFields {
+			likeConQ = likeConQ.Or(builder.Like{field, word})
+			args = append(args, "%"+word+"%")
+		}
 	}
 	b.Where(likeConQ)
 
-	// check tag
-	for ti, tagID := range tagIDs {
-		ast := "tag_rel" + strconv.Itoa(ti)
-		b.Join("INNER", "tag_rel as "+ast, "question.id = "+ast+".object_id").
-			And(builder.Eq{
-				ast + ".status": entity.TagRelStatusAvailable,
-			}).
-			And(builder.In(ast+".tag_id", tagID))
-		args = append(args, entity.TagRelStatusAvailable)
-		for _, t := range tagID {
-			args = append(args, t)
-		}
-	}
+	args = append(args, commonFilter(b, "`question`", "`question`.`id`", cond)...)
 
 	// check need filter has not accepted
-	if notAccepted {
+	if cond.NotAccepted {
 		b.And(builder.Eq{"accepted_answer_id": 0})
 		args = append(args, 0)
 	}
 
-	// check views
-	if views > -1 {
-		b.And(builder.Gte{"view_count": views})
-		args = append(args, views)
+	// check need filter has accepted
+	if cond.HasAccepted {
+		b.And(builder.Gt{"accepted_answer_id": 0})
+		args = append(args, 0)
 	}
 
-	// check answers
-	if answers == 0 {
-		b.And(builder.Eq{"answer_count": answers})
-		args = append(args, answers)
-	} else if answers > 0 {
-		b.And(builder.Gte{"answer_count": answers})
-		args = append(args, answers)
+	// check closed
+	if cond.Closed {
+		b.And(builder.Eq{"`question`.`status`": entity.QuestionStatusClosed})
+		args = append(args, entity.QuestionStatusClosed)
+	}
+
+	// check views
+	if cond.Views > -1 {
+		b.And(builder.Gte{"view_count": cond.Views})
+		args = append(args, cond.Views)
 	}
 
-	if answers == 0 {
+	// check answers
+	if cond.AnswerAmount == 0 {
 		b.And(builder.Eq{"answer_count": 0})
 		args = append(args, 0)
-	} else if answers > 0 {
-		b.And(builder.Gte{"answer_count": answers})
-		args = append(args, answers)
+	} else if cond.AnswerAmount > 0 {
+		b.And(builder.Gte{"answer_count": cond.AnswerAmount})
+		args = append(args, cond.AnswerAmount)
+	}
+
+	// check the user answered
+	if cond.AnswerUserID != "" {
+		b.And(builder.In("`question`.`id`", builder.Select("question_id").From("answer").
+			Where(builder.Eq{"user_id": cond.AnswerUserID}).
+			And(builder.Lt{"status": entity.AnswerStatusDeleted})))
+		args = append(args, cond.AnswerUserID, entity.AnswerStatusDeleted)
 	}
 
 	queryArgs := []interface{}{}
@@ -353,8 +319,8 @@ func (sr *searchRepo) SearchQuestions(ctx context.Context, words []string, tagID
 }
 
 // SearchAnswers search answer data
-func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs [][]string, accepted bool, questionID string, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
-	words = filterWords(words)
+func (sr *searchRepo) SearchAnswers(ctx context.Context, cond *schema.SearchCondition, page, pageSize int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words := filterWords(cond.Words)
 
 	var (
 		afs  = aFields
@@ -383,30 +349,18 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 
 	b.Where(likeConA)
 
-	// check tag
-	for ti, tagID := range tagIDs {
-		ast := "tag_rel" + strconv.Itoa(ti)
-		b.Join("INNER", "tag_rel as "+ast, "question_id = "+ast+".object_id").
-			And(builder.Eq{
-				ast + ".status": entity.TagRelStatusAvailable,
-			}).
-			And(builder.In(ast+".tag_id", tagID))
-		args = append(args, entity.TagRelStatusAvailable)
-		for _, t := range tagID {
-			args = append(args, t)
-		}
-	}
+	args = append(args, commonFilter(b, "`answer`", "`answer`.`question_id`", cond)...)
 
 	// check limit accepted
-	if accepted {
+	if cond.Accepted {
 		b.Where(builder.Eq{"adopted": schema.AnswerAcceptedEnable})
 		args = append(args, schema.AnswerAcceptedEnable)
 	}
 
 	// check question id
-	if questionID != "" {
-		b.Where(builder.Eq{"question_id": questionID})
-		args = append(args, questionID)
+	if cond.QuestionID != "" {
+		b.Where(builder.Eq{"question_id": cond.QuestionID})
+		args = append(args, cond.QuestionID)
 	}
 
 	queryArgs := []interface{}{}
@@ -616,3 +570,89 @@ func filterWords(words []string) (res []string) {
 	}
 	return
 }
+
+// commonFilter adds the conditions which both the questions and the answers support to the builder,
+// the args are returned in the order of their placeholders.
+func commonFilter(b *builder.Builder, objectTable, questionIDField string, cond *schema.SearchCondition) (
+	args []interface{}) {
+	// check tag
+	for ti, tagID := range cond.Tags {
+		ast := "tag_rel" + strconv.Itoa(ti)
+		b.Join("INNER", "tag_rel as "+ast, questionIDField+" = "+ast+".object_id").
+			And(builder.Eq{
+				ast + ".status": entity.TagRelStatusAvailable,
+			}).
+			And(builder.In(ast+".tag_id", tagID))
+		args = append(args, entity.TagRelStatusAvailable)
+		for _, t := range tagID {
+			args = append(args, t)
+		}
+	}
+
+	// check excluded tag
+	if len(cond.ExcludedTags) > 0 {
+		b.And(builder.NotIn(questionIDField, builder.Select("object_id").From("tag_rel").
+			Where(builder.In("tag_id", cond.ExcludedTags)).
+			And(builder.Eq{"status": entity.TagRelStatusAvailable})))
+		for _, t := range cond.ExcludedTags {
+			args = append(args, t)
+		}
+		args = append(args, entity.TagRelStatusAvailable)
+	}
+
+	// check hierarchical tag
+	if len(cond.HierarchicalTagIDs) > 0 {
+		hierarchicalCond, hierarchicalArgs := hierarchicalTagCond(questionIDField, cond.HierarchicalTagIDs)
+		b.And(hierarchicalCond)
+		args = append(args, hierarchicalArgs...)
+	}
+
+	// check user
+	if cond.UserID != "" {
+		b.Where(builder.Eq{objectTable + ".`user_id`": cond.UserID})
+		args = append(args, cond.UserID)
+	}
+
+	// check vote
+	if cond.VoteAmount == 0 {
+		b.Where(builder.Eq{objectTable + ".`vote_count`": cond.VoteAmount})
+		args = append(args, cond.VoteAmount)
+	} else if cond.VoteAmount > 0 {
+		b.Where(builder.Gte{objectTable + ".`vote_count`": cond.VoteAmount})
+		args = append(args, cond.VoteAmount)
+	}
+
+	// check created time
+	if cond.CreatedFrom > 0 {
+		createdFrom := time.Unix(cond.CreatedFrom, 0).Format(time.DateTime)
+		b.And(builder.Gte{objectTable + ".`created_at`": createdFrom})
+		args = append(args, createdFrom)
+	}
+	if cond.CreatedTo > 0 {
+		createdTo := time.Unix(cond.CreatedTo, 0).Format(time.DateTime)
+		b.And(builder.Lt{objectTable + ".`created_at`": createdTo})
+		args = append(args, createdTo)
+	}
+
+	// check the user commented
+	if cond.CommentUserID != "" {
+		b.And(builder.In(objectTable+".`id`", builder.Select("object_id").From("comment").
+			Where(builder.Eq{"user_id": cond.CommentUserID}).
+			And(builder.Eq{"status": entity.CommentStatusAvailable})))
+		args = append(args, cond.CommentUserID, entity.CommentStatusAvailable)
+	}
+	return args
+}
+
+// hierarchicalTagCond matches the questions filed under any of the hierarchical tags,
+// the args are returned in the order of their placeholders.
+func hierarchicalTagCond(questionIDField string, hierarchicalTagIDs []string) (cond builder.Cond, args []interface{}) {
+	sub := builder.Select("question_id").From("question_hierarchical_tag_rel").
+		Where(builder.In("hierarchical_tag_id", hierarchicalTagIDs)).
+		And(builder.Eq{"status": entity.QuestionHierarchicalTagRelStatusAvailable})
+	for _, id := range hierarchicalTagIDs {
+		args = append(args, id)
+	}
+	args = append(args, entity.QuestionHierarchicalTagRelStatusAvailable)
+	return builder.In(questionIDField, sub), args
+}
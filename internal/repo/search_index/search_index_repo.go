@@ -0,0 +1,281 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_index
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/search_index"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+const batchSize = 500
+
+// searchIndexRepo search index repository
+type searchIndexRepo struct {
+	data *data.Data
+}
+
+// NewSearchIndexRepo new repository
+func NewSearchIndexRepo(data *data.Data) search_index.SearchIndexRepo {
+	return &searchIndexRepo{
+		data: data,
+	}
+}
+
+// SaveDocument replaces the document with its tags and terms
+func (sr *searchIndexRepo) SaveDocument(ctx context.Context, doc *entity.SearchIndexDocument,
+	tags []*entity.SearchIndexTag, terms []*entity.SearchIndexTerm) (err error) {
+	_, err = sr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if err = deleteDocument(session, doc.ObjectID); err != nil {
+			return nil, err
+		}
+		if _, err = session.Insert(doc); err != nil {
+			return nil, err
+		}
+		if len(tags) > 0 {
+			if _, err = session.Insert(tags); err != nil {
+				return nil, err
+			}
+		}
+		for start := 0; start < len(terms); start += batchSize {
+			if _, err = session.Insert(terms[start:min(start+batchSize, len(terms))]); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// DeleteDocument deletes the document with its tags and terms
+func (sr *searchIndexRepo) DeleteDocument(ctx context.Context, objectID string) (err error) {
+	_, err = sr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		return nil, deleteDocument(session.Context(ctx), objectID)
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func deleteDocument(session *xorm.Session, objectID string) (err error) {
+	if _, err = session.Where("object_id = ?", objectID).Delete(&entity.SearchIndexTerm{}); err != nil {
+		return err
+	}
+	if _, err = session.Where("object_id = ?", objectID).Delete(&entity.SearchIndexTag{}); err != nil {
+		return err
+	}
+	_, err = session.Where("object_id = ?", objectID).Delete(&entity.SearchIndexDocument{})
+	return err
+}
+
+// GetTerms gets the occurrences of each term with the highest weights, at most limit of them for a term
+func (sr *searchIndexRepo) GetTerms(ctx context.Context, terms []string, titleOnly bool, limit int) (
+	postings []*entity.SearchIndexTerm, err error) {
+	postings = make([]*entity.SearchIndexTerm, 0)
+	for _, term := range terms {
+		termPostings := make([]*entity.SearchIndexTerm, 0)
+		session := sr.data.DB.Context(ctx).Where("term = ?", term)
+		if titleOnly {
+			session.And("title_frequency > 0")
+		}
+		err = session.Desc("weight").Limit(limit).Find(&termPostings)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		postings = append(postings, termPostings...)
+	}
+	return postings, nil
+}
+
+// GetTermDocCounts gets the amount of the documents containing each term
+func (sr *searchIndexRepo) GetTermDocCounts(ctx context.Context, terms []string, titleOnly bool) (
+	docCounts map[string]int64, err error) {
+	docCounts = make(map[string]int64, len(terms))
+	if len(terms) == 0 {
+		return docCounts, nil
+	}
+	type termCount struct {
+		Term  string `xorm:"term"`
+		Count int64  `xorm:"amount"`
+	}
+	counts := make([]*termCount, 0)
+	session := sr.data.DB.Context(ctx).Table(entity.SearchIndexTerm{}.TableName()).
+		Select("term, COUNT(*) AS amount").In("term", terms)
+	if titleOnly {
+		session.And("title_frequency > 0")
+	}
+	if err = session.GroupBy("term").Find(&counts); err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, c := range counts {
+		docCounts[c.Term] = c.Count
+	}
+	return docCounts, nil
+}
+
+// GetStats gets the amount and the average length of the documents
+func (sr *searchIndexRepo) GetStats(ctx context.Context) (docCount int64, avgLength float64, err error) {
+	docCount, err = sr.data.DB.Context(ctx).Count(&entity.SearchIndexDocument{})
+	if err != nil {
+		return 0, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if docCount == 0 {
+		return 0, 0, nil
+	}
+	totalLength, err := sr.data.DB.Context(ctx).SumInt(&entity.SearchIndexDocument{}, "length")
+	if err != nil {
+		return 0, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return docCount, float64(totalLength) / float64(docCount), nil
+}
+
+// GetObjectIDs gets the ids of the objects matching the conditions among the given ones
+func (sr *searchIndexRepo) GetObjectIDs(ctx context.Context, objectIDs []string, objectType string,
+	cond *plugin.SearchBasicCond) (matched []string, err error) {
+	matched = make([]string, 0, len(objectIDs))
+	if len(objectIDs) == 0 {
+		return matched, nil
+	}
+	session := sr.data.DB.Context(ctx).Table(entity.SearchIndexDocument{}.TableName()).In("object_id", objectIDs)
+	err = sr.filter(session, objectType, cond).Cols("object_id").Find(&matched)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDocumentPage gets the documents matching the conditions by page, only among the object ids if any
+func (sr *searchIndexRepo) GetDocumentPage(ctx context.Context, objectIDs []string, objectType string,
+	cond *plugin.SearchBasicCond, page, pageSize int) (docs []*entity.SearchIndexDocument, total int64, err error) {
+	docs = make([]*entity.SearchIndexDocument, 0)
+	session := sr.filter(sr.data.DB.Context(ctx), objectType, cond)
+	if objectIDs != nil {
+		session.In("object_id", objectIDs)
+	}
+	switch cond.Order {
+	case plugin.SearchActiveOrder:
+		session.Desc("active")
+	case plugin.SearchScoreOrder:
+		session.Desc("score")
+	default:
+		session.Desc("created")
+	}
+	total, err = session.Limit(pageSize, (page-1)*pageSize).FindAndCount(&docs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func (sr *searchIndexRepo) filter(session *xorm.Session, objectType string,
+	cond *plugin.SearchBasicCond) *xorm.Session {
+	if len(objectType) > 0 {
+		session.And("object_type = ?", objectType)
+	}
+	for _, tagIDs := range cond.TagIDs {
+		session.And(builder.In("object_id", builder.Select("object_id").
+			From(entity.SearchIndexTag{}.TableName()).Where(builder.In("tag_id", tagIDs))))
+	}
+	if len(cond.ExcludedTagIDs) > 0 {
+		session.And(builder.NotIn("object_id", builder.Select("object_id").
+			From(entity.SearchIndexTag{}.TableName()).Where(builder.In("tag_id", cond.ExcludedTagIDs))))
+	}
+	if len(cond.HierarchicalTagIDs) > 0 {
+		session.And(builder.In("question_id", builder.Select("question_id").
+			From(entity.QuestionHierarchicalTagRel{}.TableName()).
+			Where(builder.In("hierarchical_tag_id", cond.HierarchicalTagIDs)).
+			And(builder.Eq{"status": entity.QuestionHierarchicalTagRelStatusAvailable})))
+	}
+	if len(cond.UserID) > 0 {
+		session.And("user_id = ?", cond.UserID)
+	}
+	if len(cond.QuestionID) > 0 {
+		session.And("question_id = ?", cond.QuestionID)
+	}
+	if len(cond.CommentUserID) > 0 {
+		session.And(builder.Like{"comment_users", "," + cond.CommentUserID + ","})
+	}
+	if len(cond.AnswerUserID) > 0 {
+		session.And(builder.Like{"answer_users", "," + cond.AnswerUserID + ","})
+	}
+	if cond.CreatedFrom > 0 {
+		session.And("created >= ?", cond.CreatedFrom)
+	}
+	if cond.CreatedTo > 0 {
+		session.And("created < ?", cond.CreatedTo)
+	}
+	if cond.Closed {
+		session.And("status = ?", plugin.SearchContentStatusClosed)
+	}
+	if cond.QuestionAccepted != plugin.AcceptedCondAll {
+		session.And(builder.Or(builder.Neq{"object_type": constant.QuestionObjectType},
+			builder.Eq{"has_accepted": cond.QuestionAccepted == plugin.AcceptedCondTrue}))
+	}
+	if cond.AnswerAccepted != plugin.AcceptedCondAll {
+		session.And(builder.Or(builder.Neq{"object_type": constant.AnswerObjectType},
+			builder.Eq{"has_accepted": cond.AnswerAccepted == plugin.AcceptedCondTrue}))
+	}
+	if cond.VoteAmount == 0 {
+		session.And("score = ?", 0)
+	} else if cond.VoteAmount > 0 {
+		session.And("score >= ?", cond.VoteAmount)
+	}
+	if cond.ViewAmount > -1 {
+		session.And("views >= ?", cond.ViewAmount)
+	}
+	if cond.AnswerAmount == 0 {
+		session.And("answers = ?", 0)
+	} else if cond.AnswerAmount > 0 {
+		session.And("answers >= ?", cond.AnswerAmount)
+	}
+	return session
+}
+
+// Clear deletes the whole index
+func (sr *searchIndexRepo) Clear(ctx context.Context) (err error) {
+	_, err = sr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Exec("DELETE FROM " + entity.SearchIndexTerm{}.TableName()); err != nil {
+			return nil, err
+		}
+		if _, err = session.Exec("DELETE FROM " + entity.SearchIndexTag{}.TableName()); err != nil {
+			return nil, err
+		}
+		_, err = session.Exec("DELETE FROM " + entity.SearchIndexDocument{}.TableName())
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
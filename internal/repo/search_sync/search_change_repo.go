@@ -0,0 +1,222 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_sync
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/search_sync"
+	"github.com/apache/answer/pkg/obj"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const changeInsertBatchSize = 100
+
+type searchChangeRepo struct {
+	data    *data.Data
+	changed chan struct{}
+}
+
+// NewSearchChangeRepo new repository
+func NewSearchChangeRepo(data *data.Data) search_sync.SearchChangeRepo {
+	return &searchChangeRepo{
+		data:    data,
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// AddChanges records the questions and answers are changed, the other objects are ignored
+func (sr *searchChangeRepo) AddChanges(ctx context.Context, objectIDs ...string) (err error) {
+	changes := make([]*entity.SearchChange, 0, len(objectIDs))
+	for _, objectID := range objectIDs {
+		objectID = uid.DeShortID(objectID)
+		objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
+		if err != nil {
+			continue
+		}
+		if objectType != constant.QuestionObjectType && objectType != constant.AnswerObjectType {
+			continue
+		}
+		changes = append(changes, &entity.SearchChange{ObjectID: objectID, ObjectType: objectType})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	for start := 0; start < len(changes); start += changeInsertBatchSize {
+		end := min(start+changeInsertBatchSize, len(changes))
+		_, err = sr.data.DB.Context(ctx).Insert(changes[start:end])
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+	}
+	select {
+	case sr.changed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// AddTagChanges records the questions with the tags and their answers are changed
+func (sr *searchChangeRepo) AddTagChanges(ctx context.Context, tagIDs ...string) (err error) {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	questionIDs := make([]string, 0)
+	err = sr.data.DB.Context(ctx).Table(new(entity.TagRel).TableName()).Distinct("object_id").
+		In("tag_id", tagIDs).Where("status = ?", entity.TagRelStatusAvailable).Find(&questionIDs)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return sr.AddQuestionChanges(ctx, questionIDs...)
+}
+
+// AddQuestionChanges records the questions and their answers are changed,
+// the answers are indexed with the title and the visibility of the question
+func (sr *searchChangeRepo) AddQuestionChanges(ctx context.Context, questionIDs ...string) (err error) {
+	if len(questionIDs) == 0 {
+		return nil
+	}
+	questionIDs = slices.Clone(questionIDs)
+	for i := range questionIDs {
+		questionIDs[i] = uid.DeShortID(questionIDs[i])
+	}
+	answerIDs := make([]string, 0)
+	for start := 0; start < len(questionIDs); start += changeInsertBatchSize {
+		end := min(start+changeInsertBatchSize, len(questionIDs))
+		ids := make([]string, 0)
+		err = sr.data.DB.Context(ctx).Table(new(entity.Answer).TableName()).Select("id").
+			In("question_id", questionIDs[start:end]).Find(&ids)
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		answerIDs = append(answerIDs, ids...)
+	}
+	return sr.AddChanges(ctx, append(questionIDs, answerIDs...)...)
+}
+
+// Changed is notified after some changes are added
+func (sr *searchChangeRepo) Changed() <-chan struct{} {
+	return sr.changed
+}
+
+// GetLatestChangeID returns the id of the latest change, 0 if there is no change
+func (sr *searchChangeRepo) GetLatestChangeID(ctx context.Context) (id int64, err error) {
+	change := &entity.SearchChange{}
+	exist, err := sr.data.DB.Context(ctx).Desc("id").Get(change)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return 0, nil
+	}
+	return change.ID, nil
+}
+
+// CountChanges counts the changes after the cursor and returns the time of the oldest one
+func (sr *searchChangeRepo) CountChanges(ctx context.Context, cursor int64) (count int64, oldest time.Time, err error) {
+	count, err = sr.data.DB.Context(ctx).Where("id > ?", cursor).Count(&entity.SearchChange{})
+	if err != nil {
+		return 0, oldest, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if count == 0 {
+		return 0, oldest, nil
+	}
+	change := &entity.SearchChange{}
+	_, err = sr.data.DB.Context(ctx).Where("id > ?", cursor).Asc("id").Get(change)
+	if err != nil {
+		return 0, oldest, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return count, change.CreatedAt, nil
+}
+
+// PruneChanges deletes the changes up to the cursor which all the plugins, including the disabled ones, have synced.
+// The changes created before the time are deleted anyway, the plugins missed them have to be reindexed.
+func (sr *searchChangeRepo) PruneChanges(ctx context.Context, cursor int64, before time.Time) (err error) {
+	cursors := make([]*entity.SearchSyncCursor, 0)
+	err = sr.data.DB.Context(ctx).Where("sync_cursor >= 0").Find(&cursors)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, c := range cursors {
+		cursor = min(cursor, c.Cursor)
+	}
+
+	expired := &entity.SearchChange{}
+	exist, err := sr.data.DB.Context(ctx).Where("created_at < ?", before).Desc("id").Get(expired)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist && expired.ID > cursor {
+		_, err = sr.data.DB.Context(ctx).Where("sync_cursor >= 0").And("sync_cursor < ?", expired.ID).
+			Cols("sync_cursor").Update(&entity.SearchSyncCursor{Cursor: entity.SearchSyncCursorReindex})
+		if err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		cursor = expired.ID
+	}
+	if cursor <= 0 {
+		return nil
+	}
+	_, err = sr.data.DB.Context(ctx).Where("id <= ?", cursor).Delete(&entity.SearchChange{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetCursor get the sync cursor of the search plugin
+func (sr *searchChangeRepo) GetCursor(ctx context.Context, pluginSlugName string) (
+	cursor *entity.SearchSyncCursor, exist bool, err error) {
+	cursor = &entity.SearchSyncCursor{}
+	exist, err = sr.data.DB.Context(ctx).Where("plugin_slug_name = ?", pluginSlugName).Get(cursor)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return cursor, exist, nil
+}
+
+// SaveCursor save the sync cursor of the search plugin
+func (sr *searchChangeRepo) SaveCursor(ctx context.Context, cursor *entity.SearchSyncCursor) (err error) {
+	old := &entity.SearchSyncCursor{}
+	exist, err := sr.data.DB.Context(ctx).Where("plugin_slug_name = ?", cursor.PluginSlugName).Get(old)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		cols := []string{"sync_cursor"}
+		if !cursor.ReindexedAt.IsZero() {
+			cols = append(cols, "reindexed_at")
+		}
+		_, err = sr.data.DB.Context(ctx).Where("plugin_slug_name = ?", cursor.PluginSlugName).Cols(cols...).Update(cursor)
+	} else {
+		_, err = sr.data.DB.Context(ctx).Insert(cursor)
+	}
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
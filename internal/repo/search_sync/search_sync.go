@@ -21,6 +21,8 @@ package search_sync
 
 import (
 	"context"
+	"slices"
+
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/entity"
@@ -60,6 +62,76 @@ func (p *PluginSyncer) GetQuestionsPage(ctx context.Context, page, pageSize int)
 	return p.convertQuestions(ctx, questions)
 }
 
+// GetChangesSince returns the latest state of the questions and answers changed after the cursor
+func (p *PluginSyncer) GetChangesSince(ctx context.Context, cursor int64, limit int) (
+	changes []*plugin.SearchChange, nextCursor int64, err error) {
+	rows := make([]*entity.SearchChange, 0)
+	err = p.data.DB.Context(ctx).Where("id > ?", cursor).Asc("id").Limit(limit).Find(&rows)
+	if err != nil {
+		return nil, cursor, err
+	}
+	if len(rows) == 0 {
+		return nil, cursor, nil
+	}
+	nextCursor = rows[len(rows)-1].ID
+
+	// an object changed many times is returned once at its last change
+	lastChange := make(map[string]*entity.SearchChange)
+	questionIDs, answerIDs := make([]string, 0), make([]string, 0)
+	for _, row := range rows {
+		if _, ok := lastChange[row.ObjectID]; !ok {
+			if row.ObjectType == constant.QuestionObjectType {
+				questionIDs = append(questionIDs, row.ObjectID)
+			} else {
+				answerIDs = append(answerIDs, row.ObjectID)
+			}
+		}
+		lastChange[row.ObjectID] = row
+	}
+
+	contents := make(map[string]*plugin.SearchContent)
+	if len(questionIDs) > 0 {
+		questions := make([]*entity.Question, 0)
+		if err = p.data.DB.Context(ctx).In("id", questionIDs).Find(&questions); err != nil {
+			return nil, cursor, err
+		}
+		questionList, err := p.convertQuestions(ctx, questions)
+		if err != nil {
+			return nil, cursor, err
+		}
+		for _, content := range questionList {
+			contents[content.ObjectID] = content
+		}
+	}
+	if len(answerIDs) > 0 {
+		answers := make([]*entity.Answer, 0)
+		if err = p.data.DB.Context(ctx).In("id", answerIDs).Find(&answers); err != nil {
+			return nil, cursor, err
+		}
+		answerList, err := p.convertAnswers(ctx, answers)
+		if err != nil {
+			return nil, cursor, err
+		}
+		for _, content := range answerList {
+			contents[content.ObjectID] = content
+		}
+	}
+
+	for _, row := range rows {
+		if lastChange[row.ObjectID] != row {
+			continue
+		}
+		content := contents[row.ObjectID]
+		changes = append(changes, &plugin.SearchChange{
+			Cursor:   row.ID,
+			ObjectID: row.ObjectID,
+			Deleted:  content == nil || content.Status >= plugin.SearchContentStatusDeleted,
+			Content:  content,
+		})
+	}
+	return changes, nextCursor, nil
+}
+
 func (p *PluginSyncer) convertAnswers(ctx context.Context, answers []*entity.Answer) (
 	answerList []*plugin.SearchContent, err error) {
 	for _, answer := range answers {
@@ -84,13 +156,17 @@ func (p *PluginSyncer) convertAnswers(ctx context.Context, answers []*entity.Ans
 			tags = append(tags, tag.TagID)
 		}
 
+		status := plugin.SearchContentStatus(answer.Status)
+		if question.Status >= entity.QuestionStatusDeleted || question.Show != entity.QuestionShow {
+			status = plugin.SearchContentStatusDeleted
+		}
 		content := &plugin.SearchContent{
 			ObjectID:    answer.ID,
 			Title:       question.Title,
 			Type:        constant.AnswerObjectType,
 			Content:     answer.ParsedText,
 			Answers:     0,
-			Status:      plugin.SearchContentStatus(answer.Status),
+			Status:      status,
 			Tags:        tags,
 			QuestionID:  answer.QuestionID,
 			UserID:      answer.UserID,
@@ -102,6 +178,7 @@ func (p *PluginSyncer) convertAnswers(ctx context.Context, answers []*entity.Ans
 		}
 		answerList = append(answerList, content)
 	}
+	p.fillComments(ctx, answerList)
 	return answerList, nil
 }
 
@@ -118,13 +195,18 @@ func (p *PluginSyncer) convertQuestions(ctx context.Context, questions []*entity
 		for _, tag := range tagListList {
 			tags = append(tags, tag.TagID)
 		}
+		// the hidden questions are not searchable
+		status := plugin.SearchContentStatus(question.Status)
+		if question.Show != entity.QuestionShow {
+			status = plugin.SearchContentStatusDeleted
+		}
 		content := &plugin.SearchContent{
 			ObjectID:    question.ID,
 			Title:       question.Title,
 			Type:        constant.QuestionObjectType,
 			Content:     question.ParsedText,
 			Answers:     int64(question.AnswerCount),
-			Status:      plugin.SearchContentStatus(question.Status),
+			Status:      status,
 			Tags:        tags,
 			QuestionID:  question.ID,
 			UserID:      question.UserID,
@@ -136,5 +218,64 @@ func (p *PluginSyncer) convertQuestions(ctx context.Context, questions []*entity
 		}
 		questionList = append(questionList, content)
 	}
+	p.fillComments(ctx, questionList)
+	p.fillAnswerUsers(ctx, questionList)
 	return questionList, nil
 }
+
+// fillComments sets the text and the users of the available comments on the contents
+func (p *PluginSyncer) fillComments(ctx context.Context, contents []*plugin.SearchContent) {
+	if len(contents) == 0 {
+		return
+	}
+	objectIDs := make([]string, 0, len(contents))
+	for _, content := range contents {
+		objectIDs = append(objectIDs, content.ObjectID)
+	}
+	comments := make([]*entity.Comment, 0)
+	err := p.data.DB.Context(ctx).In("object_id", objectIDs).
+		Where("status = ?", entity.CommentStatusAvailable).Asc("id").Find(&comments)
+	if err != nil {
+		log.Errorf("get comment list failed %s", err)
+		return
+	}
+	commentMapping := make(map[string][]string)
+	userMapping := make(map[string][]string)
+	for _, comment := range comments {
+		commentMapping[comment.ObjectID] = append(commentMapping[comment.ObjectID], comment.ParsedText)
+		if !slices.Contains(userMapping[comment.ObjectID], comment.UserID) {
+			userMapping[comment.ObjectID] = append(userMapping[comment.ObjectID], comment.UserID)
+		}
+	}
+	for _, content := range contents {
+		content.Comments = commentMapping[content.ObjectID]
+		content.CommentUserIDs = userMapping[content.ObjectID]
+	}
+}
+
+// fillAnswerUsers sets the users who have available answers on the questions
+func (p *PluginSyncer) fillAnswerUsers(ctx context.Context, questions []*plugin.SearchContent) {
+	if len(questions) == 0 {
+		return
+	}
+	questionIDs := make([]string, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, question.ObjectID)
+	}
+	answers := make([]*entity.Answer, 0)
+	err := p.data.DB.Context(ctx).Cols("question_id", "user_id").In("question_id", questionIDs).
+		Where("status = ?", entity.AnswerStatusAvailable).Find(&answers)
+	if err != nil {
+		log.Errorf("get answer list failed %s", err)
+		return
+	}
+	userMapping := make(map[string][]string)
+	for _, answer := range answers {
+		if !slices.Contains(userMapping[answer.QuestionID], answer.UserID) {
+			userMapping[answer.QuestionID] = append(userMapping[answer.QuestionID], answer.UserID)
+		}
+	}
+	for _, question := range questions {
+		question.AnswerUserIDs = userMapping[question.ObjectID]
+	}
+}
@@ -22,15 +22,18 @@ package site_info
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 type siteInfoRepo struct {
@@ -62,6 +65,102 @@ func (sr *siteInfoRepo) SaveByType(ctx context.Context, siteType string, data *e
 	return
 }
 
+// SaveSiteSettings save the site info, the configs, the plugin configs and the write tags in one transaction
+func (sr *siteInfoRepo) SaveSiteSettings(ctx context.Context, settings *schema.SiteSettingsRows) (err error) {
+	configs := make([]*entity.Config, 0, len(settings.Configs))
+	_, err = sr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		for _, siteInfo := range settings.SiteInfos {
+			old := &entity.SiteInfo{}
+			exist, err := session.Where(builder.Eq{"type": siteInfo.Type}).Get(old)
+			if err != nil {
+				return nil, err
+			}
+			if exist {
+				_, err = session.ID(old.ID).Update(siteInfo)
+			} else {
+				_, err = session.Insert(siteInfo)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for key, value := range settings.Configs {
+			config := &entity.Config{Key: key}
+			exist, err := session.Get(config)
+			if err != nil {
+				return nil, err
+			}
+			if !exist {
+				return nil, fmt.Errorf("config not found by key: %s", key)
+			}
+			if _, err = session.ID(config.ID).Update(&entity.Config{Value: value}); err != nil {
+				return nil, err
+			}
+			config.Value = value
+			configs = append(configs, config)
+		}
+
+		for slugName, value := range settings.PluginConfigs {
+			old := &entity.PluginConfig{PluginSlugName: slugName}
+			exist, err := session.Get(old)
+			if err != nil {
+				return nil, err
+			}
+			if exist {
+				old.Value = value
+				_, err = session.ID(old.ID).Update(old)
+			} else {
+				_, err = session.Insert(&entity.PluginConfig{PluginSlugName: slugName, Value: value})
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if settings.WriteTags {
+			for attribute, tags := range map[string][]string{
+				"recommend": settings.RecommendTags,
+				"reserved":  settings.ReservedTags,
+			} {
+				_, err = session.Table(&entity.Tag{}).Where(builder.Eq{attribute: true}).
+					Update(map[string]any{attribute: false})
+				if err != nil {
+					return nil, err
+				}
+				if len(tags) == 0 {
+					continue
+				}
+				_, err = session.Table(&entity.Tag{}).In("slug_name", tags).
+					Update(map[string]any{attribute: true})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	for _, siteInfo := range settings.SiteInfos {
+		sr.setCache(ctx, siteInfo.Type, siteInfo)
+	}
+	for _, config := range configs {
+		for _, cacheKey := range []string{
+			constant.ConfigKEY2ContentCacheKeyPrefix + config.Key,
+			fmt.Sprintf("%s%d", constant.ConfigID2KEYCacheKeyPrefix, config.ID),
+		} {
+			if err := sr.data.Cache.Del(ctx, cacheKey); err != nil {
+				log.Error(err)
+			}
+		}
+	}
+	return nil
+}
+
 // GetByType get site info by type
 func (sr *siteInfoRepo) GetByType(ctx context.Context, siteType string) (siteInfo *entity.SiteInfo, exist bool, err error) {
 	siteInfo = sr.getCache(ctx, siteType)
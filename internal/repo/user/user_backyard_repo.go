@@ -168,7 +168,7 @@ func (ur *userAdminRepo) GetUserPage(ctx context.Context, page, pageSize int, us
 		))
 	}
 	if isStaff {
-		session.Join("INNER", "user_role_rel", "`user`.id = `user_role_rel`.user_id AND `user_role_rel`.role_id > 1")
+		session.Join("INNER", "user_role_rel", "`user`.id = `user_role_rel`.user_id AND `user_role_rel`.role_id IN (2, 3)")
 	}
 
 	total, err = pager.Help(page, pageSize, &users, user, session)
@@ -140,6 +140,21 @@ func (ur *userRepo) UpdateNoticeStatus(ctx context.Context, userID string, notic
 	return nil
 }
 
+// SuspendUser suspend the user until the time
+func (ur *userRepo) SuspendUser(ctx context.Context, userID string, suspendedUntil time.Time) (err error) {
+	cond := &entity.User{
+		Status:         entity.UserStatusSuspended,
+		SuspendedAt:    time.Now(),
+		SuspendedUntil: suspendedUntil,
+	}
+	_, err = ur.data.DB.Context(ctx).Where("id = ?", userID).
+		Cols("status", "suspended_at", "suspended_until").Update(cond)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
 func (ur *userRepo) UpdatePass(ctx context.Context, userID, pass string) error {
 	_, err := ur.data.DB.Context(ctx).Where("id = ?", userID).Cols("pass").Update(&entity.User{Pass: pass})
 	if err != nil {
@@ -262,7 +277,7 @@ func (ur *userRepo) SearchUserListByName(ctx context.Context, name string, limit
 	userList = make([]*entity.User, 0)
 	session := ur.data.DB.Context(ctx)
 	if onlyStaff {
-		session.Join("INNER", "user_role_rel", "`user`.id = `user_role_rel`.user_id AND `user_role_rel`.role_id > 1")
+		session.Join("INNER", "user_role_rel", "`user`.id = `user_role_rel`.user_id AND `user_role_rel`.role_id IN (2, 3)")
 	}
 	session.Where("status = ?", entity.UserStatusAvailable)
 	session.Where("username LIKE ? OR display_name LIKE ?", strings.ToLower(name)+"%", name+"%")
@@ -0,0 +1,132 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/data"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/service/webhook"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// webhookRepo webhook repository
+type webhookRepo struct {
+	data *data.Data
+}
+
+// NewWebhookRepo new repository
+func NewWebhookRepo(data *data.Data) webhook.WebhookRepo {
+	return &webhookRepo{
+		data: data,
+	}
+}
+
+// AddWebhook add webhook
+func (wr *webhookRepo) AddWebhook(ctx context.Context, webhook *entity.Webhook) (err error) {
+	_, err = wr.data.DB.Context(ctx).Insert(webhook)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateWebhook update webhook
+func (wr *webhookRepo) UpdateWebhook(ctx context.Context, webhook *entity.Webhook) (updated bool, err error) {
+	affected, err := wr.data.DB.Context(ctx).ID(webhook.ID).Where("status <> ?", entity.WebhookStatusDeleted).
+		Cols("name", "url", "secret", "event_types", "status", "updated_at").Update(webhook)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return affected > 0, nil
+}
+
+// DeleteWebhook delete webhook, the delivery log is kept
+func (wr *webhookRepo) DeleteWebhook(ctx context.Context, id string) (err error) {
+	_, err = wr.data.DB.Context(ctx).ID(id).Cols("status").
+		Update(&entity.Webhook{Status: entity.WebhookStatusDeleted})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetWebhook get webhook which is not deleted
+func (wr *webhookRepo) GetWebhook(ctx context.Context, id string) (webhook *entity.Webhook, exist bool, err error) {
+	webhook = &entity.Webhook{}
+	exist, err = wr.data.DB.Context(ctx).ID(id).Where("status <> ?", entity.WebhookStatusDeleted).Get(webhook)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetWebhookList get all webhooks which are not deleted
+func (wr *webhookRepo) GetWebhookList(ctx context.Context) (webhooks []*entity.Webhook, err error) {
+	webhooks = make([]*entity.Webhook, 0)
+	err = wr.data.DB.Context(ctx).Where("status <> ?", entity.WebhookStatusDeleted).Asc("created_at").Find(&webhooks)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetActiveWebhooks get the webhooks receiving events
+func (wr *webhookRepo) GetActiveWebhooks(ctx context.Context) (webhooks []*entity.Webhook, err error) {
+	webhooks = make([]*entity.Webhook, 0)
+	err = wr.data.DB.Context(ctx).Where("status = ?", entity.WebhookStatusActive).Find(&webhooks)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddDelivery add delivery log
+func (wr *webhookRepo) AddDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (err error) {
+	_, err = wr.data.DB.Context(ctx).Insert(delivery)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountDeliveryAttempts count the attempts of the delivery made so far
+func (wr *webhookRepo) CountDeliveryAttempts(ctx context.Context, deliveryID string) (count int64, err error) {
+	count, err = wr.data.DB.Context(ctx).Where("delivery_id = ?", deliveryID).Count(&entity.WebhookDelivery{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeliveryPage get the delivery log of the webhook by page, the latest first
+func (wr *webhookRepo) GetDeliveryPage(ctx context.Context, page, pageSize int, webhookID string) (
+	deliveries []*entity.WebhookDelivery, total int64, err error) {
+	deliveries = make([]*entity.WebhookDelivery, 0)
+	session := wr.data.DB.Context(ctx).Where("webhook_id = ?", webhookID).Desc("id")
+	total, err = pager.Help(page, pageSize, &deliveries, &entity.WebhookDelivery{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
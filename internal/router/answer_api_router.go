@@ -23,41 +23,54 @@ import (
 	"github.com/apache/answer/internal/base/middleware"
 	"github.com/apache/answer/internal/controller"
 	"github.com/apache/answer/internal/controller_admin"
+	"github.com/apache/answer/internal/schema"
 	"github.com/gin-gonic/gin"
 )
 
 type AnswerAPIRouter struct {
-	langController            *controller.LangController
-	userController            *controller.UserController
-	commentController         *controller.CommentController
-	reportController          *controller.ReportController
-	voteController            *controller.VoteController
-	tagController             *controller.TagController
-	hierarchicalTagController *controller.HierarchicalTagController
-	followController          *controller.FollowController
-	collectionController      *controller.CollectionController
-	questionController        *controller.QuestionController
-	answerController          *controller.AnswerController
-	searchController          *controller.SearchController
-	revisionController        *controller.RevisionController
-	rankController            *controller.RankController
-	adminUserController       *controller_admin.UserAdminController
-	reasonController          *controller.ReasonController
-	themeController           *controller_admin.ThemeController
-	adminSiteInfoController   *controller_admin.SiteInfoController
-	siteInfoController        *controller.SiteInfoController
-	notificationController    *controller.NotificationController
-	dashboardController       *controller.DashboardController
-	uploadController          *controller.UploadController
-	activityController        *controller.ActivityController
-	roleController            *controller_admin.RoleController
-	pluginController          *controller_admin.PluginController
-	permissionController      *controller.PermissionController
-	userPluginController      *controller.UserPluginController
-	reviewController          *controller.ReviewController
-	metaController            *controller.MetaController
-	badgeController           *controller.BadgeController
-	adminBadgeController      *controller_admin.BadgeController
+	langController                     *controller.LangController
+	userController                     *controller.UserController
+	commentController                  *controller.CommentController
+	reportController                   *controller.ReportController
+	voteController                     *controller.VoteController
+	tagController                      *controller.TagController
+	hierarchicalTagController          *controller.HierarchicalTagController
+	followController                   *controller.FollowController
+	collectionController               *controller.CollectionController
+	questionController                 *controller.QuestionController
+	answerController                   *controller.AnswerController
+	searchController                   *controller.SearchController
+	revisionController                 *controller.RevisionController
+	rankController                     *controller.RankController
+	adminUserController                *controller_admin.UserAdminController
+	reasonController                   *controller.ReasonController
+	themeController                    *controller_admin.ThemeController
+	adminSiteInfoController            *controller_admin.SiteInfoController
+	siteInfoController                 *controller.SiteInfoController
+	notificationController             *controller.NotificationController
+	dashboardController                *controller.DashboardController
+	uploadController                   *controller.UploadController
+	activityController                 *controller.ActivityController
+	roleController                     *controller_admin.RoleController
+	pluginController                   *controller_admin.PluginController
+	permissionController               *controller.PermissionController
+	userPluginController               *controller.UserPluginController
+	reviewController                   *controller.ReviewController
+	metaController                     *controller.MetaController
+	badgeController                    *controller.BadgeController
+	adminBadgeController               *controller_admin.BadgeController
+	queueMessageController             *controller_admin.QueueMessageController
+	webhookController                  *controller_admin.WebhookController
+	searchSyncController               *controller_admin.SearchSyncController
+	emailReplyController               *controller.EmailReplyController
+	personalAccessTokenController      *controller.PersonalAccessTokenController
+	adminPersonalAccessTokenController *controller_admin.PersonalAccessTokenController
+	oauthProviderController            *controller.OAuthProviderController
+	oauthClientController              *controller_admin.OAuthClientController
+	draftController                    *controller.DraftController
+	questionAutoCloseController        *controller_admin.QuestionAutoCloseController
+	rateLimitMiddleware                *middleware.RateLimitMiddleware
+	moderationController               *controller.ModerationController
 }
 
 func NewAnswerAPIRouter(
@@ -92,39 +105,63 @@ func NewAnswerAPIRouter(
 	metaController *controller.MetaController,
 	badgeController *controller.BadgeController,
 	adminBadgeController *controller_admin.BadgeController,
+	queueMessageController *controller_admin.QueueMessageController,
+	webhookController *controller_admin.WebhookController,
+	searchSyncController *controller_admin.SearchSyncController,
+	emailReplyController *controller.EmailReplyController,
+	personalAccessTokenController *controller.PersonalAccessTokenController,
+	adminPersonalAccessTokenController *controller_admin.PersonalAccessTokenController,
+	oauthProviderController *controller.OAuthProviderController,
+	oauthClientController *controller_admin.OAuthClientController,
+	draftController *controller.DraftController,
+	questionAutoCloseController *controller_admin.QuestionAutoCloseController,
+	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	moderationController *controller.ModerationController,
 ) *AnswerAPIRouter {
 	return &AnswerAPIRouter{
-		langController:            langController,
-		userController:            userController,
-		commentController:         commentController,
-		reportController:          reportController,
-		voteController:            voteController,
-		tagController:             tagController,
-		hierarchicalTagController: hierarchicalTagController,
-		followController:          followController,
-		collectionController:      collectionController,
-		questionController:        questionController,
-		answerController:          answerController,
-		searchController:          searchController,
-		revisionController:        revisionController,
-		rankController:            rankController,
-		adminUserController:       adminUserController,
-		reasonController:          reasonController,
-		themeController:           themeController,
-		adminSiteInfoController:   adminSiteInfoController,
-		notificationController:    notificationController,
-		siteInfoController:        siteInfoController,
-		dashboardController:       dashboardController,
-		uploadController:          uploadController,
-		activityController:        activityController,
-		roleController:            roleController,
-		pluginController:          pluginController,
-		permissionController:      permissionController,
-		userPluginController:      userPluginController,
-		reviewController:          reviewController,
-		metaController:            metaController,
-		badgeController:           badgeController,
-		adminBadgeController:      adminBadgeController,
+		langController:                     langController,
+		userController:                     userController,
+		commentController:                  commentController,
+		reportController:                   reportController,
+		voteController:                     voteController,
+		tagController:                      tagController,
+		hierarchicalTagController:          hierarchicalTagController,
+		followController:                   followController,
+		collectionController:               collectionController,
+		questionController:                 questionController,
+		answerController:                   answerController,
+		searchController:                   searchController,
+		revisionController:                 revisionController,
+		rankController:                     rankController,
+		adminUserController:                adminUserController,
+		reasonController:                   reasonController,
+		themeController:                    themeController,
+		adminSiteInfoController:            adminSiteInfoController,
+		notificationController:             notificationController,
+		siteInfoController:                 siteInfoController,
+		dashboardController:                dashboardController,
+		uploadController:                   uploadController,
+		activityController:                 activityController,
+		roleController:                     roleController,
+		pluginController:                   pluginController,
+		permissionController:               permissionController,
+		userPluginController:               userPluginController,
+		reviewController:                   reviewController,
+		metaController:                     metaController,
+		badgeController:                    badgeController,
+		adminBadgeController:               adminBadgeController,
+		queueMessageController:             queueMessageController,
+		webhookController:                  webhookController,
+		searchSyncController:               searchSyncController,
+		emailReplyController:               emailReplyController,
+		personalAccessTokenController:      personalAccessTokenController,
+		adminPersonalAccessTokenController: adminPersonalAccessTokenController,
+		oauthProviderController:            oauthProviderController,
+		oauthClientController:              oauthClientController,
+		draftController:                    draftController,
+		questionAutoCloseController:        questionAutoCloseController,
+		rateLimitMiddleware:                rateLimitMiddleware,
+		moderationController:               moderationController,
 	}
 }
 
@@ -151,6 +188,9 @@ func (a *AnswerAPIRouter) RegisterMustUnAuthAnswerAPIRouter(authUserMiddleware *
 
 	// plugins
 	r.GET("/plugin/status", a.pluginController.GetAllPluginStatus)
+
+	// email reply
+	r.POST("/email/inbound", a.emailReplyController.Inbound)
 }
 
 func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
@@ -181,6 +221,7 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 
 	// revision
 	r.GET("/revisions", a.revisionController.GetRevisionList)
+	r.GET("/revisions/diff", a.revisionController.GetRevisionDiff)
 
 	// tag
 	r.GET("/tags/page", a.tagController.GetTagWithPage)
@@ -192,10 +233,11 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	// hierarchical tags
 	r.GET("/hierarchical-tags", a.hierarchicalTagController.GetHierarchicalTags)
 	r.GET("/hierarchical-tags/path", a.hierarchicalTagController.GetHierarchicalTagPath)
+	r.GET("/hierarchical-tags/tree", a.hierarchicalTagController.GetHierarchicalTagTree)
 	r.GET("/hierarchical-tags/test", a.hierarchicalTagController.TestHierarchicalTag)
 
 	// search
-	r.GET("/search", a.searchController.Search)
+	r.GET("/search", a.rateLimitMiddleware.Limit(schema.RateLimitGroupSearch), a.searchController.Search)
 	r.GET("/search/desc", a.searchController.SearchDesc)
 
 	// rank
@@ -226,12 +268,12 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/reviewing/type", a.revisionController.GetReviewingType)
 
 	// comment
-	r.POST("/comment", a.commentController.AddComment)
+	r.POST("/comment", a.rateLimitMiddleware.Limit(schema.RateLimitGroupComment), a.commentController.AddComment)
 	r.DELETE("/comment", a.commentController.RemoveComment)
-	r.PUT("/comment", a.commentController.UpdateComment)
+	r.PUT("/comment", a.rateLimitMiddleware.Limit(schema.RateLimitGroupEdit), a.commentController.UpdateComment)
 
 	// report
-	r.POST("/report", a.reportController.AddReport)
+	r.POST("/report", a.rateLimitMiddleware.Limit(schema.RateLimitGroupReport), a.reportController.AddReport)
 	r.GET("/report/unreviewed/post", a.reportController.GetUnreviewedReportPostPage)
 	r.PUT("/report/review", a.reportController.ReviewReport)
 
@@ -239,9 +281,16 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/review/pending/post/page", a.reviewController.GetUnreviewedPostPage)
 	r.PUT("/review/pending/post", a.reviewController.UpdateReview)
 
+	// moderation
+	r.GET("/moderation/queue", a.moderationController.GetQueue)
+	r.POST("/moderation/claim", a.moderationController.Claim)
+	r.DELETE("/moderation/claim", a.moderationController.Release)
+	r.PUT("/moderation/items", a.moderationController.ModerateItems)
+	r.GET("/moderation/logs", a.moderationController.GetLogPage)
+
 	// vote
-	r.POST("/vote/up", a.voteController.VoteUp)
-	r.POST("/vote/down", a.voteController.VoteDown)
+	r.POST("/vote/up", a.rateLimitMiddleware.Limit(schema.RateLimitGroupVote), a.voteController.VoteUp)
+	r.POST("/vote/down", a.rateLimitMiddleware.Limit(schema.RateLimitGroupVote), a.voteController.VoteDown)
 
 	// follow
 	r.POST("/follow", a.followController.Follow)
@@ -250,7 +299,8 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	// tag
 	r.GET("/question/tags", a.tagController.SearchTagLike)
 	r.POST("/tag", a.tagController.AddTag)
-	r.PUT("/tag", a.tagController.UpdateTag)
+	r.PUT("/tag", a.rateLimitMiddleware.Limit(schema.RateLimitGroupEdit), a.tagController.UpdateTag)
+	r.PUT("/tag/rollback", a.rateLimitMiddleware.Limit(schema.RateLimitGroupEdit), a.tagController.RollbackTag)
 	r.POST("/tag/recover", a.tagController.RecoverTag)
 	r.DELETE("/tag", a.tagController.RemoveTag)
 	r.PUT("/tag/synonym", a.tagController.UpdateTagSynonym)
@@ -265,9 +315,10 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/personal/collection/page", a.questionController.PersonalCollectionPage)
 
 	// question
-	r.POST("/question", a.questionController.AddQuestion)
-	r.POST("/question/answer", a.questionController.AddQuestionByAnswer)
-	r.PUT("/question", a.questionController.UpdateQuestion)
+	r.POST("/question", a.rateLimitMiddleware.Limit(schema.RateLimitGroupQuestion), a.questionController.AddQuestion)
+	r.POST("/question/answer", a.rateLimitMiddleware.Limit(schema.RateLimitGroupQuestion), a.questionController.AddQuestionByAnswer)
+	r.PUT("/question", a.rateLimitMiddleware.Limit(schema.RateLimitGroupEdit), a.questionController.UpdateQuestion)
+	r.PUT("/question/rollback", a.rateLimitMiddleware.Limit(schema.RateLimitGroupEdit), a.questionController.RollbackQuestion)
 	r.PUT("/question/invite", a.questionController.UpdateQuestionInviteUser)
 	r.DELETE("/question", a.questionController.RemoveQuestion)
 	r.PUT("/question/status", a.questionController.CloseQuestion)
@@ -277,8 +328,9 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.POST("/question/recover", a.questionController.QuestionRecover)
 
 	// answer
-	r.POST("/answer", a.answerController.AddAnswer)
-	r.PUT("/answer", a.answerController.UpdateAnswer)
+	r.POST("/answer", a.rateLimitMiddleware.Limit(schema.RateLimitGroupAnswer), a.answerController.AddAnswer)
+	r.PUT("/answer", a.rateLimitMiddleware.Limit(schema.RateLimitGroupEdit), a.answerController.UpdateAnswer)
+	r.PUT("/answer/rollback", a.rateLimitMiddleware.Limit(schema.RateLimitGroupEdit), a.answerController.RollbackAnswer)
 	r.POST("/answer/acceptance", a.answerController.AcceptAnswer)
 	r.DELETE("/answer", a.answerController.RemoveAnswer)
 	r.POST("/answer/recover", a.answerController.RecoverAnswer)
@@ -308,7 +360,7 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/notification/read/state", a.notificationController.ClearIDUnRead)
 
 	// upload file
-	r.POST("/file", a.uploadController.UploadFile)
+	r.POST("/file", a.rateLimitMiddleware.Limit(schema.RateLimitGroupUpload), a.uploadController.UploadFile)
 	r.POST("/post/render", a.uploadController.PostRender)
 
 	// activity
@@ -322,6 +374,21 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 
 	// meta
 	r.PUT("/meta/reaction", a.metaController.AddOrUpdateReaction)
+
+	// personal access token
+	r.GET("/personal/access-tokens", a.personalAccessTokenController.GetPersonalAccessTokens)
+	r.POST("/personal/access-token", a.personalAccessTokenController.AddPersonalAccessToken)
+	r.DELETE("/personal/access-token", a.personalAccessTokenController.RevokePersonalAccessToken)
+
+	// oauth provider consent
+	r.GET("/oauth/authorize", a.oauthProviderController.GetAuthorizeInfo)
+	r.POST("/oauth/authorize", a.oauthProviderController.Consent)
+
+	// draft
+	r.GET("/draft", a.draftController.GetDraft)
+	r.PUT("/draft", a.draftController.SaveDraft)
+	r.DELETE("/draft", a.draftController.RemoveDraft)
+	r.GET("/personal/drafts/page", a.draftController.GetDraftPage)
 }
 
 func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
@@ -334,6 +401,7 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.GET("/users/page", a.adminUserController.GetUserPage)
 	r.PUT("/user/status", a.adminUserController.UpdateUserStatus)
 	r.PUT("/user/role", a.adminUserController.UpdateUserRole)
+	r.PUT("/user/custom-roles", a.adminUserController.UpdateUserCustomRoles)
 	r.GET("/user/activation", a.adminUserController.GetUserActivation)
 	r.POST("/user/activation", a.adminUserController.SendUserActivation)
 	r.POST("/user", a.adminUserController.AddUser)
@@ -373,8 +441,15 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/siteinfo/theme", a.adminSiteInfoController.SaveSiteTheme)
 	r.GET("/siteinfo/users", a.adminSiteInfoController.GetSiteUsers)
 	r.PUT("/siteinfo/users", a.adminSiteInfoController.UpdateSiteUsers)
+	r.GET("/siteinfo/rate-limit", a.adminSiteInfoController.GetSiteRateLimit)
+	r.PUT("/siteinfo/rate-limit", a.adminSiteInfoController.UpdateSiteRateLimit)
+	r.GET("/siteinfo/review", a.adminSiteInfoController.GetSiteReview)
+	r.PUT("/siteinfo/review", a.adminSiteInfoController.UpdateSiteReview)
+	r.GET("/siteinfo/settings/export", a.adminSiteInfoController.ExportSiteSettings)
+	r.POST("/siteinfo/settings/import", a.adminSiteInfoController.ImportSiteSettings)
 	r.GET("/setting/smtp", a.adminSiteInfoController.GetSMTPConfig)
 	r.PUT("/setting/smtp", a.adminSiteInfoController.UpdateSMTPConfig)
+	r.PUT("/setting/smtp/reply-secret", a.adminSiteInfoController.RotateEmailReplySecret)
 	r.GET("/setting/privileges", a.adminSiteInfoController.GetPrivilegesConfig)
 	r.PUT("/setting/privileges", a.adminSiteInfoController.UpdatePrivilegesConfig)
 
@@ -383,6 +458,10 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 
 	// roles
 	r.GET("/roles", a.roleController.GetRoleList)
+	r.POST("/role", a.roleController.AddRole)
+	r.PUT("/role", a.roleController.UpdateRole)
+	r.DELETE("/role", a.roleController.RemoveRole)
+	r.GET("/powers", a.roleController.GetPowerList)
 
 	// plugin
 	r.GET("/plugins", a.pluginController.GetPluginList)
@@ -390,7 +469,74 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.GET("/plugin/config", a.pluginController.GetPluginConfig)
 	r.PUT("/plugin/config", a.pluginController.UpdatePluginConfig)
 
+	// hierarchical tags
+	r.DELETE("/hierarchical-tags", a.hierarchicalTagController.DeleteHierarchicalTag)
+	r.PUT("/hierarchical-tags/parent", a.hierarchicalTagController.MoveHierarchicalTag)
+	r.PUT("/hierarchical-tags/order", a.hierarchicalTagController.ReorderHierarchicalTags)
+	r.GET("/hierarchical-tags/permission", a.hierarchicalTagController.GetHierarchicalTagPermission)
+	r.PUT("/hierarchical-tags/permission", a.hierarchicalTagController.UpdateHierarchicalTagPermission)
+	r.GET("/hierarchical-tags/export", a.hierarchicalTagController.ExportHierarchicalTags)
+	r.POST("/hierarchical-tags/import", a.hierarchicalTagController.ImportHierarchicalTags)
+
 	// badge
 	r.GET("/badges", a.adminBadgeController.GetBadgeList)
 	r.PUT("/badge/status", a.adminBadgeController.UpdateBadgeStatus)
+	r.GET("/badge", a.adminBadgeController.GetBadgeDetail)
+	r.POST("/badge", a.adminBadgeController.AddBadge)
+	r.PUT("/badge", a.adminBadgeController.UpdateBadge)
+	r.DELETE("/badge", a.adminBadgeController.DeleteBadge)
+	r.POST("/badge/backfill", a.adminBadgeController.BackfillBadge)
+	r.GET("/badge-groups", a.adminBadgeController.GetBadgeGroupList)
+	r.POST("/badge-group", a.adminBadgeController.AddBadgeGroup)
+	r.PUT("/badge-group", a.adminBadgeController.UpdateBadgeGroup)
+	r.DELETE("/badge-group", a.adminBadgeController.DeleteBadgeGroup)
+
+	// queue message
+	r.GET("/queue-messages/page", a.queueMessageController.GetQueueMessagePage)
+	r.PUT("/queue-messages/replay", a.queueMessageController.ReplayQueueMessages)
+
+	// webhook
+	r.GET("/webhooks", a.webhookController.GetWebhookList)
+	r.POST("/webhook", a.webhookController.AddWebhook)
+	r.PUT("/webhook", a.webhookController.UpdateWebhook)
+	r.DELETE("/webhook", a.webhookController.DeleteWebhook)
+	r.POST("/webhook/test", a.webhookController.TestWebhook)
+	r.GET("/webhook/deliveries/page", a.webhookController.GetWebhookDeliveryPage)
+
+	// search sync
+	r.GET("/search/sync", a.searchSyncController.GetSearchSyncStatus)
+	r.POST("/search/reindex", a.searchSyncController.ReindexSearch)
+
+	// personal access token
+	r.GET("/personal-access-tokens/page", a.adminPersonalAccessTokenController.GetPersonalAccessTokenPage)
+	r.DELETE("/personal-access-token", a.adminPersonalAccessTokenController.RevokePersonalAccessToken)
+
+	// oauth client
+	r.GET("/oauth/clients", a.oauthClientController.GetOAuthClients)
+	r.POST("/oauth/client", a.oauthClientController.AddOAuthClient)
+	r.PUT("/oauth/client", a.oauthClientController.UpdateOAuthClient)
+	r.DELETE("/oauth/client", a.oauthClientController.DeleteOAuthClient)
+	r.PUT("/oauth/client/secret", a.oauthClientController.ResetOAuthClientSecret)
+
+	// question auto close rule
+	r.GET("/question/auto-close-rules", a.questionAutoCloseController.GetAutoCloseRuleList)
+	r.POST("/question/auto-close-rule", a.questionAutoCloseController.AddAutoCloseRule)
+	r.PUT("/question/auto-close-rule", a.questionAutoCloseController.UpdateAutoCloseRule)
+	r.DELETE("/question/auto-close-rule", a.questionAutoCloseController.DeleteAutoCloseRule)
+}
+
+// RegisterOAuthProviderRouter the endpoints of the oauth provider, they are relative to the site url
+// which is the issuer, and they are called by the other sites, so the cross origin requests are allowed
+func (a *AnswerAPIRouter) RegisterOAuthProviderRouter(r *gin.RouterGroup) {
+	r.GET("/.well-known/openid-configuration", middleware.AllowCrossOrigin(), a.oauthProviderController.Discovery)
+
+	o := r.Group("/oauth", middleware.AllowCrossOrigin())
+	o.GET("/jwks", a.oauthProviderController.JWKS)
+	o.POST("/token", a.oauthProviderController.Token)
+	o.GET("/userinfo", a.oauthProviderController.UserInfo)
+	o.POST("/userinfo", a.oauthProviderController.UserInfo)
+	o.POST("/revoke", a.oauthProviderController.Revoke)
+	for _, path := range []string{"/jwks", "/token", "/userinfo", "/revoke"} {
+		o.OPTIONS(path)
+	}
 }
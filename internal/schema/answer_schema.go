@@ -86,6 +86,7 @@ type AnswerUpdateReq struct {
 	UserID       string `json:"-"`
 	NoNeedReview bool   `json:"-"`
 	CanEdit      bool   `json:"-"`
+	Rollback     bool   `json:"-"`
 	CaptchaID    string `json:"captcha_id"`
 	CaptchaCode  string `json:"captcha_code"`
 }
@@ -126,6 +126,8 @@ type GetUserPageResp struct {
 	RoleID int `json:"role_id"`
 	// role name
 	RoleName string `json:"role_name"`
+	// custom role ids
+	CustomRoleIDs []int `json:"custom_role_ids"`
 }
 
 // GetUserInfoReq get user request
@@ -149,6 +151,14 @@ type UpdateUserRoleReq struct {
 	LoginUserID string `json:"-"`
 }
 
+// UpdateUserCustomRolesReq update user custom roles request
+type UpdateUserCustomRolesReq struct {
+	// user id
+	UserID string `validate:"required" json:"user_id"`
+	// custom role ids, remove all custom roles when empty
+	RoleIDs []int `validate:"omitempty" json:"role_ids"`
+}
+
 // EditUserProfileReq edit user profile request
 type EditUserProfileReq struct {
 	UserID      string `validate:"required" json:"user_id"`
@@ -175,3 +175,134 @@ type GetUserBadgeAwardListResp struct {
 type BadgeTplData struct {
 	ProfileURL string
 }
+
+const (
+	// BadgeRuleMetricQuestionCount the amount of the available questions asked by the user
+	BadgeRuleMetricQuestionCount = "question_count"
+	// BadgeRuleMetricAnswerCount the amount of the available answers of the user
+	BadgeRuleMetricAnswerCount = "answer_count"
+	// BadgeRuleMetricAcceptedAnswerCount the amount of the accepted answers of the user
+	BadgeRuleMetricAcceptedAnswerCount = "accepted_answer_count"
+	// BadgeRuleMetricCommentCount the amount of the available comments of the user
+	BadgeRuleMetricCommentCount = "comment_count"
+	// BadgeRuleMetricReceivedVoteCount the votes received by all the questions and answers of the user
+	BadgeRuleMetricReceivedVoteCount = "received_vote_count"
+	// BadgeRuleMetricPostVoteCount the votes of the question or answer the event happened on,
+	// the badge is awarded to its author once per post
+	BadgeRuleMetricPostVoteCount = "post_vote_count"
+	// BadgeRuleMetricPostStreakDays the consecutive days up to today or yesterday the user posted a question or an answer
+	BadgeRuleMetricPostStreakDays = "post_streak_days"
+
+	BadgeRuleRecipientActor          = "actor"
+	BadgeRuleRecipientQuestionAuthor = "question_author"
+	BadgeRuleRecipientAnswerAuthor   = "answer_author"
+	BadgeRuleRecipientCommentAuthor  = "comment_author"
+)
+
+// BadgeRule the declarative rule of the badge defined by the admin.
+// When one of the events happens, the metric of the recipient is counted and the badge is awarded
+// once it reaches the threshold.
+type BadgeRule struct {
+	// EventTypes the events triggering the evaluation, such as question.accept
+	EventTypes []string `validate:"required,gt=0,dive,required" json:"event_types"`
+	// Metric what is counted
+	Metric string `validate:"required,oneof=question_count answer_count accepted_answer_count comment_count received_vote_count post_vote_count post_streak_days" json:"metric"`
+	// Threshold the value the metric needs to reach
+	Threshold int64 `validate:"required,min=1" json:"threshold"`
+	// TagSlugName only count the posts of the questions with this tag, for the question and answer counts
+	TagSlugName string `validate:"omitempty,max=35" json:"tag_slug_name,omitempty"`
+	// Recipient who is evaluated and awarded, the actor of the event by default
+	Recipient string `validate:"omitempty,oneof=actor question_author answer_author comment_author" json:"recipient,omitempty"`
+}
+
+// IsPerPost the per post metric is awarded once for every post reaching the threshold
+func (r *BadgeRule) IsPerPost() bool {
+	return r.Metric == BadgeRuleMetricPostVoteCount
+}
+
+// TagScoped reports whether the metric can be limited to a tag
+func (r *BadgeRule) TagScoped() bool {
+	switch r.Metric {
+	case BadgeRuleMetricQuestionCount, BadgeRuleMetricAnswerCount, BadgeRuleMetricAcceptedAnswerCount:
+		return true
+	}
+	return false
+}
+
+// GetBadgeReq get badge detail request
+type GetBadgeReq struct {
+	ID string `validate:"required" form:"id"`
+}
+
+// AddBadgeReq add badge request
+type AddBadgeReq struct {
+	Name        string            `validate:"required,notblank,max=256" json:"name"`
+	Description string            `validate:"omitempty,max=4096" json:"description"`
+	Icon        string            `validate:"required,max=1024" json:"icon"`
+	Level       entity.BadgeLevel `validate:"required,oneof=1 2 3" json:"level"`
+	// IsSingle the badge can only be awarded once to each user
+	IsSingle bool       `json:"is_single"`
+	GroupID  string     `validate:"required" json:"group_id"`
+	Rule     *BadgeRule `validate:"required" json:"rule"`
+}
+
+// UpdateBadgeReq update badge request
+type UpdateBadgeReq struct {
+	ID          string            `validate:"required" json:"id"`
+	Name        string            `validate:"required,notblank,max=256" json:"name"`
+	Description string            `validate:"omitempty,max=4096" json:"description"`
+	Icon        string            `validate:"required,max=1024" json:"icon"`
+	Level       entity.BadgeLevel `validate:"required,oneof=1 2 3" json:"level"`
+	IsSingle    bool              `json:"is_single"`
+	GroupID     string            `validate:"required" json:"group_id"`
+	// Rule nil keeps the current rule, the built-in badges keep their handlers unless a rule is given
+	Rule *BadgeRule `validate:"omitempty" json:"rule"`
+}
+
+// DeleteBadgeReq delete badge request
+type DeleteBadgeReq struct {
+	ID string `validate:"required" json:"id"`
+}
+
+// BackfillBadgeReq award the badge to everyone who already meets its rule
+type BackfillBadgeReq struct {
+	ID string `validate:"required" json:"id"`
+}
+
+// GetBadgeDetailResp the badge detail for the admin
+type GetBadgeDetailResp struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Icon        string            `json:"icon"`
+	Level       entity.BadgeLevel `json:"level"`
+	IsSingle    bool              `json:"is_single"`
+	GroupID     string            `json:"group_id"`
+	AwardCount  int               `json:"award_count"`
+	Status      BadgeStatus       `json:"status"`
+	// Handler the built-in handler awarding the badge, Rule for the badges defined by the admin
+	Handler string     `json:"handler"`
+	Rule    *BadgeRule `json:"rule,omitempty"`
+}
+
+// BadgeGroupItem badge group
+type BadgeGroupItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AddBadgeGroupReq add badge group request
+type AddBadgeGroupReq struct {
+	Name string `validate:"required,notblank,max=256" json:"name"`
+}
+
+// UpdateBadgeGroupReq update badge group request
+type UpdateBadgeGroupReq struct {
+	ID   string `validate:"required" json:"id"`
+	Name string `validate:"required,notblank,max=256" json:"name"`
+}
+
+// DeleteBadgeGroupReq delete badge group request, only the empty group can be deleted
+type DeleteBadgeGroupReq struct {
+	ID string `validate:"required" json:"id"`
+}
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// SaveDraftReq save draft request, it is sent by the editor automatically
+type SaveDraftReq struct {
+	Type string `validate:"required,oneof=question answer edit" json:"type"`
+	// ObjectID the question id of the answer drafts, the question or answer id of the edit drafts
+	ObjectID string   `validate:"required_unless=Type question" json:"object_id"`
+	Title    string   `validate:"omitempty,lte=150" json:"title"`
+	Content  string   `validate:"omitempty,lte=65535" json:"content"`
+	Tags     []string `validate:"omitempty,lte=5,dive,lte=35" json:"tags"`
+	// BaseRevisionID the revision the editor loaded, only used by the edit drafts when the draft is created
+	BaseRevisionID string `json:"base_revision_id"`
+	// Rebase the user has resolved the conflict, the draft starts from the latest revision from now on
+	Rebase bool   `json:"rebase"`
+	UserID string `json:"-"`
+	// CanViewHidden the user can view the hidden, pending and scheduled questions of the others
+	CanViewHidden bool `json:"-"`
+}
+
+// GetDraftReq get draft request
+type GetDraftReq struct {
+	Type          string `validate:"required,oneof=question answer edit" form:"type"`
+	ObjectID      string `validate:"required_unless=Type question" form:"object_id"`
+	UserID        string `json:"-"`
+	CanViewHidden bool   `json:"-"`
+}
+
+// RemoveDraftReq remove draft request
+type RemoveDraftReq struct {
+	Type     string `validate:"required,oneof=question answer edit" json:"type"`
+	ObjectID string `validate:"required_unless=Type question" json:"object_id"`
+	UserID   string `json:"-"`
+}
+
+// GetDraftPageReq get draft page request
+type GetDraftPageReq struct {
+	Page          int    `validate:"omitempty,min=1" form:"page"`
+	PageSize      int    `validate:"omitempty,min=1" form:"page_size"`
+	UserID        string `json:"-"`
+	CanViewHidden bool   `json:"-"`
+}
+
+// DraftItem draft
+type DraftItem struct {
+	ID       string   `json:"id"`
+	Type     string   `json:"type"`
+	ObjectID string   `json:"object_id"`
+	Title    string   `json:"title"`
+	Content  string   `json:"content"`
+	Tags     []string `json:"tags"`
+	// QuestionID the question the draft belongs to, it is empty for the new question drafts
+	QuestionID string `json:"question_id"`
+	// QuestionTitle the title of the question the draft belongs to, to show in the list of drafts
+	QuestionTitle  string `json:"question_title"`
+	BaseRevisionID string `json:"base_revision_id"`
+	// CurrentRevisionID the latest revision of the post, only for the edit drafts
+	CurrentRevisionID string `json:"current_revision_id"`
+	// Conflict the post has been edited by others since the edit draft started
+	Conflict bool `json:"conflict"`
+	// ObjectRemoved the question or answer the draft belongs to has been deleted
+	ObjectRemoved bool  `json:"object_removed"`
+	UpdatedAt     int64 `json:"updated_at"`
+	ExpiredAt     int64 `json:"expired_at"`
+}
@@ -81,6 +81,7 @@ type NewAnswerTemplateRawData struct {
 	AnswerID              string
 	AnswerSummary         string
 	UnsubscribeCode       string
+	CanReply              bool
 }
 
 type NewAnswerTemplateData struct {
@@ -90,6 +91,7 @@ type NewAnswerTemplateData struct {
 	AnswerUrl      string
 	AnswerSummary  string
 	UnsubscribeUrl string
+	CanReply       bool
 }
 
 type NewInviteAnswerTemplateRawData struct {
@@ -97,6 +99,7 @@ type NewInviteAnswerTemplateRawData struct {
 	QuestionTitle      string
 	QuestionID         string
 	UnsubscribeCode    string
+	CanReply           bool
 }
 
 type NewInviteAnswerTemplateData struct {
@@ -105,6 +108,7 @@ type NewInviteAnswerTemplateData struct {
 	QuestionTitle  string
 	InviteUrl      string
 	UnsubscribeUrl string
+	CanReply       bool
 }
 
 type NewCommentTemplateRawData struct {
@@ -115,6 +119,7 @@ type NewCommentTemplateRawData struct {
 	CommentID              string
 	CommentSummary         string
 	UnsubscribeCode        string
+	CanReply               bool
 }
 
 type NewCommentTemplateData struct {
@@ -124,6 +129,7 @@ type NewCommentTemplateData struct {
 	CommentUrl     string
 	CommentSummary string
 	UnsubscribeUrl string
+	CanReply       bool
 }
 
 type NewQuestionTemplateRawData struct {
@@ -133,6 +139,7 @@ type NewQuestionTemplateRawData struct {
 	UnsubscribeCode      string
 	Tags                 []string
 	TagIDs               []string
+	CanReply             bool
 }
 
 type NewQuestionTemplateData struct {
@@ -141,4 +148,52 @@ type NewQuestionTemplateData struct {
 	QuestionUrl    string
 	Tags           string
 	UnsubscribeUrl string
+	CanReply       bool
+}
+
+type DigestTemplateRawData struct {
+	Frequency             string
+	Notifications         []*DigestNotificationRawData
+	FollowingTagQuestions []*DigestQuestionRawData
+	UnansweredQuestions   []*DigestQuestionRawData
+	UnsubscribeCode       string
+}
+
+type DigestNotificationRawData struct {
+	DisplayName   string
+	Action        string
+	QuestionID    string
+	QuestionTitle string
+	AnswerID      string
+}
+
+type DigestQuestionRawData struct {
+	QuestionID    string
+	QuestionTitle string
+	VoteCount     int
+	AnswerCount   int
+}
+
+type DigestTemplateData struct {
+	SiteName              string
+	Frequency             string
+	Notifications         []*DigestNotificationTemplateData
+	FollowingTagQuestions []*DigestQuestionTemplateData
+	UnansweredQuestions   []*DigestQuestionTemplateData
+	SettingsUrl           string
+	UnsubscribeUrl        string
+}
+
+type DigestNotificationTemplateData struct {
+	DisplayName   string
+	Action        string
+	QuestionTitle string
+	Url           string
+}
+
+type DigestQuestionTemplateData struct {
+	QuestionTitle string
+	QuestionUrl   string
+	VoteCount     int
+	AnswerCount   int
 }
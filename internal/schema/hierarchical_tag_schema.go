@@ -34,6 +34,8 @@ type HierarchicalTagItem struct {
 	Level       int                    `json:"level"`
 	Path        string                 `json:"path"`
 	Description string                 `json:"description,omitempty"`
+	SortOrder   int                    `json:"sort_order"`
+	LeafOnly    bool                   `json:"leaf_only"`
 	HasChildren bool                   `json:"has_children"`
 	Children    []*HierarchicalTagItem `json:"children,omitempty"`
 }
@@ -61,6 +63,50 @@ type UpdateHierarchicalTagReq struct {
 	Description string `json:"description,omitempty"`
 }
 
+// DeleteHierarchicalTagReq request for deleting hierarchical tag with its subtree
+type DeleteHierarchicalTagReq struct {
+	ID string `json:"id" validate:"required"`
+}
+
+// MoveHierarchicalTagReq request for moving hierarchical tag under a new parent
+type MoveHierarchicalTagReq struct {
+	ID string `json:"id" validate:"required"`
+	// ParentID new parent tag ID, empty means moving to the root level
+	ParentID string `json:"parent_id"`
+}
+
+// ReorderHierarchicalTagsReq request for sorting the children of a hierarchical tag
+type ReorderHierarchicalTagsReq struct {
+	// ParentID parent tag ID, empty means sorting the root tags
+	ParentID string `json:"parent_id"`
+	// TagIDs the children in their new order, the ones not given are placed after them
+	TagIDs []string `json:"tag_ids" validate:"required,gt=0,dive,required"`
+}
+
+// GetHierarchicalTagPermissionReq request for getting the permission of hierarchical tag
+type GetHierarchicalTagPermissionReq struct {
+	ID string `form:"id" validate:"required"`
+}
+
+// HierarchicalTagPermissionResp the filing settings and the moderators of hierarchical tag
+type HierarchicalTagPermissionResp struct {
+	ID string `json:"id"`
+	// LeafOnly questions can only be filed under the descendants of the tag
+	LeafOnly bool `json:"leaf_only"`
+	// RoleIDs roles allowed to file questions under the tag and its subtree, empty means all roles
+	RoleIDs []int `json:"role_ids"`
+	// Moderators users moderating the questions filed under the tag and its subtree
+	Moderators []*UserBasicInfo `json:"moderators"`
+}
+
+// UpdateHierarchicalTagPermissionReq request for updating the permission of hierarchical tag
+type UpdateHierarchicalTagPermissionReq struct {
+	ID               string   `json:"id" validate:"required"`
+	LeafOnly         bool     `json:"leaf_only"`
+	RoleIDs          []int    `json:"role_ids" validate:"omitempty,dive,min=1"`
+	ModeratorUserIDs []string `json:"moderator_user_ids" validate:"omitempty,dive,required"`
+}
+
 // HierarchicalTagPathReq request for hierarchical tag path
 type HierarchicalTagPathReq struct {
 	TagID string `json:"tag_id" validate:"required"`
@@ -72,3 +118,66 @@ type HierarchicalTagPathResp struct {
 	DisplayPath string                 `json:"display_path"`
 	Tags        []*HierarchicalTagItem `json:"tags"`
 }
+
+const (
+	HierarchicalTagExchangeFormatJSON = "json"
+	HierarchicalTagExchangeFormatYAML = "yaml"
+	HierarchicalTagExchangeFormatCSV  = "csv"
+
+	HierarchicalTagImportActionCreate = "create"
+	HierarchicalTagImportActionUpdate = "update"
+)
+
+// HierarchicalTagExchangeItem a single hierarchical tag in an exported or imported taxonomy file
+type HierarchicalTagExchangeItem struct {
+	SlugName       string `json:"slug_name" yaml:"slug_name"`
+	ParentSlugName string `json:"parent_slug_name,omitempty" yaml:"parent_slug_name,omitempty"`
+	DisplayName    string `json:"display_name" yaml:"display_name"`
+	Description    string `json:"description,omitempty" yaml:"description,omitempty"`
+	SortOrder      int    `json:"sort_order" yaml:"sort_order"`
+}
+
+// ExportHierarchicalTagsReq request for exporting the whole taxonomy
+type ExportHierarchicalTagsReq struct {
+	Format string `validate:"required,oneof=json yaml csv" form:"format"`
+}
+
+// ExportHierarchicalTagsResp exported taxonomy file
+type ExportHierarchicalTagsResp struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// ImportHierarchicalTagsReq request for importing a taxonomy file.
+// Tags are matched by slug name, tags absent from the file are kept as they are.
+type ImportHierarchicalTagsReq struct {
+	Format  string `validate:"required,oneof=json yaml csv" json:"format"`
+	Content string `validate:"required" json:"content"`
+	// DryRun only returns the diff without applying it
+	DryRun bool `json:"dry_run"`
+}
+
+// HierarchicalTagImportChange a hierarchical tag which will be created or updated by the import
+type HierarchicalTagImportChange struct {
+	Action string                       `json:"action"`
+	Before *HierarchicalTagExchangeItem `json:"before,omitempty"`
+	After  *HierarchicalTagExchangeItem `json:"after"`
+}
+
+// HierarchicalTagImportProblem an invalid row of the imported taxonomy file
+type HierarchicalTagImportProblem struct {
+	Row      int    `json:"row"`
+	SlugName string `json:"slug_name"`
+	Reason   string `json:"reason"`
+}
+
+// ImportHierarchicalTagsResp diff of the imported taxonomy against the current one
+type ImportHierarchicalTagsResp struct {
+	DryRun    bool                            `json:"dry_run"`
+	Created   int                             `json:"created"`
+	Updated   int                             `json:"updated"`
+	Unchanged int                             `json:"unchanged"`
+	Changes   []*HierarchicalTagImportChange  `json:"changes"`
+	Problems  []*HierarchicalTagImportProblem `json:"problems,omitempty"`
+}
@@ -0,0 +1,138 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// ModerationActionApprove keeps the post, the report is ignored or the post and revision pass the review
+	ModerationActionApprove = "approve"
+	// ModerationActionReject the post or revision fails the review, the reported post is deleted
+	ModerationActionReject = "reject"
+	// ModerationActionDelete deletes the post, it is the same as reject for the revisions
+	ModerationActionDelete = "delete"
+)
+
+// ModerationSnapshot the status of the moderation item and its object at some point
+type ModerationSnapshot struct {
+	ItemStatus       int `json:"item_status"`
+	ObjectStatus     int `json:"object_status"`
+	ObjectShowStatus int `json:"object_show_status"`
+}
+
+// GetModerationQueueReq get moderation queue request
+type GetModerationQueueReq struct {
+	ItemType string `validate:"omitempty,oneof=report review revision" form:"item_type"`
+	// Tag the slug name of the tag which the question of the item has
+	Tag string `validate:"omitempty,lte=35" form:"tag"`
+	// MinAge only the items which are submitted at least the hours ago
+	MinAge int `validate:"omitempty,min=0" form:"min_age"`
+	// MaxAge only the items which are submitted at most the hours ago
+	MaxAge            int    `validate:"omitempty,min=0" form:"max_age"`
+	Page              int    `validate:"omitempty,min=1" form:"page"`
+	PageSize          int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+	UserID            string `json:"-"`
+	CanReviewQuestion bool   `json:"-"`
+	CanReviewAnswer   bool   `json:"-"`
+	CanReviewTag      bool   `json:"-"`
+}
+
+// ModerationQueueItem moderation queue item
+type ModerationQueueItem struct {
+	ItemType   string     `json:"item_type"`
+	ItemID     string     `json:"item_id"`
+	ObjectID   string     `json:"object_id"`
+	ObjectType string     `json:"object_type"`
+	QuestionID string     `json:"question_id"`
+	AnswerID   string     `json:"answer_id"`
+	CommentID  string     `json:"comment_id"`
+	Title      string     `json:"title"`
+	UrlTitle   string     `json:"url_title"`
+	ParsedText string     `json:"parsed_text"`
+	Tags       []*TagResp `json:"tags"`
+	// Reason the content of the report or the reason why the post needs review
+	Reason         string        `json:"reason"`
+	SubmitAt       int64         `json:"submit_at"`
+	AuthorUserInfo UserBasicInfo `json:"author_user_info"`
+	// ClaimUserInfo the moderator who is working on the item, it is empty if nobody claims it
+	ClaimUserInfo  *UserBasicInfo `json:"claim_user_info"`
+	ClaimExpiredAt int64          `json:"claim_expired_at"`
+}
+
+// ModerationItem moderation item
+type ModerationItem struct {
+	ItemType string `validate:"required,oneof=report review revision" json:"item_type"`
+	ItemID   string `validate:"required" json:"item_id"`
+}
+
+// ModerationClaimReq claim or release moderation item request
+type ModerationClaimReq struct {
+	ModerationItem
+	UserID string `json:"-"`
+}
+
+// ModerationClaimResp moderation claim response
+type ModerationClaimResp struct {
+	ItemType  string `json:"item_type"`
+	ItemID    string `json:"item_id"`
+	ExpiredAt int64  `json:"expired_at"`
+}
+
+// ModerateItemsReq take the action on the moderation items in bulk
+type ModerateItemsReq struct {
+	Items             []*ModerationItem `validate:"required,min=1,max=100,dive" json:"items"`
+	Action            string            `validate:"required,oneof=approve reject delete" json:"action"`
+	Reason            string            `validate:"omitempty,lte=500" json:"reason"`
+	UserID            string            `json:"-"`
+	CanReviewQuestion bool              `json:"-"`
+	CanReviewAnswer   bool              `json:"-"`
+	CanReviewTag      bool              `json:"-"`
+}
+
+// ModerateItemResult the result of the action on the moderation item
+type ModerateItemResult struct {
+	ItemType string `json:"item_type"`
+	ItemID   string `json:"item_id"`
+	Success  bool   `json:"success"`
+	// Msg the reason why the action failed
+	Msg string `json:"msg"`
+}
+
+// GetModerationLogPageReq get moderation log page request
+type GetModerationLogPageReq struct {
+	// OperatorID the moderator who took the action
+	OperatorID string `validate:"omitempty" form:"operator_id"`
+	ItemType   string `validate:"omitempty,oneof=report review revision" form:"item_type"`
+	ObjectID   string `validate:"omitempty" form:"object_id"`
+	Page       int    `validate:"omitempty,min=1" form:"page"`
+	PageSize   int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+}
+
+// ModerationLogResp moderation log response
+type ModerationLogResp struct {
+	ID           int                 `json:"id"`
+	CreatedAt    int64               `json:"created_at"`
+	OperatorInfo UserBasicInfo       `json:"operator_info"`
+	ItemType     string              `json:"item_type"`
+	ItemID       string              `json:"item_id"`
+	ObjectID     string              `json:"object_id"`
+	Action       string              `json:"action"`
+	Before       *ModerationSnapshot `json:"before"`
+	After        *ModerationSnapshot `json:"after"`
+	Reason       string              `json:"reason"`
+}
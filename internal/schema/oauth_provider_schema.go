@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "github.com/apache/answer/pkg/jwt"
+
+const (
+	// OAuthScopeOpenID issue the id token to the client
+	OAuthScopeOpenID = "openid"
+	// OAuthScopeProfile the name, username, avatar and profile page of the user
+	OAuthScopeProfile = "profile"
+	// OAuthScopeEmail the email of the user and whether it is verified
+	OAuthScopeEmail = "email"
+)
+
+// OAuthClientItem oauth client, the secret is only returned once when it is created or reset
+type OAuthClientItem struct {
+	ID           string   `json:"id"`
+	ClientID     string   `json:"client_id"`
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirect_uris"`
+	Scopes       []string `json:"scopes"`
+	Public       bool     `json:"public"`
+	CreatedAt    int64    `json:"created_at"`
+}
+
+// AddOAuthClientReq add oauth client request
+type AddOAuthClientReq struct {
+	Name         string   `validate:"required,notblank,max=128" json:"name"`
+	RedirectURIs []string `validate:"required,gt=0,lte=20,dive,required,max=1024" json:"redirect_uris"`
+	Scopes       []string `validate:"required,gt=0,dive,oneof=openid profile email" json:"scopes"`
+	// Public the client cannot keep a secret, such as the mobile or single page apps, it must use PKCE
+	Public bool `json:"public"`
+}
+
+// AddOAuthClientResp add oauth client response
+type AddOAuthClientResp struct {
+	*OAuthClientItem
+	// ClientSecret it is empty for the public clients and cannot be got again
+	ClientSecret string `json:"client_secret"`
+}
+
+// UpdateOAuthClientReq update oauth client request
+type UpdateOAuthClientReq struct {
+	ID           string   `validate:"required" json:"id"`
+	Name         string   `validate:"required,notblank,max=128" json:"name"`
+	RedirectURIs []string `validate:"required,gt=0,lte=20,dive,required,max=1024" json:"redirect_uris"`
+	Scopes       []string `validate:"required,gt=0,dive,oneof=openid profile email" json:"scopes"`
+}
+
+// DeleteOAuthClientReq delete oauth client request
+type DeleteOAuthClientReq struct {
+	ID string `validate:"required" json:"id"`
+}
+
+// ResetOAuthClientSecretReq reset oauth client secret request
+type ResetOAuthClientSecretReq struct {
+	ID string `validate:"required" json:"id"`
+}
+
+// ResetOAuthClientSecretResp reset oauth client secret response
+type ResetOAuthClientSecretResp struct {
+	ClientSecret string `json:"client_secret"`
+}
+
+// OAuthAuthorizeReq the authorization request sent by the client, the consent page passes it as it is
+type OAuthAuthorizeReq struct {
+	ClientID            string `validate:"required,max=64" form:"client_id" json:"client_id"`
+	RedirectURI         string `validate:"omitempty,max=1024" form:"redirect_uri" json:"redirect_uri"`
+	ResponseType        string `validate:"omitempty" form:"response_type" json:"response_type"`
+	Scope               string `validate:"omitempty,max=255" form:"scope" json:"scope"`
+	State               string `validate:"omitempty,max=1024" form:"state" json:"state"`
+	CodeChallenge       string `validate:"omitempty,max=128" form:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `validate:"omitempty" form:"code_challenge_method" json:"code_challenge_method"`
+	Nonce               string `validate:"omitempty,max=255" form:"nonce" json:"nonce"`
+	UserID              string `json:"-"`
+}
+
+// OAuthAuthorizeInfoResp what the consent page shows to the user
+type OAuthAuthorizeInfoResp struct {
+	ClientName string   `json:"client_name"`
+	Scopes     []string `json:"scopes"`
+	// Consented the user has granted these scopes to the client before, the page can approve it without asking
+	Consented bool `json:"consented"`
+	// RedirectURL it is set when the request is invalid, the user should be sent back to the client with the error
+	RedirectURL string `json:"redirect_url,omitempty"`
+}
+
+// OAuthConsentReq the user approves or denies the authorization request
+type OAuthConsentReq struct {
+	OAuthAuthorizeReq
+	Approve bool `json:"approve"`
+}
+
+// OAuthConsentResp oauth consent response
+type OAuthConsentResp struct {
+	// RedirectURL send the user back to the client with the code or the error
+	RedirectURL string `json:"redirect_url"`
+}
+
+// OAuthTokenReq the token request sent by the client, the client credentials can also be sent by basic auth
+type OAuthTokenReq struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Scope        string `form:"scope"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+// OAuthTokenResp oauth token response
+type OAuthTokenResp struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope"`
+	IDToken      string `json:"id_token,omitempty"`
+}
+
+// OAuthRevokeReq the token revocation request sent by the client
+type OAuthRevokeReq struct {
+	Token         string `form:"token"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+// OAuthErrorResp the error returned to the client
+type OAuthErrorResp struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// OAuthUserInfoResp the claims of the user, what it contains depends on the granted scopes
+type OAuthUserInfoResp struct {
+	Sub               string `json:"sub"`
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Picture           string `json:"picture,omitempty"`
+	Profile           string `json:"profile,omitempty"`
+	Website           string `json:"website,omitempty"`
+	Locale            string `json:"locale,omitempty"`
+	UpdatedAt         int64  `json:"updated_at,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"`
+}
+
+// OIDCDiscoveryResp the openid provider metadata
+type OIDCDiscoveryResp struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JwksURI                           string   `json:"jwks_uri"`
+	RevocationEndpoint                string   `json:"revocation_endpoint"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// JWKSResp the public keys to verify the id tokens
+type JWKSResp struct {
+	Keys []*jwt.JWK `json:"keys"`
+}
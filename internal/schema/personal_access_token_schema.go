@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// PersonalAccessTokenScopeRead read anything the user can see
+	PersonalAccessTokenScopeRead = "read"
+	// PersonalAccessTokenScopeWriteQuestion add, update and operate questions
+	PersonalAccessTokenScopeWriteQuestion = "write:question"
+	// PersonalAccessTokenScopeWriteAnswer add, update and operate answers
+	PersonalAccessTokenScopeWriteAnswer = "write:answer"
+	// PersonalAccessTokenScopeWriteComment add, update and delete comments
+	PersonalAccessTokenScopeWriteComment = "write:comment"
+	// PersonalAccessTokenScopeAdmin call the admin api, only for the admin
+	PersonalAccessTokenScopeAdmin = "admin"
+)
+
+// PersonalAccessTokenItem personal access token, the token itself is only returned once when it is created
+type PersonalAccessTokenItem struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	TokenPrefix string   `json:"token_prefix"`
+	Scopes      []string `json:"scopes"`
+	// ExpiredAt the token never expires if it is 0
+	ExpiredAt  int64 `json:"expired_at"`
+	LastUsedAt int64 `json:"last_used_at"`
+	CreatedAt  int64 `json:"created_at"`
+}
+
+// AddPersonalAccessTokenReq add personal access token request
+type AddPersonalAccessTokenReq struct {
+	Name   string   `validate:"required,notblank,max=128" json:"name"`
+	Scopes []string `validate:"required,gt=0,dive,oneof=read write:question write:answer write:comment admin" json:"scopes"`
+	// ExpiresInDays the token never expires if it is 0
+	ExpiresInDays int    `validate:"omitempty,min=0,max=3650" json:"expires_in_days"`
+	UserID        string `json:"-"`
+}
+
+// AddPersonalAccessTokenResp add personal access token response
+type AddPersonalAccessTokenResp struct {
+	*PersonalAccessTokenItem
+	// Token the token to put in the header "Authorization: Bearer <token>", it cannot be got again
+	Token string `json:"token"`
+}
+
+// RevokePersonalAccessTokenReq revoke personal access token request
+type RevokePersonalAccessTokenReq struct {
+	ID     string `validate:"required" json:"id"`
+	UserID string `json:"-"`
+	// IsAdmin the admin can revoke the token of any user
+	IsAdmin bool `json:"-"`
+}
+
+// GetPersonalAccessTokenPageReq get personal access token page request
+type GetPersonalAccessTokenPageReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1" form:"page_size"`
+	UserID   string `validate:"omitempty" form:"user_id"`
+}
+
+// AdminPersonalAccessTokenItem personal access token with the user who owns it
+type AdminPersonalAccessTokenItem struct {
+	*PersonalAccessTokenItem
+	User *UserBasicInfo `json:"user"`
+}
@@ -127,6 +127,8 @@ type ConfigField struct {
 	Value       any                  `json:"value"`
 	UIOptions   ConfigFieldUIOptions `json:"ui_options"`
 	Options     []ConfigFieldOption  `json:"options,omitempty"`
+	// EnvName the environment variable which pins the value, the value is hidden and can not be changed
+	EnvName string `json:"env_name,omitempty"`
 }
 
 type ConfigFieldUIOptions struct {
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// QuestionAutoCloseRuleItem question auto close rule
+type QuestionAutoCloseRuleItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Tag slug name, the rule applies to all questions when it is empty
+	Tag          string `json:"tag"`
+	InactiveDays int    `json:"inactive_days"`
+	NoAnswer     bool   `json:"no_answer"`
+	CloseType    int    `json:"close_type"`
+	CloseMsg     string `json:"close_msg"`
+	Active       bool   `json:"active"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
+// AddQuestionAutoCloseRuleReq add question auto close rule request
+type AddQuestionAutoCloseRuleReq struct {
+	Name string `validate:"required,notblank,max=128" json:"name"`
+	// Tag slug name, the rule applies to all questions when it is empty
+	Tag string `validate:"omitempty,max=35" json:"tag"`
+	// InactiveDays close the questions which have no activity for days
+	InactiveDays int `validate:"required,min=1,max=3650" json:"inactive_days"`
+	// NoAnswer only close the questions which have no answer
+	NoAnswer bool `json:"no_answer"`
+	// CloseType the close reason returned by the reasons api
+	CloseType int    `validate:"required" json:"close_type"`
+	CloseMsg  string `validate:"omitempty,max=1024" json:"close_msg"`
+	Active    bool   `json:"active"`
+	UserID    string `json:"-"`
+}
+
+// UpdateQuestionAutoCloseRuleReq update question auto close rule request
+type UpdateQuestionAutoCloseRuleReq struct {
+	ID           string `validate:"required" json:"id"`
+	Name         string `validate:"required,notblank,max=128" json:"name"`
+	Tag          string `validate:"omitempty,max=35" json:"tag"`
+	InactiveDays int    `validate:"required,min=1,max=3650" json:"inactive_days"`
+	NoAnswer     bool   `json:"no_answer"`
+	CloseType    int    `validate:"required" json:"close_type"`
+	CloseMsg     string `validate:"omitempty,max=1024" json:"close_msg"`
+	Active       bool   `json:"active"`
+	UserID       string `json:"-"`
+}
+
+// DeleteQuestionAutoCloseRuleReq delete question auto close rule request
+type DeleteQuestionAutoCloseRuleReq struct {
+	ID string `validate:"required" json:"id"`
+}
@@ -84,9 +84,15 @@ type QuestionAdd struct {
 	HTML string `json:"-"`
 	// tags
 	Tags []*TagItem `validate:"required,dive" json:"tags"`
+	// hierarchical tag ids
+	HierarchicalTagIDs []string `validate:"omitempty,dive,required" json:"hierarchical_tag_ids"`
+	// publish at, unix timestamp in seconds, the question is published immediately when it is empty
+	PublishAt int64 `validate:"omitempty,min=0" json:"publish_at"`
 	// user id
 	UserID string `json:"-"`
 	QuestionPermission
+	// whether user can schedule the question publishing
+	CanSchedule bool   `json:"-"`
 	CaptchaID   string `json:"captcha_id"` // captcha_id
 	CaptchaCode string `json:"captcha_code"`
 	IP          string `json:"-"`
@@ -120,6 +126,8 @@ type QuestionAddByAnswer struct {
 	AnswerHTML    string `json:"-"`
 	// tags
 	Tags []*TagItem `validate:"required,dive" json:"tags"`
+	// hierarchical tag ids
+	HierarchicalTagIDs []string `validate:"omitempty,dive,required" json:"hierarchical_tag_ids"`
 	// user id
 	UserID              string   `json:"-"`
 	MentionUsernameList []string `validate:"omitempty" json:"mention_username_list"`
@@ -201,11 +209,15 @@ type QuestionUpdate struct {
 	InviteUser []string `validate:"omitempty"  json:"invite_user"`
 	// tags
 	Tags []*TagItem `validate:"required,dive" json:"tags"`
+	// hierarchical tag ids, keep the current ones when omitted and remove all of them when empty
+	HierarchicalTagIDs []string `validate:"omitempty,dive,required" json:"hierarchical_tag_ids"`
 	// edit summary
 	EditSummary string `validate:"omitempty" json:"edit_summary"`
 	// user id
 	UserID       string `json:"-"`
 	NoNeedReview bool   `json:"-"`
+	// whether the question is rolled back to an old revision
+	Rollback bool `json:"-"`
 	QuestionPermission
 	CaptchaID   string `json:"captcha_id"` // captcha_id
 	CaptchaCode string `json:"captcha_code"`
@@ -249,40 +261,41 @@ type QuestionBaseInfo struct {
 }
 
 type QuestionInfoResp struct {
-	ID                   string         `json:"id" `
-	Title                string         `json:"title"`
-	UrlTitle             string         `json:"url_title"`
-	Content              string         `json:"content"`
-	HTML                 string         `json:"html"`
-	Description          string         `json:"description"`
-	Tags                 []*TagResp     `json:"tags"`
-	ViewCount            int            `json:"view_count"`
-	UniqueViewCount      int            `json:"unique_view_count"`
-	VoteCount            int            `json:"vote_count"`
-	AnswerCount          int            `json:"answer_count"`
-	CollectionCount      int            `json:"collection_count"`
-	FollowCount          int            `json:"follow_count"`
-	AcceptedAnswerID     string         `json:"accepted_answer_id"`
-	LastAnswerID         string         `json:"last_answer_id"`
-	CreateTime           int64          `json:"create_time"`
-	UpdateTime           int64          `json:"-"`
-	PostUpdateTime       int64          `json:"update_time"`
-	QuestionUpdateTime   int64          `json:"edit_time"`
-	Pin                  int            `json:"pin"`
-	Show                 int            `json:"show"`
-	Status               int            `json:"status"`
-	Operation            *Operation     `json:"operation,omitempty"`
-	UserID               string         `json:"-"`
-	LastEditUserID       string         `json:"-"`
-	LastAnsweredUserID   string         `json:"-"`
-	UserInfo             *UserBasicInfo `json:"user_info"`
-	UpdateUserInfo       *UserBasicInfo `json:"update_user_info,omitempty"`
-	LastAnsweredUserInfo *UserBasicInfo `json:"last_answered_user_info,omitempty"`
-	Answered             bool           `json:"answered"`
-	FirstAnswerId        string         `json:"first_answer_id"`
-	Collected            bool           `json:"collected"`
-	VoteStatus           string         `json:"vote_status"`
-	IsFollowed           bool           `json:"is_followed"`
+	ID                   string                 `json:"id" `
+	Title                string                 `json:"title"`
+	UrlTitle             string                 `json:"url_title"`
+	Content              string                 `json:"content"`
+	HTML                 string                 `json:"html"`
+	Description          string                 `json:"description"`
+	Tags                 []*TagResp             `json:"tags"`
+	HierarchicalTags     []*HierarchicalTagItem `json:"hierarchical_tags"`
+	ViewCount            int                    `json:"view_count"`
+	UniqueViewCount      int                    `json:"unique_view_count"`
+	VoteCount            int                    `json:"vote_count"`
+	AnswerCount          int                    `json:"answer_count"`
+	CollectionCount      int                    `json:"collection_count"`
+	FollowCount          int                    `json:"follow_count"`
+	AcceptedAnswerID     string                 `json:"accepted_answer_id"`
+	LastAnswerID         string                 `json:"last_answer_id"`
+	CreateTime           int64                  `json:"create_time"`
+	UpdateTime           int64                  `json:"-"`
+	PostUpdateTime       int64                  `json:"update_time"`
+	QuestionUpdateTime   int64                  `json:"edit_time"`
+	Pin                  int                    `json:"pin"`
+	Show                 int                    `json:"show"`
+	Status               int                    `json:"status"`
+	Operation            *Operation             `json:"operation,omitempty"`
+	UserID               string                 `json:"-"`
+	LastEditUserID       string                 `json:"-"`
+	LastAnsweredUserID   string                 `json:"-"`
+	UserInfo             *UserBasicInfo         `json:"user_info"`
+	UpdateUserInfo       *UserBasicInfo         `json:"update_user_info,omitempty"`
+	LastAnsweredUserInfo *UserBasicInfo         `json:"last_answered_user_info,omitempty"`
+	Answered             bool                   `json:"answered"`
+	FirstAnswerId        string                 `json:"first_answer_id"`
+	Collected            bool                   `json:"collected"`
+	VoteStatus           string                 `json:"vote_status"`
+	IsFollowed           bool                   `json:"is_followed"`
 
 	// MemberActions
 	MemberActions  []*PermissionMemberAction `json:"member_actions"`
@@ -391,6 +404,8 @@ type QuestionPageReq struct {
 	Tag       string `validate:"omitempty,gt=0,lte=100" form:"tag"`
 	Username  string `validate:"omitempty,gt=0,lte=100" form:"username"`
 	InDays    int    `validate:"omitempty,min=1" form:"in_days"`
+	// Category slug path of a hierarchical tag such as backend/go, questions in its subtree are included
+	Category string `validate:"omitempty,gt=0,lte=500" form:"category"`
 
 	LoginUserID      string `json:"-"`
 	UserIDBeSearched string `json:"-"`
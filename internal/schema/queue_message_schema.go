@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "github.com/apache/answer/internal/entity"
+
+const (
+	QueueMessageStatusPending    = "pending"
+	QueueMessageStatusProcessing = "processing"
+	QueueMessageStatusDead       = "dead"
+)
+
+var queueMessageStatusMapping = map[string]int{
+	QueueMessageStatusPending:    entity.QueueMessageStatusPending,
+	QueueMessageStatusProcessing: entity.QueueMessageStatusProcessing,
+	QueueMessageStatusDead:       entity.QueueMessageStatusDead,
+}
+
+// QueueMessageStatusName get the status name of queue message
+func QueueMessageStatusName(status int) string {
+	for name, s := range queueMessageStatusMapping {
+		if s == status {
+			return name
+		}
+	}
+	return ""
+}
+
+// GetQueueMessagePageReq get queue message page request
+type GetQueueMessagePageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// Queue queue name, empty means all queues
+	Queue string `validate:"omitempty" form:"queue"`
+	// Status message status, empty means all status
+	Status string `validate:"omitempty,oneof=pending processing dead" form:"status"`
+}
+
+// GetStatus get the status value, 0 means all status
+func (r *GetQueueMessagePageReq) GetStatus() int {
+	return queueMessageStatusMapping[r.Status]
+}
+
+// QueueMessageItem queue message
+type QueueMessageItem struct {
+	ID        int64  `json:"id"`
+	Queue     string `json:"queue"`
+	Payload   string `json:"payload"`
+	Status    string `json:"status"`
+	Attempts  int    `json:"attempts"`
+	NextRunAt int64  `json:"next_run_at"`
+	LastError string `json:"last_error"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// ReplayQueueMessagesReq replay queue messages request
+type ReplayQueueMessagesReq struct {
+	IDs []int64 `validate:"required,gt=0" json:"ids"`
+}
+
+// ReplayQueueMessagesResp replay queue messages response
+type ReplayQueueMessagesResp struct {
+	// Replayed the number of messages put back to the queue, processing messages are skipped
+	Replayed int64 `json:"replayed"`
+}
@@ -110,6 +110,8 @@ type ReviewReportReq struct {
 	Title         string     `validate:"omitempty,notblank,gte=6,lte=150" json:"title"`
 	Content       string     `validate:"omitempty,notblank,gte=6,lte=65535" json:"content"`
 	Tags          []*TagItem `validate:"omitempty,dive" json:"tags"`
-	UserID        string     `json:"-"`
-	IsAdmin       bool       `json:"-"`
+	// Reason why the moderator takes the action, it is saved in the moderation log
+	Reason  string `validate:"omitempty,lte=500" json:"reason"`
+	UserID  string `json:"-"`
+	IsAdmin bool   `json:"-"`
 }
@@ -28,8 +28,10 @@ import (
 type UpdateReviewReq struct {
 	ReviewID int    `validate:"required" json:"review_id"`
 	Status   string `validate:"required,oneof=approve reject" json:"status"`
-	UserID   string `json:"-"`
-	IsAdmin  bool   `json:"-"`
+	// Reason why the moderator takes the action, it is saved in the moderation log
+	Reason  string `validate:"omitempty,lte=500" json:"reason"`
+	UserID  string `json:"-"`
+	IsAdmin bool   `json:"-"`
 }
 
 func (r *UpdateReviewReq) IsApprove() bool {
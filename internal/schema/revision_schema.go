@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/pkg/diff"
 )
 
 // AddRevisionDTO add revision request
@@ -52,8 +53,10 @@ const RevisionAuditReject = "reject"
 
 type RevisionAuditReq struct {
 	// object id
-	ID                string `validate:"required" comment:"id" form:"id"`
-	Operation         string `validate:"required" comment:"operation" form:"operation"` //approve or reject
+	ID        string `validate:"required" comment:"id" form:"id"`
+	Operation string `validate:"required" comment:"operation" form:"operation"` //approve or reject
+	// Reason why the moderator takes the action, it is saved in the moderation log
+	Reason            string `validate:"omitempty,lte=500" comment:"reason" form:"reason"`
 	UserID            string `json:"-"`
 	CanReviewQuestion bool   `json:"-"`
 	CanReviewAnswer   bool   `json:"-"`
@@ -105,6 +108,44 @@ type GetRevisionResp struct {
 	Log             string        `json:"reason"`
 }
 
+const (
+	RevisionDiffGranularityLine = "line"
+	RevisionDiffGranularityWord = "word"
+)
+
+// GetRevisionDiffReq get the changes between two revisions of the same object
+type GetRevisionDiffReq struct {
+	// the old revision id
+	FromID string `validate:"required" form:"from_id"`
+	// the new revision id
+	ToID string `validate:"required" form:"to_id"`
+	// compare the content by line or word, line by default
+	Granularity string `validate:"omitempty,oneof=line word" form:"granularity"`
+}
+
+// GetRevisionDiffResp the changes between two revisions, the title is the display name for tags
+type GetRevisionDiffResp struct {
+	ObjectID   string          `json:"object_id"`
+	ObjectType string          `json:"object_type"`
+	From       GetRevisionResp `json:"from"`
+	To         GetRevisionResp `json:"to"`
+	Title      []*diff.Segment `json:"title"`
+	Content    []*diff.Segment `json:"content"`
+	// AddedTags RemovedTags the slug names of the tags changed, only for questions
+	AddedTags   []string `json:"added_tags"`
+	RemovedTags []string `json:"removed_tags"`
+}
+
+// RollbackRevisionReq create a new revision from an old revision of the object
+type RollbackRevisionReq struct {
+	// the old revision id
+	RevisionID  string `validate:"required" json:"revision_id"`
+	EditSummary string `validate:"omitempty" json:"edit_summary"`
+	CaptchaID   string `json:"captcha_id"`
+	CaptchaCode string `json:"captcha_code"`
+	UserID      string `json:"-"`
+}
+
 // GetReviewingTypeReq get reviewing type request
 type GetReviewingTypeReq struct {
 	CanReviewQuestion bool   `json:"-"`
@@ -24,4 +24,35 @@ type GetRoleResp struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	// the built-in roles can not be modified
+	BuiltIn    bool     `json:"built_in"`
+	PowerTypes []string `json:"power_types"`
+}
+
+// GetPowerResp get power response
+type GetPowerResp struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	PowerType   string `json:"power_type"`
+	Description string `json:"description"`
+}
+
+// AddRoleReq add custom role request
+type AddRoleReq struct {
+	Name        string   `validate:"required,notblank,lte=50" json:"name"`
+	Description string   `validate:"omitempty,lte=200" json:"description"`
+	PowerTypes  []string `validate:"omitempty,dive,required" json:"power_types"`
+}
+
+// UpdateRoleReq update custom role request
+type UpdateRoleReq struct {
+	ID          int      `validate:"required" json:"id"`
+	Name        string   `validate:"required,notblank,lte=50" json:"name"`
+	Description string   `validate:"omitempty,lte=200" json:"description"`
+	PowerTypes  []string `validate:"omitempty,dive,required" json:"power_types"`
+}
+
+// RemoveRoleReq remove custom role request
+type RemoveRoleReq struct {
+	ID int `validate:"required" json:"id"`
 }
@@ -48,20 +48,20 @@ func (s *SearchDTO) Check() (errField []*validator.FormErrorField, err error) {
 }
 
 func ReplaceSearchContent(content string) (string, []string) {
-	// Define the regular expressions for key:value pairs and [tag]
-	keyValueRegex := regexp.MustCompile(`\w+:\S+`)
-	tagRegex := regexp.MustCompile(`\[\w+\]`)
+	// Define the regular expressions for key:value pairs and [tag], -[tag] or [tag1] OR [tag2]
+	keyValueRegex := regexp.MustCompile(`[\w-]+:\S+`)
+	tagRegex := regexp.MustCompile(`-?\[[^\[\]\s]+\](\s+OR\s+\[[^\[\]\s]+\])*`)
 	// Define the pattern for characters to replace
 	replaceCharsPattern := regexp.MustCompile(`[+#.<>\-_()*]`)
 
-	// Extract key:value pairs
-	keyValues := keyValueRegex.FindAllString(content, -1)
-	// Extract [tag]
+	// Extract [tag] first, the tag like [category:backend/go] looks like a key:value pair too
 	tags := tagRegex.FindAllString(content, -1)
+	contentWithoutPatterns := tagRegex.ReplaceAllString(content, "")
+	// Extract key:value pairs
+	keyValues := keyValueRegex.FindAllString(contentWithoutPatterns, -1)
 
-	// Replace key:value pairs and [tag] with empty string
-	contentWithoutPatterns := keyValueRegex.ReplaceAllString(content, "")
-	contentWithoutPatterns = tagRegex.ReplaceAllString(contentWithoutPatterns, "")
+	// Replace key:value pairs with empty string
+	contentWithoutPatterns = keyValueRegex.ReplaceAllString(contentWithoutPatterns, "")
 
 	// Replace characters with pattern [+#.<>_()*] with space
 	replacedContent := replaceCharsPattern.ReplaceAllString(contentWithoutPatterns, " ")
@@ -86,10 +86,31 @@ type SearchCondition struct {
 	Accepted bool
 	// only show this question's answer
 	QuestionID string
-	// search query tags
+	// search query tags, the question must have one of the tags in each group
 	Tags [][]string
+	// exclude the questions with any of the tags
+	ExcludedTags []string
+	// search query hierarchical tag ids, the category with its whole subtree
+	HierarchicalTagIDs []string
 	// search query keywords
 	Words []string
+	// the keywords are joined by OR, the content only needs to match one of them
+	MatchAnyWord bool
+	// the keywords only match the title
+	InTitle bool
+	// only show closed question
+	Closed bool
+	// only show the question has accepted answer
+	HasAccepted bool
+	// created time range in unix seconds, zero means no limit, the end is exclusive
+	CreatedFrom int64
+	CreatedTo   int64
+	// the user commented on the content
+	CommentUserID string
+	// the user answered the question
+	AnswerUserID string
+	// the query refers to a category or user that does not exist, nothing can match it
+	NoMatch bool
 }
 
 // SearchAll check if search all
@@ -110,16 +131,25 @@ func (s *SearchCondition) SearchAnswer() bool {
 // Convert2PluginSearchCond convert to plugin search condition
 func (s *SearchCondition) Convert2PluginSearchCond(page, pageSize int, order string) *plugin.SearchBasicCond {
 	basic := &plugin.SearchBasicCond{
-		Page:         page,
-		PageSize:     pageSize,
-		Words:        s.Words,
-		TagIDs:       s.Tags,
-		UserID:       s.UserID,
-		Order:        plugin.SearchOrderCond(order),
-		QuestionID:   s.QuestionID,
-		VoteAmount:   s.VoteAmount,
-		ViewAmount:   s.Views,
-		AnswerAmount: s.AnswerAmount,
+		Page:               page,
+		PageSize:           pageSize,
+		Words:              s.Words,
+		MatchAnyWord:       s.MatchAnyWord,
+		TitleOnly:          s.InTitle,
+		TagIDs:             s.Tags,
+		ExcludedTagIDs:     s.ExcludedTags,
+		HierarchicalTagIDs: s.HierarchicalTagIDs,
+		UserID:             s.UserID,
+		Order:              plugin.SearchOrderCond(order),
+		QuestionID:         s.QuestionID,
+		VoteAmount:         s.VoteAmount,
+		ViewAmount:         s.Views,
+		AnswerAmount:       s.AnswerAmount,
+		CreatedFrom:        s.CreatedFrom,
+		CreatedTo:          s.CreatedTo,
+		Closed:             s.Closed,
+		CommentUserID:      s.CommentUserID,
+		AnswerUserID:       s.AnswerUserID,
 	}
 	if s.Accepted {
 		basic.AnswerAccepted = plugin.AcceptedCondTrue
@@ -128,6 +158,8 @@ func (s *SearchCondition) Convert2PluginSearchCond(page, pageSize int, order str
 	}
 	if s.NotAccepted {
 		basic.QuestionAccepted = plugin.AcceptedCondFalse
+	} else if s.HasAccepted {
+		basic.QuestionAccepted = plugin.AcceptedCondTrue
 	} else {
 		basic.QuestionAccepted = plugin.AcceptedCondAll
 	}
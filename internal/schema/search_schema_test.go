@@ -38,4 +38,16 @@ func TestReplaceSearchContent(t *testing.T) {
 	ret = strings.Join(append(patterns, replacedContent), " ")
 
 	assert.Equal(t, "user:aaa-sss score:3 [tag1] [tag2] ssssfdfdf as fsadf", ret)
+
+	content = "commented-by:aaa -[tag1] [tag2] OR [tag3] created:2024-01..2024-06 c++"
+	replacedContent, patterns = ReplaceSearchContent(content)
+	ret = strings.Join(append(patterns, replacedContent), " ")
+
+	assert.Equal(t, "commented-by:aaa created:2024-01..2024-06 -[tag1] [tag2] OR [tag3] c", ret)
+
+	content = "[category:backend/go] category:frontend goroutine"
+	replacedContent, patterns = ReplaceSearchContent(content)
+	ret = strings.Join(append(patterns, replacedContent), " ")
+
+	assert.Equal(t, "category:frontend [category:backend/go] goroutine", ret)
 }
@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// GetSearchSyncStatusResp the sync status of an enabled search plugin
+type GetSearchSyncStatusResp struct {
+	PluginSlugName string `json:"plugin_slug_name"`
+	// Cursor the id of the last change synced to the plugin
+	Cursor int64 `json:"cursor"`
+	// LatestCursor the id of the latest change
+	LatestCursor int64 `json:"latest_cursor"`
+	// PendingCount the number of the changes not synced yet
+	PendingCount int64 `json:"pending_count"`
+	// Lag seconds since the oldest change not synced yet
+	Lag int64 `json:"lag"`
+	// NeedReindex the changes the plugin missed are pruned, it is reindexed once enabled
+	NeedReindex bool  `json:"need_reindex"`
+	Reindexing  bool  `json:"reindexing"`
+	SyncedAt    int64 `json:"synced_at"`
+	ReindexedAt int64 `json:"reindexed_at"`
+}
+
+// ReindexSearchReq reindex all the contents to the search plugin
+type ReindexSearchReq struct {
+	PluginSlugName string `validate:"required" json:"plugin_slug_name"`
+}
@@ -0,0 +1,100 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"encoding/json"
+
+	"github.com/apache/answer/internal/entity"
+)
+
+// SiteSettingsVersion the version of the settings file, only the file of the same version can be imported
+const SiteSettingsVersion = 1
+
+const (
+	SiteSettingsSectionSiteInfo      = "site_info"
+	SiteSettingsSectionPrivileges    = "privileges"
+	SiteSettingsSectionPluginStatus  = "plugin_status"
+	SiteSettingsSectionPluginConfigs = "plugin_configs"
+)
+
+// SiteSettings the settings of the site, they are exported to a file to be imported to the other site.
+// The plugin config values pinned by the environment variables are not included.
+type SiteSettings struct {
+	Version    int   `json:"version"`
+	ExportedAt int64 `json:"exported_at"`
+	// SiteInfo the content of the site info sections, the key is the site type
+	SiteInfo   map[string]json.RawMessage `json:"site_info"`
+	Privileges *UpdatePrivilegesConfigReq `json:"privileges,omitempty"`
+	// PluginStatus whether the plugins are enabled, the key is the plugin slug name
+	PluginStatus map[string]bool `json:"plugin_status"`
+	// PluginConfigs the config fields of the plugins, the key is the plugin slug name
+	PluginConfigs map[string]map[string]any `json:"plugin_configs"`
+}
+
+// ExportSiteSettingsResp exported settings file
+type ExportSiteSettingsResp struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// ImportSiteSettingsReq request for importing a settings file.
+// The sections absent from the file are kept as they are.
+type ImportSiteSettingsReq struct {
+	Content string `validate:"required" json:"content"`
+	// DryRun only checks the file without applying it
+	DryRun bool   `json:"dry_run"`
+	UserID string `json:"-"`
+}
+
+// SiteSettingsImportProblem an invalid value of the imported settings file
+type SiteSettingsImportProblem struct {
+	Section string `json:"section"`
+	// Key the site type or the plugin slug name
+	Key    string `json:"key,omitempty"`
+	Field  string `json:"field,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// ImportSiteSettingsResp result of the import
+type ImportSiteSettingsResp struct {
+	DryRun        bool     `json:"dry_run"`
+	SiteInfo      []string `json:"site_info"`
+	Privileges    bool     `json:"privileges"`
+	PluginStatus  []string `json:"plugin_status"`
+	PluginConfigs []string `json:"plugin_configs"`
+	// SkippedPlugins the plugins in the file which are not installed
+	SkippedPlugins []string                     `json:"skipped_plugins,omitempty"`
+	Problems       []*SiteSettingsImportProblem `json:"problems,omitempty"`
+}
+
+// SiteSettingsRows the rows changed by importing the site settings, they are saved in one transaction
+type SiteSettingsRows struct {
+	SiteInfos []*entity.SiteInfo
+	// Configs the values of the config table, the key is the config key
+	Configs map[string]string
+	// PluginConfigs the values of the plugin config table, the key is the plugin slug name
+	PluginConfigs map[string]string
+	// WriteTags whether the recommend and reserved tags are replaced by the following ones
+	WriteTags     bool
+	RecommendTags []string
+	ReservedTags  []string
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/plugin"
 	"github.com/segmentfault/pacman/errors"
 )
 
@@ -181,6 +182,150 @@ type SiteThemeReq struct {
 	ColorScheme string                 `validate:"omitempty,gt=0,lte=100" json:"color_scheme"`
 }
 
+const (
+	RateLimitGroupQuestion = "question"
+	RateLimitGroupAnswer   = "answer"
+	RateLimitGroupComment  = "comment"
+	RateLimitGroupEdit     = "edit"
+	RateLimitGroupVote     = "vote"
+	RateLimitGroupReport   = "report"
+	RateLimitGroupSearch   = "search"
+	RateLimitGroupUpload   = "upload"
+)
+
+// SiteRateLimitReq site rate limit request
+type SiteRateLimitReq struct {
+	Enabled bool `json:"enabled"`
+	// the users whose rank is lower than this are limited by the new user quota
+	NewUserRank int                  `validate:"gte=0" json:"new_user_rank"`
+	Rules       []*SiteRateLimitRule `validate:"omitempty,dive" json:"rules"`
+}
+
+// SiteRateLimitRule the quotas of a route group in the sliding window, 0 means no limit
+type SiteRateLimitRule struct {
+	Group string `validate:"required,oneof=question answer comment edit vote report search upload" json:"group"`
+	// window size in seconds
+	Window         int `validate:"required,gte=1,lte=86400" json:"window"`
+	AnonymousLimit int `validate:"gte=0" json:"anonymous_limit"`
+	NewUserLimit   int `validate:"gte=0" json:"new_user_limit"`
+	UserLimit      int `validate:"gte=0" json:"user_limit"`
+	// count the requests by ip even if the user is logged in, so the users sharing the same ip share the quota
+	PerIP bool `json:"per_ip"`
+}
+
+// DefaultSiteRateLimit the rate limit used before the admin changes it
+func DefaultSiteRateLimit() *SiteRateLimitResp {
+	return &SiteRateLimitResp{
+		Enabled:     true,
+		NewUserRank: 10,
+		Rules: []*SiteRateLimitRule{
+			{Group: RateLimitGroupQuestion, Window: 3600, NewUserLimit: 3, UserLimit: 20},
+			{Group: RateLimitGroupAnswer, Window: 3600, NewUserLimit: 10, UserLimit: 60},
+			{Group: RateLimitGroupComment, Window: 600, NewUserLimit: 10, UserLimit: 60},
+			{Group: RateLimitGroupEdit, Window: 600, NewUserLimit: 10, UserLimit: 60},
+			{Group: RateLimitGroupVote, Window: 60, NewUserLimit: 10, UserLimit: 30},
+			{Group: RateLimitGroupReport, Window: 3600, NewUserLimit: 5, UserLimit: 30},
+			{Group: RateLimitGroupSearch, Window: 60, AnonymousLimit: 30, NewUserLimit: 60, UserLimit: 120},
+			{Group: RateLimitGroupUpload, Window: 600, NewUserLimit: 10, UserLimit: 60},
+		},
+	}
+}
+
+// GetRule get the rule of the route group, nil if the group is not limited
+func (s *SiteRateLimitResp) GetRule(group string) *SiteRateLimitRule {
+	for _, rule := range s.Rules {
+		if rule.Group == group {
+			return rule
+		}
+	}
+	return nil
+}
+
+const (
+	// ReviewActionHold hold the post until a moderator reviews it
+	ReviewActionHold = "hold"
+	// ReviewActionShadowHide hold the post like ReviewActionHold, but the author is not told it is under review
+	ReviewActionShadowHide = "shadow_hide"
+	// ReviewActionDelete delete the post directly
+	ReviewActionDelete = "delete"
+
+	// LocalReviewerSlugName the slug name of the built-in reviewer in the review pipeline
+	LocalReviewerSlugName = "local_reviewer"
+)
+
+// SiteReviewReq site review request, it is the pipeline of the reviewers which check the new posts
+type SiteReviewReq struct {
+	// Reviewers the reviewers run in the order, the enabled reviewer plugins which are not listed run after them
+	Reviewers []*SiteReviewer `validate:"omitempty,dive" json:"reviewers"`
+	// ReviewThreshold the post is held if the total weight of the reviewers which do not approve it reaches it
+	ReviewThreshold int `validate:"required,gte=1" json:"review_threshold"`
+	// DeleteThreshold the post is deleted if the total weight of the reviewers which ask to delete it reaches it
+	DeleteThreshold int `validate:"required,gte=1" json:"delete_threshold"`
+	// NeedReviewAction the action when the verdict of the pipeline is need review
+	NeedReviewAction string `validate:"required,oneof=hold shadow_hide delete" json:"need_review_action"`
+	// DeleteAction the action when the verdict of the pipeline is delete
+	DeleteAction string `validate:"required,oneof=hold shadow_hide delete" json:"delete_action"`
+	// SuspendAfterRejections suspend the author once the posts are rejected so many times in the window, 0 means never
+	SuspendAfterRejections int `validate:"gte=0" json:"suspend_after_rejections"`
+	// RejectionWindowDays only the rejections in the last days are counted
+	RejectionWindowDays int `validate:"gte=1,lte=365" json:"rejection_window_days"`
+	// SuspendDays how long the author is suspended, 0 means forever
+	SuspendDays   int                `validate:"gte=0" json:"suspend_days"`
+	LocalReviewer *SiteLocalReviewer `validate:"required" json:"local_reviewer"`
+}
+
+// SiteReviewer a reviewer in the review pipeline
+type SiteReviewer struct {
+	// SlugName the slug name of the reviewer plugin, or local_reviewer for the built-in reviewer
+	SlugName string `validate:"required,lte=100" json:"slug_name"`
+	Enabled  bool   `json:"enabled"`
+	Weight   int    `validate:"gte=1,lte=100" json:"weight"`
+	// StopOnApprove the later reviewers are skipped if this reviewer approves the post
+	StopOnApprove bool `json:"stop_on_approve"`
+	// StopOnReject the later reviewers are skipped if this reviewer does not approve the post
+	StopOnReject bool `json:"stop_on_reject"`
+}
+
+// SiteLocalReviewer the built-in reviewer, it checks the posts with the word lists, the links and the age of the author
+type SiteLocalReviewer struct {
+	Enabled bool `json:"enabled"`
+	// Keywords the post containing any of them is not approved, case insensitive
+	Keywords []string `validate:"omitempty,dive,gt=0,lte=100" json:"keywords"`
+	// Patterns the post matching any of the regular expressions is not approved
+	Patterns []string `validate:"omitempty,dive,gt=0,lte=500" json:"patterns"`
+	// MatchStatus the verdict when a keyword or pattern matches, need_review or delete_directly
+	MatchStatus string `validate:"required,oneof=need_review delete_directly" json:"match_status"`
+	// MaxLinks the max links in a post, 0 means no limit
+	MaxLinks int `validate:"gte=0" json:"max_links"`
+	// MaxLinkDensity the max links per 100 words, 0 means no limit
+	MaxLinkDensity int `validate:"gte=0" json:"max_link_density"`
+	// NewAccountDays the authors registered in the last days are new accounts
+	NewAccountDays int `validate:"gte=0" json:"new_account_days"`
+	// NewAccountRank the authors whose reputation is lower than it are new accounts
+	NewAccountRank int `validate:"gte=0" json:"new_account_rank"`
+	// NewAccountMaxLinks the max links in a post of the new accounts
+	NewAccountMaxLinks int `validate:"gte=0" json:"new_account_max_links"`
+}
+
+// DefaultSiteReview the review pipeline used before the admin changes it,
+// the first reviewer which does not approve the post decides like before
+func DefaultSiteReview() *SiteReviewResp {
+	return &SiteReviewResp{
+		ReviewThreshold:     1,
+		DeleteThreshold:     1,
+		NeedReviewAction:    ReviewActionHold,
+		DeleteAction:        ReviewActionDelete,
+		RejectionWindowDays: 30,
+		SuspendDays:         7,
+		LocalReviewer: &SiteLocalReviewer{
+			MatchStatus:        string(plugin.ReviewStatusNeedReview),
+			NewAccountDays:     3,
+			NewAccountRank:     10,
+			NewAccountMaxLinks: 2,
+		},
+	}
+}
+
 type SiteSeoReq struct {
 	Permalink int    `validate:"required,lte=4,gte=0" form:"permalink" json:"permalink"`
 	Robots    string `validate:"required" form:"robots" json:"robots"`
@@ -209,6 +354,12 @@ type SiteCustomCssHTMLResp SiteCustomCssHTMLReq
 // SiteUsersResp site users response
 type SiteUsersResp SiteUsersReq
 
+// SiteRateLimitResp site rate limit response
+type SiteRateLimitResp SiteRateLimitReq
+
+// SiteReviewResp site review response
+type SiteReviewResp SiteReviewReq
+
 // SiteThemeResp site theme response
 type SiteThemeResp struct {
 	ThemeOptions []*ThemeOption         `json:"theme_options"`
@@ -287,6 +438,8 @@ type UpdateSMTPConfigReq struct {
 	SMTPUsername       string `validate:"omitempty,gt=0,lte=256" json:"smtp_username"`
 	SMTPPassword       string `validate:"omitempty,gt=0,lte=256" json:"smtp_password"`
 	SMTPAuthentication bool   `validate:"omitempty" json:"smtp_authentication"`
+	ReplyToAddress     string `validate:"omitempty,email,lte=256" json:"reply_to_address"`
+	ReplyInboundSecret string `validate:"omitempty,gte=16,lte=256" json:"reply_inbound_secret"`
 	TestEmailRecipient string `validate:"omitempty,email" json:"test_email_recipient"`
 }
 
@@ -311,6 +464,8 @@ type GetSMTPConfigResp struct {
 	SMTPUsername       string `json:"smtp_username"`
 	SMTPPassword       string `json:"smtp_password"`
 	SMTPAuthentication bool   `json:"smtp_authentication"`
+	ReplyToAddress     string `json:"reply_to_address"`
+	ReplyInboundSecret string `json:"reply_inbound_secret"`
 }
 
 // GetManifestJsonResp get manifest json response
@@ -206,6 +206,8 @@ type UpdateTagReq struct {
 	// user id
 	UserID       string `json:"-"`
 	NoNeedReview bool   `json:"-"`
+	// whether the tag is rolled back to an old revision
+	Rollback bool `json:"-"`
 }
 
 func (r *UpdateTagReq) Check() (errFields []*validator.FormErrorField, err error) {
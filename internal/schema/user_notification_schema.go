@@ -56,6 +56,7 @@ type NotificationConfig struct {
 	Inbox                          NotificationChannelConfig `json:"inbox"`
 	AllNewQuestion                 NotificationChannelConfig `json:"all_new_question"`
 	AllNewQuestionForFollowingTags NotificationChannelConfig `json:"all_new_question_for_following_tags"`
+	Digest                         NotificationChannelConfig `json:"digest"`
 }
 
 func NewNotificationConfig(configs []*entity.UserNotificationConfig) NotificationConfig {
@@ -68,6 +69,8 @@ func NewNotificationConfig(configs []*entity.UserNotificationConfig) Notificatio
 			nc.AllNewQuestion = NewNotificationChannelConfigFormJson(item.Channels)
 		case string(constant.AllNewQuestionForFollowingTagsSource):
 			nc.AllNewQuestionForFollowingTags = NewNotificationChannelConfigFormJson(item.Channels)
+		case string(constant.DigestSource):
+			nc.Digest = NewNotificationChannelConfigFormJson(item.Channels)
 		}
 	}
 	return nc
@@ -86,15 +89,33 @@ func (n *NotificationConfig) Format() {
 		n.AllNewQuestionForFollowingTags.Key = constant.EmailChannel
 		n.AllNewQuestionForFollowingTags.Enable = false
 	}
+	if n.Digest.Key == "" {
+		n.Digest.Key = constant.EmailChannel
+		n.Digest.Enable = false
+	}
+}
+
+// DigestSchedule when to send the digest email
+type DigestSchedule struct {
+	// daily or weekly
+	Frequency string `validate:"omitempty,oneof=daily weekly" json:"frequency"`
+	// the day of the week to send the weekly digest, 0 is Sunday
+	Weekday int `validate:"gte=0,lte=6" json:"weekday"`
+	// the hour of the day to send the digest
+	Hour int `validate:"gte=0,lte=23" json:"hour"`
+	// the IANA time zone name, the site time zone is used if empty
+	Timezone string `validate:"omitempty,lte=64" json:"timezone"`
 }
 
 // UpdateUserNotificationConfigReq update user notification config request
 type UpdateUserNotificationConfigReq struct {
 	NotificationConfig
-	UserID string `json:"-"`
+	DigestSchedule *DigestSchedule `json:"digest_schedule"`
+	UserID         string          `json:"-"`
 }
 
 // GetUserNotificationConfigResp get user notification config response
 type GetUserNotificationConfigResp struct {
 	NotificationConfig
+	DigestSchedule *DigestSchedule `json:"digest_schedule"`
 }
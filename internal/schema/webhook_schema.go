@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// WebhookItem webhook, the secret is only returned once the webhook is added
+type WebhookItem struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	CreatedAt  int64    `json:"created_at"`
+}
+
+// AddWebhookReq add webhook request
+type AddWebhookReq struct {
+	Name string `validate:"required,notblank,max=128" json:"name"`
+	URL  string `validate:"required,url,max=1024" json:"url"`
+	// Secret key of the HMAC signature, generated when empty
+	Secret     string   `validate:"omitempty,max=128" json:"secret"`
+	EventTypes []string `validate:"required,gt=0,dive,required" json:"event_types"`
+	Active     bool     `json:"active"`
+}
+
+// AddWebhookResp add webhook response, the secret can not be read again later
+type AddWebhookResp struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+}
+
+// UpdateWebhookReq update webhook request
+type UpdateWebhookReq struct {
+	ID   string `validate:"required" json:"id"`
+	Name string `validate:"required,notblank,max=128" json:"name"`
+	URL  string `validate:"required,url,max=1024" json:"url"`
+	// Secret the new secret, the current one is kept when empty
+	Secret     string   `validate:"omitempty,max=128" json:"secret"`
+	EventTypes []string `validate:"required,gt=0,dive,required" json:"event_types"`
+	Active     bool     `json:"active"`
+}
+
+// DeleteWebhookReq delete webhook request
+type DeleteWebhookReq struct {
+	ID string `validate:"required" json:"id"`
+}
+
+// TestWebhookReq send a ping event to the webhook
+type TestWebhookReq struct {
+	ID string `validate:"required" json:"id"`
+}
+
+// GetWebhookDeliveryPageReq get webhook delivery page request
+type GetWebhookDeliveryPageReq struct {
+	Page      int    `validate:"omitempty,min=1" form:"page"`
+	PageSize  int    `validate:"omitempty,min=1" form:"page_size"`
+	WebhookID string `validate:"required" form:"webhook_id"`
+}
+
+// WebhookDeliveryItem an attempt to deliver an event to the webhook
+type WebhookDeliveryItem struct {
+	ID             int64  `json:"id"`
+	DeliveryID     string `json:"delivery_id"`
+	EventType      string `json:"event_type"`
+	Attempt        int    `json:"attempt"`
+	RequestBody    string `json:"request_body"`
+	ResponseStatus int    `json:"response_status"`
+	ResponseBody   string `json:"response_body"`
+	Error          string `json:"error"`
+	Success        bool   `json:"success"`
+	// Duration milliseconds
+	Duration  int64 `json:"duration"`
+	CreatedAt int64 `json:"created_at"`
+}
+
+// WebhookDeliveryMsg a queued delivery of an event to a webhook, retried until the webhook accepts it
+type WebhookDeliveryMsg struct {
+	WebhookID  string
+	DeliveryID string
+	EventType  string
+	ObjectID   string
+	Payload    string
+}
+
+// WebhookPingEvent the event type of the test delivery
+const WebhookPingEvent = "ping"
+
+// WebhookPayload the JSON body posted to the webhook
+type WebhookPayload struct {
+	DeliveryID string `json:"delivery_id"`
+	EventType  string `json:"event_type"`
+	Timestamp  int64  `json:"timestamp"`
+	// UserID who triggered the event
+	UserID         string                `json:"user_id,omitempty"`
+	ObjectID       string                `json:"object_id,omitempty"`
+	QuestionID     string                `json:"question_id,omitempty"`
+	QuestionUserID string                `json:"question_user_id,omitempty"`
+	AnswerID       string                `json:"answer_id,omitempty"`
+	AnswerUserID   string                `json:"answer_user_id,omitempty"`
+	CommentID      string                `json:"comment_id,omitempty"`
+	CommentUserID  string                `json:"comment_user_id,omitempty"`
+	Object         *WebhookPayloadObject `json:"object,omitempty"`
+	ExtraInfo      map[string]string     `json:"extra_info,omitempty"`
+}
+
+// WebhookPayloadObject the object the event happened on
+type WebhookPayloadObject struct {
+	ObjectType    string `json:"object_type"`
+	Title         string `json:"title"`
+	URL           string `json:"url"`
+	CreatorUserID string `json:"creator_user_id"`
+	QuestionID    string `json:"question_id,omitempty"`
+	AnswerID      string `json:"answer_id,omitempty"`
+	CommentID     string `json:"comment_id,omitempty"`
+}
@@ -23,47 +23,17 @@ import (
 	"context"
 
 	"github.com/apache/answer/internal/schema"
-	"github.com/segmentfault/pacman/log"
+	"github.com/apache/answer/internal/service/queue_message"
+	"xorm.io/xorm"
 )
 
 type ActivityQueueService interface {
-	Send(ctx context.Context, msg *schema.ActivityMsg)
+	Send(ctx context.Context, msg *schema.ActivityMsg) (err error)
+	SendWithSession(ctx context.Context, session *xorm.Session, msg *schema.ActivityMsg) (err error)
 	RegisterHandler(handler func(ctx context.Context, msg *schema.ActivityMsg) error)
 }
 
-type activityQueueService struct {
-	Queue   chan *schema.ActivityMsg
-	Handler func(ctx context.Context, msg *schema.ActivityMsg) error
-}
-
-func (ns *activityQueueService) Send(ctx context.Context, msg *schema.ActivityMsg) {
-	ns.Queue <- msg
-}
-
-func (ns *activityQueueService) RegisterHandler(
-	handler func(ctx context.Context, msg *schema.ActivityMsg) error) {
-	ns.Handler = handler
-}
-
-func (ns *activityQueueService) working() {
-	go func() {
-		for msg := range ns.Queue {
-			log.Debugf("received activity %+v", msg)
-			if ns.Handler == nil {
-				log.Warnf("no handler for activity")
-				continue
-			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
-				log.Error(err)
-			}
-		}
-	}()
-}
-
-// NewActivityQueueService create a new activity queue service
-func NewActivityQueueService() ActivityQueueService {
-	ns := &activityQueueService{}
-	ns.Queue = make(chan *schema.ActivityMsg, 128)
-	ns.working()
-	return ns
+// NewActivityQueueService create a new activity queue service, messages are kept in the database until they are handled
+func NewActivityQueueService(queueMessageRepo queue_message.QueueMessageRepo) ActivityQueueService {
+	return queue_message.NewQueue[schema.ActivityMsg]("activity", queueMessageRepo)
 }
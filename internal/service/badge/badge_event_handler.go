@@ -21,6 +21,8 @@ package badge
 
 import (
 	"context"
+	"sync"
+
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
@@ -34,7 +36,9 @@ type BadgeEventService struct {
 	badgeAwardRepo    BadgeAwardRepo
 	badgeRepo         BadgeRepo
 	eventRuleRepo     EventRuleRepo
+	badgeRuleRepo     BadgeRuleRepo
 	badgeAwardService *BadgeAwardService
+	backfilling       sync.Map
 }
 
 type EventRuleHandler func(ctx context.Context, event *schema.EventMsg) (awards []*entity.BadgeAward, err error)
@@ -43,11 +47,18 @@ type EventRuleRepo interface {
 	HandleEventWithRule(ctx context.Context, msg *schema.EventMsg) (awards []*entity.BadgeAward)
 }
 
+// BadgeRuleRepo counts the metrics of the badge rules defined by the admin
+type BadgeRuleRepo interface {
+	GetRuleMetric(ctx context.Context, rule *schema.BadgeRule, userID, objectID string) (value int64, err error)
+	GetRuleCandidates(ctx context.Context, rule *schema.BadgeRule) (awards []*entity.BadgeAward, err error)
+}
+
 func NewBadgeEventService(
 	data *data.Data,
 	eventQueueService event_queue.EventQueueService,
 	badgeRepo BadgeRepo,
 	eventRuleRepo EventRuleRepo,
+	badgeRuleRepo BadgeRuleRepo,
 	badgeAwardService *BadgeAwardService,
 ) *BadgeEventService {
 	n := &BadgeEventService{
@@ -55,14 +66,19 @@ func NewBadgeEventService(
 		eventQueueService: eventQueueService,
 		badgeRepo:         badgeRepo,
 		eventRuleRepo:     eventRuleRepo,
+		badgeRuleRepo:     badgeRuleRepo,
 		badgeAwardService: badgeAwardService,
 	}
-	eventQueueService.RegisterHandler(n.Handler)
+	eventQueueService.Subscribe(&event_queue.Subscriber{
+		Name:    "badge",
+		Handler: n.Handler,
+	})
 	return n
 }
 
 func (ns *BadgeEventService) Handler(ctx context.Context, msg *schema.EventMsg) error {
 	awards := ns.eventRuleRepo.HandleEventWithRule(ctx, msg)
+	awards = append(awards, ns.handleEventWithBadgeRules(ctx, msg)...)
 	if len(awards) == 0 {
 		return nil
 	}
@@ -21,20 +21,83 @@ package badge
 
 import (
 	"context"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
 )
 
 type BadgeGroupRepo interface {
 	ListGroups(ctx context.Context) (groups []*entity.BadgeGroup, err error)
 	AddGroup(ctx context.Context, group *entity.BadgeGroup) (err error)
+	GetGroup(ctx context.Context, id string) (group *entity.BadgeGroup, exist bool, err error)
+	UpdateGroup(ctx context.Context, group *entity.BadgeGroup) (err error)
+	DeleteGroup(ctx context.Context, id string) (err error)
 }
 
 type BadgeGroupService struct {
 	badgeGroupRepo BadgeGroupRepo
+	badgeRepo      BadgeRepo
 }
 
-func NewBadgeGroupService(badgeGroupRepo BadgeGroupRepo) *BadgeGroupService {
+func NewBadgeGroupService(badgeGroupRepo BadgeGroupRepo, badgeRepo BadgeRepo) *BadgeGroupService {
 	return &BadgeGroupService{
 		badgeGroupRepo: badgeGroupRepo,
+		badgeRepo:      badgeRepo,
 	}
 }
+
+// ListBadgeGroups list all badge groups
+func (b *BadgeGroupService) ListBadgeGroups(ctx context.Context) (resp []*schema.BadgeGroupItem, err error) {
+	groups, err := b.badgeGroupRepo.ListGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.BadgeGroupItem, 0, len(groups))
+	for _, group := range groups {
+		resp = append(resp, &schema.BadgeGroupItem{
+			ID:   group.ID,
+			Name: translator.Tr(handler.GetLangByCtx(ctx), group.Name),
+		})
+	}
+	return resp, nil
+}
+
+// AddBadgeGroup add badge group
+func (b *BadgeGroupService) AddBadgeGroup(ctx context.Context, req *schema.AddBadgeGroupReq) (err error) {
+	return b.badgeGroupRepo.AddGroup(ctx, &entity.BadgeGroup{Name: req.Name})
+}
+
+// UpdateBadgeGroup update badge group
+func (b *BadgeGroupService) UpdateBadgeGroup(ctx context.Context, req *schema.UpdateBadgeGroupReq) (err error) {
+	_, exist, err := b.badgeGroupRepo.GetGroup(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.BadgeGroupNotFound)
+	}
+	return b.badgeGroupRepo.UpdateGroup(ctx, &entity.BadgeGroup{ID: req.ID, Name: req.Name})
+}
+
+// DeleteBadgeGroup delete badge group which has no badges
+func (b *BadgeGroupService) DeleteBadgeGroup(ctx context.Context, req *schema.DeleteBadgeGroupReq) (err error) {
+	_, exist, err := b.badgeGroupRepo.GetGroup(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.BadgeGroupNotFound)
+	}
+	count, err := b.badgeRepo.CountByGroupID(ctx, converter.StringToInt64(req.ID))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.BadRequest(reason.BadgeGroupNotEmpty)
+	}
+	return b.badgeGroupRepo.DeleteGroup(ctx, req.ID)
+}
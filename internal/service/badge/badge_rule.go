@@ -0,0 +1,157 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package badge
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// handleEventWithBadgeRules evaluate the rules of the badges defined by the admin which are triggered by the event
+func (ns *BadgeEventService) handleEventWithBadgeRules(ctx context.Context, msg *schema.EventMsg) (
+	awards []*entity.BadgeAward) {
+	badges, err := ns.badgeRepo.ListActivatedByHandler(ctx, entity.BadgeRuleHandler)
+	if err != nil {
+		log.Errorf("list rule badges failed: %v", err)
+		return nil
+	}
+	for _, badge := range badges {
+		rule, err := parseBadgeRule(badge)
+		if err != nil {
+			log.Errorf("badge %s has an invalid rule: %v", badge.ID, err)
+			continue
+		}
+		if !triggeredBy(rule, msg.EventType) {
+			continue
+		}
+		objectID := msg.GetObjectID()
+		userID := ruleRecipient(rule, msg, objectID)
+		if len(userID) == 0 {
+			continue
+		}
+		value, err := ns.badgeRuleRepo.GetRuleMetric(ctx, rule, userID, objectID)
+		if err != nil {
+			log.Errorf("get metric of badge %s failed: %v", badge.ID, err)
+			continue
+		}
+		if value < rule.Threshold {
+			continue
+		}
+		awardKey := entity.BadgeEmptyAwardKey
+		if rule.IsPerPost() {
+			awardKey = objectID
+		}
+		awards = append(awards, &entity.BadgeAward{UserID: userID, BadgeID: badge.ID, AwardKey: awardKey})
+	}
+	return awards
+}
+
+// Backfill award the badge to everyone who already meets its rule, it runs in the background
+func (ns *BadgeEventService) Backfill(ctx context.Context, badge *entity.Badge) (err error) {
+	rule, err := parseBadgeRule(badge)
+	if err != nil {
+		return errors.BadRequest(reason.BadgeRuleRequired)
+	}
+	if _, running := ns.backfilling.LoadOrStore(badge.ID, true); running {
+		return errors.BadRequest(reason.BadgeBackfillRunning)
+	}
+	go func() {
+		defer ns.backfilling.Delete(badge.ID)
+		ctx := context.Background()
+		candidates, err := ns.badgeRuleRepo.GetRuleCandidates(ctx, rule)
+		if err != nil {
+			log.Errorf("get candidates of badge %s failed: %v", badge.ID, err)
+			return
+		}
+		awarded := 0
+		for _, candidate := range candidates {
+			if err := ns.badgeAwardService.Award(ctx, badge.ID, candidate.UserID, candidate.AwardKey); err != nil {
+				log.Debugf("error awarding badge %s: %v", badge.ID, err)
+				continue
+			}
+			awarded++
+		}
+		log.Infof("badge %s backfilled, %d candidates checked, %d handled", badge.ID, len(candidates), awarded)
+	}()
+	return nil
+}
+
+// parseBadgeRule get the rule of the badge defined by the admin
+func parseBadgeRule(badge *entity.Badge) (rule *schema.BadgeRule, err error) {
+	if badge.Handler != entity.BadgeRuleHandler {
+		return nil, errors.BadRequest(reason.BadgeRuleRequired)
+	}
+	rule = &schema.BadgeRule{}
+	if err = json.Unmarshal([]byte(badge.Param), rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// checkBadgeRule the event types must exist and only the post counts can be limited to a tag
+func checkBadgeRule(rule *schema.BadgeRule) error {
+	for _, eventType := range rule.EventTypes {
+		if !constant.IsEventType(eventType) {
+			return errors.BadRequest(reason.BadgeRuleInvalid)
+		}
+	}
+	if len(rule.TagSlugName) > 0 && !rule.TagScoped() {
+		return errors.BadRequest(reason.BadgeRuleInvalid)
+	}
+	return nil
+}
+
+func triggeredBy(rule *schema.BadgeRule, eventType constant.EventType) bool {
+	for _, t := range rule.EventTypes {
+		if t == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleRecipient the per post badges go to the author of the post, the others to the recipient of the rule
+func ruleRecipient(rule *schema.BadgeRule, msg *schema.EventMsg, objectID string) string {
+	if rule.IsPerPost() {
+		switch objectID {
+		case msg.AnswerID:
+			return msg.AnswerUserID
+		case msg.QuestionID:
+			return msg.QuestionUserID
+		}
+		return ""
+	}
+	switch rule.Recipient {
+	case schema.BadgeRuleRecipientQuestionAuthor:
+		return msg.QuestionUserID
+	case schema.BadgeRuleRecipientAnswerAuthor:
+		return msg.AnswerUserID
+	case schema.BadgeRuleRecipientCommentAuthor:
+		return msg.CommentUserID
+	}
+	return msg.UserID
+}
@@ -21,6 +21,7 @@ package badge
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/translator"
@@ -32,16 +33,21 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
+	"strconv"
 	"strings"
 )
 
 type BadgeRepo interface {
 	GetByID(ctx context.Context, id string) (badge *entity.Badge, exists bool, err error)
 	GetByIDs(ctx context.Context, ids []string) (badges []*entity.Badge, err error)
+	Add(ctx context.Context, badge *entity.Badge) (err error)
+	Update(ctx context.Context, badge *entity.Badge) (err error)
 
 	ListPaged(ctx context.Context, page int, pageSize int) (badges []*entity.Badge, total int64, err error)
 	ListActivated(ctx context.Context, page int, pageSize int) (badges []*entity.Badge, total int64, err error)
 	ListInactivated(ctx context.Context, page int, pageSize int) (badges []*entity.Badge, total int64, err error)
+	ListActivatedByHandler(ctx context.Context, handler string) (badges []*entity.Badge, err error)
+	CountByGroupID(ctx context.Context, groupID int64) (count int64, err error)
 
 	UpdateStatus(ctx context.Context, id string, status int8) (err error)
 	UpdateAwardCount(ctx context.Context, badgeID string, awardCount int) (err error)
@@ -327,3 +333,111 @@ func (b *BadgeService) UpdateStatus(ctx *gin.Context, req *schema.UpdateBadgeSta
 	}
 	return nil
 }
+
+// GetBadgeDetail get badge detail with its rule for the admin
+func (b *BadgeService) GetBadgeDetail(ctx context.Context, req *schema.GetBadgeReq) (
+	resp *schema.GetBadgeDetailResp, err error) {
+	badge, exists, err := b.badgeRepo.GetByID(ctx, uid.DeShortID(req.ID))
+	if err != nil {
+		return nil, err
+	}
+	if !exists || badge.Status == entity.BadgeStatusDeleted {
+		return nil, errors.BadRequest(reason.BadgeObjectNotFound)
+	}
+	resp = &schema.GetBadgeDetailResp{
+		ID:          uid.EnShortID(badge.ID),
+		Name:        badge.Name,
+		Description: badge.Description,
+		Icon:        badge.Icon,
+		Level:       badge.Level,
+		IsSingle:    badge.Single == entity.BadgeSingleAward,
+		GroupID:     strconv.FormatInt(badge.BadgeGroupID, 10),
+		AwardCount:  badge.AwardCount,
+		Status:      schema.BadgeStatusMap[badge.Status],
+		Handler:     badge.Handler,
+	}
+	if rule, err := parseBadgeRule(badge); err == nil {
+		resp.Rule = rule
+	}
+	return resp, nil
+}
+
+// AddBadge add a badge awarded by the rule
+func (b *BadgeService) AddBadge(ctx context.Context, req *schema.AddBadgeReq) (err error) {
+	badge := &entity.Badge{Status: entity.BadgeStatusActive}
+	if err = b.fillBadge(ctx, badge, req.Name, req.Description, req.Icon, req.Level, req.IsSingle, req.GroupID,
+		req.Rule); err != nil {
+		return err
+	}
+	return b.badgeRepo.Add(ctx, badge)
+}
+
+// UpdateBadge update badge, the awarded ones are kept when the rule changes
+func (b *BadgeService) UpdateBadge(ctx context.Context, req *schema.UpdateBadgeReq) (err error) {
+	badge, exists, err := b.badgeRepo.GetByID(ctx, uid.DeShortID(req.ID))
+	if err != nil {
+		return err
+	}
+	if !exists || badge.Status == entity.BadgeStatusDeleted {
+		return errors.BadRequest(reason.BadgeObjectNotFound)
+	}
+	if err = b.fillBadge(ctx, badge, req.Name, req.Description, req.Icon, req.Level, req.IsSingle, req.GroupID,
+		req.Rule); err != nil {
+		return err
+	}
+	return b.badgeRepo.Update(ctx, badge)
+}
+
+// DeleteBadge delete badge, the awards are hidden with it
+func (b *BadgeService) DeleteBadge(ctx context.Context, req *schema.DeleteBadgeReq) (err error) {
+	id := uid.DeShortID(req.ID)
+	_, exists, err := b.badgeRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.BadRequest(reason.BadgeObjectNotFound)
+	}
+	return b.badgeRepo.UpdateStatus(ctx, id, entity.BadgeStatusDeleted)
+}
+
+// BackfillBadge award the badge to everyone who already meets its rule
+func (b *BadgeService) BackfillBadge(ctx context.Context, req *schema.BackfillBadgeReq) (err error) {
+	badge, exists, err := b.badgeRepo.GetByID(ctx, uid.DeShortID(req.ID))
+	if err != nil {
+		return err
+	}
+	if !exists || badge.Status != entity.BadgeStatusActive {
+		return errors.BadRequest(reason.BadgeObjectNotFound)
+	}
+	return b.badgeEventService.Backfill(ctx, badge)
+}
+
+func (b *BadgeService) fillBadge(ctx context.Context, badge *entity.Badge, name, description, icon string,
+	level entity.BadgeLevel, isSingle bool, groupID string, rule *schema.BadgeRule) (err error) {
+	_, exist, err := b.badgeGroupRepo.GetGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.BadgeGroupNotFound)
+	}
+	if rule != nil {
+		if err = checkBadgeRule(rule); err != nil {
+			return err
+		}
+		param, _ := json.Marshal(rule)
+		badge.Handler = entity.BadgeRuleHandler
+		badge.Param = string(param)
+	}
+	badge.Name = name
+	badge.Description = description
+	badge.Icon = icon
+	badge.Level = level
+	badge.BadgeGroupID = converter.StringToInt64(groupID)
+	badge.Single = entity.BadgeMultiAward
+	if isSingle {
+		badge.Single = entity.BadgeSingleAward
+	}
+	return nil
+}
@@ -418,11 +418,15 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 		return insertData.ID, err
 	}
 	if canUpdate {
+		activityTypeKey := constant.ActAnswerEdited
+		if req.Rollback {
+			activityTypeKey = constant.ActAnswerRollback
+		}
 		as.activityQueueService.Send(ctx, &schema.ActivityMsg{
 			UserID:           req.UserID,
 			ObjectID:         insertData.ID,
 			OriginalObjectID: insertData.ID,
-			ActivityTypeKey:  constant.ActAnswerEdited,
+			ActivityTypeKey:  activityTypeKey,
 			RevisionID:       revisionID,
 		})
 		as.eventQueueService.Send(ctx, schema.NewEvent(constant.EventAnswerUpdate, req.UserID).TID(insertData.ID).
@@ -665,6 +669,11 @@ func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity
 		return nil, err
 	}
 	for _, item := range list {
+		// The author can not tell the shadow hidden answer from the others
+		if item.Status == entity.AnswerStatusPending && !req.CanRecover && item.UserID == req.UserID &&
+			as.reviewService.IsShadowHidden(ctx, item.ID) {
+			item.Status = entity.AnswerStatusAvailable
+		}
 		item.VoteStatus = as.voteRepo.GetVoteStatus(ctx, item.ID, req.UserID)
 		item.Collected = collectedMap[item.ID]
 		item.MemberActions = permission.GetAnswerPermission(ctx,
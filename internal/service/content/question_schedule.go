@@ -0,0 +1,137 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
+)
+
+// PublishScheduledQuestionsCron publish the scheduled questions whose publish time is up
+func (qs *QuestionService) PublishScheduledQuestionsCron(ctx context.Context) {
+	schedules, err := qs.questionScheduleService.GetDueSchedules(ctx)
+	if err != nil {
+		log.Errorf("get due question schedules failed: %v", err)
+		return
+	}
+	for _, schedule := range schedules {
+		if err := qs.publishScheduledQuestion(ctx, schedule); err != nil {
+			log.Errorf("publish scheduled question %s failed: %v", schedule.QuestionID, err)
+		}
+	}
+}
+
+func (qs *QuestionService) publishScheduledQuestion(ctx context.Context, schedule *entity.QuestionSchedule) (err error) {
+	question, exist, err := qs.questionRepo.GetQuestion(ctx, schedule.QuestionID)
+	if err != nil {
+		return err
+	}
+	// the question has been deleted or published by the admin before the time
+	if !exist || question.Status != entity.QuestionStatusScheduled {
+		return qs.questionScheduleService.FinishSchedule(ctx, schedule.ID, entity.QuestionScheduleStatusCancelled)
+	}
+
+	// the question is shown as a new one when it is published
+	now := time.Now()
+	question.Status = entity.QuestionStatusAvailable
+	question.CreatedAt = now
+	question.PostUpdateTime = now
+	question.ParsedText, err = qs.questioncommon.UpdateQuestionLink(ctx, question.ID, "", question.ParsedText, question.OriginalText)
+	if err != nil {
+		return err
+	}
+	tags, err := qs.tagCommon.GetObjectEntityTag(ctx, question.ID)
+	if err != nil {
+		log.Errorf("get question tags error %v", err)
+	}
+
+	// the question is announced only by the one who changes it from scheduled to available, so that neither
+	// the deletion in the meantime is overwritten nor another instance of the cron announces it again
+	published, err := qs.questionScheduleService.PublishQuestion(ctx, schedule.ID, question,
+		func(session *xorm.Session) error {
+			err := qs.activityQueueService.SendWithSession(ctx, session, &schema.ActivityMsg{
+				UserID:           schedule.UserID,
+				ObjectID:         question.ID,
+				OriginalObjectID: question.ID,
+				ActivityTypeKey:  constant.ActQuestionPublished,
+			})
+			if err != nil {
+				return err
+			}
+			err = qs.externalNotificationQueueService.SendWithSession(ctx, session,
+				schema.CreateNewQuestionNotificationMsg(question.ID, question.Title, question.UserID, tags))
+			if err != nil {
+				return err
+			}
+			return qs.eventQueueService.SendWithSession(ctx, session,
+				schema.NewEvent(constant.EventQuestionCreate, question.UserID).TID(question.ID).
+					QID(question.ID, question.UserID).AddExtra(constant.EventExtraScheduled, "true"))
+		})
+	if err != nil {
+		return err
+	}
+	// the schedule of a question deleted in the meantime is cancelled on the next run
+	if !published {
+		return nil
+	}
+	_ = qs.questionRepo.UpdateSearch(ctx, question.ID)
+
+	userQuestionCount, err := qs.questioncommon.GetUserQuestionCount(ctx, question.UserID)
+	if err != nil {
+		log.Errorf("get user question count error %v", err)
+	} else if err = qs.userCommon.UpdateQuestionCount(ctx, question.UserID, userQuestionCount); err != nil {
+		log.Errorf("update user question count error %v", err)
+	}
+	return nil
+}
+
+// AutoCloseQuestionsCron close the inactive questions matching the active auto close rules,
+// the admin who set up the rule is recorded as the one who closed the question
+func (qs *QuestionService) AutoCloseQuestionsCron(ctx context.Context) {
+	rules, err := qs.questionScheduleService.GetActiveAutoCloseRules(ctx)
+	if err != nil {
+		log.Errorf("get auto close rules failed: %v", err)
+		return
+	}
+	for _, rule := range rules {
+		questions, err := qs.questionScheduleService.GetInactiveQuestions(ctx, rule)
+		if err != nil {
+			log.Errorf("get inactive questions of rule %s failed: %v", rule.ID, err)
+			continue
+		}
+		for _, question := range questions {
+			err = qs.CloseQuestion(ctx, &schema.CloseQuestionReq{
+				ID:        question.ID,
+				CloseType: rule.CloseType,
+				CloseMsg:  rule.CloseMsg,
+				UserID:    rule.UserID,
+			})
+			if err != nil {
+				log.Errorf("auto close question %s by rule %s failed: %v", question.ID, rule.ID, err)
+			}
+		}
+	}
+}
@@ -42,12 +42,15 @@ import (
 	answercommon "github.com/apache/answer/internal/service/answer_common"
 	collectioncommon "github.com/apache/answer/internal/service/collection_common"
 	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/draft"
 	"github.com/apache/answer/internal/service/export"
+	hierarchicaltag "github.com/apache/answer/internal/service/hierarchical_tag"
 	metacommon "github.com/apache/answer/internal/service/meta_common"
 	"github.com/apache/answer/internal/service/notice_queue"
 	"github.com/apache/answer/internal/service/notification"
 	"github.com/apache/answer/internal/service/permission"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/question_schedule"
 	"github.com/apache/answer/internal/service/review"
 	"github.com/apache/answer/internal/service/revision_common"
 	"github.com/apache/answer/internal/service/role"
@@ -93,6 +96,9 @@ type QuestionService struct {
 	configService                    *config.ConfigService
 	eventQueueService                event_queue.EventQueueService
 	reviewRepo                       review.ReviewRepo
+	hierarchicalTagService           *hierarchicaltag.HierarchicalTagService
+	questionScheduleService          *question_schedule.QuestionScheduleService
+	draftService                     *draft.DraftService
 }
 
 func NewQuestionService(
@@ -119,6 +125,9 @@ func NewQuestionService(
 	configService *config.ConfigService,
 	eventQueueService event_queue.EventQueueService,
 	reviewRepo review.ReviewRepo,
+	hierarchicalTagService *hierarchicaltag.HierarchicalTagService,
+	questionScheduleService *question_schedule.QuestionScheduleService,
+	draftService *draft.DraftService,
 ) *QuestionService {
 	return &QuestionService{
 		activityRepo:                     activityRepo,
@@ -144,6 +153,9 @@ func NewQuestionService(
 		configService:                    configService,
 		eventQueueService:                eventQueueService,
 		reviewRepo:                       reviewRepo,
+		hierarchicalTagService:           hierarchicalTagService,
+		questionScheduleService:          questionScheduleService,
+		draftService:                     draftService,
 	}
 }
 
@@ -330,9 +342,21 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 			return errorlist, err
 		}
 	}
+	hierarchicalTags, errorlist, err := qs.checkHierarchicalTags(ctx, req.UserID, req.HierarchicalTagIDs, nil)
+	if err != nil {
+		return errorlist, err
+	}
 
 	question := &entity.Question{}
 	now := time.Now()
+	if req.PublishAt > 0 {
+		if !req.CanSchedule {
+			return nil, errors.Forbidden(reason.ForbiddenError)
+		}
+		if req.PublishAt <= now.Unix() {
+			return nil, errors.BadRequest(reason.QuestionPublishTimeInvalid)
+		}
+	}
 	question.UserID = req.UserID
 	question.Title = req.Title
 	question.OriginalText = req.Content
@@ -353,6 +377,10 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 		return
 	}
 	question.Status = qs.reviewService.AddQuestionReview(ctx, question, req.Tags, req.IP, req.UserAgent)
+	// the approved question is held until the publish time
+	if question.Status == entity.QuestionStatusAvailable && req.PublishAt > 0 {
+		question.Status = entity.QuestionStatusScheduled
+	}
 	if err := qs.questionRepo.UpdateQuestionStatus(ctx, question.ID, question.Status); err != nil {
 		return nil, err
 	}
@@ -374,6 +402,12 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 	if err != nil {
 		return
 	}
+	if len(hierarchicalTags) > 0 {
+		err = qs.hierarchicalTagService.AddHierarchicalTagsToQuestion(ctx, question.ID, getHierarchicalTagIDs(hierarchicalTags))
+		if err != nil {
+			return
+		}
+	}
 	_ = qs.questionRepo.UpdateSearch(ctx, question.ID)
 
 	revisionDTO := &schema.AddRevisionDTO{
@@ -382,7 +416,7 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 		Title:    question.Title,
 	}
 
-	questionWithTagsRevision, err := qs.changeQuestionToRevision(ctx, question, tags)
+	questionWithTagsRevision, err := qs.changeQuestionToRevision(ctx, question, tags, hierarchicalTags)
 	if err != nil {
 		return nil, err
 	}
@@ -416,8 +450,21 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 		qs.externalNotificationQueueService.Send(ctx,
 			schema.CreateNewQuestionNotificationMsg(question.ID, question.Title, question.UserID, tags))
 	}
-	qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionCreate, req.UserID).TID(question.ID).
-		QID(question.ID, question.UserID))
+	// the scheduled question is announced when it is published
+	if question.Status == entity.QuestionStatusScheduled {
+		err = qs.questionScheduleService.AddSchedule(ctx, question.ID, question.UserID, time.Unix(req.PublishAt, 0))
+		if err != nil {
+			return nil, err
+		}
+		// the event of the question comes later, when the user may be writing another one
+		err = qs.draftService.RemoveDraft(ctx, &schema.RemoveDraftReq{UserID: req.UserID, Type: entity.DraftTypeQuestion})
+		if err != nil {
+			log.Errorf("remove the draft of scheduled question %s failed: %v", question.ID, err)
+		}
+	} else {
+		qs.eventQueueService.Send(ctx, schema.NewEvent(constant.EventQuestionCreate, req.UserID).TID(question.ID).
+			QID(question.ID, question.UserID))
+	}
 
 	questionInfo, err = qs.GetQuestion(ctx, question.ID, question.UserID, req.QuestionPermission)
 	return
@@ -909,6 +956,23 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 
 	isChange := qs.tagCommon.CheckTagsIsChange(ctx, tagNameList, oldtagNameList)
 
+	oldHierarchicalTagIDs, err := qs.hierarchicalTagService.GetQuestionHierarchicalTagIDs(ctx, question.ID)
+	if err != nil {
+		return questionInfo, err
+	}
+	// hierarchical tags are kept as they are if they are not given
+	hierarchicalTagIDList := oldHierarchicalTagIDs
+	if req.HierarchicalTagIDs != nil {
+		hierarchicalTagIDList = req.HierarchicalTagIDs
+	}
+	hierarchicalTags, errorlist, err := qs.checkHierarchicalTags(ctx, req.UserID, hierarchicalTagIDList, oldHierarchicalTagIDs)
+	if err != nil {
+		return errorlist, err
+	}
+	if qs.tagCommon.CheckTagsIsChange(ctx, getHierarchicalTagIDs(hierarchicalTags), oldHierarchicalTagIDs) {
+		isChange = true
+	}
+
 	//If the content is the same, ignore it
 	if dbinfo.Title == req.Title && dbinfo.OriginalText == req.Content && !isChange {
 		return
@@ -997,9 +1061,13 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 		if tagerr != nil {
 			return questionInfo, tagerr
 		}
+		err = qs.hierarchicalTagService.AddHierarchicalTagsToQuestion(ctx, question.ID, getHierarchicalTagIDs(hierarchicalTags))
+		if err != nil {
+			return questionInfo, err
+		}
 	}
 
-	questionWithTagsRevision, err := qs.changeQuestionToRevision(ctx, question, Tags)
+	questionWithTagsRevision, err := qs.changeQuestionToRevision(ctx, question, Tags, hierarchicalTags)
 	if err != nil {
 		return nil, err
 	}
@@ -1010,10 +1078,14 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 		return
 	}
 	if canUpdate {
+		activityTypeKey := constant.ActQuestionEdited
+		if req.Rollback {
+			activityTypeKey = constant.ActQuestionRollback
+		}
 		qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 			UserID:           req.UserID,
 			ObjectID:         question.ID,
-			ActivityTypeKey:  constant.ActQuestionEdited,
+			ActivityTypeKey:  activityTypeKey,
 			RevisionID:       revisionID,
 			OriginalObjectID: question.ID,
 		})
@@ -1032,11 +1104,17 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 	if err != nil {
 		return
 	}
-	// If the question is deleted or pending, only the administrator and the author can view it
+	// If the question is deleted, pending or scheduled, only the administrator and the author can view it
 	if (question.Status == entity.QuestionStatusDeleted ||
-		question.Status == entity.QuestionStatusPending) && !per.CanReopen && question.UserID != userID {
+		question.Status == entity.QuestionStatusPending ||
+		question.Status == entity.QuestionStatusScheduled) && !per.CanReopen && question.UserID != userID {
 		return nil, errors.NotFound(reason.QuestionNotFound)
 	}
+	// The author can not tell the shadow hidden question from the others
+	if question.Status == entity.QuestionStatusPending && !per.CanReopen && question.UserID == userID &&
+		qs.reviewService.IsShadowHidden(ctx, question.ID) {
+		question.Status = entity.QuestionStatusAvailable
+	}
 	if question.Status != entity.QuestionStatusClosed {
 		per.CanReopen = false
 	}
@@ -1070,6 +1148,17 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 		operation.Level = schema.OperationLevelSecondary
 		question.Operation = operation
 	}
+	if question.Status == entity.QuestionStatusScheduled {
+		operation := &schema.Operation{}
+		operation.Msg = translator.Tr(handler.GetLangByCtx(ctx), reason.QuestionScheduled)
+		operation.Level = schema.OperationLevelSecondary
+		question.Operation = operation
+	}
+
+	question.HierarchicalTags, err = qs.hierarchicalTagService.GetQuestionHierarchicalTags(ctx, uid.DeShortID(questionID))
+	if err != nil {
+		return nil, err
+	}
 
 	question.Description = htmltext.FetchExcerpt(question.HTML, "...", 240)
 	question.MemberActions = permission.GetQuestionPermission(ctx, userID, question.UserID, question.Status,
@@ -1432,6 +1521,18 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 		}
 	}
 
+	// query by hierarchical tag condition, the whole subtree of the category is included
+	var hierarchicalTagIDs = make([]string, 0)
+	if len(req.Category) > 0 {
+		hierarchicalTagIDs, err = qs.hierarchicalTagService.GetSubtreeIDsBySlugPath(ctx, req.Category)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(hierarchicalTagIDs) == 0 {
+			return questions, 0, nil
+		}
+	}
+
 	// query by user condition
 	if req.Username != "" {
 		userinfo, exist, err := qs.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
@@ -1449,7 +1550,7 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 	}
 
 	questionList, total, err := qs.questionRepo.GetQuestionPage(ctx, req.Page, req.PageSize,
-		tagIDs, req.UserIDBeSearched, req.OrderCond, req.InDays, showHidden, req.ShowPending)
+		tagIDs, hierarchicalTagIDs, req.UserIDBeSearched, req.OrderCond, req.InDays, showHidden, req.ShowPending)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -1645,7 +1746,8 @@ func (qs *QuestionService) AdminAnswerPage(ctx context.Context, req *schema.Admi
 	return pager.NewPageModel(count, answerResp), nil
 }
 
-func (qs *QuestionService) changeQuestionToRevision(ctx context.Context, questionInfo *entity.Question, tags []*entity.Tag) (
+func (qs *QuestionService) changeQuestionToRevision(ctx context.Context, questionInfo *entity.Question, tags []*entity.Tag,
+	hierarchicalTags []*entity.HierarchicalTagSimpleInfoForRevision) (
 	questionRevision *entity.QuestionWithTagsRevision, err error) {
 	questionRevision = &entity.QuestionWithTagsRevision{}
 	questionRevision.Question = *questionInfo
@@ -1655,9 +1757,61 @@ func (qs *QuestionService) changeQuestionToRevision(ctx context.Context, questio
 		_ = copier.Copy(item, tag)
 		questionRevision.Tags = append(questionRevision.Tags, item)
 	}
+	questionRevision.HierarchicalTags = hierarchicalTags
 	return questionRevision, nil
 }
 
+// checkHierarchicalTags checks that all the hierarchical tags chosen for a question are available
+// and that the user can file the question under the ones not attached to it yet
+func (qs *QuestionService) checkHierarchicalTags(ctx context.Context, userID string, tagIDs, currentTagIDs []string) (
+	hierarchicalTags []*entity.HierarchicalTagSimpleInfoForRevision, errorlist []*validator.FormErrorField, err error) {
+	hierarchicalTags, exist, err := qs.hierarchicalTagService.GetHierarchicalTagsForRevision(ctx, tagIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	denyReason := ""
+	if !exist {
+		denyReason = reason.HierarchicalTagNotFound
+	} else {
+		current := make(map[string]bool, len(currentTagIDs))
+		for _, tagID := range currentTagIDs {
+			current[tagID] = true
+		}
+		newTagIDs := make([]string, 0)
+		for _, tag := range hierarchicalTags {
+			if !current[tag.ID] {
+				newTagIDs = append(newTagIDs, tag.ID)
+			}
+		}
+		var can bool
+		can, denyReason, err = qs.hierarchicalTagService.CanFileQuestion(ctx, userID, newTagIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		if can {
+			return hierarchicalTags, nil, nil
+		}
+	}
+	errorlist = append(errorlist, &validator.FormErrorField{
+		ErrorField: "hierarchical_tag_ids",
+		ErrorMsg:   translator.Tr(handler.GetLangByCtx(ctx), denyReason),
+	})
+	return nil, errorlist, errors.BadRequest(denyReason)
+}
+
+// IsHierarchicalTagModerator checks whether the user moderates the question through its hierarchical tags
+func (qs *QuestionService) IsHierarchicalTagModerator(ctx context.Context, userID, questionID string) (bool, error) {
+	return qs.hierarchicalTagService.IsQuestionModerator(ctx, userID, uid.DeShortID(questionID))
+}
+
+func getHierarchicalTagIDs(hierarchicalTags []*entity.HierarchicalTagSimpleInfoForRevision) []string {
+	ids := make([]string, 0, len(hierarchicalTags))
+	for _, tag := range hierarchicalTags {
+		ids = append(ids, tag.ID)
+	}
+	return ids
+}
+
 func (qs *QuestionService) SitemapCron(ctx context.Context) {
 	siteSeo, err := qs.siteInfoService.GetSiteSeo(ctx)
 	if err != nil {
@@ -0,0 +1,253 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/diff"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// revisionSnapshot the comparable parts of the object saved in a revision
+type revisionSnapshot struct {
+	Title   string
+	Content string
+	Tags    []string
+}
+
+// GetRevisionDiff get the changes between two revisions of the same object
+func (rs *RevisionService) GetRevisionDiff(ctx context.Context, req *schema.GetRevisionDiffReq) (
+	resp *schema.GetRevisionDiffResp, err error) {
+	from, err := rs.getVisibleRevision(ctx, req.FromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := rs.getVisibleRevision(ctx, req.ToID)
+	if err != nil {
+		return nil, err
+	}
+	if from.ObjectID != to.ObjectID {
+		return nil, errors.BadRequest(reason.RevisionObjectMismatch)
+	}
+
+	fromSnapshot, err := parseRevisionSnapshot(from)
+	if err != nil {
+		return nil, err
+	}
+	toSnapshot, err := parseRevisionSnapshot(to)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.GetRevisionDiffResp{
+		ObjectType:  constant.ObjectTypeNumberMapping[from.ObjectType],
+		Title:       diff.Words(fromSnapshot.Title, toSnapshot.Title),
+		AddedTags:   subtractTags(toSnapshot.Tags, fromSnapshot.Tags),
+		RemovedTags: subtractTags(fromSnapshot.Tags, toSnapshot.Tags),
+	}
+	if req.Granularity == schema.RevisionDiffGranularityWord {
+		resp.Content = diff.Words(fromSnapshot.Content, toSnapshot.Content)
+	} else {
+		resp.Content = diff.Lines(fromSnapshot.Content, toSnapshot.Content)
+	}
+
+	resp.From, err = rs.convertRevision(ctx, *from)
+	if err != nil {
+		return nil, err
+	}
+	resp.To, err = rs.convertRevision(ctx, *to)
+	if err != nil {
+		return nil, err
+	}
+	// the object id is shortened in the revision if short id is enabled
+	resp.ObjectID = resp.From.ObjectID
+	return resp, nil
+}
+
+// GetQuestionRollback build the question update request which restores the question to the revision
+func (rs *RevisionService) GetQuestionRollback(ctx context.Context, req *schema.RollbackRevisionReq) (
+	updateReq *schema.QuestionUpdate, err error) {
+	rev, err := rs.getRollbackRevision(ctx, req.RevisionID, constant.QuestionObjectType)
+	if err != nil {
+		return nil, err
+	}
+	question := &entity.QuestionWithTagsRevision{}
+	if err = json.Unmarshal([]byte(rev.Content), question); err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+
+	updateReq = &schema.QuestionUpdate{
+		ID:          rev.ObjectID,
+		Title:       question.Title,
+		Content:     question.OriginalText,
+		Tags:        make([]*schema.TagItem, 0, len(question.Tags)),
+		EditSummary: req.EditSummary,
+		UserID:      req.UserID,
+		Rollback:    true,
+		CaptchaID:   req.CaptchaID,
+		CaptchaCode: req.CaptchaCode,
+	}
+	for _, tag := range question.Tags {
+		updateReq.Tags = append(updateReq.Tags, &schema.TagItem{
+			SlugName:    tag.SlugName,
+			DisplayName: tag.DisplayName,
+		})
+	}
+	// the revisions recorded before hierarchical tags existed keep the current ones
+	if question.HierarchicalTags != nil {
+		updateReq.HierarchicalTagIDs = make([]string, 0, len(question.HierarchicalTags))
+		for _, tag := range question.HierarchicalTags {
+			updateReq.HierarchicalTagIDs = append(updateReq.HierarchicalTagIDs, tag.ID)
+		}
+	}
+	if _, err = updateReq.Check(); err != nil {
+		return nil, err
+	}
+	return updateReq, nil
+}
+
+// GetAnswerRollback build the answer update request which restores the answer to the revision
+func (rs *RevisionService) GetAnswerRollback(ctx context.Context, req *schema.RollbackRevisionReq) (
+	updateReq *schema.AnswerUpdateReq, err error) {
+	rev, err := rs.getRollbackRevision(ctx, req.RevisionID, constant.AnswerObjectType)
+	if err != nil {
+		return nil, err
+	}
+	answer := &entity.Answer{}
+	if err = json.Unmarshal([]byte(rev.Content), answer); err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+
+	updateReq = &schema.AnswerUpdateReq{
+		ID:          rev.ObjectID,
+		QuestionID:  answer.QuestionID,
+		Content:     answer.OriginalText,
+		EditSummary: req.EditSummary,
+		UserID:      req.UserID,
+		Rollback:    true,
+		CaptchaID:   req.CaptchaID,
+		CaptchaCode: req.CaptchaCode,
+	}
+	if _, err = updateReq.Check(); err != nil {
+		return nil, err
+	}
+	return updateReq, nil
+}
+
+// GetTagRollback build the tag update request which restores the tag to the revision
+func (rs *RevisionService) GetTagRollback(ctx context.Context, req *schema.RollbackRevisionReq) (
+	updateReq *schema.UpdateTagReq, err error) {
+	rev, err := rs.getRollbackRevision(ctx, req.RevisionID, constant.TagObjectType)
+	if err != nil {
+		return nil, err
+	}
+	tag := &entity.Tag{}
+	if err = json.Unmarshal([]byte(rev.Content), tag); err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+
+	updateReq = &schema.UpdateTagReq{
+		TagID:        rev.ObjectID,
+		SlugName:     tag.SlugName,
+		DisplayName:  tag.DisplayName,
+		OriginalText: tag.OriginalText,
+		EditSummary:  req.EditSummary,
+		UserID:       req.UserID,
+		Rollback:     true,
+	}
+	if _, err = updateReq.Check(); err != nil {
+		return nil, err
+	}
+	return updateReq, nil
+}
+
+// getVisibleRevision get the revision which is shown in the revision list
+func (rs *RevisionService) getVisibleRevision(ctx context.Context, revisionID string) (
+	rev *entity.Revision, err error) {
+	rev, exist, err := rs.revisionRepo.GetRevisionByID(ctx, uid.DeShortID(revisionID))
+	if err != nil {
+		return nil, err
+	}
+	if !exist || (rev.Status != entity.RevisionNormalStatus && rev.Status != entity.RevisionReviewPassStatus) {
+		return nil, errors.NotFound(reason.RevisionNotFound)
+	}
+	return rev, nil
+}
+
+func (rs *RevisionService) getRollbackRevision(ctx context.Context, revisionID, objectType string) (
+	rev *entity.Revision, err error) {
+	rev, err = rs.getVisibleRevision(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	if rev.ObjectType != constant.ObjectTypeStrMapping[objectType] {
+		return nil, errors.BadRequest(reason.RevisionObjectMismatch)
+	}
+	return rev, nil
+}
+
+func parseRevisionSnapshot(rev *entity.Revision) (snapshot *revisionSnapshot, err error) {
+	snapshot = &revisionSnapshot{}
+	switch rev.ObjectType {
+	case constant.ObjectTypeStrMapping[constant.QuestionObjectType]:
+		question := &entity.QuestionWithTagsRevision{}
+		err = json.Unmarshal([]byte(rev.Content), question)
+		snapshot.Title = question.Title
+		snapshot.Content = question.OriginalText
+		for _, tag := range question.Tags {
+			snapshot.Tags = append(snapshot.Tags, tag.SlugName)
+		}
+	case constant.ObjectTypeStrMapping[constant.AnswerObjectType]:
+		answer := &entity.Answer{}
+		err = json.Unmarshal([]byte(rev.Content), answer)
+		snapshot.Content = answer.OriginalText
+	case constant.ObjectTypeStrMapping[constant.TagObjectType]:
+		tag := &entity.Tag{}
+		err = json.Unmarshal([]byte(rev.Content), tag)
+		snapshot.Title = tag.DisplayName
+		snapshot.Content = tag.OriginalText
+	}
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return snapshot, nil
+}
+
+// subtractTags returns the tags in a but not in b
+func subtractTags(a, b []string) (tags []string) {
+	tags = make([]string, 0)
+	exists := make(map[string]bool, len(b))
+	for _, tag := range b {
+		exists[tag] = true
+	}
+	for _, tag := range a {
+		if !exists[tag] {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -34,6 +34,8 @@ import (
 	"github.com/apache/answer/internal/service/activity"
 	"github.com/apache/answer/internal/service/activity_queue"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
+	hierarchicaltag "github.com/apache/answer/internal/service/hierarchical_tag"
+	"github.com/apache/answer/internal/service/moderation_common"
 	"github.com/apache/answer/internal/service/notice_queue"
 	"github.com/apache/answer/internal/service/object_info"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
@@ -68,6 +70,8 @@ type RevisionService struct {
 	reportRepo               report_common.ReportRepo
 	reviewService            *review.ReviewService
 	reviewActivity           activity.ReviewActivityRepo
+	hierarchicalTagService   *hierarchicaltag.HierarchicalTagService
+	moderationCommon         *moderation_common.ModerationCommonService
 }
 
 func NewRevisionService(
@@ -85,6 +89,8 @@ func NewRevisionService(
 	reportRepo report_common.ReportRepo,
 	reviewService *review.ReviewService,
 	reviewActivity activity.ReviewActivityRepo,
+	hierarchicalTagService *hierarchicaltag.HierarchicalTagService,
+	moderationCommon *moderation_common.ModerationCommonService,
 ) *RevisionService {
 	return &RevisionService{
 		revisionRepo:             revisionRepo,
@@ -101,6 +107,8 @@ func NewRevisionService(
 		reportRepo:               reportRepo,
 		reviewService:            reviewService,
 		reviewActivity:           reviewActivity,
+		hierarchicalTagService:   hierarchicalTagService,
+		moderationCommon:         moderationCommon,
 	}
 }
 
@@ -115,6 +123,34 @@ func (rs *RevisionService) RevisionAudit(ctx context.Context, req *schema.Revisi
 	if revisioninfo.Status != entity.RevisionUnreviewedStatus {
 		return
 	}
+	status := entity.RevisionReviewPassStatus
+	if req.Operation == schema.RevisionAuditReject {
+		status = entity.RevisionReviewRejectStatus
+	} else if req.Operation != schema.RevisionAuditApprove {
+		return nil
+	}
+	if err = rs.moderationCommon.CheckClaim(ctx, entity.ModerationItemTypeRevision, revisioninfo.ID, req.UserID); err != nil {
+		return err
+	}
+	before := rs.moderationCommon.Snapshot(ctx, revisioninfo.Status, revisioninfo.ObjectID)
+
+	if err = rs.auditRevision(ctx, req, revisioninfo); err != nil {
+		return err
+	}
+
+	rs.moderationCommon.Record(ctx, &entity.ModerationLog{
+		UserID:   req.UserID,
+		ItemType: entity.ModerationItemTypeRevision,
+		ItemID:   revisioninfo.ID,
+		ObjectID: revisioninfo.ObjectID,
+		Action:   req.Operation,
+		Reason:   req.Reason,
+	}, before, rs.moderationCommon.Snapshot(ctx, status, revisioninfo.ObjectID))
+	return nil
+}
+
+func (rs *RevisionService) auditRevision(ctx context.Context, req *schema.RevisionAuditReq,
+	revisioninfo *entity.Revision) (err error) {
 	if req.Operation == schema.RevisionAuditReject {
 		err = rs.revisionRepo.UpdateStatus(ctx, req.ID, entity.RevisionReviewRejectStatus, req.UserID)
 		return
@@ -218,6 +254,26 @@ func (rs *RevisionService) revisionAuditQuestion(ctx context.Context, revisionit
 		if saveerr != nil {
 			return saveerr
 		}
+		// revisions recorded before hierarchical tags existed leave them untouched
+		if questioninfo.HierarchicalTags != nil {
+			hierarchicalTagIDs := make([]string, 0, len(questioninfo.HierarchicalTags))
+			for _, tag := range questioninfo.HierarchicalTags {
+				hierarchicalTagIDs = append(hierarchicalTagIDs, tag.ID)
+			}
+			// the tags removed since the revision was submitted are skipped
+			hierarchicalTags, _, saveerr := rs.hierarchicalTagService.GetHierarchicalTagsForRevision(ctx, hierarchicalTagIDs)
+			if saveerr != nil {
+				return saveerr
+			}
+			hierarchicalTagIDs = make([]string, 0, len(hierarchicalTags))
+			for _, tag := range hierarchicalTags {
+				hierarchicalTagIDs = append(hierarchicalTagIDs, tag.ID)
+			}
+			saveerr = rs.hierarchicalTagService.AddHierarchicalTagsToQuestion(ctx, question.ID, hierarchicalTagIDs)
+			if saveerr != nil {
+				return saveerr
+			}
+		}
 		rs.activityQueueService.Send(ctx, &schema.ActivityMsg{
 			UserID:           revisionitem.UserID,
 			ObjectID:         revisionitem.ObjectID,
@@ -394,28 +450,34 @@ func (rs *RevisionService) GetRevisionList(ctx context.Context, req *schema.GetR
 	}
 
 	for _, r := range revs {
-		var (
-			uinfo schema.UserBasicInfo
-			item  schema.GetRevisionResp
-		)
-
-		_ = copier.Copy(&item, r)
-		rs.parseItem(ctx, &item)
-
-		// get user info
-		userInfo, exists, e := rs.userCommon.GetUserBasicInfoByID(ctx, item.UserID)
+		item, e := rs.convertRevision(ctx, r)
 		if e != nil {
 			return nil, e
 		}
-		if exists {
-			err = copier.Copy(&uinfo, userInfo)
-			item.UserInfo = uinfo
-		}
 		resp = append(resp, item)
 	}
 	return
 }
 
+// convertRevision parse the revision content and fill in the user info
+func (rs *RevisionService) convertRevision(ctx context.Context, r entity.Revision) (item schema.GetRevisionResp, err error) {
+	var uinfo schema.UserBasicInfo
+
+	_ = copier.Copy(&item, r)
+	rs.parseItem(ctx, &item)
+
+	// get user info
+	userInfo, exists, err := rs.userCommon.GetUserBasicInfoByID(ctx, item.UserID)
+	if err != nil {
+		return item, err
+	}
+	if exists {
+		_ = copier.Copy(&uinfo, userInfo)
+		item.UserInfo = uinfo
+	}
+	return item, nil
+}
+
 func (rs *RevisionService) parseItem(ctx context.Context, item *schema.GetRevisionResp) {
 	var (
 		err          error
@@ -57,6 +57,12 @@ func (ss *SearchService) Search(ctx context.Context, dto *schema.SearchDTO) (res
 
 	// search type
 	cond := ss.searchParser.ParseStructure(ctx, dto)
+	if cond.NoMatch {
+		return &schema.SearchResp{
+			Total:         0,
+			SearchResults: make([]*schema.SearchResult, 0),
+		}, nil
+	}
 
 	// check search plugin
 	var finder plugin.Search
@@ -70,13 +76,13 @@ func (ss *SearchService) Search(ctx context.Context, dto *schema.SearchDTO) (res
 	if finder == nil {
 		if cond.SearchAll() {
 			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchContents(ctx, cond.Words, cond.Tags, cond.UserID, cond.VoteAmount, dto.Page, dto.Size, dto.Order)
+				ss.searchRepo.SearchContents(ctx, cond, dto.Page, dto.Size, dto.Order)
 		} else if cond.SearchQuestion() {
 			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchQuestions(ctx, cond.Words, cond.Tags, cond.NotAccepted, cond.Views, cond.AnswerAmount, dto.Page, dto.Size, dto.Order)
+				ss.searchRepo.SearchQuestions(ctx, cond, dto.Page, dto.Size, dto.Order)
 		} else if cond.SearchAnswer() {
 			resp.SearchResults, resp.Total, err =
-				ss.searchRepo.SearchAnswers(ctx, cond.Words, cond.Tags, cond.Accepted, cond.QuestionID, dto.Page, dto.Size, dto.Order)
+				ss.searchRepo.SearchAnswers(ctx, cond, dto.Page, dto.Size, dto.Order)
 		}
 		return
 	}
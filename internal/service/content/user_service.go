@@ -822,10 +822,14 @@ func (us *UserService) UserUnsubscribeNotification(
 		}
 		channels := schema.NewNotificationChannelsFormJson(notificationConfig.Channels)
 		// unsubscribe email notification
+		notificationConfig.Enabled = false
 		for _, channel := range channels {
 			if channel.Key == constant.EmailChannel {
 				channel.Enable = false
 			}
+			if channel.Enable {
+				notificationConfig.Enabled = true
+			}
 		}
 		notificationConfig.Channels = channels.ToJsonString()
 		if err = us.userNotificationConfigRepo.Save(ctx, notificationConfig); err != nil {
@@ -0,0 +1,323 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/activity_common"
+	"github.com/apache/answer/internal/service/export"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/token"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// sendBatchSize the amount of the digests sent in a batch
+	sendBatchSize = 100
+	// sectionLimit the max items in each section of the digest
+	sectionLimit = 10
+	// expertTagLimit the amount of the tags the user answered most, used to find the questions to answer
+	expertTagLimit = 5
+)
+
+// DigestRepo digest repository
+type DigestRepo interface {
+	GetConfig(ctx context.Context, userID string) (conf *entity.UserDigestConfig, exist bool, err error)
+	SaveConfig(ctx context.Context, conf *entity.UserDigestConfig) (err error)
+	GetDueConfigs(ctx context.Context, now time.Time, limit int) (configs []*entity.UserDigestConfig, err error)
+	UpdateSendTime(ctx context.Context, userID string, lastSentAt, nextSendAt time.Time) (err error)
+	GetUnreadNotifications(ctx context.Context, userID string, since time.Time, limit int) (
+		notifications []*entity.Notification, err error)
+	GetQuestionsByTags(ctx context.Context, tagIDs []string, since time.Time, excludedUserID string,
+		unanswered bool, limit int) (questions []*entity.Question, err error)
+	GetAnsweredTagIDs(ctx context.Context, userID string, limit int) (tagIDs []string, err error)
+}
+
+// DigestService sends the scheduled digest emails of the activities
+type DigestService struct {
+	digestRepo      DigestRepo
+	followRepo      activity_common.FollowRepo
+	userRepo        usercommon.UserRepo
+	emailService    *export.EmailService
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewDigestService new digest service
+func NewDigestService(
+	digestRepo DigestRepo,
+	followRepo activity_common.FollowRepo,
+	userRepo usercommon.UserRepo,
+	emailService *export.EmailService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *DigestService {
+	return &DigestService{
+		digestRepo:      digestRepo,
+		followRepo:      followRepo,
+		userRepo:        userRepo,
+		emailService:    emailService,
+		siteInfoService: siteInfoService,
+	}
+}
+
+// GetSchedule get the digest schedule of the user, the default one is returned if not set
+func (ds *DigestService) GetSchedule(ctx context.Context, userID string) (schedule *schema.DigestSchedule, err error) {
+	conf, exist, err := ds.digestRepo.GetConfig(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return defaultSchedule(), nil
+	}
+	return &schema.DigestSchedule{
+		Frequency: conf.Frequency,
+		Weekday:   conf.Weekday,
+		Hour:      conf.Hour,
+		Timezone:  conf.Timezone,
+	}, nil
+}
+
+// SaveSchedule save the digest schedule of the user and plan the next digest.
+// If the schedule is nil, the current one is kept, or the default one is used if not set.
+func (ds *DigestService) SaveSchedule(ctx context.Context, userID string, schedule *schema.DigestSchedule) (err error) {
+	conf, exist, err := ds.digestRepo.GetConfig(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if schedule == nil {
+		if exist {
+			return nil
+		}
+		schedule = defaultSchedule()
+	}
+	if len(schedule.Frequency) == 0 {
+		schedule.Frequency = entity.DigestFrequencyDaily
+	}
+	if len(schedule.Timezone) > 0 {
+		if _, err = time.LoadLocation(schedule.Timezone); err != nil {
+			return errors.BadRequest(reason.DigestTimezoneInvalid)
+		}
+	}
+	if !exist {
+		conf = &entity.UserDigestConfig{UserID: userID}
+	}
+	conf.Frequency = schedule.Frequency
+	conf.Weekday = schedule.Weekday
+	conf.Hour = schedule.Hour
+	conf.Timezone = schedule.Timezone
+	conf.NextSendAt = nextSendTime(time.Now(), conf, ds.getLocation(ctx, conf))
+	return ds.digestRepo.SaveConfig(ctx, conf)
+}
+
+// SendDueDigests sends the digests which are due
+func (ds *DigestService) SendDueDigests(ctx context.Context) {
+	for {
+		now := time.Now()
+		configs, err := ds.digestRepo.GetDueConfigs(ctx, now, sendBatchSize)
+		if err != nil {
+			log.Errorf("get due digests failed: %v", err)
+			return
+		}
+		for _, conf := range configs {
+			ds.sendDigest(ctx, conf, now)
+			// the next digest is planned even if this one failed, so that a broken digest is not retried forever
+			err = ds.digestRepo.UpdateSendTime(ctx, conf.UserID, now, nextSendTime(now, conf, ds.getLocation(ctx, conf)))
+			if err != nil {
+				log.Errorf("update digest send time of user %s failed: %v", conf.UserID, err)
+				return
+			}
+		}
+		if len(configs) < sendBatchSize {
+			return
+		}
+	}
+}
+
+func (ds *DigestService) sendDigest(ctx context.Context, conf *entity.UserDigestConfig, now time.Time) {
+	userInfo, exist, err := ds.userRepo.GetByUserID(ctx, conf.UserID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !exist || userInfo.Status != entity.UserStatusAvailable || userInfo.MailStatus != entity.EmailStatusAvailable {
+		return
+	}
+	// If receiver not set language, use site default language.
+	lang := userInfo.Language
+	if len(lang) == 0 || lang == translator.DefaultLangOption {
+		if interfaceInfo, _ := ds.siteInfoService.GetSiteInterface(ctx); interfaceInfo != nil {
+			lang = interfaceInfo.Language
+		}
+	}
+	if len(lang) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(lang))
+	}
+
+	since := conf.LastSentAt
+	if since.IsZero() {
+		since = now.AddDate(0, 0, -periodDays(conf.Frequency))
+	}
+	rawData := &schema.DigestTemplateRawData{
+		Frequency:       conf.Frequency,
+		UnsubscribeCode: token.GenerateToken(),
+	}
+	rawData.Notifications = ds.getNotifications(ctx, conf.UserID, since)
+
+	followedTagIDs, err := ds.followRepo.GetFollowIDs(ctx, conf.UserID, entity.Tag{}.TableName())
+	if err != nil {
+		log.Error(err)
+	}
+	rawData.FollowingTagQuestions = ds.getQuestions(ctx, followedTagIDs, since, conf.UserID, false)
+
+	expertTagIDs, err := ds.digestRepo.GetAnsweredTagIDs(ctx, conf.UserID, expertTagLimit)
+	if err != nil {
+		log.Error(err)
+	}
+	rawData.UnansweredQuestions = ds.getQuestions(ctx, expertTagIDs, since, conf.UserID, true)
+
+	if len(rawData.Notifications) == 0 && len(rawData.FollowingTagQuestions) == 0 &&
+		len(rawData.UnansweredQuestions) == 0 {
+		log.Debugf("nothing to send in the digest of user %s", conf.UserID)
+		return
+	}
+
+	title, body, err := ds.emailService.DigestTemplate(ctx, rawData)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	codeContent := &schema.EmailCodeContent{
+		SourceType:               schema.UnsubscribeSourceType,
+		Email:                    userInfo.EMail,
+		UserID:                   userInfo.ID,
+		NotificationSources:      []constant.NotificationSource{constant.DigestSource},
+		SkipValidationLatestCode: true,
+	}
+	ds.emailService.SendAndSaveCodeWithTime(ctx, userInfo.ID, userInfo.EMail, title, body,
+		rawData.UnsubscribeCode, codeContent.ToJSONString(), 7*24*time.Hour)
+}
+
+func (ds *DigestService) getNotifications(ctx context.Context, userID string, since time.Time) (
+	items []*schema.DigestNotificationRawData) {
+	notifications, err := ds.digestRepo.GetUnreadNotifications(ctx, userID, since, sectionLimit)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+	lang := handler.GetLangByCtx(ctx)
+	for _, notification := range notifications {
+		content := &schema.NotificationContent{}
+		if err := json.Unmarshal([]byte(notification.Content), content); err != nil {
+			log.Error(err)
+			continue
+		}
+		questionID := content.ObjectInfo.ObjectMap["question"]
+		if len(questionID) == 0 {
+			continue
+		}
+		item := &schema.DigestNotificationRawData{
+			Action:        translator.Tr(lang, content.NotificationAction),
+			QuestionID:    questionID,
+			QuestionTitle: content.ObjectInfo.Title,
+			AnswerID:      content.ObjectInfo.ObjectMap["answer"],
+		}
+		if content.UserInfo != nil {
+			item.DisplayName = content.UserInfo.DisplayName
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (ds *DigestService) getQuestions(ctx context.Context, tagIDs []string, since time.Time,
+	userID string, unanswered bool) (items []*schema.DigestQuestionRawData) {
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	questions, err := ds.digestRepo.GetQuestionsByTags(ctx, tagIDs, since, userID, unanswered, sectionLimit)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+	for _, question := range questions {
+		items = append(items, &schema.DigestQuestionRawData{
+			QuestionID:    question.ID,
+			QuestionTitle: question.Title,
+			VoteCount:     question.VoteCount,
+			AnswerCount:   question.AnswerCount,
+		})
+	}
+	return items
+}
+
+// getLocation the time zone of the user, or the site time zone if the user has not set it
+func (ds *DigestService) getLocation(ctx context.Context, conf *entity.UserDigestConfig) *time.Location {
+	timezone := conf.Timezone
+	if len(timezone) == 0 {
+		if interfaceInfo, err := ds.siteInfoService.GetSiteInterface(ctx); err == nil {
+			timezone = interfaceInfo.TimeZone
+		}
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func defaultSchedule() *schema.DigestSchedule {
+	return &schema.DigestSchedule{
+		Frequency: entity.DigestFrequencyDaily,
+		Weekday:   int(time.Monday),
+		Hour:      8,
+	}
+}
+
+func periodDays(frequency string) int {
+	if frequency == entity.DigestFrequencyWeekly {
+		return 7
+	}
+	return 1
+}
+
+// nextSendTime the first time after now matching the schedule in the location
+func nextSendTime(now time.Time, conf *entity.UserDigestConfig, loc *time.Location) time.Time {
+	local := now.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), conf.Hour, 0, 0, 0, loc)
+	if conf.Frequency == entity.DigestFrequencyWeekly {
+		next = next.AddDate(0, 0, (conf.Weekday-int(next.Weekday())+7)%7)
+	}
+	if !next.After(now) {
+		next = next.AddDate(0, 0, periodDays(conf.Frequency))
+	}
+	return next
+}
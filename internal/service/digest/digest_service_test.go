@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextSendTime(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	// Wednesday 2024-05-15 10:30 in Shanghai
+	now := time.Date(2024, 5, 15, 10, 30, 0, 0, loc)
+
+	cases := []struct {
+		name string
+		conf *entity.UserDigestConfig
+		want time.Time
+	}{
+		{"daily later today", &entity.UserDigestConfig{Frequency: entity.DigestFrequencyDaily, Hour: 18},
+			time.Date(2024, 5, 15, 18, 0, 0, 0, loc)},
+		{"daily passed today", &entity.UserDigestConfig{Frequency: entity.DigestFrequencyDaily, Hour: 8},
+			time.Date(2024, 5, 16, 8, 0, 0, 0, loc)},
+		{"weekly later this week", &entity.UserDigestConfig{Frequency: entity.DigestFrequencyWeekly,
+			Weekday: int(time.Friday), Hour: 8}, time.Date(2024, 5, 17, 8, 0, 0, 0, loc)},
+		{"weekly passed this week", &entity.UserDigestConfig{Frequency: entity.DigestFrequencyWeekly,
+			Weekday: int(time.Monday), Hour: 8}, time.Date(2024, 5, 20, 8, 0, 0, 0, loc)},
+		{"weekly passed today", &entity.UserDigestConfig{Frequency: entity.DigestFrequencyWeekly,
+			Weekday: int(time.Wednesday), Hour: 10}, time.Date(2024, 5, 22, 10, 0, 0, 0, loc)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.True(t, c.want.Equal(nextSendTime(now.UTC(), c.conf, loc)))
+		})
+	}
+}
@@ -0,0 +1,335 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package draft
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/event_queue"
+	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/pkg/obj"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// draftExpiration the draft is removed if it is not saved again in the duration
+	draftExpiration = 30 * 24 * time.Hour
+	// maxUserDrafts the max drafts a user can have
+	maxUserDrafts = 100
+	// newQuestionObjectID the object id of the new question drafts
+	newQuestionObjectID = "0"
+)
+
+// DraftRepo draft repository
+type DraftRepo interface {
+	AddDraft(ctx context.Context, draft *entity.Draft) (err error)
+	UpdateDraft(ctx context.Context, draft *entity.Draft) (err error)
+	GetDraft(ctx context.Context, userID, draftType, objectID string) (draft *entity.Draft, exist bool, err error)
+	GetDraftPage(ctx context.Context, page, pageSize int, userID string) (
+		drafts []*entity.Draft, total int64, err error)
+	CountUserDrafts(ctx context.Context, userID string) (count int64, err error)
+	RemoveDraft(ctx context.Context, userID, draftType, objectID string) (err error)
+	RemoveExpiredDrafts(ctx context.Context, before time.Time) (count int64, err error)
+}
+
+// DraftService the drafts of the questions, answers and edits which are saved automatically by the editor
+type DraftService struct {
+	draftRepo    DraftRepo
+	questionRepo questioncommon.QuestionRepo
+	answerRepo   answercommon.AnswerRepo
+}
+
+// NewDraftService new draft service
+func NewDraftService(
+	draftRepo DraftRepo,
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	eventQueueService event_queue.EventQueueService,
+) *DraftService {
+	ds := &DraftService{
+		draftRepo:    draftRepo,
+		questionRepo: questionRepo,
+		answerRepo:   answerRepo,
+	}
+	// the draft is useless once the content is published
+	eventQueueService.Subscribe(&event_queue.Subscriber{
+		Name: "draft",
+		EventTypes: []constant.EventType{
+			constant.EventQuestionCreate,
+			constant.EventQuestionUpdate,
+			constant.EventAnswerCreate,
+			constant.EventAnswerUpdate,
+		},
+		Handler: ds.handleEvent,
+	})
+	return ds
+}
+
+// draftObject the post the draft belongs to
+type draftObject struct {
+	questionID    string
+	questionTitle string
+	revisionID    string
+	removed       bool
+}
+
+// SaveDraft save the draft, the edit draft keeps the revision it starts from until the user rebases it
+func (ds *DraftService) SaveDraft(ctx context.Context, req *schema.SaveDraftReq) (
+	resp *schema.DraftItem, err error) {
+	objectID := normalizeObjectID(req.Type, req.ObjectID)
+	object, err := ds.getDraftObject(ctx, req.UserID, req.CanViewHidden, req.Type, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if object.removed {
+		return nil, errors.BadRequest(reason.DraftObjectNotFound)
+	}
+
+	if req.Tags == nil {
+		req.Tags = make([]string, 0)
+	}
+	tags, _ := json.Marshal(req.Tags)
+	draft, exist, err := ds.draftRepo.GetDraft(ctx, req.UserID, req.Type, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		count, err := ds.draftRepo.CountUserDrafts(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if count >= maxUserDrafts {
+			return nil, errors.BadRequest(reason.DraftTooMany)
+		}
+		draft = &entity.Draft{
+			ID:        uid.ID().String(),
+			UserID:    req.UserID,
+			DraftType: req.Type,
+			ObjectID:  objectID,
+		}
+	}
+	draft.Title = req.Title
+	draft.Content = req.Content
+	draft.Tags = string(tags)
+	draft.ExpiredAt = time.Now().Add(draftExpiration)
+	if req.Type == entity.DraftTypeEdit {
+		switch {
+		case req.Rebase:
+			draft.BaseRevisionID = object.revisionID
+		case !exist && len(req.BaseRevisionID) > 0:
+			draft.BaseRevisionID = req.BaseRevisionID
+		case !exist:
+			draft.BaseRevisionID = object.revisionID
+		}
+	}
+
+	if exist {
+		err = ds.draftRepo.UpdateDraft(ctx, draft)
+	} else {
+		err = ds.draftRepo.AddDraft(ctx, draft)
+	}
+	if err != nil {
+		return nil, err
+	}
+	draft.UpdatedAt = time.Now()
+	return convertDraftItem(draft, object), nil
+}
+
+// GetDraft get the draft of the user for the object, nil if there is no draft
+func (ds *DraftService) GetDraft(ctx context.Context, req *schema.GetDraftReq) (resp *schema.DraftItem, err error) {
+	objectID := normalizeObjectID(req.Type, req.ObjectID)
+	draft, exist, err := ds.draftRepo.GetDraft(ctx, req.UserID, req.Type, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	object, err := ds.getDraftObject(ctx, req.UserID, req.CanViewHidden, draft.DraftType, draft.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	return convertDraftItem(draft, object), nil
+}
+
+// GetDraftPage get the drafts of the user
+func (ds *DraftService) GetDraftPage(ctx context.Context, req *schema.GetDraftPageReq) (
+	resp []*schema.DraftItem, total int64, err error) {
+	drafts, total, err := ds.draftRepo.GetDraftPage(ctx, req.Page, req.PageSize, req.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp = make([]*schema.DraftItem, 0, len(drafts))
+	for _, draft := range drafts {
+		object, err := ds.getDraftObject(ctx, req.UserID, req.CanViewHidden, draft.DraftType, draft.ObjectID)
+		if err != nil {
+			return nil, 0, err
+		}
+		resp = append(resp, convertDraftItem(draft, object))
+	}
+	return resp, total, nil
+}
+
+// RemoveDraft remove the draft of the user for the object
+func (ds *DraftService) RemoveDraft(ctx context.Context, req *schema.RemoveDraftReq) (err error) {
+	return ds.draftRepo.RemoveDraft(ctx, req.UserID, req.Type, normalizeObjectID(req.Type, req.ObjectID))
+}
+
+// RemoveExpiredDrafts remove the drafts which are not saved for a long time
+func (ds *DraftService) RemoveExpiredDrafts(ctx context.Context) {
+	count, err := ds.draftRepo.RemoveExpiredDrafts(ctx, time.Now())
+	if err != nil {
+		log.Errorf("remove expired drafts failed: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Infof("removed %d expired drafts", count)
+	}
+}
+
+// handleEvent remove the draft once the question or answer is published
+func (ds *DraftService) handleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	switch msg.EventType {
+	case constant.EventQuestionCreate:
+		// the draft was removed when the scheduled question was submitted, the current one is another question
+		if msg.GetExtra(constant.EventExtraScheduled) == "true" {
+			return nil
+		}
+		return ds.draftRepo.RemoveDraft(ctx, msg.UserID, entity.DraftTypeQuestion, newQuestionObjectID)
+	case constant.EventAnswerCreate:
+		answer, exist, err := ds.answerRepo.GetAnswer(ctx, msg.TriggerObjectID)
+		if err != nil || !exist {
+			return err
+		}
+		return ds.draftRepo.RemoveDraft(ctx, msg.UserID, entity.DraftTypeAnswer, answer.QuestionID)
+	case constant.EventQuestionUpdate, constant.EventAnswerUpdate:
+		return ds.draftRepo.RemoveDraft(ctx, msg.UserID, entity.DraftTypeEdit, msg.TriggerObjectID)
+	}
+	return nil
+}
+
+// getDraftObject get the question or answer the draft belongs to. The question is treated as removed
+// if the user can not view it, the same as the question detail.
+func (ds *DraftService) getDraftObject(ctx context.Context, userID string, canViewHidden bool, draftType, objectID string) (
+	object *draftObject, err error) {
+	object = &draftObject{}
+	if draftType == entity.DraftTypeQuestion {
+		return object, nil
+	}
+
+	objectType := constant.QuestionObjectType
+	if draftType == entity.DraftTypeEdit {
+		objectType, err = obj.GetObjectTypeStrByObjectID(objectID)
+		if err != nil {
+			return nil, errors.BadRequest(reason.DraftObjectNotFound)
+		}
+	}
+	questionID := objectID
+	switch objectType {
+	case constant.QuestionObjectType:
+	case constant.AnswerObjectType:
+		answer, exist, err := ds.answerRepo.GetAnswer(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist || answer.Status == entity.AnswerStatusDeleted {
+			object.removed = true
+			return object, nil
+		}
+		object.revisionID = answer.RevisionID
+		questionID = answer.QuestionID
+	default:
+		return nil, errors.BadRequest(reason.DraftObjectNotFound)
+	}
+
+	question, exist, err := ds.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || question.Status == entity.QuestionStatusDeleted {
+		object.removed = true
+		return object, nil
+	}
+	hidden := question.Status == entity.QuestionStatusPending || question.Status == entity.QuestionStatusScheduled ||
+		question.Show == entity.QuestionHide
+	if hidden && !canViewHidden && question.UserID != userID {
+		object.removed = true
+		return object, nil
+	}
+	object.questionID = question.ID
+	object.questionTitle = question.Title
+	if objectType == constant.QuestionObjectType && draftType == entity.DraftTypeEdit {
+		object.revisionID = question.RevisionID
+	}
+	return object, nil
+}
+
+func normalizeObjectID(draftType, objectID string) string {
+	if draftType == entity.DraftTypeQuestion {
+		return newQuestionObjectID
+	}
+	return uid.DeShortID(objectID)
+}
+
+func convertDraftItem(draft *entity.Draft, object *draftObject) *schema.DraftItem {
+	item := &schema.DraftItem{
+		ID:             draft.ID,
+		Type:           draft.DraftType,
+		ObjectID:       draft.ObjectID,
+		Title:          draft.Title,
+		Content:        draft.Content,
+		Tags:           make([]string, 0),
+		QuestionID:     object.questionID,
+		QuestionTitle:  object.questionTitle,
+		BaseRevisionID: draft.BaseRevisionID,
+		ObjectRemoved:  object.removed,
+		UpdatedAt:      draft.UpdatedAt.Unix(),
+		ExpiredAt:      draft.ExpiredAt.Unix(),
+	}
+	if draft.DraftType == entity.DraftTypeQuestion {
+		item.ObjectID = ""
+	}
+	_ = json.Unmarshal([]byte(draft.Tags), &item.Tags)
+	if item.Tags == nil {
+		item.Tags = make([]string, 0)
+	}
+	if draft.DraftType == entity.DraftTypeEdit && !object.removed {
+		item.CurrentRevisionID = object.revisionID
+		item.Conflict = hasConflict(draft.BaseRevisionID, object.revisionID)
+	}
+	return item
+}
+
+// hasConflict the post has been edited since the draft started, the posts without any revision never conflict
+func hasConflict(baseRevisionID, currentRevisionID string) bool {
+	if len(currentRevisionID) == 0 || currentRevisionID == "0" {
+		return false
+	}
+	return baseRevisionID != currentRevisionID
+}
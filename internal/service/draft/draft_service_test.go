@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package draft
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type removedDraftRepo struct {
+	DraftRepo
+	removed []string
+}
+
+func (r *removedDraftRepo) RemoveDraft(ctx context.Context, userID, draftType, objectID string) error {
+	r.removed = append(r.removed, userID+":"+draftType+":"+objectID)
+	return nil
+}
+
+func TestDraftService_HandleQuestionCreate(t *testing.T) {
+	draftRepo := &removedDraftRepo{}
+	ds := &DraftService{draftRepo: draftRepo}
+
+	require.NoError(t, ds.handleEvent(context.TODO(), schema.NewEvent(constant.EventQuestionCreate, "1").TID("10")))
+	assert.Equal(t, []string{"1:question:0"}, draftRepo.removed)
+
+	// the scheduled question is published long after it was submitted, the draft now is of another question
+	draftRepo.removed = nil
+	msg := schema.NewEvent(constant.EventQuestionCreate, "1").TID("10").AddExtra(constant.EventExtraScheduled, "true")
+	require.NoError(t, ds.handleEvent(context.TODO(), msg))
+	assert.Empty(t, draftRepo.removed)
+}
+
+func TestConvertDraftItem(t *testing.T) {
+	d := &entity.Draft{
+		ID:             "1",
+		DraftType:      entity.DraftTypeEdit,
+		ObjectID:       "10010000000000001",
+		Tags:           `["go","draft"]`,
+		BaseRevisionID: "100",
+	}
+	item := convertDraftItem(d, &draftObject{questionID: "10010000000000001", revisionID: "100"})
+	assert.False(t, item.Conflict)
+	assert.Equal(t, []string{"go", "draft"}, item.Tags)
+	assert.Equal(t, "100", item.CurrentRevisionID)
+
+	// others have edited the post since the draft started
+	item = convertDraftItem(d, &draftObject{questionID: "10010000000000001", revisionID: "101"})
+	assert.True(t, item.Conflict)
+	assert.Equal(t, "101", item.CurrentRevisionID)
+
+	// the post is removed, there is nothing to conflict with
+	item = convertDraftItem(d, &draftObject{removed: true})
+	assert.False(t, item.Conflict)
+	assert.True(t, item.ObjectRemoved)
+
+	// the answer and question drafts never conflict
+	d.DraftType = entity.DraftTypeAnswer
+	item = convertDraftItem(d, &draftObject{questionID: "10010000000000001"})
+	assert.False(t, item.Conflict)
+	d.DraftType = entity.DraftTypeQuestion
+	d.Tags = "null"
+	item = convertDraftItem(d, &draftObject{})
+	assert.Empty(t, item.ObjectID)
+	assert.NotNil(t, item.Tags)
+}
+
+func TestHasConflict(t *testing.T) {
+	assert.False(t, hasConflict("1", "1"))
+	assert.True(t, hasConflict("1", "2"))
+	assert.True(t, hasConflict("0", "2"))
+	assert.False(t, hasConflict("1", "0"))
+	assert.False(t, hasConflict("1", ""))
+}
@@ -0,0 +1,268 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package email_reply
+
+import (
+	"context"
+	"crypto/subtle"
+	"slices"
+	"strings"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/action"
+	"github.com/apache/answer/internal/service/comment"
+	"github.com/apache/answer/internal/service/comment_common"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/export"
+	"github.com/apache/answer/internal/service/permission"
+	"github.com/apache/answer/internal/service/rank"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/mailreply"
+	"github.com/apache/answer/pkg/obj"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// EmailReplyService posts the replies of the notification emails as comments or answers
+type EmailReplyService struct {
+	emailService          *export.EmailService
+	userRepo              usercommon.UserRepo
+	userRoleRelService    *role.UserRoleRelService
+	rankService           *rank.RankService
+	actionService         *action.CaptchaService
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	commentCommonService  *comment_common.CommentCommonService
+	commentService        *comment.CommentService
+	answerService         *content.AnswerService
+}
+
+// NewEmailReplyService new email reply service
+func NewEmailReplyService(
+	emailService *export.EmailService,
+	userRepo usercommon.UserRepo,
+	userRoleRelService *role.UserRoleRelService,
+	rankService *rank.RankService,
+	actionService *action.CaptchaService,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	commentCommonService *comment_common.CommentCommonService,
+	commentService *comment.CommentService,
+	answerService *content.AnswerService,
+) *EmailReplyService {
+	return &EmailReplyService{
+		emailService:          emailService,
+		userRepo:              userRepo,
+		userRoleRelService:    userRoleRelService,
+		rankService:           rankService,
+		actionService:         actionService,
+		siteInfoCommonService: siteInfoCommonService,
+		commentCommonService:  commentCommonService,
+		commentService:        commentService,
+		answerService:         answerService,
+	}
+}
+
+// CheckInboundSecret checks the secret the mail gateway sends with the email, the emails are refused
+// until the secret is configured
+func (es *EmailReplyService) CheckInboundSecret(ctx context.Context, secret string) (err error) {
+	ec, err := es.emailService.GetEmailConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if len(ec.ReplyInboundSecret) == 0 {
+		return errors.Forbidden(reason.EmailReplyInboundDisabled)
+	}
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(ec.ReplyInboundSecret)) != 1 {
+		return errors.Forbidden(reason.EmailReplyInboundSecretInvalid)
+	}
+	return nil
+}
+
+// Reply posts the reply in the email. The recipient is the address the email is delivered to, if it is empty,
+// the reply address is searched in the recipients of the email.
+func (es *EmailReplyService) Reply(ctx context.Context, msg *mailreply.Message, recipient string) (err error) {
+	recipients := msg.Recipients
+	if len(recipient) > 0 {
+		recipients = []string{recipient}
+	}
+	var userID, objectID string
+	found := false
+	for _, address := range recipients {
+		if userID, objectID, found = es.emailService.ParseReplyAddress(ctx, address); found {
+			break
+		}
+	}
+	if !found {
+		return errors.BadRequest(reason.EmailReplyAddressInvalid)
+	}
+
+	userInfo, exist, err := es.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.Status != entity.UserStatusAvailable || userInfo.MailStatus != entity.EmailStatusAvailable {
+		return errors.Forbidden(reason.EmailReplyUserUnavailable)
+	}
+	// the reply address can be forwarded, only the owner of the address is allowed to reply
+	if !strings.EqualFold(strings.TrimSpace(msg.From), userInfo.EMail) {
+		return errors.Forbidden(reason.EmailReplySenderNotMatch)
+	}
+	text := mailreply.ExtractReply(msg.Text)
+	if len(text) == 0 {
+		return errors.BadRequest(reason.EmailReplyContentEmpty)
+	}
+
+	objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
+	if err != nil {
+		return err
+	}
+	switch objectType {
+	case constant.QuestionObjectType:
+		err = es.addAnswer(ctx, userID, objectID, text)
+	case constant.AnswerObjectType:
+		err = es.addComment(ctx, userID, objectID, "", text)
+	case constant.CommentObjectType:
+		var replyComment *schema.GetCommentResp
+		replyComment, err = es.commentCommonService.GetComment(ctx, objectID)
+		if err != nil {
+			return err
+		}
+		err = es.addComment(ctx, userID, replyComment.ObjectID, replyComment.CommentID, text)
+	default:
+		err = errors.BadRequest(reason.ObjectNotFound)
+	}
+	if err != nil {
+		return err
+	}
+	log.Infof("user %s replied to %s %s by email", userID, objectType, objectID)
+	return nil
+}
+
+func (es *EmailReplyService) addComment(ctx context.Context, userID, objectID, replyCommentID, text string) (err error) {
+	req := &schema.AddCommentReq{
+		ObjectID:       objectID,
+		ReplyCommentID: replyCommentID,
+		OriginalText:   text,
+		UserID:         userID,
+	}
+	if _, err = validator.GetValidatorByLang(handler.GetLangByCtx(ctx)).Check(req); err != nil {
+		return err
+	}
+
+	canList, err := es.rankService.CheckOperationPermissions(ctx, userID, []string{
+		permission.CommentAdd,
+		permission.CommentEdit,
+		permission.CommentDelete,
+		permission.LinkUrlLimit,
+	})
+	if err != nil {
+		return err
+	}
+	req.CanAdd = canList[0]
+	req.CanEdit = canList[1]
+	req.CanDelete = canList[2]
+	if !req.CanAdd {
+		return errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+	recordAction, err := es.checkCaptcha(ctx, entity.CaptchaActionComment, userID, canList[3])
+	if err != nil {
+		return err
+	}
+
+	if _, err = es.commentService.AddComment(ctx, req); err != nil {
+		return err
+	}
+	if recordAction {
+		_, _ = es.actionService.ActionRecordAdd(ctx, entity.CaptchaActionComment, userID)
+	}
+	return nil
+}
+
+func (es *EmailReplyService) addAnswer(ctx context.Context, userID, questionID, text string) (err error) {
+	req := &schema.AnswerAddReq{
+		QuestionID: questionID,
+		Content:    text,
+		UserID:     userID,
+	}
+	if _, err = validator.GetValidatorByLang(handler.GetLangByCtx(ctx)).Check(req); err != nil {
+		return err
+	}
+
+	canList, err := es.rankService.CheckOperationPermissions(ctx, userID, []string{
+		permission.AnswerAdd,
+		permission.LinkUrlLimit,
+	})
+	if err != nil {
+		return err
+	}
+	if !canList[0] {
+		return errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+	recordAction, err := es.checkCaptcha(ctx, entity.CaptchaActionAnswer, userID, canList[1])
+	if err != nil {
+		return err
+	}
+
+	write, err := es.siteInfoCommonService.GetSiteWrite(ctx)
+	if err != nil {
+		return err
+	}
+	if write.RestrictAnswer {
+		ids, err := es.answerService.GetCountByUserIDQuestionID(ctx, userID, questionID)
+		if err != nil {
+			return err
+		}
+		if len(ids) >= 1 {
+			return errors.Forbidden(reason.AnswerRestrictAnswer)
+		}
+	}
+
+	if _, err = es.answerService.Insert(ctx, req); err != nil {
+		return err
+	}
+	if recordAction {
+		_, _ = es.actionService.ActionRecordAdd(ctx, entity.CaptchaActionAnswer, userID)
+	}
+	return nil
+}
+
+// checkCaptcha the email can not carry a captcha, so the reply is rejected if the user is asked for one.
+// It returns whether the action should be recorded, same as posting on the site.
+func (es *EmailReplyService) checkCaptcha(ctx context.Context, actionType, userID string, linkUrlLimitUser bool) (
+	recordAction bool, err error) {
+	roleIDs, err := es.userRoleRelService.GetUserRoleIDs(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	isAdmin := slices.Contains(roleIDs, role.RoleAdminID) || slices.Contains(roleIDs, role.RoleModeratorID)
+	if isAdmin && linkUrlLimitUser {
+		return false, nil
+	}
+	if !es.actionService.ValidationStrategy(ctx, userID, actionType) {
+		return false, errors.Forbidden(reason.EmailReplyCaptchaRequired)
+	}
+	return true, nil
+}
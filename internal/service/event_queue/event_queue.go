@@ -21,49 +21,125 @@ package event_queue
 
 import (
 	"context"
+	"sync"
 
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/queue_message"
 	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
 )
 
 type EventQueueService interface {
-	Send(ctx context.Context, msg *schema.EventMsg)
-	RegisterHandler(handler func(ctx context.Context, msg *schema.EventMsg) error)
+	Send(ctx context.Context, msg *schema.EventMsg) (err error)
+	SendWithSession(ctx context.Context, session *xorm.Session, msg *schema.EventMsg) (err error)
+	// Subscribe add a named subscriber, every subscriber gets its own copy of the events,
+	// so that a failing subscriber is retried alone without holding the others back
+	Subscribe(subscriber *Subscriber)
 }
 
-type eventQueueService struct {
-	Queue   chan *schema.EventMsg
+// Subscriber a named consumer of the events.
+// The events of the same object are handled in the order they are sent.
+type Subscriber struct {
+	// Name unique name of the subscriber, such as badge, webhook or plugin.xxx
+	Name string
+	// EventTypes the subscribed event types, empty means all
+	EventTypes []constant.EventType
+	// Enabled reports whether the subscriber wants the events sent now, nil means always
+	Enabled func() bool
 	Handler func(ctx context.Context, msg *schema.EventMsg) error
 }
 
-func (ns *eventQueueService) Send(ctx context.Context, msg *schema.EventMsg) {
-	ns.Queue <- msg
+func (s *Subscriber) accept(msg *schema.EventMsg) bool {
+	if s.Enabled != nil && !s.Enabled() {
+		return false
+	}
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, eventType := range s.EventTypes {
+		if eventType == msg.EventType {
+			return true
+		}
+	}
+	return false
+}
+
+type subscription struct {
+	subscriber *Subscriber
+	queue      *queue_message.Queue[schema.EventMsg]
+}
+
+type eventQueueService struct {
+	queueMessageRepo queue_message.QueueMessageRepo
+	lock             sync.RWMutex
+	subscriptions    []*subscription
 }
 
-func (ns *eventQueueService) RegisterHandler(
-	handler func(ctx context.Context, msg *schema.EventMsg) error) {
-	ns.Handler = handler
+// Send add the event to the queue of every subscriber which accepts it, the copies are added all together or not at all
+func (es *eventQueueService) Send(ctx context.Context, msg *schema.EventMsg) (err error) {
+	err = es.send(msg, func(messages ...*entity.QueueMessage) error {
+		return es.queueMessageRepo.AddMessages(ctx, messages...)
+	})
+	if err != nil {
+		log.Errorf("add event %s queue messages failed: %v", msg.EventType, err)
+	}
+	return err
+}
+
+// SendWithSession add the event to the queue of every subscriber which accepts it in the transaction of the caller
+func (es *eventQueueService) SendWithSession(ctx context.Context, session *xorm.Session, msg *schema.EventMsg) (err error) {
+	return es.send(msg, func(messages ...*entity.QueueMessage) error {
+		return es.queueMessageRepo.AddMessagesWithSession(ctx, session, messages...)
+	})
+}
+
+func (es *eventQueueService) send(msg *schema.EventMsg, add func(messages ...*entity.QueueMessage) error) (err error) {
+	es.lock.RLock()
+	defer es.lock.RUnlock()
+	messages := make([]*entity.QueueMessage, 0, len(es.subscriptions))
+	queues := make([]*queue_message.Queue[schema.EventMsg], 0, len(es.subscriptions))
+	for _, s := range es.subscriptions {
+		if !s.subscriber.accept(msg) {
+			continue
+		}
+		message, err := s.queue.NewMessage(msg)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+		queues = append(queues, s.queue)
+	}
+	if err = add(messages...); err != nil {
+		return err
+	}
+	for _, queue := range queues {
+		queue.Notify()
+	}
+	return nil
 }
 
-func (ns *eventQueueService) working() {
-	go func() {
-		for msg := range ns.Queue {
-			log.Debugf("received badge %+v", msg)
-			if ns.Handler == nil {
-				log.Warnf("no handler for badge")
-				continue
-			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
-				log.Error(err)
-			}
+func (es *eventQueueService) Subscribe(subscriber *Subscriber) {
+	es.lock.Lock()
+	defer es.lock.Unlock()
+	for _, s := range es.subscriptions {
+		if s.subscriber.Name == subscriber.Name {
+			log.Errorf("event subscriber %s is already registered", subscriber.Name)
+			return
 		}
-	}()
+	}
+	queue := queue_message.NewOrderedQueue[schema.EventMsg]("event."+subscriber.Name, es.queueMessageRepo,
+		func(msg *schema.EventMsg) string { return msg.GetObjectID() })
+	queue.RegisterHandler(subscriber.Handler)
+	es.subscriptions = append(es.subscriptions, &subscription{subscriber: subscriber, queue: queue})
 }
 
-// NewEventQueueService create a new badge queue service
-func NewEventQueueService() EventQueueService {
-	ns := &eventQueueService{}
-	ns.Queue = make(chan *schema.EventMsg, 128)
-	ns.working()
-	return ns
+// NewEventQueueService create a new event queue service, messages are kept in the database until they are handled
+func NewEventQueueService(queueMessageRepo queue_message.QueueMessageRepo) EventQueueService {
+	es := &eventQueueService{
+		queueMessageRepo: queueMessageRepo,
+	}
+	es.subscribePlugins()
+	return es
 }
@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package event_queue
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/plugin"
+)
+
+// subscribePlugins every event subscriber plugin gets its own subscriber, the events are only sent while it is enabled
+func (es *eventQueueService) subscribePlugins() {
+	_ = plugin.CallEventSubscriber(func(p plugin.EventSubscriber) error {
+		slugName := p.Info().SlugName
+		es.Subscribe(&Subscriber{
+			Name:       "plugin." + slugName,
+			EventTypes: p.SubscribedEvents(),
+			Enabled:    func() bool { return plugin.StatusManager.IsEnabled(slugName) },
+			Handler: func(ctx context.Context, msg *schema.EventMsg) error {
+				return p.HandleEvent(ctx, toPluginEventMessage(msg))
+			},
+		})
+		return nil
+	})
+}
+
+func toPluginEventMessage(msg *schema.EventMsg) *plugin.EventMessage {
+	return &plugin.EventMessage{
+		EventType:      msg.EventType,
+		UserID:         msg.UserID,
+		ObjectID:       msg.GetObjectID(),
+		QuestionID:     msg.QuestionID,
+		QuestionUserID: msg.QuestionUserID,
+		AnswerID:       msg.AnswerID,
+		AnswerUserID:   msg.AnswerUserID,
+		CommentID:      msg.CommentID,
+		CommentUserID:  msg.CommentUserID,
+		ExtraInfo:      msg.ExtraInfo,
+	}
+}
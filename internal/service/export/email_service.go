@@ -20,10 +20,13 @@
 package export
 
 import (
+	"crypto/rand"
 	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"github.com/apache/answer/pkg/display"
+	"html"
 	"mime"
 	"os"
 	"strings"
@@ -36,12 +39,17 @@ import (
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/pkg/mailreply"
+	"github.com/apache/answer/pkg/uid"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 	"golang.org/x/net/context"
 	"gopkg.in/gomail.v2"
 )
 
+// ReplyTokenExpiration the reply address of the notification email can not be used after it
+const ReplyTokenExpiration = 30 * 24 * time.Hour
+
 // EmailService kit service
 type EmailService struct {
 	configService   *config.ConfigService
@@ -78,6 +86,10 @@ type EmailConfig struct {
 	SMTPUsername       string `json:"smtp_username"`
 	SMTPPassword       string `json:"smtp_password"`
 	SMTPAuthentication bool   `json:"smtp_authentication"`
+	// ReplyToAddress the address receiving the replies of the notification emails, replies are disabled if empty
+	ReplyToAddress string `json:"reply_to_address"`
+	// ReplyInboundSecret the secret the mail gateway sends with the replies, the replies are refused if it is empty
+	ReplyInboundSecret string `json:"reply_inbound_secret"`
 }
 
 func (e *EmailConfig) IsSSL() bool {
@@ -117,8 +129,23 @@ func (es *EmailService) SendAndSaveCodeWithTime(
 	es.Send(ctx, toEmailAddr, subject, body)
 }
 
+// SendAndSaveCodeWithReplyTo send email which can be replied to the reply address and save code
+func (es *EmailService) SendAndSaveCodeWithReplyTo(
+	ctx context.Context, userID, toEmailAddr, subject, body, code, codeContent, replyTo string, duration time.Duration) {
+	err := es.emailRepo.SetCode(ctx, userID, code, codeContent, duration)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	es.send(ctx, toEmailAddr, subject, body, replyTo)
+}
+
 // Send email send
 func (es *EmailService) Send(ctx context.Context, toEmailAddr, subject, body string) {
+	es.send(ctx, toEmailAddr, subject, body, "")
+}
+
+func (es *EmailService) send(ctx context.Context, toEmailAddr, subject, body, replyTo string) {
 	log.Infof("try to send email to %s", toEmailAddr)
 	ec, err := es.GetEmailConfig(ctx)
 	if err != nil {
@@ -135,6 +162,9 @@ func (es *EmailService) Send(ctx context.Context, toEmailAddr, subject, body str
 	m.SetHeader("From", fmt.Sprintf("%s <%s>", fromName, ec.FromEmail))
 	m.SetHeader("To", toEmailAddr)
 	m.SetHeader("Subject", subject)
+	if len(replyTo) > 0 {
+		m.SetHeader("Reply-To", replyTo)
+	}
 	m.SetBody("text/html", body)
 
 	d := gomail.NewDialer(ec.SMTPHost, ec.SMTPPort, ec.SMTPUsername, ec.SMTPPassword)
@@ -154,6 +184,58 @@ func (es *EmailService) Send(ctx context.Context, toEmailAddr, subject, body str
 	}
 }
 
+// ReplyToAddress returns the address for the user to reply to the object by email,
+// it is empty if the reply address is not configured.
+func (es *EmailService) ReplyToAddress(ctx context.Context, userID, objectID string) string {
+	ec, err := es.GetEmailConfig(ctx)
+	if err != nil || len(ec.ReplyToAddress) == 0 {
+		return ""
+	}
+	secret, err := es.configService.GetStringValue(ctx, constant.EmailReplySecretKey)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	token, err := mailreply.NewToken(secret, userID, uid.DeShortID(objectID), time.Now())
+	if err != nil {
+		log.Errorf("generate reply token failed: %s", err)
+		return ""
+	}
+	return mailreply.Address(ec.ReplyToAddress, token)
+}
+
+// ParseReplyAddress gets the user and the object from the reply address which the email is sent to
+func (es *EmailService) ParseReplyAddress(ctx context.Context, recipient string) (userID, objectID string, ok bool) {
+	ec, err := es.GetEmailConfig(ctx)
+	if err != nil || len(ec.ReplyToAddress) == 0 {
+		return "", "", false
+	}
+	token, ok := mailreply.TokenFromAddress(ec.ReplyToAddress, recipient)
+	if !ok {
+		return "", "", false
+	}
+	secret, err := es.configService.GetStringValue(ctx, constant.EmailReplySecretKey)
+	if err != nil {
+		log.Error(err)
+		return "", "", false
+	}
+	userID, objectID, err = mailreply.ParseToken(secret, token, ReplyTokenExpiration)
+	if err != nil {
+		log.Debugf("parse reply token failed: %s", err)
+		return "", "", false
+	}
+	return userID, objectID, true
+}
+
+// RotateReplySecret replaces the secret signing the reply addresses, the reply addresses sent before are invalid
+func (es *EmailService) RotateReplySecret(ctx context.Context) (err error) {
+	secret := make([]byte, 32)
+	if _, err = rand.Read(secret); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return es.configService.UpdateConfig(ctx, constant.EmailReplySecretKey, hex.EncodeToString(secret))
+}
+
 // VerifyUrlExpired email send
 func (es *EmailService) VerifyUrlExpired(ctx context.Context, code string) (content string) {
 	content, err := es.emailRepo.VerifyCode(ctx, code)
@@ -241,6 +323,7 @@ func (es *EmailService) NewAnswerTemplate(ctx context.Context, raw *schema.NewAn
 		AnswerUrl:      display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl, raw.QuestionID, raw.QuestionTitle, raw.AnswerID),
 		AnswerSummary:  raw.AnswerSummary,
 		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+		CanReply:       raw.CanReply,
 	}
 
 	lang := handler.GetLangByCtx(ctx)
@@ -266,6 +349,7 @@ func (es *EmailService) NewInviteAnswerTemplate(ctx context.Context, raw *schema
 		QuestionTitle:  raw.QuestionTitle,
 		InviteUrl:      display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, raw.QuestionID, raw.QuestionTitle),
 		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+		CanReply:       raw.CanReply,
 	}
 
 	lang := handler.GetLangByCtx(ctx)
@@ -291,6 +375,7 @@ func (es *EmailService) NewCommentTemplate(ctx context.Context, raw *schema.NewC
 		QuestionTitle:  raw.QuestionTitle,
 		CommentSummary: raw.CommentSummary,
 		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+		CanReply:       raw.CanReply,
 	}
 	templateData.CommentUrl = display.CommentURL(seoInfo.Permalink,
 		siteInfo.SiteUrl, raw.QuestionID, raw.QuestionTitle, raw.AnswerID, raw.CommentID)
@@ -317,6 +402,7 @@ func (es *EmailService) NewQuestionTemplate(ctx context.Context, raw *schema.New
 		QuestionTitle:  raw.QuestionTitle,
 		Tags:           strings.Join(raw.Tags, ", "),
 		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+		CanReply:       raw.CanReply,
 	}
 	templateData.QuestionUrl = display.QuestionURL(
 		seoInfo.Permalink, siteInfo.SiteUrl, raw.QuestionID, raw.QuestionTitle)
@@ -327,6 +413,56 @@ func (es *EmailService) NewQuestionTemplate(ctx context.Context, raw *schema.New
 	return title, body, nil
 }
 
+// DigestTemplate digest template
+func (es *EmailService) DigestTemplate(ctx context.Context, raw *schema.DigestTemplateRawData) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seoInfo, err := es.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.DigestTemplateData{
+		SiteName:       siteInfo.Name,
+		Frequency:      raw.Frequency,
+		SettingsUrl:    fmt.Sprintf("%s/users/settings/notify", siteInfo.SiteUrl),
+		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
+	}
+	for _, n := range raw.Notifications {
+		item := &schema.DigestNotificationTemplateData{
+			DisplayName:   html.EscapeString(n.DisplayName),
+			Action:        n.Action,
+			QuestionTitle: html.EscapeString(n.QuestionTitle),
+		}
+		if len(n.AnswerID) > 0 {
+			item.Url = display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl, n.QuestionID, n.QuestionTitle, n.AnswerID)
+		} else {
+			item.Url = display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, n.QuestionID, n.QuestionTitle)
+		}
+		templateData.Notifications = append(templateData.Notifications, item)
+	}
+	convertQuestions := func(questions []*schema.DigestQuestionRawData) (items []*schema.DigestQuestionTemplateData) {
+		for _, q := range questions {
+			items = append(items, &schema.DigestQuestionTemplateData{
+				QuestionTitle: html.EscapeString(q.QuestionTitle),
+				QuestionUrl:   display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, q.QuestionID, q.QuestionTitle),
+				VoteCount:     q.VoteCount,
+				AnswerCount:   q.AnswerCount,
+			})
+		}
+		return items
+	}
+	templateData.FollowingTagQuestions = convertQuestions(raw.FollowingTagQuestions)
+	templateData.UnansweredQuestions = convertQuestions(raw.UnansweredQuestions)
+
+	lang := handler.GetLangByCtx(ctx)
+	title = translator.TrWithData(lang, constant.EmailTplKeyDigestTitle, templateData)
+	body = translator.TrWithData(lang, constant.EmailTplKeyDigestBody, templateData)
+	return title, body, nil
+}
+
 func (es *EmailService) GetEmailConfig(ctx context.Context) (ec *EmailConfig, err error) {
 	emailConf, err := es.configService.GetStringValue(ctx, constant.EmailConfigKey)
 	if err != nil {
@@ -0,0 +1,345 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package hierarchical_tag
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"gopkg.in/yaml.v3"
+)
+
+var hierarchicalTagCSVHeader = []string{"slug_name", "parent_slug_name", "display_name", "description", "sort_order"}
+
+// ExportHierarchicalTags exports the whole taxonomy, parents are always listed before their children
+func (hs *HierarchicalTagService) ExportHierarchicalTags(ctx context.Context, req *schema.ExportHierarchicalTagsReq) (
+	resp *schema.ExportHierarchicalTagsResp, err error) {
+	tags, err := hs.hierarchicalTagRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slugMapping := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		slugMapping[tag.ID] = tag.SlugName
+	}
+	items := make([]*schema.HierarchicalTagExchangeItem, 0, len(tags))
+	for _, tag := range tags {
+		items = append(items, &schema.HierarchicalTagExchangeItem{
+			SlugName:       tag.SlugName,
+			ParentSlugName: slugMapping[tag.ParentID],
+			DisplayName:    tag.DisplayName,
+			Description:    tag.Description,
+			SortOrder:      tag.SortOrder,
+		})
+	}
+
+	resp = &schema.ExportHierarchicalTagsResp{
+		FileName: fmt.Sprintf("hierarchical-tags-%s.%s", time.Now().Format("20060102150405"), req.Format),
+	}
+	switch req.Format {
+	case schema.HierarchicalTagExchangeFormatJSON:
+		resp.ContentType = "application/json"
+		resp.Content, err = json.MarshalIndent(items, "", "  ")
+	case schema.HierarchicalTagExchangeFormatYAML:
+		resp.ContentType = "application/x-yaml"
+		resp.Content, err = yaml.Marshal(items)
+	case schema.HierarchicalTagExchangeFormatCSV:
+		resp.ContentType = "text/csv"
+		resp.Content, err = encodeHierarchicalTagCSV(items)
+	default:
+		return nil, errors.BadRequest(reason.RequestFormatError)
+	}
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return resp, nil
+}
+
+// ImportHierarchicalTags imports a taxonomy file. Tags are matched by slug name, new ones are created
+// and changed ones are updated, tags absent from the file are kept. Nothing is written when the
+// request is a dry run or when any row is invalid, the diff against the current taxonomy is returned.
+func (hs *HierarchicalTagService) ImportHierarchicalTags(ctx context.Context, req *schema.ImportHierarchicalTagsReq) (
+	resp *schema.ImportHierarchicalTagsResp, err error) {
+	items, err := decodeHierarchicalTagItems(req.Format, req.Content)
+	if err != nil {
+		return nil, errors.BadRequest(reason.HierarchicalTagImportFormatError).WithError(err)
+	}
+
+	existingTags, err := hs.hierarchicalTagRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existingBySlug := make(map[string]*entity.HierarchicalTag, len(existingTags))
+	existingByID := make(map[string]*entity.HierarchicalTag, len(existingTags))
+	for _, tag := range existingTags {
+		existingBySlug[tag.SlugName] = tag
+		existingByID[tag.ID] = tag
+	}
+
+	resp = &schema.ImportHierarchicalTagsResp{
+		DryRun:  req.DryRun,
+		Changes: make([]*schema.HierarchicalTagImportChange, 0),
+	}
+	resp.Problems = hs.checkHierarchicalTagImportItems(ctx, items, existingBySlug, existingByID)
+	if len(resp.Problems) > 0 {
+		return resp, errors.BadRequest(reason.HierarchicalTagImportInvalid)
+	}
+
+	for _, item := range items {
+		tag, ok := existingBySlug[item.SlugName]
+		if !ok {
+			resp.Created++
+			resp.Changes = append(resp.Changes, &schema.HierarchicalTagImportChange{
+				Action: schema.HierarchicalTagImportActionCreate,
+				After:  item,
+			})
+			continue
+		}
+		before := &schema.HierarchicalTagExchangeItem{
+			SlugName:    tag.SlugName,
+			DisplayName: tag.DisplayName,
+			Description: tag.Description,
+			SortOrder:   tag.SortOrder,
+		}
+		if parent, ok := existingByID[tag.ParentID]; ok {
+			before.ParentSlugName = parent.SlugName
+		}
+		if *before == *item {
+			resp.Unchanged++
+			continue
+		}
+		resp.Updated++
+		resp.Changes = append(resp.Changes, &schema.HierarchicalTagImportChange{
+			Action: schema.HierarchicalTagImportActionUpdate,
+			Before: before,
+			After:  item,
+		})
+	}
+	if req.DryRun || len(resp.Changes) == 0 {
+		return resp, nil
+	}
+
+	// reuse the IDs of deleted tags with the same slug name, as slug names are unique
+	slugIDMapping := make(map[string]string, len(existingTags)+len(items))
+	for _, tag := range existingTags {
+		slugIDMapping[tag.SlugName] = tag.ID
+	}
+	createdSlugNames := make([]string, 0, resp.Created)
+	for _, change := range resp.Changes {
+		if change.Action == schema.HierarchicalTagImportActionCreate {
+			createdSlugNames = append(createdSlugNames, change.After.SlugName)
+		}
+	}
+	deletedTags, err := hs.hierarchicalTagRepo.GetDeletedBySlugNames(ctx, createdSlugNames)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range deletedTags {
+		slugIDMapping[tag.SlugName] = tag.ID
+	}
+	for _, slugName := range createdSlugNames {
+		if _, ok := slugIDMapping[slugName]; !ok {
+			slugIDMapping[slugName] = uid.ID().String()
+		}
+	}
+
+	tags := make([]*entity.HierarchicalTag, 0, len(resp.Changes))
+	for _, change := range resp.Changes {
+		item := change.After
+		tag := &entity.HierarchicalTag{
+			ID:          slugIDMapping[item.SlugName],
+			Name:        item.SlugName,
+			SlugName:    item.SlugName,
+			ParentID:    slugIDMapping[item.ParentSlugName],
+			DisplayName: item.DisplayName,
+			Description: item.Description,
+			SortOrder:   item.SortOrder,
+		}
+		if existing, ok := existingBySlug[item.SlugName]; ok {
+			tag.Name = existing.Name
+		}
+		tags = append(tags, tag)
+	}
+	if err = hs.hierarchicalTagRepo.Import(ctx, tags); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// checkHierarchicalTagImportItems validates every row and checks the resulting tree has no cycle
+func (hs *HierarchicalTagService) checkHierarchicalTagImportItems(ctx context.Context,
+	items []*schema.HierarchicalTagExchangeItem, existingBySlug map[string]*entity.HierarchicalTag,
+	existingByID map[string]*entity.HierarchicalTag) (problems []*schema.HierarchicalTagImportProblem) {
+	lang := handler.GetLangByCtx(ctx)
+	addProblem := func(row int, slugName, reasonKey string) {
+		problems = append(problems, &schema.HierarchicalTagImportProblem{
+			Row:      row,
+			SlugName: slugName,
+			Reason:   translator.Tr(lang, reasonKey),
+		})
+	}
+
+	// parent slug name of every tag after the import
+	parentMapping := make(map[string]string, len(existingBySlug)+len(items))
+	for slugName, tag := range existingBySlug {
+		if parent, ok := existingByID[tag.ParentID]; ok {
+			parentMapping[slugName] = parent.SlugName
+		} else {
+			parentMapping[slugName] = ""
+		}
+	}
+	itemRows := make(map[string]int, len(items))
+	for i, item := range items {
+		row := i + 1
+		switch {
+		case len(item.SlugName) == 0:
+			addProblem(row, item.SlugName, reason.HierarchicalTagSlugNameRequired)
+			continue
+		case len(item.DisplayName) == 0:
+			addProblem(row, item.SlugName, reason.HierarchicalTagDisplayNameRequired)
+			continue
+		}
+		if _, ok := itemRows[item.SlugName]; ok {
+			addProblem(row, item.SlugName, reason.HierarchicalTagSlugNameDuplicate)
+			continue
+		}
+		itemRows[item.SlugName] = row
+		parentMapping[item.SlugName] = item.ParentSlugName
+	}
+
+	for _, item := range items {
+		row, ok := itemRows[item.SlugName]
+		if !ok || len(item.ParentSlugName) == 0 {
+			continue
+		}
+		if _, ok := parentMapping[item.ParentSlugName]; !ok {
+			addProblem(row, item.SlugName, reason.HierarchicalTagParentNotFound)
+			continue
+		}
+		// walk up to the root, meeting the tag itself means a cycle
+		ancestor := item.ParentSlugName
+		for depth := 0; len(ancestor) > 0 && depth <= len(parentMapping); depth++ {
+			if ancestor == item.SlugName {
+				addProblem(row, item.SlugName, reason.HierarchicalTagCannotMoveUnderDescendant)
+				break
+			}
+			ancestor = parentMapping[ancestor]
+		}
+	}
+	return problems
+}
+
+func encodeHierarchicalTagCSV(items []*schema.HierarchicalTagExchangeItem) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	if err := w.Write(hierarchicalTagCSVHeader); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		record := []string{item.SlugName, item.ParentSlugName, item.DisplayName, item.Description,
+			strconv.Itoa(item.SortOrder)}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func decodeHierarchicalTagItems(format, content string) (items []*schema.HierarchicalTagExchangeItem, err error) {
+	switch format {
+	case schema.HierarchicalTagExchangeFormatJSON:
+		err = json.Unmarshal([]byte(content), &items)
+	case schema.HierarchicalTagExchangeFormatYAML:
+		err = yaml.Unmarshal([]byte(content), &items)
+	case schema.HierarchicalTagExchangeFormatCSV:
+		items, err = decodeHierarchicalTagCSV(content)
+	default:
+		err = fmt.Errorf("unsupported format %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		item.SlugName = strings.TrimSpace(item.SlugName)
+		item.ParentSlugName = strings.TrimSpace(item.ParentSlugName)
+		item.DisplayName = strings.TrimSpace(item.DisplayName)
+	}
+	return items, nil
+}
+
+// decodeHierarchicalTagCSV decodes a csv file whose first line is the header, columns may be in any order
+func decodeHierarchicalTagCSV(content string) (items []*schema.HierarchicalTagExchangeItem, err error) {
+	r := csv.NewReader(strings.NewReader(content))
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["slug_name"]; !ok {
+		return nil, fmt.Errorf("missing slug_name column")
+	}
+	get := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		item := &schema.HierarchicalTagExchangeItem{
+			SlugName:       get(record, "slug_name"),
+			ParentSlugName: get(record, "parent_slug_name"),
+			DisplayName:    get(record, "display_name"),
+			Description:    get(record, "description"),
+		}
+		if sortOrder := strings.TrimSpace(get(record, "sort_order")); len(sortOrder) > 0 {
+			if item.SortOrder, err = strconv.Atoi(sortOrder); err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,234 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package hierarchical_tag
+
+import (
+	"context"
+	"slices"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// GetHierarchicalTagPermission gets the filing settings and the moderators of a hierarchical tag
+func (hs *HierarchicalTagService) GetHierarchicalTagPermission(ctx context.Context, req *schema.GetHierarchicalTagPermissionReq) (
+	resp *schema.HierarchicalTagPermissionResp, err error) {
+	tag, exist, err := hs.hierarchicalTagRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.HierarchicalTagNotFound)
+	}
+
+	resp = &schema.HierarchicalTagPermissionResp{
+		ID:         tag.ID,
+		LeafOnly:   tag.LeafOnly,
+		RoleIDs:    make([]int, 0),
+		Moderators: make([]*schema.UserBasicInfo, 0),
+	}
+	roleRels, err := hs.hierarchicalTagRepo.GetRoleRels(ctx, []string{tag.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range roleRels {
+		resp.RoleIDs = append(resp.RoleIDs, rel.RoleID)
+	}
+
+	moderators, err := hs.hierarchicalTagRepo.GetModerators(ctx, tag.ID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(moderators))
+	for _, moderator := range moderators {
+		userIDs = append(userIDs, moderator.UserID)
+	}
+	userInfoMapping, err := hs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, userID := range userIDs {
+		if userInfo, ok := userInfoMapping[userID]; ok {
+			resp.Moderators = append(resp.Moderators, userInfo)
+		}
+	}
+	return resp, nil
+}
+
+// UpdateHierarchicalTagPermission updates the filing settings and the moderators of a hierarchical tag
+func (hs *HierarchicalTagService) UpdateHierarchicalTagPermission(ctx context.Context, req *schema.UpdateHierarchicalTagPermissionReq) error {
+	_, exist, err := hs.hierarchicalTagRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.HierarchicalTagNotFound)
+	}
+
+	roleIDs := converter.UniqueArray(req.RoleIDs)
+	roleMapping, err := hs.roleService.GetRoleMapping(ctx)
+	if err != nil {
+		return err
+	}
+	for _, roleID := range roleIDs {
+		if _, ok := roleMapping[roleID]; !ok {
+			return errors.BadRequest(reason.HierarchicalTagRoleNotFound)
+		}
+	}
+
+	moderatorUserIDs := converter.UniqueArray(req.ModeratorUserIDs)
+	userInfoMapping, err := hs.userCommon.BatchUserBasicInfoByID(ctx, moderatorUserIDs)
+	if err != nil {
+		return err
+	}
+	for _, userID := range moderatorUserIDs {
+		if _, ok := userInfoMapping[userID]; !ok {
+			return errors.BadRequest(reason.HierarchicalTagModeratorNotFound)
+		}
+	}
+	return hs.hierarchicalTagRepo.UpdatePermission(ctx, req.ID, req.LeafOnly, roleIDs, moderatorUserIDs)
+}
+
+// CanFileQuestion checks whether the user can file questions under the hierarchical tags.
+// A leaf-only tag with children never accepts questions. Roles allowed on the tag and on every
+// ancestor must include one of the user's roles, built-in or custom, unless the user is an admin or moderates the subtree.
+func (hs *HierarchicalTagService) CanFileQuestion(ctx context.Context, userID string, tagIDs []string) (
+	can bool, denyReason string, err error) {
+	if len(tagIDs) == 0 {
+		return true, "", nil
+	}
+	tags, err := hs.hierarchicalTagRepo.GetAll(ctx)
+	if err != nil {
+		return false, "", err
+	}
+	tagMapping := make(map[string]*entity.HierarchicalTag, len(tags))
+	hasChildren := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tagMapping[tag.ID] = tag
+		hasChildren[tag.ParentID] = true
+	}
+
+	roleIDs, err := hs.userRoleRelService.GetUserRoleIDs(ctx, userID)
+	if err != nil {
+		return false, "", err
+	}
+	isAdmin := slices.Contains(roleIDs, role.RoleAdminID)
+	moderated, err := hs.getModeratedTagIDMapping(ctx, userID)
+	if err != nil {
+		return false, "", err
+	}
+	roleRels, err := hs.hierarchicalTagRepo.GetRoleRels(ctx, nil)
+	if err != nil {
+		return false, "", err
+	}
+	allowedRoles := make(map[string]map[int]bool)
+	for _, rel := range roleRels {
+		if allowedRoles[rel.HierarchicalTagID] == nil {
+			allowedRoles[rel.HierarchicalTagID] = make(map[int]bool)
+		}
+		allowedRoles[rel.HierarchicalTagID][rel.RoleID] = true
+	}
+
+	for _, tagID := range tagIDs {
+		tag, ok := tagMapping[tagID]
+		if !ok {
+			return false, reason.HierarchicalTagNotFound, nil
+		}
+		if tag.LeafOnly && hasChildren[tag.ID] {
+			return false, reason.HierarchicalTagLeafOnly, nil
+		}
+		if isAdmin {
+			continue
+		}
+		path := ancestorsOf(tagMapping, tag)
+		if isModeratedPath(moderated, path) {
+			continue
+		}
+		for _, node := range path {
+			if roles, ok := allowedRoles[node.ID]; ok && !slices.ContainsFunc(roleIDs, func(roleID int) bool { return roles[roleID] }) {
+				return false, reason.HierarchicalTagFilingForbidden, nil
+			}
+		}
+	}
+	return true, "", nil
+}
+
+// IsQuestionModerator checks whether the user moderates a subtree containing any hierarchical tag of the question
+func (hs *HierarchicalTagService) IsQuestionModerator(ctx context.Context, userID, questionID string) (bool, error) {
+	if len(userID) == 0 {
+		return false, nil
+	}
+	moderated, err := hs.getModeratedTagIDMapping(ctx, userID)
+	if err != nil || len(moderated) == 0 {
+		return false, err
+	}
+	tagIDs, err := hs.GetQuestionHierarchicalTagIDs(ctx, questionID)
+	if err != nil || len(tagIDs) == 0 {
+		return false, err
+	}
+	tags, err := hs.hierarchicalTagRepo.GetAll(ctx)
+	if err != nil {
+		return false, err
+	}
+	tagMapping := make(map[string]*entity.HierarchicalTag, len(tags))
+	for _, tag := range tags {
+		tagMapping[tag.ID] = tag
+	}
+	for _, tagID := range tagIDs {
+		tag, ok := tagMapping[tagID]
+		if ok && isModeratedPath(moderated, ancestorsOf(tagMapping, tag)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (hs *HierarchicalTagService) getModeratedTagIDMapping(ctx context.Context, userID string) (map[string]bool, error) {
+	tagIDs, err := hs.hierarchicalTagRepo.GetModeratedTagIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	moderated := make(map[string]bool, len(tagIDs))
+	for _, tagID := range tagIDs {
+		moderated[tagID] = true
+	}
+	return moderated, nil
+}
+
+// ancestorsOf returns the tag followed by its ancestors up to the root
+func ancestorsOf(tagMapping map[string]*entity.HierarchicalTag, tag *entity.HierarchicalTag) (path []*entity.HierarchicalTag) {
+	for node := tag; node != nil && len(path) <= len(tagMapping); node = tagMapping[node.ParentID] {
+		path = append(path, node)
+	}
+	return path
+}
+
+func isModeratedPath(moderated map[string]bool, path []*entity.HierarchicalTag) bool {
+	for _, node := range path {
+		if moderated[node.ID] {
+			return true
+		}
+	}
+	return false
+}
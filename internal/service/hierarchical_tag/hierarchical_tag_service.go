@@ -0,0 +1,399 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package hierarchical_tag
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/role"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// HierarchicalTagRepo hierarchical tag repository
+type HierarchicalTagRepo interface {
+	GetByParentID(ctx context.Context, parentID string) (tags []*entity.HierarchicalTag, err error)
+	GetAll(ctx context.Context) (tags []*entity.HierarchicalTag, err error)
+	GetDeletedBySlugNames(ctx context.Context, slugNames []string) (tags []*entity.HierarchicalTag, err error)
+	GetByID(ctx context.Context, id string) (tag *entity.HierarchicalTag, exist bool, err error)
+	GetByIDs(ctx context.Context, ids []string) (tags []*entity.HierarchicalTag, err error)
+	GetBySlugName(ctx context.Context, slugName string) (tag *entity.HierarchicalTag, exist bool, err error)
+	Create(ctx context.Context, tag *entity.HierarchicalTag) (err error)
+	Update(ctx context.Context, tag *entity.HierarchicalTag) (err error)
+	Move(ctx context.Context, tagID, parentID string) (err error)
+	Delete(ctx context.Context, tagID string) (err error)
+	Import(ctx context.Context, tags []*entity.HierarchicalTag) (err error)
+	Reorder(ctx context.Context, parentID string, tagIDs []string) (err error)
+	UpdatePermission(ctx context.Context, tagID string, leafOnly bool, roleIDs []int, moderatorUserIDs []string) (err error)
+	GetRoleRels(ctx context.Context, tagIDs []string) (rels []*entity.HierarchicalTagRoleRel, err error)
+	GetModerators(ctx context.Context, tagID string) (moderators []*entity.HierarchicalTagModerator, err error)
+	GetModeratedTagIDs(ctx context.Context, userID string) (tagIDs []string, err error)
+	GetPath(ctx context.Context, tagID string) (path string, tags []*entity.HierarchicalTag, err error)
+	HasChildren(ctx context.Context, tagID string) (bool, error)
+	CreateQuestionTagRel(ctx context.Context, questionID, tagID string) (err error)
+	GetQuestionTagRels(ctx context.Context, questionID string) (rels []*entity.QuestionHierarchicalTagRel, err error)
+	RemoveQuestionTagRels(ctx context.Context, questionID string) (err error)
+}
+
+// HierarchicalTagService hierarchical tag service
+type HierarchicalTagService struct {
+	hierarchicalTagRepo HierarchicalTagRepo
+	roleService         *role.RoleService
+	userRoleRelService  *role.UserRoleRelService
+	userCommon          *usercommon.UserCommon
+}
+
+// NewHierarchicalTagService new hierarchical tag service
+func NewHierarchicalTagService(
+	hierarchicalTagRepo HierarchicalTagRepo,
+	roleService *role.RoleService,
+	userRoleRelService *role.UserRoleRelService,
+	userCommon *usercommon.UserCommon,
+) *HierarchicalTagService {
+	return &HierarchicalTagService{
+		hierarchicalTagRepo: hierarchicalTagRepo,
+		roleService:         roleService,
+		userRoleRelService:  userRoleRelService,
+		userCommon:          userCommon,
+	}
+}
+
+// GetHierarchicalTags gets hierarchical tags by parent ID
+func (hs *HierarchicalTagService) GetHierarchicalTags(ctx context.Context, req *schema.GetHierarchicalTagsReq) (*schema.GetHierarchicalTagsResp, error) {
+	tags, err := hs.hierarchicalTagRepo.GetByParentID(ctx, req.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &schema.GetHierarchicalTagsResp{
+		Tags: make([]*schema.HierarchicalTagItem, 0, len(tags)),
+	}
+
+	for _, tag := range tags {
+		hasChildren, err := hs.hierarchicalTagRepo.HasChildren(ctx, tag.ID)
+		if err != nil {
+			log.Error(err)
+			hasChildren = false
+		}
+
+		tagItem := &schema.HierarchicalTagItem{
+			ID:          tag.ID,
+			Name:        tag.Name,
+			SlugName:    tag.SlugName,
+			DisplayName: tag.DisplayName,
+			ParentID:    tag.ParentID,
+			Level:       tag.Level,
+			Path:        tag.Path,
+			Description: tag.Description,
+			SortOrder:   tag.SortOrder,
+			LeafOnly:    tag.LeafOnly,
+			HasChildren: hasChildren,
+		}
+		resp.Tags = append(resp.Tags, tagItem)
+	}
+
+	return resp, nil
+}
+
+// GetHierarchicalTagTree gets the whole hierarchical tag tree built from a single query
+func (hs *HierarchicalTagService) GetHierarchicalTagTree(ctx context.Context) ([]*entity.HierarchicalTagWithChildren, error) {
+	tags, err := hs.hierarchicalTagRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]*entity.HierarchicalTagWithChildren, len(tags))
+	for _, tag := range tags {
+		nodes[tag.ID] = &entity.HierarchicalTagWithChildren{HierarchicalTag: *tag}
+	}
+	roots := make([]*entity.HierarchicalTagWithChildren, 0)
+	// the nodes are all built above, so the children are linked whatever the order of the tags is,
+	// the order only keeps the children sorted as the query returns them
+	for _, tag := range tags {
+		node := nodes[tag.ID]
+		parent, ok := nodes[tag.ParentID]
+		if !ok {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	return roots, nil
+}
+
+// CreateHierarchicalTag creates a new hierarchical tag
+func (hs *HierarchicalTagService) CreateHierarchicalTag(ctx context.Context, req *schema.CreateHierarchicalTagReq) error {
+	tag := &entity.HierarchicalTag{
+		ID:          uid.ID().String(),
+		Name:        req.Name,
+		SlugName:    req.SlugName,
+		ParentID:    req.ParentID,
+		DisplayName: req.DisplayName,
+		Description: req.Description,
+		Status:      entity.HierarchicalTagStatusAvailable,
+		SortOrder:   0,
+	}
+
+	return hs.hierarchicalTagRepo.Create(ctx, tag)
+}
+
+// UpdateHierarchicalTag updates hierarchical tag
+func (hs *HierarchicalTagService) UpdateHierarchicalTag(ctx context.Context, req *schema.UpdateHierarchicalTagReq) error {
+	tag, exist, err := hs.hierarchicalTagRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.HierarchicalTagNotFound)
+	}
+
+	tag.Name = req.Name
+	tag.SlugName = req.SlugName
+	tag.DisplayName = req.DisplayName
+	tag.Description = req.Description
+
+	return hs.hierarchicalTagRepo.Update(ctx, tag)
+}
+
+// DeleteHierarchicalTag soft deletes a hierarchical tag and its whole subtree
+func (hs *HierarchicalTagService) DeleteHierarchicalTag(ctx context.Context, req *schema.DeleteHierarchicalTagReq) error {
+	return hs.hierarchicalTagRepo.Delete(ctx, req.ID)
+}
+
+// MoveHierarchicalTag moves a hierarchical tag and its subtree under a new parent
+func (hs *HierarchicalTagService) MoveHierarchicalTag(ctx context.Context, req *schema.MoveHierarchicalTagReq) error {
+	return hs.hierarchicalTagRepo.Move(ctx, req.ID, req.ParentID)
+}
+
+// ReorderHierarchicalTags sorts the children of a hierarchical tag
+func (hs *HierarchicalTagService) ReorderHierarchicalTags(ctx context.Context, req *schema.ReorderHierarchicalTagsReq) error {
+	return hs.hierarchicalTagRepo.Reorder(ctx, req.ParentID, req.TagIDs)
+}
+
+// GetHierarchicalTagPath gets the full path of a hierarchical tag
+func (hs *HierarchicalTagService) GetHierarchicalTagPath(ctx context.Context, req *schema.HierarchicalTagPathReq) (*schema.HierarchicalTagPathResp, error) {
+	path, tags, err := hs.hierarchicalTagRepo.GetPath(ctx, req.TagID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &schema.HierarchicalTagPathResp{
+		Path:        path,
+		DisplayPath: path,
+		Tags:        make([]*schema.HierarchicalTagItem, 0, len(tags)),
+	}
+
+	for _, tag := range tags {
+		hasChildren, err := hs.hierarchicalTagRepo.HasChildren(ctx, tag.ID)
+		if err != nil {
+			log.Error(err)
+			hasChildren = false
+		}
+
+		tagItem := &schema.HierarchicalTagItem{
+			ID:          tag.ID,
+			Name:        tag.Name,
+			SlugName:    tag.SlugName,
+			DisplayName: tag.DisplayName,
+			ParentID:    tag.ParentID,
+			Level:       tag.Level,
+			Path:        tag.Path,
+			Description: tag.Description,
+			SortOrder:   tag.SortOrder,
+			LeafOnly:    tag.LeafOnly,
+			HasChildren: hasChildren,
+		}
+		resp.Tags = append(resp.Tags, tagItem)
+	}
+
+	return resp, nil
+}
+
+// AddHierarchicalTagsToQuestion adds hierarchical tags to a question
+func (hs *HierarchicalTagService) AddHierarchicalTagsToQuestion(ctx context.Context, questionID string, tagIDs []string) error {
+	// Remove existing relationships
+	err := hs.hierarchicalTagRepo.RemoveQuestionTagRels(ctx, questionID)
+	if err != nil {
+		return err
+	}
+
+	// Add new relationships
+	for _, tagID := range tagIDs {
+		err = hs.hierarchicalTagRepo.CreateQuestionTagRel(ctx, questionID, tagID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetQuestionHierarchicalTags gets hierarchical tags for a question
+func (hs *HierarchicalTagService) GetQuestionHierarchicalTags(ctx context.Context, questionID string) ([]*schema.HierarchicalTagItem, error) {
+	rels, err := hs.hierarchicalTagRepo.GetQuestionTagRels(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]*schema.HierarchicalTagItem, 0, len(rels))
+	for _, rel := range rels {
+		tag, exist, err := hs.hierarchicalTagRepo.GetByID(ctx, rel.HierarchicalTagID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			continue
+		}
+
+		hasChildren, err := hs.hierarchicalTagRepo.HasChildren(ctx, tag.ID)
+		if err != nil {
+			log.Error(err)
+			hasChildren = false
+		}
+
+		tagItem := &schema.HierarchicalTagItem{
+			ID:          tag.ID,
+			Name:        tag.Name,
+			SlugName:    tag.SlugName,
+			DisplayName: tag.DisplayName,
+			ParentID:    tag.ParentID,
+			Level:       tag.Level,
+			Path:        rel.HierarchicalTagPath,
+			Description: tag.Description,
+			SortOrder:   tag.SortOrder,
+			LeafOnly:    tag.LeafOnly,
+			HasChildren: hasChildren,
+		}
+		tags = append(tags, tagItem)
+	}
+
+	return tags, nil
+}
+
+// GetHierarchicalTagsForRevision gets the available hierarchical tags by IDs in the given order,
+// exist is false if any of them is not available.
+func (hs *HierarchicalTagService) GetHierarchicalTagsForRevision(ctx context.Context, tagIDs []string) (
+	tags []*entity.HierarchicalTagSimpleInfoForRevision, exist bool, err error) {
+	tags = make([]*entity.HierarchicalTagSimpleInfoForRevision, 0, len(tagIDs))
+	if len(tagIDs) == 0 {
+		return tags, true, nil
+	}
+	tagList, err := hs.hierarchicalTagRepo.GetByIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, false, err
+	}
+	tagMapping := make(map[string]*entity.HierarchicalTag, len(tagList))
+	for _, tag := range tagList {
+		tagMapping[tag.ID] = tag
+	}
+	exist = true
+	seen := make(map[string]bool, len(tagIDs))
+	for _, tagID := range tagIDs {
+		tag, ok := tagMapping[tagID]
+		if !ok {
+			exist = false
+			continue
+		}
+		if seen[tagID] {
+			continue
+		}
+		seen[tagID] = true
+		tags = append(tags, &entity.HierarchicalTagSimpleInfoForRevision{
+			ID:          tag.ID,
+			SlugName:    tag.SlugName,
+			DisplayName: tag.DisplayName,
+			Path:        tag.Path,
+		})
+	}
+	return tags, exist, nil
+}
+
+// GetQuestionHierarchicalTagIDs gets the IDs of the hierarchical tags attached to a question
+func (hs *HierarchicalTagService) GetQuestionHierarchicalTagIDs(ctx context.Context, questionID string) (tagIDs []string, err error) {
+	rels, err := hs.hierarchicalTagRepo.GetQuestionTagRels(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs = make([]string, 0, len(rels))
+	for _, rel := range rels {
+		tagIDs = append(tagIDs, rel.HierarchicalTagID)
+	}
+	return tagIDs, nil
+}
+
+// GetSubtreeIDsBySlugPath gets the IDs of the tags matched by a slug path such as "backend/go"
+// together with all of their descendants. The path is matched against the end of the tag's
+// ancestry, so "go" matches every tag with that slug name and "backend/go" only those under backend.
+func (hs *HierarchicalTagService) GetSubtreeIDsBySlugPath(ctx context.Context, slugPath string) (tagIDs []string, err error) {
+	slugNames := make([]string, 0)
+	for _, slugName := range strings.Split(strings.ToLower(slugPath), "/") {
+		if slugName = strings.TrimSpace(slugName); len(slugName) > 0 {
+			slugNames = append(slugNames, slugName)
+		}
+	}
+	tagIDs = make([]string, 0)
+	if len(slugNames) == 0 {
+		return tagIDs, nil
+	}
+	tags, err := hs.hierarchicalTagRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tagMapping := make(map[string]*entity.HierarchicalTag, len(tags))
+	children := make(map[string][]string, len(tags))
+	for _, tag := range tags {
+		tagMapping[tag.ID] = tag
+		children[tag.ParentID] = append(children[tag.ParentID], tag.ID)
+	}
+	for _, tag := range tags {
+		matched, current := true, tag
+		for i := len(slugNames) - 1; i >= 0; i-- {
+			if current == nil || current.SlugName != slugNames[i] {
+				matched = false
+				break
+			}
+			current = tagMapping[current.ParentID]
+		}
+		if matched {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+	}
+
+	seen := make(map[string]bool, len(tagIDs))
+	for _, tagID := range tagIDs {
+		seen[tagID] = true
+	}
+	for i := 0; i < len(tagIDs); i++ {
+		for _, childID := range children[tagIDs[i]] {
+			if !seen[childID] {
+				seen[childID] = true
+				tagIDs = append(tagIDs, childID)
+			}
+		}
+	}
+	return tagIDs, nil
+}
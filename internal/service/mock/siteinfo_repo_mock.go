@@ -87,6 +87,20 @@ func (mr *MockSiteInfoRepoMockRecorder) SaveByType(ctx, siteType, data any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveByType", reflect.TypeOf((*MockSiteInfoRepo)(nil).SaveByType), ctx, siteType, data)
 }
 
+// SaveSiteSettings mocks base method.
+func (m *MockSiteInfoRepo) SaveSiteSettings(ctx context.Context, settings *schema.SiteSettingsRows) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSiteSettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSiteSettings indicates an expected call of SaveSiteSettings.
+func (mr *MockSiteInfoRepoMockRecorder) SaveSiteSettings(ctx, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSiteSettings", reflect.TypeOf((*MockSiteInfoRepo)(nil).SaveSiteSettings), ctx, settings)
+}
+
 // MockSiteInfoCommonService is a mock of SiteInfoCommonService interface.
 type MockSiteInfoCommonService struct {
 	ctrl     *gomock.Controller
@@ -243,6 +257,36 @@ func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteLogin(ctx any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteLogin", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteLogin), ctx)
 }
 
+// GetSiteRateLimit mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteRateLimit(ctx context.Context) (*schema.SiteRateLimitResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteRateLimit", ctx)
+	ret0, _ := ret[0].(*schema.SiteRateLimitResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteRateLimit indicates an expected call of GetSiteRateLimit.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteRateLimit(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteRateLimit", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteRateLimit), ctx)
+}
+
+// GetSiteReview mocks base method.
+func (m *MockSiteInfoCommonService) GetSiteReview(ctx context.Context) (*schema.SiteReviewResp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteReview", ctx)
+	ret0, _ := ret[0].(*schema.SiteReviewResp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiteReview indicates an expected call of GetSiteReview.
+func (mr *MockSiteInfoCommonServiceMockRecorder) GetSiteReview(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteReview", reflect.TypeOf((*MockSiteInfoCommonService)(nil).GetSiteReview), ctx)
+}
+
 // GetSiteSeo mocks base method.
 func (m *MockSiteInfoCommonService) GetSiteSeo(ctx context.Context) (*schema.SiteSeoResp, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,358 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/content"
+	"github.com/apache/answer/internal/service/moderation_common"
+	"github.com/apache/answer/internal/service/object_info"
+	"github.com/apache/answer/internal/service/report"
+	"github.com/apache/answer/internal/service/report_common"
+	"github.com/apache/answer/internal/service/review"
+	"github.com/apache/answer/internal/service/revision"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/htmltext"
+	myErrors "github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// claimLease the claim of the item is released automatically after the duration
+	claimLease = 15 * time.Minute
+	// defaultQueuePageSize the default page size of the queue
+	defaultQueuePageSize = 20
+)
+
+// ModerationService the queue of the reports, the posts waiting for review and the unreviewed revisions
+type ModerationService struct {
+	moderationRepo    moderation_common.ModerationRepo
+	reportRepo        report_common.ReportRepo
+	reviewRepo        review.ReviewRepo
+	revisionRepo      revision.RevisionRepo
+	objectInfoService *object_info.ObjService
+	userCommon        *usercommon.UserCommon
+	reportService     *report.ReportService
+	reviewService     *review.ReviewService
+	revisionService   *content.RevisionService
+}
+
+// NewModerationService new moderation service
+func NewModerationService(
+	moderationRepo moderation_common.ModerationRepo,
+	reportRepo report_common.ReportRepo,
+	reviewRepo review.ReviewRepo,
+	revisionRepo revision.RevisionRepo,
+	objectInfoService *object_info.ObjService,
+	userCommon *usercommon.UserCommon,
+	reportService *report.ReportService,
+	reviewService *review.ReviewService,
+	revisionService *content.RevisionService,
+) *ModerationService {
+	return &ModerationService{
+		moderationRepo:    moderationRepo,
+		reportRepo:        reportRepo,
+		reviewRepo:        reviewRepo,
+		revisionRepo:      revisionRepo,
+		objectInfoService: objectInfoService,
+		userCommon:        userCommon,
+		reportService:     reportService,
+		reviewService:     reviewService,
+		revisionService:   revisionService,
+	}
+}
+
+// GetQueue get the pending items, the oldest first
+func (ms *ModerationService) GetQueue(ctx context.Context, req *schema.GetModerationQueueReq) (
+	pageModel *pager.PageModel, err error) {
+	now := time.Now()
+	cond := &moderation_common.QueueCond{
+		ItemType: req.ItemType,
+		RevisionObjectTypes: schema.RevisionSearch{
+			CanReviewQuestion: req.CanReviewQuestion,
+			CanReviewAnswer:   req.CanReviewAnswer,
+			CanReviewTag:      req.CanReviewTag,
+		}.GetCanReviewObjectTypes(),
+		TagSlugName: req.Tag,
+	}
+	if req.MinAge > 0 {
+		cond.SubmittedBefore = now.Add(-time.Duration(req.MinAge) * time.Hour)
+	}
+	if req.MaxAge > 0 {
+		cond.SubmittedAfter = now.Add(-time.Duration(req.MaxAge) * time.Hour)
+	}
+	page, pageSize := req.Page, req.PageSize
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultQueuePageSize
+	}
+	items, total, err := ms.moderationRepo.GetQueuePage(ctx, page, pageSize, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := ms.moderationRepo.GetActiveClaims(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	claimMapping := make(map[string]*entity.ModerationClaim, len(claims))
+	userIDs := make([]string, 0)
+	for _, claim := range claims {
+		claimMapping[claim.ItemType+claim.ItemID] = claim
+		userIDs = append(userIDs, claim.UserID)
+	}
+	infoMapping := make(map[string]*schema.UnreviewedRevisionInfoInfo, len(items))
+	for _, item := range items {
+		info, err := ms.objectInfoService.GetUnreviewedRevisionInfo(ctx, item.ObjectID)
+		if err != nil {
+			log.Errorf("get object %s info failed: %v", item.ObjectID, err)
+			continue
+		}
+		infoMapping[item.ObjectID] = info
+		userIDs = append(userIDs, info.ObjectCreatorUserID)
+	}
+	userInfoMapping, err := ms.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.ModerationQueueItem, 0, len(items))
+	for _, item := range items {
+		info, ok := infoMapping[item.ObjectID]
+		if !ok {
+			continue
+		}
+		r := &schema.ModerationQueueItem{
+			ItemType:   item.ItemType,
+			ItemID:     item.ItemID,
+			ObjectID:   info.ObjectID,
+			ObjectType: info.ObjectType,
+			QuestionID: info.QuestionID,
+			AnswerID:   info.AnswerID,
+			CommentID:  info.CommentID,
+			Title:      info.Title,
+			UrlTitle:   htmltext.UrlTitle(info.Title),
+			ParsedText: info.Html,
+			Tags:       info.Tags,
+			Reason:     item.Reason,
+			SubmitAt:   item.SubmitAt.Unix(),
+		}
+		if userInfo, ok := userInfoMapping[info.ObjectCreatorUserID]; ok {
+			r.AuthorUserInfo = *userInfo
+		}
+		if claim, ok := claimMapping[item.ItemType+item.ItemID]; ok {
+			r.ClaimUserInfo = userInfoMapping[claim.UserID]
+			r.ClaimExpiredAt = claim.ExpiredAt.Unix()
+		}
+		resp = append(resp, r)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// Claim claim the item for the moderator, others can not handle the item until the claim expires
+func (ms *ModerationService) Claim(ctx context.Context, req *schema.ModerationClaimReq) (
+	resp *schema.ModerationClaimResp, err error) {
+	if err = ms.checkPending(ctx, &req.ModerationItem); err != nil {
+		return nil, err
+	}
+	claim := &entity.ModerationClaim{
+		ItemType:  req.ItemType,
+		ItemID:    req.ItemID,
+		UserID:    req.UserID,
+		ExpiredAt: time.Now().Add(claimLease),
+	}
+	saved, err := ms.moderationRepo.SaveClaim(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, myErrors.BadRequest(reason.ModerationItemClaimed)
+	}
+	return &schema.ModerationClaimResp{
+		ItemType:  claim.ItemType,
+		ItemID:    claim.ItemID,
+		ExpiredAt: claim.ExpiredAt.Unix(),
+	}, nil
+}
+
+// Release release the claim of the moderator
+func (ms *ModerationService) Release(ctx context.Context, req *schema.ModerationClaimReq) (err error) {
+	return ms.moderationRepo.RemoveClaim(ctx, req.ItemType, req.ItemID, req.UserID)
+}
+
+// checkPending check the item exists and is not handled
+func (ms *ModerationService) checkPending(ctx context.Context, item *schema.ModerationItem) (err error) {
+	pending := false
+	switch item.ItemType {
+	case entity.ModerationItemTypeReport:
+		r, exist, err := ms.reportRepo.GetByID(ctx, item.ItemID)
+		if err != nil {
+			return err
+		}
+		pending = exist && r.Status == entity.ReportStatusPending
+	case entity.ModerationItemTypeReview:
+		reviewID, _ := strconv.Atoi(item.ItemID)
+		r, exist, err := ms.reviewRepo.GetReview(ctx, reviewID)
+		if err != nil {
+			return err
+		}
+		pending = exist && r.Status == entity.ReviewStatusPending
+	case entity.ModerationItemTypeRevision:
+		r, exist, err := ms.revisionRepo.GetRevisionByID(ctx, item.ItemID)
+		if err != nil {
+			return err
+		}
+		pending = exist && r.Status == entity.RevisionUnreviewedStatus
+	}
+	if !pending {
+		return myErrors.BadRequest(reason.ModerationItemNotFound)
+	}
+	return nil
+}
+
+// ModerateItems take the action on the items one by one, the failure of one item does not stop the others
+func (ms *ModerationService) ModerateItems(ctx context.Context, req *schema.ModerateItemsReq) (
+	resp []*schema.ModerateItemResult, err error) {
+	lang := handler.GetLangByCtx(ctx)
+	resp = make([]*schema.ModerateItemResult, 0, len(req.Items))
+	for _, item := range req.Items {
+		result := &schema.ModerateItemResult{ItemType: item.ItemType, ItemID: item.ItemID, Success: true}
+		if err := ms.moderateItem(ctx, req, item); err != nil {
+			result.Success = false
+			var myErr *myErrors.Error
+			if errors.As(err, &myErr) {
+				result.Msg = translator.Tr(lang, myErr.Reason)
+			} else {
+				log.Errorf("moderate %s %s failed: %v", item.ItemType, item.ItemID, err)
+				result.Msg = translator.Tr(lang, reason.UnknownError)
+			}
+		}
+		resp = append(resp, result)
+	}
+	return resp, nil
+}
+
+func (ms *ModerationService) moderateItem(ctx context.Context, req *schema.ModerateItemsReq,
+	item *schema.ModerationItem) (err error) {
+	if err = ms.checkPending(ctx, item); err != nil {
+		return err
+	}
+	isApprove := req.Action == schema.ModerationActionApprove
+	switch item.ItemType {
+	case entity.ModerationItemTypeReport:
+		operationType := constant.ReportOperationDeletePost
+		if isApprove {
+			operationType = constant.ReportOperationIgnoreReport
+		}
+		return ms.reportService.ReviewReport(ctx, &schema.ReviewReportReq{
+			FlagID:        item.ItemID,
+			OperationType: operationType,
+			Reason:        req.Reason,
+			UserID:        req.UserID,
+			IsAdmin:       true,
+		})
+	case entity.ModerationItemTypeReview:
+		status := "reject"
+		if isApprove {
+			status = "approve"
+		}
+		reviewID, _ := strconv.Atoi(item.ItemID)
+		return ms.reviewService.UpdateReview(ctx, &schema.UpdateReviewReq{
+			ReviewID: reviewID,
+			Status:   status,
+			Reason:   req.Reason,
+			UserID:   req.UserID,
+			IsAdmin:  true,
+		})
+	case entity.ModerationItemTypeRevision:
+		operation := schema.RevisionAuditReject
+		if isApprove {
+			operation = schema.RevisionAuditApprove
+		}
+		return ms.revisionService.RevisionAudit(ctx, &schema.RevisionAuditReq{
+			ID:                item.ItemID,
+			Operation:         operation,
+			Reason:            req.Reason,
+			UserID:            req.UserID,
+			CanReviewQuestion: req.CanReviewQuestion,
+			CanReviewAnswer:   req.CanReviewAnswer,
+			CanReviewTag:      req.CanReviewTag,
+		})
+	}
+	return nil
+}
+
+// GetLogPage get the moderation logs, the latest first
+func (ms *ModerationService) GetLogPage(ctx context.Context, req *schema.GetModerationLogPageReq) (
+	pageModel *pager.PageModel, err error) {
+	cond := &entity.ModerationLog{
+		UserID:   req.OperatorID,
+		ItemType: req.ItemType,
+		ObjectID: req.ObjectID,
+	}
+	logs, total, err := ms.moderationRepo.GetLogPage(ctx, req.Page, req.PageSize, cond)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(logs))
+	for _, l := range logs {
+		userIDs = append(userIDs, l.UserID)
+	}
+	userInfoMapping, err := ms.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.ModerationLogResp, 0, len(logs))
+	for _, l := range logs {
+		r := &schema.ModerationLogResp{
+			ID:        l.ID,
+			CreatedAt: l.CreatedAt.Unix(),
+			ItemType:  l.ItemType,
+			ItemID:    l.ItemID,
+			ObjectID:  l.ObjectID,
+			Action:    l.Action,
+			Reason:    l.Reason,
+			Before:    &schema.ModerationSnapshot{},
+			After:     &schema.ModerationSnapshot{},
+		}
+		_ = json.Unmarshal([]byte(l.BeforeSnapshot), r.Before)
+		_ = json.Unmarshal([]byte(l.AfterSnapshot), r.After)
+		if userInfo, ok := userInfoMapping[l.UserID]; ok {
+			r.OperatorInfo = *userInfo
+		}
+		resp = append(resp, r)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package moderation_common
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/object_info"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ModerationRepo moderation repository
+type ModerationRepo interface {
+	GetClaim(ctx context.Context, itemType, itemID string) (claim *entity.ModerationClaim, exist bool, err error)
+	GetActiveClaims(ctx context.Context, now time.Time) (claims []*entity.ModerationClaim, err error)
+	SaveClaim(ctx context.Context, claim *entity.ModerationClaim) (saved bool, err error)
+	RemoveClaim(ctx context.Context, itemType, itemID, userID string) (err error)
+	RemoveItemClaim(ctx context.Context, itemType, itemID string) (err error)
+	AddLog(ctx context.Context, moderationLog *entity.ModerationLog) (err error)
+	GetLogPage(ctx context.Context, page, pageSize int, cond *entity.ModerationLog) (
+		logs []*entity.ModerationLog, total int64, err error)
+	GetQueuePage(ctx context.Context, page, pageSize int, cond *QueueCond) (
+		items []*QueueItem, total int64, err error)
+}
+
+// QueueCond the condition of the pending items in the moderation queue
+type QueueCond struct {
+	// ItemType only the items of the type, all the types if it is empty
+	ItemType string
+	// RevisionObjectTypes the object types of the revisions which the user can review
+	RevisionObjectTypes []int
+	// TagSlugName only the items whose object is a question with the tag
+	TagSlugName string
+	// SubmittedAfter and SubmittedBefore limit the submit time if they are not zero
+	SubmittedAfter  time.Time
+	SubmittedBefore time.Time
+}
+
+// QueueItem the pending report, review or unreviewed revision in the moderation queue
+type QueueItem struct {
+	ItemType string    `xorm:"item_type"`
+	ItemID   string    `xorm:"item_id"`
+	ObjectID string    `xorm:"object_id"`
+	Reason   string    `xorm:"reason"`
+	SubmitAt time.Time `xorm:"submit_at"`
+}
+
+// ModerationCommonService the claims of the moderation items and the log of the moderator actions,
+// it is used by the report, review and revision services when the moderator handles the items
+type ModerationCommonService struct {
+	moderationRepo    ModerationRepo
+	objectInfoService *object_info.ObjService
+}
+
+// NewModerationCommonService new moderation common service
+func NewModerationCommonService(
+	moderationRepo ModerationRepo,
+	objectInfoService *object_info.ObjService,
+) *ModerationCommonService {
+	return &ModerationCommonService{
+		moderationRepo:    moderationRepo,
+		objectInfoService: objectInfoService,
+	}
+}
+
+// CheckClaim check whether the item is claimed by another moderator whose claim is not expired
+func (ms *ModerationCommonService) CheckClaim(ctx context.Context, itemType, itemID, userID string) (err error) {
+	claim, exist, err := ms.moderationRepo.GetClaim(ctx, itemType, itemID)
+	if err != nil {
+		return err
+	}
+	if exist && claim.UserID != userID && claim.ExpiredAt.After(time.Now()) {
+		return errors.BadRequest(reason.ModerationItemClaimed)
+	}
+	return nil
+}
+
+// Snapshot get the status of the item and its object
+func (ms *ModerationCommonService) Snapshot(ctx context.Context, itemStatus int, objectID string) (
+	snapshot *schema.ModerationSnapshot) {
+	snapshot = &schema.ModerationSnapshot{ItemStatus: itemStatus}
+	info, err := ms.objectInfoService.GetUnreviewedRevisionInfo(ctx, objectID)
+	if err != nil {
+		log.Errorf("get object %s info failed: %v", objectID, err)
+		return snapshot
+	}
+	snapshot.ObjectStatus = info.Status
+	snapshot.ObjectShowStatus = info.ShowStatus
+	return snapshot
+}
+
+// Record log the action of the moderator, the item is handled so the claim of it is released.
+// The action has been taken, so the error is only logged.
+func (ms *ModerationCommonService) Record(ctx context.Context, moderationLog *entity.ModerationLog,
+	before, after *schema.ModerationSnapshot) {
+	beforeData, _ := json.Marshal(before)
+	afterData, _ := json.Marshal(after)
+	moderationLog.BeforeSnapshot = string(beforeData)
+	moderationLog.AfterSnapshot = string(afterData)
+	if err := ms.moderationRepo.AddLog(ctx, moderationLog); err != nil {
+		log.Errorf("add moderation log failed: %v", err)
+	}
+	if err := ms.moderationRepo.RemoveItemClaim(ctx, moderationLog.ItemType, moderationLog.ItemID); err != nil {
+		log.Errorf("remove moderation claim failed: %v", err)
+	}
+}
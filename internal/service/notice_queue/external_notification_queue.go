@@ -23,47 +23,17 @@ import (
 	"context"
 
 	"github.com/apache/answer/internal/schema"
-	"github.com/segmentfault/pacman/log"
+	"github.com/apache/answer/internal/service/queue_message"
+	"xorm.io/xorm"
 )
 
 type ExternalNotificationQueueService interface {
-	Send(ctx context.Context, msg *schema.ExternalNotificationMsg)
+	Send(ctx context.Context, msg *schema.ExternalNotificationMsg) (err error)
+	SendWithSession(ctx context.Context, session *xorm.Session, msg *schema.ExternalNotificationMsg) (err error)
 	RegisterHandler(handler func(ctx context.Context, msg *schema.ExternalNotificationMsg) error)
 }
 
-type externalNotificationQueueService struct {
-	Queue   chan *schema.ExternalNotificationMsg
-	Handler func(ctx context.Context, msg *schema.ExternalNotificationMsg) error
-}
-
-func (ns *externalNotificationQueueService) Send(ctx context.Context, msg *schema.ExternalNotificationMsg) {
-	ns.Queue <- msg
-}
-
-func (ns *externalNotificationQueueService) RegisterHandler(
-	handler func(ctx context.Context, msg *schema.ExternalNotificationMsg) error) {
-	ns.Handler = handler
-}
-
-func (ns *externalNotificationQueueService) working() {
-	go func() {
-		for msg := range ns.Queue {
-			log.Debugf("received notification %+v", msg)
-			if ns.Handler == nil {
-				log.Warnf("no handler for notification")
-				continue
-			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
-				log.Error(err)
-			}
-		}
-	}()
-}
-
-// NewNewQuestionNotificationQueueService create a new notification queue service
-func NewNewQuestionNotificationQueueService() ExternalNotificationQueueService {
-	ns := &externalNotificationQueueService{}
-	ns.Queue = make(chan *schema.ExternalNotificationMsg, 128)
-	ns.working()
-	return ns
+// NewNewQuestionNotificationQueueService create a new notification queue service, messages are kept in the database until they are handled
+func NewNewQuestionNotificationQueueService(queueMessageRepo queue_message.QueueMessageRepo) ExternalNotificationQueueService {
+	return queue_message.NewQueue[schema.ExternalNotificationMsg]("external_notification", queueMessageRepo)
 }
@@ -23,47 +23,17 @@ import (
 	"context"
 
 	"github.com/apache/answer/internal/schema"
-	"github.com/segmentfault/pacman/log"
+	"github.com/apache/answer/internal/service/queue_message"
+	"xorm.io/xorm"
 )
 
 type NotificationQueueService interface {
-	Send(ctx context.Context, msg *schema.NotificationMsg)
+	Send(ctx context.Context, msg *schema.NotificationMsg) (err error)
+	SendWithSession(ctx context.Context, session *xorm.Session, msg *schema.NotificationMsg) (err error)
 	RegisterHandler(handler func(ctx context.Context, msg *schema.NotificationMsg) error)
 }
 
-type notificationQueueService struct {
-	Queue   chan *schema.NotificationMsg
-	Handler func(ctx context.Context, msg *schema.NotificationMsg) error
-}
-
-func (ns *notificationQueueService) Send(ctx context.Context, msg *schema.NotificationMsg) {
-	ns.Queue <- msg
-}
-
-func (ns *notificationQueueService) RegisterHandler(
-	handler func(ctx context.Context, msg *schema.NotificationMsg) error) {
-	ns.Handler = handler
-}
-
-func (ns *notificationQueueService) working() {
-	go func() {
-		for msg := range ns.Queue {
-			log.Debugf("received notification %+v", msg)
-			if ns.Handler == nil {
-				log.Warnf("no handler for notification")
-				continue
-			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
-				log.Error(err)
-			}
-		}
-	}()
-}
-
-// NewNotificationQueueService create a new notification queue service
-func NewNotificationQueueService() NotificationQueueService {
-	ns := &notificationQueueService{}
-	ns.Queue = make(chan *schema.NotificationMsg, 128)
-	ns.working()
-	return ns
+// NewNotificationQueueService create a new notification queue service, messages are kept in the database until they are handled
+func NewNotificationQueueService(queueMessageRepo queue_message.QueueMessageRepo) NotificationQueueService {
+	return queue_message.NewQueue[schema.NotificationMsg]("notification", queueMessageRepo)
 }
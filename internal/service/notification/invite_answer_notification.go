@@ -72,12 +72,14 @@ func (ns *ExternalNotificationService) sendInviteAnswerNotificationEmail(ctx con
 	if len(lang) > 0 {
 		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(lang))
 	}
+	replyTo := ns.emailService.ReplyToAddress(ctx, userID, rawData.QuestionID)
+	rawData.CanReply = len(replyTo) > 0
 	title, body, err := ns.emailService.NewInviteAnswerTemplate(ctx, rawData)
 	if err != nil {
 		log.Error(err)
 		return
 	}
 
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	ns.emailService.SendAndSaveCodeWithReplyTo(ctx, userID, email, title, body,
+		rawData.UnsubscribeCode, codeContent.ToJSONString(), replyTo, 1*24*time.Hour)
 }
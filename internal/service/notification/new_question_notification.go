@@ -178,6 +178,8 @@ func (ns *ExternalNotificationService) sendNewQuestionNotificationEmail(ctx cont
 	if len(userInfo.Language) > 0 {
 		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(userInfo.Language))
 	}
+	replyTo := ns.emailService.ReplyToAddress(ctx, userInfo.ID, rawData.QuestionID)
+	rawData.CanReply = len(replyTo) > 0
 	title, body, err := ns.emailService.NewQuestionTemplate(ctx, rawData)
 	if err != nil {
 		log.Error(err)
@@ -194,8 +196,8 @@ func (ns *ExternalNotificationService) sendNewQuestionNotificationEmail(ctx cont
 		},
 		SkipValidationLatestCode: true,
 	}
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userInfo.ID, userInfo.EMail, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	ns.emailService.SendAndSaveCodeWithReplyTo(ctx, userInfo.ID, userInfo.EMail, title, body,
+		rawData.UnsubscribeCode, codeContent.ToJSONString(), replyTo, 1*24*time.Hour)
 }
 
 func (ns *ExternalNotificationService) syncNewQuestionNotificationToPlugin(ctx context.Context,
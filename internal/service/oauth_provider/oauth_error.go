@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package oauth_provider
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// the error codes defined by RFC 6749 and RFC 6750
+const (
+	ErrorInvalidRequest          = "invalid_request"
+	ErrorInvalidClient           = "invalid_client"
+	ErrorInvalidGrant            = "invalid_grant"
+	ErrorInvalidScope            = "invalid_scope"
+	ErrorInvalidToken            = "invalid_token"
+	ErrorUnsupportedGrantType    = "unsupported_grant_type"
+	ErrorUnsupportedResponseType = "unsupported_response_type"
+	ErrorAccessDenied            = "access_denied"
+	ErrorServerError             = "server_error"
+)
+
+// OAuthError the error returned to the client as the oauth specifications require,
+// it is not translated and not wrapped in the common response body
+type OAuthError struct {
+	Code        string
+	Description string
+	StatusCode  int
+}
+
+func newOAuthError(code, description string) *OAuthError {
+	statusCode := http.StatusBadRequest
+	if code == ErrorInvalidClient || code == ErrorInvalidToken {
+		statusCode = http.StatusUnauthorized
+	}
+	return &OAuthError{Code: code, Description: description, StatusCode: statusCode}
+}
+
+func (e *OAuthError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
@@ -0,0 +1,815 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package oauth_provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/jwt"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	ResponseTypeCode           = "code"
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+	CodeChallengeMethodS256    = "S256"
+	CodeChallengeMethodPlain   = "plain"
+	TokenTypeBearer            = "Bearer"
+
+	authorizationCodeExpiresIn = 10 * time.Minute
+	// refreshTokenExpiresIn the refresh token is rotated every time it is used
+	refreshTokenExpiresIn = 30 * 24 * time.Hour
+	idTokenExpiresIn      = time.Hour
+)
+
+// OAuthProviderRepo oauth provider repository
+type OAuthProviderRepo interface {
+	AddClient(ctx context.Context, client *entity.OAuthClient) (err error)
+	UpdateClient(ctx context.Context, client *entity.OAuthClient) (err error)
+	UpdateClientSecret(ctx context.Context, id, secretHash string) (err error)
+	DeleteClient(ctx context.Context, client *entity.OAuthClient) (err error)
+	GetClient(ctx context.Context, id string) (client *entity.OAuthClient, exist bool, err error)
+	GetClientByClientID(ctx context.Context, clientID string) (client *entity.OAuthClient, exist bool, err error)
+	GetClientList(ctx context.Context) (clients []*entity.OAuthClient, err error)
+
+	AddAuthorizationCode(ctx context.Context, code *entity.OAuthAuthorizationCode) (err error)
+	GetAuthorizationCodeByHash(ctx context.Context, codeHash string) (
+		code *entity.OAuthAuthorizationCode, exist bool, err error)
+	UseAuthorizationCode(ctx context.Context, id string) (used bool, err error)
+	RevokeGrant(ctx context.Context, grantID string) (err error)
+	RemoveExpiredAuthorizationCodes(ctx context.Context, before time.Time) (count int64, err error)
+
+	SetAccessToken(ctx context.Context, tokenHash string, token *entity.OAuthAccessToken) (err error)
+	GetAccessToken(ctx context.Context, tokenHash string) (token *entity.OAuthAccessToken, exist bool, err error)
+	RemoveAccessToken(ctx context.Context, tokenHash string) (err error)
+
+	AddRefreshToken(ctx context.Context, token *entity.OAuthRefreshToken) (err error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (
+		token *entity.OAuthRefreshToken, exist bool, err error)
+	RevokeRefreshToken(ctx context.Context, id string) (revoked bool, err error)
+
+	GetConsent(ctx context.Context, userID, clientID string) (consent *entity.OAuthConsent, exist bool, err error)
+	SaveConsent(ctx context.Context, consent *entity.OAuthConsent) (err error)
+}
+
+// OAuthProviderService the oauth 2.0 authorization server and openid provider,
+// other applications can sign in the users with their accounts of the site
+type OAuthProviderService struct {
+	oauthProviderRepo     OAuthProviderRepo
+	userRepo              usercommon.UserRepo
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	configService         *config.ConfigService
+}
+
+// NewOAuthProviderService new oauth provider service
+func NewOAuthProviderService(
+	oauthProviderRepo OAuthProviderRepo,
+	userRepo usercommon.UserRepo,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	configService *config.ConfigService,
+) *OAuthProviderService {
+	return &OAuthProviderService{
+		oauthProviderRepo:     oauthProviderRepo,
+		userRepo:              userRepo,
+		siteInfoCommonService: siteInfoCommonService,
+		configService:         configService,
+	}
+}
+
+// GetClientList get all oauth clients
+func (ps *OAuthProviderService) GetClientList(ctx context.Context) (resp []*schema.OAuthClientItem, err error) {
+	clients, err := ps.oauthProviderRepo.GetClientList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.OAuthClientItem, 0, len(clients))
+	for _, client := range clients {
+		resp = append(resp, convertClientItem(client))
+	}
+	return resp, nil
+}
+
+// AddClient add oauth client, the secret is only returned here
+func (ps *OAuthProviderService) AddClient(ctx context.Context, req *schema.AddOAuthClientReq) (
+	resp *schema.AddOAuthClientResp, err error) {
+	if err = checkRedirectURIs(req.RedirectURIs); err != nil {
+		return nil, err
+	}
+	client := &entity.OAuthClient{
+		ID:           uid.ID().String(),
+		ClientID:     randomToken(16),
+		Name:         req.Name,
+		RedirectURIs: strings.Join(req.RedirectURIs, "\n"),
+		Scopes:       strings.Join(uniqueScopes(req.Scopes), " "),
+		Public:       req.Public,
+		Status:       entity.OAuthClientStatusAvailable,
+	}
+	resp = &schema.AddOAuthClientResp{}
+	if !client.Public {
+		resp.ClientSecret = randomToken(32)
+		client.SecretHash = hashToken(resp.ClientSecret)
+	}
+	if err = ps.oauthProviderRepo.AddClient(ctx, client); err != nil {
+		return nil, err
+	}
+	client.CreatedAt = time.Now()
+	resp.OAuthClientItem = convertClientItem(client)
+	return resp, nil
+}
+
+// UpdateClient update oauth client
+func (ps *OAuthProviderService) UpdateClient(ctx context.Context, req *schema.UpdateOAuthClientReq) (err error) {
+	client, exist, err := ps.oauthProviderRepo.GetClient(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.OAuthClientNotFound)
+	}
+	if err = checkRedirectURIs(req.RedirectURIs); err != nil {
+		return err
+	}
+	client.Name = req.Name
+	client.RedirectURIs = strings.Join(req.RedirectURIs, "\n")
+	client.Scopes = strings.Join(uniqueScopes(req.Scopes), " ")
+	return ps.oauthProviderRepo.UpdateClient(ctx, client)
+}
+
+// DeleteClient delete oauth client, the refresh tokens issued to it are revoked
+func (ps *OAuthProviderService) DeleteClient(ctx context.Context, req *schema.DeleteOAuthClientReq) (err error) {
+	client, exist, err := ps.oauthProviderRepo.GetClient(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.OAuthClientNotFound)
+	}
+	return ps.oauthProviderRepo.DeleteClient(ctx, client)
+}
+
+// ResetClientSecret generate a new secret for the client, the old one stops working at once
+func (ps *OAuthProviderService) ResetClientSecret(ctx context.Context, req *schema.ResetOAuthClientSecretReq) (
+	resp *schema.ResetOAuthClientSecretResp, err error) {
+	client, exist, err := ps.oauthProviderRepo.GetClient(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.OAuthClientNotFound)
+	}
+	if client.Public {
+		return nil, errors.BadRequest(reason.OAuthClientPublic)
+	}
+	resp = &schema.ResetOAuthClientSecretResp{ClientSecret: randomToken(32)}
+	if err = ps.oauthProviderRepo.UpdateClientSecret(ctx, client.ID, hashToken(resp.ClientSecret)); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetAuthorizeInfo check the authorization request and get what the consent page shows
+func (ps *OAuthProviderService) GetAuthorizeInfo(ctx context.Context, req *schema.OAuthAuthorizeReq) (
+	resp *schema.OAuthAuthorizeInfoResp, err error) {
+	client, redirectURI, err := ps.checkClientRedirectURI(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.OAuthAuthorizeInfoResp{ClientName: client.Name}
+	scopes, oauthErr := checkAuthorizeRequest(client, req)
+	if oauthErr != nil {
+		resp.RedirectURL = buildRedirectURL(redirectURI, map[string]string{
+			"error": oauthErr.Code, "error_description": oauthErr.Description, "state": req.State})
+		return resp, nil
+	}
+	resp.Scopes = scopes
+
+	consent, exist, err := ps.oauthProviderRepo.GetConsent(ctx, req.UserID, client.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		granted := strings.Fields(consent.Scope)
+		resp.Consented = !slices.ContainsFunc(scopes, func(scope string) bool {
+			return !slices.Contains(granted, scope)
+		})
+	}
+	return resp, nil
+}
+
+// Consent the user approves or denies the authorization request, the authorization code is issued if approved
+func (ps *OAuthProviderService) Consent(ctx context.Context, req *schema.OAuthConsentReq) (
+	resp *schema.OAuthConsentResp, err error) {
+	client, redirectURI, err := ps.checkClientRedirectURI(ctx, &req.OAuthAuthorizeReq)
+	if err != nil {
+		return nil, err
+	}
+	scopes, oauthErr := checkAuthorizeRequest(client, &req.OAuthAuthorizeReq)
+	if oauthErr == nil && !req.Approve {
+		oauthErr = newOAuthError(ErrorAccessDenied, "the user denied the request")
+	}
+	if oauthErr != nil {
+		return &schema.OAuthConsentResp{RedirectURL: buildRedirectURL(redirectURI, map[string]string{
+			"error": oauthErr.Code, "error_description": oauthErr.Description, "state": req.State})}, nil
+	}
+
+	consent, exist, err := ps.oauthProviderRepo.GetConsent(ctx, req.UserID, client.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	granted := scopes
+	if exist {
+		granted = uniqueScopes(append(strings.Fields(consent.Scope), scopes...))
+	}
+	err = ps.oauthProviderRepo.SaveConsent(ctx, &entity.OAuthConsent{
+		UserID: req.UserID, ClientID: client.ClientID, Scope: strings.Join(granted, " ")})
+	if err != nil {
+		return nil, err
+	}
+
+	code := randomToken(32)
+	codeChallengeMethod := req.CodeChallengeMethod
+	if len(req.CodeChallenge) > 0 && len(codeChallengeMethod) == 0 {
+		codeChallengeMethod = CodeChallengeMethodPlain
+	}
+	err = ps.oauthProviderRepo.AddAuthorizationCode(ctx, &entity.OAuthAuthorizationCode{
+		ID:                  uid.ID().String(),
+		CodeHash:            hashToken(code),
+		ClientID:            client.ClientID,
+		UserID:              req.UserID,
+		RedirectURI:         redirectURI,
+		RedirectURISent:     len(req.RedirectURI) > 0,
+		Scope:               strings.Join(scopes, " "),
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: codeChallengeMethod,
+		Nonce:               req.Nonce,
+		AuthTime:            time.Now().Unix(),
+		ExpiredAt:           time.Now().Add(authorizationCodeExpiresIn),
+		Status:              entity.OAuthAuthorizationCodeStatusAvailable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &schema.OAuthConsentResp{RedirectURL: buildRedirectURL(redirectURI, map[string]string{
+		"code": code, "state": req.State})}, nil
+}
+
+// Token exchange the authorization code or the refresh token for the tokens
+func (ps *OAuthProviderService) Token(ctx context.Context, req *schema.OAuthTokenReq) (
+	resp *schema.OAuthTokenResp, err error) {
+	if req.GrantType != GrantTypeAuthorizationCode && req.GrantType != GrantTypeRefreshToken {
+		return nil, newOAuthError(ErrorUnsupportedGrantType, "grant_type must be authorization_code or refresh_token")
+	}
+	client, err := ps.authenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if req.GrantType == GrantTypeAuthorizationCode {
+		return ps.exchangeAuthorizationCode(ctx, client, req)
+	}
+	return ps.exchangeRefreshToken(ctx, client, req)
+}
+
+func (ps *OAuthProviderService) exchangeAuthorizationCode(ctx context.Context,
+	client *entity.OAuthClient, req *schema.OAuthTokenReq) (resp *schema.OAuthTokenResp, err error) {
+	if len(req.Code) == 0 {
+		return nil, newOAuthError(ErrorInvalidRequest, "code is required")
+	}
+	code, exist, err := ps.oauthProviderRepo.GetAuthorizationCodeByHash(ctx, hashToken(req.Code))
+	if err != nil {
+		return nil, err
+	}
+	if !exist || code.ClientID != client.ClientID {
+		return nil, newOAuthError(ErrorInvalidGrant, "the code is invalid or expired")
+	}
+	// the code can only be used once, if it is used again, it may have been stolen,
+	// so the tokens issued for it are revoked
+	used, err := ps.oauthProviderRepo.UseAuthorizationCode(ctx, code.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		if err = ps.oauthProviderRepo.RevokeGrant(ctx, code.ID); err != nil {
+			return nil, err
+		}
+		return nil, newOAuthError(ErrorInvalidGrant, "the code is invalid or expired")
+	}
+	if time.Now().After(code.ExpiredAt) {
+		return nil, newOAuthError(ErrorInvalidGrant, "the code is invalid or expired")
+	}
+	if oauthErr := checkTokenRedirectURI(code, req.RedirectURI); oauthErr != nil {
+		return nil, oauthErr
+	}
+	if len(code.CodeChallenge) > 0 &&
+		!VerifyCodeChallenge(code.CodeChallenge, code.CodeChallengeMethod, req.CodeVerifier) {
+		return nil, newOAuthError(ErrorInvalidGrant, "code_verifier does not match the code_challenge")
+	}
+	user, err := ps.getAvailableUser(ctx, code.UserID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err = ps.issueTokens(ctx, client, user, code.ID, code.Scope, code.Nonce, code.AuthTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// the code may be used again while the tokens are issued, the grant revoked then
+	// does not cover the tokens issued after it, so revoke it again
+	code, exist, err = ps.oauthProviderRepo.GetAuthorizationCodeByHash(ctx, hashToken(req.Code))
+	if err != nil {
+		return nil, err
+	}
+	if exist && code.Status == entity.OAuthAuthorizationCodeStatusRevoked {
+		if err = ps.oauthProviderRepo.RevokeGrant(ctx, code.ID); err != nil {
+			return nil, err
+		}
+		return nil, newOAuthError(ErrorInvalidGrant, "the code is invalid or expired")
+	}
+	return resp, nil
+}
+
+func (ps *OAuthProviderService) exchangeRefreshToken(ctx context.Context,
+	client *entity.OAuthClient, req *schema.OAuthTokenReq) (resp *schema.OAuthTokenResp, err error) {
+	if len(req.RefreshToken) == 0 {
+		return nil, newOAuthError(ErrorInvalidRequest, "refresh_token is required")
+	}
+	token, exist, err := ps.oauthProviderRepo.GetRefreshTokenByHash(ctx, hashToken(req.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if !exist || token.ClientID != client.ClientID || time.Now().After(token.ExpiredAt) {
+		return nil, newOAuthError(ErrorInvalidGrant, "the refresh token is invalid or expired")
+	}
+	scope := token.Scope
+	if len(req.Scope) > 0 {
+		granted := strings.Fields(token.Scope)
+		scopes := uniqueScopes(strings.Fields(req.Scope))
+		for _, s := range scopes {
+			if !slices.Contains(granted, s) {
+				return nil, newOAuthError(ErrorInvalidScope, "the scope exceeds the one granted")
+			}
+		}
+		scope = strings.Join(scopes, " ")
+	}
+
+	// the refresh token is rotated, if it has been used by others at the same time, the request fails
+	revoked, err := ps.oauthProviderRepo.RevokeRefreshToken(ctx, token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !revoked {
+		return nil, newOAuthError(ErrorInvalidGrant, "the refresh token is invalid or expired")
+	}
+	user, err := ps.getAvailableUser(ctx, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return ps.issueTokens(ctx, client, user, token.GrantID, scope, "", 0)
+}
+
+func (ps *OAuthProviderService) issueTokens(ctx context.Context, client *entity.OAuthClient,
+	user *entity.User, grantID, scope, nonce string, authTime int64) (resp *schema.OAuthTokenResp, err error) {
+	resp = &schema.OAuthTokenResp{
+		AccessToken:  randomToken(32),
+		TokenType:    TokenTypeBearer,
+		ExpiresIn:    int64(constant.OAuthAccessTokenCacheTime.Seconds()),
+		RefreshToken: randomToken(32),
+		Scope:        scope,
+	}
+	err = ps.oauthProviderRepo.SetAccessToken(ctx, hashToken(resp.AccessToken), &entity.OAuthAccessToken{
+		ClientID: client.ClientID, UserID: user.ID, Scope: scope})
+	if err != nil {
+		return nil, err
+	}
+	err = ps.oauthProviderRepo.AddRefreshToken(ctx, &entity.OAuthRefreshToken{
+		ID:              uid.ID().String(),
+		ClientID:        client.ClientID,
+		UserID:          user.ID,
+		TokenHash:       hashToken(resp.RefreshToken),
+		Scope:           scope,
+		ExpiredAt:       time.Now().Add(refreshTokenExpiresIn),
+		Status:          entity.OAuthRefreshTokenStatusAvailable,
+		GrantID:         grantID,
+		AccessTokenHash: hashToken(resp.AccessToken),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := strings.Fields(scope)
+	if !slices.Contains(scopes, schema.OAuthScopeOpenID) {
+		return resp, nil
+	}
+	issuer, err := ps.getIssuer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ps.getSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	claims := &idTokenClaims{
+		Issuer:            issuer,
+		Audience:          client.ClientID,
+		ExpiresAt:         now.Add(idTokenExpiresIn).Unix(),
+		IssuedAt:          now.Unix(),
+		AuthTime:          authTime,
+		Nonce:             nonce,
+		OAuthUserInfoResp: ps.buildUserInfo(ctx, issuer, user, scopes),
+	}
+	resp.IDToken, err = jwt.Sign(key, claims)
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return resp, nil
+}
+
+// UserInfo get the claims of the user who authorized the access token
+func (ps *OAuthProviderService) UserInfo(ctx context.Context, accessToken string) (
+	resp *schema.OAuthUserInfoResp, err error) {
+	token, exist, err := ps.oauthProviderRepo.GetAccessToken(ctx, hashToken(accessToken))
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, newOAuthError(ErrorInvalidToken, "the access token is invalid or expired")
+	}
+	_, exist, err = ps.oauthProviderRepo.GetClientByClientID(ctx, token.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, newOAuthError(ErrorInvalidToken, "the access token is invalid or expired")
+	}
+	user, err := ps.getAvailableUser(ctx, token.UserID)
+	if _, ok := err.(*OAuthError); ok {
+		return nil, newOAuthError(ErrorInvalidToken, "the access token is invalid or expired")
+	}
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := ps.getIssuer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ps.buildUserInfo(ctx, issuer, user, strings.Fields(token.Scope)), nil
+}
+
+// Revoke revoke the access token or refresh token, the unknown tokens are ignored as RFC 7009 requires
+func (ps *OAuthProviderService) Revoke(ctx context.Context, req *schema.OAuthRevokeReq) (err error) {
+	client, err := ps.authenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return err
+	}
+	if len(req.Token) == 0 {
+		return newOAuthError(ErrorInvalidRequest, "token is required")
+	}
+	tokenHash := hashToken(req.Token)
+	refreshToken, exist, err := ps.oauthProviderRepo.GetRefreshTokenByHash(ctx, tokenHash)
+	if err != nil {
+		return err
+	}
+	if exist {
+		if refreshToken.ClientID == client.ClientID {
+			_, err = ps.oauthProviderRepo.RevokeRefreshToken(ctx, refreshToken.ID)
+		}
+		return err
+	}
+	accessToken, exist, err := ps.oauthProviderRepo.GetAccessToken(ctx, tokenHash)
+	if err != nil {
+		return err
+	}
+	if exist && accessToken.ClientID == client.ClientID {
+		return ps.oauthProviderRepo.RemoveAccessToken(ctx, tokenHash)
+	}
+	return nil
+}
+
+// RemoveExpiredAuthorizationCodes remove the authorization codes expired for a day, the reuse of the codes
+// expired recently can still be detected
+func (ps *OAuthProviderService) RemoveExpiredAuthorizationCodes(ctx context.Context) {
+	count, err := ps.oauthProviderRepo.RemoveExpiredAuthorizationCodes(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		log.Errorf("remove expired authorization codes failed: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Infof("removed %d expired authorization codes", count)
+	}
+}
+
+// Discovery the openid provider metadata, the issuer is the site url
+func (ps *OAuthProviderService) Discovery(ctx context.Context) (resp *schema.OIDCDiscoveryResp, err error) {
+	issuer, err := ps.getIssuer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.OIDCDiscoveryResp{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/oauth/authorize",
+		TokenEndpoint:                     issuer + "/oauth/token",
+		UserinfoEndpoint:                  issuer + "/oauth/userinfo",
+		JwksURI:                           issuer + "/oauth/jwks",
+		RevocationEndpoint:                issuer + "/oauth/revoke",
+		ScopesSupported:                   []string{schema.OAuthScopeOpenID, schema.OAuthScopeProfile, schema.OAuthScopeEmail},
+		ResponseTypesSupported:            []string{ResponseTypeCode},
+		GrantTypesSupported:               []string{GrantTypeAuthorizationCode, GrantTypeRefreshToken},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{CodeChallengeMethodS256, CodeChallengeMethodPlain},
+		ClaimsSupported: []string{"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "name",
+			"preferred_username", "picture", "profile", "website", "locale", "updated_at", "email", "email_verified"},
+	}, nil
+}
+
+// JWKS the public keys to verify the id tokens
+func (ps *OAuthProviderService) JWKS(ctx context.Context) (resp *schema.JWKSResp, err error) {
+	key, err := ps.getSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.JWKSResp{Keys: []*jwt.JWK{jwt.NewJWK(&key.PublicKey)}}, nil
+}
+
+// checkClientRedirectURI check the client and the redirect uri, the user cannot be sent back to the client
+// if any of them is invalid, so the errors are shown to the user instead
+func (ps *OAuthProviderService) checkClientRedirectURI(ctx context.Context, req *schema.OAuthAuthorizeReq) (
+	client *entity.OAuthClient, redirectURI string, err error) {
+	client, exist, err := ps.oauthProviderRepo.GetClientByClientID(ctx, req.ClientID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !exist {
+		return nil, "", errors.BadRequest(reason.OAuthClientNotFound)
+	}
+	redirectURIs := strings.Split(client.RedirectURIs, "\n")
+	if len(req.RedirectURI) == 0 {
+		// the redirect uri can be omitted only if the client has just one
+		if len(redirectURIs) != 1 {
+			return nil, "", errors.BadRequest(reason.OAuthRedirectURIMismatch)
+		}
+		return client, redirectURIs[0], nil
+	}
+	if !slices.Contains(redirectURIs, req.RedirectURI) {
+		return nil, "", errors.BadRequest(reason.OAuthRedirectURIMismatch)
+	}
+	return client, req.RedirectURI, nil
+}
+
+// checkAuthorizeRequest check the rest of the authorization request, the errors are returned to the client
+func checkAuthorizeRequest(client *entity.OAuthClient, req *schema.OAuthAuthorizeReq) (
+	scopes []string, oauthErr *OAuthError) {
+	if req.ResponseType != ResponseTypeCode {
+		return nil, newOAuthError(ErrorUnsupportedResponseType, "response_type must be code")
+	}
+	allowed := strings.Fields(client.Scopes)
+	if len(strings.TrimSpace(req.Scope)) == 0 {
+		scopes = allowed
+	} else {
+		scopes = uniqueScopes(strings.Fields(req.Scope))
+		for _, scope := range scopes {
+			if !slices.Contains(allowed, scope) {
+				return nil, newOAuthError(ErrorInvalidScope, "the scope "+scope+" is not allowed")
+			}
+		}
+	}
+	if len(req.CodeChallenge) == 0 {
+		if client.Public {
+			return nil, newOAuthError(ErrorInvalidRequest, "code_challenge is required")
+		}
+		return scopes, nil
+	}
+	if len(req.CodeChallengeMethod) > 0 &&
+		req.CodeChallengeMethod != CodeChallengeMethodS256 && req.CodeChallengeMethod != CodeChallengeMethodPlain {
+		return nil, newOAuthError(ErrorInvalidRequest, "code_challenge_method must be S256 or plain")
+	}
+	// the public clients have no secret, the plain challenge would protect nothing if the code is intercepted
+	if client.Public && req.CodeChallengeMethod != CodeChallengeMethodS256 {
+		return nil, newOAuthError(ErrorInvalidRequest, "code_challenge_method must be S256 for the public clients")
+	}
+	if len(req.CodeChallenge) < 43 || len(req.CodeChallenge) > 128 {
+		return nil, newOAuthError(ErrorInvalidRequest, "code_challenge must be 43 to 128 characters")
+	}
+	return scopes, nil
+}
+
+// checkTokenRedirectURI the redirect uri of the token request must be identical to the one of the authorization
+// request if it was included there, otherwise it can be omitted
+func checkTokenRedirectURI(code *entity.OAuthAuthorizationCode, redirectURI string) (oauthErr *OAuthError) {
+	if code.RedirectURISent && len(redirectURI) == 0 {
+		return newOAuthError(ErrorInvalidGrant, "redirect_uri is required as it was sent in the authorization request")
+	}
+	if len(redirectURI) > 0 && redirectURI != code.RedirectURI {
+		return newOAuthError(ErrorInvalidGrant, "redirect_uri does not match the authorization request")
+	}
+	return nil
+}
+
+// authenticateClient authenticate the client by the client id and secret, the public clients have no secret
+func (ps *OAuthProviderService) authenticateClient(ctx context.Context, clientID, clientSecret string) (
+	client *entity.OAuthClient, err error) {
+	if len(clientID) == 0 {
+		return nil, newOAuthError(ErrorInvalidClient, "client authentication failed")
+	}
+	client, exist, err := ps.oauthProviderRepo.GetClientByClientID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, newOAuthError(ErrorInvalidClient, "client authentication failed")
+	}
+	if client.Public {
+		return client, nil
+	}
+	if subtle.ConstantTimeCompare([]byte(hashToken(clientSecret)), []byte(client.SecretHash)) != 1 {
+		return nil, newOAuthError(ErrorInvalidClient, "client authentication failed")
+	}
+	return client, nil
+}
+
+func (ps *OAuthProviderService) getAvailableUser(ctx context.Context, userID string) (user *entity.User, err error) {
+	user, exist, err := ps.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || user.Status != entity.UserStatusAvailable {
+		return nil, newOAuthError(ErrorInvalidGrant, "the user is not available")
+	}
+	return user, nil
+}
+
+func (ps *OAuthProviderService) buildUserInfo(ctx context.Context, issuer string, user *entity.User,
+	scopes []string) (info *schema.OAuthUserInfoResp) {
+	info = &schema.OAuthUserInfoResp{Sub: user.ID}
+	if slices.Contains(scopes, schema.OAuthScopeProfile) {
+		info.Name = user.DisplayName
+		info.PreferredUsername = user.Username
+		info.Picture = ps.siteInfoCommonService.FormatAvatar(ctx, user.Avatar, user.EMail, user.Status).GetURL()
+		if len(info.Picture) > 0 && strings.HasPrefix(info.Picture, "/") {
+			info.Picture = issuer + info.Picture
+		}
+		info.Profile = display.UserURL(issuer, user.Username)
+		info.Website = user.Website
+		info.Locale = user.Language
+		info.UpdatedAt = user.UpdatedAt.Unix()
+	}
+	if slices.Contains(scopes, schema.OAuthScopeEmail) {
+		emailVerified := user.MailStatus == entity.EmailStatusAvailable
+		info.Email = user.EMail
+		info.EmailVerified = &emailVerified
+	}
+	return info
+}
+
+func (ps *OAuthProviderService) getIssuer(ctx context.Context) (issuer string, err error) {
+	siteGeneral, err := ps.siteInfoCommonService.GetSiteGeneral(ctx)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(siteGeneral.SiteUrl, "/"), nil
+}
+
+func (ps *OAuthProviderService) getSigningKey(ctx context.Context) (key *rsa.PrivateKey, err error) {
+	content, err := ps.configService.GetStringValue(ctx, constant.OAuthSigningKeyConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err = jwt.ParsePrivateKey(content)
+	if err != nil {
+		log.Errorf("parse oauth signing key failed: %v", err)
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return key, nil
+}
+
+// idTokenClaims the claims of the id token, the claims of the user are the same as the userinfo
+type idTokenClaims struct {
+	Issuer    string `json:"iss"`
+	Audience  string `json:"aud"`
+	ExpiresAt int64  `json:"exp"`
+	IssuedAt  int64  `json:"iat"`
+	AuthTime  int64  `json:"auth_time,omitempty"`
+	Nonce     string `json:"nonce,omitempty"`
+	*schema.OAuthUserInfoResp
+}
+
+// VerifyCodeChallenge verify the code verifier sent by the client with the code challenge of PKCE
+func VerifyCodeChallenge(codeChallenge, method, codeVerifier string) bool {
+	if len(codeVerifier) < 43 || len(codeVerifier) > 128 {
+		return false
+	}
+	expected := codeVerifier
+	if method == CodeChallengeMethodS256 {
+		sum := sha256.Sum256([]byte(codeVerifier))
+		expected = base64.RawURLEncoding.EncodeToString(sum[:])
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(codeChallenge)) == 1
+}
+
+// checkRedirectURIs the redirect uris must be absolute without the fragment, the custom schemes are allowed
+// for the native apps
+func checkRedirectURIs(redirectURIs []string) error {
+	for _, redirectURI := range redirectURIs {
+		u, err := url.Parse(redirectURI)
+		if err != nil || !u.IsAbs() || len(u.Fragment) > 0 || strings.Contains(redirectURI, "\n") {
+			return errors.BadRequest(reason.OAuthRedirectURIInvalid)
+		}
+		switch strings.ToLower(u.Scheme) {
+		case "javascript", "data", "vbscript", "file":
+			return errors.BadRequest(reason.OAuthRedirectURIInvalid)
+		case "http", "https":
+			if len(u.Host) == 0 {
+				return errors.BadRequest(reason.OAuthRedirectURIInvalid)
+			}
+		}
+	}
+	return nil
+}
+
+// buildRedirectURL add the params to the redirect uri, the query of the redirect uri is kept
+func buildRedirectURL(redirectURI string, params map[string]string) string {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+	query := u.Query()
+	for key, value := range params {
+		if len(value) > 0 {
+			query.Set(key, value)
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+func convertClientItem(client *entity.OAuthClient) *schema.OAuthClientItem {
+	return &schema.OAuthClientItem{
+		ID:           client.ID,
+		ClientID:     client.ClientID,
+		Name:         client.Name,
+		RedirectURIs: strings.Split(client.RedirectURIs, "\n"),
+		Scopes:       strings.Fields(client.Scopes),
+		Public:       client.Public,
+		CreatedAt:    client.CreatedAt.Unix(),
+	}
+}
+
+func uniqueScopes(scopes []string) []string {
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(result, scope) {
+			result = append(result, scope)
+		}
+	}
+	return result
+}
+
+func randomToken(size int) string {
+	b := make([]byte, size)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
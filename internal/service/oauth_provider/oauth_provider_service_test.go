@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package oauth_provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	verifier := "dBjftJeZ4CVP-mJ92K9mH4bEOf3Rb2Wd9wLLD8GcTq0"
+	challenge := "M52wzK8Js6TfbKzDGQ9UbYNj2JM4-CCGBQwZFqMe4P0"
+	assert.True(t, VerifyCodeChallenge(challenge, CodeChallengeMethodS256, verifier))
+	assert.False(t, VerifyCodeChallenge(challenge, CodeChallengeMethodS256, verifier+"a"))
+	assert.False(t, VerifyCodeChallenge(challenge, CodeChallengeMethodPlain, verifier))
+	assert.True(t, VerifyCodeChallenge(verifier, CodeChallengeMethodPlain, verifier))
+	// the verifier is too short
+	assert.False(t, VerifyCodeChallenge("abc", CodeChallengeMethodPlain, "abc"))
+}
+
+func TestCheckRedirectURIs(t *testing.T) {
+	assert.NoError(t, checkRedirectURIs([]string{
+		"https://example.com/callback", "http://127.0.0.1:8080/cb?x=1", "com.example.app:/oauth"}))
+	assert.Error(t, checkRedirectURIs([]string{"/callback"}))
+	assert.Error(t, checkRedirectURIs([]string{"https://example.com/callback#token"}))
+	assert.Error(t, checkRedirectURIs([]string{"javascript:alert(1)"}))
+	assert.Error(t, checkRedirectURIs([]string{"https:///callback"}))
+}
+
+func TestCheckTokenRedirectURI(t *testing.T) {
+	code := &entity.OAuthAuthorizationCode{RedirectURI: "https://example.com/callback", RedirectURISent: true}
+	assert.Nil(t, checkTokenRedirectURI(code, "https://example.com/callback"))
+	assert.Equal(t, ErrorInvalidGrant, checkTokenRedirectURI(code, "").Code)
+	assert.Equal(t, ErrorInvalidGrant, checkTokenRedirectURI(code, "https://example.com/other").Code)
+
+	// the redirect uri was omitted in the authorization request as the client has just one
+	code.RedirectURISent = false
+	assert.Nil(t, checkTokenRedirectURI(code, ""))
+	assert.Nil(t, checkTokenRedirectURI(code, "https://example.com/callback"))
+	assert.Equal(t, ErrorInvalidGrant, checkTokenRedirectURI(code, "https://example.com/other").Code)
+}
+
+func TestCheckAuthorizeRequest(t *testing.T) {
+	client := &entity.OAuthClient{Scopes: "openid profile"}
+	req := &schema.OAuthAuthorizeReq{ResponseType: ResponseTypeCode}
+	scopes, oauthErr := checkAuthorizeRequest(client, req)
+	assert.Nil(t, oauthErr)
+	assert.Equal(t, []string{"openid", "profile"}, scopes)
+
+	req.Scope = "openid openid"
+	scopes, oauthErr = checkAuthorizeRequest(client, req)
+	assert.Nil(t, oauthErr)
+	assert.Equal(t, []string{"openid"}, scopes)
+
+	req.Scope = "openid email"
+	_, oauthErr = checkAuthorizeRequest(client, req)
+	assert.Equal(t, ErrorInvalidScope, oauthErr.Code)
+
+	req.Scope = ""
+	req.ResponseType = "token"
+	_, oauthErr = checkAuthorizeRequest(client, req)
+	assert.Equal(t, ErrorUnsupportedResponseType, oauthErr.Code)
+
+	// the public clients must use PKCE
+	client.Public = true
+	req.ResponseType = ResponseTypeCode
+	_, oauthErr = checkAuthorizeRequest(client, req)
+	assert.Equal(t, ErrorInvalidRequest, oauthErr.Code)
+	req.CodeChallenge = strings.Repeat("a", 43)
+	req.CodeChallengeMethod = CodeChallengeMethodS256
+	_, oauthErr = checkAuthorizeRequest(client, req)
+	assert.Nil(t, oauthErr)
+	// and the S256 challenge
+	req.CodeChallengeMethod = CodeChallengeMethodPlain
+	_, oauthErr = checkAuthorizeRequest(client, req)
+	assert.Equal(t, ErrorInvalidRequest, oauthErr.Code)
+	req.CodeChallengeMethod = ""
+	_, oauthErr = checkAuthorizeRequest(client, req)
+	assert.Equal(t, ErrorInvalidRequest, oauthErr.Code)
+}
+
+func TestBuildRedirectURL(t *testing.T) {
+	assert.Equal(t, "https://example.com/cb?code=abc&state=xyz&x=1",
+		buildRedirectURL("https://example.com/cb?x=1", map[string]string{"code": "abc", "state": "xyz"}))
+	assert.Equal(t, "https://example.com/cb?error=access_denied",
+		buildRedirectURL("https://example.com/cb", map[string]string{"error": "access_denied", "state": ""}))
+}
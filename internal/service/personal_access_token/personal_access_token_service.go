@@ -0,0 +1,300 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package personal_access_token
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/role"
+	usercommon "github.com/apache/answer/internal/service/user_common"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// TokenPrefix the prefix of the personal access tokens, it tells them from the access tokens of the login
+	TokenPrefix = "ans_pat_"
+	// tokenPrefixLength the length of the start of the token kept to recognize it
+	tokenPrefixLength = len(TokenPrefix) + 6
+	// maxUserTokens the max personal access tokens a user can have
+	maxUserTokens = 20
+	// lastUsedInterval the last used time is updated at most once in the interval
+	lastUsedInterval = time.Minute
+
+	apiPath      = "/answer/api/v1"
+	adminAPIPath = "/answer/admin/api"
+)
+
+// PersonalAccessTokenRepo personal access token repository
+type PersonalAccessTokenRepo interface {
+	AddToken(ctx context.Context, token *entity.PersonalAccessToken) (err error)
+	GetToken(ctx context.Context, id string) (token *entity.PersonalAccessToken, exist bool, err error)
+	GetTokenByHash(ctx context.Context, tokenHash string) (token *entity.PersonalAccessToken, exist bool, err error)
+	GetUserTokens(ctx context.Context, userID string) (tokens []*entity.PersonalAccessToken, err error)
+	GetTokenPage(ctx context.Context, page, pageSize int, userID string) (
+		tokens []*entity.PersonalAccessToken, total int64, err error)
+	CountUserTokens(ctx context.Context, userID string) (count int64, err error)
+	RevokeToken(ctx context.Context, id string) (err error)
+	UpdateLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) (err error)
+}
+
+// PersonalAccessTokenService the tokens created by the users to call the api from scripts and bots
+type PersonalAccessTokenService struct {
+	personalAccessTokenRepo PersonalAccessTokenRepo
+	userRepo                usercommon.UserRepo
+	userCommon              *usercommon.UserCommon
+	userRoleRelService      *role.UserRoleRelService
+}
+
+// NewPersonalAccessTokenService new personal access token service
+func NewPersonalAccessTokenService(
+	personalAccessTokenRepo PersonalAccessTokenRepo,
+	userRepo usercommon.UserRepo,
+	userCommon *usercommon.UserCommon,
+	userRoleRelService *role.UserRoleRelService,
+) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{
+		personalAccessTokenRepo: personalAccessTokenRepo,
+		userRepo:                userRepo,
+		userCommon:              userCommon,
+		userRoleRelService:      userRoleRelService,
+	}
+}
+
+// IsPersonalAccessToken whether the token in the request is a personal access token
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, TokenPrefix)
+}
+
+// GetUserTokens get the personal access tokens of the user
+func (ps *PersonalAccessTokenService) GetUserTokens(ctx context.Context, userID string) (
+	resp []*schema.PersonalAccessTokenItem, err error) {
+	tokens, err := ps.personalAccessTokenRepo.GetUserTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.PersonalAccessTokenItem, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, convertPersonalAccessToken(token))
+	}
+	return resp, nil
+}
+
+// AddToken create a personal access token for the user
+func (ps *PersonalAccessTokenService) AddToken(ctx context.Context, req *schema.AddPersonalAccessTokenReq) (
+	resp *schema.AddPersonalAccessTokenResp, err error) {
+	if slices.Contains(req.Scopes, schema.PersonalAccessTokenScopeAdmin) {
+		roleIDs, err := ps.userRoleRelService.GetUserRoleIDs(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(roleIDs, role.RoleAdminID) {
+			return nil, errors.Forbidden(reason.PersonalAccessTokenAdminScopeForbidden)
+		}
+	}
+	count, err := ps.personalAccessTokenRepo.CountUserTokens(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxUserTokens {
+		return nil, errors.BadRequest(reason.PersonalAccessTokenTooMany)
+	}
+
+	plainToken, err := generateToken()
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	slices.Sort(req.Scopes)
+	token := &entity.PersonalAccessToken{
+		ID:          uid.ID().String(),
+		UserID:      req.UserID,
+		Name:        req.Name,
+		TokenHash:   hashToken(plainToken),
+		TokenPrefix: plainToken[:tokenPrefixLength],
+		Scopes:      strings.Join(slices.Compact(req.Scopes), ","),
+		Status:      entity.PersonalAccessTokenStatusAvailable,
+	}
+	if req.ExpiresInDays > 0 {
+		token.ExpiredAt = time.Now().AddDate(0, 0, req.ExpiresInDays)
+	}
+	if err = ps.personalAccessTokenRepo.AddToken(ctx, token); err != nil {
+		return nil, err
+	}
+	token.CreatedAt = time.Now()
+	return &schema.AddPersonalAccessTokenResp{
+		PersonalAccessTokenItem: convertPersonalAccessToken(token),
+		Token:                   plainToken,
+	}, nil
+}
+
+// RevokeToken revoke the personal access token, the user can only revoke their own tokens
+func (ps *PersonalAccessTokenService) RevokeToken(ctx context.Context, req *schema.RevokePersonalAccessTokenReq) (
+	err error) {
+	token, exist, err := ps.personalAccessTokenRepo.GetToken(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist || (!req.IsAdmin && token.UserID != req.UserID) {
+		return errors.BadRequest(reason.PersonalAccessTokenNotFound)
+	}
+	return ps.personalAccessTokenRepo.RevokeToken(ctx, token.ID)
+}
+
+// GetTokenPage get the personal access tokens of all users for the admin
+func (ps *PersonalAccessTokenService) GetTokenPage(ctx context.Context, req *schema.GetPersonalAccessTokenPageReq) (
+	resp []*schema.AdminPersonalAccessTokenItem, total int64, err error) {
+	tokens, total, err := ps.personalAccessTokenRepo.GetTokenPage(ctx, req.Page, req.PageSize, req.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	userIDs := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		userIDs = append(userIDs, token.UserID)
+	}
+	users, err := ps.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp = make([]*schema.AdminPersonalAccessTokenItem, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, &schema.AdminPersonalAccessTokenItem{
+			PersonalAccessTokenItem: convertPersonalAccessToken(token),
+			User:                    users[token.UserID],
+		})
+	}
+	return resp, total, nil
+}
+
+// Authenticate gets the user of the personal access token and checks whether the scopes of the token
+// allow the request. The returned error is the response of the rejected request.
+func (ps *PersonalAccessTokenService) Authenticate(ctx context.Context, plainToken, method, path string) (
+	userInfo *entity.UserCacheInfo, err error) {
+	token, exist, err := ps.personalAccessTokenRepo.GetTokenByHash(ctx, hashToken(plainToken))
+	if err != nil {
+		log.Error(err)
+		return nil, errors.Unauthorized(reason.UnauthorizedError)
+	}
+	now := time.Now()
+	if !exist || (!token.ExpiredAt.IsZero() && token.ExpiredAt.Before(now)) {
+		return nil, errors.Unauthorized(reason.UnauthorizedError)
+	}
+	if !ScopeAllowed(strings.Split(token.Scopes, ","), method, path) {
+		return nil, errors.Forbidden(reason.PersonalAccessTokenScopeDenied)
+	}
+
+	user, exist, err := ps.userRepo.GetByUserID(ctx, token.UserID)
+	if err != nil {
+		log.Error(err)
+		return nil, errors.Unauthorized(reason.UnauthorizedError)
+	}
+	// the token works only while the owner can sign in
+	if !exist || user.Status == entity.UserStatusDeleted {
+		return nil, errors.Unauthorized(reason.UnauthorizedError)
+	}
+	if user.Status != entity.UserStatusAvailable {
+		return nil, errors.Forbidden(reason.UserSuspended)
+	}
+	roleIDs, err := ps.userRoleRelService.GetUserRoleIDs(ctx, user.ID)
+	if err != nil {
+		log.Error(err)
+		return nil, errors.Unauthorized(reason.UnauthorizedError)
+	}
+
+	if now.Sub(token.LastUsedAt) > lastUsedInterval {
+		if err = ps.personalAccessTokenRepo.UpdateLastUsedAt(ctx, token.ID, now); err != nil {
+			log.Error(err)
+		}
+	}
+	return &entity.UserCacheInfo{
+		UserID:      user.ID,
+		UserStatus:  user.Status,
+		EmailStatus: user.MailStatus,
+		RoleID:      role.BuiltInRoleOf(roleIDs),
+	}, nil
+}
+
+// ScopeAllowed whether the scopes allow the request. The api of the personal access tokens themselves and the
+// other write requests not covered by a scope, such as the account settings, are never allowed.
+func ScopeAllowed(scopes []string, method, path string) bool {
+	if strings.Contains(path, adminAPIPath) {
+		return slices.Contains(scopes, schema.PersonalAccessTokenScopeAdmin)
+	}
+	idx := strings.Index(path, apiPath)
+	if idx < 0 {
+		return false
+	}
+	resource := strings.TrimPrefix(path[idx+len(apiPath):], "/")
+	if strings.HasPrefix(resource, "personal/access-token") {
+		return false
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return slices.Contains(scopes, schema.PersonalAccessTokenScopeRead)
+	}
+	switch {
+	case strings.HasPrefix(resource, "question"):
+		return slices.Contains(scopes, schema.PersonalAccessTokenScopeWriteQuestion)
+	case strings.HasPrefix(resource, "answer"):
+		return slices.Contains(scopes, schema.PersonalAccessTokenScopeWriteAnswer)
+	case strings.HasPrefix(resource, "comment"):
+		return slices.Contains(scopes, schema.PersonalAccessTokenScopeWriteComment)
+	}
+	return false
+}
+
+func convertPersonalAccessToken(token *entity.PersonalAccessToken) *schema.PersonalAccessTokenItem {
+	item := &schema.PersonalAccessTokenItem{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      strings.Split(token.Scopes, ","),
+		CreatedAt:   token.CreatedAt.Unix(),
+	}
+	if !token.ExpiredAt.IsZero() {
+		item.ExpiredAt = token.ExpiredAt.Unix()
+	}
+	if !token.LastUsedAt.IsZero() {
+		item.LastUsedAt = token.LastUsedAt.Unix()
+	}
+	return item
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return TokenPrefix + hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package personal_access_token
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopeAllowed(t *testing.T) {
+	cases := []struct {
+		name   string
+		scopes []string
+		method string
+		path   string
+		want   bool
+	}{
+		{"read", []string{"read"}, http.MethodGet, "/answer/api/v1/question/page", true},
+		{"read without scope", []string{"write:question"}, http.MethodGet, "/answer/api/v1/question/page", false},
+		{"write question", []string{"write:question"}, http.MethodPost, "/answer/api/v1/question", true},
+		{"write question with base url", []string{"write:question"}, http.MethodPut, "/forum/answer/api/v1/question", true},
+		{"write answer without scope", []string{"read", "write:question"}, http.MethodPost, "/answer/api/v1/answer", false},
+		{"write answer", []string{"write:answer"}, http.MethodPost, "/answer/api/v1/answer/acceptance", true},
+		{"write comment", []string{"write:comment"}, http.MethodDelete, "/answer/api/v1/comment", true},
+		{"write not covered", []string{"read", "write:question", "write:answer", "write:comment"},
+			http.MethodPut, "/answer/api/v1/user/password", false},
+		{"manage tokens", []string{"read"}, http.MethodGet, "/answer/api/v1/personal/access-tokens", false},
+		{"admin", []string{"admin"}, http.MethodGet, "/answer/admin/api/users/page", true},
+		{"admin without scope", []string{"read"}, http.MethodGet, "/answer/admin/api/users/page", false},
+		{"admin scope on user api", []string{"admin"}, http.MethodGet, "/answer/api/v1/question/page", false},
+		{"unknown route", []string{"read"}, http.MethodGet, "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, ScopeAllowed(c.scopes, c.method, c.path))
+		})
+	}
+}
@@ -22,6 +22,7 @@ package plugin_common
 import (
 	"context"
 	"encoding/json"
+	"sort"
 
 	"github.com/apache/answer/internal/base/data"
 	"github.com/apache/answer/internal/repo/search_sync"
@@ -35,6 +36,7 @@ import (
 	"github.com/apache/answer/internal/schema"
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/importer"
+	"github.com/apache/answer/internal/service/search_index"
 	"github.com/apache/answer/plugin"
 )
 
@@ -59,6 +61,7 @@ type PluginCommonService struct {
 	pluginUserConfigRepo PluginUserConfigRepo
 	data                 *data.Data
 	importerService      *importer.ImporterService
+	searchIndexService   *search_index.SearchIndexService
 }
 
 // NewPluginCommonService new report service
@@ -68,6 +71,7 @@ func NewPluginCommonService(
 	configService *config.ConfigService,
 	data *data.Data,
 	importerService *importer.ImporterService,
+	searchIndexService *search_index.SearchIndexService,
 ) *PluginCommonService {
 
 	p := &PluginCommonService{
@@ -76,6 +80,7 @@ func NewPluginCommonService(
 		pluginUserConfigRepo: pluginUserConfigRepo,
 		data:                 data,
 		importerService:      importerService,
+		searchIndexService:   searchIndexService,
 	}
 	p.initPluginData()
 	return p
@@ -92,14 +97,20 @@ func (ps *PluginCommonService) UpdatePluginStatus(ctx context.Context) (err erro
 
 // UpdatePluginConfig update plugin config
 func (ps *PluginCommonService) UpdatePluginConfig(ctx context.Context, req *schema.UpdatePluginConfigReq) (err error) {
-	configValue, _ := json.Marshal(req.ConfigFields)
+	// the values pinned by the environment variables are never saved
+	configValue, _ := json.Marshal(StripPluginConfigEnv(req.PluginSlugName, req.ConfigFields))
 	err = ps.pluginConfigRepo.SavePluginConfig(ctx, req.PluginSlugName, string(configValue))
 	if err != nil {
 		return err
 	}
+	ps.RegisterPluginFuncs(ctx, req.PluginSlugName)
+	return nil
+}
 
+// RegisterPluginFuncs register the functions the plugin calls back, it is done after its config is saved
+func (ps *PluginCommonService) RegisterPluginFuncs(ctx context.Context, slugName string) {
 	_ = plugin.CallSearch(func(search plugin.Search) error {
-		if search.Info().SlugName == req.PluginSlugName {
+		if search.Info().SlugName == slugName {
 			search.RegisterSyncer(ctx, search_sync.NewPluginSyncer(ps.data))
 		}
 		return nil
@@ -108,7 +119,93 @@ func (ps *PluginCommonService) UpdatePluginConfig(ctx context.Context, req *sche
 		importer.RegisterImporterFunc(ctx, ps.importerService.NewImporterFunc())
 		return nil
 	})
-	return nil
+}
+
+// GetPluginConfigs get the saved configs of all the plugins, the key is the plugin slug name
+func (ps *PluginCommonService) GetPluginConfigs(ctx context.Context) (configs map[string]map[string]any, err error) {
+	pluginConfigs, err := ps.pluginConfigRepo.GetPluginConfigAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	configs = make(map[string]map[string]any)
+	for _, pluginConfig := range pluginConfigs {
+		configFields := make(map[string]any)
+		if err := json.Unmarshal([]byte(pluginConfig.Value), &configFields); err != nil {
+			log.Errorf("parse plugin config failed: %s %v", pluginConfig.PluginSlugName, err)
+			continue
+		}
+		configs[pluginConfig.PluginSlugName] = StripPluginConfigEnv(pluginConfig.PluginSlugName, configFields)
+	}
+	return configs, nil
+}
+
+// ReceivePluginConfigs pass the configs to the installed plugins, the configs of the other plugins are skipped.
+// Once a plugin rejects its config, the configs of all the plugins are restored.
+func (ps *PluginCommonService) ReceivePluginConfigs(ctx context.Context, configs map[string]map[string]any) (
+	received, skipped []string, failedSlugName string, err error) {
+	installed := make(map[string]bool)
+	_ = plugin.CallConfig(func(fn plugin.Config) error {
+		installed[fn.Info().SlugName] = true
+		return nil
+	})
+
+	snapshot := ps.SnapshotPluginConfigs()
+	for slugName, configFields := range configs {
+		if !installed[slugName] {
+			skipped = append(skipped, slugName)
+			continue
+		}
+		configValue, _ := json.Marshal(ApplyPluginConfigEnv(slugName, configFields))
+		err = plugin.CallConfig(func(fn plugin.Config) error {
+			if fn.Info().SlugName == slugName {
+				return fn.ConfigReceiver(configValue)
+			}
+			return nil
+		})
+		// the plugin may keep a part of the rejected config, so it is restored too
+		received = append(received, slugName)
+		if err != nil {
+			ps.RestorePluginConfigs(snapshot, received)
+			return nil, nil, slugName, err
+		}
+	}
+	sort.Strings(received)
+	sort.Strings(skipped)
+	return received, skipped, "", nil
+}
+
+// SnapshotPluginConfigs get the configs which the plugins are using now, the key is the plugin slug name.
+// The plugins whose config is never saved are in the snapshot too, so all of them can be restored exactly.
+func (ps *PluginCommonService) SnapshotPluginConfigs() (snapshot map[string][]byte) {
+	snapshot = make(map[string][]byte)
+	_ = plugin.CallConfig(func(fn plugin.Config) error {
+		configFields := make(map[string]any)
+		for _, field := range fn.ConfigFields() {
+			configFields[field.Name] = field.Value
+		}
+		snapshot[fn.Info().SlugName], _ = json.Marshal(configFields)
+		return nil
+	})
+	return snapshot
+}
+
+// RestorePluginConfigs pass the configs of the snapshot to the plugins again
+func (ps *PluginCommonService) RestorePluginConfigs(snapshot map[string][]byte, slugNames []string) {
+	for _, slugName := range slugNames {
+		configValue, ok := snapshot[slugName]
+		if !ok {
+			continue
+		}
+		err := plugin.CallConfig(func(fn plugin.Config) error {
+			if fn.Info().SlugName == slugName {
+				return fn.ConfigReceiver(configValue)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Errorf("restore plugin config failed: %s %v", slugName, err)
+		}
+	}
 }
 
 // UpdatePluginUserConfig update plugin config
@@ -154,22 +251,37 @@ func (ps *PluginCommonService) initPluginData() {
 		}
 	}
 
-	// init plugin config
+	// init plugin config, the values pinned by the environment variables override the saved ones
 	pluginConfigs, err := ps.pluginConfigRepo.GetPluginConfigAll(context.Background())
 	if err != nil {
 		log.Error(err)
 	} else {
+		configValues := make(map[string]string)
 		for _, pluginConfig := range pluginConfigs {
-			err := plugin.CallConfig(func(fn plugin.Config) error {
-				if fn.Info().SlugName == pluginConfig.PluginSlugName {
-					return fn.ConfigReceiver([]byte(pluginConfig.Value))
-				}
+			configValues[pluginConfig.PluginSlugName] = pluginConfig.Value
+		}
+		_ = plugin.CallConfig(func(fn plugin.Config) error {
+			slugName := fn.Info().SlugName
+			configValue, exist := configValues[slugName]
+			envNames := GetPluginConfigEnvNames(slugName)
+			if !exist && len(envNames) == 0 {
 				return nil
-			})
-			if err != nil {
-				log.Errorf("parse plugin config failed: %s %v", pluginConfig.PluginSlugName, err)
 			}
-		}
+			if len(envNames) > 0 {
+				configFields := make(map[string]any)
+				if exist {
+					if err := json.Unmarshal([]byte(configValue), &configFields); err != nil {
+						log.Errorf("parse plugin config failed: %s %v", slugName, err)
+					}
+				}
+				value, _ := json.Marshal(ApplyPluginConfigEnv(slugName, configFields))
+				configValue = string(value)
+			}
+			if err := fn.ConfigReceiver([]byte(configValue)); err != nil {
+				log.Errorf("parse plugin config failed: %s %v", slugName, err)
+			}
+			return nil
+		})
 
 		_ = plugin.CallCache(func(cache plugin.Cache) error {
 			ps.data.Cache = cache
@@ -177,6 +289,9 @@ func (ps *PluginCommonService) initPluginData() {
 		})
 	}
 
+	// build the built-in search index if it is enabled but still empty
+	ps.searchIndexService.RegisterSyncer(context.Background(), search_sync.NewPluginSyncer(ps.data))
+
 	// init plugin user config
 	plugin.RegisterGetPluginUserConfigFunc(func(userID, pluginSlugName string) []byte {
 		pluginUserConfig, exist, err := ps.pluginUserConfigRepo.GetPluginUserConfig(context.Background(), userID, pluginSlugName)
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin_common
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/apache/answer/plugin"
+)
+
+// PluginConfigEnvPrefix the environment variable ANSWER_PLUGIN_<SLUG_NAME>_<FIELD_NAME> pins the value of the
+// plugin config field, e.g. ANSWER_PLUGIN_S3_SECRET_KEY for the field secret_key of the plugin s3.
+// The pinned values are never saved in the database or exported.
+const PluginConfigEnvPrefix = "ANSWER_PLUGIN_"
+
+var envNameInvalidChars = regexp.MustCompile(`[^A-Z0-9]+`)
+
+// PluginConfigEnvName get the name of the environment variable which pins the plugin config field
+func PluginConfigEnvName(pluginSlugName, fieldName string) string {
+	name := strings.ToUpper(pluginSlugName + "_" + fieldName)
+	return PluginConfigEnvPrefix + envNameInvalidChars.ReplaceAllString(name, "_")
+}
+
+// GetPluginConfigEnvNames get the environment variables set for the plugin, the key is the field name
+func GetPluginConfigEnvNames(pluginSlugName string) (envNames map[string]string) {
+	envNames = make(map[string]string)
+	for _, field := range getPluginConfigFields(pluginSlugName) {
+		envName := PluginConfigEnvName(pluginSlugName, field.Name)
+		if _, ok := os.LookupEnv(envName); ok {
+			envNames[field.Name] = envName
+		}
+	}
+	return envNames
+}
+
+// ApplyPluginConfigEnv get a copy of the config with the values pinned by the environment variables
+func ApplyPluginConfigEnv(pluginSlugName string, configFields map[string]any) (merged map[string]any) {
+	merged = make(map[string]any, len(configFields))
+	for name, value := range configFields {
+		merged[name] = value
+	}
+	for _, field := range getPluginConfigFields(pluginSlugName) {
+		value, ok := os.LookupEnv(PluginConfigEnvName(pluginSlugName, field.Name))
+		if !ok {
+			continue
+		}
+		merged[field.Name] = value
+		// the switch is the only field whose value is not a string
+		if field.Type == plugin.ConfigTypeSwitch {
+			if b, err := strconv.ParseBool(value); err == nil {
+				merged[field.Name] = b
+			}
+		}
+	}
+	return merged
+}
+
+// StripPluginConfigEnv get a copy of the config without the values pinned by the environment variables
+func StripPluginConfigEnv(pluginSlugName string, configFields map[string]any) (stripped map[string]any) {
+	envNames := GetPluginConfigEnvNames(pluginSlugName)
+	stripped = make(map[string]any, len(configFields))
+	for name, value := range configFields {
+		if _, ok := envNames[name]; !ok {
+			stripped[name] = value
+		}
+	}
+	return stripped
+}
+
+func getPluginConfigFields(pluginSlugName string) (fields []plugin.ConfigField) {
+	_ = plugin.CallConfig(func(fn plugin.Config) error {
+		if fn.Info().SlugName == pluginSlugName {
+			fields = fn.ConfigFields()
+		}
+		return nil
+	})
+	return fields
+}
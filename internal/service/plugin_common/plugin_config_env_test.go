@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin_common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type envTestPlugin struct {
+	config map[string]any
+}
+
+func (p *envTestPlugin) Info() plugin.Info {
+	return plugin.Info{SlugName: "env-test"}
+}
+
+func (p *envTestPlugin) ConfigFields() []plugin.ConfigField {
+	return []plugin.ConfigField{
+		{Name: "secret_key", Type: plugin.ConfigTypeInput, Value: p.config["secret_key"]},
+		{Name: "enabled", Type: plugin.ConfigTypeSwitch, Value: p.config["enabled"]},
+		{Name: "region", Type: plugin.ConfigTypeInput, Value: p.config["region"]},
+	}
+}
+
+func (p *envTestPlugin) ConfigReceiver(config []byte) error {
+	received := make(map[string]any)
+	if err := json.Unmarshal(config, &received); err != nil {
+		return err
+	}
+	p.config = received
+	if received["region"] == "bad" {
+		return fmt.Errorf("region is invalid")
+	}
+	return nil
+}
+
+type envTestPluginConfigRepo struct {
+	configs []*entity.PluginConfig
+}
+
+func (r *envTestPluginConfigRepo) SavePluginConfig(ctx context.Context, pluginSlugName, configValue string) error {
+	return nil
+}
+
+func (r *envTestPluginConfigRepo) GetPluginConfigAll(ctx context.Context) ([]*entity.PluginConfig, error) {
+	return r.configs, nil
+}
+
+var testPlugin = &envTestPlugin{}
+
+func init() {
+	plugin.Register(testPlugin)
+}
+
+func TestPluginConfigEnv(t *testing.T) {
+	assert.Equal(t, "ANSWER_PLUGIN_ENV_TEST_SECRET_KEY", PluginConfigEnvName("env-test", "secret_key"))
+
+	t.Setenv("ANSWER_PLUGIN_ENV_TEST_SECRET_KEY", "s3cret")
+	t.Setenv("ANSWER_PLUGIN_ENV_TEST_ENABLED", "true")
+	assert.Equal(t, map[string]string{
+		"secret_key": "ANSWER_PLUGIN_ENV_TEST_SECRET_KEY",
+		"enabled":    "ANSWER_PLUGIN_ENV_TEST_ENABLED",
+	}, GetPluginConfigEnvNames("env-test"))
+	assert.Empty(t, GetPluginConfigEnvNames("not-installed"))
+
+	config := map[string]any{"secret_key": "saved", "enabled": false, "region": "us"}
+	assert.Equal(t, map[string]any{"secret_key": "s3cret", "enabled": true, "region": "us"},
+		ApplyPluginConfigEnv("env-test", config))
+	assert.Equal(t, map[string]any{"region": "us"}, StripPluginConfigEnv("env-test", config))
+	// the config is not changed
+	assert.Equal(t, "saved", config["secret_key"])
+}
+
+func TestPluginCommonService_ReceivePluginConfigs(t *testing.T) {
+	t.Setenv("ANSWER_PLUGIN_ENV_TEST_SECRET_KEY", "s3cret")
+	// the plugin has no saved config, it uses the one received before
+	ps := &PluginCommonService{pluginConfigRepo: &envTestPluginConfigRepo{}}
+	testPlugin.config = map[string]any{"region": "us", "enabled": true}
+
+	received, skipped, failedSlugName, err := ps.ReceivePluginConfigs(context.TODO(), map[string]map[string]any{
+		"env-test":      {"region": "eu"},
+		"not-installed": {"key": "value"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, failedSlugName)
+	assert.Equal(t, []string{"env-test"}, received)
+	assert.Equal(t, []string{"not-installed"}, skipped)
+	assert.Equal(t, map[string]any{"region": "eu", "secret_key": "s3cret"}, testPlugin.config)
+
+	// the rejected config is restored to the one the plugin used before
+	snapshot := ps.SnapshotPluginConfigs()
+	_, _, failedSlugName, err = ps.ReceivePluginConfigs(context.TODO(), map[string]map[string]any{
+		"env-test": {"region": "bad"},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "env-test", failedSlugName)
+	assert.Equal(t, map[string]any{"region": "eu", "secret_key": "s3cret", "enabled": nil}, testPlugin.config)
+	assert.Equal(t, snapshot, ps.SnapshotPluginConfigs())
+}
+
+func TestPluginCommonService_RestorePluginConfigs(t *testing.T) {
+	ps := &PluginCommonService{pluginConfigRepo: &envTestPluginConfigRepo{}}
+	testPlugin.config = map[string]any{"region": "us", "enabled": true}
+	snapshot := ps.SnapshotPluginConfigs()
+
+	received, _, _, err := ps.ReceivePluginConfigs(context.TODO(), map[string]map[string]any{
+		"env-test": {"region": "eu", "secret_key": "imported"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "eu", testPlugin.config["region"])
+
+	ps.RestorePluginConfigs(snapshot, received)
+	assert.Equal(t, map[string]any{"region": "us", "enabled": true, "secret_key": nil}, testPlugin.config)
+	assert.Equal(t, snapshot, ps.SnapshotPluginConfigs())
+}
@@ -34,19 +34,29 @@ import (
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/content"
 	"github.com/apache/answer/internal/service/dashboard"
+	"github.com/apache/answer/internal/service/digest"
+	"github.com/apache/answer/internal/service/draft"
+	"github.com/apache/answer/internal/service/email_reply"
 	"github.com/apache/answer/internal/service/event_queue"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/file_record"
 	"github.com/apache/answer/internal/service/follow"
+	hierarchicaltag "github.com/apache/answer/internal/service/hierarchical_tag"
 	"github.com/apache/answer/internal/service/importer"
 	"github.com/apache/answer/internal/service/meta"
 	metacommon "github.com/apache/answer/internal/service/meta_common"
+	"github.com/apache/answer/internal/service/moderation"
+	"github.com/apache/answer/internal/service/moderation_common"
 	"github.com/apache/answer/internal/service/notice_queue"
 	"github.com/apache/answer/internal/service/notification"
 	notficationcommon "github.com/apache/answer/internal/service/notification_common"
+	"github.com/apache/answer/internal/service/oauth_provider"
 	"github.com/apache/answer/internal/service/object_info"
+	"github.com/apache/answer/internal/service/personal_access_token"
 	"github.com/apache/answer/internal/service/plugin_common"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
+	"github.com/apache/answer/internal/service/question_schedule"
+	"github.com/apache/answer/internal/service/queue_message"
 	"github.com/apache/answer/internal/service/rank"
 	"github.com/apache/answer/internal/service/reason"
 	"github.com/apache/answer/internal/service/report"
@@ -54,7 +64,9 @@ import (
 	"github.com/apache/answer/internal/service/review"
 	"github.com/apache/answer/internal/service/revision_common"
 	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/internal/service/search_index"
 	"github.com/apache/answer/internal/service/search_parser"
+	"github.com/apache/answer/internal/service/search_sync"
 	"github.com/apache/answer/internal/service/siteinfo"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	"github.com/apache/answer/internal/service/tag"
@@ -64,6 +76,7 @@ import (
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/internal/service/user_external_login"
 	"github.com/apache/answer/internal/service/user_notification_config"
+	"github.com/apache/answer/internal/service/webhook"
 	"github.com/google/wire"
 )
 
@@ -128,5 +141,17 @@ var ProviderSetService = wire.NewSet(
 	badge.NewBadgeGroupService,
 	importer.NewImporterService,
 	file_record.NewFileRecordService,
-	NewHierarchicalTagService,
+	hierarchicaltag.NewHierarchicalTagService,
+	queue_message.NewQueueMessageService,
+	webhook.NewWebhookService,
+	search_index.NewSearchIndexService,
+	search_sync.NewSearchSyncService,
+	digest.NewDigestService,
+	email_reply.NewEmailReplyService,
+	personal_access_token.NewPersonalAccessTokenService,
+	oauth_provider.NewOAuthProviderService,
+	draft.NewDraftService,
+	question_schedule.NewQuestionScheduleService,
+	moderation_common.NewModerationCommonService,
+	moderation.NewModerationService,
 )
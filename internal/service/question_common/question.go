@@ -59,7 +59,7 @@ type QuestionRepo interface {
 	UpdateQuestion(ctx context.Context, question *entity.Question, Cols []string) (err error)
 	GetQuestion(ctx context.Context, id string) (question *entity.Question, exist bool, err error)
 	GetQuestionList(ctx context.Context, question *entity.Question) (questions []*entity.Question, err error)
-	GetQuestionPage(ctx context.Context, page, pageSize int, tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
+	GetQuestionPage(ctx context.Context, page, pageSize int, tagIDs, hierarchicalTagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
 		questionList []*entity.Question, total int64, err error)
 	GetRecommendQuestionPageByTags(ctx context.Context, userID string, tagIDs, followedQuestionIDs []string, page, pageSize int) (questionList []*entity.Question, total int64, err error)
 	UpdateQuestionStatus(ctx context.Context, questionID string, status int) (err error)
@@ -698,6 +698,17 @@ func (qs *QuestionCommon) ShowFormatWithTag(ctx context.Context, data *entity.Qu
 		Tags = append(Tags, item)
 	}
 	info.Tags = Tags
+	if data.HierarchicalTags != nil {
+		info.HierarchicalTags = make([]*schema.HierarchicalTagItem, 0, len(data.HierarchicalTags))
+		for _, tag := range data.HierarchicalTags {
+			info.HierarchicalTags = append(info.HierarchicalTags, &schema.HierarchicalTagItem{
+				ID:          tag.ID,
+				SlugName:    tag.SlugName,
+				DisplayName: tag.DisplayName,
+				Path:        tag.Path,
+			})
+		}
+	}
 	return info
 }
 
@@ -0,0 +1,262 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/config"
+	tagcommon "github.com/apache/answer/internal/service/tag_common"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+const (
+	// publishBatchSize the max number of scheduled questions published in one run
+	publishBatchSize = 100
+	// autoCloseBatchSize the max number of questions closed by one rule in one run
+	autoCloseBatchSize = 100
+	// questionCloseReasonsConfigKey the close reasons which can be chosen when closing a question
+	questionCloseReasonsConfigKey = "question.close.reasons"
+)
+
+// QuestionScheduleRepo question schedule repository
+type QuestionScheduleRepo interface {
+	AddSchedule(ctx context.Context, schedule *entity.QuestionSchedule) (err error)
+	GetDueSchedules(ctx context.Context, before time.Time, limit int) (schedules []*entity.QuestionSchedule, err error)
+	UpdateScheduleStatus(ctx context.Context, id string, status int) (err error)
+	PublishQuestion(ctx context.Context, scheduleID string, question *entity.Question,
+		enqueue func(session *xorm.Session) error) (published bool, err error)
+	AddAutoCloseRule(ctx context.Context, rule *entity.QuestionAutoCloseRule) (err error)
+	UpdateAutoCloseRule(ctx context.Context, rule *entity.QuestionAutoCloseRule) (err error)
+	DeleteAutoCloseRule(ctx context.Context, id string) (err error)
+	GetAutoCloseRule(ctx context.Context, id string) (rule *entity.QuestionAutoCloseRule, exist bool, err error)
+	GetAutoCloseRuleList(ctx context.Context) (rules []*entity.QuestionAutoCloseRule, err error)
+	GetActiveAutoCloseRules(ctx context.Context) (rules []*entity.QuestionAutoCloseRule, err error)
+	GetInactiveQuestions(ctx context.Context, rule *entity.QuestionAutoCloseRule, before time.Time, limit int) (
+		questions []*entity.Question, err error)
+}
+
+// QuestionScheduleService keeps the questions scheduled to publish and the rules closing the inactive questions
+type QuestionScheduleService struct {
+	questionScheduleRepo QuestionScheduleRepo
+	tagCommon            *tagcommon.TagCommonService
+	configService        *config.ConfigService
+}
+
+// NewQuestionScheduleService new question schedule service
+func NewQuestionScheduleService(
+	questionScheduleRepo QuestionScheduleRepo,
+	tagCommon *tagcommon.TagCommonService,
+	configService *config.ConfigService,
+) *QuestionScheduleService {
+	return &QuestionScheduleService{
+		questionScheduleRepo: questionScheduleRepo,
+		tagCommon:            tagCommon,
+		configService:        configService,
+	}
+}
+
+// AddSchedule hold the question until the publish time
+func (qs *QuestionScheduleService) AddSchedule(ctx context.Context, questionID, userID string, publishAt time.Time) (err error) {
+	return qs.questionScheduleRepo.AddSchedule(ctx, &entity.QuestionSchedule{
+		ID:         uid.ID().String(),
+		QuestionID: uid.DeShortID(questionID),
+		UserID:     userID,
+		PublishAt:  publishAt,
+		Status:     entity.QuestionScheduleStatusPending,
+	})
+}
+
+// GetDueSchedules get the schedules which should be published now
+func (qs *QuestionScheduleService) GetDueSchedules(ctx context.Context) (schedules []*entity.QuestionSchedule, err error) {
+	return qs.questionScheduleRepo.GetDueSchedules(ctx, time.Now(), publishBatchSize)
+}
+
+// FinishSchedule mark the schedule as published or cancelled, so it will not be picked up again
+func (qs *QuestionScheduleService) FinishSchedule(ctx context.Context, id string, status int) (err error) {
+	return qs.questionScheduleRepo.UpdateScheduleStatus(ctx, id, status)
+}
+
+// PublishQuestion make the scheduled question available and finish its schedule, enqueue adds the messages
+// announcing the question in the same transaction. published is false if the question is no longer scheduled.
+func (qs *QuestionScheduleService) PublishQuestion(ctx context.Context, scheduleID string, question *entity.Question,
+	enqueue func(session *xorm.Session) error) (published bool, err error) {
+	return qs.questionScheduleRepo.PublishQuestion(ctx, scheduleID, question, enqueue)
+}
+
+// GetActiveAutoCloseRules get the rules which should be applied
+func (qs *QuestionScheduleService) GetActiveAutoCloseRules(ctx context.Context) (
+	rules []*entity.QuestionAutoCloseRule, err error) {
+	return qs.questionScheduleRepo.GetActiveAutoCloseRules(ctx)
+}
+
+// GetInactiveQuestions get the questions matching the rule
+func (qs *QuestionScheduleService) GetInactiveQuestions(ctx context.Context, rule *entity.QuestionAutoCloseRule) (
+	questions []*entity.Question, err error) {
+	before := time.Now().AddDate(0, 0, -rule.InactiveDays)
+	return qs.questionScheduleRepo.GetInactiveQuestions(ctx, rule, before, autoCloseBatchSize)
+}
+
+// GetAutoCloseRuleList get all auto close rules
+func (qs *QuestionScheduleService) GetAutoCloseRuleList(ctx context.Context) (
+	resp []*schema.QuestionAutoCloseRuleItem, err error) {
+	rules, err := qs.questionScheduleRepo.GetAutoCloseRuleList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs := make([]string, 0)
+	for _, rule := range rules {
+		if rule.TagID != "0" {
+			tagIDs = append(tagIDs, rule.TagID)
+		}
+	}
+	tagMapping := make(map[string]string, len(tagIDs))
+	if len(tagIDs) > 0 {
+		tags, err := qs.tagCommon.GetTagListByIDs(ctx, tagIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			tagMapping[tag.ID] = tag.SlugName
+		}
+	}
+
+	resp = make([]*schema.QuestionAutoCloseRuleItem, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, &schema.QuestionAutoCloseRuleItem{
+			ID:           rule.ID,
+			Name:         rule.Name,
+			Tag:          tagMapping[rule.TagID],
+			InactiveDays: rule.InactiveDays,
+			NoAnswer:     rule.NoAnswer,
+			CloseType:    rule.CloseType,
+			CloseMsg:     rule.CloseMsg,
+			Active:       rule.Status == entity.QuestionAutoCloseRuleStatusActive,
+			CreatedAt:    rule.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// AddAutoCloseRule add auto close rule
+func (qs *QuestionScheduleService) AddAutoCloseRule(ctx context.Context, req *schema.AddQuestionAutoCloseRuleReq) (err error) {
+	if err = qs.checkCloseType(ctx, req.CloseType); err != nil {
+		return err
+	}
+	tagID, err := qs.getTagID(ctx, req.Tag)
+	if err != nil {
+		return err
+	}
+	return qs.questionScheduleRepo.AddAutoCloseRule(ctx, &entity.QuestionAutoCloseRule{
+		ID:           uid.ID().String(),
+		UserID:       req.UserID,
+		Name:         req.Name,
+		TagID:        tagID,
+		InactiveDays: req.InactiveDays,
+		NoAnswer:     req.NoAnswer,
+		CloseType:    req.CloseType,
+		CloseMsg:     req.CloseMsg,
+		Status:       autoCloseRuleStatus(req.Active),
+	})
+}
+
+// UpdateAutoCloseRule update auto close rule, the admin who updates it becomes the operator of the closing
+func (qs *QuestionScheduleService) UpdateAutoCloseRule(ctx context.Context, req *schema.UpdateQuestionAutoCloseRuleReq) (err error) {
+	_, exist, err := qs.questionScheduleRepo.GetAutoCloseRule(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.QuestionAutoCloseRuleNotFound)
+	}
+	if err = qs.checkCloseType(ctx, req.CloseType); err != nil {
+		return err
+	}
+	tagID, err := qs.getTagID(ctx, req.Tag)
+	if err != nil {
+		return err
+	}
+	return qs.questionScheduleRepo.UpdateAutoCloseRule(ctx, &entity.QuestionAutoCloseRule{
+		ID:           req.ID,
+		UserID:       req.UserID,
+		Name:         req.Name,
+		TagID:        tagID,
+		InactiveDays: req.InactiveDays,
+		NoAnswer:     req.NoAnswer,
+		CloseType:    req.CloseType,
+		CloseMsg:     req.CloseMsg,
+		Status:       autoCloseRuleStatus(req.Active),
+	})
+}
+
+// DeleteAutoCloseRule delete auto close rule
+func (qs *QuestionScheduleService) DeleteAutoCloseRule(ctx context.Context, req *schema.DeleteQuestionAutoCloseRuleReq) (err error) {
+	return qs.questionScheduleRepo.DeleteAutoCloseRule(ctx, req.ID)
+}
+
+// checkCloseType the close type must be one of the question close reasons,
+// the duplicate reason is not allowed because it needs the link of the original question
+func (qs *QuestionScheduleService) checkCloseType(ctx context.Context, closeType int) (err error) {
+	cf, err := qs.configService.GetConfigByID(ctx, closeType)
+	if err != nil || cf == nil {
+		return errors.BadRequest(reason.QuestionAutoCloseTypeInvalid)
+	}
+	if cf.Key == constant.ReasonADuplicate {
+		return errors.BadRequest(reason.QuestionAutoCloseTypeInvalid)
+	}
+	reasonKeys, err := qs.configService.GetArrayStringValue(ctx, questionCloseReasonsConfigKey)
+	if err != nil {
+		return err
+	}
+	for _, key := range reasonKeys {
+		if key == cf.Key {
+			return nil
+		}
+	}
+	return errors.BadRequest(reason.QuestionAutoCloseTypeInvalid)
+}
+
+func (qs *QuestionScheduleService) getTagID(ctx context.Context, slugName string) (tagID string, err error) {
+	if len(slugName) == 0 {
+		return "0", nil
+	}
+	tag, exist, err := qs.tagCommon.GetTagBySlugName(ctx, slugName)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "", errors.BadRequest(reason.TagNotFound)
+	}
+	return tag.ID, nil
+}
+
+func autoCloseRuleStatus(active bool) int {
+	if active {
+		return entity.QuestionAutoCloseRuleStatusActive
+	}
+	return entity.QuestionAutoCloseRuleStatusInactive
+}
@@ -0,0 +1,228 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package queue_message
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
+)
+
+const (
+	// queueBatchSize the max number of messages claimed at once
+	queueBatchSize = 20
+	// queuePollInterval how often the outbox is checked when there is no new message
+	queuePollInterval = 5 * time.Second
+	// queueLease how long a claimed message is hidden from the other workers
+	queueLease = 5 * time.Minute
+	// queueMaxAttempts messages failing more than this are moved to dead-letter
+	queueMaxAttempts = 8
+	queueBaseBackoff = 10 * time.Second
+	queueMaxBackoff  = time.Hour
+)
+
+// Queue a durable queue of T backed by the queue_message table.
+// Messages are kept until the handler succeeds, failed ones are retried with
+// exponential backoff and moved to dead-letter after queueMaxAttempts.
+type Queue[T any] struct {
+	name     string
+	repo     QueueMessageRepo
+	notify   chan struct{}
+	once     sync.Once
+	orderKey func(msg *T) string
+	handler  func(ctx context.Context, msg *T) error
+}
+
+// NewQueue create a new durable queue, the worker starts when the handler is registered
+func NewQueue[T any](name string, repo QueueMessageRepo) *Queue[T] {
+	return &Queue[T]{
+		name:   name,
+		repo:   repo,
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// NewOrderedQueue create a new durable queue in which the messages with the same order key are handled one by one
+// in the order they are sent, a failed message holds the following ones back until it is handled or dead.
+func NewOrderedQueue[T any](name string, repo QueueMessageRepo, orderKey func(msg *T) string) *Queue[T] {
+	q := NewQueue[T](name, repo)
+	q.orderKey = orderKey
+	return q
+}
+
+// Send add the message to the outbox, the message is lost if an error is returned
+func (q *Queue[T]) Send(ctx context.Context, msg *T) (err error) {
+	message, err := q.NewMessage(msg)
+	if err == nil {
+		err = q.repo.AddMessages(ctx, message)
+	}
+	if err != nil {
+		log.Errorf("add %s queue message failed: %v", q.name, err)
+		return err
+	}
+	q.Notify()
+	return nil
+}
+
+// SendWithSession add the message to the outbox in the transaction of the caller,
+// the message is handled only if the transaction is committed
+func (q *Queue[T]) SendWithSession(ctx context.Context, session *xorm.Session, msg *T) (err error) {
+	message, err := q.NewMessage(msg)
+	if err != nil {
+		return err
+	}
+	if err = q.repo.AddMessagesWithSession(ctx, session, message); err != nil {
+		return err
+	}
+	q.Notify()
+	return nil
+}
+
+// NewMessage build the outbox message of msg without adding it
+func (q *Queue[T]) NewMessage(msg *T) (message *entity.QueueMessage, err error) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s queue message failed: %w", q.name, err)
+	}
+	message = &entity.QueueMessage{
+		Queue:     q.name,
+		Payload:   string(payload),
+		Status:    entity.QueueMessageStatusPending,
+		NextRunAt: time.Now(),
+	}
+	if q.orderKey != nil {
+		message.OrderKey = q.orderKey(msg)
+	}
+	return message, nil
+}
+
+// Notify wake up the worker to check the outbox now instead of waiting for the next poll
+func (q *Queue[T]) Notify() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// RegisterHandler set the handler and start consuming the outbox
+func (q *Queue[T]) RegisterHandler(handler func(ctx context.Context, msg *T) error) {
+	q.handler = handler
+	q.once.Do(q.working)
+}
+
+func (q *Queue[T]) working() {
+	go func() {
+		ticker := time.NewTicker(queuePollInterval)
+		defer ticker.Stop()
+		for {
+			if q.consume() < queueBatchSize {
+				select {
+				case <-q.notify:
+				case <-ticker.C:
+				}
+			}
+		}
+	}()
+}
+
+// consume handle a batch of due messages and return how many were claimed
+func (q *Queue[T]) consume() int {
+	ctx := context.Background()
+	messages, err := q.repo.ClaimDueMessages(ctx, q.name, queueBatchSize, queueLease)
+	if err != nil {
+		log.Errorf("claim %s queue messages failed: %v", q.name, err)
+		return 0
+	}
+	for _, message := range messages {
+		log.Debugf("received %s %s", q.name, message.Payload)
+		if err := q.handle(ctx, message); err != nil {
+			log.Errorf("handle %s queue message %d failed: %v", q.name, message.ID, err)
+			q.fail(ctx, message, err)
+			continue
+		}
+		if err := q.repo.DeleteMessage(ctx, message.ID); err != nil {
+			log.Error(err)
+		}
+	}
+	return len(messages)
+}
+
+func (q *Queue[T]) handle(ctx context.Context, message *entity.QueueMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	msg := new(T)
+	if err = json.Unmarshal([]byte(message.Payload), msg); err != nil {
+		return err
+	}
+	return q.handler(WithIdempotencyKey(ctx, message.ID, fmt.Sprintf("%s:%d", q.name, message.ID)), msg)
+}
+
+type idempotencyKeyCtxKey struct{}
+
+type idempotencyKey struct {
+	messageID int64
+	key       string
+}
+
+// WithIdempotencyKey set the key of the message being handled to ctx
+func WithIdempotencyKey(ctx context.Context, messageID int64, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, &idempotencyKey{messageID: messageID, key: key})
+}
+
+// IdempotencyKey get the key of the message being handled, it is the same for every attempt of the message
+// and unique for each message and handler. ok is false if ctx does not come from a queue handler.
+func IdempotencyKey(ctx context.Context) (messageID int64, key string, ok bool) {
+	k, ok := ctx.Value(idempotencyKeyCtxKey{}).(*idempotencyKey)
+	if !ok {
+		return 0, "", false
+	}
+	return k.messageID, k.key, true
+}
+
+func (q *Queue[T]) fail(ctx context.Context, message *entity.QueueMessage, handleErr error) {
+	attempts := message.Attempts + 1
+	var err error
+	if attempts >= queueMaxAttempts {
+		err = q.repo.UpdateMessageDead(ctx, message.ID, attempts, handleErr.Error())
+	} else {
+		err = q.repo.UpdateMessageRetry(ctx, message.ID, attempts, time.Now().Add(backoff(attempts)), handleErr.Error())
+	}
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// backoff 10s, 20s, 40s ... up to an hour
+func backoff(attempts int) time.Duration {
+	d := queueBaseBackoff << (attempts - 1)
+	if d <= 0 || d > queueMaxBackoff {
+		return queueMaxBackoff
+	}
+	return d
+}
@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package queue_message
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/answer/internal/base/pager"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"xorm.io/xorm"
+)
+
+// QueueMessageRepo queue message repository
+type QueueMessageRepo interface {
+	// AddMessages add the messages in one transaction
+	AddMessages(ctx context.Context, messages ...*entity.QueueMessage) (err error)
+	// AddMessagesWithSession add the messages in the transaction of the caller
+	AddMessagesWithSession(ctx context.Context, session *xorm.Session, messages ...*entity.QueueMessage) (err error)
+	// ClaimDueMessages lock the due pending messages and the ones whose lease has expired for the lease duration,
+	// a message is skipped while an earlier one with the same order key is still pending or processing
+	ClaimDueMessages(ctx context.Context, queue string, limit int, lease time.Duration) (
+		messages []*entity.QueueMessage, err error)
+	DeleteMessage(ctx context.Context, id int64) (err error)
+	UpdateMessageRetry(ctx context.Context, id int64, attempts int, nextRunAt time.Time, lastError string) (err error)
+	UpdateMessageDead(ctx context.Context, id int64, attempts int, lastError string) (err error)
+	GetMessagePage(ctx context.Context, page, pageSize int, queue string, status int) (
+		messages []*entity.QueueMessage, total int64, err error)
+	// ReplayMessages reset the given pending or dead messages to be handled again right now
+	ReplayMessages(ctx context.Context, ids []int64) (affected int64, err error)
+}
+
+// QueueMessageService lets the admin inspect and replay the queued messages
+type QueueMessageService struct {
+	queueMessageRepo QueueMessageRepo
+}
+
+// NewQueueMessageService new queue message service
+func NewQueueMessageService(queueMessageRepo QueueMessageRepo) *QueueMessageService {
+	return &QueueMessageService{
+		queueMessageRepo: queueMessageRepo,
+	}
+}
+
+// GetQueueMessagePage get queue messages by page
+func (qs *QueueMessageService) GetQueueMessagePage(ctx context.Context, req *schema.GetQueueMessagePageReq) (
+	pageModel *pager.PageModel, err error) {
+	messages, total, err := qs.queueMessageRepo.GetMessagePage(ctx, req.Page, req.PageSize, req.Queue, req.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]*schema.QueueMessageItem, 0, len(messages))
+	for _, message := range messages {
+		resp = append(resp, &schema.QueueMessageItem{
+			ID:        message.ID,
+			Queue:     message.Queue,
+			Payload:   message.Payload,
+			Status:    schema.QueueMessageStatusName(message.Status),
+			Attempts:  message.Attempts,
+			NextRunAt: message.NextRunAt.Unix(),
+			LastError: message.LastError,
+			CreatedAt: message.CreatedAt.Unix(),
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// ReplayQueueMessages put the failed messages back to the queue
+func (qs *QueueMessageService) ReplayQueueMessages(ctx context.Context, req *schema.ReplayQueueMessagesReq) (
+	resp *schema.ReplayQueueMessagesResp, err error) {
+	affected, err := qs.queueMessageRepo.ReplayMessages(ctx, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.ReplayQueueMessagesResp{Replayed: affected}, nil
+}
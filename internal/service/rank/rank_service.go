@@ -225,12 +225,12 @@ func (rs *RankService) CheckVotePermission(ctx context.Context, userID, objectID
 // getUserPowerMapping get user power mapping
 func (rs *RankService) getUserPowerMapping(ctx context.Context, userID string) (powerMapping map[string]bool) {
 	powerMapping = make(map[string]bool, 0)
-	userRole, err := rs.roleService.GetUserRole(ctx, userID)
+	userRoles, err := rs.roleService.GetUserRoleIDs(ctx, userID)
 	if err != nil {
 		log.Error(err)
 		return powerMapping
 	}
-	powers, err := rs.rolePowerService.GetRolePowerList(ctx, userRole)
+	powers, err := rs.rolePowerService.GetRolesPowerList(ctx, userRoles)
 	if err != nil {
 		log.Error(err)
 		return powerMapping
@@ -32,6 +32,7 @@ import (
 	answercommon "github.com/apache/answer/internal/service/answer_common"
 	"github.com/apache/answer/internal/service/comment_common"
 	"github.com/apache/answer/internal/service/config"
+	"github.com/apache/answer/internal/service/moderation_common"
 	"github.com/apache/answer/internal/service/object_info"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
 	"github.com/apache/answer/internal/service/report_common"
@@ -57,6 +58,7 @@ type ReportService struct {
 	reportHandle      *report_handle.ReportHandle
 	configService     *config.ConfigService
 	eventQueueService event_queue.EventQueueService
+	moderationCommon  *moderation_common.ModerationCommonService
 }
 
 // NewReportService new report service
@@ -70,6 +72,7 @@ func NewReportService(
 	reportHandle *report_handle.ReportHandle,
 	configService *config.ConfigService,
 	eventQueueService event_queue.EventQueueService,
+	moderationCommon *moderation_common.ModerationCommonService,
 ) *ReportService {
 	return &ReportService{
 		reportRepo:        reportRepo,
@@ -81,6 +84,7 @@ func NewReportService(
 		reportHandle:      reportHandle,
 		configService:     configService,
 		eventQueueService: eventQueueService,
+		moderationCommon:  moderationCommon,
 	}
 }
 
@@ -216,16 +220,31 @@ func (rs *ReportService) ReviewReport(ctx context.Context, req *schema.ReviewRep
 		return nil
 	}
 
-	// ignore this report
-	if req.OperationType == constant.ReportOperationIgnoreReport {
-		return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusIgnore)
+	if err = rs.moderationCommon.CheckClaim(ctx, entity.ModerationItemTypeReport, report.ID, req.UserID); err != nil {
+		return err
 	}
+	before := rs.moderationCommon.Snapshot(ctx, report.Status, report.ObjectID)
 
-	if err = rs.reportHandle.UpdateReportedObject(ctx, report, req); err != nil {
+	status := entity.ReportStatusCompleted
+	// ignore this report
+	if req.OperationType == constant.ReportOperationIgnoreReport {
+		status = entity.ReportStatusIgnore
+	} else if err = rs.reportHandle.UpdateReportedObject(ctx, report, req); err != nil {
 		return
 	}
+	if err = rs.reportRepo.UpdateStatus(ctx, report.ID, status); err != nil {
+		return err
+	}
 
-	return rs.reportRepo.UpdateStatus(ctx, report.ID, entity.ReportStatusCompleted)
+	rs.moderationCommon.Record(ctx, &entity.ModerationLog{
+		UserID:   req.UserID,
+		ItemType: entity.ModerationItemTypeReport,
+		ItemID:   report.ID,
+		ObjectID: report.ObjectID,
+		Action:   req.OperationType,
+		Reason:   req.Reason,
+	}, before, rs.moderationCommon.Snapshot(ctx, status, report.ObjectID))
+	return nil
 }
 
 func (rs *ReportService) sendEvent(ctx context.Context,
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package review
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/pkg/htmltext"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	trLocalReviewerName        = "review.local_reviewer.name"
+	trLocalReviewerKeyword     = "review.local_reviewer.keyword"
+	trLocalReviewerPattern     = "review.local_reviewer.pattern"
+	trLocalReviewerLinks       = "review.local_reviewer.links"
+	trLocalReviewerLinkDensity = "review.local_reviewer.link_density"
+	trLocalReviewerNewAccount  = "review.local_reviewer.new_account"
+)
+
+var linkRegexp = regexp.MustCompile(`(?i)<a\s[^>]*href\s*=`)
+
+// localReviewer the built-in reviewer, it checks the post with the word lists, the links and the age of the author
+// so that the site does not need an external service
+type localReviewer struct {
+	config   *schema.SiteLocalReviewer
+	patterns []*regexp.Regexp
+	// authorCreatedAt the time the author registered, it is zero if unknown
+	authorCreatedAt time.Time
+}
+
+func newLocalReviewer(config *schema.SiteLocalReviewer, authorCreatedAt time.Time) *localReviewer {
+	lr := &localReviewer{config: config, authorCreatedAt: authorCreatedAt}
+	for _, pattern := range config.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Warnf("local reviewer pattern %s is invalid: %v", pattern, err)
+			continue
+		}
+		lr.patterns = append(lr.patterns, re)
+	}
+	return lr
+}
+
+// Review review the content like a reviewer plugin
+func (lr *localReviewer) Review(content *plugin.ReviewContent) (result *plugin.ReviewResult) {
+	lang := i18n.Language(content.Language)
+	text := content.Title + "\n" + htmltext.ClearText(content.Content)
+	matchStatus := plugin.ReviewStatus(lr.config.MatchStatus)
+
+	lowerText := strings.ToLower(text)
+	for _, keyword := range lr.config.Keywords {
+		if len(keyword) > 0 && strings.Contains(lowerText, strings.ToLower(keyword)) {
+			return lr.reject(matchStatus, translator.TrWithData(lang, trLocalReviewerKeyword,
+				map[string]any{"Keyword": keyword}))
+		}
+	}
+	// the links are only in the html, so the patterns are matched with both
+	for _, re := range lr.patterns {
+		if re.MatchString(text) || re.MatchString(content.Content) {
+			return lr.reject(matchStatus, translator.TrWithData(lang, trLocalReviewerPattern,
+				map[string]any{"Pattern": re.String()}))
+		}
+	}
+
+	links := len(linkRegexp.FindAllStringIndex(content.Content, -1))
+	if links == 0 {
+		return &plugin.ReviewResult{Approved: true, ReviewStatus: plugin.ReviewStatusApproved}
+	}
+	if lr.isNewAccount(content.Author) && links > lr.config.NewAccountMaxLinks {
+		return lr.reject(plugin.ReviewStatusNeedReview, translator.TrWithData(lang, trLocalReviewerNewAccount,
+			map[string]any{"Links": links}))
+	}
+	if lr.config.MaxLinks > 0 && links > lr.config.MaxLinks {
+		return lr.reject(plugin.ReviewStatusNeedReview, translator.TrWithData(lang, trLocalReviewerLinks,
+			map[string]any{"Links": links}))
+	}
+	if lr.config.MaxLinkDensity > 0 {
+		words := max(len(strings.Fields(text)), 1)
+		if links*100 > lr.config.MaxLinkDensity*words {
+			return lr.reject(plugin.ReviewStatusNeedReview, translator.TrWithData(lang, trLocalReviewerLinkDensity,
+				map[string]any{"Links": links, "Words": words}))
+		}
+	}
+	return &plugin.ReviewResult{Approved: true, ReviewStatus: plugin.ReviewStatusApproved}
+}
+
+// isNewAccount the staff are never new accounts
+func (lr *localReviewer) isNewAccount(author plugin.ReviewContentAuthor) bool {
+	if author.Role == role.RoleAdminID || author.Role == role.RoleModeratorID {
+		return false
+	}
+	if lr.config.NewAccountDays > 0 && !lr.authorCreatedAt.IsZero() &&
+		time.Since(lr.authorCreatedAt) < time.Duration(lr.config.NewAccountDays)*24*time.Hour {
+		return true
+	}
+	return lr.config.NewAccountRank > 0 && author.Rank < lr.config.NewAccountRank
+}
+
+func (lr *localReviewer) reject(status plugin.ReviewStatus, reason string) *plugin.ReviewResult {
+	return &plugin.ReviewResult{Approved: false, ReviewStatus: status, Reason: reason}
+}
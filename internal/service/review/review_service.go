@@ -21,13 +21,18 @@ package review
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
 	"github.com/apache/answer/internal/base/pager"
 	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	answercommon "github.com/apache/answer/internal/service/answer_common"
+	"github.com/apache/answer/internal/service/moderation_common"
 	"github.com/apache/answer/internal/service/notice_queue"
 	"github.com/apache/answer/internal/service/object_info"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
@@ -51,6 +56,7 @@ type ReviewRepo interface {
 	GetReview(ctx context.Context, reviewID int) (review *entity.Review, exist bool, err error)
 	GetReviewByObject(ctx context.Context, objectID string) (review *entity.Review, exist bool, err error)
 	GetReviewCount(ctx context.Context, status int) (count int64, err error)
+	GetUserRejectedReviewCount(ctx context.Context, userID string, since time.Time) (count int64, err error)
 	GetReviewPage(ctx context.Context, page, pageSize int, cond *entity.Review) (reviewList []*entity.Review, total int64, err error)
 }
 
@@ -68,6 +74,7 @@ type ReviewService struct {
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	notificationQueueService         notice_queue.NotificationQueueService
 	siteInfoService                  siteinfo_common.SiteInfoCommonService
+	moderationCommon                 *moderation_common.ModerationCommonService
 }
 
 // NewReviewService new review service
@@ -84,6 +91,7 @@ func NewReviewService(
 	questionCommon *questioncommon.QuestionCommon,
 	notificationQueueService notice_queue.NotificationQueueService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	moderationCommon *moderation_common.ModerationCommonService,
 ) *ReviewService {
 	return &ReviewService{
 		reviewRepo:                       reviewRepo,
@@ -98,6 +106,7 @@ func NewReviewService(
 		questionCommon:                   questionCommon,
 		notificationQueueService:         notificationQueueService,
 		siteInfoService:                  siteInfoService,
+		moderationCommon:                 moderationCommon,
 	}
 }
 
@@ -171,6 +180,75 @@ func (cs *ReviewService) getReviewContentAuthorInfo(ctx context.Context, userID
 	return
 }
 
+// reviewStep a reviewer in the review pipeline with its setting
+type reviewStep struct {
+	slugName string
+	reviewer interface {
+		Review(content *plugin.ReviewContent) *plugin.ReviewResult
+	}
+	weight        int
+	stopOnApprove bool
+	stopOnReject  bool
+}
+
+// getReviewSteps get the reviewers in the order of the policy, the enabled reviewers which are not listed
+// run after them and stop the pipeline once they do not approve the post, as they did before the policy
+func (cs *ReviewService) getReviewSteps(ctx context.Context, policy *schema.SiteReviewResp, userID string) (
+	steps []*reviewStep) {
+	reviewers := make(map[string]plugin.Reviewer)
+	var slugNames []string
+	_ = plugin.CallReviewer(func(reviewer plugin.Reviewer) error {
+		slugName := reviewer.Info().SlugName
+		reviewers[slugName] = reviewer
+		slugNames = append(slugNames, slugName)
+		return nil
+	})
+
+	var local *localReviewer
+	if policy.LocalReviewer != nil && policy.LocalReviewer.Enabled {
+		var createdAt time.Time
+		if userInfo, exist, err := cs.userRepo.GetByUserID(ctx, userID); err != nil {
+			log.Errorf("get user info failed, err: %v", err)
+		} else if exist {
+			createdAt = userInfo.CreatedAt
+		}
+		local = newLocalReviewer(policy.LocalReviewer, createdAt)
+	}
+
+	listed := make(map[string]bool)
+	for _, item := range policy.Reviewers {
+		listed[item.SlugName] = true
+		if !item.Enabled {
+			continue
+		}
+		step := &reviewStep{slugName: item.SlugName, weight: item.Weight,
+			stopOnApprove: item.StopOnApprove, stopOnReject: item.StopOnReject}
+		if item.SlugName == schema.LocalReviewerSlugName {
+			if local == nil {
+				continue
+			}
+			step.reviewer = local
+		} else if reviewer, ok := reviewers[item.SlugName]; ok {
+			step.reviewer = reviewer
+		} else {
+			continue
+		}
+		steps = append(steps, step)
+	}
+
+	if local != nil && !listed[schema.LocalReviewerSlugName] {
+		steps = append(steps, &reviewStep{slugName: schema.LocalReviewerSlugName, reviewer: local,
+			weight: 1, stopOnReject: true})
+	}
+	for _, slugName := range slugNames {
+		if !listed[slugName] {
+			steps = append(steps, &reviewStep{slugName: slugName, reviewer: reviewers[slugName],
+				weight: 1, stopOnReject: true})
+		}
+	}
+	return steps
+}
+
 // call plugin to review
 func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectID string,
 	reviewContent *plugin.ReviewContent) (reviewStatus plugin.ReviewStatus) {
@@ -188,26 +266,127 @@ func (cs *ReviewService) callPluginToReview(ctx context.Context, userID, objectI
 	if siteInterface, _ := cs.siteInfoService.GetSiteInterface(ctx); siteInterface != nil {
 		reviewContent.Language = siteInterface.Language
 	}
+	policy, err := cs.siteInfoService.GetSiteReview(ctx)
+	if err != nil {
+		log.Errorf("get site review failed, err: %v", err)
+		policy = schema.DefaultSiteReview()
+	}
 
-	_ = plugin.CallReviewer(func(reviewer plugin.Reviewer) error {
-		// If one of the reviewer plugin return false, then the review is not approved
-		if reviewStatus != plugin.ReviewStatusApproved {
-			return nil
+	verdict := runReviewSteps(cs.getReviewSteps(ctx, policy, userID), policy, reviewContent, r)
+	if verdict == plugin.ReviewStatusApproved {
+		return reviewStatus
+	}
+
+	action := policy.NeedReviewAction
+	if verdict == plugin.ReviewStatusDeleteDirectly {
+		action = policy.DeleteAction
+	}
+	switch action {
+	case schema.ReviewActionDelete:
+		// the deleted post is recorded as rejected, so that it counts for the suspension of the author
+		reviewStatus = plugin.ReviewStatusDeleteDirectly
+		r.Status = entity.ReviewStatusRejected
+	case schema.ReviewActionShadowHide:
+		reviewStatus = plugin.ReviewStatusNeedReview
+		r.ShadowHide = true
+	default:
+		reviewStatus = plugin.ReviewStatusNeedReview
+	}
+
+	if err := cs.reviewRepo.AddReview(ctx, r); err != nil {
+		log.Errorf("add review failed, err: %v", err)
+	}
+	if reviewStatus == plugin.ReviewStatusDeleteDirectly {
+		cs.checkAuthorRejections(ctx, policy, userID)
+	}
+	return reviewStatus
+}
+
+// runReviewSteps run the reviewers and sum up the weights of them which do not approve the post,
+// the first of them is recorded as the submitter of the review
+func runReviewSteps(steps []*reviewStep, policy *schema.SiteReviewResp,
+	reviewContent *plugin.ReviewContent, r *entity.Review) (verdict plugin.ReviewStatus) {
+	var reviewWeight, deleteWeight int
+	for _, step := range steps {
+		result := step.reviewer.Review(reviewContent)
+		if result == nil || result.Approved {
+			if step.stopOnApprove {
+				break
+			}
+			continue
+		}
+		switch result.ReviewStatus {
+		case plugin.ReviewStatusDeleteDirectly:
+			deleteWeight += step.weight
+		case plugin.ReviewStatusNeedReview:
+			reviewWeight += step.weight
+		default:
+			continue
 		}
-		if result := reviewer.Review(reviewContent); !result.Approved {
-			reviewStatus = result.ReviewStatus
+		if len(r.Submitter) == 0 {
+			r.Submitter = step.slugName
 			r.Reason = result.Reason
-			r.Submitter = reviewer.Info().SlugName
 		}
-		return nil
-	})
-
-	if reviewStatus == plugin.ReviewStatusNeedReview {
-		if err := cs.reviewRepo.AddReview(ctx, r); err != nil {
-			log.Errorf("add review failed, err: %v", err)
+		if step.stopOnReject {
+			break
 		}
 	}
-	return reviewStatus
+
+	switch {
+	case deleteWeight > 0 && deleteWeight >= policy.DeleteThreshold:
+		return plugin.ReviewStatusDeleteDirectly
+	case reviewWeight+deleteWeight > 0 && reviewWeight+deleteWeight >= policy.ReviewThreshold:
+		return plugin.ReviewStatusNeedReview
+	default:
+		return plugin.ReviewStatusApproved
+	}
+}
+
+// checkAuthorRejections suspend the author if the posts of the author are rejected too many times
+func (cs *ReviewService) checkAuthorRejections(ctx context.Context, policy *schema.SiteReviewResp, userID string) {
+	if policy.SuspendAfterRejections <= 0 {
+		return
+	}
+	roleID, err := cs.userRoleService.GetUserRole(ctx, userID)
+	if err != nil {
+		log.Errorf("get user role failed, err: %v", err)
+		return
+	}
+	if roleID != role.RoleUserID {
+		return
+	}
+	since := time.Now().AddDate(0, 0, -policy.RejectionWindowDays)
+	count, err := cs.reviewRepo.GetUserRejectedReviewCount(ctx, userID, since)
+	if err != nil {
+		log.Errorf("get user rejected review count failed, err: %v", err)
+		return
+	}
+	if count < int64(policy.SuspendAfterRejections) {
+		return
+	}
+	userInfo, exist, err := cs.userRepo.GetByUserID(ctx, userID)
+	if err != nil || !exist || userInfo.Status != entity.UserStatusAvailable {
+		return
+	}
+	suspendedUntil := entity.PermanentSuspensionTime
+	if policy.SuspendDays > 0 {
+		suspendedUntil = time.Now().AddDate(0, 0, policy.SuspendDays)
+	}
+	if err = cs.userCommon.SuspendUser(ctx, userInfo, suspendedUntil); err != nil {
+		log.Errorf("suspend user %s failed, err: %v", userID, err)
+		return
+	}
+	log.Infof("user %s is suspended after %d rejected posts", userID, count)
+}
+
+// IsShadowHidden whether the pending post is shadow hidden, the author sees it as a normal post
+func (cs *ReviewService) IsShadowHidden(ctx context.Context, objectID string) bool {
+	review, exist, err := cs.reviewRepo.GetReviewByObject(ctx, uid.DeShortID(objectID))
+	if err != nil {
+		log.Errorf("get review by object failed, err: %v", err)
+		return false
+	}
+	return exist && review.Status == entity.ReviewStatusPending && review.ShadowHide
 }
 
 // UpdateReview update review
@@ -222,17 +401,41 @@ func (cs *ReviewService) UpdateReview(ctx context.Context, req *schema.UpdateRev
 	if review.Status != entity.ReviewStatusPending {
 		return nil
 	}
+	itemID := strconv.Itoa(review.ID)
+	if err = cs.moderationCommon.CheckClaim(ctx, entity.ModerationItemTypeReview, itemID, req.UserID); err != nil {
+		return err
+	}
+	before := cs.moderationCommon.Snapshot(ctx, review.Status, review.ObjectID)
 
 	if err = cs.updateObjectStatus(ctx, review, req.IsApprove()); err != nil {
 		return err
 	}
 
+	status := entity.ReviewStatusRejected
 	if req.IsApprove() {
-		err = cs.reviewRepo.UpdateReviewStatus(ctx, req.ReviewID, req.UserID, entity.ReviewStatusApproved)
-	} else {
-		err = cs.reviewRepo.UpdateReviewStatus(ctx, req.ReviewID, req.UserID, entity.ReviewStatusRejected)
+		status = entity.ReviewStatusApproved
 	}
-	return
+	if err = cs.reviewRepo.UpdateReviewStatus(ctx, req.ReviewID, req.UserID, status); err != nil {
+		return err
+	}
+
+	if !req.IsApprove() {
+		if policy, err := cs.siteInfoService.GetSiteReview(ctx); err != nil {
+			log.Errorf("get site review failed, err: %v", err)
+		} else {
+			cs.checkAuthorRejections(ctx, policy, review.UserID)
+		}
+	}
+
+	cs.moderationCommon.Record(ctx, &entity.ModerationLog{
+		UserID:   req.UserID,
+		ItemType: entity.ModerationItemTypeReview,
+		ItemID:   itemID,
+		ObjectID: review.ObjectID,
+		Action:   req.Status,
+		Reason:   req.Reason,
+	}, before, cs.moderationCommon.Snapshot(ctx, status, review.ObjectID))
+	return nil
 }
 
 // update object status
@@ -384,6 +587,9 @@ func (cs *ReviewService) GetUnreviewedPostPage(ctx context.Context, req *schema.
 		return
 	}
 
+	if req.ReviewerMapping != nil {
+		req.ReviewerMapping[schema.LocalReviewerSlugName] = translator.Tr(handler.GetLangByCtx(ctx), trLocalReviewerName)
+	}
 	resp := make([]*schema.GetUnreviewedPostPageResp, 0)
 	for _, review := range reviewList {
 		info, err := cs.objectInfoService.GetUnreviewedRevisionInfo(ctx, review.ObjectID)
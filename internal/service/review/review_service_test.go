@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package review
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/role"
+	"github.com/apache/answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+type fixedReviewer struct {
+	status plugin.ReviewStatus
+	called int
+}
+
+func (f *fixedReviewer) Review(content *plugin.ReviewContent) *plugin.ReviewResult {
+	f.called++
+	return &plugin.ReviewResult{Approved: f.status == plugin.ReviewStatusApproved, ReviewStatus: f.status, Reason: "reason"}
+}
+
+func TestRunReviewSteps(t *testing.T) {
+	policy := schema.DefaultSiteReview()
+	policy.ReviewThreshold = 3
+	policy.DeleteThreshold = 2
+	content := &plugin.ReviewContent{}
+
+	approve := &fixedReviewer{status: plugin.ReviewStatusApproved}
+	hold := &fixedReviewer{status: plugin.ReviewStatusNeedReview}
+	del := &fixedReviewer{status: plugin.ReviewStatusDeleteDirectly}
+
+	// the weight of one reviewer does not reach the threshold
+	r := &entity.Review{}
+	verdict := runReviewSteps([]*reviewStep{
+		{slugName: "a", reviewer: approve, weight: 1},
+		{slugName: "b", reviewer: hold, weight: 2},
+	}, policy, content, r)
+	assert.Equal(t, plugin.ReviewStatusApproved, verdict)
+
+	r = &entity.Review{}
+	verdict = runReviewSteps([]*reviewStep{
+		{slugName: "b", reviewer: hold, weight: 2},
+		{slugName: "c", reviewer: del, weight: 1},
+	}, policy, content, r)
+	assert.Equal(t, plugin.ReviewStatusNeedReview, verdict)
+	assert.Equal(t, "b", r.Submitter)
+
+	r = &entity.Review{}
+	verdict = runReviewSteps([]*reviewStep{
+		{slugName: "c", reviewer: del, weight: 2},
+	}, policy, content, r)
+	assert.Equal(t, plugin.ReviewStatusDeleteDirectly, verdict)
+
+	// the later reviewers are skipped
+	hold.called = 0
+	verdict = runReviewSteps([]*reviewStep{
+		{slugName: "a", reviewer: approve, weight: 1, stopOnApprove: true},
+		{slugName: "b", reviewer: hold, weight: 5},
+	}, policy, content, &entity.Review{})
+	assert.Equal(t, plugin.ReviewStatusApproved, verdict)
+	assert.Equal(t, 0, hold.called)
+
+	verdict = runReviewSteps([]*reviewStep{
+		{slugName: "b", reviewer: hold, weight: 1, stopOnReject: true},
+		{slugName: "c", reviewer: del, weight: 5},
+	}, policy, content, &entity.Review{})
+	assert.Equal(t, plugin.ReviewStatusApproved, verdict)
+}
+
+func TestLocalReviewer_Review(t *testing.T) {
+	config := &schema.SiteLocalReviewer{
+		Enabled:            true,
+		Keywords:           []string{"Casino"},
+		Patterns:           []string{`\d{3}-\d{4}`, "("},
+		MatchStatus:        string(plugin.ReviewStatusDeleteDirectly),
+		MaxLinks:           3,
+		NewAccountDays:     3,
+		NewAccountMaxLinks: 1,
+	}
+	oldAccount := time.Now().AddDate(0, 0, -30)
+	lr := newLocalReviewer(config, oldAccount)
+	// the invalid pattern is skipped
+	assert.Len(t, lr.patterns, 1)
+
+	result := lr.Review(&plugin.ReviewContent{Title: "best casino", Content: "<p>hello</p>"})
+	assert.False(t, result.Approved)
+	assert.Equal(t, plugin.ReviewStatusDeleteDirectly, result.ReviewStatus)
+
+	result = lr.Review(&plugin.ReviewContent{Content: "<p>call 555-1234</p>"})
+	assert.Equal(t, plugin.ReviewStatusDeleteDirectly, result.ReviewStatus)
+
+	twoLinks := `<p><a href="https://a.com">a</a> and <a href="https://b.com">b</a> some words here</p>`
+	result = lr.Review(&plugin.ReviewContent{Content: twoLinks})
+	assert.True(t, result.Approved)
+
+	result = lr.Review(&plugin.ReviewContent{Content: twoLinks + twoLinks})
+	assert.Equal(t, plugin.ReviewStatusNeedReview, result.ReviewStatus)
+
+	// the new account can post less links, but the staff are never new accounts
+	lr = newLocalReviewer(config, time.Now())
+	result = lr.Review(&plugin.ReviewContent{Content: twoLinks})
+	assert.Equal(t, plugin.ReviewStatusNeedReview, result.ReviewStatus)
+	result = lr.Review(&plugin.ReviewContent{Content: twoLinks, Author: plugin.ReviewContentAuthor{Role: role.RoleAdminID}})
+	assert.True(t, result.Approved)
+
+	config.MaxLinkDensity = 10
+	lr = newLocalReviewer(config, oldAccount)
+	result = lr.Review(&plugin.ReviewContent{Content: twoLinks})
+	assert.Equal(t, plugin.ReviewStatusNeedReview, result.ReviewStatus)
+}
@@ -21,11 +21,15 @@ package role
 
 import (
 	"context"
+
+	"github.com/apache/answer/internal/entity"
 )
 
 // RolePowerRelRepo rolePowerRel repository
 type RolePowerRelRepo interface {
 	GetRolePowerTypeList(ctx context.Context, roleID int) (powers []string, err error)
+	GetRolesPowerTypeList(ctx context.Context, roleIDs []int) (powers []string, err error)
+	GetRolePowerRelList(ctx context.Context, roleIDs []int) (rels []*entity.RolePowerRel, err error)
 }
 
 // RolePowerRelService user service
@@ -48,11 +52,16 @@ func (rs *RolePowerRelService) GetRolePowerList(ctx context.Context, roleID int)
 	return rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, roleID)
 }
 
-// GetUserPowerList get  list all
+// GetRolesPowerList get the powers of all the roles, the same power may appear more than once
+func (rs *RolePowerRelService) GetRolesPowerList(ctx context.Context, roleIDs []int) (powers []string, err error) {
+	return rs.rolePowerRelRepo.GetRolesPowerTypeList(ctx, roleIDs)
+}
+
+// GetUserPowerList get the powers of the user from the built-in role and the custom roles
 func (rs *RolePowerRelService) GetUserPowerList(ctx context.Context, userID string) (powers []string, err error) {
-	roleID, err := rs.userRoleRelService.GetUserRole(ctx, userID)
+	roleIDs, err := rs.userRoleRelService.GetUserRoleIDs(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	return rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, roleID)
+	return rs.rolePowerRelRepo.GetRolesPowerTypeList(ctx, roleIDs)
 }
@@ -21,18 +21,20 @@ package role
 
 import (
 	"context"
+	"strings"
 
 	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/base/translator"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
 	"github.com/jinzhu/copier"
+	"github.com/segmentfault/pacman/errors"
 )
 
 const (
-	// Since there is currently no need to edit roles to add roles and other operations,
-	// the current role information is translated directly.
-	// Later on, when the relevant ability is available, it can be adjusted by the user himself.
+	// The built-in roles can not be edited, so their information is translated directly.
+	// The custom roles added by the admin are shown as they are.
 
 	RoleUserID      = 1
 	RoleAdminID     = 2
@@ -51,20 +53,55 @@ const (
 	trRoleDescriptionModerator = "role.description.moderator"
 )
 
+// BuiltInRoleIDs every user has exactly one of the built-in roles, the custom roles are assigned additionally
+var BuiltInRoleIDs = []int{RoleUserID, RoleAdminID, RoleModeratorID}
+
+// IsBuiltInRole whether the role is one of the built-in roles
+func IsBuiltInRole(roleID int) bool {
+	for _, id := range BuiltInRoleIDs {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// BuiltInRoleOf get the built-in role from the roles of the user, the user role if there is none
+func BuiltInRoleOf(roleIDs []int) int {
+	for _, roleID := range roleIDs {
+		if IsBuiltInRole(roleID) {
+			return roleID
+		}
+	}
+	return RoleUserID
+}
+
 // RoleRepo role repository
 type RoleRepo interface {
 	GetRoleAllList(ctx context.Context) (roles []*entity.Role, err error)
 	GetRoleAllMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error)
+	GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error)
+	AddRole(ctx context.Context, role *entity.Role, powerTypes []string) (err error)
+	UpdateRole(ctx context.Context, role *entity.Role, powerTypes []string) (err error)
+	RemoveRole(ctx context.Context, roleID int) (err error)
 }
 
 // RoleService user service
 type RoleService struct {
-	roleRepo RoleRepo
+	roleRepo         RoleRepo
+	powerRepo        PowerRepo
+	rolePowerRelRepo RolePowerRelRepo
 }
 
-func NewRoleService(roleRepo RoleRepo) *RoleService {
+func NewRoleService(
+	roleRepo RoleRepo,
+	powerRepo PowerRepo,
+	rolePowerRelRepo RolePowerRelRepo,
+) *RoleService {
 	return &RoleService{
-		roleRepo: roleRepo,
+		roleRepo:         roleRepo,
+		powerRepo:        powerRepo,
+		rolePowerRelRepo: rolePowerRelRepo,
 	}
 }
 
@@ -75,15 +112,131 @@ func (rs *RoleService) GetRoleList(ctx context.Context) (resp []*schema.GetRoleR
 		return
 	}
 
+	roleIDs := make([]int, 0, len(roles))
 	for _, role := range roles {
 		rs.translateRole(ctx, role)
+		roleIDs = append(roleIDs, role.ID)
+	}
+
+	rels, err := rs.rolePowerRelRepo.GetRolePowerRelList(ctx, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	rolePowerMapping := make(map[int][]string, len(roles))
+	for _, rel := range rels {
+		rolePowerMapping[rel.RoleID] = append(rolePowerMapping[rel.RoleID], rel.PowerType)
 	}
 
 	resp = []*schema.GetRoleResp{}
 	_ = copier.Copy(&resp, roles)
+	for _, r := range resp {
+		r.BuiltIn = IsBuiltInRole(r.ID)
+		r.PowerTypes = rolePowerMapping[r.ID]
+		if r.PowerTypes == nil {
+			r.PowerTypes = make([]string, 0)
+		}
+	}
 	return
 }
 
+// GetPowerList get all powers which can be assigned to the roles
+func (rs *RoleService) GetPowerList(ctx context.Context) (resp []*schema.GetPowerResp, err error) {
+	powers, err := rs.powerRepo.GetPowerList(ctx, &entity.Power{})
+	if err != nil {
+		return nil, err
+	}
+	resp = []*schema.GetPowerResp{}
+	_ = copier.Copy(&resp, powers)
+	return resp, nil
+}
+
+// AddRole add custom role
+func (rs *RoleService) AddRole(ctx context.Context, req *schema.AddRoleReq) (err error) {
+	if err = rs.checkRoleName(ctx, 0, req.Name); err != nil {
+		return err
+	}
+	if err = rs.checkPowerTypes(ctx, req.PowerTypes); err != nil {
+		return err
+	}
+	role := &entity.Role{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	return rs.roleRepo.AddRole(ctx, role, req.PowerTypes)
+}
+
+// UpdateRole update custom role and its powers
+func (rs *RoleService) UpdateRole(ctx context.Context, req *schema.UpdateRoleReq) (err error) {
+	if err = rs.checkCustomRole(ctx, req.ID); err != nil {
+		return err
+	}
+	if err = rs.checkRoleName(ctx, req.ID, req.Name); err != nil {
+		return err
+	}
+	if err = rs.checkPowerTypes(ctx, req.PowerTypes); err != nil {
+		return err
+	}
+	role := &entity.Role{
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	return rs.roleRepo.UpdateRole(ctx, role, req.PowerTypes)
+}
+
+// RemoveRole remove custom role, the users who have the role lose it
+func (rs *RoleService) RemoveRole(ctx context.Context, req *schema.RemoveRoleReq) (err error) {
+	if err = rs.checkCustomRole(ctx, req.ID); err != nil {
+		return err
+	}
+	return rs.roleRepo.RemoveRole(ctx, req.ID)
+}
+
+func (rs *RoleService) checkCustomRole(ctx context.Context, roleID int) (err error) {
+	if IsBuiltInRole(roleID) {
+		return errors.BadRequest(reason.RoleBuiltInCannotModify)
+	}
+	_, exist, err := rs.roleRepo.GetRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	return nil
+}
+
+// checkRoleName the role name should be unique, ignore the role itself when updating
+func (rs *RoleService) checkRoleName(ctx context.Context, roleID int, name string) (err error) {
+	roles, err := rs.roleRepo.GetRoleAllList(ctx)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if role.ID != roleID && strings.EqualFold(role.Name, name) {
+			return errors.BadRequest(reason.RoleNameAlreadyExists)
+		}
+	}
+	return nil
+}
+
+func (rs *RoleService) checkPowerTypes(ctx context.Context, powerTypes []string) (err error) {
+	powers, err := rs.powerRepo.GetPowerList(ctx, &entity.Power{})
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool, len(powers))
+	for _, power := range powers {
+		exists[power.PowerType] = true
+	}
+	for _, powerType := range powerTypes {
+		if !exists[powerType] {
+			return errors.BadRequest(reason.RolePowerNotFound)
+		}
+	}
+	return nil
+}
+
 func (rs *RoleService) GetRoleMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error) {
 	return rs.roleRepo.GetRoleAllMapping(ctx)
 }
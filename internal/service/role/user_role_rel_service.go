@@ -22,7 +22,9 @@ package role
 import (
 	"context"
 
+	"github.com/apache/answer/internal/base/reason"
 	"github.com/apache/answer/internal/entity"
+	"github.com/segmentfault/pacman/errors"
 )
 
 // UserRoleRelRepo userRoleRel repository
@@ -32,6 +34,7 @@ type UserRoleRelRepo interface {
 	GetUserRoleRelListByRoleID(ctx context.Context, roleIDs []int) (
 		userRoleRelList []*entity.UserRoleRel, err error)
 	GetUserRoleRel(ctx context.Context, userID string) (rolePowerRel *entity.UserRoleRel, exist bool, err error)
+	SaveUserCustomRoleRel(ctx context.Context, userID string, roleIDs []int) (err error)
 }
 
 // UserRoleRelService user service
@@ -53,6 +56,27 @@ func (us *UserRoleRelService) SaveUserRole(ctx context.Context, userID string, r
 	return us.userRoleRelRepo.SaveUserRoleRel(ctx, userID, roleID)
 }
 
+// SaveUserCustomRoles replace the custom roles of the user, the built-in role is not changed
+func (us *UserRoleRelService) SaveUserCustomRoles(ctx context.Context, userID string, roleIDs []int) (err error) {
+	roleMapping, err := us.roleService.GetRoleMapping(ctx)
+	if err != nil {
+		return err
+	}
+	customRoleIDs := make([]int, 0, len(roleIDs))
+	added := make(map[int]bool, len(roleIDs))
+	for _, roleID := range roleIDs {
+		if roleMapping[roleID] == nil || IsBuiltInRole(roleID) {
+			return errors.BadRequest(reason.RoleNotFound)
+		}
+		if added[roleID] {
+			continue
+		}
+		added[roleID] = true
+		customRoleIDs = append(customRoleIDs, roleID)
+	}
+	return us.userRoleRelRepo.SaveUserCustomRoleRel(ctx, userID, customRoleIDs)
+}
+
 // GetUserRoleMapping get user role mapping
 func (us *UserRoleRelService) GetUserRoleMapping(ctx context.Context, userIDs []string) (
 	userRoleMapping map[string]*entity.Role, err error) {
@@ -97,11 +121,31 @@ func (us *UserRoleRelService) GetUserRoleRelMapping(ctx context.Context, userIDs
 	}
 
 	for _, rel := range relList {
-		userRoleRelMapping[rel.UserID] = rel.RoleID
+		if IsBuiltInRole(rel.RoleID) {
+			userRoleRelMapping[rel.UserID] = rel.RoleID
+		}
 	}
 	return userRoleRelMapping, nil
 }
 
+// GetUserCustomRoleMapping get the custom role ids of the users
+func (us *UserRoleRelService) GetUserCustomRoleMapping(ctx context.Context, userIDs []string) (
+	userCustomRoleMapping map[string][]int, err error) {
+	userCustomRoleMapping = make(map[string][]int, 0)
+
+	relList, err := us.userRoleRelRepo.GetUserRoleRelList(ctx, userIDs)
+	if err != nil {
+		return userCustomRoleMapping, err
+	}
+
+	for _, rel := range relList {
+		if !IsBuiltInRole(rel.RoleID) {
+			userCustomRoleMapping[rel.UserID] = append(userCustomRoleMapping[rel.UserID], rel.RoleID)
+		}
+	}
+	return userCustomRoleMapping, nil
+}
+
 // GetUserRole get user role
 func (us *UserRoleRelService) GetUserRole(ctx context.Context, userID string) (roleID int, err error) {
 	rolePowerRel, exist, err := us.userRoleRelRepo.GetUserRoleRel(ctx, userID)
@@ -115,6 +159,26 @@ func (us *UserRoleRelService) GetUserRole(ctx context.Context, userID string) (r
 	return rolePowerRel.RoleID, nil
 }
 
+// GetUserRoleIDs get the built-in role and the custom roles of the user
+func (us *UserRoleRelService) GetUserRoleIDs(ctx context.Context, userID string) (roleIDs []int, err error) {
+	relList, err := us.userRoleRelRepo.GetUserRoleRelList(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	hasBuiltInRole := false
+	for _, rel := range relList {
+		if IsBuiltInRole(rel.RoleID) {
+			hasBuiltInRole = true
+		}
+		roleIDs = append(roleIDs, rel.RoleID)
+	}
+	if !hasBuiltInRole {
+		// set default role
+		roleIDs = append(roleIDs, RoleUserID)
+	}
+	return roleIDs, nil
+}
+
 // GetUserByRoleID get user by role id
 func (us *UserRoleRelService) GetUserByRoleID(ctx context.Context, roleIDs []int) (rel []*entity.UserRoleRel, err error) {
 	rolePowerRels, err := us.userRoleRelRepo.GetUserRoleRelListByRoleID(ctx, roleIDs)
@@ -0,0 +1,471 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_index
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/pkg/fulltext"
+	"github.com/apache/answer/pkg/obj"
+	"github.com/apache/answer/plugin"
+	strip "github.com/grokify/html-strip-tags-go"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// SlugName the slug name of the built-in search plugin
+	SlugName = "builtin_search"
+	// titleWeight a term in the title counts as many times as it in the content
+	titleWeight = 3
+	// maxPositions the positions kept for each term in a document
+	maxPositions = 32
+	// contentPositionGap keeps the phrases from matching across the title and the content
+	contentPositionGap = 100
+	defaultPageSize    = 30
+	rebuildPageSize    = 100
+	// maxTermPostings only the occurrences of a term with the highest weights are ranked,
+	// the documents containing the term less are pruned, so that a common term stays cheap
+	maxTermPostings = 1000
+)
+
+// SearchIndexRepo the storage of the built-in search index
+type SearchIndexRepo interface {
+	SaveDocument(ctx context.Context, doc *entity.SearchIndexDocument, tags []*entity.SearchIndexTag,
+		terms []*entity.SearchIndexTerm) (err error)
+	DeleteDocument(ctx context.Context, objectID string) (err error)
+	GetTerms(ctx context.Context, terms []string, titleOnly bool, limit int) (
+		postings []*entity.SearchIndexTerm, err error)
+	GetTermDocCounts(ctx context.Context, terms []string, titleOnly bool) (docCounts map[string]int64, err error)
+	GetStats(ctx context.Context) (docCount int64, avgLength float64, err error)
+	GetObjectIDs(ctx context.Context, objectIDs []string, objectType string, cond *plugin.SearchBasicCond) (
+		matched []string, err error)
+	GetDocumentPage(ctx context.Context, objectIDs []string, objectType string, cond *plugin.SearchBasicCond,
+		page, pageSize int) (docs []*entity.SearchIndexDocument, total int64, err error)
+	Clear(ctx context.Context) (err error)
+}
+
+// SearchIndexService the built-in full-text search engine, it is registered as a search plugin
+// so that it can be enabled like the other search engines.
+type SearchIndexService struct {
+	searchIndexRepo SearchIndexRepo
+	rebuilding      atomic.Bool
+}
+
+var searchIndex = &SearchIndexService{}
+
+func init() {
+	plugin.Register(searchIndex)
+}
+
+// NewSearchIndexService new search index service
+func NewSearchIndexService(searchIndexRepo SearchIndexRepo) *SearchIndexService {
+	searchIndex.searchIndexRepo = searchIndexRepo
+	return searchIndex
+}
+
+func (s *SearchIndexService) Info() plugin.Info {
+	return plugin.Info{
+		Name:        plugin.MakeTranslator("backend.builtin_search.name"),
+		SlugName:    SlugName,
+		Description: plugin.MakeTranslator("backend.builtin_search.description"),
+		Author:      "Answer",
+		Version:     "1.0.0",
+		Link:        "https://answer.apache.org",
+	}
+}
+
+func (s *SearchIndexService) Description() plugin.SearchDesc {
+	return plugin.SearchDesc{}
+}
+
+// RegisterSyncer builds the index in background if it is empty
+func (s *SearchIndexService) RegisterSyncer(ctx context.Context, syncer plugin.SearchSyncer) {
+	if s.searchIndexRepo == nil || !plugin.StatusManager.IsEnabled(SlugName) {
+		return
+	}
+	docCount, _, err := s.searchIndexRepo.GetStats(ctx)
+	if err != nil {
+		log.Errorf("get search index stats failed: %v", err)
+		return
+	}
+	if docCount > 0 {
+		return
+	}
+	go func() {
+		if err := s.Rebuild(context.Background(), syncer); err != nil {
+			log.Errorf("build search index failed: %v", err)
+		}
+	}()
+}
+
+// Rebuild clears the index and indexes all the questions and answers again
+func (s *SearchIndexService) Rebuild(ctx context.Context, syncer plugin.SearchSyncer) (err error) {
+	if !s.rebuilding.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer s.rebuilding.Store(false)
+
+	if err = s.searchIndexRepo.Clear(ctx); err != nil {
+		return err
+	}
+	indexed := 0
+	for page := 1; ; page++ {
+		questions, err := syncer.GetQuestionsPage(ctx, page, rebuildPageSize)
+		if err != nil {
+			return err
+		}
+		for _, question := range questions {
+			if err = s.UpdateContent(ctx, question); err != nil {
+				return err
+			}
+		}
+		indexed += len(questions)
+		if len(questions) < rebuildPageSize {
+			break
+		}
+	}
+	for page := 1; ; page++ {
+		answers, err := syncer.GetAnswersPage(ctx, page, rebuildPageSize)
+		if err != nil {
+			return err
+		}
+		for _, answer := range answers {
+			if err = s.UpdateContent(ctx, answer); err != nil {
+				return err
+			}
+		}
+		indexed += len(answers)
+		if len(answers) < rebuildPageSize {
+			break
+		}
+	}
+	log.Infof("search index is rebuilt with %d questions and answers", indexed)
+	return nil
+}
+
+// UpdateContent indexes the question or answer, it is removed from the index once deleted
+func (s *SearchIndexService) UpdateContent(ctx context.Context, content *plugin.SearchContent) (err error) {
+	if content.Status >= plugin.SearchContentStatusDeleted {
+		return s.searchIndexRepo.DeleteDocument(ctx, content.ObjectID)
+	}
+
+	titleTokens := fulltext.Tokenize(content.Title)
+	contentTokens := fulltext.Tokenize(strip.StripTags(content.Content))
+	offset := contentPositionGap
+	if len(titleTokens) > 0 {
+		offset += titleTokens[len(titleTokens)-1].Position
+	}
+	for i := range contentTokens {
+		contentTokens[i].Position += offset
+	}
+	// the comments follow the content, each of them is apart from the others
+	for _, comment := range content.Comments {
+		if len(contentTokens) > 0 {
+			offset = contentTokens[len(contentTokens)-1].Position + contentPositionGap
+		}
+		for _, token := range fulltext.Tokenize(strip.StripTags(comment)) {
+			token.Position += offset
+			contentTokens = append(contentTokens, token)
+		}
+	}
+
+	doc := &entity.SearchIndexDocument{
+		ObjectID:     content.ObjectID,
+		ObjectType:   content.Type,
+		QuestionID:   content.QuestionID,
+		UserID:       content.UserID,
+		Answers:      content.Answers,
+		Views:        content.Views,
+		Score:        content.Score,
+		HasAccepted:  content.HasAccepted,
+		Created:      content.Created,
+		Active:       content.Active,
+		Length:       len(titleTokens) + len(contentTokens),
+		Status:       int(content.Status),
+		AnswerUsers:  joinIDs(content.AnswerUserIDs),
+		CommentUsers: joinIDs(content.CommentUserIDs),
+	}
+
+	terms := make(map[string]*entity.SearchIndexTerm)
+	positions := make(map[string][]string)
+	add := func(token fulltext.Token, inTitle bool) {
+		term, ok := terms[token.Term]
+		if !ok {
+			term = &entity.SearchIndexTerm{Term: token.Term, ObjectID: content.ObjectID, DocLength: doc.Length}
+			terms[token.Term] = term
+		}
+		if inTitle {
+			term.TitleFrequency++
+		} else {
+			term.Frequency++
+		}
+		if len(positions[token.Term]) < maxPositions {
+			positions[token.Term] = append(positions[token.Term], strconv.Itoa(token.Position))
+		}
+	}
+	for _, token := range titleTokens {
+		add(token, true)
+	}
+	for _, token := range contentTokens {
+		add(token, false)
+	}
+
+	termList := make([]*entity.SearchIndexTerm, 0, len(terms))
+	for _, term := range terms {
+		term.Weight = term.Frequency + titleWeight*term.TitleFrequency
+		term.Positions = strings.Join(positions[term.Term], ",")
+		termList = append(termList, term)
+	}
+	tags := make([]*entity.SearchIndexTag, 0, len(content.Tags))
+	for _, tagID := range content.Tags {
+		tags = append(tags, &entity.SearchIndexTag{ObjectID: content.ObjectID, TagID: tagID})
+	}
+	return s.searchIndexRepo.SaveDocument(ctx, doc, tags, termList)
+}
+
+// DeleteContent removes the question or answer from the index
+func (s *SearchIndexService) DeleteContent(ctx context.Context, objectID string) (err error) {
+	return s.searchIndexRepo.DeleteDocument(ctx, objectID)
+}
+
+func (s *SearchIndexService) SearchContents(ctx context.Context, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	return s.search(ctx, "", cond)
+}
+
+func (s *SearchIndexService) SearchQuestions(ctx context.Context, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	return s.search(ctx, constant.QuestionObjectType, cond)
+}
+
+func (s *SearchIndexService) SearchAnswers(ctx context.Context, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	return s.search(ctx, constant.AnswerObjectType, cond)
+}
+
+// search ranks the documents matching any of the words by BM25. A word with several terms, like a quoted
+// phrase or CJK text, only matches when its terms are next to each other. Only the documents containing
+// a term the most are ranked, see maxTermPostings.
+func (s *SearchIndexService) search(ctx context.Context, objectType string, cond *plugin.SearchBasicCond) (
+	res []plugin.SearchResult, total int64, err error) {
+	res = make([]plugin.SearchResult, 0)
+	page, pageSize := cond.Page, cond.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+
+	phrases := make([][]fulltext.Token, 0)
+	for _, word := range cond.Words {
+		if tokens := fulltext.Tokenize(word); len(tokens) > 0 {
+			phrases = append(phrases, tokens)
+		}
+	}
+	if len(phrases) == 0 {
+		docs, total, err := s.searchIndexRepo.GetDocumentPage(ctx, nil, objectType, cond, page, pageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, doc := range docs {
+			res = append(res, plugin.SearchResult{ID: doc.ObjectID, Type: doc.ObjectType})
+		}
+		return res, total, nil
+	}
+
+	scores, err := s.score(ctx, phrases, cond.TitleOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+	objectIDs := make([]string, 0, len(scores))
+	for objectID := range scores {
+		if len(objectType) > 0 {
+			if t, _ := obj.GetObjectTypeStrByObjectID(objectID); t != objectType {
+				continue
+			}
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+	if len(objectIDs) == 0 {
+		return res, 0, nil
+	}
+
+	// the other orders are applied by the database among the matched documents
+	if cond.Order != plugin.SearchRelevanceOrder {
+		docs, total, err := s.searchIndexRepo.GetDocumentPage(ctx, objectIDs, objectType, cond, page, pageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, doc := range docs {
+			res = append(res, plugin.SearchResult{ID: doc.ObjectID, Type: doc.ObjectType})
+		}
+		return res, total, nil
+	}
+	if hasFilter(cond) {
+		objectIDs, err = s.searchIndexRepo.GetObjectIDs(ctx, objectIDs, objectType, cond)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	sortObjectIDs(objectIDs, scores)
+
+	total = int64(len(objectIDs))
+	start := (page - 1) * pageSize
+	if start >= len(objectIDs) {
+		return res, total, nil
+	}
+	end := min(start+pageSize, len(objectIDs))
+	for _, objectID := range objectIDs[start:end] {
+		objectType, _ := obj.GetObjectTypeStrByObjectID(objectID)
+		res = append(res, plugin.SearchResult{ID: objectID, Type: objectType})
+	}
+	return res, total, nil
+}
+
+// score sums the BM25 scores of the matched phrases of each document, only the title is counted if titleOnly
+func (s *SearchIndexService) score(ctx context.Context, phrases [][]fulltext.Token, titleOnly bool) (
+	scores map[string]float64, err error) {
+	termSet := make(map[string]bool)
+	terms := make([]string, 0)
+	for _, phrase := range phrases {
+		for _, token := range phrase {
+			if !termSet[token.Term] {
+				termSet[token.Term] = true
+				terms = append(terms, token.Term)
+			}
+		}
+	}
+	postings, err := s.searchIndexRepo.GetTerms(ctx, terms, titleOnly, maxTermPostings)
+	if err != nil {
+		return nil, err
+	}
+	// the pruned postings still count for the rarity of the terms
+	termDocCounts, err := s.searchIndexRepo.GetTermDocCounts(ctx, terms, titleOnly)
+	if err != nil {
+		return nil, err
+	}
+	docCount, avgLength, err := s.searchIndexRepo.GetStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	termDocs := make(map[string]map[string]*entity.SearchIndexTerm)
+	for _, posting := range postings {
+		if titleOnly {
+			posting.Frequency = 0
+		}
+		if termDocs[posting.Term] == nil {
+			termDocs[posting.Term] = make(map[string]*entity.SearchIndexTerm)
+		}
+		termDocs[posting.Term][posting.ObjectID] = posting
+	}
+
+	scores = make(map[string]float64)
+	for _, phrase := range phrases {
+		for objectID, first := range termDocs[phrase[0].Term] {
+			if !matchPhrase(phrase, first, objectID, termDocs) {
+				continue
+			}
+			for _, token := range phrase {
+				posting := termDocs[token.Term][objectID]
+				idf := fulltext.BM25IDF(docCount, termDocCounts[token.Term])
+				frequency := float64(posting.Frequency + titleWeight*posting.TitleFrequency)
+				scores[objectID] += fulltext.BM25(idf, frequency, float64(posting.DocLength), avgLength)
+			}
+		}
+	}
+	return scores, nil
+}
+
+// matchPhrase checks the document contains all the terms of the phrase at the same distances
+func matchPhrase(phrase []fulltext.Token, first *entity.SearchIndexTerm, objectID string,
+	termDocs map[string]map[string]*entity.SearchIndexTerm) bool {
+	truncated := false
+	positionSets := make([]map[int]bool, len(phrase))
+	for i, token := range phrase {
+		posting, ok := termDocs[token.Term][objectID]
+		if !ok {
+			return false
+		}
+		positionSets[i] = parsePositions(posting.Positions)
+		if posting.Frequency+posting.TitleFrequency > len(positionSets[i]) {
+			truncated = true
+		}
+	}
+	if len(phrase) == 1 {
+		return true
+	}
+	for start := range parsePositions(first.Positions) {
+		matched := true
+		for i := 1; i < len(phrase); i++ {
+			if !positionSets[i][start+phrase[i].Position-phrase[0].Position] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	// the positions of the frequent terms are not all kept, so the phrase can not be ruled out
+	return truncated
+}
+
+func parsePositions(positions string) map[int]bool {
+	set := make(map[int]bool)
+	for _, position := range strings.Split(positions, ",") {
+		if p, err := strconv.Atoi(position); err == nil {
+			set[p] = true
+		}
+	}
+	return set
+}
+
+// hasFilter whether the documents need to be filtered by the conditions other than the words
+func hasFilter(cond *plugin.SearchBasicCond) bool {
+	return len(cond.TagIDs) > 0 || len(cond.HierarchicalTagIDs) > 0 || len(cond.UserID) > 0 ||
+		len(cond.QuestionID) > 0 || cond.QuestionAccepted != plugin.AcceptedCondAll ||
+		cond.AnswerAccepted != plugin.AcceptedCondAll ||
+		cond.VoteAmount > -1 || cond.ViewAmount > -1 || cond.AnswerAmount > -1 ||
+		len(cond.ExcludedTagIDs) > 0 || cond.CreatedFrom > 0 || cond.CreatedTo > 0 || cond.Closed ||
+		len(cond.CommentUserID) > 0 || len(cond.AnswerUserID) > 0
+}
+
+// joinIDs joins the ids and wraps them by comma, so that an id can be matched by LIKE '%,id,%'
+func joinIDs(ids []string) string {
+	return "," + strings.Join(ids, ",") + ","
+}
+
+// sortObjectIDs sorts the object ids by the relevance
+func sortObjectIDs(objectIDs []string, scores map[string]float64) {
+	sort.SliceStable(objectIDs, func(i, j int) bool {
+		a, b := objectIDs[i], objectIDs[j]
+		if scores[a] != scores[b] {
+			return scores[a] > scores[b]
+		}
+		return a > b
+	})
+}
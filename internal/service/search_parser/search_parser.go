@@ -25,22 +25,30 @@ import (
 	"github.com/apache/answer/internal/base/constant"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/apache/answer/internal/schema"
+	hierarchicaltag "github.com/apache/answer/internal/service/hierarchical_tag"
 	"github.com/apache/answer/internal/service/tag_common"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 	"github.com/apache/answer/pkg/converter"
 )
 
 type SearchParser struct {
-	tagCommonService *tag_common.TagCommonService
-	userCommon       *usercommon.UserCommon
+	tagCommonService       *tag_common.TagCommonService
+	userCommon             *usercommon.UserCommon
+	hierarchicalTagService *hierarchicaltag.HierarchicalTagService
 }
 
-func NewSearchParser(tagCommonService *tag_common.TagCommonService, userCommon *usercommon.UserCommon) *SearchParser {
+func NewSearchParser(
+	tagCommonService *tag_common.TagCommonService,
+	userCommon *usercommon.UserCommon,
+	hierarchicalTagService *hierarchicaltag.HierarchicalTagService,
+) *SearchParser {
 	return &SearchParser{
-		tagCommonService: tagCommonService,
-		userCommon:       userCommon,
+		tagCommonService:       tagCommonService,
+		userCommon:             userCommon,
+		hierarchicalTagService: hierarchicalTagService,
 	}
 }
 
@@ -53,12 +61,26 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 		limitWords = 5
 	)
 
+	// match category before the tags, it may be written like a tag: [category:backend/go]
+	var hasCategory bool
+	cond.HierarchicalTagIDs, hasCategory = sp.parseCategory(ctx, &query)
+	if hasCategory && len(cond.HierarchicalTagIDs) == 0 {
+		cond.NoMatch = true
+	}
+
 	// match tags
+	cond.ExcludedTags = sp.parseExcludedTags(ctx, &query)
 	cond.Tags = sp.parseTags(ctx, &query)
 
 	// match all
+	var hasCommentUser bool
+	cond.CommentUserID, hasCommentUser = sp.parseCommentedBy(ctx, &query, dto.UserID)
+	if hasCommentUser && len(cond.CommentUserID) == 0 {
+		cond.NoMatch = true
+	}
 	cond.UserID = sp.parseUserID(ctx, &query, dto.UserID)
 	cond.VoteAmount = sp.parseVotes(&query)
+	cond.CreatedFrom, cond.CreatedTo = sp.parseCreated(&query)
 	cond.Words = sp.parseWithin(&query)
 
 	// match questions
@@ -71,9 +93,33 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 		cond.TargetType = constant.QuestionObjectType
 	}
 	cond.AnswerAmount = sp.parseAnswers(&query)
+	if sp.parseIsUnanswered(&query) {
+		cond.AnswerAmount = 0
+	}
 	if cond.AnswerAmount != -1 {
 		cond.TargetType = constant.QuestionObjectType
 	}
+	cond.Closed = sp.parseIsClosed(&query)
+	if cond.Closed {
+		cond.TargetType = constant.QuestionObjectType
+	}
+	cond.HasAccepted = sp.parseHasAccepted(&query)
+	if cond.HasAccepted {
+		cond.TargetType = constant.QuestionObjectType
+	}
+	var hasAnswerUser bool
+	cond.AnswerUserID, hasAnswerUser = sp.parseAnsweredBy(ctx, &query, dto.UserID)
+	if hasAnswerUser {
+		cond.TargetType = constant.QuestionObjectType
+		if len(cond.AnswerUserID) == 0 {
+			cond.NoMatch = true
+		}
+	}
+	// the answers have no title of their own
+	cond.InTitle = sp.parseInTitle(&query)
+	if cond.InTitle {
+		cond.TargetType = constant.QuestionObjectType
+	}
 
 	// match answers
 	cond.Accepted = sp.parseAccepted(&query)
@@ -94,7 +140,16 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 
 	if len(strings.TrimSpace(query)) > 0 {
 		words := strings.Split(strings.TrimSpace(query), " ")
-		cond.Words = append(cond.Words, words...)
+		for _, word := range words {
+			if len(word) == 0 {
+				continue
+			}
+			if word == "OR" {
+				cond.MatchAnyWord = true
+				continue
+			}
+			cond.Words = append(cond.Words, word)
+		}
 	}
 
 	// check limit words
@@ -104,40 +159,34 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 	return
 }
 
-// parseTags parse search tags, return tag ids array
+// parseTags parse search tags like: [go] [docker] or [go] OR [rust], return tag ids array,
+// the question must have one of the tags in each group
 func (sp *SearchParser) parseTags(ctx context.Context, query *string) (tags [][]string) {
 	var (
 		// expire tag pattern
-		exprTag = `\[(.*?)\]`
-		q       = *query
-		limit   = 5
+		exprTagGroup = `\[[^\[\]\s]+\](\s+OR\s+\[[^\[\]\s]+\])*`
+		exprTag      = `\[(.*?)\]`
+		q            = *query
+		limit        = 5
 	)
 
-	re := regexp.MustCompile(exprTag)
-	res := re.FindAllStringSubmatch(q, -1)
-	if len(res) == 0 {
+	re := regexp.MustCompile(exprTagGroup)
+	groups := re.FindAllString(q, -1)
+	if len(groups) == 0 {
 		return
 	}
 
+	tagRe := regexp.MustCompile(exprTag)
 	tags = make([][]string, 0)
-	for _, item := range res {
+	for _, group := range groups {
 		tagGroup := make([]string, 0)
-		tag, exists, err := sp.tagCommonService.GetTagBySlugName(ctx, item[1])
-		if err != nil || !exists {
-			continue
+		for _, item := range tagRe.FindAllStringSubmatch(group, -1) {
+			tagGroup = append(tagGroup, sp.getTagIDs(ctx, item[1])...)
 		}
-		tagGroup = append(tagGroup, tag.ID)
-		if tag.MainTagID > 0 {
-			tagGroup = append(tagGroup, fmt.Sprintf("%d", tag.MainTagID))
-		}
-		synIDs, err := sp.tagCommonService.GetTagIDsByMainTagID(ctx, tag.ID)
-		if err != nil || !exists {
+		if len(tagGroup) == 0 {
 			continue
 		}
-		tagGroup = append(tagGroup, tag.ID)
-		tagGroup = append(tagGroup, synIDs...)
-		tagGroup = converter.UniqueArray(tagGroup)
-		tags = append(tags, tagGroup)
+		tags = append(tags, converter.UniqueArray(tagGroup))
 	}
 
 	// limit maximum 5 tags
@@ -150,6 +199,71 @@ func (sp *SearchParser) parseTags(ctx context.Context, query *string) (tags [][]
 	return
 }
 
+// parseExcludedTags parse the excluded tags like: -[tag], return the tag ids with their synonyms
+func (sp *SearchParser) parseExcludedTags(ctx context.Context, query *string) (tagIDs []string) {
+	var (
+		expr = `(^|\s)-\[(.*?)\]`
+		q    = *query
+	)
+
+	re := regexp.MustCompile(expr)
+	res := re.FindAllStringSubmatch(q, -1)
+	if len(res) == 0 {
+		return
+	}
+	for _, item := range res {
+		tagIDs = append(tagIDs, sp.getTagIDs(ctx, item[2])...)
+	}
+	tagIDs = converter.UniqueArray(tagIDs)
+
+	*query = strings.TrimSpace(re.ReplaceAllString(q, " "))
+	return
+}
+
+// getTagIDs return the id of the tag with its main tag and synonyms
+func (sp *SearchParser) getTagIDs(ctx context.Context, slugName string) (tagIDs []string) {
+	tag, exists, err := sp.tagCommonService.GetTagBySlugName(ctx, slugName)
+	if err != nil || !exists {
+		return nil
+	}
+	tagIDs = append(tagIDs, tag.ID)
+	if tag.MainTagID > 0 {
+		tagIDs = append(tagIDs, fmt.Sprintf("%d", tag.MainTagID))
+	}
+	synIDs, err := sp.tagCommonService.GetTagIDsByMainTagID(ctx, tag.ID)
+	if err != nil {
+		return tagIDs
+	}
+	return append(tagIDs, synIDs...)
+}
+
+// parseCategory parse search category like: category:backend/go or [category:backend/go],
+// return the hierarchical tag ids of its subtree and whether the query has a category
+func (sp *SearchParser) parseCategory(ctx context.Context, query *string) (hierarchicalTagIDs []string, has bool) {
+	var (
+		expr = `\[category:([^\[\]\s]+)\]|category:(\S+)`
+		q    = *query
+	)
+
+	re := regexp.MustCompile(expr)
+	res := re.FindStringSubmatch(q)
+	if len(res) > 2 {
+		has = true
+		slugPath := res[1]
+		if len(slugPath) == 0 {
+			slugPath = res[2]
+		}
+		ids, err := sp.hierarchicalTagService.GetSubtreeIDsBySlugPath(ctx, slugPath)
+		if err == nil && len(ids) > 0 {
+			hierarchicalTagIDs = ids
+		}
+		q = re.ReplaceAllString(q, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
 // parseUserID return user id or current login user id
 func (sp *SearchParser) parseUserID(ctx context.Context, query *string, currentUserID string) (userID string) {
 	var (
@@ -332,3 +446,158 @@ func (sp *SearchParser) parseIsAnswer(query *string) (isAnswer bool) {
 	*query = strings.TrimSpace(q)
 	return
 }
+
+// parseIsClosed check the result if only limit closed question or not
+func (sp *SearchParser) parseIsClosed(query *string) (isClosed bool) {
+	var (
+		q    = *query
+		expr = `is:closed`
+	)
+
+	if strings.Contains(q, expr) {
+		isClosed = true
+		q = strings.ReplaceAll(q, expr, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// parseIsUnanswered check the result if only limit question without answer or not
+func (sp *SearchParser) parseIsUnanswered(query *string) (isUnanswered bool) {
+	var (
+		q    = *query
+		expr = `is:unanswered`
+	)
+
+	if strings.Contains(q, expr) {
+		isUnanswered = true
+		q = strings.ReplaceAll(q, expr, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// parseHasAccepted check the result if only limit question has accepted answer or not
+func (sp *SearchParser) parseHasAccepted(query *string) (hasAccepted bool) {
+	var (
+		q    = *query
+		expr = `has:accepted`
+	)
+
+	if strings.Contains(q, expr) {
+		hasAccepted = true
+		q = strings.ReplaceAll(q, expr, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// parseInTitle check the keywords if only match the title or not
+func (sp *SearchParser) parseInTitle(query *string) (inTitle bool) {
+	var (
+		q    = *query
+		expr = `in:title`
+	)
+
+	if strings.Contains(q, expr) {
+		inTitle = true
+		q = strings.ReplaceAll(q, expr, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// parseCommentedBy return the id of the user commented, like: commented-by:username or commented-by:me
+func (sp *SearchParser) parseCommentedBy(ctx context.Context, query *string, currentUserID string) (
+	userID string, has bool) {
+	return sp.parseUserPrefix(ctx, query, "commented-by", currentUserID)
+}
+
+// parseAnsweredBy return the id of the user answered, like: answered-by:username or answered-by:me
+func (sp *SearchParser) parseAnsweredBy(ctx context.Context, query *string, currentUserID string) (
+	userID string, has bool) {
+	return sp.parseUserPrefix(ctx, query, "answered-by", currentUserID)
+}
+
+// parseUserPrefix return the id of the user named after the prefix and whether the query has the prefix,
+// me means the current login user, the id is empty if the user is not found or nobody is logged in
+func (sp *SearchParser) parseUserPrefix(ctx context.Context, query *string, prefix, currentUserID string) (
+	userID string, has bool) {
+	var (
+		q  = *query
+		re = regexp.MustCompile(prefix + `:(\S+)`)
+	)
+
+	res := re.FindStringSubmatch(q)
+	if len(res) > 1 {
+		has = true
+		if res[1] == "me" {
+			userID = currentUserID
+		} else {
+			user, has, err := sp.userCommon.GetUserBasicInfoByUserName(ctx, res[1])
+			if err == nil && has {
+				userID = user.ID
+			}
+		}
+		q = re.ReplaceAllString(q, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// parseCreated parse the created time range like: created:2024-01..2024-06, created:2024-01-15.. or created:2024
+func (sp *SearchParser) parseCreated(query *string) (from, to int64) {
+	var (
+		q    = *query
+		expr = `created:(\S+)`
+	)
+
+	re := regexp.MustCompile(expr)
+	res := re.FindStringSubmatch(q)
+	if len(res) > 1 {
+		from, to = parseDateRange(res[1])
+		q = re.ReplaceAllString(q, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
+
+// parseDateRange return the unix seconds range of the dates, the end is exclusive, zero means no limit
+func parseDateRange(value string) (from, to int64) {
+	start, end, isRange := strings.Cut(value, "..")
+	if !isRange {
+		end = start
+	}
+	if begin, _, ok := parseDate(start); ok {
+		from = begin.Unix()
+	}
+	if _, next, ok := parseDate(end); ok {
+		to = next.Unix()
+	}
+	return
+}
+
+// parseDate parse the date in year, month or day, return the beginning of it and of the next one
+func parseDate(value string) (begin, next time.Time, ok bool) {
+	layouts := []struct {
+		layout              string
+		years, months, days int
+	}{
+		{layout: "2006-01-02", days: 1},
+		{layout: "2006-01", months: 1},
+		{layout: "2006", years: 1},
+	}
+	for _, l := range layouts {
+		t, err := time.ParseInLocation(l.layout, value, time.Local)
+		if err == nil {
+			return t, t.AddDate(l.years, l.months, l.days), true
+		}
+	}
+	return
+}
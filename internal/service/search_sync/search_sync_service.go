@@ -0,0 +1,287 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_sync
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	syncPollInterval = 10 * time.Second
+	syncBatchSize    = 100
+	reindexPageSize  = 100
+	// changeRetention the changes older than it are pruned even if a disabled plugin has not synced them,
+	// the plugin is reindexed once it is enabled again.
+	changeRetention = 7 * 24 * time.Hour
+)
+
+// SearchChangeRepo records the changes of the questions and answers and the sync cursors of the search plugins
+type SearchChangeRepo interface {
+	AddChanges(ctx context.Context, objectIDs ...string) (err error)
+	AddTagChanges(ctx context.Context, tagIDs ...string) (err error)
+	AddQuestionChanges(ctx context.Context, questionIDs ...string) (err error)
+	Changed() <-chan struct{}
+	GetLatestChangeID(ctx context.Context) (id int64, err error)
+	CountChanges(ctx context.Context, cursor int64) (count int64, oldest time.Time, err error)
+	PruneChanges(ctx context.Context, cursor int64, before time.Time) (err error)
+	GetCursor(ctx context.Context, pluginSlugName string) (cursor *entity.SearchSyncCursor, exist bool, err error)
+	SaveCursor(ctx context.Context, cursor *entity.SearchSyncCursor) (err error)
+}
+
+// SearchSyncService keeps the enabled search plugins up to date with the recorded changes
+type SearchSyncService struct {
+	searchChangeRepo SearchChangeRepo
+	syncer           plugin.SearchSyncer
+	reindexing       sync.Map
+}
+
+// NewSearchSyncService new search sync service
+func NewSearchSyncService(
+	searchChangeRepo SearchChangeRepo,
+	syncer plugin.SearchSyncer,
+) *SearchSyncService {
+	ss := &SearchSyncService{
+		searchChangeRepo: searchChangeRepo,
+		syncer:           syncer,
+	}
+	go ss.working()
+	return ss
+}
+
+func (ss *SearchSyncService) working() {
+	ticker := time.NewTicker(syncPollInterval)
+	defer ticker.Stop()
+	for {
+		ss.SyncAll(context.Background())
+		select {
+		case <-ss.searchChangeRepo.Changed():
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncAll applies the pending changes to every enabled search plugin, then prunes the changes all of them synced
+func (ss *SearchSyncService) SyncAll(ctx context.Context) {
+	minCursor := int64(math.MaxInt64)
+	_ = plugin.CallSearch(func(search plugin.Search) error {
+		cursor, err := ss.sync(ctx, search)
+		if err != nil {
+			log.Errorf("sync search plugin %s failed: %v", search.Info().SlugName, err)
+		}
+		minCursor = min(minCursor, cursor)
+		return nil
+	})
+	if err := ss.searchChangeRepo.PruneChanges(ctx, minCursor, time.Now().Add(-changeRetention)); err != nil {
+		log.Errorf("prune search changes failed: %v", err)
+	}
+}
+
+// sync applies the changes after the cursor of the plugin and returns the cursor it reached
+func (ss *SearchSyncService) sync(ctx context.Context, search plugin.Search) (reached int64, err error) {
+	slugName := search.Info().SlugName
+	if startID, ok := ss.reindexing.Load(slugName); ok {
+		return startID.(int64), nil
+	}
+	cursor, exist, err := ss.searchChangeRepo.GetCursor(ctx, slugName)
+	if err != nil {
+		return 0, err
+	}
+	if !exist {
+		// the plugin syncs all the contents by itself when it is registered, so it starts from now on
+		latestID, err := ss.searchChangeRepo.GetLatestChangeID(ctx)
+		if err != nil {
+			return 0, err
+		}
+		cursor = &entity.SearchSyncCursor{PluginSlugName: slugName, Cursor: latestID}
+		return latestID, ss.searchChangeRepo.SaveCursor(ctx, cursor)
+	}
+	if cursor.Cursor == entity.SearchSyncCursorReindex {
+		ss.startReindex(search)
+		return 0, nil
+	}
+
+	for {
+		changes, nextCursor, err := ss.syncer.GetChangesSince(ctx, cursor.Cursor, syncBatchSize)
+		if err != nil {
+			return cursor.Cursor, err
+		}
+		if nextCursor == cursor.Cursor {
+			return cursor.Cursor, nil
+		}
+		for _, change := range changes {
+			if change.Deleted {
+				err = search.DeleteContent(ctx, change.ObjectID)
+			} else {
+				err = search.UpdateContent(ctx, change.Content)
+			}
+			if err != nil {
+				return cursor.Cursor, err
+			}
+		}
+		cursor.Cursor = nextCursor
+		if err = ss.searchChangeRepo.SaveCursor(ctx, cursor); err != nil {
+			return cursor.Cursor, err
+		}
+	}
+}
+
+// GetSyncStatus returns how far each enabled search plugin lags behind the changes
+func (ss *SearchSyncService) GetSyncStatus(ctx context.Context) (resp []*schema.GetSearchSyncStatusResp, err error) {
+	resp = make([]*schema.GetSearchSyncStatusResp, 0)
+	latestID, err := ss.searchChangeRepo.GetLatestChangeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = plugin.CallSearch(func(search plugin.Search) error {
+		slugName := search.Info().SlugName
+		item := &schema.GetSearchSyncStatusResp{
+			PluginSlugName: slugName,
+			LatestCursor:   latestID,
+		}
+		_, item.Reindexing = ss.reindexing.Load(slugName)
+		cursor, exist, err := ss.searchChangeRepo.GetCursor(ctx, slugName)
+		if err != nil {
+			return err
+		}
+		if exist {
+			item.Cursor = cursor.Cursor
+			item.NeedReindex = cursor.Cursor == entity.SearchSyncCursorReindex
+			item.SyncedAt = cursor.UpdatedAt.Unix()
+			if !cursor.ReindexedAt.IsZero() {
+				item.ReindexedAt = cursor.ReindexedAt.Unix()
+			}
+			if !item.NeedReindex {
+				count, oldest, err := ss.searchChangeRepo.CountChanges(ctx, cursor.Cursor)
+				if err != nil {
+					return err
+				}
+				item.PendingCount = count
+				if count > 0 {
+					item.Lag = int64(time.Since(oldest).Seconds())
+				}
+			}
+		}
+		resp = append(resp, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Reindex syncs all the questions and answers to the enabled search plugin again in background
+func (ss *SearchSyncService) Reindex(ctx context.Context, req *schema.ReindexSearchReq) (err error) {
+	var target plugin.Search
+	_ = plugin.CallSearch(func(search plugin.Search) error {
+		if search.Info().SlugName == req.PluginSlugName {
+			target = search
+		}
+		return nil
+	})
+	if target == nil {
+		return errors.BadRequest(reason.SearchPluginNotFound)
+	}
+	if !ss.startReindex(target) {
+		return errors.BadRequest(reason.SearchReindexing)
+	}
+	return nil
+}
+
+func (ss *SearchSyncService) startReindex(search plugin.Search) (started bool) {
+	slugName := search.Info().SlugName
+	if _, loaded := ss.reindexing.LoadOrStore(slugName, int64(0)); loaded {
+		return false
+	}
+	go func() {
+		defer ss.reindexing.Delete(slugName)
+		if err := ss.reindex(context.Background(), search); err != nil {
+			log.Errorf("reindex search plugin %s failed: %v", slugName, err)
+		}
+	}()
+	return true
+}
+
+// reindex pages through all the contents, the changes made meanwhile are synced from the cursor taken before
+func (ss *SearchSyncService) reindex(ctx context.Context, search plugin.Search) (err error) {
+	slugName := search.Info().SlugName
+	latestID, err := ss.searchChangeRepo.GetLatestChangeID(ctx)
+	if err != nil {
+		return err
+	}
+	// keep the changes made meanwhile from being pruned
+	ss.reindexing.Store(slugName, latestID)
+	apply := func(contents []*plugin.SearchContent) error {
+		for _, content := range contents {
+			if content.Status >= plugin.SearchContentStatusDeleted {
+				err = search.DeleteContent(ctx, content.ObjectID)
+			} else {
+				err = search.UpdateContent(ctx, content)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	indexed := 0
+	for page := 1; ; page++ {
+		questions, err := ss.syncer.GetQuestionsPage(ctx, page, reindexPageSize)
+		if err != nil {
+			return err
+		}
+		if err = apply(questions); err != nil {
+			return err
+		}
+		indexed += len(questions)
+		if len(questions) < reindexPageSize {
+			break
+		}
+	}
+	for page := 1; ; page++ {
+		answers, err := ss.syncer.GetAnswersPage(ctx, page, reindexPageSize)
+		if err != nil {
+			return err
+		}
+		if err = apply(answers); err != nil {
+			return err
+		}
+		indexed += len(answers)
+		if len(answers) < reindexPageSize {
+			break
+		}
+	}
+	log.Infof("search plugin %s is reindexed with %d questions and answers", slugName, indexed)
+	return ss.searchChangeRepo.SaveCursor(ctx, &entity.SearchSyncCursor{
+		PluginSlugName: slugName,
+		Cursor:         latestID,
+		ReindexedAt:    time.Now(),
+	})
+}
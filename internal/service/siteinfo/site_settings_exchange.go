@@ -0,0 +1,405 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package siteinfo
+
+import (
+	"context"
+	"encoding/json"
+	errpkg "errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/handler"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/base/translator"
+	"github.com/apache/answer/internal/base/validator"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/plugin_common"
+	"github.com/apache/answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// exchangeSiteTypes the site info sections in the settings file, the privileges are exchanged apart
+// because the config of the powers is updated with them
+var exchangeSiteTypes = []string{
+	constant.SiteTypeGeneral,
+	constant.SiteTypeInterface,
+	constant.SiteTypeBranding,
+	constant.SiteTypeWrite,
+	constant.SiteTypeLegal,
+	constant.SiteTypeSeo,
+	constant.SiteTypeLogin,
+	constant.SiteTypeCustomCssHTML,
+	constant.SiteTypeTheme,
+	constant.SiteTypeUsers,
+	constant.SiteTypeRateLimit,
+	constant.SiteTypeReview,
+}
+
+// newSiteInfoReq get the request to save the site info section, it is nil if the section can not be exchanged
+func newSiteInfoReq(siteType string) any {
+	switch siteType {
+	case constant.SiteTypeGeneral:
+		return &schema.SiteGeneralReq{}
+	case constant.SiteTypeInterface:
+		return &schema.SiteInterfaceReq{}
+	case constant.SiteTypeBranding:
+		return &schema.SiteBrandingReq{}
+	case constant.SiteTypeWrite:
+		return &schema.SiteWriteReq{}
+	case constant.SiteTypeLegal:
+		return &schema.SiteLegalReq{}
+	case constant.SiteTypeSeo:
+		return &schema.SiteSeoReq{}
+	case constant.SiteTypeLogin:
+		return &schema.SiteLoginReq{}
+	case constant.SiteTypeCustomCssHTML:
+		return &schema.SiteCustomCssHTMLReq{}
+	case constant.SiteTypeTheme:
+		return &schema.SiteThemeReq{}
+	case constant.SiteTypeUsers:
+		return &schema.SiteUsersReq{}
+	case constant.SiteTypeRateLimit:
+		return &schema.SiteRateLimitReq{}
+	case constant.SiteTypeReview:
+		return &schema.SiteReviewReq{}
+	}
+	return nil
+}
+
+// ExportSiteSettings export the site info, the privileges and the plugin settings to a json file
+func (s *SiteInfoService) ExportSiteSettings(ctx context.Context) (resp *schema.ExportSiteSettingsResp, err error) {
+	settings := &schema.SiteSettings{
+		Version:    schema.SiteSettingsVersion,
+		ExportedAt: time.Now().Unix(),
+		SiteInfo:   make(map[string]json.RawMessage),
+	}
+	for _, siteType := range exchangeSiteTypes {
+		siteInfo, exist, err := s.siteInfoRepo.GetByType(ctx, siteType)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			continue
+		}
+		if !json.Valid([]byte(siteInfo.Content)) {
+			log.Warnf("site info %s is not a valid json, it is not exported", siteType)
+			continue
+		}
+		settings.SiteInfo[siteType] = json.RawMessage(siteInfo.Content)
+	}
+
+	privileges, exist, err := s.siteInfoRepo.GetByType(ctx, constant.SiteTypePrivileges)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		settings.Privileges = &schema.UpdatePrivilegesConfigReq{}
+		if err = json.Unmarshal([]byte(privileges.Content), settings.Privileges); err != nil {
+			log.Warnf("privileges is not a valid json, it is not exported: %v", err)
+			settings.Privileges = nil
+		}
+	}
+
+	pluginStatus, _ := plugin.StatusManager.MarshalJSON()
+	_ = json.Unmarshal(pluginStatus, &settings.PluginStatus)
+	settings.PluginConfigs, err = s.pluginCommonService.GetPluginConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return &schema.ExportSiteSettingsResp{
+		FileName:    fmt.Sprintf("answer-settings-%s.json", time.Now().Format("20060102150405")),
+		ContentType: "application/json",
+		Content:     content,
+	}, nil
+}
+
+// ImportSiteSettings import the settings file. Nothing is changed if any value of the file is invalid,
+// the plugins check their configs by receiving them, so the configs they used before are restored
+// after a dry run or when the values can not be saved.
+func (s *SiteInfoService) ImportSiteSettings(ctx context.Context, req *schema.ImportSiteSettingsReq) (
+	resp *schema.ImportSiteSettingsResp, err error) {
+	settings := &schema.SiteSettings{}
+	if err = json.Unmarshal([]byte(req.Content), settings); err != nil {
+		return nil, errors.BadRequest(reason.SiteSettingsImportFormatError).WithError(err)
+	}
+	if settings.Version != schema.SiteSettingsVersion {
+		return nil, errors.BadRequest(reason.SiteSettingsImportVersionError)
+	}
+
+	resp = &schema.ImportSiteSettingsResp{
+		DryRun:        req.DryRun,
+		SiteInfo:      make([]string, 0),
+		PluginStatus:  make([]string, 0),
+		PluginConfigs: make([]string, 0),
+	}
+	siteInfoReqs := s.checkImportedSiteInfo(ctx, settings, resp)
+	if settings.Privileges != nil {
+		resp.Problems = append(resp.Problems, s.checkImportedValue(ctx,
+			schema.SiteSettingsSectionPrivileges, "", settings.Privileges)...)
+	}
+
+	installed := make(map[string]bool)
+	_ = plugin.CallBase(func(base plugin.Base) error {
+		installed[base.Info().SlugName] = true
+		return nil
+	})
+	skipped := make(map[string]bool)
+	for slugName := range settings.PluginStatus {
+		if installed[slugName] {
+			resp.PluginStatus = append(resp.PluginStatus, slugName)
+		} else {
+			skipped[slugName] = true
+		}
+	}
+	sort.Strings(resp.PluginStatus)
+	if len(resp.Problems) > 0 {
+		return resp, errors.BadRequest(reason.SiteSettingsImportInvalid)
+	}
+
+	snapshot := s.pluginCommonService.SnapshotPluginConfigs()
+	received, skippedConfigs, failedSlugName, err := s.pluginCommonService.ReceivePluginConfigs(ctx, settings.PluginConfigs)
+	if err != nil {
+		resp.Problems = append(resp.Problems, &schema.SiteSettingsImportProblem{
+			Section: schema.SiteSettingsSectionPluginConfigs,
+			Key:     failedSlugName,
+			Reason:  importProblemReason(ctx, err),
+		})
+		return resp, errors.BadRequest(reason.SiteSettingsImportInvalid)
+	}
+	resp.PluginConfigs = append(resp.PluginConfigs, received...)
+	for _, slugName := range skippedConfigs {
+		skipped[slugName] = true
+	}
+	for slugName := range skipped {
+		resp.SkippedPlugins = append(resp.SkippedPlugins, slugName)
+	}
+	sort.Strings(resp.SkippedPlugins)
+	resp.Privileges = settings.Privileges != nil
+
+	if req.DryRun {
+		s.pluginCommonService.RestorePluginConfigs(snapshot, received)
+		return resp, nil
+	}
+
+	previousPluginStatus, _ := plugin.StatusManager.MarshalJSON()
+	if err = s.saveImportedSettings(ctx, settings, siteInfoReqs, resp, req.UserID); err != nil {
+		s.pluginCommonService.RestorePluginConfigs(snapshot, received)
+		restorePluginStatus(previousPluginStatus)
+		return nil, err
+	}
+	for _, slugName := range received {
+		s.pluginCommonService.RegisterPluginFuncs(ctx, slugName)
+	}
+	return resp, nil
+}
+
+// saveImportedSettings save all the imported values in one transaction, the rows are the same as the ones saved by the api
+func (s *SiteInfoService) saveImportedSettings(ctx context.Context, settings *schema.SiteSettings,
+	siteInfoReqs map[string]any, resp *schema.ImportSiteSettingsResp, userID string) (err error) {
+	rows := &schema.SiteSettingsRows{
+		Configs:       make(map[string]string),
+		PluginConfigs: make(map[string]string),
+	}
+	for _, siteType := range resp.SiteInfo {
+		switch r := siteInfoReqs[siteType].(type) {
+		case *schema.SiteGeneralReq:
+			r.FormatSiteUrl()
+		case *schema.SiteWriteReq:
+			r.UserID = userID
+			rows.WriteTags = true
+			rows.RecommendTags, rows.ReservedTags = siteWriteTags(r)
+		}
+		content, _ := json.Marshal(siteInfoReqs[siteType])
+		rows.SiteInfos = append(rows.SiteInfos, &entity.SiteInfo{
+			Type:    siteType,
+			Content: string(content),
+			Status:  1,
+		})
+	}
+
+	if settings.Privileges != nil {
+		privileges, privilegeConfigs, err := s.privilegesConfigRows(ctx, settings.Privileges)
+		if err != nil {
+			return err
+		}
+		if privileges != nil {
+			rows.SiteInfos = append(rows.SiteInfos, privileges)
+		}
+		for key, value := range privilegeConfigs {
+			rows.Configs[key] = value
+		}
+	}
+
+	if len(resp.PluginStatus) > 0 {
+		for _, slugName := range resp.PluginStatus {
+			plugin.StatusManager.Enable(slugName, settings.PluginStatus[slugName])
+		}
+		content, err := plugin.StatusManager.MarshalJSON()
+		if err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err)
+		}
+		rows.Configs[constant.PluginStatus] = string(content)
+	}
+
+	for _, slugName := range resp.PluginConfigs {
+		// the values pinned by the environment variables are never saved
+		configValue, _ := json.Marshal(plugin_common.StripPluginConfigEnv(slugName, settings.PluginConfigs[slugName]))
+		rows.PluginConfigs[slugName] = string(configValue)
+	}
+	return s.siteInfoRepo.SaveSiteSettings(ctx, rows)
+}
+
+// restorePluginStatus restore the status of the plugins which is changed before the import fails
+func restorePluginStatus(previous []byte) {
+	status, current := make(map[string]bool), make(map[string]bool)
+	_ = json.Unmarshal(previous, &status)
+	content, _ := plugin.StatusManager.MarshalJSON()
+	_ = json.Unmarshal(content, &current)
+	// the plugins which have no previous status are disabled as before
+	for slugName := range current {
+		if _, ok := status[slugName]; !ok {
+			plugin.StatusManager.Enable(slugName, false)
+		}
+	}
+	if err := plugin.StatusManager.UnmarshalJSON(previous); err != nil {
+		log.Errorf("restore plugin status failed: %v", err)
+	}
+}
+
+// checkImportedSiteInfo decode and check the site info sections, the key of the requests is the site type
+func (s *SiteInfoService) checkImportedSiteInfo(ctx context.Context, settings *schema.SiteSettings,
+	resp *schema.ImportSiteSettingsResp) (siteInfoReqs map[string]any) {
+	siteInfoReqs = make(map[string]any)
+	for siteType, content := range settings.SiteInfo {
+		siteInfoReq := newSiteInfoReq(siteType)
+		if siteInfoReq == nil {
+			resp.Problems = append(resp.Problems, &schema.SiteSettingsImportProblem{
+				Section: schema.SiteSettingsSectionSiteInfo,
+				Key:     siteType,
+				Reason:  translator.Tr(handler.GetLangByCtx(ctx), reason.SiteSettingsUnknownSiteType),
+			})
+			continue
+		}
+		if err := json.Unmarshal(content, siteInfoReq); err != nil {
+			resp.Problems = append(resp.Problems, &schema.SiteSettingsImportProblem{
+				Section: schema.SiteSettingsSectionSiteInfo,
+				Key:     siteType,
+				Reason:  err.Error(),
+			})
+			continue
+		}
+		problems := s.checkImportedValue(ctx, schema.SiteSettingsSectionSiteInfo, siteType, siteInfoReq)
+		if len(problems) == 0 {
+			if err := s.checkImportedSiteInfoValue(ctx, siteInfoReq); err != nil {
+				problems = append(problems, &schema.SiteSettingsImportProblem{
+					Section: schema.SiteSettingsSectionSiteInfo,
+					Key:     siteType,
+					Reason:  importProblemReason(ctx, err),
+				})
+			}
+		}
+		if len(problems) > 0 {
+			resp.Problems = append(resp.Problems, problems...)
+			continue
+		}
+		siteInfoReqs[siteType] = siteInfoReq
+		resp.SiteInfo = append(resp.SiteInfo, siteType)
+	}
+	sort.Strings(resp.SiteInfo)
+	sort.Slice(resp.Problems, func(i, j int) bool {
+		return resp.Problems[i].Key < resp.Problems[j].Key
+	})
+	return siteInfoReqs
+}
+
+// checkImportedValue check the value with the validator which checks the request of the api
+func (s *SiteInfoService) checkImportedValue(ctx context.Context, section, key string, value any) (
+	problems []*schema.SiteSettingsImportProblem) {
+	errFields, err := validator.GetValidatorByLang(handler.GetLangByCtx(ctx)).Check(value)
+	if err == nil {
+		return nil
+	}
+	for _, errField := range errFields {
+		problems = append(problems, &schema.SiteSettingsImportProblem{
+			Section: section,
+			Key:     key,
+			Field:   errField.ErrorField,
+			Reason:  errField.ErrorMsg,
+		})
+	}
+	if len(problems) == 0 {
+		problems = append(problems, &schema.SiteSettingsImportProblem{
+			Section: section,
+			Key:     key,
+			Reason:  importProblemReason(ctx, err),
+		})
+	}
+	return problems
+}
+
+// checkImportedSiteInfoValue the checks which are done when the section is saved and are not in the validator
+func (s *SiteInfoService) checkImportedSiteInfoValue(ctx context.Context, siteInfoReq any) (err error) {
+	switch r := siteInfoReq.(type) {
+	case *schema.SiteInterfaceReq:
+		if !translator.CheckLanguageIsValid(r.Language) {
+			return errors.BadRequest(reason.LangNotFound)
+		}
+	case *schema.SiteReviewReq:
+		for _, pattern := range r.LocalReviewer.Patterns {
+			if _, err = regexp.Compile(pattern); err != nil {
+				return errors.BadRequest(reason.ReviewPatternInvalid)
+			}
+		}
+	case *schema.SiteWriteReq:
+		for _, tags := range [][]*schema.SiteWriteTag{r.RecommendTags, r.ReservedTags} {
+			slugNames := make([]string, 0, len(tags))
+			for _, tag := range tags {
+				slugNames = append(slugNames, tag.SlugName)
+			}
+			if err = s.tagCommonService.CheckTag(ctx, slugNames, ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// importProblemReason the message of the error, the reason is translated if the error has no message
+func importProblemReason(ctx context.Context, err error) string {
+	var e *errors.Error
+	if errpkg.As(err, &e) {
+		if len(e.Message) > 0 {
+			return e.Message
+		}
+		return translator.Tr(handler.GetLangByCtx(ctx), e.Reason)
+	}
+	return err.Error()
+}
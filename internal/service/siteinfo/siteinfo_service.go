@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	errpkg "errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/apache/answer/internal/base/constant"
@@ -35,6 +36,7 @@ import (
 	"github.com/apache/answer/internal/service/config"
 	"github.com/apache/answer/internal/service/export"
 	"github.com/apache/answer/internal/service/file_record"
+	"github.com/apache/answer/internal/service/plugin_common"
 	questioncommon "github.com/apache/answer/internal/service/question_common"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	tagcommon "github.com/apache/answer/internal/service/tag_common"
@@ -52,6 +54,7 @@ type SiteInfoService struct {
 	configService         *config.ConfigService
 	questioncommon        *questioncommon.QuestionCommon
 	fileRecordService     *file_record.FileRecordService
+	pluginCommonService   *plugin_common.PluginCommonService
 }
 
 func NewSiteInfoService(
@@ -62,7 +65,7 @@ func NewSiteInfoService(
 	configService *config.ConfigService,
 	questioncommon *questioncommon.QuestionCommon,
 	fileRecordService *file_record.FileRecordService,
-
+	pluginCommonService *plugin_common.PluginCommonService,
 ) *SiteInfoService {
 	plugin.RegisterGetSiteURLFunc(func() string {
 		generalSiteInfo, err := siteInfoCommonService.GetSiteGeneral(context.Background())
@@ -81,6 +84,7 @@ func NewSiteInfoService(
 		configService:         configService,
 		questioncommon:        questioncommon,
 		fileRecordService:     fileRecordService,
+		pluginCommonService:   pluginCommonService,
 	}
 }
 
@@ -186,7 +190,24 @@ func (s *SiteInfoService) SaveSiteBranding(ctx context.Context, req *schema.Site
 
 // SaveSiteWrite save site configuration about write
 func (s *SiteInfoService) SaveSiteWrite(ctx context.Context, req *schema.SiteWriteReq) (resp interface{}, err error) {
-	recommendTags, reservedTags := make([]string, 0), make([]string, 0)
+	recommendTags, reservedTags := siteWriteTags(req)
+	errData, err := s.tagCommonService.SetSiteWriteTag(ctx, recommendTags, reservedTags, req.UserID)
+	if err != nil {
+		return errData, err
+	}
+
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeWrite,
+		Content: string(content),
+		Status:  1,
+	}
+	return nil, s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeWrite, data)
+}
+
+// siteWriteTags get the slug names of the recommend and reserved tags without the duplicated ones
+func siteWriteTags(req *schema.SiteWriteReq) (recommendTags, reservedTags []string) {
+	recommendTags, reservedTags = make([]string, 0), make([]string, 0)
 	recommendTagMapping, reservedTagMapping := make(map[string]bool), make(map[string]bool)
 	for _, tag := range req.ReservedTags {
 		if !recommendTagMapping[tag.SlugName] {
@@ -205,18 +226,7 @@ func (s *SiteInfoService) SaveSiteWrite(ctx context.Context, req *schema.SiteWri
 			recommendTags = append(recommendTags, tag.SlugName)
 		}
 	}
-	errData, err := s.tagCommonService.SetSiteWriteTag(ctx, recommendTags, reservedTags, req.UserID)
-	if err != nil {
-		return errData, err
-	}
-
-	content, _ := json.Marshal(req)
-	data := &entity.SiteInfo{
-		Type:    constant.SiteTypeWrite,
-		Content: string(content),
-		Status:  1,
-	}
-	return nil, s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeWrite, data)
+	return recommendTags, reservedTags
 }
 
 // SaveSiteLegal save site legal configuration
@@ -274,6 +284,48 @@ func (s *SiteInfoService) SaveSiteUsers(ctx context.Context, req *schema.SiteUse
 	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeUsers, data)
 }
 
+// GetSiteRateLimit get site rate limit config
+func (s *SiteInfoService) GetSiteRateLimit(ctx context.Context) (resp *schema.SiteRateLimitResp, err error) {
+	return s.siteInfoCommonService.GetSiteRateLimit(ctx)
+}
+
+// SaveSiteRateLimit save site rate limit config
+func (s *SiteInfoService) SaveSiteRateLimit(ctx context.Context, req *schema.SiteRateLimitReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeRateLimit,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeRateLimit, data)
+}
+
+// GetSiteReview get site review config
+func (s *SiteInfoService) GetSiteReview(ctx context.Context) (resp *schema.SiteReviewResp, err error) {
+	return s.siteInfoCommonService.GetSiteReview(ctx)
+}
+
+// SaveSiteReview save site review config
+func (s *SiteInfoService) SaveSiteReview(ctx context.Context, req *schema.SiteReviewReq) (err error) {
+	for _, pattern := range req.LocalReviewer.Patterns {
+		if _, err = regexp.Compile(pattern); err != nil {
+			return errors.BadRequest(reason.ReviewPatternInvalid)
+		}
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeReview,
+		Content: string(content),
+		Status:  1,
+	}
+	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeReview, data)
+}
+
+// RotateEmailReplySecret replace the secret signing the reply addresses of the notification emails
+func (s *SiteInfoService) RotateEmailReplySecret(ctx context.Context) (err error) {
+	return s.emailService.RotateReplySecret(ctx)
+}
+
 // GetSMTPConfig get smtp config
 func (s *SiteInfoService) GetSMTPConfig(ctx context.Context) (resp *schema.GetSMTPConfigResp, err error) {
 	emailConfig, err := s.emailService.GetEmailConfig(ctx)
@@ -283,6 +335,7 @@ func (s *SiteInfoService) GetSMTPConfig(ctx context.Context) (resp *schema.GetSM
 	resp = &schema.GetSMTPConfigResp{}
 	_ = copier.Copy(resp, emailConfig)
 	resp.SMTPPassword = strings.Repeat("*", len(resp.SMTPPassword))
+	resp.ReplyInboundSecret = strings.Repeat("*", len(resp.ReplyInboundSecret))
 	return resp, nil
 }
 
@@ -299,6 +352,9 @@ func (s *SiteInfoService) UpdateSMTPConfig(ctx context.Context, req *schema.Upda
 	if len(ec.SMTPPassword) > 0 && ec.SMTPPassword == strings.Repeat("*", len(ec.SMTPPassword)) {
 		ec.SMTPPassword = emailConfig.SMTPPassword
 	}
+	if len(ec.ReplyInboundSecret) > 0 && ec.ReplyInboundSecret == strings.Repeat("*", len(ec.ReplyInboundSecret)) {
+		ec.ReplyInboundSecret = emailConfig.ReplyInboundSecret
+	}
 
 	err = s.emailService.SetEmailConfig(ctx, ec)
 	if err != nil {
@@ -386,18 +442,41 @@ func (s *SiteInfoService) translatePrivilegeOptions(ctx context.Context, privile
 }
 
 func (s *SiteInfoService) UpdatePrivilegesConfig(ctx context.Context, req *schema.UpdatePrivilegesConfigReq) (err error) {
+	data, configs, err := s.privilegesConfigRows(ctx, req)
+	if err != nil || data == nil {
+		return err
+	}
+	err = s.siteInfoRepo.SaveByType(ctx, constant.SiteTypePrivileges, data)
+	if err != nil {
+		return err
+	}
+
+	// update privilege in config
+	for key, value := range configs {
+		err = s.configService.UpdateConfig(ctx, key, value)
+		if err != nil {
+			return err
+		}
+	}
+	return
+}
+
+// privilegesConfigRows get the site info of the privilege level and the config values of the privileges,
+// the site info is nil if the level can not be chosen
+func (s *SiteInfoService) privilegesConfigRows(ctx context.Context, req *schema.UpdatePrivilegesConfigReq) (
+	data *entity.SiteInfo, configs map[string]string, err error) {
 	var choosePrivileges []*constant.Privilege
 	if req.Level == schema.PrivilegeLevelCustom {
 		choosePrivileges = req.CustomPrivileges
 	} else {
 		chooseOption := schema.DefaultPrivilegeOptions.Choose(req.Level)
 		if chooseOption == nil {
-			return nil
+			return nil, nil, nil
 		}
 		choosePrivileges = chooseOption.Privileges
 	}
 	if choosePrivileges == nil {
-		return nil
+		return nil, nil, nil
 	}
 
 	// update site info that user choose which privilege level
@@ -418,30 +497,22 @@ func (s *SiteInfoService) UpdatePrivilegesConfig(ctx context.Context, req *schem
 	} else {
 		privilege := &schema.UpdatePrivilegesConfigReq{}
 		if err = s.siteInfoCommonService.GetSiteInfoByType(ctx, constant.SiteTypePrivileges, privilege); err != nil {
-			return err
+			return nil, nil, err
 		}
 		req.CustomPrivileges = privilege.CustomPrivileges
 	}
 
 	content, _ := json.Marshal(req)
-	data := &entity.SiteInfo{
+	data = &entity.SiteInfo{
 		Type:    constant.SiteTypePrivileges,
 		Content: string(content),
 		Status:  1,
 	}
-	err = s.siteInfoRepo.SaveByType(ctx, constant.SiteTypePrivileges, data)
-	if err != nil {
-		return err
-	}
-
-	// update privilege in config
+	configs = make(map[string]string)
 	for _, privilege := range choosePrivileges {
-		err = s.configService.UpdateConfig(ctx, privilege.Key, fmt.Sprintf("%d", privilege.Value))
-		if err != nil {
-			return err
-		}
+		configs[privilege.Key] = fmt.Sprintf("%d", privilege.Value)
 	}
-	return
+	return data, configs, nil
 }
 
 func (s *SiteInfoService) CleanUpRemovedBrandingFiles(
@@ -36,6 +36,7 @@ type SiteInfoRepo interface {
 	SaveByType(ctx context.Context, siteType string, data *entity.SiteInfo) (err error)
 	GetByType(ctx context.Context, siteType string) (siteInfo *entity.SiteInfo, exist bool, err error)
 	IsBrandingFileUsed(ctx context.Context, filePath string) (bool, error)
+	SaveSiteSettings(ctx context.Context, settings *schema.SiteSettingsRows) (err error)
 }
 
 // siteInfoCommonService site info common service
@@ -56,6 +57,8 @@ type SiteInfoCommonService interface {
 	GetSiteCustomCssHTML(ctx context.Context) (resp *schema.SiteCustomCssHTMLResp, err error)
 	GetSiteTheme(ctx context.Context) (resp *schema.SiteThemeResp, err error)
 	GetSiteSeo(ctx context.Context) (resp *schema.SiteSeoResp, err error)
+	GetSiteRateLimit(ctx context.Context) (resp *schema.SiteRateLimitResp, err error)
+	GetSiteReview(ctx context.Context) (resp *schema.SiteReviewResp, err error)
 	GetSiteInfoByType(ctx context.Context, siteType string, resp interface{}) (err error)
 	IsBrandingFileUsed(ctx context.Context, filePath string) bool
 }
@@ -185,6 +188,24 @@ func (s *siteInfoCommonService) GetSiteLogin(ctx context.Context) (resp *schema.
 	return resp, nil
 }
 
+// GetSiteRateLimit get site rate limit config, the default config is used if the admin never saves it
+func (s *siteInfoCommonService) GetSiteRateLimit(ctx context.Context) (resp *schema.SiteRateLimitResp, err error) {
+	resp = schema.DefaultSiteRateLimit()
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeRateLimit, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSiteReview get site review config, the default config is used if the admin never saves it
+func (s *siteInfoCommonService) GetSiteReview(ctx context.Context) (resp *schema.SiteReviewResp, err error) {
+	resp = schema.DefaultSiteReview()
+	if err = s.GetSiteInfoByType(ctx, constant.SiteTypeReview, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetSiteCustomCssHTML get site custom css html config
 func (s *siteInfoCommonService) GetSiteCustomCssHTML(ctx context.Context) (resp *schema.SiteCustomCssHTMLResp, err error) {
 	resp = &schema.SiteCustomCssHTMLResp{}
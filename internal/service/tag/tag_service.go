@@ -27,6 +27,7 @@ import (
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/service/activity_queue"
 	"github.com/apache/answer/internal/service/revision_common"
+	"github.com/apache/answer/internal/service/search_sync"
 	"github.com/apache/answer/internal/service/siteinfo_common"
 	tagcommonser "github.com/apache/answer/internal/service/tag_common"
 	"github.com/apache/answer/pkg/htmltext"
@@ -51,6 +52,7 @@ type TagService struct {
 	followCommon         activity_common.FollowRepo
 	siteInfoService      siteinfo_common.SiteInfoCommonService
 	activityQueueService activity_queue.ActivityQueueService
+	searchChangeRepo     search_sync.SearchChangeRepo
 }
 
 // NewTagService new tag service
@@ -61,6 +63,7 @@ func NewTagService(
 	followCommon activity_common.FollowRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	activityQueueService activity_queue.ActivityQueueService,
+	searchChangeRepo search_sync.SearchChangeRepo,
 ) *TagService {
 	return &TagService{
 		tagRepo:              tagRepo,
@@ -69,6 +72,7 @@ func NewTagService(
 		followCommon:         followCommon,
 		siteInfoService:      siteInfoService,
 		activityQueueService: activityQueueService,
+		searchChangeRepo:     searchChangeRepo,
 	}
 }
 
@@ -108,7 +112,15 @@ func (ts *TagService) RemoveTag(ctx context.Context, req *schema.RemoveTagReq) (
 
 // UpdateTag update tag
 func (ts *TagService) UpdateTag(ctx context.Context, req *schema.UpdateTagReq) (err error) {
-	return ts.tagCommonService.UpdateTag(ctx, req)
+	err = ts.tagCommonService.UpdateTag(ctx, req)
+	if err != nil {
+		return err
+	}
+	// the questions are synced to the search plugins again with the renamed tag
+	if err = ts.searchChangeRepo.AddTagChanges(ctx, req.TagID); err != nil {
+		log.Errorf("add search changes of tag %s failed: %v", req.TagID, err)
+	}
+	return nil
 }
 
 // RecoverTag recover tag
@@ -491,6 +503,10 @@ func (ts *TagService) MergeTag(ctx context.Context, req *schema.MergeTagReq) (er
 		return err
 	}
 
+	// 6. sync the questions moved to the target tag to the search plugins
+	if err = ts.searchChangeRepo.AddTagChanges(ctx, targetTagInfo.ID); err != nil {
+		log.Errorf("add search changes of tag %s failed: %v", targetTagInfo.ID, err)
+	}
 	return nil
 }
 
@@ -920,11 +920,15 @@ func (ts *TagCommonService) UpdateTag(ctx context.Context, req *schema.UpdateTag
 		return err
 	}
 	if canUpdate {
+		activityTypeKey := constant.ActTagEdited
+		if req.Rollback {
+			activityTypeKey = constant.ActTagRollback
+		}
 		ts.activityQueueService.Send(ctx, &schema.ActivityMsg{
 			UserID:           req.UserID,
 			ObjectID:         tagInfo.ID,
 			OriginalObjectID: tagInfo.ID,
-			ActivityTypeKey:  constant.ActTagEdited,
+			ActivityTypeKey:  activityTypeKey,
 			RevisionID:       revisionID,
 		})
 	}
@@ -222,6 +222,10 @@ func (us *UserAdminService) UpdateUserRole(ctx context.Context, req *schema.Upda
 	if req.UserID == req.LoginUserID {
 		return errors.BadRequest(reason.UserCannotUpdateYourRole)
 	}
+	// the custom roles are assigned by UpdateUserCustomRoles
+	if !role.IsBuiltInRole(req.RoleID) {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
 
 	err = us.userRoleRelService.SaveUserRole(ctx, req.UserID, req.RoleID)
 	if err != nil {
@@ -232,6 +236,18 @@ func (us *UserAdminService) UpdateUserRole(ctx context.Context, req *schema.Upda
 	return
 }
 
+// UpdateUserCustomRoles replace the custom roles of the user
+func (us *UserAdminService) UpdateUserCustomRoles(ctx context.Context, req *schema.UpdateUserCustomRolesReq) (err error) {
+	_, exist, err := us.userRepo.GetUserInfo(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	return us.userRoleRelService.SaveUserCustomRoles(ctx, req.UserID, req.RoleIDs)
+}
+
 // AddUser add user
 func (us *UserAdminService) AddUser(ctx context.Context, req *schema.AddUserReq) (err error) {
 	_, has, err := us.userRepo.GetUserInfoByEmail(ctx, req.Email)
@@ -553,7 +569,17 @@ func (us *UserAdminService) setUserRoleInfo(ctx context.Context, resp []*schema.
 		return
 	}
 
+	userCustomRoleMapping, err := us.userRoleRelService.GetUserCustomRoleMapping(ctx, userIDs)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
 	for _, u := range resp {
+		u.CustomRoleIDs = userCustomRoleMapping[u.UserID]
+		if u.CustomRoleIDs == nil {
+			u.CustomRoleIDs = make([]int, 0)
+		}
 		r := userRoleMapping[u.UserID]
 		if r == nil {
 			continue
@@ -22,6 +22,7 @@ package usercommon
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/pkg/converter"
@@ -48,6 +49,7 @@ type UserRepo interface {
 	UpdateLastLoginDate(ctx context.Context, userID string) (err error)
 	UpdateEmailStatus(ctx context.Context, userID string, emailStatus int) error
 	UpdateNoticeStatus(ctx context.Context, userID string, noticeStatus int) error
+	SuspendUser(ctx context.Context, userID string, suspendedUntil time.Time) (err error)
 	UpdateEmail(ctx context.Context, userID, email string) error
 	UpdateUserInterface(ctx context.Context, userID, language, colorSchema string) (err error)
 	UpdatePass(ctx context.Context, userID, pass string) error
@@ -141,6 +143,18 @@ func (us *UserCommon) UpdateQuestionCount(ctx context.Context, userID string, nu
 	return us.userRepo.UpdateQuestionCount(ctx, userID, num)
 }
 
+// SuspendUser suspend the user until the time, the status in the login cache is updated too
+func (us *UserCommon) SuspendUser(ctx context.Context, userInfo *entity.User, suspendedUntil time.Time) (err error) {
+	if err = us.userRepo.SuspendUser(ctx, userInfo.ID, suspendedUntil); err != nil {
+		return err
+	}
+	return us.authService.SetUserStatus(ctx, &entity.UserCacheInfo{
+		UserID:      userInfo.ID,
+		EmailStatus: userInfo.MailStatus,
+		UserStatus:  entity.UserStatusSuspended,
+	})
+}
+
 func (us *UserCommon) BatchUserBasicInfoByID(ctx context.Context, userIDs []string) (map[string]*schema.UserBasicInfo, error) {
 	userIDs = checker.FilterEmptyString(userIDs)
 	userMap := make(map[string]*schema.UserBasicInfo)
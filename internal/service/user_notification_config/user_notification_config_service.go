@@ -24,6 +24,7 @@ import (
 	"github.com/apache/answer/internal/base/constant"
 	"github.com/apache/answer/internal/entity"
 	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/digest"
 	usercommon "github.com/apache/answer/internal/service/user_common"
 )
 
@@ -41,15 +42,18 @@ type UserNotificationConfigRepo interface {
 type UserNotificationConfigService struct {
 	userRepo                   usercommon.UserRepo
 	userNotificationConfigRepo UserNotificationConfigRepo
+	digestService              *digest.DigestService
 }
 
 func NewUserNotificationConfigService(
 	userRepo usercommon.UserRepo,
 	userNotificationConfigRepo UserNotificationConfigRepo,
+	digestService *digest.DigestService,
 ) *UserNotificationConfigService {
 	return &UserNotificationConfigService{
 		userRepo:                   userRepo,
 		userNotificationConfigRepo: userNotificationConfigRepo,
+		digestService:              digestService,
 	}
 }
 
@@ -62,6 +66,10 @@ func (us *UserNotificationConfigService) GetUserNotificationConfig(ctx context.C
 	resp = &schema.GetUserNotificationConfigResp{}
 	resp.NotificationConfig = schema.NewNotificationConfig(notificationConfigs)
 	resp.Format()
+	resp.DigestSchedule, err = us.digestService.GetSchedule(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
@@ -85,6 +93,17 @@ func (us *UserNotificationConfigService) UpdateUserNotificationConfig(
 	if err != nil {
 		return err
 	}
+	if req.NotificationConfig.Digest.Enable || req.DigestSchedule != nil {
+		err = us.digestService.SaveSchedule(ctx, req.UserID, req.DigestSchedule)
+		if err != nil {
+			return err
+		}
+	}
+	err = us.userNotificationConfigRepo.Save(ctx,
+		us.convertToEntity(ctx, req.UserID, constant.DigestSource, req.NotificationConfig.Digest))
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,223 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/pkg/display"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/log"
+)
+
+// handleEvent queue a delivery of the event for every active webhook subscribing to it. The deliveries are added
+// all together or not at all, an error makes the event retried without posting it twice to any webhook.
+func (ws *WebhookService) handleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	webhooks, err := ws.webhookRepo.GetActiveWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+	var payload *schema.WebhookPayload
+	messages := make([]*entity.QueueMessage, 0)
+	for _, webhook := range webhooks {
+		if !subscribed(webhook, msg.EventType) {
+			continue
+		}
+		if payload == nil {
+			var public bool
+			if payload, public = ws.buildPayload(ctx, msg); !public {
+				log.Debugf("the object %s of event %s is not public, it is not posted", msg.GetObjectID(), msg.EventType)
+				return nil
+			}
+		}
+		deliveryMsg, err := newWebhookDeliveryMsg(webhook.ID, payload)
+		if err != nil {
+			return err
+		}
+		message, err := ws.deliveryQueue.NewMessage(deliveryMsg)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	if err = ws.queueMessageRepo.AddMessages(ctx, messages...); err != nil {
+		return err
+	}
+	ws.deliveryQueue.Notify()
+	return nil
+}
+
+// handleDelivery post the queued delivery, an error makes the queue retry it later with backoff
+func (ws *WebhookService) handleDelivery(ctx context.Context, msg *schema.WebhookDeliveryMsg) error {
+	webhook, exist, err := ws.webhookRepo.GetWebhook(ctx, msg.WebhookID)
+	if err != nil {
+		return err
+	}
+	if !exist || webhook.Status != entity.WebhookStatusActive {
+		log.Debugf("webhook %s is not active, delivery %s is dropped", msg.WebhookID, msg.DeliveryID)
+		return nil
+	}
+	attempts, err := ws.webhookRepo.CountDeliveryAttempts(ctx, msg.DeliveryID)
+	if err != nil {
+		return err
+	}
+	delivery := ws.sender.post(ctx, webhook, msg)
+	delivery.Attempt = int(attempts) + 1
+	if err = ws.webhookRepo.AddDelivery(ctx, delivery); err != nil {
+		return err
+	}
+	if !delivery.Success {
+		return fmt.Errorf("deliver %s to webhook %s failed: %d %s",
+			msg.DeliveryID, msg.WebhookID, delivery.ResponseStatus, delivery.Error)
+	}
+	return nil
+}
+
+// buildPayload enrich the event with the info of the object it happened on. The events on the hidden,
+// pending or scheduled posts are not public, except the deletions, which are posted with the ids only.
+func (ws *WebhookService) buildPayload(ctx context.Context, msg *schema.EventMsg) (
+	payload *schema.WebhookPayload, public bool) {
+	payload = &schema.WebhookPayload{
+		EventType:      string(msg.EventType),
+		UserID:         msg.UserID,
+		ObjectID:       msg.GetObjectID(),
+		QuestionID:     msg.QuestionID,
+		QuestionUserID: msg.QuestionUserID,
+		AnswerID:       msg.AnswerID,
+		AnswerUserID:   msg.AnswerUserID,
+		CommentID:      msg.CommentID,
+		CommentUserID:  msg.CommentUserID,
+		ExtraInfo:      msg.ExtraInfo,
+	}
+	if len(payload.ObjectID) == 0 {
+		return payload, true
+	}
+	objInfo, err := ws.objectInfoService.GetInfo(ctx, payload.ObjectID)
+	if err != nil {
+		log.Debugf("get object info of %s failed: %v", payload.ObjectID, err)
+	}
+	if objInfo == nil || !isPublicObject(objInfo) {
+		if !isDeleteEvent(msg.EventType) {
+			return nil, false
+		}
+		payload.ExtraInfo = nil
+		return payload, true
+	}
+	payload.Object = &schema.WebhookPayloadObject{
+		ObjectType:    objInfo.ObjectType,
+		Title:         objInfo.Title,
+		CreatorUserID: objInfo.ObjectCreatorUserID,
+		QuestionID:    objInfo.QuestionID,
+		AnswerID:      objInfo.AnswerID,
+		CommentID:     objInfo.CommentID,
+	}
+	payload.Object.URL = ws.objectURL(ctx, objInfo)
+	return payload, true
+}
+
+// isPublicObject whether the object and the question it belongs to can be seen by everyone
+func isPublicObject(objInfo *schema.SimpleObjectInfo) bool {
+	if len(objInfo.QuestionID) > 0 {
+		if objInfo.QuestionStatus != entity.QuestionStatusAvailable &&
+			objInfo.QuestionStatus != entity.QuestionStatusClosed {
+			return false
+		}
+		if objInfo.QuestionShow != entity.QuestionShow {
+			return false
+		}
+	}
+	if len(objInfo.AnswerID) > 0 && objInfo.AnswerStatus != entity.AnswerStatusAvailable {
+		return false
+	}
+	if len(objInfo.CommentID) > 0 && objInfo.CommentStatus != entity.CommentStatusAvailable {
+		return false
+	}
+	return true
+}
+
+func isDeleteEvent(eventType constant.EventType) bool {
+	switch eventType {
+	case constant.EventQuestionDelete, constant.EventAnswerDelete, constant.EventCommentDelete:
+		return true
+	}
+	return false
+}
+
+func (ws *WebhookService) objectURL(ctx context.Context, objInfo *schema.SimpleObjectInfo) string {
+	if len(objInfo.QuestionID) == 0 {
+		return ""
+	}
+	siteInfo, err := ws.siteInfoCommonService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	seoInfo, err := ws.siteInfoCommonService.GetSiteSeo(ctx)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	switch objInfo.ObjectType {
+	case constant.AnswerObjectType:
+		return display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl, objInfo.QuestionID, objInfo.Title, objInfo.AnswerID)
+	case constant.CommentObjectType:
+		return display.CommentURL(seoInfo.Permalink, siteInfo.SiteUrl, objInfo.QuestionID, objInfo.Title,
+			objInfo.AnswerID, objInfo.CommentID)
+	default:
+		return display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, objInfo.QuestionID, objInfo.Title)
+	}
+}
+
+// newWebhookDeliveryMsg every delivery gets its own id, it is kept across the retries
+func newWebhookDeliveryMsg(webhookID string, payload *schema.WebhookPayload) (*schema.WebhookDeliveryMsg, error) {
+	body := *payload
+	body.DeliveryID = uid.ID().String()
+	body.Timestamp = time.Now().Unix()
+	content, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.WebhookDeliveryMsg{
+		WebhookID:  webhookID,
+		DeliveryID: body.DeliveryID,
+		EventType:  body.EventType,
+		ObjectID:   body.ObjectID,
+		Payload:    string(content),
+	}, nil
+}
+
+func subscribed(webhook *entity.Webhook, eventType constant.EventType) bool {
+	for _, t := range strings.Split(webhook.EventTypes, ",") {
+		if t == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/queue_message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type activeWebhookRepo struct {
+	WebhookRepo
+	webhooks []*entity.Webhook
+}
+
+func (r *activeWebhookRepo) GetActiveWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	return r.webhooks, nil
+}
+
+type outboxRepo struct {
+	queue_message.QueueMessageRepo
+	err      error
+	messages []*entity.QueueMessage
+}
+
+func (r *outboxRepo) AddMessages(ctx context.Context, messages ...*entity.QueueMessage) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.messages = append(r.messages, messages...)
+	return nil
+}
+
+func TestWebhookService_HandleEvent(t *testing.T) {
+	outbox := &outboxRepo{err: fmt.Errorf("database is gone")}
+	ws := &WebhookService{
+		webhookRepo: &activeWebhookRepo{webhooks: []*entity.Webhook{
+			{ID: "1", EventTypes: "user.update"},
+			{ID: "2", EventTypes: "answer.create,user.update"},
+			{ID: "3", EventTypes: "answer.create"},
+		}},
+		queueMessageRepo: outbox,
+		deliveryQueue:    queue_message.NewQueue[schema.WebhookDeliveryMsg]("webhook", outbox),
+	}
+	msg := schema.NewEvent(constant.EventUserUpdate, "10")
+
+	// the event is retried if the deliveries can not be queued
+	assert.Error(t, ws.handleEvent(context.TODO(), msg))
+	assert.Empty(t, outbox.messages)
+
+	outbox.err = nil
+	require.NoError(t, ws.handleEvent(context.TODO(), msg))
+	require.Len(t, outbox.messages, 2)
+	for _, message := range outbox.messages {
+		assert.Equal(t, "webhook", message.Queue)
+	}
+}
+
+func TestIsPublicObject(t *testing.T) {
+	question := func(status, show int) schema.SimpleObjectInfo {
+		return schema.SimpleObjectInfo{ObjectType: constant.QuestionObjectType, QuestionID: "1",
+			QuestionStatus: status, QuestionShow: show}
+	}
+	answer := func(info schema.SimpleObjectInfo, status int) *schema.SimpleObjectInfo {
+		info.ObjectType, info.AnswerID, info.AnswerStatus = constant.AnswerObjectType, "2", status
+		return &info
+	}
+	comment := func(info *schema.SimpleObjectInfo, status int) *schema.SimpleObjectInfo {
+		c := *info
+		c.ObjectType, c.CommentID, c.CommentStatus = constant.CommentObjectType, "3", status
+		return &c
+	}
+	available := question(entity.QuestionStatusAvailable, entity.QuestionShow)
+	closed := question(entity.QuestionStatusClosed, entity.QuestionShow)
+	hidden := question(entity.QuestionStatusAvailable, entity.QuestionHide)
+	pending := question(entity.QuestionStatusPending, entity.QuestionShow)
+	scheduled := question(entity.QuestionStatusScheduled, entity.QuestionShow)
+
+	tests := []struct {
+		name    string
+		objInfo *schema.SimpleObjectInfo
+		want    bool
+	}{
+		{name: "question", objInfo: &available, want: true},
+		{name: "closed question", objInfo: &closed, want: true},
+		{name: "hidden question", objInfo: &hidden, want: false},
+		{name: "pending question", objInfo: &pending, want: false},
+		{name: "scheduled question", objInfo: &scheduled, want: false},
+		{name: "answer", objInfo: answer(available, entity.AnswerStatusAvailable), want: true},
+		{name: "pending answer", objInfo: answer(available, entity.AnswerStatusPending), want: false},
+		{name: "answer of hidden question", objInfo: answer(hidden, entity.AnswerStatusAvailable), want: false},
+		{name: "comment", objInfo: comment(&available, entity.CommentStatusAvailable), want: true},
+		{name: "pending comment", objInfo: comment(&available, entity.CommentStatusPending), want: false},
+		{name: "comment of deleted answer",
+			objInfo: comment(answer(available, entity.AnswerStatusDeleted), entity.CommentStatusAvailable), want: false},
+		{name: "comment of pending question", objInfo: comment(&pending, entity.CommentStatusAvailable), want: false},
+		{name: "tag", objInfo: &schema.SimpleObjectInfo{ObjectType: constant.TagObjectType, TagID: "4"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isPublicObject(tt.objInfo))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+)
+
+const (
+	// SignatureHeader HMAC-SHA256 of the request body keyed by the webhook secret, such as sha256=xxx
+	SignatureHeader = "X-Answer-Signature"
+	EventHeader     = "X-Answer-Event"
+	DeliveryHeader  = "X-Answer-Delivery"
+
+	webhookTimeout       = 10 * time.Second
+	webhookResponseLimit = 4096
+)
+
+// Sign the payload with the secret of the webhook
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type webhookSender struct {
+	client *http.Client
+}
+
+func newWebhookSender() *webhookSender {
+	return &webhookSender{client: &http.Client{Timeout: webhookTimeout}}
+}
+
+// post the payload to the webhook, any 2xx response means it is accepted
+func (s *webhookSender) post(ctx context.Context, webhook *entity.Webhook, msg *schema.WebhookDeliveryMsg) (
+	delivery *entity.WebhookDelivery) {
+	delivery = &entity.WebhookDelivery{
+		WebhookID:   webhook.ID,
+		DeliveryID:  msg.DeliveryID,
+		EventType:   msg.EventType,
+		Attempt:     1,
+		RequestBody: msg.Payload,
+	}
+	start := time.Now()
+	defer func() {
+		delivery.Duration = time.Since(start).Milliseconds()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewBufferString(msg.Payload))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Answer-Webhook")
+	req.Header.Set(EventHeader, msg.EventType)
+	req.Header.Set(DeliveryHeader, msg.DeliveryID)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, []byte(msg.Payload)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	delivery.ResponseStatus = resp.StatusCode
+	delivery.ResponseBody = string(body)
+	delivery.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	return delivery
+}
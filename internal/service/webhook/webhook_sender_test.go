@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSender_Post(t *testing.T) {
+	var received *http.Request
+	var receivedBody []byte
+	status := http.StatusInternalServerError
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	webhook := &entity.Webhook{ID: "1", URL: server.URL, Secret: "secret"}
+	msg, err := newWebhookDeliveryMsg(webhook.ID, &schema.WebhookPayload{EventType: "answer.create", ObjectID: "2"})
+	require.NoError(t, err)
+	sender := newWebhookSender()
+
+	delivery := sender.post(context.TODO(), webhook, msg)
+	assert.False(t, delivery.Success)
+	assert.Equal(t, http.StatusInternalServerError, delivery.ResponseStatus)
+
+	status = http.StatusOK
+	delivery = sender.post(context.TODO(), webhook, msg)
+	assert.True(t, delivery.Success)
+	assert.Equal(t, "ok", delivery.ResponseBody)
+	assert.Equal(t, msg.Payload, string(receivedBody))
+	assert.Equal(t, Sign("secret", receivedBody), received.Header.Get(SignatureHeader))
+	assert.Equal(t, "answer.create", received.Header.Get(EventHeader))
+	assert.Equal(t, msg.DeliveryID, received.Header.Get(DeliveryHeader))
+}
+
+func TestWebhookSender_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	webhook := &entity.Webhook{ID: "1", URL: server.URL, Secret: "secret"}
+	msg, err := newWebhookDeliveryMsg(webhook.ID, &schema.WebhookPayload{EventType: schema.WebhookPingEvent})
+	require.NoError(t, err)
+
+	delivery := newWebhookSender().post(context.TODO(), webhook, msg)
+	assert.False(t, delivery.Success)
+	assert.NotEmpty(t, delivery.Error)
+}
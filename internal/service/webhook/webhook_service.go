@@ -0,0 +1,242 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apache/answer/internal/base/constant"
+	"github.com/apache/answer/internal/base/reason"
+	"github.com/apache/answer/internal/entity"
+	"github.com/apache/answer/internal/schema"
+	"github.com/apache/answer/internal/service/event_queue"
+	"github.com/apache/answer/internal/service/object_info"
+	"github.com/apache/answer/internal/service/queue_message"
+	"github.com/apache/answer/internal/service/siteinfo_common"
+	"github.com/apache/answer/pkg/token"
+	"github.com/apache/answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// WebhookRepo webhook repository
+type WebhookRepo interface {
+	AddWebhook(ctx context.Context, webhook *entity.Webhook) (err error)
+	UpdateWebhook(ctx context.Context, webhook *entity.Webhook) (updated bool, err error)
+	DeleteWebhook(ctx context.Context, id string) (err error)
+	GetWebhook(ctx context.Context, id string) (webhook *entity.Webhook, exist bool, err error)
+	GetWebhookList(ctx context.Context) (webhooks []*entity.Webhook, err error)
+	GetActiveWebhooks(ctx context.Context) (webhooks []*entity.Webhook, err error)
+	AddDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (err error)
+	CountDeliveryAttempts(ctx context.Context, deliveryID string) (count int64, err error)
+	GetDeliveryPage(ctx context.Context, page, pageSize int, webhookID string) (
+		deliveries []*entity.WebhookDelivery, total int64, err error)
+}
+
+// WebhookService posts the subscribed site events to the webhooks managed by the admin
+type WebhookService struct {
+	webhookRepo           WebhookRepo
+	objectInfoService     *object_info.ObjService
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	queueMessageRepo      queue_message.QueueMessageRepo
+	deliveryQueue         *queue_message.Queue[schema.WebhookDeliveryMsg]
+	sender                *webhookSender
+}
+
+// NewWebhookService new webhook service
+func NewWebhookService(
+	webhookRepo WebhookRepo,
+	eventQueueService event_queue.EventQueueService,
+	queueMessageRepo queue_message.QueueMessageRepo,
+	objectInfoService *object_info.ObjService,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+) *WebhookService {
+	ws := &WebhookService{
+		webhookRepo:           webhookRepo,
+		objectInfoService:     objectInfoService,
+		siteInfoCommonService: siteInfoCommonService,
+		queueMessageRepo:      queueMessageRepo,
+		sender:                newWebhookSender(),
+	}
+	// every webhook gets its own delivery, so that a failing endpoint is retried alone
+	ws.deliveryQueue = queue_message.NewOrderedQueue[schema.WebhookDeliveryMsg]("webhook", queueMessageRepo,
+		func(msg *schema.WebhookDeliveryMsg) string { return msg.WebhookID + ":" + msg.ObjectID })
+	ws.deliveryQueue.RegisterHandler(ws.handleDelivery)
+	eventQueueService.Subscribe(&event_queue.Subscriber{
+		Name:    "webhook",
+		Handler: ws.handleEvent,
+	})
+	return ws
+}
+
+// GetWebhookList get all webhooks
+func (ws *WebhookService) GetWebhookList(ctx context.Context) (resp []*schema.WebhookItem, err error) {
+	webhooks, err := ws.webhookRepo.GetWebhookList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.WebhookItem, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		resp = append(resp, &schema.WebhookItem{
+			ID:         webhook.ID,
+			Name:       webhook.Name,
+			URL:        webhook.URL,
+			EventTypes: splitEventTypes(webhook.EventTypes),
+			Active:     webhook.Status == entity.WebhookStatusActive,
+			CreatedAt:  webhook.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// AddWebhook add webhook, the secret is returned only here
+func (ws *WebhookService) AddWebhook(ctx context.Context, req *schema.AddWebhookReq) (
+	resp *schema.AddWebhookResp, err error) {
+	if err = checkEventTypes(req.EventTypes); err != nil {
+		return nil, err
+	}
+	if len(req.Secret) == 0 {
+		req.Secret = token.GenerateToken()
+	}
+	webhook := &entity.Webhook{
+		ID:         uid.ID().String(),
+		Name:       req.Name,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: strings.Join(req.EventTypes, ","),
+		Status:     webhookStatus(req.Active),
+	}
+	if err = ws.webhookRepo.AddWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return &schema.AddWebhookResp{ID: webhook.ID, Secret: webhook.Secret}, nil
+}
+
+// UpdateWebhook update webhook
+func (ws *WebhookService) UpdateWebhook(ctx context.Context, req *schema.UpdateWebhookReq) (err error) {
+	if err = checkEventTypes(req.EventTypes); err != nil {
+		return err
+	}
+	webhook, exist, err := ws.webhookRepo.GetWebhook(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.WebhookNotFound)
+	}
+	if len(req.Secret) == 0 {
+		req.Secret = webhook.Secret
+	}
+	updated, err := ws.webhookRepo.UpdateWebhook(ctx, &entity.Webhook{
+		ID:         req.ID,
+		Name:       req.Name,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: strings.Join(req.EventTypes, ","),
+		Status:     webhookStatus(req.Active),
+	})
+	if err != nil {
+		return err
+	}
+	// deleted in the meantime
+	if !updated {
+		return errors.BadRequest(reason.WebhookNotFound)
+	}
+	return nil
+}
+
+// DeleteWebhook delete webhook, the queued deliveries are dropped
+func (ws *WebhookService) DeleteWebhook(ctx context.Context, req *schema.DeleteWebhookReq) (err error) {
+	return ws.webhookRepo.DeleteWebhook(ctx, req.ID)
+}
+
+// TestWebhook post a ping event to the webhook right now, the result is recorded in the delivery log
+func (ws *WebhookService) TestWebhook(ctx context.Context, req *schema.TestWebhookReq) (
+	resp *schema.WebhookDeliveryItem, err error) {
+	webhook, exist, err := ws.webhookRepo.GetWebhook(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.WebhookNotFound)
+	}
+	msg, err := newWebhookDeliveryMsg(webhook.ID, &schema.WebhookPayload{EventType: schema.WebhookPingEvent})
+	if err != nil {
+		return nil, err
+	}
+	delivery := ws.sender.post(ctx, webhook, msg)
+	if err = ws.webhookRepo.AddDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return convertWebhookDelivery(delivery), nil
+}
+
+// GetWebhookDeliveryPage get the delivery log of the webhook
+func (ws *WebhookService) GetWebhookDeliveryPage(ctx context.Context, req *schema.GetWebhookDeliveryPageReq) (
+	resp []*schema.WebhookDeliveryItem, total int64, err error) {
+	deliveries, total, err := ws.webhookRepo.GetDeliveryPage(ctx, req.Page, req.PageSize, req.WebhookID)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp = make([]*schema.WebhookDeliveryItem, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		resp = append(resp, convertWebhookDelivery(delivery))
+	}
+	return resp, total, nil
+}
+
+func convertWebhookDelivery(delivery *entity.WebhookDelivery) *schema.WebhookDeliveryItem {
+	return &schema.WebhookDeliveryItem{
+		ID:             delivery.ID,
+		DeliveryID:     delivery.DeliveryID,
+		EventType:      delivery.EventType,
+		Attempt:        delivery.Attempt,
+		RequestBody:    delivery.RequestBody,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		Success:        delivery.Success,
+		Duration:       delivery.Duration,
+		CreatedAt:      delivery.CreatedAt.Unix(),
+	}
+}
+
+func checkEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		if !constant.IsEventType(eventType) {
+			return errors.BadRequest(reason.WebhookEventTypeInvalid)
+		}
+	}
+	return nil
+}
+
+func splitEventTypes(eventTypes string) []string {
+	if len(eventTypes) == 0 {
+		return []string{}
+	}
+	return strings.Split(eventTypes, ",")
+}
+
+func webhookStatus(active bool) int {
+	if active {
+		return entity.WebhookStatusActive
+	}
+	return entity.WebhookStatusInactive
+}
@@ -0,0 +1,208 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package diff finds the changes between two texts
+package diff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Operation how a segment is changed
+type Operation string
+
+const (
+	Equal  Operation = "equal"
+	Insert Operation = "insert"
+	Delete Operation = "delete"
+)
+
+// maxEditDistance limits the work of comparing, the texts which differ more are shown as replaced as a whole
+const maxEditDistance = 1000
+
+// Segment a run of text which is kept, inserted or deleted
+type Segment struct {
+	Type Operation `json:"type"`
+	Text string    `json:"text"`
+}
+
+// Lines compares the texts line by line, every line keeps its line break
+func Lines(from, to string) []*Segment {
+	return compare(splitLines(from), splitLines(to))
+}
+
+// Words compares the texts word by word, the spaces and punctuations are compared as well
+func Words(from, to string) []*Segment {
+	return compare(splitWords(from), splitWords(to))
+}
+
+func compare(a, b []string) []*Segment {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	segments := make([]*Segment, 0)
+	add := func(op Operation, token string) {
+		if len(segments) > 0 && segments[len(segments)-1].Type == op {
+			segments[len(segments)-1].Text += token
+			return
+		}
+		segments = append(segments, &Segment{Type: op, Text: token})
+	}
+	for _, token := range a[:prefix] {
+		add(Equal, token)
+	}
+	for _, edit := range shortestEdit(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		add(edit.op, edit.token)
+	}
+	for _, token := range a[len(a)-suffix:] {
+		add(Equal, token)
+	}
+	return segments
+}
+
+type edit struct {
+	op    Operation
+	token string
+}
+
+// shortestEdit the myers algorithm, returns the edits turning a into b
+func shortestEdit(a, b []string) []edit {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+	limit := n + m
+	if limit > maxEditDistance {
+		limit = maxEditDistance
+	}
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	trace := make([][]int, 0)
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace, offset)
+			}
+		}
+	}
+
+	edits := make([]edit, 0, n+m)
+	for _, token := range a {
+		edits = append(edits, edit{op: Delete, token: token})
+	}
+	for _, token := range b {
+		edits = append(edits, edit{op: Insert, token: token})
+	}
+	return edits
+}
+
+func backtrack(a, b []string, trace [][]int, offset int) []edit {
+	edits := make([]edit, 0)
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			edits = append(edits, edit{op: Equal, token: a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, edit{op: Insert, token: b[y-1]})
+			} else {
+				edits = append(edits, edit{op: Delete, token: a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+func splitLines(text string) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// splitWords splits the text into the words, the runs of spaces and the single other characters,
+// the CJK characters are taken one by one because there is no space between the words
+func splitWords(text string) []string {
+	tokens := make([]string, 0)
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch {
+		case unicode.IsSpace(runes[i]):
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		case isWordRune(runes[i]):
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+		return false
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
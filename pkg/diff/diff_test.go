@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	segments := Lines("a\nb\nc\n", "a\nB\nc\nd\n")
+	assert.Equal(t, []*Segment{
+		{Type: Equal, Text: "a\n"},
+		{Type: Delete, Text: "b\n"},
+		{Type: Insert, Text: "B\n"},
+		{Type: Equal, Text: "c\n"},
+		{Type: Insert, Text: "d\n"},
+	}, segments)
+
+	assert.Equal(t, []*Segment{{Type: Equal, Text: "same"}}, Lines("same", "same"))
+	assert.Equal(t, []*Segment{{Type: Insert, Text: "new"}}, Lines("", "new"))
+	assert.Empty(t, Lines("", ""))
+}
+
+func TestWords(t *testing.T) {
+	segments := Words("How to write Go code?", "How to test Go code?")
+	assert.Equal(t, []*Segment{
+		{Type: Equal, Text: "How to "},
+		{Type: Delete, Text: "write"},
+		{Type: Insert, Text: "test"},
+		{Type: Equal, Text: " Go code?"},
+	}, segments)
+
+	segments = Words("你好世界", "你好中国")
+	assert.Equal(t, []*Segment{
+		{Type: Equal, Text: "你好"},
+		{Type: Delete, Text: "世界"},
+		{Type: Insert, Text: "中国"},
+	}, segments)
+}
+
+func TestCompare_Rebuild(t *testing.T) {
+	from := "one two three four five six seven"
+	to := "zero one three four 4.5 five seven eight"
+	var left, right strings.Builder
+	for _, segment := range Words(from, to) {
+		if segment.Type != Insert {
+			left.WriteString(segment.Text)
+		}
+		if segment.Type != Delete {
+			right.WriteString(segment.Text)
+		}
+	}
+	assert.Equal(t, from, left.String())
+	assert.Equal(t, to, right.String())
+}
+
+func TestCompare_TooDifferent(t *testing.T) {
+	from := strings.Repeat("a\n", maxEditDistance)
+	to := strings.Repeat("b\n", maxEditDistance)
+	assert.Equal(t, []*Segment{
+		{Type: Delete, Text: from},
+		{Type: Insert, Text: to},
+	}, Lines(from, to))
+}
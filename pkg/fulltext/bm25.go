@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package fulltext
+
+import "math"
+
+const (
+	// BM25K1 controls how quickly the term frequency saturates
+	BM25K1 = 1.2
+	// BM25B controls how much the document length normalizes the term frequency
+	BM25B = 0.75
+)
+
+// BM25IDF the inverse document frequency of a term found in docFreq of the docCount documents
+func BM25IDF(docCount, docFreq int64) float64 {
+	return math.Log(1 + (float64(docCount-docFreq)+0.5)/(float64(docFreq)+0.5))
+}
+
+// BM25 the score of a term with the frequency in the document
+func BM25(idf, frequency, docLength, avgDocLength float64) float64 {
+	if avgDocLength <= 0 {
+		avgDocLength = 1
+	}
+	return idf * frequency * (BM25K1 + 1) / (frequency + BM25K1*(1-BM25B+BM25B*docLength/avgDocLength))
+}
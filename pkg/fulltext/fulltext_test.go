@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	cases := map[string]string{
+		"caresses": "caress", "ponies": "poni", "cats": "cat", "feed": "feed", "agreed": "agre",
+		"plastered": "plaster", "motoring": "motor", "sing": "sing", "conflated": "conflat",
+		"hopping": "hop", "falling": "fall", "filing": "file", "happy": "happi", "sky": "sky",
+		"relational": "relat", "conditional": "condit", "digitizer": "digit", "operator": "oper",
+		"hopefulness": "hope", "sensibiliti": "sensibl", "triplicate": "triplic", "goodness": "good",
+		"allowance": "allow", "adjustable": "adjust", "replacement": "replac", "adoption": "adopt",
+		"effective": "effect", "cease": "ceas", "controll": "control", "roll": "roll",
+		"generalizations": "gener", "connections": "connect", "connected": "connect", "go": "go",
+	}
+	for word, stem := range cases {
+		assert.Equal(t, stem, Stem(word), word)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize("How to configure the Connections in Go?")
+	assert.Equal(t, []Token{
+		{Term: "how", Position: 0},
+		{Term: "configur", Position: 2},
+		{Term: "connect", Position: 4},
+		{Term: "go", Position: 6},
+	}, tokens)
+
+	tokens = Tokenize("使用搜索 v1.2")
+	assert.Equal(t, []Token{
+		{Term: "使用", Position: 0},
+		{Term: "用搜", Position: 1},
+		{Term: "搜索", Position: 2},
+		{Term: "v1", Position: 3},
+		{Term: "2", Position: 4},
+	}, tokens)
+
+	assert.Equal(t, []string{"デー", "ータ"}, Terms("データ"))
+	assert.Empty(t, Tokenize("the of and"))
+}
+
+func TestBM25(t *testing.T) {
+	rare, common := BM25IDF(1000, 1), BM25IDF(1000, 500)
+	assert.Greater(t, rare, common)
+	assert.Greater(t, BM25(rare, 3, 100, 100), BM25(rare, 1, 100, 100))
+	assert.Greater(t, BM25(rare, 1, 50, 100), BM25(rare, 1, 200, 100))
+}
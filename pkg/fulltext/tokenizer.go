@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package fulltext provides the text analysis and ranking used by the built-in search index.
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxTermLength the terms longer than it are dropped, they are rarely searched and bloat the index
+const MaxTermLength = 64
+
+// Token a term and its position in the text
+type Token struct {
+	Term     string
+	Position int
+}
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "but": true,
+	"by": true, "for": true, "if": true, "in": true, "into": true, "is": true, "it": true, "no": true,
+	"not": true, "of": true, "on": true, "or": true, "such": true, "that": true, "the": true,
+	"their": true, "then": true, "there": true, "these": true, "they": true, "this": true, "to": true,
+	"was": true, "will": true, "with": true,
+}
+
+// Tokenize splits the text into lower case terms. The english words are stemmed and the stop words are
+// skipped, while their positions are kept so that the phrases still match. The runs of CJK characters
+// are split into overlapping bigrams because there are no spaces between the words.
+func Tokenize(text string) (tokens []Token) {
+	tokens = make([]Token, 0)
+	position := 0
+	word := strings.Builder{}
+	cjk := make([]rune, 0)
+
+	flushWord := func() {
+		if word.Len() == 0 {
+			return
+		}
+		term := normalizeWord(word.String())
+		word.Reset()
+		if len(term) > 0 && len(term) <= MaxTermLength {
+			tokens = append(tokens, Token{Term: term, Position: position})
+		}
+		position++
+	}
+	flushCJK := func() {
+		if len(cjk) == 1 {
+			tokens = append(tokens, Token{Term: string(cjk), Position: position})
+			position++
+		}
+		for i := 0; i+1 < len(cjk); i++ {
+			tokens = append(tokens, Token{Term: string(cjk[i : i+2]), Position: position})
+			position++
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return tokens
+}
+
+// Terms returns the distinct terms of the text
+func Terms(text string) (terms []string) {
+	seen := make(map[string]bool)
+	for _, token := range Tokenize(text) {
+		if !seen[token.Term] {
+			seen[token.Term] = true
+			terms = append(terms, token.Term)
+		}
+	}
+	return terms
+}
+
+// normalizeWord returns empty if the word is a stop word
+func normalizeWord(word string) string {
+	if stopWords[word] {
+		return ""
+	}
+	if isASCIILetters(word) {
+		return Stem(word)
+	}
+	return word
+}
+
+func isASCIILetters(word string) bool {
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return false
+		}
+	}
+	return len(word) > 0
+}
+
+func isCJK(r rune) bool {
+	// the prolonged sound mark is shared by hiragana and katakana
+	return r == '\u30fc' || unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package jwt signs and verifies the RS256 json web tokens, it only covers what the oauth provider needs.
+package jwt
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+const algorithm = "RS256"
+
+var (
+	ErrInvalidKey       = errors.New("jwt: invalid key")
+	ErrInvalidToken     = errors.New("jwt: invalid token")
+	ErrInvalidSignature = errors.New("jwt: invalid signature")
+)
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// JWK the public key in the format of the json web key
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// ParsePrivateKey parse the RSA private key in PEM, both PKCS#1 and PKCS#8 are supported
+func ParsePrivateKey(content string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(content))
+	if block == nil {
+		return nil, ErrInvalidKey
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	return rsaKey, nil
+}
+
+// KeyID the id of the public key, it changes when the key changes
+func KeyID(pub *rsa.PublicKey) string {
+	sum := sha256.Sum256(x509.MarshalPKCS1PublicKey(pub))
+	return base64.RawURLEncoding.EncodeToString(sum[:])[:16]
+}
+
+// NewJWK the json web key of the public key
+func NewJWK(pub *rsa.PublicKey) *JWK {
+	return &JWK{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: algorithm,
+		Kid: KeyID(pub),
+		N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}
+}
+
+// Sign sign the claims with the private key
+func Sign(key *rsa.PrivateKey, claims any) (string, error) {
+	headerContent, err := json.Marshal(&header{Alg: algorithm, Typ: "JWT", Kid: KeyID(&key.PublicKey)})
+	if err != nil {
+		return "", err
+	}
+	claimsContent, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(headerContent) + "." +
+		base64.RawURLEncoding.EncodeToString(claimsContent)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Verify verify the signature of the token and unmarshal the claims, the claims themselves are not checked
+func Verify(token string, pub *rsa.PublicKey, claims any) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrInvalidToken
+	}
+	headerContent, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return ErrInvalidToken
+	}
+	h := &header{}
+	if err = json.Unmarshal(headerContent, h); err != nil || h.Alg != algorithm {
+		return ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return ErrInvalidToken
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err = rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature); err != nil {
+		return ErrInvalidSignature
+	}
+	claimsContent, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ErrInvalidToken
+	}
+	if err = json.Unmarshal(claimsContent, claims); err != nil {
+		return ErrInvalidToken
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package jwt_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/apache/answer/pkg/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	content := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	parsed, err := jwt.ParsePrivateKey(content)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	token, err := jwt.Sign(parsed, map[string]any{"sub": "1", "aud": "client"})
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(token, "."), 3)
+
+	claims := map[string]any{}
+	require.NoError(t, jwt.Verify(token, &key.PublicKey, &claims))
+	assert.Equal(t, "1", claims["sub"])
+	assert.Equal(t, "client", claims["aud"])
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	assert.ErrorIs(t, jwt.Verify(token, &other.PublicKey, &claims), jwt.ErrInvalidSignature)
+	assert.ErrorIs(t, jwt.Verify("abc", &key.PublicKey, &claims), jwt.ErrInvalidToken)
+
+	_, err = jwt.ParsePrivateKey("not a key")
+	assert.ErrorIs(t, err, jwt.ErrInvalidKey)
+}
+
+func TestNewJWK(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwk := jwt.NewJWK(&key.PublicKey)
+	assert.Equal(t, "RSA", jwk.Kty)
+	assert.Equal(t, "RS256", jwk.Alg)
+	assert.Equal(t, "AQAB", jwk.E)
+	assert.Equal(t, jwt.KeyID(&key.PublicKey), jwk.Kid)
+	assert.NotEmpty(t, jwk.N)
+}
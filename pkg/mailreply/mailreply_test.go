@@ -0,0 +1,195 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mailreply_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/answer/pkg/mailreply"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToken(t *testing.T) {
+	token, err := mailreply.NewToken("secret", "1", "10020000000000001", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, strings.ToLower(token), token)
+
+	userID, objectID, err := mailreply.ParseToken("secret", token, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "1", userID)
+	assert.Equal(t, "10020000000000001", objectID)
+
+	// some mail servers change the case of the address
+	_, _, err = mailreply.ParseToken("secret", strings.ToUpper(token), time.Hour)
+	assert.NoError(t, err)
+
+	_, _, err = mailreply.ParseToken("other", token, time.Hour)
+	assert.ErrorIs(t, err, mailreply.ErrInvalidToken)
+
+	parts := strings.Split(token, "-")
+	_, _, err = mailreply.ParseToken("secret", "2-"+strings.Join(parts[1:], "-"), time.Hour)
+	assert.ErrorIs(t, err, mailreply.ErrInvalidToken)
+
+	// the issued day is signed too, it can not be moved forward
+	_, _, err = mailreply.ParseToken("secret", parts[0]+"-"+parts[1]+"-zzzz-"+parts[3], time.Hour)
+	assert.ErrorIs(t, err, mailreply.ErrInvalidToken)
+
+	_, _, err = mailreply.ParseToken("secret", "abc", time.Hour)
+	assert.ErrorIs(t, err, mailreply.ErrInvalidToken)
+
+	_, err = mailreply.NewToken("secret", "1", "not-a-number", time.Now())
+	assert.Error(t, err)
+
+	oldToken, err := mailreply.NewToken("secret", "1", "10020000000000001", time.Now().AddDate(0, 0, -31))
+	require.NoError(t, err)
+	_, _, err = mailreply.ParseToken("secret", oldToken, 30*24*time.Hour)
+	assert.ErrorIs(t, err, mailreply.ErrExpiredToken)
+	_, _, err = mailreply.ParseToken("secret", oldToken, 60*24*time.Hour)
+	assert.NoError(t, err)
+}
+
+func TestAddress(t *testing.T) {
+	address := mailreply.Address("reply@example.com", "abc-def")
+	assert.Equal(t, "reply+abc-def@example.com", address)
+	assert.Empty(t, mailreply.Address("reply", "abc"))
+
+	token, ok := mailreply.TokenFromAddress("reply@example.com", "Reply+ABC-def@Example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "abc-def", token)
+
+	_, ok = mailreply.TokenFromAddress("reply@example.com", "reply@example.com")
+	assert.False(t, ok)
+	_, ok = mailreply.TokenFromAddress("reply@example.com", "reply+abc@other.com")
+	assert.False(t, ok)
+	_, ok = mailreply.TokenFromAddress("reply@example.com", "other+abc@example.com")
+	assert.False(t, ok)
+}
+
+func TestParseMessage(t *testing.T) {
+	t.Run("plain text", func(t *testing.T) {
+		raw := "From: Alice <Alice@example.com>\r\n" +
+			"To: reply+abc@example.com\r\n" +
+			"Subject: =?UTF-8?Q?Re:_caf=C3=A9?=\r\n" +
+			"Content-Type: text/plain; charset=utf-8\r\n" +
+			"\r\n" +
+			"Thanks!\r\n"
+		msg, err := mailreply.ParseMessage(strings.NewReader(raw))
+		require.NoError(t, err)
+		assert.Equal(t, "Alice@example.com", msg.From)
+		assert.Equal(t, []string{"reply+abc@example.com"}, msg.Recipients)
+		assert.Equal(t, "Re: café", msg.Subject)
+		assert.Equal(t, "Thanks!\r\n", msg.Text)
+	})
+
+	t.Run("multipart", func(t *testing.T) {
+		raw := "From: alice@example.com\r\n" +
+			"To: someone@example.com\r\n" +
+			"Delivered-To: reply+abc@example.com\r\n" +
+			"Content-Type: multipart/alternative; boundary=\"b1\"\r\n" +
+			"\r\n" +
+			"--b1\r\n" +
+			"Content-Type: text/plain; charset=utf-8\r\n" +
+			"Content-Transfer-Encoding: quoted-printable\r\n" +
+			"\r\n" +
+			"caf=C3=A9 is =\r\nopen\r\n" +
+			"--b1\r\n" +
+			"Content-Type: text/html; charset=utf-8\r\n" +
+			"\r\n" +
+			"<p>café is open</p>\r\n" +
+			"--b1--\r\n"
+		msg, err := mailreply.ParseMessage(strings.NewReader(raw))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"someone@example.com", "reply+abc@example.com"}, msg.Recipients)
+		assert.Equal(t, "café is open", msg.Text)
+	})
+
+	t.Run("html only", func(t *testing.T) {
+		raw := "From: alice@example.com\r\n" +
+			"To: reply+abc@example.com\r\n" +
+			"Content-Type: multipart/mixed; boundary=\"b1\"\r\n" +
+			"\r\n" +
+			"--b1\r\n" +
+			"Content-Type: text/html; charset=utf-8\r\n" +
+			"Content-Transfer-Encoding: base64\r\n" +
+			"\r\n" +
+			"PGRpdj5GaXJzdCAmYW1wOyBzZWNvbmQ8L2Rpdj48YmxvY2txdW90ZT5xdW90ZWQ8L2Jsb2Nr\r\n" +
+			"cXVvdGU+\r\n" +
+			"--b1\r\n" +
+			"Content-Type: image/png\r\n" +
+			"\r\n" +
+			"binary\r\n" +
+			"--b1--\r\n"
+		msg, err := mailreply.ParseMessage(strings.NewReader(raw))
+		require.NoError(t, err)
+		assert.Equal(t, "First & second\n", msg.Text)
+	})
+}
+
+func TestExtractReply(t *testing.T) {
+	cases := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "gmail",
+			text: "Sounds good.\n\nOn Mon, Jan 1, 2024 at 10:00 AM Answer <reply@example.com> wrote:\n> the question\n",
+			want: "Sounds good.",
+		},
+		{
+			name: "wrapped header",
+			text: "Sounds good.\n\nOn Mon, Jan 1, 2024 at 10:00 AM Answer\n<reply@example.com> wrote:\n> the question\n",
+			want: "Sounds good.",
+		},
+		{
+			name: "outlook",
+			text: "Sounds good.\r\n\r\n-----Original Message-----\r\nFrom: Answer\r\n",
+			want: "Sounds good.",
+		},
+		{
+			name: "signature",
+			text: "Sounds good.\n\nkeep this\n-- \nAlice\n",
+			want: "Sounds good.\n\nkeep this",
+		},
+		{
+			name: "mobile",
+			text: "Sounds good.\n\nSent from my phone\n",
+			want: "Sounds good.",
+		},
+		{
+			name: "inline quotes",
+			text: "> first\nyes\n> second\nno\n",
+			want: "yes\nno",
+		},
+		{
+			name: "on in the reply",
+			text: "On second thought\nit works.\n",
+			want: "On second thought\nit works.",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, mailreply.ExtractReply(c.text))
+		})
+	}
+}
@@ -0,0 +1,212 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mailreply
+
+import (
+	"bufio"
+	"encoding/base64"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	strip "github.com/grokify/html-strip-tags-go"
+)
+
+// maxBodySize the max size of a text part read from the message
+const maxBodySize = 1 << 20
+
+// Message the parts of an inbound email used to post the reply
+type Message struct {
+	// From the address of the sender
+	From string
+	// Recipients the addresses in To, Cc and Delivered-To
+	Recipients []string
+	Subject    string
+	// Text the plain text body, it is converted from the html body if there is no plain text one
+	Text string
+}
+
+var (
+	// replyHeaderRegexps the lines above the quoted message added by the mail clients
+	replyHeaderRegexps = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^on\s.+\swrote:$`),
+		regexp.MustCompile(`(?i)^-+\s*original message\s*-+$`),
+		regexp.MustCompile(`^_{10,}$`),
+		regexp.MustCompile(`(?i)^from:\s.+$`),
+	}
+	// signatureRegexps the first line of the signatures
+	signatureRegexps = []*regexp.Regexp{
+		regexp.MustCompile(`^--\s?$`),
+		regexp.MustCompile(`(?i)^sent from my\s`),
+		regexp.MustCompile(`(?i)^get outlook for\s`),
+	}
+	htmlBlockquoteRegexp = regexp.MustCompile(`(?is)<blockquote.*?</blockquote>`)
+	htmlLineBreakRegexp  = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>|</li>`)
+	blankLinesRegexp     = regexp.MustCompile(`\n{3,}`)
+)
+
+// ParseMessage parses the raw email
+func ParseMessage(r io.Reader) (msg *Message, err error) {
+	m, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+	msg = &Message{}
+	if from, err := mail.ParseAddress(m.Header.Get("From")); err == nil {
+		msg.From = from.Address
+	}
+	for _, key := range []string{"To", "Cc", "Delivered-To", "X-Original-To"} {
+		addresses, err := m.Header.AddressList(key)
+		if err != nil {
+			continue
+		}
+		for _, address := range addresses {
+			msg.Recipients = append(msg.Recipients, address.Address)
+		}
+	}
+	decoder := new(mime.WordDecoder)
+	if subject, err := decoder.DecodeHeader(m.Header.Get("Subject")); err == nil {
+		msg.Subject = subject
+	}
+
+	plain, htmlText, err := readBody(m.Header.Get("Content-Type"), m.Header.Get("Content-Transfer-Encoding"), m.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(plain)) > 0 {
+		msg.Text = plain
+	} else {
+		msg.Text = htmlToText(htmlText)
+	}
+	return msg, nil
+}
+
+// readBody reads the first plain text and html parts of the body
+func readBody(contentType, encoding string, body io.Reader) (plain, htmlText string, err error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return plain, htmlText, err
+			}
+			p, h, err := readBody(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return plain, htmlText, err
+			}
+			if len(plain) == 0 {
+				plain = p
+			}
+			if len(htmlText) == 0 {
+				htmlText = h
+			}
+		}
+		return plain, htmlText, nil
+	}
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return "", "", nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, &newlineSkipper{r: bufio.NewReader(body)})
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	content, err := io.ReadAll(io.LimitReader(body, maxBodySize))
+	if err != nil {
+		return "", "", err
+	}
+	if mediaType == "text/html" {
+		return "", string(content), nil
+	}
+	return string(content), "", nil
+}
+
+// newlineSkipper removes the line breaks in the base64 content
+type newlineSkipper struct {
+	r *bufio.Reader
+}
+
+func (n *newlineSkipper) Read(p []byte) (int, error) {
+	i := 0
+	for i < len(p) {
+		b, err := n.r.ReadByte()
+		if err != nil {
+			return i, err
+		}
+		if b == '\r' || b == '\n' {
+			continue
+		}
+		p[i] = b
+		i++
+	}
+	return i, nil
+}
+
+func htmlToText(content string) string {
+	content = htmlBlockquoteRegexp.ReplaceAllString(content, "")
+	content = htmlLineBreakRegexp.ReplaceAllString(content, "\n")
+	return html.UnescapeString(strip.StripTags(content))
+}
+
+// ExtractReply removes the quoted message and the signature from the text of the reply
+func ExtractReply(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	reply := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRightFunc(lines[i], func(r rune) bool { return r == ' ' || r == '\t' })
+		trimmed := strings.TrimSpace(line)
+		if matchAny(signatureRegexps, line) || matchAny(replyHeaderRegexps, trimmed) {
+			break
+		}
+		// the header of the quoted message can be wrapped, like "On Mon, Jan 1, 2024 at 10:00 AM Someone\n<a@b.c> wrote:"
+		if i+1 < len(lines) && strings.HasPrefix(strings.ToLower(trimmed), "on ") &&
+			matchAny(replyHeaderRegexps, trimmed+" "+strings.TrimSpace(lines[i+1])) {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		reply = append(reply, line)
+	}
+	return strings.TrimSpace(blankLinesRegexp.ReplaceAllString(strings.Join(reply, "\n"), "\n\n"))
+}
+
+func matchAny(regexps []*regexp.Regexp, line string) bool {
+	for _, re := range regexps {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
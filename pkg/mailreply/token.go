@@ -0,0 +1,122 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package mailreply
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// signatureLength the bytes of the HMAC kept in the token, the token has to fit in the local part of an address
+	signatureLength = 10
+	secondsPerDay   = 24 * 60 * 60
+)
+
+var (
+	// ErrInvalidToken the token is malformed or the signature does not match
+	ErrInvalidToken = errors.New("invalid reply token")
+	// ErrExpiredToken the token is issued too long ago
+	ErrExpiredToken = errors.New("expired reply token")
+
+	signatureEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+)
+
+// NewToken signs the user who replies, the object the reply goes to and the day the token is issued. The ids are
+// numeric and written in base 36 like the day, everything is in lower case as some mail servers do not keep the case
+// of the address.
+func NewToken(secret, userID, objectID string, issuedAt time.Time) (token string, err error) {
+	user, err := strconv.ParseUint(userID, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	object, err := strconv.ParseUint(objectID, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	payload := strconv.FormatUint(user, 36) + "-" + strconv.FormatUint(object, 36) + "-" +
+		strconv.FormatInt(issuedAt.Unix()/secondsPerDay, 36)
+	return payload + "-" + sign(secret, payload), nil
+}
+
+// ParseToken verifies the token and returns the user and the object in it, the token issued more than maxAge ago
+// is expired
+func ParseToken(secret, token string, maxAge time.Duration) (userID, objectID string, err error) {
+	parts := strings.Split(strings.ToLower(token), "-")
+	if len(parts) != 4 {
+		return "", "", ErrInvalidToken
+	}
+	payload := strings.Join(parts[:3], "-")
+	if !hmac.Equal([]byte(sign(secret, payload)), []byte(parts[3])) {
+		return "", "", ErrInvalidToken
+	}
+	user, err := strconv.ParseUint(parts[0], 36, 64)
+	if err != nil {
+		return "", "", ErrInvalidToken
+	}
+	object, err := strconv.ParseUint(parts[1], 36, 64)
+	if err != nil {
+		return "", "", ErrInvalidToken
+	}
+	issuedDay, err := strconv.ParseInt(parts[2], 36, 64)
+	if err != nil {
+		return "", "", ErrInvalidToken
+	}
+	// the day is rounded down, so the token lives at most one day longer than maxAge
+	if time.Since(time.Unix(issuedDay*secondsPerDay, 0)) > maxAge+24*time.Hour {
+		return "", "", ErrExpiredToken
+	}
+	return strconv.FormatUint(user, 10), strconv.FormatUint(object, 10), nil
+}
+
+func sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return signatureEncoding.EncodeToString(mac.Sum(nil)[:signatureLength])
+}
+
+// Address adds the token to the local part of the address, e.g. reply@example.com becomes
+// reply+token@example.com
+func Address(address, token string) string {
+	local, domain, ok := strings.Cut(address, "@")
+	if !ok {
+		return ""
+	}
+	return local + "+" + token + "@" + domain
+}
+
+// TokenFromAddress gets the token from the recipient if it is a plus address of the address
+func TokenFromAddress(address, recipient string) (token string, ok bool) {
+	local, domain, ok := strings.Cut(strings.ToLower(address), "@")
+	if !ok {
+		return "", false
+	}
+	recipientLocal, recipientDomain, ok := strings.Cut(strings.ToLower(recipient), "@")
+	if !ok || recipientDomain != domain {
+		return "", false
+	}
+	token, ok = strings.CutPrefix(recipientLocal, local+"+")
+	return token, ok && len(token) > 0
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/apache/answer/internal/base/constant"
+)
+
+// EventType is the type of the site event, such as answer.create
+type EventType = constant.EventType
+
+const (
+	EventUserUpdate = constant.EventUserUpdate
+	EventUserShare  = constant.EventUserShare
+
+	EventQuestionCreate = constant.EventQuestionCreate
+	EventQuestionUpdate = constant.EventQuestionUpdate
+	EventQuestionDelete = constant.EventQuestionDelete
+	EventQuestionVote   = constant.EventQuestionVote
+	EventQuestionAccept = constant.EventQuestionAccept
+	EventQuestionFlag   = constant.EventQuestionFlag
+	EventQuestionReact  = constant.EventQuestionReact
+
+	EventAnswerCreate = constant.EventAnswerCreate
+	EventAnswerUpdate = constant.EventAnswerUpdate
+	EventAnswerDelete = constant.EventAnswerDelete
+	EventAnswerVote   = constant.EventAnswerVote
+	EventAnswerFlag   = constant.EventAnswerFlag
+	EventAnswerReact  = constant.EventAnswerReact
+
+	EventCommentCreate = constant.EventCommentCreate
+	EventCommentUpdate = constant.EventCommentUpdate
+	EventCommentDelete = constant.EventCommentDelete
+	EventCommentVote   = constant.EventCommentVote
+	EventCommentFlag   = constant.EventCommentFlag
+)
+
+type EventSubscriber interface {
+	Base
+
+	// SubscribedEvents returns the event types the plugin subscribes to, empty means all
+	SubscribedEvents() []EventType
+
+	// HandleEvent handles the event. Events returning an error are retried later with backoff,
+	// the events of the same object are delivered in the order they happened.
+	HandleEvent(ctx context.Context, event *EventMessage) error
+}
+
+// EventMessage is the site event sent to the subscribers
+type EventMessage struct {
+	// the type of the event
+	EventType EventType `json:"event_type"`
+	// who triggered the event
+	UserID string `json:"user_id"`
+	// the object the event happened on, it is the comment, answer or question id
+	ObjectID string `json:"object_id"`
+
+	QuestionID     string `json:"question_id"`
+	QuestionUserID string `json:"question_user_id"`
+	AnswerID       string `json:"answer_id"`
+	AnswerUserID   string `json:"answer_user_id"`
+	CommentID      string `json:"comment_id"`
+	CommentUserID  string `json:"comment_user_id"`
+
+	ExtraInfo map[string]string `json:"extra_info"`
+}
+
+var (
+	// CallEventSubscriber is a function that calls all registered event subscriber plugins, including the disabled ones
+	CallEventSubscriber,
+	registerEventSubscriber = MakePlugin[EventSubscriber](true)
+)
@@ -126,6 +126,10 @@ func Register(p Base) {
 	if _, ok := p.(KVStorage); ok {
 		registerKVStorage(p.(KVStorage))
 	}
+
+	if _, ok := p.(EventSubscriber); ok {
+		registerEventSubscriber(p.(EventSubscriber))
+	}
 }
 
 type Stack[T Base] struct {
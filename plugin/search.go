@@ -45,6 +45,23 @@ type SearchContent struct {
 	Active      int64               `json:"active"`
 	Score       int64               `json:"score"`
 	HasAccepted bool                `json:"hasAccepted"`
+	// Comments the text of the available comments on the content
+	Comments []string `json:"comments"`
+	// CommentUserIDs the users commented on the content
+	CommentUserIDs []string `json:"commentUserIDs"`
+	// AnswerUserIDs the users answered the question. Only for question.
+	AnswerUserIDs []string `json:"answerUserIDs"`
+}
+
+// SearchChange is a question or answer changed after the cursor
+type SearchChange struct {
+	// Cursor the position of the change, the changes after it are returned by the next call
+	Cursor   int64  `json:"cursor"`
+	ObjectID string `json:"objectID"`
+	// Deleted the content is deleted, hidden or not exist anymore, it should be removed from the index
+	Deleted bool `json:"deleted"`
+	// Content the latest content, it is nil if the content is not exist anymore
+	Content *SearchContent `json:"content"`
 }
 
 type SearchBasicCond struct {
@@ -55,8 +72,17 @@ type SearchBasicCond struct {
 
 	// The keywords for search.
 	Words []string
-	// TagIDs is a list of tag IDs.
+	// MatchAnyWord the keywords are joined by OR in the query, the object only needs to match one of them.
+	// The built-in search always matches any of the keywords.
+	MatchAnyWord bool
+	// TitleOnly the keywords only match the title.
+	TitleOnly bool
+	// TagIDs is a list of tag IDs, the object must have one of the tags in each group.
 	TagIDs [][]string
+	// ExcludedTagIDs the object must not have any of the tags.
+	ExcludedTagIDs []string
+	// HierarchicalTagIDs is a list of hierarchical tag IDs, the question must be filed under one of them.
+	HierarchicalTagIDs []string
 	// The object's owner user ID.
 	UserID string
 	// The order of the search result.
@@ -76,6 +102,17 @@ type SearchBasicCond struct {
 	ViewAmount int
 	// greater than or equal to the number of answers. Only support search question.
 	AnswerAmount int
+
+	// The object is created at or after the time in unix seconds, zero means no limit.
+	CreatedFrom int64
+	// The object is created before the time in unix seconds, zero means no limit.
+	CreatedTo int64
+	// Only the closed questions. Only support search question.
+	Closed bool
+	// The user commented on the object.
+	CommentUserID string
+	// The user answered the question. Only support search question.
+	AnswerUserID string
 }
 
 type SearchAcceptedCond int
@@ -90,6 +127,7 @@ const (
 
 const (
 	SearchContentStatusAvailable = 1
+	SearchContentStatusClosed    = 2
 	SearchContentStatusDeleted   = 10
 )
 
@@ -121,6 +159,10 @@ type SearchDesc struct {
 type SearchSyncer interface {
 	GetAnswersPage(ctx context.Context, page, pageSize int) (answerList []*SearchContent, err error)
 	GetQuestionsPage(ctx context.Context, page, pageSize int) (questionList []*SearchContent, err error)
+	// GetChangesSince returns the questions and answers changed after the cursor, including the changes of
+	// their comments and tags. Each content is returned once with its latest state. Pass the nextCursor to
+	// the next call, no more changes if it is equal to the cursor.
+	GetChangesSince(ctx context.Context, cursor int64, limit int) (changes []*SearchChange, nextCursor int64, err error)
 }
 
 var (
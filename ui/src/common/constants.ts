@@ -102,6 +102,11 @@ export const ADMIN_NAV_MENUS = [
     name: 'badges',
     icon: 'award-fill',
   },
+  {
+    name: 'queue_messages',
+    path: 'queue-messages',
+    icon: 'inboxes-fill',
+  },
   {
     name: 'apperance',
     icon: 'palette-fill',
@@ -127,6 +132,7 @@ export const ADMIN_NAV_MENUS = [
       { name: 'seo' },
       { name: 'login' },
       { name: 'privileges' },
+      { name: 'rate_limit', path: 'rate-limit' },
     ],
   },
   {
@@ -428,6 +428,8 @@ export interface AdminSettingsSmtp {
   smtp_port: number;
   smtp_username?: string;
   test_email_recipient?: string;
+  reply_to_address?: string;
+  reply_inbound_secret?: string;
 }
 
 export interface AdminSettingsUsers {
@@ -519,6 +521,51 @@ export interface AdminSettingsLogin {
   allow_password_login: boolean;
 }
 
+export type QueueMessageStatus = 'pending' | 'processing' | 'dead';
+
+export interface QueueMessageItem {
+  id: number;
+  queue: string;
+  payload: string;
+  status: QueueMessageStatus;
+  attempts: number;
+  next_run_at: number;
+  last_error: string;
+  created_at: number;
+}
+
+export interface DraftItem {
+  id: string;
+  type: 'question' | 'answer' | 'edit';
+  object_id: string;
+  title: string;
+  content: string;
+  tags: string[];
+  question_id: string;
+  question_title: string;
+  base_revision_id: string;
+  current_revision_id: string;
+  conflict: boolean;
+  object_removed: boolean;
+  updated_at: number;
+  expired_at: number;
+}
+
+export interface AdminSettingsRateLimitRule {
+  group: string;
+  window: number;
+  anonymous_limit: number;
+  new_user_limit: number;
+  user_limit: number;
+  per_ip: boolean;
+}
+
+export interface AdminSettingsRateLimit {
+  enabled: boolean;
+  new_user_rank: number;
+  rules: AdminSettingsRateLimitRule[];
+}
+
 /**
  * @description interface for Activity
  */
@@ -723,6 +770,28 @@ export interface OauthBindEmailReq {
   must: boolean;
 }
 
+export interface OAuthAuthorizeReq {
+  client_id: string;
+  redirect_uri?: string;
+  response_type?: string;
+  scope?: string;
+  state?: string;
+  code_challenge?: string;
+  code_challenge_method?: string;
+  nonce?: string;
+}
+
+export interface OAuthAuthorizeInfo {
+  client_name: string;
+  scopes: string[];
+  consented: boolean;
+  redirect_url?: string;
+}
+
+export interface OAuthConsentReq extends OAuthAuthorizeReq {
+  approve: boolean;
+}
+
 export interface UserOauthConnectorItem {
   icon: string;
   name: string;
@@ -735,10 +804,18 @@ export interface NotificationConfigItem {
   enable: boolean;
   key: string;
 }
+export interface DigestSchedule {
+  frequency: 'daily' | 'weekly';
+  weekday: number;
+  hour: number;
+  timezone: string;
+}
 export interface NotificationConfig {
   all_new_question: NotificationConfigItem;
   all_new_question_for_following_tags: NotificationConfigItem;
   inbox: NotificationConfigItem;
+  digest: NotificationConfigItem;
+  digest_schedule?: DigestSchedule;
 }
 
 export interface ActivatedPlugin {
@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import { FC, useState } from 'react';
+import { Button, Form, Table, Stack } from 'react-bootstrap';
+import { useSearchParams } from 'react-router-dom';
+import { useTranslation } from 'react-i18next';
+
+import classNames from 'classnames';
+
+import { Empty, FormatTime, Pagination, QueryGroup } from '@/components';
+import * as Type from '@/common/interface';
+import {
+  useQueryQueueMessages,
+  replayQueueMessages,
+} from '@/services/admin/queue_messages';
+import { useToast } from '@/hooks';
+
+const StatusFilterKeys = ['all', 'pending', 'processing', 'dead'];
+
+const bgMap = {
+  pending: 'text-bg-warning',
+  processing: 'text-bg-info',
+  dead: 'text-bg-danger',
+};
+
+const PAGE_SIZE = 20;
+
+const QueueMessages: FC = () => {
+  const { t } = useTranslation('translation', {
+    keyPrefix: 'admin.queue_messages',
+  });
+
+  const [urlSearchParams, setUrlSearchParams] = useSearchParams();
+  const curPage = Number(urlSearchParams.get('page') || '1');
+  const curFilter = urlSearchParams.get('filter') || StatusFilterKeys[0];
+  const curQueue = urlSearchParams.get('queue') || '';
+  const [selected, setSelected] = useState<number[]>([]);
+  const Toast = useToast();
+
+  const { data, isLoading, mutate } = useQueryQueueMessages({
+    page: curPage,
+    page_size: PAGE_SIZE,
+    ...(curQueue ? { queue: curQueue } : {}),
+    ...(curFilter === 'all' ? {} : { status: curFilter }),
+  });
+
+  const handleQueue = (e) => {
+    urlSearchParams.set('queue', e.target.value);
+    urlSearchParams.delete('page');
+    setUrlSearchParams(urlSearchParams);
+  };
+
+  const handleSelect = (id: number, checked: boolean) => {
+    setSelected(checked ? [...selected, id] : selected.filter((_) => _ !== id));
+  };
+
+  const handleReplay = (ids: number[]) => {
+    replayQueueMessages(ids).then((resp) => {
+      Toast.onShow({
+        msg: t('replayed', { count: resp.replayed }),
+        variant: 'success',
+      });
+      setSelected([]);
+      mutate();
+    });
+  };
+
+  const canReplay = (message: Type.QueueMessageItem) => {
+    return message.status !== 'processing';
+  };
+
+  return (
+    <>
+      <h3 className="mb-4">{t('title')}</h3>
+      <div className="d-flex flex-wrap justify-content-between align-items-center">
+        <Stack direction="horizontal" gap={3} className="mb-3">
+          <QueryGroup
+            data={StatusFilterKeys}
+            currentSort={curFilter}
+            sortKey="filter"
+            i18nKeyPrefix="admin.queue_messages"
+          />
+          <Button
+            variant="outline-secondary"
+            size="sm"
+            disabled={selected.length === 0}
+            onClick={() => handleReplay(selected)}>
+            {t('replay_selected')}
+          </Button>
+        </Stack>
+
+        <Form.Control
+          size="sm"
+          type="search"
+          value={curQueue}
+          onChange={handleQueue}
+          placeholder={t('filter.placeholder')}
+          style={{ width: '12.25rem' }}
+          className="mb-3"
+        />
+      </div>
+      <Table responsive="md">
+        <thead>
+          <tr>
+            <th style={{ width: '3%' }} />
+            <th style={{ width: '8%' }}>{t('message_id')}</th>
+            <th style={{ width: '15%' }}>{t('queue')}</th>
+            <th className="min-w-15">{t('payload')}</th>
+            <th style={{ width: '10%' }}>{t('status')}</th>
+            <th style={{ width: '8%' }}>{t('attempts')}</th>
+            <th style={{ width: '12%' }}>{t('next_run')}</th>
+            <th className="text-end" style={{ width: '9%' }}>
+              {t('action')}
+            </th>
+          </tr>
+        </thead>
+        <tbody className="align-middle">
+          {data?.list?.map((message) => (
+            <tr key={message.id}>
+              <td>
+                <Form.Check
+                  type="checkbox"
+                  disabled={!canReplay(message)}
+                  checked={selected.includes(message.id)}
+                  onChange={(e) => handleSelect(message.id, e.target.checked)}
+                />
+              </td>
+              <td>{message.id}</td>
+              <td className="text-break">{message.queue}</td>
+              <td>
+                <code className="d-block text-break small">
+                  {message.payload}
+                </code>
+                {message.last_error && (
+                  <div className="text-danger small text-break">
+                    {t('last_error')}: {message.last_error}
+                  </div>
+                )}
+              </td>
+              <td>
+                <span className={classNames('badge', bgMap[message.status])}>
+                  {t(message.status)}
+                </span>
+              </td>
+              <td>{message.attempts}</td>
+              <td>
+                <FormatTime time={message.next_run_at} className="small" />
+              </td>
+              <td className="text-end">
+                {canReplay(message) && (
+                  <Button
+                    variant="link"
+                    size="sm"
+                    className="p-0"
+                    onClick={() => handleReplay([message.id])}>
+                    {t('replay')}
+                  </Button>
+                )}
+              </td>
+            </tr>
+          ))}
+        </tbody>
+      </Table>
+      {Number(data?.count) <= 0 && !isLoading && <Empty />}
+      <div className="mt-4 mb-2 d-flex justify-content-center">
+        <Pagination
+          currentPage={curPage}
+          totalSize={data?.count || 0}
+          pageSize={PAGE_SIZE}
+        />
+      </div>
+    </>
+  );
+};
+
+export default QueueMessages;
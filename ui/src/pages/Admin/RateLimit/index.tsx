@@ -0,0 +1,172 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import { FC, FormEvent, useEffect, useState } from 'react';
+import { Button, Form, Table } from 'react-bootstrap';
+import { useTranslation } from 'react-i18next';
+
+import type * as Type from '@/common/interface';
+import { getRateLimitSetting, putRateLimitSetting } from '@/services';
+import { useToast } from '@/hooks';
+
+type RuleNumberField =
+  | 'window'
+  | 'anonymous_limit'
+  | 'new_user_limit'
+  | 'user_limit';
+
+const numberFields: RuleNumberField[] = [
+  'window',
+  'anonymous_limit',
+  'new_user_limit',
+  'user_limit',
+];
+
+const Index: FC = () => {
+  const { t } = useTranslation('translation', {
+    keyPrefix: 'admin.rate_limit',
+  });
+  const Toast = useToast();
+  const [setting, setSetting] = useState<Type.AdminSettingsRateLimit>();
+
+  useEffect(() => {
+    getRateLimitSetting().then((resp) => {
+      setSetting(resp);
+    });
+  }, []);
+
+  const updateRule = (
+    index: number,
+    rule: Partial<Type.AdminSettingsRateLimitRule>,
+  ) => {
+    if (!setting) {
+      return;
+    }
+    const rules = setting.rules.map((li, i) => {
+      return i === index ? { ...li, ...rule } : li;
+    });
+    setSetting({ ...setting, rules });
+  };
+
+  const onSubmit = (evt: FormEvent) => {
+    evt.preventDefault();
+    evt.stopPropagation();
+    if (!setting) {
+      return;
+    }
+    putRateLimitSetting(setting).then(() => {
+      Toast.onShow({
+        msg: t('update', { keyPrefix: 'toast' }),
+        variant: 'success',
+      });
+    });
+  };
+
+  if (!setting) {
+    return null;
+  }
+
+  return (
+    <>
+      <h3 className="mb-4">{t('title')}</h3>
+      <Form noValidate onSubmit={onSubmit}>
+        <Form.Group controlId="enabled" className="mb-3">
+          <Form.Check
+            type="switch"
+            label={t('enabled.label')}
+            checked={setting.enabled}
+            onChange={(evt) => {
+              setSetting({ ...setting, enabled: evt.target.checked });
+            }}
+          />
+        </Form.Group>
+        <Form.Group controlId="new_user_rank" className="mb-3">
+          <Form.Label>{t('new_user_rank.label')}</Form.Label>
+          <Form.Control
+            type="number"
+            min={0}
+            value={setting.new_user_rank}
+            onChange={(evt) => {
+              setSetting({
+                ...setting,
+                new_user_rank: Number(evt.target.value),
+              });
+            }}
+          />
+          <Form.Text>{t('new_user_rank.text')}</Form.Text>
+        </Form.Group>
+        <Form.Group className="mb-3">
+          <Form.Label>{t('rules.label')}</Form.Label>
+          <Table responsive="md">
+            <thead>
+              <tr>
+                <th>{t('group')}</th>
+                {numberFields.map((field) => {
+                  return <th key={field}>{t(field)}</th>;
+                })}
+                <th>{t('per_ip')}</th>
+              </tr>
+            </thead>
+            <tbody className="align-middle">
+              {setting.rules.map((rule, index) => {
+                return (
+                  <tr key={rule.group}>
+                    <td>{t(`groups.${rule.group}`)}</td>
+                    {numberFields.map((field) => {
+                      return (
+                        <td key={field}>
+                          <Form.Control
+                            type="number"
+                            size="sm"
+                            min={field === 'window' ? 1 : 0}
+                            value={rule[field]}
+                            onChange={(evt) => {
+                              updateRule(index, {
+                                [field]: Number(evt.target.value),
+                              });
+                            }}
+                          />
+                        </td>
+                      );
+                    })}
+                    <td>
+                      <Form.Check
+                        type="switch"
+                        checked={rule.per_ip}
+                        onChange={(evt) => {
+                          updateRule(index, { per_ip: evt.target.checked });
+                        }}
+                      />
+                    </td>
+                  </tr>
+                );
+              })}
+            </tbody>
+          </Table>
+          <Form.Text>{t('rules.text')}</Form.Text>
+        </Form.Group>
+        <Button variant="primary" type="submit">
+          {t('btn_submit', { keyPrefix: 'form' })}
+        </Button>
+      </Form>
+    </>
+  );
+};
+
+export default Index;
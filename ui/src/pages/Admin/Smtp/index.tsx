@@ -18,13 +18,24 @@
  */
 
 import React, { FC, useEffect, useState } from 'react';
+import { Button } from 'react-bootstrap';
 import { useTranslation } from 'react-i18next';
 
 import type * as Type from '@/common/interface';
 import { useToast } from '@/hooks';
-import { useSmtpSetting, updateSmtpSetting } from '@/services';
+import {
+  useSmtpSetting,
+  updateSmtpSetting,
+  rotateEmailReplySecret,
+} from '@/services';
 import pattern from '@/common/pattern';
-import { SchemaForm, JSONSchema, UISchema, initFormData } from '@/components';
+import {
+  SchemaForm,
+  JSONSchema,
+  UISchema,
+  initFormData,
+  Modal,
+} from '@/components';
 import { handleFormError, scrollToElementTop } from '@/utils';
 
 const Smtp: FC = () => {
@@ -77,6 +88,16 @@ const Smtp: FC = () => {
         type: 'string',
         title: t('smtp_password.label'),
       },
+      reply_to_address: {
+        type: 'string',
+        title: t('reply_to_address.label'),
+        description: t('reply_to_address.text'),
+      },
+      reply_inbound_secret: {
+        type: 'string',
+        title: t('reply_inbound_secret.label'),
+        description: t('reply_inbound_secret.text'),
+      },
       test_email_recipient: {
         type: 'string',
         title: t('test_email_recipient.label'),
@@ -135,6 +156,28 @@ const Smtp: FC = () => {
         },
       },
     },
+    reply_to_address: {
+      'ui:options': {
+        inputType: 'email',
+        validator: (value) => {
+          if (value && !pattern.email.test(value)) {
+            return t('reply_to_address.msg');
+          }
+          return true;
+        },
+      },
+    },
+    reply_inbound_secret: {
+      'ui:options': {
+        inputType: 'password',
+        validator: (value) => {
+          if (value && value.length < 16) {
+            return t('reply_inbound_secret.msg');
+          }
+          return true;
+        },
+      },
+    },
     test_email_recipient: {
       'ui:options': {
         inputType: 'email',
@@ -168,6 +211,8 @@ const Smtp: FC = () => {
       ...(formData.smtp_authentication.value
         ? { smtp_password: formData.smtp_password.value }
         : {}),
+      reply_to_address: formData.reply_to_address.value,
+      reply_inbound_secret: formData.reply_inbound_secret.value,
       test_email_recipient: formData.test_email_recipient.value,
     };
 
@@ -221,6 +266,23 @@ const Smtp: FC = () => {
   const handleOnChange = (data) => {
     setFormData(data);
   };
+
+  const handleRotateReplySecret = () => {
+    Modal.confirm({
+      title: t('rotate_reply_secret.label'),
+      content: t('rotate_reply_secret.text'),
+      cancelBtnVariant: 'link',
+      confirmBtnVariant: 'danger',
+      onConfirm: () => {
+        rotateEmailReplySecret().then(() => {
+          Toast.onShow({
+            msg: t('update', { keyPrefix: 'toast' }),
+            variant: 'success',
+          });
+        });
+      },
+    });
+  };
   return (
     <>
       <h3 className="mb-4">{t('page_title')}</h3>
@@ -231,6 +293,12 @@ const Smtp: FC = () => {
         onChange={handleOnChange}
         onSubmit={onSubmit}
       />
+      <div className="mt-4">
+        <Button variant="outline-danger" onClick={handleRotateReplySecret}>
+          {t('rotate_reply_secret.label')}
+        </Button>
+        <div className="form-text">{t('rotate_reply_secret.text')}</div>
+      </div>
     </>
   );
 };
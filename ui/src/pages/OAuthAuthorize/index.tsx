@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import { FC, memo, useEffect, useState } from 'react';
+import { Container, Row, Col, Card, Button } from 'react-bootstrap';
+import { useSearchParams } from 'react-router-dom';
+import { useTranslation } from 'react-i18next';
+
+import { WelcomeTitle } from '@/components';
+import { usePageTags } from '@/hooks';
+import { getOAuthAuthorizeInfo, oAuthConsent } from '@/services';
+import type * as Type from '@/common/interface';
+
+const paramKeys = [
+  'client_id',
+  'redirect_uri',
+  'response_type',
+  'scope',
+  'state',
+  'code_challenge',
+  'code_challenge_method',
+  'nonce',
+];
+
+const Index: FC = () => {
+  const { t } = useTranslation('translation', {
+    keyPrefix: 'oauth_authorize',
+  });
+  usePageTags({
+    title: t('page_title'),
+  });
+  const [searchParams] = useSearchParams();
+  const [info, setInfo] = useState<Type.OAuthAuthorizeInfo>();
+  const [invalid, setInvalid] = useState(false);
+  const [submitting, setSubmitting] = useState(false);
+
+  const params = paramKeys.reduce(
+    (acc, key) => {
+      const value = searchParams.get(key);
+      if (value) {
+        acc[key] = value;
+      }
+      return acc;
+    },
+    { client_id: '' } as Type.OAuthAuthorizeReq,
+  );
+
+  const handleConsent = (approve: boolean) => {
+    setSubmitting(true);
+    oAuthConsent({ ...params, approve })
+      .then((res) => {
+        window.location.replace(res.redirect_url);
+      })
+      .catch(() => {
+        setSubmitting(false);
+      });
+  };
+
+  useEffect(() => {
+    if (!params.client_id) {
+      setInvalid(true);
+      return;
+    }
+    getOAuthAuthorizeInfo(params)
+      .then((res) => {
+        if (res.redirect_url) {
+          window.location.replace(res.redirect_url);
+          return;
+        }
+        if (res.consented) {
+          handleConsent(true);
+          return;
+        }
+        setInfo(res);
+      })
+      .catch(() => {
+        setInvalid(true);
+      });
+  }, [searchParams]);
+
+  return (
+    <Container style={{ paddingTop: '4rem', paddingBottom: '6rem' }}>
+      <WelcomeTitle />
+      <Row className="justify-content-center">
+        <Col md={6} lg={5} xl={4}>
+          {invalid && <p className="text-center">{t('invalid')}</p>}
+          {info && (
+            <Card>
+              <Card.Body>
+                <h5 className="mb-3">
+                  {t('title', { name: info.client_name })}
+                </h5>
+                <p>{t('desc', { name: info.client_name })}</p>
+                <ul>
+                  {info.scopes.map((scope) => (
+                    <li key={scope}>{t(`scopes.${scope}`)}</li>
+                  ))}
+                </ul>
+                <p className="small text-secondary">{t('hint')}</p>
+                <div className="d-flex justify-content-end">
+                  <Button
+                    variant="link"
+                    className="me-2"
+                    disabled={submitting}
+                    onClick={() => handleConsent(false)}>
+                    {t('btn_deny')}
+                  </Button>
+                  <Button
+                    variant="primary"
+                    disabled={submitting}
+                    onClick={() => handleConsent(true)}>
+                    {t('btn_allow')}
+                  </Button>
+                </div>
+              </Card.Body>
+            </Card>
+          )}
+        </Col>
+      </Row>
+    </Container>
+  );
+};
+
+export default memo(Index);
@@ -42,9 +42,36 @@ const Index: FC = () => {
         <div className="mb-1">
           <Trans i18nKey="search.tips.question" components={{ 1: <code /> }} />
         </div>
-        <div>
+        <div className="mb-1">
           <Trans i18nKey="search.tips.is_answer" components={{ 1: <code /> }} />
         </div>
+        <div className="mb-1">
+          <Trans i18nKey="search.tips.exclude_tag" components={{ 1: <code /> }} />
+        </div>
+        <div className="mb-1">
+          <Trans i18nKey="search.tips.tag_or" components={{ 1: <code /> }} />
+        </div>
+        <div className="mb-1">
+          <Trans i18nKey="search.tips.created" components={{ 1: <code /> }} />
+        </div>
+        <div className="mb-1">
+          <Trans i18nKey="search.tips.is_closed" components={{ 1: <code /> }} />
+        </div>
+        <div className="mb-1">
+          <Trans i18nKey="search.tips.is_unanswered" components={{ 1: <code /> }} />
+        </div>
+        <div className="mb-1">
+          <Trans i18nKey="search.tips.has_accepted" components={{ 1: <code /> }} />
+        </div>
+        <div className="mb-1">
+          <Trans i18nKey="search.tips.commented_by" components={{ 1: <code /> }} />
+        </div>
+        <div className="mb-1">
+          <Trans i18nKey="search.tips.answered_by" components={{ 1: <code /> }} />
+        </div>
+        <div>
+          <Trans i18nKey="search.tips.in_title" components={{ 1: <code /> }} />
+        </div>
       </Card.Body>
     </Card>
   );
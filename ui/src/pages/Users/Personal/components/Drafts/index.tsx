@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import { FC, memo } from 'react';
+import { Badge, Button, ListGroup, ListGroupItem } from 'react-bootstrap';
+import { Link } from 'react-router-dom';
+import { useTranslation } from 'react-i18next';
+
+import type { DraftItem } from '@/common/interface';
+import { pathFactory } from '@/router/pathFactory';
+import { FormatTime } from '@/components';
+import { removeDraft } from '@/services';
+
+interface Props {
+  visible: boolean;
+  data: DraftItem[];
+  onRemove: () => void;
+}
+
+const draftLink = (item: DraftItem) => {
+  if (item.type === 'question') {
+    return '/questions/ask';
+  }
+  if (item.type === 'edit') {
+    return item.object_id === item.question_id
+      ? `/posts/${item.question_id}/edit`
+      : `/posts/${item.question_id}/${item.object_id}/edit`;
+  }
+  return pathFactory.questionLanding(item.question_id);
+};
+
+const Index: FC<Props> = ({ visible, data, onRemove }) => {
+  const { t } = useTranslation('translation', {
+    keyPrefix: 'personal.draft',
+  });
+  if (!visible || !data?.length) {
+    return null;
+  }
+
+  const handleDiscard = (item: DraftItem) => {
+    removeDraft({ type: item.type, object_id: item.object_id }).then(() => {
+      onRemove();
+    });
+  };
+
+  return (
+    <ListGroup className="rounded-0">
+      {data.map((item) => {
+        const title =
+          item.type === 'question' ? item.title : item.question_title;
+        return (
+          <ListGroupItem
+            className="d-flex py-3 px-0 bg-transparent border-start-0 border-end-0"
+            key={item.id}>
+            <div
+              className="me-3 text-end text-secondary flex-shrink-0"
+              style={{ width: '80px' }}>
+              {t(item.type)}
+            </div>
+            <div className="flex-grow-1">
+              {item.object_removed ? (
+                <span className="text-break">{title || t('untitled')}</span>
+              ) : (
+                <Link className="text-break" to={draftLink(item)}>
+                  {title || t('untitled')}
+                </Link>
+              )}
+              <div className="d-flex align-items-center small text-secondary">
+                <FormatTime time={item.updated_at} preFix={t('saved')} />
+                {item.conflict && (
+                  <Badge bg="warning" className="ms-2">
+                    {t('conflict')}
+                  </Badge>
+                )}
+                {item.object_removed && (
+                  <Badge bg="secondary" className="ms-2">
+                    {t('removed')}
+                  </Badge>
+                )}
+              </div>
+            </div>
+            <div className="flex-shrink-0 ms-3">
+              {!item.object_removed && (
+                <Link className="btn btn-link btn-sm" to={draftLink(item)}>
+                  {t('continue')}
+                </Link>
+              )}
+              <Button
+                variant="link"
+                size="sm"
+                className="text-danger"
+                onClick={() => handleDiscard(item)}>
+                {t('discard')}
+              </Button>
+            </div>
+          </ListGroupItem>
+        );
+      })}
+    </ListGroup>
+  );
+};
+
+export default memo(Index);
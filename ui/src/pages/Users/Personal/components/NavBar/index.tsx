@@ -63,6 +63,11 @@ const list = [
     path: '/badges',
     name: 'badges',
   },
+  {
+    role: 'self', // Only visible to author
+    path: '/drafts',
+    name: 'drafts',
+  },
 ];
 const Index: FC<Props> = ({ slug, tabName = 'overview', isSelf }) => {
   const { t } = useTranslation('translation', { keyPrefix: 'personal' });
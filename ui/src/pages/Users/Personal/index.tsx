@@ -44,6 +44,7 @@ import {
   Answers,
   Votes,
   Badges,
+  Drafts,
 } from './components';
 
 const Personal: FC = () => {
@@ -58,7 +59,11 @@ const Personal: FC = () => {
   const { data: userInfo } = usePersonalInfoByName(username);
   const { data: topData } = usePersonalTop(username, tabName);
 
-  const { data: listData, isLoading = true } = usePersonalListByTabName(
+  const {
+    data: listData,
+    isLoading = true,
+    mutate,
+  } = usePersonalListByTabName(
     {
       username,
       page: Number(page),
@@ -125,6 +130,11 @@ const Personal: FC = () => {
             visible={tabName === 'badges'}
             username={username}
           />
+          <Drafts
+            data={list}
+            visible={tabName === 'drafts' && isSelf}
+            onRemove={() => mutate()}
+          />
           {!list?.length && !isLoading && <Empty />}
 
           {count > 0 && (
@@ -53,6 +53,45 @@ const Index = () => {
         description: t('all_new_question_for_following_tags.description'),
         default: configData?.all_new_question_for_following_tags.enable,
       },
+      digest: {
+        type: 'boolean',
+        title: t('digest.label'),
+        description: t('digest.description'),
+        default: configData?.digest?.enable,
+      },
+      digest_frequency: {
+        type: 'string',
+        title: t('digest_frequency.label'),
+        enum: ['daily', 'weekly'],
+        enumNames: [t('digest_frequency.daily'), t('digest_frequency.weekly')],
+        default: configData?.digest_schedule?.frequency || 'daily',
+      },
+      digest_weekday: {
+        type: 'number',
+        title: t('digest_weekday.label'),
+        description: t('digest_weekday.description'),
+        enum: [1, 2, 3, 4, 5, 6, 0],
+        enumNames: [1, 2, 3, 4, 5, 6, 0].map((day) =>
+          t(`digest_weekday.day_${day}`),
+        ),
+        default: configData?.digest_schedule?.weekday ?? 1,
+      },
+      digest_hour: {
+        type: 'number',
+        title: t('digest_hour.label'),
+        enum: Array.from({ length: 24 }, (_, hour) => hour),
+        enumNames: Array.from(
+          { length: 24 },
+          (_, hour) => `${String(hour).padStart(2, '0')}:00`,
+        ),
+        default: configData?.digest_schedule?.hour ?? 8,
+      },
+      digest_timezone: {
+        type: 'string',
+        title: t('digest_timezone.label'),
+        description: t('digest_timezone.description'),
+        default: configData?.digest_schedule?.timezone || '',
+      },
     },
   };
   const uiSchema: UISchema = {
@@ -75,6 +114,24 @@ const Index = () => {
         text: t('all_new_question_for_following_tags.description'),
       },
     },
+    digest: {
+      'ui:widget': 'switch',
+      'ui:options': {
+        label: t('turn_on'),
+      },
+    },
+    digest_frequency: {
+      'ui:widget': 'select',
+    },
+    digest_weekday: {
+      'ui:widget': 'select',
+    },
+    digest_hour: {
+      'ui:widget': 'select',
+    },
+    digest_timezone: {
+      'ui:widget': 'timezone',
+    },
   };
   const [formData, setFormData] = useState<FormDataType>(initFormData(schema));
 
@@ -98,6 +155,16 @@ const Index = () => {
         enable: formData.all_new_question_for_following_tags.value,
         key: configData?.all_new_question_for_following_tags.key,
       },
+      digest: {
+        enable: formData.digest.value,
+        key: configData?.digest?.key || 'email',
+      },
+      digest_schedule: {
+        frequency: formData.digest_frequency.value,
+        weekday: Number(formData.digest_weekday.value),
+        hour: Number(formData.digest_hour.value),
+        timezone: formData.digest_timezone.value,
+      },
     } as NotificationConfig;
 
     putNotificationConfig(params).then(() => {
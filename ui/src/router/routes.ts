@@ -322,6 +322,13 @@ const routes: RouteNode[] = [
         path: '/users/auth-landing',
         page: 'pages/Users/AuthCallback',
       },
+      {
+        path: '/oauth/authorize',
+        page: 'pages/OAuthAuthorize',
+        guard: () => {
+          return guard.logged();
+        },
+      },
       // for admin
       {
         path: 'admin',
@@ -402,6 +409,10 @@ const routes: RouteNode[] = [
             path: 'privileges',
             page: 'pages/Admin/Privileges',
           },
+          {
+            path: 'rate-limit',
+            page: 'pages/Admin/RateLimit',
+          },
           {
             path: 'installed-plugins',
             page: 'pages/Admin/Plugins/Installed',
@@ -414,6 +425,10 @@ const routes: RouteNode[] = [
             path: 'badges',
             page: 'pages/Admin/Badges',
           },
+          {
+            path: 'queue-messages',
+            page: 'pages/Admin/QueueMessages',
+          },
         ],
       },
       {
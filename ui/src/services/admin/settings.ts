@@ -109,6 +109,11 @@ export const updateSmtpSetting = (params: Type.AdminSettingsSmtp) => {
   return request.put(apiUrl, params);
 };
 
+export const rotateEmailReplySecret = () => {
+  const apiUrl = `/answer/admin/api/setting/smtp/reply-secret`;
+  return request.put(apiUrl);
+};
+
 export const getAdminLanguageOptions = () => {
   const apiUrl = `/answer/admin/api/language/options`;
   return request.get<Type.LangsType[]>(apiUrl);
@@ -178,6 +183,16 @@ export const putLoginSetting = (params: Type.AdminSettingsLogin) => {
   return request.put('/answer/admin/api/siteinfo/login', params);
 };
 
+export const getRateLimitSetting = () => {
+  return request.get<Type.AdminSettingsRateLimit>(
+    '/answer/admin/api/siteinfo/rate-limit',
+  );
+};
+
+export const putRateLimitSetting = (params: Type.AdminSettingsRateLimit) => {
+  return request.put('/answer/admin/api/siteinfo/rate-limit', params);
+};
+
 export const getUsersSetting = () => {
   return request.get<AdminSettingsUsers>('/answer/admin/api/siteinfo/users');
 };
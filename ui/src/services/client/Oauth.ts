@@ -18,6 +18,7 @@
  */
 
 import useSWR from 'swr';
+import qs from 'qs';
 
 import request from '@/utils/request';
 import type * as Type from '@/common/interface';
@@ -42,3 +43,17 @@ export const useOauthConnectorInfoByUser = () => {
 export const userOauthUnbind = (data: { external_id: string }) => {
   return request.delete('/answer/api/v1/connector/user/unbinding', data);
 };
+
+export const getOAuthAuthorizeInfo = (params: Type.OAuthAuthorizeReq) => {
+  const apiUrl = `/answer/api/v1/oauth/authorize?${qs.stringify(params, {
+    skipNulls: true,
+  })}`;
+  return request.get<Type.OAuthAuthorizeInfo>(apiUrl);
+};
+
+export const oAuthConsent = (data: Type.OAuthConsentReq) => {
+  return request.post<{ redirect_url: string }>(
+    '/answer/api/v1/oauth/authorize',
+    data,
+  );
+};
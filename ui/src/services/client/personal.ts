@@ -93,6 +93,11 @@ export const usePersonalListByTabName = (params: ListReq, tabName: string) => {
     delete params.order;
     apiUrl = '/answer/api/v1/badge/user/awards';
   }
+  if (tabName === 'drafts') {
+    delete params.order;
+    delete params.username;
+    apiUrl = '/answer/api/v1/personal/drafts/page';
+  }
 
   const queryParams = qs.stringify(params, { skipNulls: true });
   const { data, error, mutate } = useSWR<ListRes, Error>(
@@ -109,3 +114,7 @@ export const usePersonalListByTabName = (params: ListReq, tabName: string) => {
     mutate,
   };
 };
+
+export const removeDraft = (params: { type: string; object_id: string }) => {
+  return request.delete('/answer/api/v1/draft', params);
+};